@@ -24,6 +24,7 @@ type AuthService interface {
 	SendAuthResponse(ctx context.Context, c *gin.Context, user *userModel.User, code int)
 	RefreshAccessToken(c *gin.Context) (string, error)
     FetchUserIdAccessToken(ctx context.Context) (uint, error)
+    RevokeUserTokens(userId uint)
 }
 
 // DefaultAuthService 構造体の定義
@@ -196,7 +197,8 @@ func (as *DefaultAuthService) RefreshAccessToken(c *gin.Context) (string, error)
     // 例えば、データベース内のリフレッシュトークンと照合するなどの検証を行います
 
     userId,valid := validateRefreshTokenAndGetUserID(refreshToken)
-    if !valid {
+    // 退会の手続き中のユーザーはリフレッシュトークンで再発行しない(ユーザーの取得でも論理削除済みは除かれる)
+    if !valid || isRevoked(userId, time.Now()) {
         // c.Status(http.StatusUnauthorized) // HTTPステータスコードを401に設定
         return "", errors.New("invalid refreshToken")
     }
@@ -289,10 +291,19 @@ func (as *DefaultAuthService) FetchUserIdAccessToken(ctx context.Context) (uint,
     if !ok || userId == 0 {
         return 0, utils.UnauthenticatedError("Invalid user ID")
     }
+    // 退会などでトークンを無効にしたユーザーの発行済みのアクセストークンは受け付けない
+    if isRevoked(uint(userId), time.Now()) {
+        return 0, utils.UnauthenticatedError("Token revoked")
+    }
     // すべての検証が成功した場合はユーザーIDを返す
     return uint(userId), nil
 }
 
+// RevokeUserTokens はユーザーに発行済みのアクセストークン・リフレッシュトークンを無効にします(退会時に使用)
+func (as *DefaultAuthService) RevokeUserTokens(userId uint) {
+    revokedUsers.Store(userId, time.Now())
+}
+
 func validateRefreshTokenAndGetUserID(refreshToken string) (uint, bool) {
     // refreshToken の検証ロジックを実装
     token, err := jwt.Parse(refreshToken, func(token *jwt.Token) (interface{}, error) {
@@ -40,4 +40,8 @@ func (m *MockAuthService) SignUp(ctx context.Context, c *gin.Context) (*model.Us
 
 func (m *MockAuthService) SendAuthResponse(ctx context.Context, c *gin.Context, user *model.User, code int) {
     m.Called(ctx, c, user, code)
-}
\ No newline at end of file
+}
+
+func (m *MockAuthService) RevokeUserTokens(userId uint) {
+	m.Called(userId)
+}
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// アクセストークンの有効期限(JWTLogic.CreateAccessToken と合わせる)
+const accessTokenLifetime = 15 * time.Minute
+
+// 退会などでトークンを無効にしたユーザーと無効にした日時
+// アクセストークンはDBで管理していないため、有効期限が切れるまでの間はここで拒否する
+var revokedUsers sync.Map
+
+// 退会などでトークンを無効にしたユーザーかどうかを判定します(発行済みのアクセストークンの有効期限を過ぎたものは記録から除く)
+func isRevoked(userId uint, now time.Time) bool {
+    value, ok := revokedUsers.Load(userId)
+    if !ok {
+        return false
+    }
+    if now.Sub(value.(time.Time)) > accessTokenLifetime {
+        revokedUsers.Delete(userId)
+        return false
+    }
+    return true
+}
@@ -0,0 +1,240 @@
+package dailyreport
+
+import (
+	"fmt"
+	"math"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/common/event"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 値上がり・値下がり上位として表示する銘柄数
+const moverLimit = 5
+
+// 保有銘柄ごとの合計株数
+type holding struct {
+    code     string
+    quantity float64
+}
+
+// 資産クラスの表示名
+var assetClassLabels = map[AssetClass]string{
+    AssetClassCashJpy:     "円預金",
+    AssetClassCashUsd:     "ドル預金",
+    AssetClassStock:       "米国株",
+    AssetClassFund:        "投資信託",
+    AssetClassCrypto:      "仮想通貨",
+    AssetClassFixedIncome: "固定利回り資産",
+}
+
+// スナップショットの資産クラスごとの評価額(円)を表示順に並べる
+func assetClassAmounts(asset model.TotalAsset, usdJpy float64) []float64 {
+    return []float64{
+        asset.CashJpy,
+        math.Round(asset.CashUsd * usdJpy),
+        asset.Stock,
+        asset.Fund,
+        asset.Crypto,
+        asset.FixedIncomeAsset,
+    }
+}
+
+// assetClassAmounts と同じ並び順の資産クラス
+var assetClassOrder = []AssetClass{
+    AssetClassCashJpy,
+    AssetClassCashUsd,
+    AssetClassStock,
+    AssetClassFund,
+    AssetClassCrypto,
+    AssetClassFixedIncome,
+}
+
+// 最新と前回のスナップショットから資産総額・資産クラスごとの増減と為替影響を計算する
+// 前回のスナップショットがない場合は増減なしとして扱う
+func createTotalChange(today model.TotalAsset, previous *model.TotalAsset, usdJpy float64) *Report {
+    report := &Report{
+        Date:              today.CreatedAt.UTC().Format("2006-01-02"),
+        UsdJpy:            usdJpy,
+        PreviousUsdJpy:    usdJpy,
+        AssetClassChanges: make([]*AssetClassChange, 0, len(assetClassOrder)),
+    }
+
+    amounts := assetClassAmounts(today, usdJpy)
+    previousAmounts := amounts
+    if previous != nil {
+        previousDate := previous.CreatedAt.UTC().Format("2006-01-02")
+        report.PreviousDate = &previousDate
+        report.PreviousUsdJpy = previous.UsdJpy
+        // 前回のドル円が不明な場合は最新のドル円で換算する
+        previousUsdJpy := previous.UsdJpy
+        if previousUsdJpy == 0 {
+            previousUsdJpy = usdJpy
+        }
+        previousAmounts = assetClassAmounts(*previous, previousUsdJpy)
+        if previous.UsdJpy != 0 {
+            fxImpact := calculateFxImpact(today, usdJpy, previous.UsdJpy)
+            report.FxImpact = &fxImpact
+        }
+    }
+
+    previousTotal := 0.0
+    for i, assetClass := range assetClassOrder {
+        report.AssetClassChanges = append(report.AssetClassChanges, &AssetClassChange{
+            AssetClass:     assetClass,
+            Amount:         amounts[i],
+            PreviousAmount: previousAmounts[i],
+            Change:         amounts[i] - previousAmounts[i],
+        })
+        report.TotalAmount += amounts[i]
+        previousTotal += previousAmounts[i]
+    }
+    report.TotalChange = report.TotalAmount - previousTotal
+    if previousTotal != 0 {
+        report.TotalChangeRate = roundTo2(report.TotalChange / previousTotal * 100)
+    }
+    return report
+}
+
+// 米ドル建て資産(米国株とドル預金)の評価額のうち、ドル円の変動による増減(円)を計算する
+func calculateFxImpact(today model.TotalAsset, usdJpy float64, previousUsdJpy float64) float64 {
+    if usdJpy == 0 {
+        return 0
+    }
+    usdAmount := today.Stock/usdJpy + today.CashUsd
+    return math.Round(usdAmount * (usdJpy - previousUsdJpy))
+}
+
+// 同じ銘柄を複数回に分けて購入している場合があるため、銘柄ごとに株数を合計する(最初に登録した順)
+func sumQuantityByCode(modelStocks []model.UsStock) []holding {
+    holdings := []holding{}
+    indexes := make(map[string]int)
+    for _, modelStock := range modelStocks {
+        if i, ok := indexes[modelStock.Code]; ok {
+            holdings[i].quantity += modelStock.Quantity
+            continue
+        }
+        indexes[modelStock.Code] = len(holdings)
+        holdings = append(holdings, holding{code: modelStock.Code, quantity: modelStock.Quantity})
+    }
+    return holdings
+}
+
+func holdingCodes(holdings []holding) []string {
+    codes := make([]string, len(holdings))
+    for i, h := range holdings {
+        codes[i] = h.code
+    }
+    return codes
+}
+
+// 保有銘柄の前日比から値上がり・値下がりそれぞれの上位銘柄を作成する
+func createHoldingMovers(holdings []holding, quotes []marketPrice.MarketPriceDto, usdJpy float64) ([]*HoldingMover, []*HoldingMover) {
+    quoteMap := make(map[string]marketPrice.MarketPriceDto, len(quotes))
+    for _, quote := range quotes {
+        quoteMap[quote.Ticker] = quote
+    }
+
+    gainers := []*HoldingMover{}
+    losers := []*HoldingMover{}
+    for _, h := range holdings {
+        quote, ok := quoteMap[h.code]
+        if !ok {
+            continue
+        }
+        mover := &HoldingMover{
+            Code:         h.code,
+            Quantity:     h.quantity,
+            CurrentPrice: quote.CurrentPrice,
+            ChangeRate:   roundTo2(quote.CurrentRate),
+            Change:       math.Round(quote.PriceGets * h.quantity * usdJpy),
+        }
+        if mover.ChangeRate > 0 {
+            gainers = append(gainers, mover)
+        } else if mover.ChangeRate < 0 {
+            losers = append(losers, mover)
+        }
+    }
+
+    sort.SliceStable(gainers, func(i, j int) bool { return gainers[i].ChangeRate > gainers[j].ChangeRate })
+    sort.SliceStable(losers, func(i, j int) bool { return losers[i].ChangeRate < losers[j].ChangeRate })
+    if len(gainers) > moverLimit {
+        gainers = gainers[:moverLimit]
+    }
+    if len(losers) > moverLimit {
+        losers = losers[:moverLimit]
+    }
+    return gainers, losers
+}
+
+// 配当支払日のイベントと保有株数から受取予定の配当(円、税引前)を作成する
+func createExpectedDividends(holdings []holding, events []event.Event, usdJpy float64) ([]*ExpectedDividend, float64) {
+    quantities := make(map[string]float64, len(holdings))
+    for _, h := range holdings {
+        quantities[h.code] = h.quantity
+    }
+
+    dividends := []*ExpectedDividend{}
+    total := 0.0
+    for _, upcoming := range events {
+        if upcoming.Type != event.TypeDividendPayment || upcoming.Amount == nil {
+            continue
+        }
+        quantity := quantities[upcoming.Ticker]
+        if quantity <= 0 {
+            continue
+        }
+        amount := math.Round(*upcoming.Amount * quantity * usdJpy)
+        dividends = append(dividends, &ExpectedDividend{
+            Ticker:         upcoming.Ticker,
+            PaymentDate:    upcoming.Date,
+            AmountPerShare: *upcoming.Amount,
+            Quantity:       quantity,
+            Amount:         amount,
+        })
+        total += amount
+    }
+    return dividends, total
+}
+
+// サマリーの件名を作成する
+func createDailyReportSubject(report *Report) string {
+    return fmt.Sprintf("[日次サマリー] %s 資産総額 %s (%s)", report.Date, formatYen(report.TotalAmount), formatSignedPercent(report.TotalChangeRate))
+}
+
+func roundTo2(value float64) float64 {
+    return math.Round(value*100) / 100
+}
+
+// 円の金額を3桁区切りで表示する(例: ¥1,234,567, -¥500)
+func formatYen(value float64) string {
+    sign := ""
+    if value < 0 {
+        sign = "-"
+        value = -value
+    }
+    digits := strconv.FormatFloat(math.Round(value), 'f', 0, 64)
+    var builder strings.Builder
+    for i, digit := range digits {
+        if i != 0 && (len(digits)-i)%3 == 0 {
+            builder.WriteByte(',')
+        }
+        builder.WriteRune(digit)
+    }
+    return sign + "¥" + builder.String()
+}
+
+// 増減額を符号付きで表示する(例: +¥1,000)
+func formatSignedYen(value float64) string {
+    if value > 0 {
+        return "+" + formatYen(value)
+    }
+    return formatYen(value)
+}
+
+// 増減率を符号付きで表示する(例: +1.25%)
+func formatSignedPercent(value float64) string {
+    return fmt.Sprintf("%+.2f%%", value)
+}
@@ -0,0 +1,92 @@
+package dailyreport
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+var templateFuncs = template.FuncMap{
+    "yen":           formatYen,
+    "signedYen":     formatSignedYen,
+    "signedPercent": formatSignedPercent,
+    "assetClass":    func(assetClass AssetClass) string { return assetClassLabels[assetClass] },
+}
+
+// HTMLメールのテンプレート
+var dailyReportTemplate = template.Must(template.New("dailyReport").Funcs(templateFuncs).Parse(`<!DOCTYPE html>
+<html lang="ja">
+<head><meta charset="UTF-8"><title>{{.Date}} の資産サマリー</title></head>
+<body style="font-family: sans-serif; color: #333;">
+<h2>{{.Date}} の資産サマリー</h2>
+<p>資産総額: <strong>{{yen .TotalAmount}}</strong> (前回比 {{signedYen .TotalChange}} / {{signedPercent .TotalChangeRate}})</p>
+{{if .FxImpact}}<p>為替影響: {{signedYen .FxImpact}} (ドル円 {{printf "%.2f" .PreviousUsdJpy}} → {{printf "%.2f" .UsdJpy}})</p>{{end}}
+<h3>資産クラス別</h3>
+<table border="1" cellpadding="4" cellspacing="0">
+<tr><th>資産クラス</th><th>評価額</th><th>前回比</th></tr>
+{{range .AssetClassChanges}}<tr><td>{{assetClass .AssetClass}}</td><td align="right">{{yen .Amount}}</td><td align="right">{{signedYen .Change}}</td></tr>
+{{end}}</table>
+<h3>値上がり上位</h3>
+{{if .TopGainers}}<ul>
+{{range .TopGainers}}<li>{{.Code}} {{signedPercent .ChangeRate}} ({{signedYen .Change}})</li>
+{{end}}</ul>{{else}}<p>なし</p>{{end}}
+<h3>値下がり上位</h3>
+{{if .TopLosers}}<ul>
+{{range .TopLosers}}<li>{{.Code}} {{signedPercent .ChangeRate}} ({{signedYen .Change}})</li>
+{{end}}</ul>{{else}}<p>なし</p>{{end}}
+<h3>30日以内の配当予定 (合計 {{yen .ExpectedDividendTotal}})</h3>
+{{if .UpcomingDividends}}<ul>
+{{range .UpcomingDividends}}<li>{{.PaymentDate}} {{.Ticker}} ${{.AmountPerShare}} × {{.Quantity}}株 = {{yen .Amount}}</li>
+{{end}}</ul>{{else}}<p>なし</p>{{end}}
+</body>
+</html>
+`))
+
+// renderDailyReportHTML はサマリーをHTMLメールの本文に変換します
+func renderDailyReportHTML(report *Report) (string, error) {
+    var buffer bytes.Buffer
+    if err := dailyReportTemplate.Execute(&buffer, report); err != nil {
+        return "", err
+    }
+    return buffer.String(), nil
+}
+
+// renderDailyReportText はサマリーをテキストの本文(Slack・Discord等)に変換します
+func renderDailyReportText(report *Report) string {
+    var builder strings.Builder
+    fmt.Fprintf(&builder, "%s の資産サマリー\n", report.Date)
+    fmt.Fprintf(&builder, "資産総額: %s (前回比 %s / %s)\n", formatYen(report.TotalAmount), formatSignedYen(report.TotalChange), formatSignedPercent(report.TotalChangeRate))
+    if report.FxImpact != nil {
+        fmt.Fprintf(&builder, "為替影響: %s (ドル円 %.2f → %.2f)\n", formatSignedYen(*report.FxImpact), report.PreviousUsdJpy, report.UsdJpy)
+    }
+
+    builder.WriteString("\n資産クラス別\n")
+    for _, change := range report.AssetClassChanges {
+        fmt.Fprintf(&builder, "  %s: %s (%s)\n", assetClassLabels[change.AssetClass], formatYen(change.Amount), formatSignedYen(change.Change))
+    }
+
+    builder.WriteString("\n値上がり上位\n")
+    writeHoldingMovers(&builder, report.TopGainers)
+    builder.WriteString("\n値下がり上位\n")
+    writeHoldingMovers(&builder, report.TopLosers)
+
+    fmt.Fprintf(&builder, "\n30日以内の配当予定 (合計 %s)\n", formatYen(report.ExpectedDividendTotal))
+    if len(report.UpcomingDividends) == 0 {
+        builder.WriteString("  なし\n")
+    }
+    for _, dividend := range report.UpcomingDividends {
+        fmt.Fprintf(&builder, "  %s %s $%g × %g株 = %s\n", dividend.PaymentDate, dividend.Ticker, dividend.AmountPerShare, dividend.Quantity, formatYen(dividend.Amount))
+    }
+    return builder.String()
+}
+
+func writeHoldingMovers(builder *strings.Builder, movers []*HoldingMover) {
+    if len(movers) == 0 {
+        builder.WriteString("  なし\n")
+        return
+    }
+    for _, mover := range movers {
+        fmt.Fprintf(builder, "  %s %s (%s)\n", mover.Code, formatSignedPercent(mover.ChangeRate), formatSignedYen(mover.Change))
+    }
+}
@@ -0,0 +1,178 @@
+package dailyreport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"my-us-stock-backend/app/common/event"
+	"my-us-stock-backend/app/common/notification"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/repository/assets/stock"
+	dailyReport "my-us-stock-backend/app/repository/daily-report"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	"my-us-stock-backend/app/repository/market-price/currency"
+	totalAssets "my-us-stock-backend/app/repository/total-assets"
+)
+
+// 配当予定を集計する日数
+const upcomingDividendDays = 30
+
+var errNoTotalAsset = errors.New("資産のスナップショットが登録されていません")
+
+// AssetClass は資産クラスを表します(GraphQL の AssetClass と同じ値)
+type AssetClass string
+
+const (
+    AssetClassCashJpy     AssetClass = "CASH_JPY"
+    AssetClassCashUsd     AssetClass = "CASH_USD"
+    AssetClassStock       AssetClass = "STOCK"
+    AssetClassFund        AssetClass = "FUND"
+    AssetClassCrypto      AssetClass = "CRYPTO"
+    AssetClassFixedIncome AssetClass = "FIXED_INCOME"
+)
+
+// Report は日次サマリーの内容です(JSONで保存し、GraphQL の DailyReport として返す)
+type Report struct {
+    Date                  string              `json:"date"`
+    PreviousDate          *string             `json:"previousDate,omitempty"`
+    TotalAmount           float64             `json:"totalAmount"`
+    TotalChange           float64             `json:"totalChange"`
+    TotalChangeRate       float64             `json:"totalChangeRate"`
+    AssetClassChanges     []*AssetClassChange `json:"assetClassChanges"`
+    UsdJpy                float64             `json:"usdJpy"`
+    PreviousUsdJpy        float64             `json:"previousUsdJpy"`
+    FxImpact              *float64            `json:"fxImpact,omitempty"`
+    TopGainers            []*HoldingMover     `json:"topGainers"`
+    TopLosers             []*HoldingMover     `json:"topLosers"`
+    UpcomingDividends     []*ExpectedDividend `json:"upcomingDividends"`
+    ExpectedDividendTotal float64             `json:"expectedDividendTotal"`
+}
+
+// AssetClassChange は資産クラスごとの評価額(円)の増減です
+type AssetClassChange struct {
+    AssetClass     AssetClass `json:"assetClass"`
+    Amount         float64    `json:"amount"`
+    PreviousAmount float64    `json:"previousAmount"`
+    Change         float64    `json:"change"`
+}
+
+// HoldingMover は保有銘柄の前日比です
+type HoldingMover struct {
+    Code         string  `json:"code"`
+    Quantity     float64 `json:"quantity"`
+    CurrentPrice float64 `json:"currentPrice"`
+    ChangeRate   float64 `json:"changeRate"`
+    Change       float64 `json:"change"`
+}
+
+// ExpectedDividend は受取予定の配当(円、税引前)です
+type ExpectedDividend struct {
+    Ticker         string  `json:"ticker"`
+    PaymentDate    string  `json:"paymentDate"`
+    AmountPerShare float64 `json:"amountPerShare"`
+    Quantity       float64 `json:"quantity"`
+    Amount         float64 `json:"amount"`
+}
+
+// ReportSender インターフェースの定義
+type ReportSender interface {
+    SendDailyReport(ctx context.Context, userId uint) error
+}
+
+// DefaultReportSender 構造体の定義
+type DefaultReportSender struct {
+    DailyReportRepo dailyReport.DailyReportRepository
+    TotalAssetRepo totalAssets.TotalAssetRepository
+    StockRepo stock.UsStockRepository
+    MarketPriceRepo marketPrice.MarketPriceRepository
+    CurrencyRepo currency.CurrencyRepository
+    EventCollector event.EventCollector
+    NotificationService notification.NotificationService
+}
+
+// NewReportSender は DefaultReportSender の新しいインスタンスを作成します
+func NewReportSender(dailyReportRepo dailyReport.DailyReportRepository, totalAssetRepo totalAssets.TotalAssetRepository, stockRepo stock.UsStockRepository, marketPriceRepo marketPrice.MarketPriceRepository, currencyRepo currency.CurrencyRepository, eventCollector event.EventCollector, notificationService notification.NotificationService) ReportSender {
+    return &DefaultReportSender{
+        DailyReportRepo: dailyReportRepo,
+        TotalAssetRepo: totalAssetRepo,
+        StockRepo: stockRepo,
+        MarketPriceRepo: marketPriceRepo,
+        CurrencyRepo: currencyRepo,
+        EventCollector: eventCollector,
+        NotificationService: notificationService,
+    }
+}
+
+// SendDailyReport は最新の資産スナップショットからサマリーを作成・保存し、通知先へ送信します
+func (s *DefaultReportSender) SendDailyReport(ctx context.Context, userId uint) error {
+    report, err := s.createDailyReport(ctx, userId)
+    if err != nil {
+        return err
+    }
+    content, err := json.Marshal(report)
+    if err != nil {
+        return err
+    }
+    dto := dailyReport.CreateDailyReportDto{UserId: userId, Date: report.Date, Content: string(content)}
+    if _, err := s.DailyReportRepo.UpsertDailyReport(ctx, dto); err != nil {
+        return err
+    }
+
+    htmlBody, err := renderDailyReportHTML(report)
+    if err != nil {
+        return err
+    }
+    return s.NotificationService.NotifyHTML(ctx, userId, notification.EventDailySummary, createDailyReportSubject(report), renderDailyReportText(report), htmlBody)
+}
+
+// 最新と前回の資産スナップショット、保有銘柄の値動き、配当予定からサマリーを作成する
+func (s *DefaultReportSender) createDailyReport(ctx context.Context, userId uint) (*Report, error) {
+    modelAssets, err := s.TotalAssetRepo.FetchTotalAssetListById(ctx, userId, 2)
+    if err != nil {
+        return nil, err
+    }
+    if len(modelAssets) == 0 {
+        return nil, errNoTotalAsset
+    }
+    today := modelAssets[0]
+    var previous *model.TotalAsset
+    if len(modelAssets) > 1 {
+        previous = &modelAssets[1]
+    }
+
+    // スナップショットにドル円が記録されていない場合は現在のドル円で代用する
+    usdJpy := today.UsdJpy
+    if usdJpy == 0 {
+        usdJpy, err = s.CurrencyRepo.FetchCurrentUsdJpy(ctx)
+        if err != nil {
+            return nil, err
+        }
+    }
+
+    modelStocks, err := s.StockRepo.FetchUsStockListById(ctx, userId)
+    if err != nil {
+        return nil, err
+    }
+    holdings := sumQuantityByCode(modelStocks)
+
+    var quotes []marketPrice.MarketPriceDto
+    if len(holdings) != 0 {
+        quotes, err = s.MarketPriceRepo.FetchMarketPriceList(ctx, holdingCodes(holdings))
+        if err != nil {
+            return nil, err
+        }
+    }
+
+    // 配当予定が取得できなくてもサマリーは作成する
+    events, err := s.EventCollector.FetchUpcomingEvents(ctx, userId, upcomingDividendDays)
+    if err != nil {
+        log.Printf("配当予定の取得に失敗しました(userId: %d): %v", userId, err)
+        events = nil
+    }
+
+    report := createTotalChange(today, previous, usdJpy)
+    report.TopGainers, report.TopLosers = createHoldingMovers(holdings, quotes, usdJpy)
+    report.UpcomingDividends, report.ExpectedDividendTotal = createExpectedDividends(holdings, events, usdJpy)
+    return report, nil
+}
@@ -0,0 +1,274 @@
+package dailyreport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"my-us-stock-backend/app/common/event"
+	"my-us-stock-backend/app/common/notification"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/repository/assets/stock"
+	dailyReport "my-us-stock-backend/app/repository/daily-report"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	"my-us-stock-backend/app/repository/market-price/currency"
+	totalAssets "my-us-stock-backend/app/repository/total-assets"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockTotalAssetRepository は TotalAssetRepository のモックです。
+type MockTotalAssetRepository struct {
+    mock.Mock
+}
+
+func (m *MockTotalAssetRepository) FetchTotalAssetListById(ctx context.Context, userId uint, day int) ([]model.TotalAsset, error) {
+    args := m.Called(ctx, userId, day)
+    return args.Get(0).([]model.TotalAsset), args.Error(1)
+}
+
+func (m *MockTotalAssetRepository) FindTodayTotalAsset(ctx context.Context, userId uint) (*model.TotalAsset, error) {
+    args := m.Called(ctx, userId)
+    return args.Get(0).(*model.TotalAsset), args.Error(1)
+}
+
+func (m *MockTotalAssetRepository) UpdateTotalAsset(ctx context.Context, dto totalAssets.UpdateTotalAssetDto) (*model.TotalAsset, error) {
+    args := m.Called(ctx, dto)
+    return args.Get(0).(*model.TotalAsset), args.Error(1)
+}
+
+func (m *MockTotalAssetRepository) CreateTodayTotalAsset(ctx context.Context, dto totalAssets.CreateTotalAssetDto) (*model.TotalAsset, error) {
+    args := m.Called(ctx, dto)
+    return args.Get(0).(*model.TotalAsset), args.Error(1)
+}
+
+type testMocks struct {
+    dailyReportRepo     *dailyReport.MockDailyReportRepository
+    totalAssetRepo      *MockTotalAssetRepository
+    stockRepo           *stock.MockUsStockRepository
+    marketPriceRepo     *marketPrice.MockMarketPriceRepository
+    currencyRepo        *currency.MockCurrencyRepository
+    eventCollector      *event.MockEventCollector
+    notificationService *notification.MockNotificationService
+}
+
+func newTestSender() (ReportSender, testMocks) {
+    mocks := testMocks{
+        dailyReportRepo:     dailyReport.NewMockDailyReportRepository(),
+        totalAssetRepo:      new(MockTotalAssetRepository),
+        stockRepo:           stock.NewMockUsStockRepository(),
+        marketPriceRepo:     marketPrice.NewMockMarketPriceRepository(),
+        currencyRepo:        currency.NewMockCurrencyRepository(),
+        eventCollector:      event.NewMockEventCollector(),
+        notificationService: notification.NewMockNotificationService(),
+    }
+    sender := NewReportSender(mocks.dailyReportRepo, mocks.totalAssetRepo, mocks.stockRepo, mocks.marketPriceRepo, mocks.currencyRepo, mocks.eventCollector, mocks.notificationService)
+    return sender, mocks
+}
+
+func TestCreateTotalChange(t *testing.T) {
+    today := model.TotalAsset{Model: gorm.Model{CreatedAt: time.Date(2024, 1, 11, 1, 0, 0, 0, time.UTC)}, CashJpy: 100000, CashUsd: 1000, Stock: 1500000, Fund: 300000, Crypto: 50000, FixedIncomeAsset: 200000, UsdJpy: 150}
+    previous := model.TotalAsset{Model: gorm.Model{CreatedAt: time.Date(2024, 1, 10, 1, 0, 0, 0, time.UTC)}, CashJpy: 100000, CashUsd: 1000, Stock: 1450000, Fund: 290000, Crypto: 60000, FixedIncomeAsset: 200000, UsdJpy: 148}
+
+    report := createTotalChange(today, &previous, 150)
+
+    assert.Equal(t, "2024-01-11", report.Date)
+    assert.Equal(t, "2024-01-10", *report.PreviousDate)
+    assert.Len(t, report.AssetClassChanges, 6)
+    assert.Equal(t, AssetClassCashUsd, report.AssetClassChanges[1].AssetClass)
+    assert.Equal(t, 150000.0, report.AssetClassChanges[1].Amount)
+    assert.Equal(t, 148000.0, report.AssetClassChanges[1].PreviousAmount)
+    assert.Equal(t, 50000.0, report.AssetClassChanges[2].Change)
+    assert.Equal(t, -10000.0, report.AssetClassChanges[4].Change)
+    assert.Equal(t, 2300000.0, report.TotalAmount)
+    assert.Equal(t, 52000.0, report.TotalChange)
+    assert.Equal(t, 2.31, report.TotalChangeRate)
+    // (1500000 / 150 + 1000) × (150 - 148)
+    assert.Equal(t, 22000.0, *report.FxImpact)
+}
+
+// 前回のスナップショットがない場合、前回のドル円が不明な場合は増減・為替影響なしとする
+func TestCreateTotalChangeWithoutPrevious(t *testing.T) {
+    today := model.TotalAsset{CashJpy: 100000, Stock: 1500000, UsdJpy: 150}
+
+    report := createTotalChange(today, nil, 150)
+    assert.Nil(t, report.PreviousDate)
+    assert.Nil(t, report.FxImpact)
+    assert.Equal(t, 0.0, report.TotalChange)
+    assert.Equal(t, 0.0, report.TotalChangeRate)
+
+    report = createTotalChange(today, &model.TotalAsset{CashJpy: 100000, Stock: 1400000}, 150)
+    assert.Nil(t, report.FxImpact)
+    assert.Equal(t, 100000.0, report.TotalChange)
+}
+
+func TestCreateHoldingMovers(t *testing.T) {
+    holdings := sumQuantityByCode([]model.UsStock{
+        {Code: "AAPL", Quantity: 10}, {Code: "KO", Quantity: 20}, {Code: "AAPL", Quantity: 5},
+        {Code: "MSFT", Quantity: 3}, {Code: "PG", Quantity: 4},
+    })
+    quotes := []marketPrice.MarketPriceDto{
+        {Ticker: "AAPL", CurrentPrice: 180, PriceGets: 3.6, CurrentRate: 2.04},
+        {Ticker: "KO", CurrentPrice: 58, PriceGets: -0.58, CurrentRate: -0.99},
+        {Ticker: "MSFT", CurrentPrice: 400, PriceGets: 12, CurrentRate: 3.09},
+        {Ticker: "PG", CurrentPrice: 150, PriceGets: 0, CurrentRate: 0},
+    }
+
+    gainers, losers := createHoldingMovers(holdings, quotes, 150)
+
+    assert.Len(t, gainers, 2)
+    assert.Equal(t, "MSFT", gainers[0].Code)
+    assert.Equal(t, "AAPL", gainers[1].Code)
+    assert.Equal(t, 15.0, gainers[1].Quantity)
+    assert.Equal(t, 8100.0, gainers[1].Change)
+    assert.Len(t, losers, 1)
+    assert.Equal(t, "KO", losers[0].Code)
+    assert.Equal(t, -1740.0, losers[0].Change)
+}
+
+// 値上がり・値下がりはそれぞれ上位5銘柄まで
+func TestCreateHoldingMoversLimit(t *testing.T) {
+    holdings := []holding{}
+    quotes := []marketPrice.MarketPriceDto{}
+    for i, code := range []string{"A", "B", "C", "D", "E", "F", "G"} {
+        holdings = append(holdings, holding{code: code, quantity: 1})
+        quotes = append(quotes, marketPrice.MarketPriceDto{Ticker: code, CurrentRate: float64(i + 1)})
+    }
+
+    gainers, losers := createHoldingMovers(holdings, quotes, 150)
+
+    assert.Len(t, gainers, 5)
+    assert.Equal(t, "G", gainers[0].Code)
+    assert.Empty(t, losers)
+}
+
+func TestCreateExpectedDividends(t *testing.T) {
+    amount := 0.46
+    exAmount := 0.24
+    holdings := []holding{{code: "KO", quantity: 20}, {code: "AAPL", quantity: 10}}
+    events := []event.Event{
+        {Ticker: "AAPL", Type: event.TypeExDividend, Date: "2024-02-09", Amount: &exAmount},
+        {Ticker: "AAPL", Type: event.TypeEarnings, Date: "2024-02-01"},
+        {Ticker: "KO", Type: event.TypeDividendPayment, Date: "2024-02-15", Amount: &amount},
+    }
+
+    dividends, total := createExpectedDividends(holdings, events, 150)
+
+    assert.Len(t, dividends, 1)
+    assert.Equal(t, "KO", dividends[0].Ticker)
+    assert.Equal(t, "2024-02-15", dividends[0].PaymentDate)
+    assert.Equal(t, 1380.0, dividends[0].Amount)
+    assert.Equal(t, 1380.0, total)
+}
+
+func TestFormatYen(t *testing.T) {
+    assert.Equal(t, "¥0", formatYen(0))
+    assert.Equal(t, "¥999", formatYen(999))
+    assert.Equal(t, "¥1,234,567", formatYen(1234567))
+    assert.Equal(t, "-¥12,000", formatYen(-12000))
+    assert.Equal(t, "+¥1,000", formatSignedYen(1000))
+    assert.Equal(t, "+1.25%", formatSignedPercent(1.25))
+    assert.Equal(t, "-0.50%", formatSignedPercent(-0.5))
+}
+
+func TestRenderDailyReport(t *testing.T) {
+    fxImpact := 22000.0
+    report := &Report{
+        Date: "2024-01-11", TotalAmount: 2300000, TotalChange: 52000, TotalChangeRate: 2.31,
+        UsdJpy: 150, PreviousUsdJpy: 148, FxImpact: &fxImpact,
+        AssetClassChanges: []*AssetClassChange{{AssetClass: AssetClassStock, Amount: 1500000, PreviousAmount: 1450000, Change: 50000}},
+        TopGainers: []*HoldingMover{{Code: "MSFT", Quantity: 3, CurrentPrice: 400, ChangeRate: 3.09, Change: 5400}},
+        TopLosers: []*HoldingMover{},
+        UpcomingDividends: []*ExpectedDividend{{Ticker: "KO", PaymentDate: "2024-02-15", AmountPerShare: 0.46, Quantity: 20, Amount: 1380}},
+        ExpectedDividendTotal: 1380,
+    }
+
+    text := renderDailyReportText(report)
+    assert.Contains(t, text, "資産総額: ¥2,300,000 (前回比 +¥52,000 / +2.31%)")
+    assert.Contains(t, text, "為替影響: +¥22,000 (ドル円 148.00 → 150.00)")
+    assert.Contains(t, text, "米国株: ¥1,500,000 (+¥50,000)")
+    assert.Contains(t, text, "MSFT +3.09% (+¥5,400)")
+    assert.Contains(t, text, "2024-02-15 KO $0.46 × 20株 = ¥1,380")
+
+    html, err := renderDailyReportHTML(report)
+    assert.NoError(t, err)
+    assert.Contains(t, html, "<strong>¥2,300,000</strong>")
+    // html/template は + を文字参照にエスケープする
+    assert.Contains(t, html, "為替影響: &#43;¥22,000")
+    assert.Contains(t, html, "<td>米国株</td>")
+    assert.Contains(t, html, "<li>MSFT &#43;3.09% (&#43;¥5,400)</li>")
+    assert.True(t, strings.Contains(html, "値下がり上位</h3>\n<p>なし</p>"))
+
+    assert.Equal(t, "[日次サマリー] 2024-01-11 資産総額 ¥2,300,000 (+2.31%)", createDailyReportSubject(report))
+}
+
+func TestSendDailyReport(t *testing.T) {
+    sender, mocks := newTestSender()
+    userId := uint(1)
+    amount := 0.46
+
+    mocks.totalAssetRepo.On("FetchTotalAssetListById", mock.Anything, userId, 2).Return([]model.TotalAsset{
+        {Model: gorm.Model{CreatedAt: time.Date(2024, 1, 11, 1, 0, 0, 0, time.UTC)}, CashJpy: 100000, Stock: 270000, UsdJpy: 150},
+        {Model: gorm.Model{CreatedAt: time.Date(2024, 1, 10, 1, 0, 0, 0, time.UTC)}, CashJpy: 100000, Stock: 260000, UsdJpy: 148},
+    }, nil)
+    mocks.stockRepo.On("FetchUsStockListById", mock.Anything, userId).Return([]model.UsStock{{Code: "KO", Quantity: 20}, {Code: "MSFT", Quantity: 3}}, nil)
+    mocks.marketPriceRepo.On("FetchMarketPriceList", mock.Anything, []string{"KO", "MSFT"}).Return([]marketPrice.MarketPriceDto{
+        {Ticker: "KO", CurrentPrice: 58, PriceGets: -0.58, CurrentRate: -0.99},
+        {Ticker: "MSFT", CurrentPrice: 400, PriceGets: 12, CurrentRate: 3.09},
+    }, nil)
+    mocks.eventCollector.On("FetchUpcomingEvents", mock.Anything, userId, 30).Return([]event.Event{
+        {Ticker: "KO", Type: event.TypeDividendPayment, Date: "2024-02-15", Amount: &amount},
+    }, nil)
+    var savedDto dailyReport.CreateDailyReportDto
+    mocks.dailyReportRepo.On("UpsertDailyReport", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+        savedDto = args.Get(1).(dailyReport.CreateDailyReportDto)
+    }).Return(&model.DailyReport{}, nil)
+    mocks.notificationService.On("NotifyHTML", mock.Anything, userId, notification.EventDailySummary, "[日次サマリー] 2024-01-11 資産総額 ¥370,000 (+2.78%)", mock.Anything, mock.Anything).Return(nil)
+
+    err := sender.SendDailyReport(context.Background(), userId)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "2024-01-11", savedDto.Date)
+    var saved Report
+    assert.NoError(t, json.Unmarshal([]byte(savedDto.Content), &saved))
+    assert.Equal(t, 10000.0, saved.TotalChange)
+    assert.Equal(t, "MSFT", saved.TopGainers[0].Code)
+    assert.Equal(t, "KO", saved.TopLosers[0].Code)
+    assert.Equal(t, 1380.0, saved.ExpectedDividendTotal)
+    mocks.currencyRepo.AssertNotCalled(t, "FetchCurrentUsdJpy", mock.Anything)
+    mocks.notificationService.AssertExpectations(t)
+}
+
+// 配当予定の取得に失敗してもサマリーは送信する
+func TestSendDailyReportWithoutEvents(t *testing.T) {
+    sender, mocks := newTestSender()
+    userId := uint(1)
+
+    mocks.totalAssetRepo.On("FetchTotalAssetListById", mock.Anything, userId, 2).Return([]model.TotalAsset{{CashJpy: 100000}}, nil)
+    mocks.currencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(150.0, nil)
+    mocks.stockRepo.On("FetchUsStockListById", mock.Anything, userId).Return([]model.UsStock{}, nil)
+    mocks.eventCollector.On("FetchUpcomingEvents", mock.Anything, userId, 30).Return(nil, errors.New("api error"))
+    mocks.dailyReportRepo.On("UpsertDailyReport", mock.Anything, mock.Anything).Return(&model.DailyReport{}, nil)
+    mocks.notificationService.On("NotifyHTML", mock.Anything, userId, notification.EventDailySummary, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+    err := sender.SendDailyReport(context.Background(), userId)
+
+    assert.NoError(t, err)
+    mocks.marketPriceRepo.AssertNotCalled(t, "FetchMarketPriceList", mock.Anything, mock.Anything)
+    mocks.notificationService.AssertExpectations(t)
+}
+
+func TestSendDailyReportWithoutTotalAsset(t *testing.T) {
+    sender, mocks := newTestSender()
+
+    mocks.totalAssetRepo.On("FetchTotalAssetListById", mock.Anything, uint(1), 2).Return([]model.TotalAsset{}, nil)
+
+    err := sender.SendDailyReport(context.Background(), 1)
+
+    assert.Error(t, err)
+    mocks.notificationService.AssertNotCalled(t, "NotifyHTML", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,22 @@
+package dailyreport
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReportSender は ReportSender のモックです。
+type MockReportSender struct {
+	mock.Mock
+}
+
+// NewMockReportSender は新しい MockReportSender を作成し、初期設定を行います。
+func NewMockReportSender() *MockReportSender {
+	return &MockReportSender{}
+}
+
+func (m *MockReportSender) SendDailyReport(ctx context.Context, userId uint) error {
+	args := m.Called(ctx, userId)
+	return args.Error(0)
+}
@@ -0,0 +1,121 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"my-us-stock-backend/app/database/model"
+	"sort"
+	"time"
+)
+
+// 保有銘柄のティッカーを重複なく取得する
+func uniqueTickers(modelStocks []model.UsStock) []string {
+    tickers := make([]string, 0, len(modelStocks))
+    seen := make(map[string]struct{}, len(modelStocks))
+    for _, modelStock := range modelStocks {
+        if _, ok := seen[modelStock.Code]; ok {
+            continue
+        }
+        seen[modelStock.Code] = struct{}{}
+        tickers = append(tickers, modelStock.Code)
+    }
+    return tickers
+}
+
+// 市場価格の次回決算発表日時から決算発表イベントを作成する
+func fetchEarningsEvents(ctx context.Context, c *DefaultEventCollector, tickers []string, from time.Time, to time.Time) ([]Event, error) {
+    marketPrices, err := c.MarketPriceRepo.FetchMarketPriceList(ctx, tickers)
+    if err != nil {
+        return nil, err
+    }
+    events := []Event{}
+    for _, marketPrice := range marketPrices {
+        date, ok := parseEventDate(marketPrice.EarningsAnnouncement, from, to)
+        if !ok {
+            continue
+        }
+        events = append(events, Event{
+            Ticker: marketPrice.Ticker,
+            Type:   TypeEarnings,
+            Date:   date,
+            Title:  fmt.Sprintf("%s 決算発表", marketPrice.Ticker),
+        })
+    }
+    return events, nil
+}
+
+// 配当履歴(発表済みの予定を含む)から権利落ち・配当支払イベントを作成する
+// 配当情報の取得に失敗した銘柄はスキップする
+func fetchDividendEvents(ctx context.Context, c *DefaultEventCollector, tickers []string, from time.Time, to time.Time) []Event {
+    type tickerEvents struct {
+        events []Event
+    }
+    results := make(chan tickerEvents, len(tickers))
+    for _, ticker := range tickers {
+        go func(ticker string) {
+            histories, err := c.MarketPriceRepo.FetchDividendHistory(ctx, ticker)
+            if err != nil {
+                log.Printf("配当情報の取得に失敗しました(%s): %v", ticker, err)
+                results <- tickerEvents{}
+                return
+            }
+            events := []Event{}
+            for _, history := range histories {
+                amount := history.Amount
+                if date, ok := parseEventDate(history.ExDate, from, to); ok {
+                    events = append(events, Event{
+                        Ticker: ticker,
+                        Type:   TypeExDividend,
+                        Date:   date,
+                        Title:  fmt.Sprintf("%s 権利落ち日 ($%g)", ticker, amount),
+                        Amount: &amount,
+                    })
+                }
+                if date, ok := parseEventDate(history.PaymentDate, from, to); ok {
+                    events = append(events, Event{
+                        Ticker: ticker,
+                        Type:   TypeDividendPayment,
+                        Date:   date,
+                        Title:  fmt.Sprintf("%s 配当支払日 ($%g)", ticker, amount),
+                        Amount: &amount,
+                    })
+                }
+            }
+            results <- tickerEvents{events: events}
+        }(ticker)
+    }
+
+    events := []Event{}
+    for i := 0; i < len(tickers); i++ {
+        result := <-results
+        events = append(events, result.events...)
+    }
+    return events
+}
+
+// 日付(日時の場合は先頭の日付部分)を解析し、期間内であればYYYY-MM-DD形式で返却する
+func parseEventDate(value string, from time.Time, to time.Time) (string, bool) {
+    if len(value) < len("2006-01-02") {
+        return "", false
+    }
+    date, err := time.Parse("2006-01-02", value[:len("2006-01-02")])
+    if err != nil || date.Before(from) || date.After(to) {
+        return "", false
+    }
+    return date.Format("2006-01-02"), true
+}
+
+// イベントを日付・ティッカー・種類の順に並べる
+func sortEvents(events []Event) []Event {
+    sort.SliceStable(events, func(i, j int) bool {
+        if events[i].Date != events[j].Date {
+            return events[i].Date < events[j].Date
+        }
+        if events[i].Ticker != events[j].Ticker {
+            return events[i].Ticker < events[j].Ticker
+        }
+        return events[i].Type < events[j].Type
+    })
+    return events
+}
@@ -0,0 +1,72 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/repository/assets/stock"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	"time"
+)
+
+// 取得できるイベントの最大日数
+const MaxEventDays = 365
+
+// ErrInvalidDays は取得日数が範囲外の場合のエラーです
+var ErrInvalidDays = errors.New("取得日数は1〜365の範囲で指定してください")
+
+// イベントの種類
+const (
+    TypeEarnings        = "EARNINGS"
+    TypeExDividend      = "EX_DIVIDEND"
+    TypeDividendPayment = "DIVIDEND_PAYMENT"
+)
+
+// Event は保有銘柄の決算発表・権利落ち・配当支払の予定を表します
+type Event struct {
+    Ticker string
+    Type   string
+    Date   string // YYYY-MM-DD
+    Title  string
+    Amount *float64 // 1株あたりの配当金額(ドル、配当イベントのみ)
+}
+
+// EventCollector インターフェースの定義
+type EventCollector interface {
+    FetchUpcomingEvents(ctx context.Context, userId uint, days int) ([]Event, error)
+}
+
+// DefaultEventCollector 構造体の定義
+type DefaultEventCollector struct {
+    StockRepo stock.UsStockRepository
+    MarketPriceRepo marketPrice.MarketPriceRepository
+}
+
+// NewEventCollector は DefaultEventCollector の新しいインスタンスを作成します
+func NewEventCollector(stockRepo stock.UsStockRepository, marketPriceRepo marketPrice.MarketPriceRepository) EventCollector {
+    return &DefaultEventCollector{StockRepo: stockRepo, MarketPriceRepo: marketPriceRepo}
+}
+
+// FetchUpcomingEvents は指定ユーザーの保有銘柄について、今日から指定日数以内の決算発表・権利落ち・配当支払のイベントを取得します
+func (c *DefaultEventCollector) FetchUpcomingEvents(ctx context.Context, userId uint, days int) ([]Event, error) {
+    if days < 1 || days > MaxEventDays {
+        return nil, ErrInvalidDays
+    }
+    modelStocks, err := c.StockRepo.FetchUsStockListById(ctx, userId)
+    if err != nil {
+        return nil, err
+    }
+    tickers := uniqueTickers(modelStocks)
+    if len(tickers) == 0 {
+        return []Event{}, nil
+    }
+    now := time.Now()
+    from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+    to := from.AddDate(0, 0, days)
+
+    earningsEvents, err := fetchEarningsEvents(ctx, c, tickers, from, to)
+    if err != nil {
+        return nil, err
+    }
+    dividendEvents := fetchDividendEvents(ctx, c, tickers, from, to)
+    return sortEvents(append(earningsEvents, dividendEvents...)), nil
+}
@@ -0,0 +1,51 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/repository/assets/stock"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// 今日から指定日数後の日付を返す
+func daysLater(days int) string {
+    return time.Now().AddDate(0, 0, days).Format("2006-01-02")
+}
+
+// 期間内のイベントのみを日付順に返却し、配当情報が取得できない銘柄はスキップする
+func TestFetchUpcomingEvents(t *testing.T) {
+    mockStockRepo := stock.NewMockUsStockRepository()
+    mockMarketPriceRepo := marketPrice.NewMockMarketPriceRepository()
+    collector := NewEventCollector(mockStockRepo, mockMarketPriceRepo)
+
+    mockStockRepo.On("FetchUsStockListById", mock.Anything, uint(1)).Return([]model.UsStock{
+        {Code: "AAPL", UserId: 1},
+        {Code: "KO", UserId: 1},
+    }, nil)
+    mockMarketPriceRepo.On("FetchMarketPriceList", mock.Anything, []string{"AAPL", "KO"}).Return([]marketPrice.MarketPriceDto{
+        {Ticker: "KO", EarningsAnnouncement: daysLater(3) + "T10:59:00.000+0000"},
+    }, nil)
+    mockMarketPriceRepo.On("FetchDividendHistory", mock.Anything, "AAPL").Return([]marketPrice.DividendHistoryDto{
+        {ExDate: daysLater(5), PaymentDate: daysLater(12), Amount: 0.24},
+    }, nil)
+    mockMarketPriceRepo.On("FetchDividendHistory", mock.Anything, "KO").Return([]marketPrice.DividendHistoryDto(nil), errors.New("rate limit exceeded"))
+
+    events, err := collector.FetchUpcomingEvents(context.Background(), 1, 30)
+
+    assert.NoError(t, err)
+    amount := 0.24
+    assert.Equal(t, []Event{
+        {Ticker: "KO", Type: TypeEarnings, Date: daysLater(3), Title: "KO 決算発表"},
+        {Ticker: "AAPL", Type: TypeExDividend, Date: daysLater(5), Title: "AAPL 権利落ち日 ($0.24)", Amount: &amount},
+        {Ticker: "AAPL", Type: TypeDividendPayment, Date: daysLater(12), Title: "AAPL 配当支払日 ($0.24)", Amount: &amount},
+    }, events)
+
+    _, err = collector.FetchUpcomingEvents(context.Background(), 1, 366)
+    assert.ErrorIs(t, err, ErrInvalidDays)
+}
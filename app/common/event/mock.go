@@ -0,0 +1,25 @@
+package event
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockEventCollector は EventCollector のモックです。
+type MockEventCollector struct {
+	mock.Mock
+}
+
+// NewMockEventCollector は新しい MockEventCollector を作成し、初期設定を行います。
+func NewMockEventCollector() *MockEventCollector {
+	return &MockEventCollector{}
+}
+
+func (m *MockEventCollector) FetchUpcomingEvents(ctx context.Context, userId uint, days int) ([]Event, error) {
+	args := m.Called(ctx, userId, days)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Event), args.Error(1)
+}
@@ -0,0 +1,78 @@
+package household
+
+import (
+	"context"
+	"errors"
+	repoHousehold "my-us-stock-backend/app/repository/household"
+	"strconv"
+)
+
+// HouseholdAccess は世帯で共有している資産へのアクセス権限を確認します
+type HouseholdAccess interface {
+    ScopeUserIds(ctx context.Context, userId uint, householdId *string) ([]uint, error)
+    AuthorizeEdit(ctx context.Context, userId uint, holding interface{}, id uint) error
+}
+
+// DefaultHouseholdAccess 構造体の定義
+type DefaultHouseholdAccess struct {
+    HouseholdRepo repoHousehold.HouseholdRepository
+}
+
+// NewHouseholdAccess は DefaultHouseholdAccess の新しいインスタンスを作成します
+func NewHouseholdAccess(householdRepo repoHousehold.HouseholdRepository) HouseholdAccess {
+    return &DefaultHouseholdAccess{HouseholdRepo: householdRepo}
+}
+
+// ScopeUserIds は参照する資産の所有者のidのリストを返します
+// 世帯を指定しない場合は本人のみ、指定した場合は招待を承認した世帯の全メンバー(閲覧者以上の権限が必要)
+func (a *DefaultHouseholdAccess) ScopeUserIds(ctx context.Context, userId uint, householdId *string) ([]uint, error) {
+    if householdId == nil {
+        return []uint{userId}, nil
+    }
+    id, err := strconv.ParseUint(*householdId, 10, 64)
+    if err != nil || id == 0 {
+        return nil, errors.New("入力された世帯idが無効です")
+    }
+    member, err := a.HouseholdRepo.FetchMember(ctx, uint(id), userId)
+    if err != nil {
+        return nil, err
+    }
+    if member == nil {
+        return nil, errors.New("指定された世帯が見つかりません")
+    }
+    members, err := a.HouseholdRepo.FetchMembers(ctx, uint(id))
+    if err != nil {
+        return nil, err
+    }
+    userIds := make([]uint, len(members))
+    for i, m := range members {
+        userIds[i] = m.UserId
+    }
+    return userIds, nil
+}
+
+// AuthorizeEdit は保有資産を更新・削除できるか確認します
+// 本人の保有資産か、編集者以上の権限で共に所属している世帯のメンバーの保有資産のみ編集できる
+func (a *DefaultHouseholdAccess) AuthorizeEdit(ctx context.Context, userId uint, holding interface{}, id uint) error {
+    ownerId, err := a.HouseholdRepo.FetchHoldingOwnerId(ctx, holding, id)
+    if err != nil {
+        return err
+    }
+    if ownerId == userId {
+        return nil
+    }
+    roles, err := a.HouseholdRepo.FetchSharedRoles(ctx, userId, ownerId)
+    if err != nil {
+        return err
+    }
+    for _, role := range roles {
+        if role == repoHousehold.RoleOwner || role == repoHousehold.RoleEditor {
+            return nil
+        }
+    }
+    // 共有されていない資産は存在を知らせない
+    if len(roles) == 0 {
+        return errors.New("指定された保有資産が見つかりません")
+    }
+    return errors.New("この保有資産を編集する権限がありません")
+}
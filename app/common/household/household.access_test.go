@@ -0,0 +1,70 @@
+package household
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	repoHousehold "my-us-stock-backend/app/repository/household"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestScopeUserIdsWithoutHousehold(t *testing.T) {
+    mockRepo := repoHousehold.NewMockHouseholdRepository()
+    access := NewHouseholdAccess(mockRepo)
+
+    userIds, err := access.ScopeUserIds(context.Background(), 1, nil)
+
+    assert.NoError(t, err)
+    assert.Equal(t, []uint{1}, userIds)
+    mockRepo.AssertNotCalled(t, "FetchMember", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestScopeUserIdsWithHousehold(t *testing.T) {
+    mockRepo := repoHousehold.NewMockHouseholdRepository()
+    access := NewHouseholdAccess(mockRepo)
+    mockRepo.On("FetchMember", mock.Anything, uint(3), uint(1)).Return(&model.HouseholdMember{HouseholdId: 3, UserId: 1, Role: repoHousehold.RoleViewer}, nil)
+    mockRepo.On("FetchMembers", mock.Anything, uint(3)).Return([]repoHousehold.HouseholdMemberDto{{UserId: 2}, {UserId: 1}}, nil)
+
+    householdId := "3"
+    userIds, err := access.ScopeUserIds(context.Background(), 1, &householdId)
+
+    assert.NoError(t, err)
+    assert.Equal(t, []uint{2, 1}, userIds)
+}
+
+func TestScopeUserIdsNotMember(t *testing.T) {
+    mockRepo := repoHousehold.NewMockHouseholdRepository()
+    access := NewHouseholdAccess(mockRepo)
+    mockRepo.On("FetchMember", mock.Anything, uint(3), uint(1)).Return(nil, nil)
+
+    householdId := "3"
+    userIds, err := access.ScopeUserIds(context.Background(), 1, &householdId)
+
+    assert.Nil(t, userIds)
+    assert.EqualError(t, err, "指定された世帯が見つかりません")
+
+    invalidId := "abc"
+    _, err = access.ScopeUserIds(context.Background(), 1, &invalidId)
+    assert.EqualError(t, err, "入力された世帯idが無効です")
+}
+
+func TestAuthorizeEdit(t *testing.T) {
+    mockRepo := repoHousehold.NewMockHouseholdRepository()
+    access := NewHouseholdAccess(mockRepo)
+    mockRepo.On("FetchHoldingOwnerId", mock.Anything, mock.Anything, uint(10)).Return(uint(1), nil)
+    mockRepo.On("FetchHoldingOwnerId", mock.Anything, mock.Anything, uint(20)).Return(uint(2), nil)
+    mockRepo.On("FetchSharedRoles", mock.Anything, uint(1), uint(2)).Return([]string{repoHousehold.RoleViewer}, nil)
+    mockRepo.On("FetchSharedRoles", mock.Anything, uint(3), uint(2)).Return([]string{repoHousehold.RoleViewer, repoHousehold.RoleEditor}, nil)
+    mockRepo.On("FetchSharedRoles", mock.Anything, uint(4), uint(2)).Return([]string{}, nil)
+
+    // 本人の保有資産
+    assert.NoError(t, access.AuthorizeEdit(context.Background(), 1, &model.UsStock{}, 10))
+    // 閲覧者は編集できない
+    assert.EqualError(t, access.AuthorizeEdit(context.Background(), 1, &model.UsStock{}, 20), "この保有資産を編集する権限がありません")
+    // いずれかの世帯で編集者であれば編集できる
+    assert.NoError(t, access.AuthorizeEdit(context.Background(), 3, &model.UsStock{}, 20))
+    // 共有されていない資産
+    assert.EqualError(t, access.AuthorizeEdit(context.Background(), 4, &model.UsStock{}, 20), "指定された保有資産が見つかりません")
+}
@@ -0,0 +1,30 @@
+package household
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockHouseholdAccess は HouseholdAccess のモックです。
+type MockHouseholdAccess struct {
+	mock.Mock
+}
+
+// NewMockHouseholdAccess は新しい MockHouseholdAccess を作成し、初期設定を行います。
+func NewMockHouseholdAccess() *MockHouseholdAccess {
+	return &MockHouseholdAccess{}
+}
+
+func (m *MockHouseholdAccess) ScopeUserIds(ctx context.Context, userId uint, householdId *string) ([]uint, error) {
+	args := m.Called(ctx, userId, householdId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+func (m *MockHouseholdAccess) AuthorizeEdit(ctx context.Context, userId uint, holding interface{}, id uint) error {
+	args := m.Called(ctx, userId, holding, id)
+	return args.Error(0)
+}
@@ -0,0 +1,100 @@
+package housekeeping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	repoAccount "my-us-stock-backend/app/repository/account"
+	repoFixedIncome "my-us-stock-backend/app/repository/assets/fixed-income"
+	repoDeletedHolding "my-us-stock-backend/app/repository/deleted-holding"
+	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
+	"os"
+	"strconv"
+	"time"
+)
+
+// 削除した保有資産をゴミ箱に残す日数のデフォルト値
+const defaultRetentionDays = 30
+
+// Housekeeper はバックグラウンドで定期実行するデータの整理(退会ユーザー・削除済みの保有資産の削除、満期償還)を行います
+type Housekeeper interface {
+    PurgeScheduledAccounts(ctx context.Context) error
+    PurgeExpiredHoldings(ctx context.Context) error
+    MatureFixedIncomeAssets(ctx context.Context) error
+}
+
+// DefaultHousekeeper 構造体の定義
+type DefaultHousekeeper struct {
+    AccountRepo repoAccount.AccountRepository
+    DeletedHoldingRepo repoDeletedHolding.DeletedHoldingRepository
+    FixedIncomeRepo repoFixedIncome.FixedIncomeRepository
+    CurrencyRepo repoCurrency.CurrencyRepository
+    Retention time.Duration
+}
+
+// NewHousekeeper は DefaultHousekeeper の新しいインスタンスを作成します
+func NewHousekeeper(accountRepo repoAccount.AccountRepository, deletedHoldingRepo repoDeletedHolding.DeletedHoldingRepository, fixedIncomeRepo repoFixedIncome.FixedIncomeRepository, currencyRepo repoCurrency.CurrencyRepository, retention time.Duration) Housekeeper {
+    return &DefaultHousekeeper{
+        AccountRepo: accountRepo,
+        DeletedHoldingRepo: deletedHoldingRepo,
+        FixedIncomeRepo: fixedIncomeRepo,
+        CurrencyRepo: currencyRepo,
+        Retention: retention,
+    }
+}
+
+// RetentionFromEnv は削除した保有資産をゴミ箱に残す期間を環境変数(DELETED_HOLDING_RETENTION_DAYS)から読み込みます
+// 未設定・不正な値の場合は30日とします
+func RetentionFromEnv() time.Duration {
+    days, err := strconv.Atoi(os.Getenv("DELETED_HOLDING_RETENTION_DAYS"))
+    if err != nil || days <= 0 {
+        days = defaultRetentionDays
+    }
+    return time.Duration(days) * 24 * time.Hour
+}
+
+// PurgeScheduledAccounts は猶予期間が終了したユーザーと全てのデータを削除します
+// 1件失敗しても他のユーザーの削除は続け、失敗したものはまとめて返します(次回の実行で再度削除する)
+func (h *DefaultHousekeeper) PurgeScheduledAccounts(ctx context.Context) error {
+    userIds, err := h.AccountRepo.FetchAccountIdsToPurge(ctx, time.Now())
+    if err != nil {
+        return err
+    }
+    var errs []error
+    for _, userId := range userIds {
+        if err := h.AccountRepo.DeleteAccount(ctx, userId); err != nil {
+            errs = append(errs, fmt.Errorf("user %d: %w", userId, err))
+        }
+    }
+    return errors.Join(errs...)
+}
+
+// PurgeExpiredHoldings は保持期間を過ぎた削除済みの保有資産を完全に削除します
+func (h *DefaultHousekeeper) PurgeExpiredHoldings(ctx context.Context) error {
+    purged, err := h.DeletedHoldingRepo.PurgeDeletedHoldings(ctx, time.Now().Add(-h.Retention))
+    if err != nil {
+        return err
+    }
+    if purged > 0 {
+        log.Printf("削除済みの保有資産を%d件完全に削除しました", purged)
+    }
+    return nil
+}
+
+// MatureFixedIncomeAssets は満期日を迎えた固定利回り資産を満期償還済みにし、償還金額を現金に移します
+func (h *DefaultHousekeeper) MatureFixedIncomeAssets(ctx context.Context) error {
+    // 償還した資産の評価額を資産総額から差し引くためにドル建ての評価額の換算に用いる
+    currentUsdJpy, err := h.CurrencyRepo.FetchCurrentUsdJpy(ctx)
+    if err != nil {
+        return err
+    }
+    matured, err := h.FixedIncomeRepo.MatureFixedIncomeAssets(ctx, time.Now(), currentUsdJpy)
+    if err != nil {
+        return err
+    }
+    if matured > 0 {
+        log.Printf("満期を迎えた固定利回り資産を%d件償還しました", matured)
+    }
+    return nil
+}
@@ -0,0 +1,94 @@
+package housekeeping
+
+import (
+	"context"
+	"errors"
+	repoAccount "my-us-stock-backend/app/repository/account"
+	repoFixedIncome "my-us-stock-backend/app/repository/assets/fixed-income"
+	repoDeletedHolding "my-us-stock-backend/app/repository/deleted-holding"
+	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testRetention = 30 * 24 * time.Hour
+
+type testMocks struct {
+    accountRepo        *repoAccount.MockAccountRepository
+    deletedHoldingRepo *repoDeletedHolding.MockDeletedHoldingRepository
+    fixedIncomeRepo    *repoFixedIncome.MockFixedIncomeAssetRepository
+    currencyRepo       *repoCurrency.MockCurrencyRepository
+}
+
+func newTestHousekeeper() (Housekeeper, testMocks) {
+    mocks := testMocks{
+        accountRepo:        repoAccount.NewMockAccountRepository(),
+        deletedHoldingRepo: repoDeletedHolding.NewMockDeletedHoldingRepository(),
+        fixedIncomeRepo:    repoFixedIncome.NewMockFixedIncomeAssetRepository(),
+        currencyRepo:       repoCurrency.NewMockCurrencyRepository(),
+    }
+    housekeeper := NewHousekeeper(mocks.accountRepo, mocks.deletedHoldingRepo, mocks.fixedIncomeRepo, mocks.currencyRepo, testRetention)
+    return housekeeper, mocks
+}
+
+// 1件失敗しても残りのユーザーは削除する
+func TestPurgeScheduledAccounts(t *testing.T) {
+    housekeeper, mocks := newTestHousekeeper()
+    mocks.accountRepo.On("FetchAccountIdsToPurge", mock.Anything, mock.Anything).Return([]uint{1, 2, 3}, nil)
+    mocks.accountRepo.On("DeleteAccount", mock.Anything, uint(1)).Return(nil)
+    mocks.accountRepo.On("DeleteAccount", mock.Anything, uint(2)).Return(errors.New("deadlock"))
+    mocks.accountRepo.On("DeleteAccount", mock.Anything, uint(3)).Return(nil)
+
+    err := housekeeper.PurgeScheduledAccounts(context.Background())
+
+    assert.EqualError(t, err, "user 2: deadlock")
+    mocks.accountRepo.AssertNumberOfCalls(t, "DeleteAccount", 3)
+}
+
+// 保持期間より前に削除されたものを完全に削除する
+func TestPurgeExpiredHoldings(t *testing.T) {
+    housekeeper, mocks := newTestHousekeeper()
+    expectedBefore := time.Now().Add(-testRetention)
+    mocks.deletedHoldingRepo.On("PurgeDeletedHoldings", mock.Anything, mock.MatchedBy(func(deletedBefore time.Time) bool {
+        return deletedBefore.Sub(expectedBefore).Abs() < time.Minute
+    })).Return(int64(2), nil)
+
+    err := housekeeper.PurgeExpiredHoldings(context.Background())
+
+    assert.NoError(t, err)
+    mocks.deletedHoldingRepo.AssertExpectations(t)
+}
+
+// 現在のドル円で償還した資産の評価額を換算する
+func TestMatureFixedIncomeAssets(t *testing.T) {
+    housekeeper, mocks := newTestHousekeeper()
+    mocks.currencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(150.0, nil)
+    mocks.fixedIncomeRepo.On("MatureFixedIncomeAssets", mock.Anything, mock.Anything, 150.0).Return(int64(1), nil)
+
+    err := housekeeper.MatureFixedIncomeAssets(context.Background())
+
+    assert.NoError(t, err)
+    mocks.fixedIncomeRepo.AssertExpectations(t)
+}
+
+// ドル円が取得できない場合は償還しない
+func TestMatureFixedIncomeAssetsCurrencyError(t *testing.T) {
+    housekeeper, mocks := newTestHousekeeper()
+    mocks.currencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(0.0, errors.New("currency unavailable"))
+
+    err := housekeeper.MatureFixedIncomeAssets(context.Background())
+
+    assert.EqualError(t, err, "currency unavailable")
+    mocks.fixedIncomeRepo.AssertNotCalled(t, "MatureFixedIncomeAssets", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRetentionFromEnv(t *testing.T) {
+    t.Setenv("DELETED_HOLDING_RETENTION_DAYS", "7")
+    assert.Equal(t, 7*24*time.Hour, RetentionFromEnv())
+
+    t.Setenv("DELETED_HOLDING_RETENTION_DAYS", "")
+    assert.Equal(t, 30*24*time.Hour, RetentionFromEnv())
+}
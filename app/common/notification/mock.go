@@ -0,0 +1,32 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockNotificationService は NotificationService のモックです。
+type MockNotificationService struct {
+	mock.Mock
+}
+
+// NewMockNotificationService は新しい MockNotificationService を作成し、初期設定を行います。
+func NewMockNotificationService() *MockNotificationService {
+	return &MockNotificationService{}
+}
+
+func (m *MockNotificationService) Notify(ctx context.Context, userId uint, event string, subject string, body string) error {
+	args := m.Called(ctx, userId, event, subject, body)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) NotifyHTML(ctx context.Context, userId uint, event string, subject string, body string, htmlBody string) error {
+	args := m.Called(ctx, userId, event, subject, body, htmlBody)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) DispatchPendingNotifications(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
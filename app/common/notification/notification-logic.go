@@ -0,0 +1,109 @@
+package notification
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"my-us-stock-backend/app/database/model"
+	"strings"
+	"time"
+)
+
+// 再送の上限回数と間隔
+const (
+    maxDeliveryAttempts = 5
+    baseRetryInterval   = time.Minute
+    maxRetryInterval    = time.Hour
+)
+
+// 汎用Webhookのペイロード
+type webhookPayload struct {
+    ID        uint   `json:"id"`
+    Event     string `json:"event"`
+    Subject   string `json:"subject"`
+    Body      string `json:"body"`
+    CreatedAt string `json:"createdAt"`
+}
+
+// createWebhookPayload は通知先の種類に応じたJSONを作成します
+// Slackは text、Discordは content にMarkdownで件名と本文を設定します
+func createWebhookPayload(channelType string, delivery model.NotificationDelivery) ([]byte, error) {
+    switch channelType {
+    case ChannelTypeSlack:
+        return json.Marshal(map[string]string{"text": fmt.Sprintf("*%s*\n%s", delivery.Subject, delivery.Body)})
+    case ChannelTypeDiscord:
+        return json.Marshal(map[string]string{"content": fmt.Sprintf("**%s**\n%s", delivery.Subject, delivery.Body)})
+    case ChannelTypeWebhook:
+        return json.Marshal(webhookPayload{
+            ID:        delivery.ID,
+            Event:     delivery.Event,
+            Subject:   delivery.Subject,
+            Body:      delivery.Body,
+            CreatedAt: delivery.CreatedAt.Format(time.RFC3339),
+        })
+    default:
+        return nil, fmt.Errorf("未対応の通知先です: %s", channelType)
+    }
+}
+
+// signPayload は「タイムスタンプ.本文」のHMAC-SHA256を16進数で返します
+func signPayload(secret string, timestamp string, payload []byte) string {
+    mac := hmac.New(sha256.New, []byte(secret))
+    mac.Write([]byte(timestamp + "."))
+    mac.Write(payload)
+    return hex.EncodeToString(mac.Sum(nil))
+}
+
+// メールのパート区切り
+const mailBoundary = "my-us-stock-notification-boundary"
+
+// createMailMessage はUTF-8のメールを作成します
+// HTML本文がある場合はテキストとHTMLの multipart/alternative にします
+func createMailMessage(from string, to string, delivery model.NotificationDelivery) []byte {
+    var builder strings.Builder
+    builder.WriteString("From: " + from + "\r\n")
+    builder.WriteString("To: " + to + "\r\n")
+    builder.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", delivery.Subject) + "\r\n")
+    builder.WriteString("MIME-Version: 1.0\r\n")
+    if delivery.HTMLBody == "" {
+        builder.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+        builder.WriteString("\r\n")
+        builder.WriteString(toCRLF(delivery.Body))
+        builder.WriteString("\r\n")
+        return []byte(builder.String())
+    }
+
+    builder.WriteString("Content-Type: multipart/alternative; boundary=\"" + mailBoundary + "\"\r\n")
+    builder.WriteString("\r\n")
+    builder.WriteString("--" + mailBoundary + "\r\n")
+    builder.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+    builder.WriteString("\r\n")
+    builder.WriteString(toCRLF(delivery.Body))
+    builder.WriteString("\r\n")
+    builder.WriteString("--" + mailBoundary + "\r\n")
+    builder.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+    builder.WriteString("\r\n")
+    builder.WriteString(toCRLF(delivery.HTMLBody))
+    builder.WriteString("\r\n")
+    builder.WriteString("--" + mailBoundary + "--\r\n")
+    return []byte(builder.String())
+}
+
+func toCRLF(text string) string {
+    return strings.ReplaceAll(text, "\n", "\r\n")
+}
+
+// retryInterval は失敗回数に応じた再送までの間隔を返します(1分から倍々で最大1時間)
+func retryInterval(attempts int) time.Duration {
+    interval := baseRetryInterval
+    for i := 1; i < attempts; i++ {
+        interval *= 2
+        if interval >= maxRetryInterval {
+            return maxRetryInterval
+        }
+    }
+    return interval
+}
@@ -0,0 +1,125 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"my-us-stock-backend/app/database/model"
+	repoNotification "my-us-stock-backend/app/repository/notification"
+	"net/http"
+	"time"
+)
+
+// 通知の種類
+const (
+    EventAlertTriggered = "ALERT_TRIGGERED"
+    EventSnapshotFailed = "SNAPSHOT_FAILED"
+    EventDailySummary   = "DAILY_SUMMARY"
+)
+
+// 1回の送信処理で扱う通知の上限
+const dispatchBatchSize = 100
+
+// 送信処理で確保した通知を他の実行から取得させない時間(送信結果が記録されないまま過ぎた場合は再送される)
+const deliveryClaimDuration = 10 * time.Minute
+
+// 通知先が削除されている場合は再送しても成功しないため、すぐに失敗とする
+var errChannelNotFound = errors.New("通知先が見つかりません")
+
+// NotificationService インターフェースの定義
+type NotificationService interface {
+    Notify(ctx context.Context, userId uint, event string, subject string, body string) error
+    NotifyHTML(ctx context.Context, userId uint, event string, subject string, body string, htmlBody string) error
+    DispatchPendingNotifications(ctx context.Context) error
+}
+
+// DefaultNotificationService 構造体の定義
+type DefaultNotificationService struct {
+    NotificationRepo repoNotification.NotificationRepository
+    Senders map[string]Sender
+}
+
+// NewNotificationService は DefaultNotificationService の新しいインスタンスを作成します
+func NewNotificationService(notificationRepo repoNotification.NotificationRepository, client *http.Client) NotificationService {
+    webhookSender := NewWebhookSender(client)
+    senders := map[string]Sender{
+        ChannelTypeWebhook: webhookSender,
+        ChannelTypeSlack:   webhookSender,
+        ChannelTypeDiscord: webhookSender,
+        ChannelTypeEmail:   NewSMTPSender(),
+    }
+    return &DefaultNotificationService{NotificationRepo: notificationRepo, Senders: senders}
+}
+
+// Notify はユーザーの有効な通知先すべてに対して通知を送信キューへ登録します
+// 実際の送信は DispatchPendingNotifications で非同期に行います
+func (s *DefaultNotificationService) Notify(ctx context.Context, userId uint, event string, subject string, body string) error {
+    return s.NotifyHTML(ctx, userId, event, subject, body, "")
+}
+
+// NotifyHTML はメールではHTML、その他の通知先ではテキストの本文を使う通知を送信キューへ登録します
+func (s *DefaultNotificationService) NotifyHTML(ctx context.Context, userId uint, event string, subject string, body string, htmlBody string) error {
+    dto := repoNotification.CreateNotificationDeliveryDto{
+        UserId:   userId,
+        Event:    event,
+        Subject:  subject,
+        Body:     body,
+        HTMLBody: htmlBody,
+    }
+    _, err := s.NotificationRepo.EnqueueNotificationDeliveries(ctx, dto, time.Now())
+    return err
+}
+
+// DispatchPendingNotifications は送信予定日時を過ぎた通知を送信します
+// 失敗した通知は間隔を空けて再送し、上限回数に達したものは失敗として記録します
+func (s *DefaultNotificationService) DispatchPendingNotifications(ctx context.Context) error {
+    return s.dispatchPendingNotifications(ctx, time.Now())
+}
+
+func (s *DefaultNotificationService) dispatchPendingNotifications(ctx context.Context, now time.Time) error {
+    deliveries, err := s.NotificationRepo.ClaimDueNotificationDeliveryList(ctx, now, dispatchBatchSize, now.Add(deliveryClaimDuration))
+    if err != nil {
+        return err
+    }
+
+    for _, delivery := range deliveries {
+        attempts := delivery.Attempts + 1
+        sendErr := s.send(ctx, delivery)
+
+        updateDto := repoNotification.UpdateNotificationDeliveryDto{
+            ID:            delivery.ID,
+            Attempts:      attempts,
+            NextAttemptAt: delivery.NextAttemptAt,
+        }
+        switch {
+        case sendErr == nil:
+            updateDto.Status = repoNotification.StatusSent
+            updateDto.SentAt = &now
+        case attempts >= maxDeliveryAttempts || errors.Is(sendErr, errChannelNotFound):
+            updateDto.Status = repoNotification.StatusFailed
+            updateDto.LastError = sendErr.Error()
+        default:
+            updateDto.Status = repoNotification.StatusPending
+            updateDto.LastError = sendErr.Error()
+            updateDto.NextAttemptAt = now.Add(retryInterval(attempts))
+        }
+        // 更新に失敗しても他の通知の送信は続ける
+        if err := s.NotificationRepo.UpdateNotificationDelivery(ctx, updateDto); err != nil {
+            log.Printf("通知(id: %d)の送信結果の更新に失敗しました: %v", delivery.ID, err)
+        }
+    }
+    return nil
+}
+
+func (s *DefaultNotificationService) send(ctx context.Context, delivery model.NotificationDelivery) error {
+    // 通知先が削除されている場合は送信できない
+    if delivery.Channel.ID == 0 {
+        return errChannelNotFound
+    }
+    sender, ok := s.Senders[delivery.Channel.Type]
+    if !ok {
+        return fmt.Errorf("未対応の通知先です: %s", delivery.Channel.Type)
+    }
+    return sender.Send(ctx, delivery.Channel, delivery)
+}
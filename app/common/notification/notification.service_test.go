@@ -0,0 +1,74 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/database/model"
+	repoNotification "my-us-stock-backend/app/repository/notification"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockSender は Sender のモックです。
+type MockSender struct {
+    mock.Mock
+}
+
+func (m *MockSender) Send(ctx context.Context, channel model.NotificationChannel, delivery model.NotificationDelivery) error {
+    args := m.Called(ctx, channel, delivery)
+    return args.Error(0)
+}
+
+func TestNotify(t *testing.T) {
+    mockRepo := repoNotification.NewMockNotificationRepository()
+    service := &DefaultNotificationService{NotificationRepo: mockRepo}
+
+    expectedDto := repoNotification.CreateNotificationDeliveryDto{UserId: 1, Event: EventSnapshotFailed, Subject: "件名", Body: "本文"}
+    mockRepo.On("EnqueueNotificationDeliveries", mock.Anything, expectedDto, mock.Anything).Return([]model.NotificationDelivery{}, nil)
+
+    err := service.Notify(context.Background(), 1, EventSnapshotFailed, "件名", "本文")
+
+    assert.NoError(t, err)
+    mockRepo.AssertExpectations(t)
+}
+
+// 送信結果に応じて、送信済み・再送待ち・失敗を記録する
+func TestDispatchPendingNotifications(t *testing.T) {
+    mockRepo := repoNotification.NewMockNotificationRepository()
+    mockSender := new(MockSender)
+    service := &DefaultNotificationService{NotificationRepo: mockRepo, Senders: map[string]Sender{ChannelTypeSlack: mockSender}}
+
+    now := time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)
+    okChannel := model.NotificationChannel{Model: gorm.Model{ID: 1}, Type: ChannelTypeSlack, Target: "https://hooks.example.com/ok"}
+    ngChannel := model.NotificationChannel{Model: gorm.Model{ID: 2}, Type: ChannelTypeSlack, Target: "https://hooks.example.com/ng"}
+    deliveries := []model.NotificationDelivery{
+        {Model: gorm.Model{ID: 1}, ChannelId: 1, Channel: okChannel, Status: "PENDING", NextAttemptAt: now},
+        {Model: gorm.Model{ID: 2}, ChannelId: 2, Channel: ngChannel, Status: "PENDING", Attempts: 1, NextAttemptAt: now},
+        {Model: gorm.Model{ID: 3}, ChannelId: 2, Channel: ngChannel, Status: "PENDING", Attempts: 4, NextAttemptAt: now},
+        // 通知先が削除されている
+        {Model: gorm.Model{ID: 4}, ChannelId: 3, Status: "PENDING", NextAttemptAt: now},
+    }
+    mockRepo.On("ClaimDueNotificationDeliveryList", mock.Anything, now, dispatchBatchSize, now.Add(deliveryClaimDuration)).Return(deliveries, nil)
+    mockSender.On("Send", mock.Anything, okChannel, mock.Anything).Return(nil)
+    mockSender.On("Send", mock.Anything, ngChannel, mock.Anything).Return(errors.New("connection refused"))
+    mockRepo.On("UpdateNotificationDelivery", mock.Anything, mock.Anything).Return(nil)
+
+    err := service.dispatchPendingNotifications(context.Background(), now)
+
+    assert.NoError(t, err)
+    mockRepo.AssertCalled(t, "UpdateNotificationDelivery", mock.Anything, repoNotification.UpdateNotificationDeliveryDto{ID: 1, Status: "SENT", Attempts: 1, NextAttemptAt: now, SentAt: &now})
+    mockRepo.AssertCalled(t, "UpdateNotificationDelivery", mock.Anything, repoNotification.UpdateNotificationDeliveryDto{ID: 2, Status: "PENDING", Attempts: 2, NextAttemptAt: now.Add(2 * time.Minute), LastError: "connection refused"})
+    mockRepo.AssertCalled(t, "UpdateNotificationDelivery", mock.Anything, repoNotification.UpdateNotificationDeliveryDto{ID: 3, Status: "FAILED", Attempts: 5, NextAttemptAt: now, LastError: "connection refused"})
+    mockRepo.AssertCalled(t, "UpdateNotificationDelivery", mock.Anything, repoNotification.UpdateNotificationDeliveryDto{ID: 4, Status: "FAILED", Attempts: 1, NextAttemptAt: now, LastError: "通知先が見つかりません"})
+}
+
+func TestRetryInterval(t *testing.T) {
+    assert.Equal(t, time.Minute, retryInterval(1))
+    assert.Equal(t, 2*time.Minute, retryInterval(2))
+    assert.Equal(t, 8*time.Minute, retryInterval(4))
+    assert.Equal(t, time.Hour, retryInterval(10))
+}
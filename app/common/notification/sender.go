@@ -0,0 +1,19 @@
+package notification
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+)
+
+// 通知先の種類
+const (
+    ChannelTypeWebhook = "WEBHOOK"
+    ChannelTypeSlack   = "SLACK"
+    ChannelTypeDiscord = "DISCORD"
+    ChannelTypeEmail   = "EMAIL"
+)
+
+// Sender は通知先の種類ごとの送信処理を表します
+type Sender interface {
+    Send(ctx context.Context, channel model.NotificationChannel, delivery model.NotificationDelivery) error
+}
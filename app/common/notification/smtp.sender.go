@@ -0,0 +1,48 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"my-us-stock-backend/app/database/model"
+	"net"
+	"net/smtp"
+	"os"
+)
+
+// SMTPSender はメールで通知を送信します
+type SMTPSender struct {
+    host string
+    port string
+    username string
+    password string
+    from string
+}
+
+// NewSMTPSender は環境変数の設定から新しい SMTPSender インスタンスを作成します
+func NewSMTPSender() *SMTPSender {
+    port := os.Getenv("SMTP_PORT")
+    if port == "" {
+        port = "587"
+    }
+    return &SMTPSender{
+        host: os.Getenv("SMTP_HOST"),
+        port: port,
+        username: os.Getenv("SMTP_USERNAME"),
+        password: os.Getenv("SMTP_PASSWORD"),
+        from: os.Getenv("SMTP_FROM"),
+    }
+}
+
+// Send は通知先のメールアドレスへ通知を送信します
+func (s *SMTPSender) Send(ctx context.Context, channel model.NotificationChannel, delivery model.NotificationDelivery) error {
+    if s.host == "" || s.from == "" {
+        return fmt.Errorf("SMTPサーバーが設定されていません")
+    }
+
+    var auth smtp.Auth
+    if s.username != "" {
+        auth = smtp.PlainAuth("", s.username, s.password, s.host)
+    }
+    message := createMailMessage(s.from, channel.Target, delivery)
+    return smtp.SendMail(net.JoinHostPort(s.host, s.port), auth, s.from, []string{channel.Target}, message)
+}
@@ -0,0 +1,103 @@
+package notification
+
+import (
+	"bufio"
+	"context"
+	"mime"
+	"my-us-stock-backend/app/database/model"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// startTestSMTPServer は受信したメールの宛先と本文を返すだけのSMTPサーバーを起動します
+func startTestSMTPServer(t *testing.T) (string, <-chan string, <-chan string) {
+    listener, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatalf("Failed to listen: %v", err)
+    }
+    t.Cleanup(func() { listener.Close() })
+
+    recipients := make(chan string, 1)
+    messages := make(chan string, 1)
+    go func() {
+        conn, err := listener.Accept()
+        if err != nil {
+            return
+        }
+        defer conn.Close()
+        tp := textproto.NewConn(conn)
+        tp.PrintfLine("220 localhost ESMTP")
+        for {
+            line, err := tp.ReadLine()
+            if err != nil {
+                return
+            }
+            command := strings.ToUpper(line)
+            switch {
+            case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+                tp.PrintfLine("250 localhost")
+            case strings.HasPrefix(command, "RCPT TO:"):
+                recipients <- strings.Trim(line[len("RCPT TO:"):], "<>")
+                tp.PrintfLine("250 OK")
+            case strings.HasPrefix(command, "DATA"):
+                tp.PrintfLine("354 Start mail input")
+                data, _ := tp.ReadDotLines()
+                messages <- strings.Join(data, "\n")
+                tp.PrintfLine("250 OK")
+            case strings.HasPrefix(command, "QUIT"):
+                tp.PrintfLine("221 Bye")
+                return
+            default:
+                tp.PrintfLine("250 OK")
+            }
+        }
+    }()
+    return listener.Addr().String(), recipients, messages
+}
+
+func TestSMTPSender(t *testing.T) {
+    addr, recipients, messages := startTestSMTPServer(t)
+    host, port, _ := net.SplitHostPort(addr)
+    sender := &SMTPSender{host: host, port: port, from: "noreply@example.com"}
+
+    channel := model.NotificationChannel{Type: ChannelTypeEmail, Target: "user@example.com"}
+    err := sender.Send(context.Background(), channel, testDelivery)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "user@example.com", <-recipients)
+    message := <-messages
+    reader := textproto.NewReader(bufio.NewReader(strings.NewReader(message + "\n")))
+    header, _ := reader.ReadMIMEHeader()
+    subject, _ := new(mime.WordDecoder).DecodeHeader(header.Get("Subject"))
+    assert.Equal(t, "AAPL の株価が 170 以下になりました", subject)
+    assert.Contains(t, message, "現在値: 168.5")
+}
+
+// SMTPサーバーが未設定の場合はエラーとし、再送の対象とする
+func TestSMTPSenderNotConfigured(t *testing.T) {
+    sender := &SMTPSender{}
+    err := sender.Send(context.Background(), model.NotificationChannel{Type: ChannelTypeEmail, Target: "user@example.com"}, testDelivery)
+    assert.Error(t, err)
+}
+
+// HTML本文がある場合はテキストとHTMLの両方を送信する
+func TestSMTPSenderHTML(t *testing.T) {
+    addr, recipients, messages := startTestSMTPServer(t)
+    host, port, _ := net.SplitHostPort(addr)
+    sender := &SMTPSender{host: host, port: port, from: "noreply@example.com"}
+
+    delivery := testDelivery
+    delivery.HTMLBody = "<p>現在値: 168.5</p>"
+    err := sender.Send(context.Background(), model.NotificationChannel{Type: ChannelTypeEmail, Target: "user@example.com"}, delivery)
+
+    assert.NoError(t, err)
+    <-recipients
+    message := <-messages
+    assert.Contains(t, message, "multipart/alternative")
+    assert.Contains(t, message, "Content-Type: text/plain; charset=UTF-8")
+    assert.Contains(t, message, "<p>現在値: 168.5</p>")
+}
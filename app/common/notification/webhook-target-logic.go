@@ -0,0 +1,108 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrDisallowedWebhookTarget は内部ネットワーク宛ての通知先を表すエラーです
+var ErrDisallowedWebhookTarget = errors.New("内部ネットワーク宛ての通知先は登録できません")
+
+// 名前解決の結果に関わらず拒否するホスト名(クラウドのメタデータサービスなど)
+var disallowedWebhookHosts = map[string]bool{
+    "localhost":                true,
+    "metadata.google.internal": true,
+}
+
+// IsPrivate では判定されないが内部向けに使われるアドレス帯
+var disallowedWebhookNetworks = []*net.IPNet{
+    mustParseCIDR("0.0.0.0/8"),
+    mustParseCIDR("100.64.0.0/10"),
+    mustParseCIDR("192.0.0.0/24"),
+    mustParseCIDR("198.18.0.0/15"),
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+    _, network, err := net.ParseCIDR(cidr)
+    if err != nil {
+        panic(err)
+    }
+    return network
+}
+
+// isDisallowedWebhookIP はループバック・プライベート・リンクローカル(メタデータの 169.254.169.254 を含む)などのアドレスかを判定します
+func isDisallowedWebhookIP(ip net.IP) bool {
+    if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+        ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+        return true
+    }
+    for _, network := range disallowedWebhookNetworks {
+        if network.Contains(ip) {
+            return true
+        }
+    }
+    return false
+}
+
+// ValidateWebhookTarget は通知先のホストを名前解決し、内部ネットワーク宛てであればエラーを返します
+// 名前解決できない場合は登録を妨げず、送信時の接続先チェックに委ねます
+func ValidateWebhookTarget(ctx context.Context, target string) error {
+    parsed, err := url.Parse(target)
+    if err != nil {
+        return err
+    }
+    host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+    if disallowedWebhookHosts[host] || strings.HasSuffix(host, ".localhost") {
+        return ErrDisallowedWebhookTarget
+    }
+    if ip := net.ParseIP(host); ip != nil {
+        if isDisallowedWebhookIP(ip) {
+            return ErrDisallowedWebhookTarget
+        }
+        return nil
+    }
+    addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+    if err != nil {
+        return nil
+    }
+    for _, addr := range addrs {
+        if isDisallowedWebhookIP(addr.IP) {
+            return ErrDisallowedWebhookTarget
+        }
+    }
+    return nil
+}
+
+// controlWebhookDial は実際の接続先アドレスを検証し、DNSリバインディングやリダイレクトによる内部ネットワークへの接続を防ぎます
+func controlWebhookDial(network string, address string, _ syscall.RawConn) error {
+    host, _, err := net.SplitHostPort(address)
+    if err != nil {
+        return err
+    }
+    ip := net.ParseIP(host)
+    if ip == nil || isDisallowedWebhookIP(ip) {
+        return ErrDisallowedWebhookTarget
+    }
+    return nil
+}
+
+// newWebhookHTTPClient は接続時に宛先アドレスを検証する HTTP クライアントを作成します
+func newWebhookHTTPClient() *http.Client {
+    dialer := &net.Dialer{
+        Timeout: 5 * time.Second,
+        Control: controlWebhookDial,
+    }
+    transport := &http.Transport{
+        // プロキシ経由では接続先の検証ができないため使用しない
+        Proxy:               nil,
+        DialContext:         dialer.DialContext,
+        TLSHandshakeTimeout: 5 * time.Second,
+    }
+    return &http.Client{Timeout: 10 * time.Second, Transport: transport}
+}
@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"my-us-stock-backend/app/database/model"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// 署名付きWebhookのヘッダー
+const (
+    HeaderTimestamp = "X-MyUsStock-Timestamp"
+    HeaderSignature = "X-MyUsStock-Signature"
+)
+
+// WebhookSender は Webhook・Slack・Discord へHTTPで通知を送信します
+type WebhookSender struct {
+    httpClient *http.Client
+}
+
+// NewWebhookSender は新しい WebhookSender インスタンスを作成します
+// client を省略した場合は内部ネットワーク宛ての接続を拒否するクライアントを使用します
+func NewWebhookSender(client *http.Client) *WebhookSender {
+    if client == nil {
+        client = newWebhookHTTPClient()
+    }
+    return &WebhookSender{httpClient: client}
+}
+
+// Send は通知先の種類に応じたペイロードをPOSTします
+// WEBHOOKの場合は「タイムスタンプ.本文」のHMAC-SHA256署名をヘッダーに付与します
+func (s *WebhookSender) Send(ctx context.Context, channel model.NotificationChannel, delivery model.NotificationDelivery) error {
+    payload, err := createWebhookPayload(channel.Type, delivery)
+    if err != nil {
+        return err
+    }
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, channel.Target, bytes.NewReader(payload))
+    if err != nil {
+        return err
+    }
+    req.Header.Set("Content-Type", "application/json")
+    if channel.Type == ChannelTypeWebhook {
+        timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+        req.Header.Set(HeaderTimestamp, timestamp)
+        req.Header.Set(HeaderSignature, "sha256="+signPayload(channel.Secret, timestamp, payload))
+    }
+
+    resp, err := s.httpClient.Do(req)
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+        // 応答本文には通知先の内部情報が含まれ得るため、ステータスコードのみを記録する
+        return fmt.Errorf("通知先がステータス %d を返しました", resp.StatusCode)
+    }
+    return nil
+}
@@ -0,0 +1,119 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"my-us-stock-backend/app/database/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var testDelivery = model.NotificationDelivery{
+    Model:   gorm.Model{ID: 10, CreatedAt: time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)},
+    Event:   EventAlertTriggered,
+    Subject: "AAPL の株価が 170 以下になりました",
+    Body:    "現在値: 168.5",
+}
+
+// 汎用Webhookは署名を検証できる
+func TestWebhookSenderSignedWebhook(t *testing.T) {
+    var receivedBody []byte
+    var receivedHeader http.Header
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        receivedBody, _ = io.ReadAll(r.Body)
+        receivedHeader = r.Header
+        w.WriteHeader(http.StatusNoContent)
+    }))
+    defer server.Close()
+
+    sender := NewWebhookSender(server.Client())
+    channel := model.NotificationChannel{Type: ChannelTypeWebhook, Target: server.URL, Secret: "secret-0123456789"}
+    err := sender.Send(context.Background(), channel, testDelivery)
+
+    assert.NoError(t, err)
+    timestamp := receivedHeader.Get(HeaderTimestamp)
+    assert.NotEmpty(t, timestamp)
+    assert.Equal(t, "sha256="+signPayload("secret-0123456789", timestamp, receivedBody), receivedHeader.Get(HeaderSignature))
+
+    var payload webhookPayload
+    assert.NoError(t, json.Unmarshal(receivedBody, &payload))
+    assert.Equal(t, uint(10), payload.ID)
+    assert.Equal(t, EventAlertTriggered, payload.Event)
+    assert.Equal(t, "2024-01-05T09:00:00Z", payload.CreatedAt)
+}
+
+// Slack・Discordはそれぞれの形式で送信し、署名は付与しない
+func TestWebhookSenderSlackAndDiscord(t *testing.T) {
+    var payload map[string]string
+    var signature string
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        payload = map[string]string{}
+        json.NewDecoder(r.Body).Decode(&payload)
+        signature = r.Header.Get(HeaderSignature)
+    }))
+    defer server.Close()
+
+    sender := NewWebhookSender(server.Client())
+
+    err := sender.Send(context.Background(), model.NotificationChannel{Type: ChannelTypeSlack, Target: server.URL}, testDelivery)
+    assert.NoError(t, err)
+    assert.Equal(t, "*AAPL の株価が 170 以下になりました*\n現在値: 168.5", payload["text"])
+    assert.Empty(t, signature)
+
+    err = sender.Send(context.Background(), model.NotificationChannel{Type: ChannelTypeDiscord, Target: server.URL}, testDelivery)
+    assert.NoError(t, err)
+    assert.Equal(t, "**AAPL の株価が 170 以下になりました**\n現在値: 168.5", payload["content"])
+}
+
+func TestWebhookSenderErrorStatus(t *testing.T) {
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        http.Error(w, "invalid_token", http.StatusForbidden)
+    }))
+    defer server.Close()
+
+    sender := NewWebhookSender(server.Client())
+    err := sender.Send(context.Background(), model.NotificationChannel{Type: ChannelTypeSlack, Target: server.URL}, testDelivery)
+
+    assert.Error(t, err)
+    assert.Contains(t, err.Error(), "403")
+    assert.NotContains(t, err.Error(), "invalid_token")
+}
+
+// クライアントを指定しない場合は内部ネットワーク宛てに接続しない
+func TestWebhookSenderRejectsInternalAddress(t *testing.T) {
+    requested := false
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        requested = true
+    }))
+    defer server.Close()
+
+    sender := NewWebhookSender(nil)
+    err := sender.Send(context.Background(), model.NotificationChannel{Type: ChannelTypeSlack, Target: server.URL}, testDelivery)
+
+    assert.ErrorIs(t, err, ErrDisallowedWebhookTarget)
+    assert.False(t, requested)
+}
+
+func TestValidateWebhookTarget(t *testing.T) {
+    disallowed := []string{
+        "http://127.0.0.1:8080/hook",
+        "http://localhost/hook",
+        "http://169.254.169.254/latest/meta-data/",
+        "http://metadata.google.internal/computeMetadata/v1/",
+        "https://10.0.0.5/hook",
+        "https://192.168.1.10/hook",
+        "http://[::1]/hook",
+        "http://[fd00::1]/hook",
+        "http://100.64.0.1/hook",
+    }
+    for _, target := range disallowed {
+        assert.ErrorIs(t, ValidateWebhookTarget(context.Background(), target), ErrDisallowedWebhookTarget, target)
+    }
+    assert.NoError(t, ValidateWebhookTarget(context.Background(), "https://93.184.216.34/hook"))
+}
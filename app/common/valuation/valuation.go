@@ -0,0 +1,113 @@
+package valuation
+
+import (
+	"context"
+	"fmt"
+	"my-us-stock-backend/app/database/model"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	repoMarketCrypto "my-us-stock-backend/app/repository/market-price/crypto"
+	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
+	repoFundPrice "my-us-stock-backend/app/repository/market-price/fund"
+	"sync"
+)
+
+// Valuation は保有資産を現在の価格で円換算して評価します
+// 資産総額の登録・取得と共有リンクの公開で同じ評価額になるよう、評価方法をここにまとめる
+type Valuation struct {
+    MarketPriceRepo marketPrice.MarketPriceRepository
+    CurrencyRepo repoCurrency.CurrencyRepository
+    MarketCryptoRepo repoMarketCrypto.CryptoRepository
+    FundPriceRepo repoFundPrice.FundPriceRepository
+}
+
+// NewValuation は Valuation の新しいインスタンスを作成します
+func NewValuation(marketPriceRepo marketPrice.MarketPriceRepository, currencyRepo repoCurrency.CurrencyRepository, marketCryptoRepo repoMarketCrypto.CryptoRepository, fundPriceRepo repoFundPrice.FundPriceRepository) *Valuation {
+    return &Valuation{MarketPriceRepo: marketPriceRepo, CurrencyRepo: currencyRepo, MarketCryptoRepo: marketCryptoRepo, FundPriceRepo: fundPriceRepo}
+}
+
+// StockValues は米国株式の評価額(円)を保有資産と同じ順に返します(現在値が見つからない銘柄がある場合はエラー)
+func (v *Valuation) StockValues(ctx context.Context, modelStocks []model.UsStock) ([]float64, error) {
+    usStockCodes := make([]string, len(modelStocks))
+    for i, modelStock := range modelStocks {
+        usStockCodes[i] = modelStock.Code
+    }
+
+    // マーケットプライスを取得してマップに変換
+    marketPrices, err := v.MarketPriceRepo.FetchMarketPriceList(ctx, usStockCodes)
+    if err != nil {
+        return nil, err
+    }
+    priceMap := make(map[string]float64, len(marketPrices))
+    for _, mp := range marketPrices {
+        priceMap[mp.Ticker] = mp.CurrentPrice
+    }
+
+    // 現在のドル円を取得
+    currentUsdJpy, err := v.CurrencyRepo.FetchCurrentUsdJpy(ctx)
+    if err != nil {
+        return nil, err
+    }
+
+    values := make([]float64, len(modelStocks))
+    for i, modelStock := range modelStocks {
+        currentPrice, ok := priceMap[modelStock.Code]
+        if !ok {
+            return nil, fmt.Errorf("market price not found for stock code: %s", modelStock.Code)
+        }
+        values[i] = modelStock.Quantity * currentPrice * currentUsdJpy
+    }
+    return values, nil
+}
+
+// FundValues は日本投資信託の評価額(円)を保有資産と同じ順に返します(基準価額が取得できない銘柄がある場合はエラー)
+func (v *Valuation) FundValues(ctx context.Context, modelFunds []model.JapanFund) ([]float64, error) {
+    return evaluateConcurrently(modelFunds, func(mf model.JapanFund) (float64, error) {
+        fundPrice, err := v.FundPriceRepo.FindFundPriceByCode(ctx, mf.Code)
+        if err != nil {
+            return 0, err
+        }
+        return mf.GetPriceTotal * fundPrice.Price / mf.GetPrice, nil
+    })
+}
+
+// CryptoValues は仮想通貨の評価額(円)を保有資産と同じ順に返します(現在価格が取得できない銘柄がある場合はエラー)
+func (v *Valuation) CryptoValues(ctx context.Context, modelCryptos []model.Crypto) ([]float64, error) {
+    return evaluateConcurrently(modelCryptos, func(mc model.Crypto) (float64, error) {
+        cryptoPrice, err := v.MarketCryptoRepo.FetchCryptoPrice(mc.Code)
+        if err != nil {
+            return 0, err
+        }
+        return mc.Quantity * cryptoPrice.Price, nil
+    })
+}
+
+// Sum は評価額の合計を返します
+func Sum(values []float64) float64 {
+    var total float64
+    for _, value := range values {
+        total += value
+    }
+    return total
+}
+
+// 銘柄ごとに価格を並行して取得して評価する(1件でも失敗した場合はエラー)
+func evaluateConcurrently[T any](holdings []T, evaluate func(T) (float64, error)) ([]float64, error) {
+    values := make([]float64, len(holdings))
+    errors := make([]error, len(holdings))
+    var wg sync.WaitGroup
+    for i, holding := range holdings {
+        wg.Add(1)
+        go func(i int, holding T) {
+            defer wg.Done()
+            values[i], errors[i] = evaluate(holding)
+        }(i, holding)
+    }
+    wg.Wait()
+
+    for _, err := range errors {
+        if err != nil {
+            return nil, err
+        }
+    }
+    return values, nil
+}
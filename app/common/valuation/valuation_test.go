@@ -0,0 +1,63 @@
+package valuation
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/database/model"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	repoMarketCrypto "my-us-stock-backend/app/repository/market-price/crypto"
+	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
+	repoFundPrice "my-us-stock-backend/app/repository/market-price/fund"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStockValues(t *testing.T) {
+    marketPriceRepo := marketPrice.NewMockMarketPriceRepository()
+    currencyRepo := repoCurrency.NewMockCurrencyRepository()
+    v := NewValuation(marketPriceRepo, currencyRepo, repoMarketCrypto.NewMockCryptoRepository(), repoFundPrice.NewMockFundPriceRepository())
+    marketPriceRepo.On("FetchMarketPriceList", mock.Anything, []string{"AAPL", "KO"}).Return([]marketPrice.MarketPriceDto{
+        {Ticker: "KO", CurrentPrice: 60},
+        {Ticker: "AAPL", CurrentPrice: 150},
+    }, nil)
+    currencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(100.0, nil)
+
+    values, err := v.StockValues(context.Background(), []model.UsStock{{Code: "AAPL", Quantity: 10}, {Code: "KO", Quantity: 5}})
+
+    assert.NoError(t, err)
+    assert.Equal(t, []float64{150000, 30000}, values)
+    assert.Equal(t, 180000.0, Sum(values))
+
+    // 現在値が見つからない銘柄がある場合はエラー
+    marketPriceRepo.On("FetchMarketPriceList", mock.Anything, []string{"MSFT"}).Return([]marketPrice.MarketPriceDto{}, nil)
+    _, err = v.StockValues(context.Background(), []model.UsStock{{Code: "MSFT", Quantity: 1}})
+    assert.EqualError(t, err, "market price not found for stock code: MSFT")
+}
+
+func TestFundAndCryptoValues(t *testing.T) {
+    marketCryptoRepo := repoMarketCrypto.NewMockCryptoRepository()
+    fundPriceRepo := repoFundPrice.NewMockFundPriceRepository()
+    v := NewValuation(marketPrice.NewMockMarketPriceRepository(), repoCurrency.NewMockCurrencyRepository(), marketCryptoRepo, fundPriceRepo)
+    fundPriceRepo.On("FindFundPriceByCode", mock.Anything, "0331418A").Return(&model.FundPrice{Code: "0331418A", Price: 15000}, nil)
+    fundPriceRepo.On("FindFundPriceByCode", mock.Anything, "03311187").Return(&model.FundPrice{Code: "03311187", Price: 20000}, nil)
+    marketCryptoRepo.On("FetchCryptoPrice", "btc").Return(&repoMarketCrypto.Crypto{Name: "btc", Price: 2000000}, nil)
+    marketCryptoRepo.On("FetchCryptoPrice", "eth").Return((*repoMarketCrypto.Crypto)(nil), errors.New("timeout"))
+
+    // 保有資産と同じ順に返す
+    values, err := v.FundValues(context.Background(), []model.JapanFund{
+        {Code: "0331418A", GetPrice: 10000, GetPriceTotal: 100000},
+        {Code: "03311187", GetPrice: 10000, GetPriceTotal: 50000},
+    })
+    assert.NoError(t, err)
+    assert.Equal(t, []float64{150000, 100000}, values)
+
+    values, err = v.CryptoValues(context.Background(), []model.Crypto{{Code: "btc", Quantity: 0.1}})
+    assert.NoError(t, err)
+    assert.Equal(t, []float64{200000}, values)
+
+    // 1件でも価格が取得できない場合はエラー
+    _, err = v.CryptoValues(context.Background(), []model.Crypto{{Code: "btc", Quantity: 0.1}, {Code: "eth", Quantity: 1}})
+    assert.EqualError(t, err, "timeout")
+}
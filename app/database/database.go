@@ -1,6 +1,7 @@
 package database
 
 import (
+	"fmt"
 	"log"
 	"my-us-stock-backend/app/database/model"
 	"os"
@@ -19,13 +20,55 @@ func Connect() *gorm.DB {
 	return db
 }
 
-// モデルに基づいてテーブルを作成または更新
-func Migrate(db *gorm.DB) {
-	db.AutoMigrate(&model.Crypto{})
-	db.AutoMigrate(&model.TotalAsset{})
-	db.AutoMigrate(&model.FixedIncomeAsset{})
-	db.AutoMigrate(&model.JapanFund{})
-	db.AutoMigrate(&model.UsStock{})
-	db.AutoMigrate(&model.FundPrice{})
-	db.AutoMigrate(&model.User{})
+// Migrate はモデルに基づいてテーブルを作成または更新し、既存のデータを移行します
+// いずれかの手順が失敗した場合はそこで中断してエラーを返します
+func Migrate(db *gorm.DB) error {
+	// 保有資産の口座を必須にする前に、口座の列を NULL 許容で追加して口座が未設定の保有資産を既定の口座に移す
+	if err := db.AutoMigrate(&model.BrokerageAccount{}); err != nil {
+		return err
+	}
+	if err := addNullableAccountIds(db); err != nil {
+		return fmt.Errorf("保有資産への口座の列の追加に失敗しました: %w", err)
+	}
+	if err := backfillDefaultAccounts(db); err != nil {
+		return fmt.Errorf("既定の口座への移行に失敗しました: %w", err)
+	}
+	if err := backfillFixedIncomeCurrencies(db); err != nil {
+		return fmt.Errorf("固定利回り資産の通貨の移行に失敗しました: %w", err)
+	}
+	if err := db.AutoMigrate(
+		&model.Crypto{},
+		&model.TotalAsset{},
+		&model.FixedIncomeAsset{},
+		&model.JapanFund{},
+		&model.UsStock{},
+		&model.FundPrice{},
+		&model.FundPriceHistory{},
+		&model.FundNavFailure{},
+	); err != nil {
+		return err
+	}
+	// 日次価格の履歴を記録する前に登録された投資信託の価格を履歴に引き継ぐ
+	if err := backfillFundPriceHistories(db); err != nil {
+		return fmt.Errorf("投資信託の価格履歴の移行に失敗しました: %w", err)
+	}
+	return db.AutoMigrate(
+		&model.User{},
+		&model.Instrument{},
+		&model.PriceHistory{},
+		&model.CalendarToken{},
+		&model.Watchlist{},
+		&model.AlertRule{},
+		&model.TriggeredAlert{},
+		&model.NotificationChannel{},
+		&model.NotificationDelivery{},
+		&model.DailyReport{},
+		&model.DividendReceipt{},
+		&model.StockSale{},
+		&model.TotalAssetAccount{},
+		&model.NisaPurchase{},
+		&model.Household{},
+		&model.HouseholdMember{},
+		&model.ShareToken{},
+	)
 }
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"my-us-stock-backend/app/database/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 口座(account_id)を参照している保有資産のテーブル
+var accountHoldingTables = []string{"us_stocks", "cryptos", "japan_funds", "fixed_income_assets"}
+
+// addNullableAccountIds は口座を必須にする前に作成された保有資産のテーブルに account_id 列を NULL 許容で追加します
+// 登録済みの行がある状態で NOT NULL の列は追加できないため、既定の口座に移した後の AutoMigrate で NOT NULL にする
+func addNullableAccountIds(db *gorm.DB) error {
+	for _, table := range accountHoldingTables {
+		if !db.Migrator().HasTable(table) || db.Migrator().HasColumn(table, "account_id") {
+			continue
+		}
+		if err := db.Exec("ALTER TABLE ? ADD COLUMN ? bigint", clause.Table{Name: table}, clause.Column{Name: "account_id"}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillDefaultAccounts は口座が設定されていない保有資産(論理削除済みを含む)を各ユーザーの既定の口座に移します
+func backfillDefaultAccounts(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range accountHoldingTables {
+			if !tx.Migrator().HasTable(table) {
+				continue
+			}
+			var userIds []uint
+			if err := tx.Table(table).Where("account_id IS NULL").Distinct().Pluck("user_id", &userIds).Error; err != nil {
+				return err
+			}
+			for _, userId := range userIds {
+				accountId, err := findOrCreateDefaultAccount(tx, userId)
+				if err != nil {
+					return err
+				}
+				if err := tx.Table(table).Where("account_id IS NULL AND user_id = ?", userId).Update("account_id", accountId).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// findOrCreateDefaultAccount はユーザーの既定の口座のIDを返します(まだない場合は作成する)
+func findOrCreateDefaultAccount(db *gorm.DB, userId uint) (uint, error) {
+	var account model.BrokerageAccount
+	if err := db.Where("user_id = ? AND is_default = ?", userId, true).Order("id").Limit(1).Find(&account).Error; err != nil {
+		return 0, err
+	}
+	if account.ID != 0 {
+		return account.ID, nil
+	}
+	account = model.BrokerageAccount{Name: model.DefaultBrokerageAccountName, Type: model.DefaultBrokerageAccountType, IsDefault: true, UserId: userId}
+	if err := db.Create(&account).Error; err != nil {
+		return 0, err
+	}
+	return account.ID, nil
+}
+
+// backfillFixedIncomeCurrencies は通貨を登録する前に作成された固定利回り資産に通貨を設定します
+// 列の既定値(JPY)のまま追加するとドル建ての資産も円建てになるため、NULL 許容で追加して購入時為替の有無から判断し、AutoMigrate で NOT NULL にする
+func backfillFixedIncomeCurrencies(db *gorm.DB) error {
+	table := "fixed_income_assets"
+	if !db.Migrator().HasTable(table) {
+		return nil
+	}
+	if !db.Migrator().HasColumn(table, "currency") {
+		if err := db.Exec("ALTER TABLE ? ADD COLUMN ? varchar(3)", clause.Table{Name: table}, clause.Column{Name: "currency"}).Error; err != nil {
+			return err
+		}
+	}
+	return db.Table(table).Where("currency IS NULL").Update("currency", gorm.Expr("CASE WHEN usd_jpy IS NOT NULL THEN ? ELSE ? END", "USD", "JPY")).Error
+}
+
+// backfillFundPriceHistories は日次価格の履歴がない投資信託について、現在の価格を履歴に記録します
+// 履歴の記録を始める前に登録された価格を引き継ぐため、基準日(手動登録の場合は最終更新日)の価格とする
+func backfillFundPriceHistories(db *gorm.DB) error {
+	var fundPrices []model.FundPrice
+	if err := db.Where("code NOT IN (?)", db.Model(&model.FundPriceHistory{}).Select("code")).Find(&fundPrices).Error; err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, fundPrice := range fundPrices {
+			date := fundPrice.UpdatedAt
+			if fundPrice.NavDate != nil {
+				date = *fundPrice.NavDate
+			}
+			history := model.FundPriceHistory{
+				Code:  fundPrice.Code,
+				Date:  time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+				Price: fundPrice.Price,
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&history).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
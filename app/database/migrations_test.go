@@ -0,0 +1,139 @@
+package database
+
+import (
+	"my-us-stock-backend/app/database/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// 口座が必須になる前の米国株式のテーブル
+type legacyUsStock struct {
+	gorm.Model
+	Code     string  `gorm:"size:6;not null"`
+	GetPrice float64 `gorm:"type:float"`
+	Quantity float64 `gorm:"type:float"`
+	Sector   string  `gorm:"size:255;not null"`
+	UsdJpy   float64 `gorm:"type:float"`
+	UserId   uint    `gorm:"not null;index"`
+}
+
+func (legacyUsStock) TableName() string {
+	return "us_stocks"
+}
+
+// 通貨・口座を登録する前の固定利回り資産のテーブル
+type legacyFixedIncomeAsset struct {
+	gorm.Model
+	Code          string   `gorm:"size:255;not null"`
+	GetPriceTotal float64  `gorm:"type:float"`
+	DividendRate  float64  `gorm:"type:float"`
+	UsdJpy        *float64 `gorm:"type:float"`
+	UserId        uint     `gorm:"not null;index"`
+}
+
+func (legacyFixedIncomeAsset) TableName() string {
+	return "fixed_income_assets"
+}
+
+// テスト用のデータベース設定
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	return db
+}
+
+// 登録済みの保有資産がある状態で口座を必須にできる
+func TestMigrateBackfillsDefaultAccounts(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, db.AutoMigrate(&legacyUsStock{}))
+	db.Create(&legacyUsStock{Code: "AAPL", Quantity: 10, Sector: "IT", UserId: 1})
+	db.Create(&legacyUsStock{Code: "MSFT", Quantity: 5, Sector: "IT", UserId: 1})
+	deleted := legacyUsStock{Code: "KO", Quantity: 3, Sector: "Food", UserId: 2}
+	db.Create(&deleted)
+	db.Delete(&deleted)
+
+	assert.NoError(t, Migrate(db))
+
+	// 口座が未設定の保有資産(論理削除済みを含む)はユーザーごとの既定の口座に移す
+	var accounts []model.BrokerageAccount
+	db.Where("is_default = ?", true).Order("user_id").Find(&accounts)
+	assert.Len(t, accounts, 2)
+	assert.Equal(t, model.DefaultBrokerageAccountName, accounts[0].Name)
+	var usStocks []model.UsStock
+	db.Unscoped().Order("id").Find(&usStocks)
+	assert.Len(t, usStocks, 3)
+	assert.Equal(t, accounts[0].ID, usStocks[0].AccountId)
+	assert.Equal(t, accounts[0].ID, usStocks[1].AccountId)
+	assert.Equal(t, accounts[1].ID, usStocks[2].AccountId)
+
+	// 移行後は口座が必須になる
+	columnTypes, err := db.Migrator().ColumnTypes(&model.UsStock{})
+	assert.NoError(t, err)
+	for _, columnType := range columnTypes {
+		if columnType.Name() == "account_id" {
+			nullable, _ := columnType.Nullable()
+			assert.False(t, nullable)
+		}
+	}
+
+	// 再度実行しても既定の口座は増えない
+	assert.NoError(t, Migrate(db))
+	var count int64
+	db.Model(&model.BrokerageAccount{}).Count(&count)
+	assert.Equal(t, int64(2), count)
+}
+
+// 購入時為替が登録されている既存の固定利回り資産はドル建てにする
+func TestMigrateBackfillsFixedIncomeCurrencies(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, db.AutoMigrate(&legacyFixedIncomeAsset{}))
+	usdJpy := 150.0
+	db.Create(&legacyFixedIncomeAsset{Code: "米国債", GetPriceTotal: 480, DividendRate: 4.5, UsdJpy: &usdJpy, UserId: 1})
+	db.Create(&legacyFixedIncomeAsset{Code: "個人向け国債", GetPriceTotal: 1000000, DividendRate: 0.5, UserId: 1})
+
+	assert.NoError(t, Migrate(db))
+
+	var assets []model.FixedIncomeAsset
+	db.Order("id").Find(&assets)
+	assert.Len(t, assets, 2)
+	assert.Equal(t, "USD", assets[0].Currency)
+	assert.Equal(t, "JPY", assets[1].Currency)
+
+	// 移行後に登録した資産は既定値の円建てになる
+	db.Create(&model.FixedIncomeAsset{Code: "社債", GetPriceTotal: 500000, AccountId: assets[1].AccountId, UserId: 1})
+	var created model.FixedIncomeAsset
+	db.Where("code = ?", "社債").First(&created)
+	assert.Equal(t, "JPY", created.Currency)
+}
+
+// 履歴のない投資信託は現在の価格を基準日(手動登録の場合は最終更新日)の履歴として引き継ぎ、再実行しても重複しない
+func TestMigrateBackfillsFundPriceHistories(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, Migrate(db))
+	navDate := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	db.Create(&model.FundPrice{Name: "Backfill Nav", Code: "BKF001", Price: 12000, NavDate: &navDate})
+	db.Create(&model.FundPrice{Name: "Backfill Manual", Code: "BKF002", Price: 8000})
+	db.Create(&model.FundPrice{Name: "Backfill Existing", Code: "BKF003", Price: 9000})
+	db.Create(&model.FundPriceHistory{Code: "BKF003", Date: navDate, Price: 8900})
+
+	assert.NoError(t, Migrate(db))
+	assert.NoError(t, Migrate(db))
+
+	var navHistories, manualHistories, existingHistories []model.FundPriceHistory
+	db.Where("code = ?", "BKF001").Find(&navHistories)
+	assert.Len(t, navHistories, 1)
+	assert.Equal(t, 12000.0, navHistories[0].Price)
+	assert.True(t, navHistories[0].Date.Equal(navDate))
+	db.Where("code = ?", "BKF002").Find(&manualHistories)
+	assert.Len(t, manualHistories, 1)
+	assert.Equal(t, 8000.0, manualHistories[0].Price)
+	db.Where("code = ?", "BKF003").Find(&existingHistories)
+	assert.Len(t, existingHistories, 1)
+	assert.Equal(t, 8900.0, existingHistories[0].Price)
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AlertRule は価格・為替・配当利回りのアラート条件を表します。
+// TargetType: STOCK_PRICE / DIVIDEND_YIELD / FX_RATE / CRYPTO_PRICE
+// Condition: ABOVE(閾値以上) / BELOW(閾値以下)
+// Mode: ONCE(一度通知したら無効化) / COOLDOWN(通知後、指定時間は再通知しない)
+type AlertRule struct {
+    gorm.Model
+	TargetType      string     `gorm:"size:20;not null"`
+	Symbol          string     `gorm:"size:10;not null"`
+	Condition       string     `gorm:"size:10;not null"`
+	Threshold       float64    `gorm:"type:float"`
+	Mode            string     `gorm:"size:10;not null"`
+	CooldownMinutes int
+	Enabled         bool
+	LastTriggeredAt *time.Time
+	UserId          uint       `gorm:"not null;index"`
+}
+
+// TriggeredAlert はアラート条件を満たした履歴を表します。
+type TriggeredAlert struct {
+    gorm.Model
+	AlertRuleId uint      `gorm:"not null;index"`
+	TargetType  string    `gorm:"size:20;not null"`
+	Symbol      string    `gorm:"size:10;not null"`
+	Condition   string    `gorm:"size:10;not null"`
+	Threshold   float64   `gorm:"type:float"`
+	Value       float64   `gorm:"type:float"`
+	TriggeredAt time.Time `gorm:"not null"`
+	UserId      uint      `gorm:"not null;index"`
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"gorm.io/gorm"
+)
+
+// 口座を指定せずに登録した保有資産の登録先となる既定の口座
+const (
+	DefaultBrokerageAccountName = "メイン口座"
+	DefaultBrokerageAccountType = "TAXABLE"
+)
+
+// BrokerageAccount は保有資産を管理する証券口座を表します。
+// Type: NISA_GROWTH(NISA成長投資枠) / NISA_TSUMITATE(NISAつみたて投資枠) / TAXABLE(特定口座) / IDECO / OVERSEAS(海外証券口座)
+type BrokerageAccount struct {
+    gorm.Model
+	Name   string `gorm:"size:255;not null"`
+	Type   string `gorm:"size:20;not null"`
+	IsDefault bool `gorm:"not null;default:false"`// 口座を指定せずに登録した保有資産の登録先
+	UserId uint   `gorm:"not null;index"`
+}
+
+// TotalAssetAccount は資産スナップショットの口座別の内訳を表します。
+// AccountId: 保有資産の口座を必須にする前に作成した内訳のうち、口座を設定していない保有資産の内訳はnil
+type TotalAssetAccount struct {
+    gorm.Model
+	TotalAssetId     uint    `gorm:"not null;index"`
+	AccountId        *uint
+	Stock            float64 `gorm:"type:float"`// 円ベースで登録
+	Fund             float64 `gorm:"type:float"`// 円ベースで登録
+	Crypto           float64 `gorm:"type:float"`// 円ベースで登録
+	FixedIncomeAsset float64 `gorm:"type:float"`// 円ベースで登録
+	UserId           uint    `gorm:"not null;index"`
+}
@@ -0,0 +1,10 @@
+package model
+
+import "gorm.io/gorm"
+
+// CalendarToken はカレンダーアプリからiCalendarフィードを購読するためのトークンを表します。
+type CalendarToken struct {
+    gorm.Model
+    UserId uint   `gorm:"not null;uniqueIndex" json:"userId"`
+    Token  string `gorm:"size:64;not null;uniqueIndex" json:"token"`
+}
@@ -10,5 +10,6 @@ type Crypto struct {
 	Code   string `gorm:"size:10;not null"`
 	GetPrice float64 `gorm:"type:float"`
 	Quantity float64 `gorm:"type:float"`
+	AccountId uint `gorm:"not null;index"`// 証券口座
 	UserId uint `gorm:"not null;index"`
 }
@@ -0,0 +1,15 @@
+package model
+
+import (
+	"gorm.io/gorm"
+)
+
+// DailyReport は資産スナップショット登録後に生成する日次サマリーを表します。
+// Date: 対象日(YYYY-MM-DD, UTC)
+// Content: サマリー本体(JSON)
+type DailyReport struct {
+    gorm.Model
+	UserId  uint   `gorm:"not null;uniqueIndex:idx_daily_report_user_date"`
+	Date    string `gorm:"size:10;not null;uniqueIndex:idx_daily_report_user_date"`
+	Content string `gorm:"type:text"`
+}
@@ -1,11 +1,16 @@
 package model
 
 import (
+	"time"
+
 	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
 // FixedIncomeFund は債券・不動産・クラウドファンディングなど、固定された収入や利回りを持つ資産を表します。
+// CouponFrequency: AT_MATURITY(満期一括) / ANNUAL / SEMI_ANNUAL / QUARTERLY / MONTHLY(未設定の場合は配当支払い月から判断する)
+// Status: ACTIVE(保有中) / MATURED(満期償還済み)
+// ValuationMode: FACE(額面) / AMORTIZED_COST(償却原価) / MARKET(時価)
 type FixedIncomeAsset struct {
     gorm.Model
 	Code   string  `gorm:"size:255;not null"`
@@ -13,5 +18,15 @@ type FixedIncomeAsset struct {
 	DividendRate float64 `gorm:"type:float"`
 	UsdJpy   *float64 `gorm:"type:float"`
 	PaymentMonth  pq.Int64Array `gorm:"type:int[]"`
+	IssueDate *time.Time // 発行日
+	MaturityDate *time.Time `gorm:"index"`// 満期日(未設定の場合は満期なし)
+	CouponFrequency string `gorm:"size:20"`
+	Currency string `gorm:"size:3;not null;default:JPY"`
+	RedemptionAmount *float64 `gorm:"type:float"`// 償還金額(未設定の場合は取得価格合計)
+	Status string `gorm:"size:20;not null;default:ACTIVE;index"`
+	ValuationMode string `gorm:"size:20;not null;default:AMORTIZED_COST"`
+	CleanPrice *float64 `gorm:"type:float"`// 額面100あたりの時価(時価評価で用いる)
+	PurchaseDate *time.Time // 購入日(未設定の場合は登録日)
+	AccountId uint `gorm:"not null;index"`// 証券口座
 	UserId uint `gorm:"not null;index"`
 }
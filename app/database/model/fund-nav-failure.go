@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FundNavFailure は価格が未登録の保有中の投資信託について、基準価額の自動取得に失敗したことを表します。
+// 価格が登録済みの投資信託の失敗は FundPrice に記録します。
+type FundNavFailure struct {
+    gorm.Model
+	Code     string    `gorm:"size:10;not null;uniqueIndex"`
+	Reason   string    `gorm:"size:255"`// 直近の自動取得に失敗した理由
+	FailedAt time.Time `gorm:"not null"`// 直近の自動取得に失敗した日時
+}
@@ -0,0 +1,15 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FundPriceHistory は投資信託の日次価格(基準価額)の履歴を表します。
+type FundPriceHistory struct {
+    gorm.Model
+	Code  string    `gorm:"size:10;not null;uniqueIndex:idx_fund_price_histories_code_date"`
+	Date  time.Time `gorm:"not null;uniqueIndex:idx_fund_price_histories_code_date"`
+	Price float64   `gorm:"type:float"`
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
@@ -10,4 +12,8 @@ type FundPrice struct {
 	Name   string  `gorm:"size:255;not null"`
 	Code   string  `gorm:"size:10;not null"`
 	Price float64 `gorm:"type:float"`
+	NavDate *time.Time // 基準価額の基準日(手動登録の場合はnil)
+	NavFetchedAt *time.Time // 基準価額を最後に自動取得した日時
+	NavFetchError string `gorm:"size:255"` // 直近の自動取得に失敗した理由(成功した場合は空)
+	NavFetchFailedAt *time.Time // 直近の自動取得に失敗した日時
 }
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Household は複数のユーザーで資産を共有する世帯を表します。
+// UserId: 世帯を作成したユーザー(オーナー)
+type Household struct {
+    gorm.Model
+	Name   string `gorm:"size:255;not null"`
+	UserId uint   `gorm:"not null;index"`
+}
+
+// HouseholdMember は世帯のメンバーと権限を表します。
+// Role: OWNER(オーナー) / EDITOR(編集者) / VIEWER(閲覧者)
+// AcceptedAt: 招待を承認した日時(nilの場合は承認待ちの招待で、資産は共有されない)
+type HouseholdMember struct {
+    gorm.Model
+	HouseholdId uint       `gorm:"not null;index"`
+	Role        string     `gorm:"size:10;not null"`
+	UserId      uint       `gorm:"not null;index"`
+	AcceptedAt  *time.Time
+}
@@ -0,0 +1,16 @@
+package model
+
+import (
+	"gorm.io/gorm"
+)
+
+// Instrument は銘柄の企業情報(会社名・セクターなど)のキャッシュを表します。
+type Instrument struct {
+    gorm.Model
+	Ticker   string `gorm:"size:10;not null;uniqueIndex"`
+	Name     string `gorm:"size:255"`
+	Sector   string `gorm:"size:255"`
+	Industry string `gorm:"size:255"`
+	Country  string `gorm:"size:255"`
+	Exchange string `gorm:"size:255"`
+}
@@ -11,5 +11,6 @@ type JapanFund struct {
 	Code   string  `gorm:"size:10;not null"`
 	GetPriceTotal float64 `gorm:"type:float"`
 	GetPrice float64 `gorm:"type:float"`
+	AccountId uint `gorm:"not null;index"`// 証券口座
 	UserId uint `gorm:"not null;index"`
 }
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"gorm.io/gorm"
+)
+
+// NisaPurchase はNISA口座での購入記録を表します(年間投資枠の利用額の計算に使用)。
+// Frame: GROWTH(成長投資枠) / TSUMITATE(つみたて投資枠)
+// HoldingType: US_STOCK / JAPAN_FUND
+type NisaPurchase struct {
+    gorm.Model
+	Year        int     `gorm:"not null;index"`
+	Frame       string  `gorm:"size:20;not null"`
+	HoldingType string  `gorm:"size:20;not null"`
+	Code        string  `gorm:"size:10;not null"`
+	Amount      float64 `gorm:"type:float"`// 円ベースで登録
+	AccountId   uint    `gorm:"not null;index"`
+	UserId      uint    `gorm:"not null;index"`
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NotificationChannel はユーザーが登録した通知先を表します。
+// Type: WEBHOOK(署名付きWebhook) / SLACK / DISCORD / EMAIL
+// Target: 通知先のURL(EMAILの場合はメールアドレス)
+type NotificationChannel struct {
+    gorm.Model
+	Type    string `gorm:"size:20;not null"`
+	Name    string `gorm:"size:100;not null"`
+	Target  string `gorm:"size:500;not null"`
+	Secret  string `gorm:"size:128"`
+	Enabled bool
+	UserId  uint   `gorm:"not null;index"`
+}
+
+// NotificationDelivery は通知の送信キューと送信履歴を表します。
+// Status: PENDING(送信待ち・再送待ち) / SENT(送信済み) / FAILED(再送上限に到達)
+type NotificationDelivery struct {
+    gorm.Model
+	ChannelId     uint                `gorm:"not null;index"`
+	Channel       NotificationChannel `gorm:"foreignKey:ChannelId"`
+	Event         string              `gorm:"size:30;not null"`
+	Subject       string              `gorm:"size:200;not null"`
+	Body          string              `gorm:"type:text"`
+	HTMLBody      string              `gorm:"type:text"`// メール用(空の場合はテキストのみ)
+	Status        string              `gorm:"size:10;not null;index"`
+	Attempts      int
+	NextAttemptAt time.Time           `gorm:"index"`
+	LastError     string              `gorm:"size:1000"`
+	SentAt        *time.Time
+	UserId        uint                `gorm:"not null;index"`
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PriceHistory は銘柄の日次価格(四本値)の履歴を表します。
+type PriceHistory struct {
+    gorm.Model
+	Ticker string    `gorm:"size:10;not null;uniqueIndex:idx_price_histories_ticker_date"`
+	Date   time.Time `gorm:"not null;uniqueIndex:idx_price_histories_ticker_date"`
+	Open   float64   `gorm:"type:float"`
+	High   float64   `gorm:"type:float"`
+	Low    float64   `gorm:"type:float"`
+	Close  float64   `gorm:"type:float"`
+	Volume float64   `gorm:"type:float"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ShareToken はポートフォリオを閲覧専用で公開するための共有リンクのトークンを表します。
+// Privacy: PERCENTAGE(構成比のみ公開) / AMOUNT(金額も公開)
+// TokenHash: トークンのSHA-256ハッシュ(トークン自体は作成時にのみ返却し、保存しない)
+type ShareToken struct {
+    gorm.Model
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex"`
+	Privacy   string    `gorm:"size:20;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UserId    uint      `gorm:"not null;index"`
+}
@@ -0,0 +1,31 @@
+package model
+
+import "gorm.io/gorm"
+
+// DividendReceipt は米国株の配当の受取記録を表します(確定申告の外国税額控除に使用)。
+// 金額はドル建て、国内源泉徴収税額のみ円建てで記録します。
+type DividendReceipt struct {
+    gorm.Model
+	Code        string  `gorm:"size:10;not null"`
+	PaymentDate string  `gorm:"size:10;not null;index"` // YYYY-MM-DD
+	Amount      float64 `gorm:"type:float"`             // 税引前の配当金(ドル)
+	ForeignTax  float64 `gorm:"type:float"`             // 外国(米国)源泉徴収税額(ドル)
+	DomesticTax float64 `gorm:"type:float"`             // 国内源泉徴収税額(円)
+	UsdJpy      float64 `gorm:"type:float"`             // 支払日のTTM
+	UserId      uint    `gorm:"not null;index"`
+}
+
+// StockSale は米国株の売却記録を表します(譲渡損益の計算に使用)。
+// 売却代金は売却日のTTM、取得費は取得日のTTMで円換算します。
+type StockSale struct {
+    gorm.Model
+	Code      string  `gorm:"size:10;not null"`
+	TradeDate string  `gorm:"size:10;not null;index"` // YYYY-MM-DD
+	Quantity  float64 `gorm:"type:float"`
+	SalePrice float64 `gorm:"type:float"` // 売却単価(ドル)
+	GetPrice  float64 `gorm:"type:float"` // 取得単価(ドル)
+	Fee       float64 `gorm:"type:float"` // 売却手数料(ドル)
+	UsdJpy    float64 `gorm:"type:float"` // 売却日のTTM
+	GetUsdJpy float64 `gorm:"type:float"` // 取得日のTTM
+	UserId    uint    `gorm:"not null;index"`
+}
@@ -13,5 +13,6 @@ type TotalAsset struct {
 	Fund float64 `gorm:"type:float"`// 円ベースで登録
 	Crypto float64 `gorm:"type:float"`// 円ベースで登録
 	FixedIncomeAsset float64 `gorm:"type:float"`// 円ベースで登録
+	UsdJpy float64 `gorm:"type:float"`// 登録時点のドル円(取得できなかった場合は0)
 	UserId uint `gorm:"not null;index"`
 }
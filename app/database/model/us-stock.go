@@ -12,5 +12,6 @@ type UsStock struct {
 	Quantity float64 `gorm:"type:float"`
 	Sector   string  `gorm:"size:255;not null"`
 	UsdJpy   float64 `gorm:"type:float"`
+	AccountId uint `gorm:"not null;index"`// 証券口座
 	UserId uint `gorm:"not null;index"`
 }
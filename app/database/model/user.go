@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
@@ -10,4 +12,5 @@ type User struct {
 	Name   string `gorm:"size:255" json:"name,omitempty"`
 	Email  string `gorm:"size:255;not null;unique" json:"email,omitempty"`
 	Password  string `gorm:"size:255;not null" json:"password,omitempty"`
+	PurgeAt   *time.Time `gorm:"index" json:"-"`// 退会の猶予期間の終了日時(過ぎると全てのデータを削除する)
 }
@@ -0,0 +1,15 @@
+package model
+
+import (
+	"gorm.io/gorm"
+)
+
+// Watchlist は購入を検討している(未保有の)銘柄を表します。
+type Watchlist struct {
+    gorm.Model
+	Ticker      string   `gorm:"size:10;not null"`
+	Note        string   `gorm:"size:1000"`
+	TargetPrice *float64 `gorm:"type:float"`
+	TargetYield *float64 `gorm:"type:float"`
+	UserId      uint     `gorm:"not null;index"`
+}
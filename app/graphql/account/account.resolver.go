@@ -0,0 +1,18 @@
+package account
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+)
+
+type Resolver struct {
+    AccountService AccountService
+}
+
+func NewResolver(accountService AccountService) *Resolver {
+    return &Resolver{AccountService: accountService}
+}
+
+func (r *Resolver) DeleteAccount(ctx context.Context, input generated.DeleteAccountInput) (*generated.AccountDeletion, error) {
+    return r.AccountService.DeleteAccount(ctx, input)
+}
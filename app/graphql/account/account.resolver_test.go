@@ -0,0 +1,35 @@
+package account
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAccountService は AccountService のモックです。
+type MockAccountService struct {
+    mock.Mock
+}
+
+func (m *MockAccountService) DeleteAccount(ctx context.Context, input generated.DeleteAccountInput) (*generated.AccountDeletion, error) {
+    args := m.Called(ctx, input)
+    return args.Get(0).(*generated.AccountDeletion), args.Error(1)
+}
+
+func TestDeleteAccountResolver(t *testing.T) {
+    mockService := new(MockAccountService)
+    resolver := NewResolver(mockService)
+
+    input := generated.DeleteAccountInput{Password: "password"}
+    deletion := &generated.AccountDeletion{Deleted: true}
+    mockService.On("DeleteAccount", mock.Anything, input).Return(deletion, nil)
+
+    result, err := resolver.DeleteAccount(context.Background(), input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, deletion, result)
+    mockService.AssertExpectations(t)
+}
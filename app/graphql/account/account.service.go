@@ -0,0 +1,73 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	repoAccount "my-us-stock-backend/app/repository/account"
+	"my-us-stock-backend/app/repository/user"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 退会の猶予期間の上限(日)
+const maxGracePeriodDays = 30
+
+// AccountService インターフェースの定義
+type AccountService interface {
+    DeleteAccount(ctx context.Context, input generated.DeleteAccountInput) (*generated.AccountDeletion, error)
+}
+
+// DefaultAccountService 構造体の定義
+type DefaultAccountService struct {
+    UserRepo user.UserRepository
+    AccountRepo repoAccount.AccountRepository
+    Auth auth.AuthService
+}
+
+// NewAccountService は DefaultAccountService の新しいインスタンスを作成します
+func NewAccountService(userRepo user.UserRepository, accountRepo repoAccount.AccountRepository, auth auth.AuthService) AccountService {
+    return &DefaultAccountService{
+        UserRepo: userRepo,
+        AccountRepo: accountRepo,
+        Auth: auth,
+    }
+}
+
+// DeleteAccount はパスワードを確認した上で、ログインユーザーを退会させます
+// 猶予期間を指定した場合はログインできない状態にし、期間を過ぎてから全てのデータを削除します
+func (s *DefaultAccountService) DeleteAccount(ctx context.Context, input generated.DeleteAccountInput) (*generated.AccountDeletion, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    if input.GracePeriodDays < 0 || input.GracePeriodDays > maxGracePeriodDays {
+        return nil, utils.DefaultGraphQLError(fmt.Sprintf("猶予期間は0〜%d日で指定してください", maxGracePeriodDays))
+    }
+    modelUser, err := s.UserRepo.FindUserByID(ctx, userId)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    if err := bcrypt.CompareHashAndPassword([]byte(modelUser.Password), []byte(input.Password)); err != nil {
+        return nil, utils.DefaultGraphQLError("パスワードが一致しません")
+    }
+
+    if input.GracePeriodDays == 0 {
+        if err := s.AccountRepo.DeleteAccount(ctx, userId); err != nil {
+            return nil, utils.DefaultGraphQLError(err.Error())
+        }
+        s.Auth.RevokeUserTokens(userId)
+        return &generated.AccountDeletion{Deleted: true}, nil
+    }
+    purgeAt := time.Now().UTC().AddDate(0, 0, input.GracePeriodDays)
+    if err := s.AccountRepo.ScheduleAccountDeletion(ctx, userId, purgeAt); err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    // 猶予期間中はログイン中のセッションも使えないようにする
+    s.Auth.RevokeUserTokens(userId)
+    formatted := purgeAt.Format(time.RFC3339)
+    return &generated.AccountDeletion{Deleted: false, PurgeAt: &formatted}, nil
+}
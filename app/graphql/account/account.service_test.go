@@ -0,0 +1,134 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	repoAccount "my-us-stock-backend/app/repository/account"
+	repoUser "my-us-stock-backend/app/repository/user"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// MockUserRepository は UserRepository のモックです。
+type MockUserRepository struct {
+    mock.Mock
+}
+
+func (m *MockUserRepository) FindUserByID(ctx context.Context, id uint) (*model.User, error) {
+    args := m.Called(ctx, id)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) CreateUser(ctx context.Context, createDto repoUser.CreateUserDto) (*model.User, error) {
+    args := m.Called(ctx, createDto)
+    return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+    args := m.Called(ctx, email)
+    return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetAllUserByEmail(ctx context.Context, email string) ([]*model.User, error) {
+    args := m.Called(ctx, email)
+    return args.Get(0).([]*model.User), args.Error(1)
+}
+
+type testMocks struct {
+    userRepo    *MockUserRepository
+    accountRepo *repoAccount.MockAccountRepository
+    auth        *auth.MockAuthService
+}
+
+func newTestService() (AccountService, testMocks) {
+    mocks := testMocks{
+        userRepo:    new(MockUserRepository),
+        accountRepo: repoAccount.NewMockAccountRepository(),
+        auth:        auth.NewMockAuthService(),
+    }
+    service := NewAccountService(mocks.userRepo, mocks.accountRepo, mocks.auth)
+    return service, mocks
+}
+
+// パスワード「password」のユーザーでログインしている状態にする
+func setupUser(t *testing.T, mocks testMocks, userId uint) {
+    hashed, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+    if err != nil {
+        t.Fatal(err)
+    }
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mocks.userRepo.On("FindUserByID", mock.Anything, userId).Return(&model.User{Model: gorm.Model{ID: userId}, Password: string(hashed)}, nil)
+    mocks.auth.On("RevokeUserTokens", userId).Return()
+}
+
+func TestDeleteAccount(t *testing.T) {
+    service, mocks := newTestService()
+    setupUser(t, mocks, 1)
+    mocks.accountRepo.On("DeleteAccount", mock.Anything, uint(1)).Return(nil)
+
+    result, err := service.DeleteAccount(context.Background(), generated.DeleteAccountInput{Password: "password"})
+
+    assert.NoError(t, err)
+    assert.True(t, result.Deleted)
+    assert.Nil(t, result.PurgeAt)
+    mocks.accountRepo.AssertExpectations(t)
+    mocks.auth.AssertCalled(t, "RevokeUserTokens", uint(1))
+}
+
+func TestDeleteAccountWithGracePeriod(t *testing.T) {
+    service, mocks := newTestService()
+    setupUser(t, mocks, 1)
+    expectedPurgeAt := time.Now().UTC().AddDate(0, 0, 14)
+    isAfterGracePeriod := mock.MatchedBy(func(purgeAt time.Time) bool {
+        return purgeAt.Sub(expectedPurgeAt).Abs() < time.Minute
+    })
+    mocks.accountRepo.On("ScheduleAccountDeletion", mock.Anything, uint(1), isAfterGracePeriod).Return(nil)
+
+    result, err := service.DeleteAccount(context.Background(), generated.DeleteAccountInput{Password: "password", GracePeriodDays: 14})
+
+    assert.NoError(t, err)
+    assert.False(t, result.Deleted)
+    purgeAt, err := time.Parse(time.RFC3339, *result.PurgeAt)
+    assert.NoError(t, err)
+    assert.WithinDuration(t, expectedPurgeAt, purgeAt, time.Minute)
+    mocks.accountRepo.AssertNotCalled(t, "DeleteAccount", mock.Anything, mock.Anything)
+    // 猶予期間中はログイン中のセッションも使えない
+    mocks.auth.AssertCalled(t, "RevokeUserTokens", uint(1))
+}
+
+func TestDeleteAccountInvalid(t *testing.T) {
+    service, mocks := newTestService()
+    setupUser(t, mocks, 1)
+
+    _, err := service.DeleteAccount(context.Background(), generated.DeleteAccountInput{Password: "wrong"})
+    assert.EqualError(t, err, "input: パスワードが一致しません")
+
+    _, err = service.DeleteAccount(context.Background(), generated.DeleteAccountInput{Password: "password", GracePeriodDays: 31})
+    assert.EqualError(t, err, "input: 猶予期間は0〜30日で指定してください")
+
+    mocks.accountRepo.AssertNotCalled(t, "DeleteAccount", mock.Anything, mock.Anything)
+    mocks.accountRepo.AssertNotCalled(t, "ScheduleAccountDeletion", mock.Anything, mock.Anything, mock.Anything)
+    mocks.auth.AssertNotCalled(t, "RevokeUserTokens", mock.Anything)
+}
+
+func TestDeleteAccountUnauthenticated(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(0), errors.New("invalid token"))
+
+    result, err := service.DeleteAccount(context.Background(), generated.DeleteAccountInput{Password: "password"})
+
+    assert.Nil(t, result)
+    assert.Error(t, err)
+    mocks.userRepo.AssertNotCalled(t, "FindUserByID", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,76 @@
+package alert
+
+import (
+	"fmt"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	"strings"
+	"time"
+)
+
+// 為替アラートで指定できる通貨ペア(CurrencyRepositoryが取得できるのはUSDJPYのみ)
+const fxSymbolUsdJpy = "USDJPY"
+
+// normalizeSymbol は監視対象に応じてシンボルの表記を揃えます
+// 米国株・為替は大文字、仮想通貨は取得APIに合わせて小文字にします
+func normalizeSymbol(targetType generated.AlertTargetType, symbol string) (string, error) {
+    trimmed := strings.TrimSpace(symbol)
+    if trimmed == "" {
+        return "", fmt.Errorf("シンボルを入力してください")
+    }
+    switch targetType {
+    case generated.AlertTargetTypeFxRate:
+        if strings.ToUpper(trimmed) != fxSymbolUsdJpy {
+            return "", fmt.Errorf("為替アラートはUSDJPYのみ指定できます")
+        }
+        return fxSymbolUsdJpy, nil
+    case generated.AlertTargetTypeCryptoPrice:
+        return strings.ToLower(trimmed), nil
+    default:
+        return strings.ToUpper(trimmed), nil
+    }
+}
+
+// validateAlertRule は閾値と通知モードの組み合わせを検証します
+func validateAlertRule(threshold float64, mode generated.AlertMode, cooldownMinutes int) error {
+    if threshold <= 0 {
+        return fmt.Errorf("閾値は0より大きい値を指定してください")
+    }
+    if mode == generated.AlertModeCooldown && cooldownMinutes < 1 {
+        return fmt.Errorf("再通知までの間隔は1分以上を指定してください")
+    }
+    return nil
+}
+
+func convertToGeneratedAlertRule(alertRule model.AlertRule) *generated.AlertRule {
+    var lastTriggeredAt *string
+    if alertRule.LastTriggeredAt != nil {
+        formatted := alertRule.LastTriggeredAt.Format(time.RFC3339)
+        lastTriggeredAt = &formatted
+    }
+    return &generated.AlertRule{
+        ID:              utils.ConvertIdToString(alertRule.ID),
+        TargetType:      generated.AlertTargetType(alertRule.TargetType),
+        Symbol:          alertRule.Symbol,
+        Condition:       generated.AlertCondition(alertRule.Condition),
+        Threshold:       alertRule.Threshold,
+        Mode:            generated.AlertMode(alertRule.Mode),
+        CooldownMinutes: alertRule.CooldownMinutes,
+        Enabled:         alertRule.Enabled,
+        LastTriggeredAt: lastTriggeredAt,
+    }
+}
+
+func convertToGeneratedTriggeredAlert(triggeredAlert model.TriggeredAlert) *generated.TriggeredAlert {
+    return &generated.TriggeredAlert{
+        ID:          utils.ConvertIdToString(triggeredAlert.ID),
+        AlertRuleID: utils.ConvertIdToString(triggeredAlert.AlertRuleId),
+        TargetType:  generated.AlertTargetType(triggeredAlert.TargetType),
+        Symbol:      triggeredAlert.Symbol,
+        Condition:   generated.AlertCondition(triggeredAlert.Condition),
+        Threshold:   triggeredAlert.Threshold,
+        Value:       triggeredAlert.Value,
+        TriggeredAt: triggeredAlert.TriggeredAt.Format(time.RFC3339),
+    }
+}
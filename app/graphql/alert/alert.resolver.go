@@ -0,0 +1,34 @@
+package alert
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+)
+
+type Resolver struct {
+    AlertService AlertService
+}
+
+func NewResolver(alertService AlertService) *Resolver {
+    return &Resolver{AlertService: alertService}
+}
+
+func (r *Resolver) AlertRules(ctx context.Context) ([]*generated.AlertRule, error) {
+    return r.AlertService.AlertRules(ctx)
+}
+
+func (r *Resolver) TriggeredAlerts(ctx context.Context, limit *int) ([]*generated.TriggeredAlert, error) {
+    return r.AlertService.TriggeredAlerts(ctx, limit)
+}
+
+func (r *Resolver) CreateAlertRule(ctx context.Context, input generated.CreateAlertRuleInput) (*generated.AlertRule, error) {
+    return r.AlertService.CreateAlertRule(ctx, input)
+}
+
+func (r *Resolver) UpdateAlertRule(ctx context.Context, input generated.UpdateAlertRuleInput) (*generated.AlertRule, error) {
+    return r.AlertService.UpdateAlertRule(ctx, input)
+}
+
+func (r *Resolver) DeleteAlertRule(ctx context.Context, id string) (bool, error) {
+    return r.AlertService.DeleteAlertRule(ctx, id)
+}
@@ -0,0 +1,116 @@
+package alert
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAlertService は AlertService のモックです。
+type MockAlertService struct {
+    mock.Mock
+}
+
+func (m *MockAlertService) AlertRules(ctx context.Context) ([]*generated.AlertRule, error) {
+    args := m.Called(ctx)
+    return args.Get(0).([]*generated.AlertRule), args.Error(1)
+}
+
+func (m *MockAlertService) TriggeredAlerts(ctx context.Context, limit *int) ([]*generated.TriggeredAlert, error) {
+    args := m.Called(ctx, limit)
+    return args.Get(0).([]*generated.TriggeredAlert), args.Error(1)
+}
+
+func (m *MockAlertService) CreateAlertRule(ctx context.Context, input generated.CreateAlertRuleInput) (*generated.AlertRule, error) {
+    args := m.Called(ctx, input)
+    return args.Get(0).(*generated.AlertRule), args.Error(1)
+}
+
+func (m *MockAlertService) UpdateAlertRule(ctx context.Context, input generated.UpdateAlertRuleInput) (*generated.AlertRule, error) {
+    args := m.Called(ctx, input)
+    return args.Get(0).(*generated.AlertRule), args.Error(1)
+}
+
+func (m *MockAlertService) DeleteAlertRule(ctx context.Context, id string) (bool, error) {
+    args := m.Called(ctx, id)
+    return args.Get(0).(bool), args.Error(1)
+}
+
+func TestAlertRules(t *testing.T) {
+    mockService := new(MockAlertService)
+    resolver := NewResolver(mockService)
+
+    alertRules := []*generated.AlertRule{
+        {ID: "1", TargetType: generated.AlertTargetTypeStockPrice, Symbol: "AAPL", Condition: generated.AlertConditionBelow, Threshold: 170, Mode: generated.AlertModeCooldown, CooldownMinutes: 60, Enabled: true},
+    }
+    mockService.On("AlertRules", mock.Anything).Return(alertRules, nil)
+
+    result, err := resolver.AlertRules(context.Background())
+
+    assert.NoError(t, err)
+    assert.Equal(t, alertRules, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestTriggeredAlerts(t *testing.T) {
+    mockService := new(MockAlertService)
+    resolver := NewResolver(mockService)
+
+    limit := 10
+    triggeredAlerts := []*generated.TriggeredAlert{
+        {ID: "1", AlertRuleID: "1", TargetType: generated.AlertTargetTypeFxRate, Symbol: "USDJPY", Condition: generated.AlertConditionAbove, Threshold: 150, Value: 150.5, TriggeredAt: "2024-01-05T09:00:00Z"},
+    }
+    mockService.On("TriggeredAlerts", mock.Anything, &limit).Return(triggeredAlerts, nil)
+
+    result, err := resolver.TriggeredAlerts(context.Background(), &limit)
+
+    assert.NoError(t, err)
+    assert.Equal(t, triggeredAlerts, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestCreateAlertRule(t *testing.T) {
+    mockService := new(MockAlertService)
+    resolver := NewResolver(mockService)
+
+    input := generated.CreateAlertRuleInput{TargetType: generated.AlertTargetTypeStockPrice, Symbol: "AAPL", Condition: generated.AlertConditionBelow, Threshold: 170, Mode: generated.AlertModeOnce}
+    alertRule := &generated.AlertRule{ID: "1", TargetType: input.TargetType, Symbol: "AAPL", Condition: input.Condition, Threshold: 170, Mode: input.Mode, Enabled: true}
+    mockService.On("CreateAlertRule", mock.Anything, input).Return(alertRule, nil)
+
+    result, err := resolver.CreateAlertRule(context.Background(), input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, alertRule, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestUpdateAlertRule(t *testing.T) {
+    mockService := new(MockAlertService)
+    resolver := NewResolver(mockService)
+
+    input := generated.UpdateAlertRuleInput{ID: "1", Condition: generated.AlertConditionAbove, Threshold: 200, Mode: generated.AlertModeOnce, Enabled: false}
+    alertRule := &generated.AlertRule{ID: "1", Condition: input.Condition, Threshold: 200, Mode: input.Mode, Enabled: false}
+    mockService.On("UpdateAlertRule", mock.Anything, input).Return(alertRule, nil)
+
+    result, err := resolver.UpdateAlertRule(context.Background(), input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, alertRule, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestDeleteAlertRule(t *testing.T) {
+    mockService := new(MockAlertService)
+    resolver := NewResolver(mockService)
+
+    mockService.On("DeleteAlertRule", mock.Anything, "1").Return(true, nil)
+
+    result, err := resolver.DeleteAlertRule(context.Background(), "1")
+
+    assert.NoError(t, err)
+    assert.True(t, result)
+    mockService.AssertExpectations(t)
+}
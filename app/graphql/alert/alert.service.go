@@ -0,0 +1,155 @@
+package alert
+
+import (
+	"context"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	"my-us-stock-backend/app/repository/alert"
+)
+
+// 発生履歴の取得件数の上限
+const maxTriggeredAlertLimit = 500
+
+// AlertService インターフェースの定義
+type AlertService interface {
+    AlertRules(ctx context.Context) ([]*generated.AlertRule, error)
+    TriggeredAlerts(ctx context.Context, limit *int) ([]*generated.TriggeredAlert, error)
+    CreateAlertRule(ctx context.Context, input generated.CreateAlertRuleInput) (*generated.AlertRule, error)
+    UpdateAlertRule(ctx context.Context, input generated.UpdateAlertRuleInput) (*generated.AlertRule, error)
+    DeleteAlertRule(ctx context.Context, id string) (bool, error)
+}
+
+// DefaultAlertService 構造体の定義
+type DefaultAlertService struct {
+    AlertRepo alert.AlertRepository
+    Auth auth.AuthService
+}
+
+// NewAlertService は DefaultAlertService の新しいインスタンスを作成します
+func NewAlertService(alertRepo alert.AlertRepository, auth auth.AuthService) AlertService {
+    return &DefaultAlertService{AlertRepo: alertRepo, Auth: auth}
+}
+
+// AlertRules はユーザーのアラート条件を取得します
+func (s *DefaultAlertService) AlertRules(ctx context.Context) ([]*generated.AlertRule, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+
+    modelAlertRules, err := s.AlertRepo.FetchAlertRuleListById(ctx, userId)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    alertRules := make([]*generated.AlertRule, 0, len(modelAlertRules))
+    for _, modelAlertRule := range modelAlertRules {
+        alertRules = append(alertRules, convertToGeneratedAlertRule(modelAlertRule))
+    }
+    return alertRules, nil
+}
+
+// TriggeredAlerts はユーザーのアラート発生履歴を新しい順に取得します
+func (s *DefaultAlertService) TriggeredAlerts(ctx context.Context, limit *int) ([]*generated.TriggeredAlert, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+
+    fetchLimit := 50
+    if limit != nil {
+        fetchLimit = *limit
+    }
+    if fetchLimit < 1 || fetchLimit > maxTriggeredAlertLimit {
+        return nil, utils.DefaultGraphQLError("取得件数は1〜500の範囲で指定してください")
+    }
+
+    modelTriggeredAlerts, err := s.AlertRepo.FetchTriggeredAlertListById(ctx, userId, fetchLimit)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    triggeredAlerts := make([]*generated.TriggeredAlert, 0, len(modelTriggeredAlerts))
+    for _, modelTriggeredAlert := range modelTriggeredAlerts {
+        triggeredAlerts = append(triggeredAlerts, convertToGeneratedTriggeredAlert(modelTriggeredAlert))
+    }
+    return triggeredAlerts, nil
+}
+
+// アラート条件を登録します
+func (s *DefaultAlertService) CreateAlertRule(ctx context.Context, input generated.CreateAlertRuleInput) (*generated.AlertRule, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+
+    symbol, err := normalizeSymbol(input.TargetType, input.Symbol)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    if err := validateAlertRule(input.Threshold, input.Mode, input.CooldownMinutes); err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+
+    createDto := alert.CreateAlertRuleDto{
+        TargetType:      string(input.TargetType),
+        Symbol:          symbol,
+        Condition:       string(input.Condition),
+        Threshold:       input.Threshold,
+        Mode:            string(input.Mode),
+        CooldownMinutes: input.CooldownMinutes,
+        UserId:          userId,
+    }
+    modelAlertRule, err := s.AlertRepo.CreateAlertRule(ctx, createDto)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return convertToGeneratedAlertRule(*modelAlertRule), nil
+}
+
+// アラート条件を更新します
+func (s *DefaultAlertService) UpdateAlertRule(ctx context.Context, input generated.UpdateAlertRuleInput) (*generated.AlertRule, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+
+    id, err := utils.ConvertIdToUint(input.ID)
+    if err != nil || id == 0 {
+        return nil, utils.DefaultGraphQLError("入力されたidが無効です")
+    }
+    if err := validateAlertRule(input.Threshold, input.Mode, input.CooldownMinutes); err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+
+    updateDto := alert.UpdateAlertRuleDto{
+        ID:              id,
+        Condition:       string(input.Condition),
+        Threshold:       input.Threshold,
+        Mode:            string(input.Mode),
+        CooldownMinutes: input.CooldownMinutes,
+        Enabled:         input.Enabled,
+        UserId:          userId,
+    }
+    modelAlertRule, err := s.AlertRepo.UpdateAlertRule(ctx, updateDto)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return convertToGeneratedAlertRule(*modelAlertRule), nil
+}
+
+// アラート条件を削除します
+func (s *DefaultAlertService) DeleteAlertRule(ctx context.Context, id string) (bool, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return false, utils.UnauthenticatedError("Invalid user ID")
+    }
+
+    deleteId, err := utils.ConvertIdToUint(id)
+    if err != nil || deleteId == 0 {
+        return false, utils.DefaultGraphQLError("入力されたidが無効です")
+    }
+    if err := s.AlertRepo.DeleteAlertRule(ctx, deleteId, userId); err != nil {
+        return false, utils.DefaultGraphQLError(err.Error())
+    }
+    return true, nil
+}
@@ -0,0 +1,148 @@
+package alert
+
+import (
+	"context"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	repo "my-us-stock-backend/app/repository/alert"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestAlertRulesService(t *testing.T) {
+    mockRepo := repo.NewMockAlertRepository()
+    mockAuth := auth.NewMockAuthService()
+    service := NewAlertService(mockRepo, mockAuth)
+
+    userId := uint(1)
+    lastTriggeredAt := time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mockRepo.On("FetchAlertRuleListById", mock.Anything, userId).Return([]model.AlertRule{
+        {Model: gorm.Model{ID: 1}, TargetType: "STOCK_PRICE", Symbol: "AAPL", Condition: "BELOW", Threshold: 170, Mode: "COOLDOWN", CooldownMinutes: 60, Enabled: true, LastTriggeredAt: &lastTriggeredAt, UserId: userId},
+    }, nil)
+
+    result, err := service.AlertRules(context.Background())
+
+    assert.NoError(t, err)
+    assert.Len(t, result, 1)
+    assert.Equal(t, generated.AlertTargetTypeStockPrice, result[0].TargetType)
+    assert.Equal(t, generated.AlertConditionBelow, result[0].Condition)
+    assert.Equal(t, "2024-01-05T09:00:00Z", *result[0].LastTriggeredAt)
+}
+
+func TestAlertRulesServiceUnauthenticated(t *testing.T) {
+    mockRepo := repo.NewMockAlertRepository()
+    mockAuth := auth.NewMockAuthService()
+    service := NewAlertService(mockRepo, mockAuth)
+
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(0), nil)
+
+    _, err := service.AlertRules(context.Background())
+
+    assert.Error(t, err)
+    mockRepo.AssertNotCalled(t, "FetchAlertRuleListById", mock.Anything, mock.Anything)
+}
+
+// シンボルは監視対象に応じて表記を揃えて保存する
+func TestCreateAlertRuleService(t *testing.T) {
+    mockRepo := repo.NewMockAlertRepository()
+    mockAuth := auth.NewMockAuthService()
+    service := NewAlertService(mockRepo, mockAuth)
+
+    userId := uint(1)
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    expectedDto := repo.CreateAlertRuleDto{TargetType: "FX_RATE", Symbol: "USDJPY", Condition: "ABOVE", Threshold: 150, Mode: "ONCE", UserId: userId}
+    mockRepo.On("CreateAlertRule", mock.Anything, expectedDto).Return(&model.AlertRule{
+        Model: gorm.Model{ID: 1}, TargetType: "FX_RATE", Symbol: "USDJPY", Condition: "ABOVE", Threshold: 150, Mode: "ONCE", Enabled: true, UserId: userId,
+    }, nil)
+
+    input := generated.CreateAlertRuleInput{TargetType: generated.AlertTargetTypeFxRate, Symbol: " usdjpy ", Condition: generated.AlertConditionAbove, Threshold: 150, Mode: generated.AlertModeOnce}
+    result, err := service.CreateAlertRule(context.Background(), input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "USDJPY", result.Symbol)
+    assert.True(t, result.Enabled)
+    assert.Nil(t, result.LastTriggeredAt)
+    mockRepo.AssertExpectations(t)
+}
+
+func TestCreateAlertRuleServiceInvalidInput(t *testing.T) {
+    mockRepo := repo.NewMockAlertRepository()
+    mockAuth := auth.NewMockAuthService()
+    service := NewAlertService(mockRepo, mockAuth)
+
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+
+    inputs := []generated.CreateAlertRuleInput{
+        // USDJPY以外の通貨ペア
+        {TargetType: generated.AlertTargetTypeFxRate, Symbol: "EURJPY", Condition: generated.AlertConditionAbove, Threshold: 160, Mode: generated.AlertModeOnce},
+        // 閾値が0以下
+        {TargetType: generated.AlertTargetTypeStockPrice, Symbol: "AAPL", Condition: generated.AlertConditionBelow, Threshold: 0, Mode: generated.AlertModeOnce},
+        // 再通知間隔が未指定
+        {TargetType: generated.AlertTargetTypeDividendYield, Symbol: "KO", Condition: generated.AlertConditionAbove, Threshold: 4, Mode: generated.AlertModeCooldown, CooldownMinutes: 0},
+        // シンボルが空
+        {TargetType: generated.AlertTargetTypeCryptoPrice, Symbol: " ", Condition: generated.AlertConditionAbove, Threshold: 100, Mode: generated.AlertModeOnce},
+    }
+    for _, input := range inputs {
+        _, err := service.CreateAlertRule(context.Background(), input)
+        assert.Error(t, err)
+    }
+    mockRepo.AssertNotCalled(t, "CreateAlertRule", mock.Anything, mock.Anything)
+}
+
+func TestUpdateAlertRuleService(t *testing.T) {
+    mockRepo := repo.NewMockAlertRepository()
+    mockAuth := auth.NewMockAuthService()
+    service := NewAlertService(mockRepo, mockAuth)
+
+    userId := uint(1)
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    expectedDto := repo.UpdateAlertRuleDto{ID: 1, Condition: "BELOW", Threshold: 160, Mode: "COOLDOWN", CooldownMinutes: 30, Enabled: false, UserId: userId}
+    mockRepo.On("UpdateAlertRule", mock.Anything, expectedDto).Return(&model.AlertRule{
+        Model: gorm.Model{ID: 1}, TargetType: "STOCK_PRICE", Symbol: "AAPL", Condition: "BELOW", Threshold: 160, Mode: "COOLDOWN", CooldownMinutes: 30, Enabled: false, UserId: userId,
+    }, nil)
+
+    input := generated.UpdateAlertRuleInput{ID: "1", Condition: generated.AlertConditionBelow, Threshold: 160, Mode: generated.AlertModeCooldown, CooldownMinutes: 30, Enabled: false}
+    result, err := service.UpdateAlertRule(context.Background(), input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, 160.0, result.Threshold)
+    assert.False(t, result.Enabled)
+    mockRepo.AssertExpectations(t)
+}
+
+func TestDeleteAlertRuleService(t *testing.T) {
+    mockRepo := repo.NewMockAlertRepository()
+    mockAuth := auth.NewMockAuthService()
+    service := NewAlertService(mockRepo, mockAuth)
+
+    userId := uint(1)
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mockRepo.On("DeleteAlertRule", mock.Anything, uint(1), userId).Return(nil)
+
+    result, err := service.DeleteAlertRule(context.Background(), "1")
+
+    assert.NoError(t, err)
+    assert.True(t, result)
+    mockRepo.AssertExpectations(t)
+}
+
+// 取得件数の上限を超える指定はエラーとする
+func TestTriggeredAlertsServiceInvalidLimit(t *testing.T) {
+    mockRepo := repo.NewMockAlertRepository()
+    mockAuth := auth.NewMockAuthService()
+    service := NewAlertService(mockRepo, mockAuth)
+
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+
+    limit := 1000
+    _, err := service.TriggeredAlerts(context.Background(), &limit)
+
+    assert.Error(t, err)
+    mockRepo.AssertNotCalled(t, "FetchTriggeredAlertListById", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,30 @@
+package brokerageaccount
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+)
+
+type Resolver struct {
+    BrokerageAccountService BrokerageAccountService
+}
+
+func NewResolver(brokerageAccountService BrokerageAccountService) *Resolver {
+    return &Resolver{BrokerageAccountService: brokerageAccountService}
+}
+
+func (r *Resolver) BrokerageAccounts(ctx context.Context) ([]*generated.BrokerageAccount, error) {
+    return r.BrokerageAccountService.BrokerageAccounts(ctx)
+}
+
+func (r *Resolver) CreateBrokerageAccount(ctx context.Context, input generated.CreateBrokerageAccountInput) (*generated.BrokerageAccount, error) {
+    return r.BrokerageAccountService.CreateBrokerageAccount(ctx, input)
+}
+
+func (r *Resolver) UpdateBrokerageAccount(ctx context.Context, input generated.UpdateBrokerageAccountInput) (*generated.BrokerageAccount, error) {
+    return r.BrokerageAccountService.UpdateBrokerageAccount(ctx, input)
+}
+
+func (r *Resolver) DeleteBrokerageAccount(ctx context.Context, id string) (bool, error) {
+    return r.BrokerageAccountService.DeleteBrokerageAccount(ctx, id)
+}
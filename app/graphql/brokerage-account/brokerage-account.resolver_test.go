@@ -0,0 +1,64 @@
+package brokerageaccount
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBrokerageAccountService は BrokerageAccountService のモックです。
+type MockBrokerageAccountService struct {
+    mock.Mock
+}
+
+func (m *MockBrokerageAccountService) BrokerageAccounts(ctx context.Context) ([]*generated.BrokerageAccount, error) {
+    args := m.Called(ctx)
+    return args.Get(0).([]*generated.BrokerageAccount), args.Error(1)
+}
+
+func (m *MockBrokerageAccountService) CreateBrokerageAccount(ctx context.Context, input generated.CreateBrokerageAccountInput) (*generated.BrokerageAccount, error) {
+    args := m.Called(ctx, input)
+    return args.Get(0).(*generated.BrokerageAccount), args.Error(1)
+}
+
+func (m *MockBrokerageAccountService) UpdateBrokerageAccount(ctx context.Context, input generated.UpdateBrokerageAccountInput) (*generated.BrokerageAccount, error) {
+    args := m.Called(ctx, input)
+    return args.Get(0).(*generated.BrokerageAccount), args.Error(1)
+}
+
+func (m *MockBrokerageAccountService) DeleteBrokerageAccount(ctx context.Context, id string) (bool, error) {
+    args := m.Called(ctx, id)
+    return args.Bool(0), args.Error(1)
+}
+
+func TestBrokerageAccountsResolver(t *testing.T) {
+    mockService := new(MockBrokerageAccountService)
+    resolver := NewResolver(mockService)
+
+    accounts := []*generated.BrokerageAccount{{ID: "1", Name: "SBI NISA", Type: generated.AccountTypeNisaGrowth}}
+    mockService.On("BrokerageAccounts", mock.Anything).Return(accounts, nil)
+
+    result, err := resolver.BrokerageAccounts(context.Background())
+
+    assert.NoError(t, err)
+    assert.Equal(t, accounts, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestCreateBrokerageAccountResolver(t *testing.T) {
+    mockService := new(MockBrokerageAccountService)
+    resolver := NewResolver(mockService)
+
+    input := generated.CreateBrokerageAccountInput{Name: "SBI NISA", Type: generated.AccountTypeNisaGrowth}
+    account := &generated.BrokerageAccount{ID: "1", Name: "SBI NISA", Type: generated.AccountTypeNisaGrowth}
+    mockService.On("CreateBrokerageAccount", mock.Anything, input).Return(account, nil)
+
+    result, err := resolver.CreateBrokerageAccount(context.Background(), input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, account, result)
+    mockService.AssertExpectations(t)
+}
@@ -0,0 +1,138 @@
+package brokerageaccount
+
+import (
+	"context"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	repoBrokerageAccount "my-us-stock-backend/app/repository/brokerage-account"
+	"strings"
+)
+
+// 口座名の最大文字数
+const maxAccountNameLength = 255
+
+// BrokerageAccountService インターフェースの定義
+type BrokerageAccountService interface {
+    BrokerageAccounts(ctx context.Context) ([]*generated.BrokerageAccount, error)
+    CreateBrokerageAccount(ctx context.Context, input generated.CreateBrokerageAccountInput) (*generated.BrokerageAccount, error)
+    UpdateBrokerageAccount(ctx context.Context, input generated.UpdateBrokerageAccountInput) (*generated.BrokerageAccount, error)
+    DeleteBrokerageAccount(ctx context.Context, id string) (bool, error)
+}
+
+// DefaultBrokerageAccountService 構造体の定義
+type DefaultBrokerageAccountService struct {
+    BrokerageAccountRepo repoBrokerageAccount.BrokerageAccountRepository
+    Auth auth.AuthService
+}
+
+// NewBrokerageAccountService は DefaultBrokerageAccountService の新しいインスタンスを作成します
+func NewBrokerageAccountService(brokerageAccountRepo repoBrokerageAccount.BrokerageAccountRepository, auth auth.AuthService) BrokerageAccountService {
+    return &DefaultBrokerageAccountService{BrokerageAccountRepo: brokerageAccountRepo, Auth: auth}
+}
+
+// BrokerageAccounts はログインユーザーの証券口座を登録順に取得します
+func (s *DefaultBrokerageAccountService) BrokerageAccounts(ctx context.Context) ([]*generated.BrokerageAccount, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    modelAccounts, err := s.BrokerageAccountRepo.FetchBrokerageAccountListById(ctx, userId)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    accounts := make([]*generated.BrokerageAccount, len(modelAccounts))
+    for i := range modelAccounts {
+        accounts[i] = convertToBrokerageAccount(&modelAccounts[i])
+    }
+    return accounts, nil
+}
+
+// CreateBrokerageAccount は証券口座を作成します
+func (s *DefaultBrokerageAccountService) CreateBrokerageAccount(ctx context.Context, input generated.CreateBrokerageAccountInput) (*generated.BrokerageAccount, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    name, err := validateAccountInput(input.Name, input.Type)
+    if err != nil {
+        return nil, err
+    }
+    modelAccount, err := s.BrokerageAccountRepo.CreateBrokerageAccount(ctx, repoBrokerageAccount.CreateBrokerageAccountDto{
+        Name:   name,
+        Type:   input.Type.String(),
+        UserId: userId,
+    })
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return convertToBrokerageAccount(modelAccount), nil
+}
+
+// UpdateBrokerageAccount は証券口座の名称・種類を更新します
+func (s *DefaultBrokerageAccountService) UpdateBrokerageAccount(ctx context.Context, input generated.UpdateBrokerageAccountInput) (*generated.BrokerageAccount, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    updateId, convertError := utils.ConvertIdToUint(input.ID)
+    if convertError != nil || updateId == 0 {
+        return nil, utils.DefaultGraphQLError("入力されたidが無効です")
+    }
+    name, err := validateAccountInput(input.Name, input.Type)
+    if err != nil {
+        return nil, err
+    }
+    modelAccount, err := s.BrokerageAccountRepo.UpdateBrokerageAccount(ctx, repoBrokerageAccount.UpdateBrokerageAccountDto{
+        ID:     updateId,
+        Name:   name,
+        Type:   input.Type.String(),
+        UserId: userId,
+    })
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return convertToBrokerageAccount(modelAccount), nil
+}
+
+// DeleteBrokerageAccount は証券口座を削除します(保有資産が登録されている口座は削除できない)
+func (s *DefaultBrokerageAccountService) DeleteBrokerageAccount(ctx context.Context, id string) (bool, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return false, utils.UnauthenticatedError("Invalid user ID")
+    }
+    deleteId, convertError := utils.ConvertIdToUint(id)
+    if convertError != nil || deleteId == 0 {
+        return false, utils.DefaultGraphQLError("入力されたidが無効です")
+    }
+    if err := s.BrokerageAccountRepo.DeleteBrokerageAccount(ctx, deleteId, userId); err != nil {
+        return false, utils.DefaultGraphQLError(err.Error())
+    }
+    return true, nil
+}
+
+// 口座名・口座の種類を検証し、前後の空白を除いた口座名を返す
+func validateAccountInput(name string, accountType generated.AccountType) (string, error) {
+    name = strings.TrimSpace(name)
+    if name == "" {
+        return "", utils.DefaultGraphQLError("口座名を入力してください")
+    }
+    if len([]rune(name)) > maxAccountNameLength {
+        return "", utils.DefaultGraphQLError("口座名は255文字以内で入力してください")
+    }
+    if !accountType.IsValid() {
+        return "", utils.DefaultGraphQLError("口座の種類が無効です")
+    }
+    return name, nil
+}
+
+// モデルをGraphQLの型に変換する
+func convertToBrokerageAccount(modelAccount *model.BrokerageAccount) *generated.BrokerageAccount {
+    return &generated.BrokerageAccount{
+        ID:   utils.ConvertIdToString(modelAccount.ID),
+        Name: modelAccount.Name,
+        Type: generated.AccountType(modelAccount.Type),
+        IsDefault: modelAccount.IsDefault,
+    }
+}
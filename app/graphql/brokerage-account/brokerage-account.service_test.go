@@ -0,0 +1,115 @@
+package brokerageaccount
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	repoBrokerageAccount "my-us-stock-backend/app/repository/brokerage-account"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type testMocks struct {
+    brokerageAccountRepo *repoBrokerageAccount.MockBrokerageAccountRepository
+    auth                 *auth.MockAuthService
+}
+
+func newTestService() (BrokerageAccountService, testMocks) {
+    mocks := testMocks{
+        brokerageAccountRepo: repoBrokerageAccount.NewMockBrokerageAccountRepository(),
+        auth:                 auth.NewMockAuthService(),
+    }
+    service := NewBrokerageAccountService(mocks.brokerageAccountRepo, mocks.auth)
+    return service, mocks
+}
+
+func TestBrokerageAccounts(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    mocks.brokerageAccountRepo.On("FetchBrokerageAccountListById", mock.Anything, uint(1)).Return([]model.BrokerageAccount{
+        {Model: gorm.Model{ID: 1}, Name: "SBI NISA", Type: "NISA_GROWTH", UserId: 1},
+        {Model: gorm.Model{ID: 2}, Name: "メイン口座", Type: "TAXABLE", IsDefault: true, UserId: 1},
+    }, nil)
+
+    accounts, err := service.BrokerageAccounts(context.Background())
+
+    assert.NoError(t, err)
+    assert.Equal(t, []*generated.BrokerageAccount{
+        {ID: "1", Name: "SBI NISA", Type: generated.AccountTypeNisaGrowth},
+        {ID: "2", Name: "メイン口座", Type: generated.AccountTypeTaxable, IsDefault: true},
+    }, accounts)
+}
+
+func TestBrokerageAccountsUnauthenticated(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(0), errors.New("invalid token"))
+
+    accounts, err := service.BrokerageAccounts(context.Background())
+
+    assert.Nil(t, accounts)
+    assert.Error(t, err)
+    mocks.brokerageAccountRepo.AssertNotCalled(t, "FetchBrokerageAccountListById", mock.Anything, mock.Anything)
+}
+
+func TestCreateBrokerageAccount(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    dto := repoBrokerageAccount.CreateBrokerageAccountDto{Name: "楽天 iDeCo", Type: "IDECO", UserId: 1}
+    mocks.brokerageAccountRepo.On("CreateBrokerageAccount", mock.Anything, dto).Return(&model.BrokerageAccount{Model: gorm.Model{ID: 3}, Name: dto.Name, Type: dto.Type, UserId: 1}, nil)
+
+    // 口座名の前後の空白は取り除く
+    account, err := service.CreateBrokerageAccount(context.Background(), generated.CreateBrokerageAccountInput{Name: " 楽天 iDeCo ", Type: generated.AccountTypeIdeco})
+
+    assert.NoError(t, err)
+    assert.Equal(t, &generated.BrokerageAccount{ID: "3", Name: "楽天 iDeCo", Type: generated.AccountTypeIdeco}, account)
+}
+
+func TestCreateBrokerageAccountInvalidInput(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+
+    _, err := service.CreateBrokerageAccount(context.Background(), generated.CreateBrokerageAccountInput{Name: "  ", Type: generated.AccountTypeTaxable})
+    assert.EqualError(t, err, "input: 口座名を入力してください")
+
+    _, err = service.CreateBrokerageAccount(context.Background(), generated.CreateBrokerageAccountInput{Name: strings.Repeat("口", 256), Type: generated.AccountTypeTaxable})
+    assert.EqualError(t, err, "input: 口座名は255文字以内で入力してください")
+
+    _, err = service.CreateBrokerageAccount(context.Background(), generated.CreateBrokerageAccountInput{Name: "SBI", Type: generated.AccountType("BANK")})
+    assert.EqualError(t, err, "input: 口座の種類が無効です")
+    mocks.brokerageAccountRepo.AssertNotCalled(t, "CreateBrokerageAccount", mock.Anything, mock.Anything)
+}
+
+func TestUpdateBrokerageAccount(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    dto := repoBrokerageAccount.UpdateBrokerageAccountDto{ID: 1, Name: "SBI つみたて", Type: "NISA_TSUMITATE", UserId: 1}
+    mocks.brokerageAccountRepo.On("UpdateBrokerageAccount", mock.Anything, dto).Return(&model.BrokerageAccount{Model: gorm.Model{ID: 1}, Name: dto.Name, Type: dto.Type, UserId: 1}, nil)
+
+    account, err := service.UpdateBrokerageAccount(context.Background(), generated.UpdateBrokerageAccountInput{ID: "1", Name: "SBI つみたて", Type: generated.AccountTypeNisaTsumitate})
+    assert.NoError(t, err)
+    assert.Equal(t, generated.AccountTypeNisaTsumitate, account.Type)
+
+    _, err = service.UpdateBrokerageAccount(context.Background(), generated.UpdateBrokerageAccountInput{ID: "abc", Name: "SBI", Type: generated.AccountTypeTaxable})
+    assert.EqualError(t, err, "input: 入力されたidが無効です")
+}
+
+func TestDeleteBrokerageAccount(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    mocks.brokerageAccountRepo.On("DeleteBrokerageAccount", mock.Anything, uint(1), uint(1)).Return(nil)
+    mocks.brokerageAccountRepo.On("DeleteBrokerageAccount", mock.Anything, uint(2), uint(1)).Return(errors.New("保有資産が登録されている口座は削除できません"))
+
+    result, err := service.DeleteBrokerageAccount(context.Background(), "1")
+    assert.NoError(t, err)
+    assert.True(t, result)
+
+    result, err = service.DeleteBrokerageAccount(context.Background(), "2")
+    assert.False(t, result)
+    assert.EqualError(t, err, "input: 保有資産が登録されている口座は削除できません")
+}
@@ -13,8 +13,8 @@ func NewResolver(CryptoService CryptoService) *Resolver {
     return &Resolver{CryptoService: CryptoService}
 }
 
-func (r *Resolver) Cryptos(ctx context.Context) ([]*generated.Crypto, error) {
-    return r.CryptoService.Cryptos(ctx)
+func (r *Resolver) Cryptos(ctx context.Context, accountId *string, householdId *string) ([]*generated.Crypto, error) {
+    return r.CryptoService.Cryptos(ctx, accountId, householdId)
 }
 
 func (r *Resolver) CreateCrypto(ctx context.Context, input generated.CreateCryptoInput) (*generated.Crypto, error) {
@@ -14,8 +14,8 @@ type MockCryptoService struct {
     mock.Mock
 }
 
-func (m *MockCryptoService) Cryptos(ctx context.Context) ([]*generated.Crypto, error) {
-    args := m.Called(ctx)
+func (m *MockCryptoService) Cryptos(ctx context.Context, accountId *string, householdId *string) ([]*generated.Crypto, error) {
+    args := m.Called(ctx, accountId, householdId)
     return args.Get(0).([]*generated.Crypto), args.Error(1)
 }
 
@@ -43,9 +43,9 @@ func TestCryptos(t *testing.T) {
         {ID: "1",Code: "btc", GetPrice: 5047113.0, Quantity: 0.05, CurrentPrice: 5947113.84},
         {ID: "2",Code: "xrp",  GetPrice: 88.0, Quantity: 2,CurrentPrice: 88.2},
     }
-    mockService.On("Cryptos", mock.Anything).Return(cryptos, nil)
+    mockService.On("Cryptos", mock.Anything, (*string)(nil), (*string)(nil)).Return(cryptos, nil)
 
-    result, err := resolver.Cryptos(context.Background())
+    result, err := resolver.Cryptos(context.Background(), nil, nil)
     
     assert.NoError(t, err)
     assert.Equal(t, cryptos, result)
@@ -3,6 +3,7 @@ package crypto
 import (
 	"context"
 	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/common/household"
 	"my-us-stock-backend/app/database/model"
 	"my-us-stock-backend/app/graphql/generated"
 	"my-us-stock-backend/app/graphql/utils"
@@ -12,7 +13,7 @@ import (
 
 // CryptoService インターフェースの定義
 type CryptoService interface {
-    Cryptos(ctx context.Context) ([]*generated.Crypto, error)
+    Cryptos(ctx context.Context, accountId *string, householdId *string) ([]*generated.Crypto, error)
 	CreateCrypto(ctx context.Context, input generated.CreateCryptoInput) (*generated.Crypto, error)
     UpdateCrypto(ctx context.Context, input generated.UpdateCryptoInput) (*generated.Crypto, error)
     DeleteCrypto(ctx context.Context, id string) (bool, error)
@@ -23,25 +24,47 @@ type DefaultCryptoService struct {
     Repo crypto.CryptoRepository // インターフェースを利用
 	MarketPriceRepo marketPrice.CryptoRepository
     Auth auth.AuthService        // 認証サービスのインターフェース
+    HouseholdAccess household.HouseholdAccess
 }
 
 // NewCryptoService は DefaultUsStockService の新しいインスタンスを作成します
-func NewCryptoService(stockRepo crypto.CryptoRepository, auth auth.AuthService, marketPriceRepo marketPrice.CryptoRepository) CryptoService {
-    return &DefaultCryptoService{Repo: stockRepo, Auth: auth, MarketPriceRepo: marketPriceRepo}
+func NewCryptoService(stockRepo crypto.CryptoRepository, auth auth.AuthService, marketPriceRepo marketPrice.CryptoRepository, householdAccess household.HouseholdAccess) CryptoService {
+    return &DefaultCryptoService{Repo: stockRepo, Auth: auth, MarketPriceRepo: marketPriceRepo, HouseholdAccess: householdAccess}
 }
 
 // Cryptos はユーザーの米国株式情報リストを取得します
-func (s *DefaultCryptoService) Cryptos(ctx context.Context) ([]*generated.Crypto, error) {
+func (s *DefaultCryptoService) Cryptos(ctx context.Context, accountId *string, householdId *string) ([]*generated.Crypto, error) {
     // アクセストークンの検証（コメントアウトされている部分は必要に応じて実装してください）
     userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
     if userId == 0 {
         return nil, utils.UnauthenticatedError("Invalid user ID")
     }
+    filterAccountId, convertError := utils.ConvertNullableIdToUint(accountId)
+    if convertError != nil {
+        return nil, utils.DefaultGraphQLError("入力された口座idが無効です")
+    }
 
-    modelCryptos, err := s.Repo.FetchCryptoListById(ctx, userId)
+    // 世帯の指定がある場合は世帯の全メンバーの保有資産を対象にする
+    scopeUserIds, err := s.HouseholdAccess.ScopeUserIds(ctx, userId, householdId)
     if err != nil {
         return nil, utils.DefaultGraphQLError(err.Error())
     }
+    var modelCryptos []model.Crypto
+    for _, scopeUserId := range scopeUserIds {
+        userCryptos, err := s.Repo.FetchCryptoListById(ctx, scopeUserId)
+        if err != nil {
+            return nil, utils.DefaultGraphQLError(err.Error())
+        }
+        modelCryptos = append(modelCryptos, userCryptos...)
+    }
+    // 口座の指定がある場合はその口座の保有資産のみ対象にする
+    filteredCryptos := make([]model.Crypto, 0, len(modelCryptos))
+    for _, modelCrypto := range modelCryptos {
+        if utils.InAccount(modelCrypto.AccountId, filterAccountId) {
+            filteredCryptos = append(filteredCryptos, modelCrypto)
+        }
+    }
+    modelCryptos = filteredCryptos
 
     // modelCryptosが空の場合は空の配列を返却する
     if len(modelCryptos) == 0 {
@@ -79,6 +102,7 @@ func (s *DefaultCryptoService) Cryptos(ctx context.Context) ([]*generated.Crypto
         case result := <-results:
             cryptos = append(cryptos, &generated.Crypto{
                 ID: utils.ConvertIdToString(result.crypto.ID),
+                AccountID: utils.ConvertIdToString(result.crypto.AccountId),
                 Code:         result.crypto.Code,
                 GetPrice:     result.crypto.GetPrice,
                 Quantity:     result.crypto.Quantity,
@@ -100,11 +124,16 @@ func (s *DefaultCryptoService) CreateCrypto(ctx context.Context, input generated
         return nil, utils.UnauthenticatedError("Invalid user ID")
     }
 	
+    createAccountId, convertError := utils.ConvertNullableIdToUint(input.AccountID)
+    if convertError != nil {
+        return nil, utils.DefaultGraphQLError("入力された口座idが無効です")
+    }
 	// 値入れ直し
 	createDto := crypto.CreateCryptDto{
 		Code: input.Code,
 		GetPrice: input.GetPrice,
 		Quantity: input.Quantity,
+		AccountId: createAccountId,
 		UserId: userId,
 	}
 
@@ -120,6 +149,7 @@ func (s *DefaultCryptoService) CreateCrypto(ctx context.Context, input generated
 	// 市場情報を追加して返却
 	return &generated.Crypto{
         ID: utils.ConvertIdToString(modelStock.ID),
+        AccountID: utils.ConvertIdToString(modelStock.AccountId),
 		Code: modelStock.Code,
 		GetPrice: modelStock.GetPrice,
 		Quantity:     modelStock.Quantity,
@@ -145,6 +175,10 @@ func (s *DefaultCryptoService) UpdateCrypto(ctx context.Context, input generated
 
 	}
 
+    // 他のユーザーの保有資産は世帯で編集者以上の権限がある場合のみ更新できる
+    if err := s.HouseholdAccess.AuthorizeEdit(ctx, userId, &model.Crypto{}, updateId); err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
     modelCrypto, err := s.Repo.UpdateCrypto(ctx, updateDto)
     if err != nil {
         return nil, utils.DefaultGraphQLError(err.Error())
@@ -157,6 +191,7 @@ func (s *DefaultCryptoService) UpdateCrypto(ctx context.Context, input generated
 	// 市場情報を追加して返却
 	return &generated.Crypto{
         ID: utils.ConvertIdToString(modelCrypto.ID),
+        AccountID: utils.ConvertIdToString(modelCrypto.AccountId),
 		Code: modelCrypto.Code,
 		GetPrice: modelCrypto.GetPrice,
 		Quantity:     modelCrypto.Quantity,
@@ -177,6 +212,10 @@ func (s *DefaultCryptoService) DeleteCrypto(ctx context.Context, id string) (boo
     if convertError != nil || deleteId == 0 {
         return false, utils.DefaultGraphQLError("入力されたidが無効です")
        }
+    // 他のユーザーの保有資産は世帯で編集者以上の権限がある場合のみ削除できる
+    if err := s.HouseholdAccess.AuthorizeEdit(ctx, userId, &model.Crypto{}, deleteId); err != nil {
+        return false, utils.DefaultGraphQLError(err.Error())
+    }
     var err = s.Repo.DeleteCrypto(ctx, deleteId)
 	// 市場情報を追加して返却
     if err != nil {
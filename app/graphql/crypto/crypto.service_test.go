@@ -2,6 +2,7 @@ package crypto
 
 import (
 	"context"
+	"my-us-stock-backend/app/common/household"
 	"my-us-stock-backend/app/common/auth"
 	"my-us-stock-backend/app/database/model"
 	"my-us-stock-backend/app/graphql/generated"
@@ -19,11 +20,13 @@ func TestCryptosService(t *testing.T) {
 	mockCryptoRepo := crypto.NewMockCryptoRepository()
     mockMarkeCryptoRepo := marketPrice.NewMockCryptoRepository()
 	mockAuth := auth.NewMockAuthService()
-	service := NewCryptoService(mockCryptoRepo, mockAuth, mockMarkeCryptoRepo)
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewCryptoService(mockCryptoRepo, mockAuth, mockMarkeCryptoRepo, mockHouseholdAccess)
 
 	// モックの期待値設定
 	userId := uint(1)
 	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+	mockHouseholdAccess.On("ScopeUserIds", mock.Anything, userId, (*string)(nil)).Return([]uint{userId}, nil)
 
 	mockCryptos := []model.Crypto{
 		{Code: "xrp", GetPrice: 88.0, Quantity: 2.0},
@@ -35,7 +38,7 @@ func TestCryptosService(t *testing.T) {
 	mockMarkeCryptoRepo.On("FetchCryptoPrice", "xrp").Return(mockMarketPrice, nil)
 
 	// テスト対象メソッドの実行
-	cryptos, err := service.Cryptos(context.Background())
+	cryptos, err := service.Cryptos(context.Background(), nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, cryptos)
 	assert.Len(t, cryptos, 1)
@@ -51,11 +54,42 @@ func TestCryptosService(t *testing.T) {
 	mockAuth.AssertExpectations(t)
 }
 
+// 口座を指定した場合はその口座の暗号通貨のみ返す
+func TestCryptosServiceFilterByAccount(t *testing.T) {
+	mockCryptoRepo := crypto.NewMockCryptoRepository()
+	mockMarketCryptoRepo := marketPrice.NewMockCryptoRepository()
+	mockAuth := auth.NewMockAuthService()
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewCryptoService(mockCryptoRepo, mockAuth, mockMarketCryptoRepo, mockHouseholdAccess)
+
+	userId := uint(1)
+	accountId := uint(2)
+	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+	mockHouseholdAccess.On("ScopeUserIds", mock.Anything, userId, (*string)(nil)).Return([]uint{userId}, nil)
+	mockCryptoRepo.On("FetchCryptoListById", mock.Anything, userId).Return([]model.Crypto{
+		{Model: gorm.Model{ID: 1}, Code: "btc", GetPrice: 5000000, Quantity: 0.1, AccountId: 1},
+		{Model: gorm.Model{ID: 2}, Code: "btc", GetPrice: 9000000, Quantity: 0.2, AccountId: accountId},
+	}, nil)
+	mockMarketCryptoRepo.On("FetchCryptoPrice", "btc").Return(&marketPrice.Crypto{Name: "btc", Price: 10000000}, nil)
+
+	filterAccountId := "2"
+	cryptos, err := service.Cryptos(context.Background(), &filterAccountId, nil)
+	assert.NoError(t, err)
+	assert.Len(t, cryptos, 1)
+	assert.Equal(t, "2", cryptos[0].ID)
+	assert.Equal(t, "2", cryptos[0].AccountID)
+
+	invalidAccountId := "abc"
+	_, err = service.Cryptos(context.Background(), &invalidAccountId, nil)
+	assert.EqualError(t, err, "input: 入力された口座idが無効です")
+}
+
 func TestCreateCryptoService(t *testing.T) {
 	mockCryptoRepo := crypto.NewMockCryptoRepository()
     mockMarketCryptoRepo := marketPrice.NewMockCryptoRepository()
 	mockAuth := auth.NewMockAuthService()
-	service := NewCryptoService(mockCryptoRepo, mockAuth, mockMarketCryptoRepo)
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewCryptoService(mockCryptoRepo, mockAuth, mockMarketCryptoRepo, mockHouseholdAccess)
 
 	// モックの期待値設定
 	userId := uint(1)
@@ -99,11 +133,13 @@ func TestUpdateCryptoService(t *testing.T) {
 	mockCryptoRepo := crypto.NewMockCryptoRepository()
 	mockMarketCryptoRepo := marketPrice.NewMockCryptoRepository()
 	mockAuth := auth.NewMockAuthService()
-	service := NewCryptoService(mockCryptoRepo, mockAuth, mockMarketCryptoRepo)
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewCryptoService(mockCryptoRepo, mockAuth, mockMarketCryptoRepo, mockHouseholdAccess)
 
 	// モックの期待値設定
 	userId := uint(1)
 	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+	mockHouseholdAccess.On("AuthorizeEdit", mock.Anything, userId, mock.Anything, mock.Anything).Return(nil)
 
 	updateId := uint(1)
 	updatedCrypto := &model.Crypto{
@@ -142,11 +178,13 @@ func TestDeleteCryptoService(t *testing.T) {
 	mockCryptoRepo := crypto.NewMockCryptoRepository()
 	mockMarketCryptoRepo := marketPrice.NewMockCryptoRepository()
 	mockAuth := auth.NewMockAuthService()
-	service := NewCryptoService(mockCryptoRepo, mockAuth, mockMarketCryptoRepo)
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewCryptoService(mockCryptoRepo, mockAuth, mockMarketCryptoRepo, mockHouseholdAccess)
 
 	// モックの期待値設定
 	userId := uint(1)
 	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+	mockHouseholdAccess.On("AuthorizeEdit", mock.Anything, userId, mock.Anything, mock.Anything).Return(nil)
 
 	deleteId := uint(1)
 	mockCryptoRepo.On("DeleteCrypto", mock.Anything, deleteId).Return(nil)
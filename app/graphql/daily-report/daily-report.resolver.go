@@ -0,0 +1,18 @@
+package dailyreport
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+)
+
+type Resolver struct {
+    DailyReportService DailyReportService
+}
+
+func NewResolver(dailyReportService DailyReportService) *Resolver {
+    return &Resolver{DailyReportService: dailyReportService}
+}
+
+func (r *Resolver) DailyReport(ctx context.Context, date string) (*generated.DailyReport, error) {
+    return r.DailyReportService.DailyReport(ctx, date)
+}
@@ -0,0 +1,34 @@
+package dailyreport
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDailyReportService は DailyReportService のモックです。
+type MockDailyReportService struct {
+    mock.Mock
+}
+
+func (m *MockDailyReportService) DailyReport(ctx context.Context, date string) (*generated.DailyReport, error) {
+    args := m.Called(ctx, date)
+    return args.Get(0).(*generated.DailyReport), args.Error(1)
+}
+
+func TestDailyReport(t *testing.T) {
+    mockService := new(MockDailyReportService)
+    resolver := NewResolver(mockService)
+
+    report := &generated.DailyReport{Date: "2024-01-11", TotalAmount: 2300000}
+    mockService.On("DailyReport", mock.Anything, "2024-01-11").Return(report, nil)
+
+    result, err := resolver.DailyReport(context.Background(), "2024-01-11")
+
+    assert.NoError(t, err)
+    assert.Equal(t, report, result)
+    mockService.AssertExpectations(t)
+}
@@ -0,0 +1,55 @@
+package dailyreport
+
+import (
+	"context"
+	"encoding/json"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	dailyReport "my-us-stock-backend/app/repository/daily-report"
+	"time"
+)
+
+// DailyReportService インターフェースの定義
+type DailyReportService interface {
+    DailyReport(ctx context.Context, date string) (*generated.DailyReport, error)
+}
+
+// DefaultDailyReportService 構造体の定義
+type DefaultDailyReportService struct {
+    DailyReportRepo dailyReport.DailyReportRepository
+    Auth auth.AuthService
+}
+
+// NewDailyReportService は DefaultDailyReportService の新しいインスタンスを作成します
+func NewDailyReportService(dailyReportRepo dailyReport.DailyReportRepository, auth auth.AuthService) DailyReportService {
+    return &DefaultDailyReportService{
+        DailyReportRepo: dailyReportRepo,
+        Auth: auth,
+    }
+}
+
+// DailyReport はログインユーザーの指定日のサマリーを取得します(未作成の場合はnull)
+// サマリーの作成・送信は app/common/daily-report で資産スナップショットの登録時に行います
+func (s *DefaultDailyReportService) DailyReport(ctx context.Context, date string) (*generated.DailyReport, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    if _, err := time.Parse("2006-01-02", date); err != nil {
+        return nil, utils.DefaultGraphQLError("日付はYYYY-MM-DD形式で指定してください")
+    }
+
+    modelReport, err := s.DailyReportRepo.FindDailyReport(ctx, userId, date)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    if modelReport == nil {
+        return nil, nil
+    }
+    var report generated.DailyReport
+    if err := json.Unmarshal([]byte(modelReport.Content), &report); err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return &report, nil
+}
@@ -0,0 +1,83 @@
+package dailyreport
+
+import (
+	"context"
+	"encoding/json"
+	"my-us-stock-backend/app/common/auth"
+	commonDailyReport "my-us-stock-backend/app/common/daily-report"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	dailyReport "my-us-stock-backend/app/repository/daily-report"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testMocks struct {
+    dailyReportRepo *dailyReport.MockDailyReportRepository
+    auth            *auth.MockAuthService
+}
+
+func newTestService() (DailyReportService, testMocks) {
+    mocks := testMocks{
+        dailyReportRepo: dailyReport.NewMockDailyReportRepository(),
+        auth:            auth.NewMockAuthService(),
+    }
+    service := NewDailyReportService(mocks.dailyReportRepo, mocks.auth)
+    return service, mocks
+}
+
+func TestDailyReportService(t *testing.T) {
+    service, mocks := newTestService()
+    userId := uint(1)
+
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mocks.dailyReportRepo.On("FindDailyReport", mock.Anything, userId, "2024-01-11").Return(&model.DailyReport{Content: `{"date":"2024-01-11","totalAmount":2300000,"assetClassChanges":[],"topGainers":[],"topLosers":[],"upcomingDividends":[]}`}, nil)
+    mocks.dailyReportRepo.On("FindDailyReport", mock.Anything, userId, "2024-01-12").Return(nil, nil)
+
+    report, err := service.DailyReport(context.Background(), "2024-01-11")
+    assert.NoError(t, err)
+    assert.Equal(t, 2300000.0, report.TotalAmount)
+
+    report, err = service.DailyReport(context.Background(), "2024-01-12")
+    assert.NoError(t, err)
+    assert.Nil(t, report)
+
+    _, err = service.DailyReport(context.Background(), "2024/01/11")
+    assert.Error(t, err)
+}
+
+// 送信時に保存したサマリーをそのまま GraphQL の型として返せる
+func TestDailyReportServiceSavedContent(t *testing.T) {
+    service, mocks := newTestService()
+    userId := uint(1)
+    fxImpact := 22000.0
+    content, _ := json.Marshal(commonDailyReport.Report{
+        Date: "2024-01-11", TotalAmount: 2300000, FxImpact: &fxImpact,
+        AssetClassChanges: []*commonDailyReport.AssetClassChange{{AssetClass: commonDailyReport.AssetClassStock, Amount: 1500000, Change: 50000}},
+        TopGainers: []*commonDailyReport.HoldingMover{{Code: "MSFT", ChangeRate: 3.09}},
+    })
+
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mocks.dailyReportRepo.On("FindDailyReport", mock.Anything, userId, "2024-01-11").Return(&model.DailyReport{Content: string(content)}, nil)
+
+    report, err := service.DailyReport(context.Background(), "2024-01-11")
+
+    assert.NoError(t, err)
+    assert.Equal(t, 22000.0, *report.FxImpact)
+    assert.Equal(t, generated.AssetClassStock, report.AssetClassChanges[0].AssetClass)
+    assert.Equal(t, 50000.0, report.AssetClassChanges[0].Change)
+    assert.Equal(t, "MSFT", report.TopGainers[0].Code)
+}
+
+func TestDailyReportServiceUnauthenticated(t *testing.T) {
+    service, mocks := newTestService()
+
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(0), nil)
+
+    _, err := service.DailyReport(context.Background(), "2024-01-11")
+
+    assert.Error(t, err)
+    mocks.dailyReportRepo.AssertNotCalled(t, "FindDailyReport", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,69 @@
+package deletedholding
+
+import (
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	repoDeletedHolding "my-us-stock-backend/app/repository/deleted-holding"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 論理削除済みの保有資産を種類をまたいで削除日時の新しい順に並べる
+// holdingType を指定した場合はその種類のみ
+func convertToDeletedHoldings(dto repoDeletedHolding.DeletedHoldingsDto, holdingType *generated.HoldingType, retention time.Duration) []*generated.DeletedHolding {
+    holdings := []*generated.DeletedHolding{}
+    include := func(t generated.HoldingType) bool {
+        return holdingType == nil || *holdingType == t
+    }
+    if include(generated.HoldingTypeUsStock) {
+        for _, usStock := range dto.UsStocks {
+            holding := newDeletedHolding(usStock.Model, generated.HoldingTypeUsStock, usStock.Code, retention)
+            holding.Quantity, holding.GetPrice = floatPtr(usStock.Quantity), floatPtr(usStock.GetPrice)
+            holdings = append(holdings, holding)
+        }
+    }
+    if include(generated.HoldingTypeCrypto) {
+        for _, crypto := range dto.Cryptos {
+            holding := newDeletedHolding(crypto.Model, generated.HoldingTypeCrypto, crypto.Code, retention)
+            holding.Quantity, holding.GetPrice = floatPtr(crypto.Quantity), floatPtr(crypto.GetPrice)
+            holdings = append(holdings, holding)
+        }
+    }
+    if include(generated.HoldingTypeJapanFund) {
+        for _, japanFund := range dto.JapanFunds {
+            holding := newDeletedHolding(japanFund.Model, generated.HoldingTypeJapanFund, japanFund.Code, retention)
+            name := japanFund.Name
+            holding.Name, holding.GetPrice, holding.GetPriceTotal = &name, floatPtr(japanFund.GetPrice), floatPtr(japanFund.GetPriceTotal)
+            holdings = append(holdings, holding)
+        }
+    }
+    if include(generated.HoldingTypeFixedIncomeAsset) {
+        for _, fixedIncomeAsset := range dto.FixedIncomeAssets {
+            holding := newDeletedHolding(fixedIncomeAsset.Model, generated.HoldingTypeFixedIncomeAsset, fixedIncomeAsset.Code, retention)
+            holding.GetPriceTotal = floatPtr(fixedIncomeAsset.GetPriceTotal)
+            holdings = append(holdings, holding)
+        }
+    }
+    // RFC3339(UTC)の文字列は時刻順に並ぶ
+    sort.SliceStable(holdings, func(i, j int) bool {
+        return holdings[i].DeletedAt > holdings[j].DeletedAt
+    })
+    return holdings
+}
+
+func newDeletedHolding(model gorm.Model, holdingType generated.HoldingType, code string, retention time.Duration) *generated.DeletedHolding {
+    deletedAt := model.DeletedAt.Time.UTC()
+    return &generated.DeletedHolding{
+        ID:        utils.ConvertIdToString(model.ID),
+        Type:      holdingType,
+        Code:      code,
+        DeletedAt: deletedAt.Format(time.RFC3339),
+        PurgeAt:   deletedAt.Add(retention).Format(time.RFC3339),
+    }
+}
+
+func floatPtr(value float64) *float64 {
+    return &value
+}
@@ -0,0 +1,34 @@
+package deletedholding
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+)
+
+type Resolver struct {
+    DeletedHoldingService DeletedHoldingService
+}
+
+func NewResolver(deletedHoldingService DeletedHoldingService) *Resolver {
+    return &Resolver{DeletedHoldingService: deletedHoldingService}
+}
+
+func (r *Resolver) DeletedHoldings(ctx context.Context, holdingType *generated.HoldingType) ([]*generated.DeletedHolding, error) {
+    return r.DeletedHoldingService.DeletedHoldings(ctx, holdingType)
+}
+
+func (r *Resolver) RestoreUsStock(ctx context.Context, id string) (bool, error) {
+    return r.DeletedHoldingService.RestoreUsStock(ctx, id)
+}
+
+func (r *Resolver) RestoreCrypto(ctx context.Context, id string) (bool, error) {
+    return r.DeletedHoldingService.RestoreCrypto(ctx, id)
+}
+
+func (r *Resolver) RestoreJapanFund(ctx context.Context, id string) (bool, error) {
+    return r.DeletedHoldingService.RestoreJapanFund(ctx, id)
+}
+
+func (r *Resolver) RestoreFixedIncomeAsset(ctx context.Context, id string) (bool, error) {
+    return r.DeletedHoldingService.RestoreFixedIncomeAsset(ctx, id)
+}
@@ -0,0 +1,67 @@
+package deletedholding
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDeletedHoldingService は DeletedHoldingService のモックです。
+type MockDeletedHoldingService struct {
+    mock.Mock
+}
+
+func (m *MockDeletedHoldingService) DeletedHoldings(ctx context.Context, holdingType *generated.HoldingType) ([]*generated.DeletedHolding, error) {
+    args := m.Called(ctx, holdingType)
+    return args.Get(0).([]*generated.DeletedHolding), args.Error(1)
+}
+
+func (m *MockDeletedHoldingService) RestoreUsStock(ctx context.Context, id string) (bool, error) {
+    args := m.Called(ctx, id)
+    return args.Bool(0), args.Error(1)
+}
+
+func (m *MockDeletedHoldingService) RestoreCrypto(ctx context.Context, id string) (bool, error) {
+    args := m.Called(ctx, id)
+    return args.Bool(0), args.Error(1)
+}
+
+func (m *MockDeletedHoldingService) RestoreJapanFund(ctx context.Context, id string) (bool, error) {
+    args := m.Called(ctx, id)
+    return args.Bool(0), args.Error(1)
+}
+
+func (m *MockDeletedHoldingService) RestoreFixedIncomeAsset(ctx context.Context, id string) (bool, error) {
+    args := m.Called(ctx, id)
+    return args.Bool(0), args.Error(1)
+}
+
+func TestDeletedHoldingsResolver(t *testing.T) {
+    mockService := new(MockDeletedHoldingService)
+    resolver := NewResolver(mockService)
+
+    holdings := []*generated.DeletedHolding{{ID: "3", Type: generated.HoldingTypeUsStock, Code: "AAPL"}}
+    mockService.On("DeletedHoldings", mock.Anything, (*generated.HoldingType)(nil)).Return(holdings, nil)
+
+    result, err := resolver.DeletedHoldings(context.Background(), nil)
+
+    assert.NoError(t, err)
+    assert.Equal(t, holdings, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestRestoreFixedIncomeAssetResolver(t *testing.T) {
+    mockService := new(MockDeletedHoldingService)
+    resolver := NewResolver(mockService)
+
+    mockService.On("RestoreFixedIncomeAsset", mock.Anything, "7").Return(true, nil)
+
+    result, err := resolver.RestoreFixedIncomeAsset(context.Background(), "7")
+
+    assert.NoError(t, err)
+    assert.True(t, result)
+    mockService.AssertExpectations(t)
+}
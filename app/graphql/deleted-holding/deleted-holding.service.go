@@ -0,0 +1,83 @@
+package deletedholding
+
+import (
+	"context"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	repoDeletedHolding "my-us-stock-backend/app/repository/deleted-holding"
+	"time"
+)
+
+// DeletedHoldingService インターフェースの定義
+type DeletedHoldingService interface {
+    DeletedHoldings(ctx context.Context, holdingType *generated.HoldingType) ([]*generated.DeletedHolding, error)
+    RestoreUsStock(ctx context.Context, id string) (bool, error)
+    RestoreCrypto(ctx context.Context, id string) (bool, error)
+    RestoreJapanFund(ctx context.Context, id string) (bool, error)
+    RestoreFixedIncomeAsset(ctx context.Context, id string) (bool, error)
+}
+
+// DefaultDeletedHoldingService 構造体の定義
+type DefaultDeletedHoldingService struct {
+    DeletedHoldingRepo repoDeletedHolding.DeletedHoldingRepository
+    Auth auth.AuthService
+    Retention time.Duration
+}
+
+// NewDeletedHoldingService は DefaultDeletedHoldingService の新しいインスタンスを作成します
+func NewDeletedHoldingService(deletedHoldingRepo repoDeletedHolding.DeletedHoldingRepository, auth auth.AuthService, retention time.Duration) DeletedHoldingService {
+    return &DefaultDeletedHoldingService{
+        DeletedHoldingRepo: deletedHoldingRepo,
+        Auth: auth,
+        Retention: retention,
+    }
+}
+
+// DeletedHoldings はログインユーザーの削除済みの保有資産を取得します
+func (s *DefaultDeletedHoldingService) DeletedHoldings(ctx context.Context, holdingType *generated.HoldingType) ([]*generated.DeletedHolding, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    dto, err := s.DeletedHoldingRepo.FetchDeletedHoldings(ctx, userId)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return convertToDeletedHoldings(*dto, holdingType, s.Retention), nil
+}
+
+// RestoreUsStock は削除済みの米国株式を復元します
+func (s *DefaultDeletedHoldingService) RestoreUsStock(ctx context.Context, id string) (bool, error) {
+    return s.restore(ctx, id, s.DeletedHoldingRepo.RestoreUsStock)
+}
+
+// RestoreCrypto は削除済みの暗号通貨を復元します
+func (s *DefaultDeletedHoldingService) RestoreCrypto(ctx context.Context, id string) (bool, error) {
+    return s.restore(ctx, id, s.DeletedHoldingRepo.RestoreCrypto)
+}
+
+// RestoreJapanFund は削除済みの投資信託を復元します
+func (s *DefaultDeletedHoldingService) RestoreJapanFund(ctx context.Context, id string) (bool, error) {
+    return s.restore(ctx, id, s.DeletedHoldingRepo.RestoreJapanFund)
+}
+
+// RestoreFixedIncomeAsset は削除済みの固定利回り資産を復元します
+func (s *DefaultDeletedHoldingService) RestoreFixedIncomeAsset(ctx context.Context, id string) (bool, error) {
+    return s.restore(ctx, id, s.DeletedHoldingRepo.RestoreFixedIncomeAsset)
+}
+
+func (s *DefaultDeletedHoldingService) restore(ctx context.Context, id string, restore func(ctx context.Context, id uint, userId uint) error) (bool, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return false, utils.UnauthenticatedError("Invalid user ID")
+    }
+    restoreId, err := utils.ConvertIdToUint(id)
+    if err != nil || restoreId == 0 {
+        return false, utils.DefaultGraphQLError("入力されたidが無効です")
+    }
+    if err := restore(ctx, restoreId, userId); err != nil {
+        return false, utils.DefaultGraphQLError(err.Error())
+    }
+    return true, nil
+}
@@ -0,0 +1,103 @@
+package deletedholding
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	repoDeletedHolding "my-us-stock-backend/app/repository/deleted-holding"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+const testRetention = 30 * 24 * time.Hour
+
+type testMocks struct {
+    deletedHoldingRepo *repoDeletedHolding.MockDeletedHoldingRepository
+    auth               *auth.MockAuthService
+}
+
+func newTestService() (DeletedHoldingService, testMocks) {
+    mocks := testMocks{
+        deletedHoldingRepo: repoDeletedHolding.NewMockDeletedHoldingRepository(),
+        auth:               auth.NewMockAuthService(),
+    }
+    service := NewDeletedHoldingService(mocks.deletedHoldingRepo, mocks.auth, testRetention)
+    return service, mocks
+}
+
+func deletedModel(id uint, deletedAt time.Time) gorm.Model {
+    return gorm.Model{ID: id, DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}}
+}
+
+func TestDeletedHoldings(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    mocks.deletedHoldingRepo.On("FetchDeletedHoldings", mock.Anything, uint(1)).Return(&repoDeletedHolding.DeletedHoldingsDto{
+        UsStocks:          []model.UsStock{{Model: deletedModel(3, time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)), Code: "AAPL", Quantity: 10, GetPrice: 150}},
+        JapanFunds:        []model.JapanFund{{Model: deletedModel(5, time.Date(2024, 1, 12, 9, 0, 0, 0, time.UTC)), Code: "0331418A", Name: "eMAXIS Slim 米国株式(S&P500)", GetPrice: 20000, GetPriceTotal: 200000}},
+        FixedIncomeAssets: []model.FixedIncomeAsset{{Model: deletedModel(7, time.Date(2024, 1, 11, 9, 0, 0, 0, time.UTC)), Code: "社債A", GetPriceTotal: 500000}},
+    }, nil)
+
+    holdings, err := service.DeletedHoldings(context.Background(), nil)
+
+    assert.NoError(t, err)
+    assert.Len(t, holdings, 3)
+    // 削除日時の新しい順
+    assert.Equal(t, generated.HoldingTypeJapanFund, holdings[0].Type)
+    assert.Equal(t, "eMAXIS Slim 米国株式(S&P500)", *holdings[0].Name)
+    assert.Nil(t, holdings[0].Quantity)
+    assert.Equal(t, generated.HoldingTypeFixedIncomeAsset, holdings[1].Type)
+    assert.Equal(t, 500000.0, *holdings[1].GetPriceTotal)
+    assert.Nil(t, holdings[1].GetPrice)
+    assert.Equal(t, &generated.DeletedHolding{
+        ID:        "3",
+        Type:      generated.HoldingTypeUsStock,
+        Code:      "AAPL",
+        Quantity:  floatPtr(10),
+        GetPrice:  floatPtr(150),
+        DeletedAt: "2024-01-10T09:00:00Z",
+        PurgeAt:   "2024-02-09T09:00:00Z",
+    }, holdings[2])
+
+    usStock := generated.HoldingTypeUsStock
+    holdings, err = service.DeletedHoldings(context.Background(), &usStock)
+    assert.NoError(t, err)
+    assert.Len(t, holdings, 1)
+    assert.Equal(t, "AAPL", holdings[0].Code)
+}
+
+func TestDeletedHoldingsUnauthenticated(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(0), errors.New("invalid token"))
+
+    holdings, err := service.DeletedHoldings(context.Background(), nil)
+
+    assert.Nil(t, holdings)
+    assert.Error(t, err)
+    mocks.deletedHoldingRepo.AssertNotCalled(t, "FetchDeletedHoldings", mock.Anything, mock.Anything)
+}
+
+func TestRestore(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    mocks.deletedHoldingRepo.On("RestoreUsStock", mock.Anything, uint(3), uint(1)).Return(nil)
+    mocks.deletedHoldingRepo.On("RestoreCrypto", mock.Anything, uint(4), uint(1)).Return(errors.New("同じ銘柄が既に登録されているため復元できません"))
+
+    result, err := service.RestoreUsStock(context.Background(), "3")
+    assert.NoError(t, err)
+    assert.True(t, result)
+
+    result, err = service.RestoreCrypto(context.Background(), "4")
+    assert.False(t, result)
+    assert.EqualError(t, err, "input: 同じ銘柄が既に登録されているため復元できません")
+
+    _, err = service.RestoreJapanFund(context.Background(), "abc")
+    assert.EqualError(t, err, "input: 入力されたidが無効です")
+    mocks.deletedHoldingRepo.AssertNotCalled(t, "RestoreJapanFund", mock.Anything, mock.Anything, mock.Anything)
+}
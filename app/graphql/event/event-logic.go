@@ -0,0 +1,21 @@
+package event
+
+import (
+	commonEvent "my-us-stock-backend/app/common/event"
+	"my-us-stock-backend/app/graphql/generated"
+)
+
+// イベントをレスポンスの型に変換する
+func convertToCalendarEvents(events []commonEvent.Event) []*generated.CalendarEvent {
+    calendarEvents := make([]*generated.CalendarEvent, len(events))
+    for i, event := range events {
+        calendarEvents[i] = &generated.CalendarEvent{
+            Ticker: event.Ticker,
+            Type:   generated.CalendarEventType(event.Type),
+            Date:   event.Date,
+            Title:  event.Title,
+            Amount: event.Amount,
+        }
+    }
+    return calendarEvents
+}
@@ -0,0 +1,22 @@
+package event
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+)
+
+type Resolver struct {
+    EventService EventService
+}
+
+func NewResolver(eventService EventService) *Resolver {
+    return &Resolver{EventService: eventService}
+}
+
+func (r *Resolver) UpcomingEvents(ctx context.Context, days int) ([]*generated.CalendarEvent, error) {
+    return r.EventService.UpcomingEvents(ctx, days)
+}
+
+func (r *Resolver) IssueCalendarToken(ctx context.Context) (string, error) {
+    return r.EventService.IssueCalendarToken(ctx)
+}
@@ -0,0 +1,61 @@
+package event
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockEventService は EventService のモックです。
+type MockEventService struct {
+    mock.Mock
+}
+
+func (m *MockEventService) UpcomingEvents(ctx context.Context, days int) ([]*generated.CalendarEvent, error) {
+    args := m.Called(ctx, days)
+    return args.Get(0).([]*generated.CalendarEvent), args.Error(1)
+}
+
+func (m *MockEventService) FetchUpcomingEventsByUserId(ctx context.Context, userId uint, days int) ([]*generated.CalendarEvent, error) {
+    args := m.Called(ctx, userId, days)
+    return args.Get(0).([]*generated.CalendarEvent), args.Error(1)
+}
+
+func (m *MockEventService) IssueCalendarToken(ctx context.Context) (string, error) {
+    args := m.Called(ctx)
+    return args.String(0), args.Error(1)
+}
+
+// UpcomingEvents メソッドのテスト
+func TestUpcomingEvents(t *testing.T) {
+    mockService := new(MockEventService)
+    resolver := NewResolver(mockService)
+
+    mockEvents := []*generated.CalendarEvent{
+        {Ticker: "AAPL", Type: generated.CalendarEventTypeEarnings, Date: "2024-01-31", Title: "AAPL 決算発表"},
+    }
+    mockService.On("UpcomingEvents", mock.Anything, 30).Return(mockEvents, nil)
+
+    result, err := resolver.UpcomingEvents(context.Background(), 30)
+
+    assert.NoError(t, err)
+    assert.Equal(t, mockEvents, result)
+    mockService.AssertExpectations(t)
+}
+
+// IssueCalendarToken メソッドのテスト
+func TestIssueCalendarToken(t *testing.T) {
+    mockService := new(MockEventService)
+    resolver := NewResolver(mockService)
+
+    mockService.On("IssueCalendarToken", mock.Anything).Return("issued-token", nil)
+
+    result, err := resolver.IssueCalendarToken(context.Background())
+
+    assert.NoError(t, err)
+    assert.Equal(t, "issued-token", result)
+    mockService.AssertExpectations(t)
+}
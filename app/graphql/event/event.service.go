@@ -0,0 +1,72 @@
+package event
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"my-us-stock-backend/app/common/auth"
+	commonEvent "my-us-stock-backend/app/common/event"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	"my-us-stock-backend/app/repository/assets/stock"
+	"my-us-stock-backend/app/repository/calendar"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+)
+
+// EventService インターフェースの定義
+type EventService interface {
+    UpcomingEvents(ctx context.Context, days int) ([]*generated.CalendarEvent, error)
+    FetchUpcomingEventsByUserId(ctx context.Context, userId uint, days int) ([]*generated.CalendarEvent, error)
+    IssueCalendarToken(ctx context.Context) (string, error)
+}
+
+// DefaultEventService 構造体の定義
+type DefaultEventService struct {
+    Auth auth.AuthService
+    EventCollector commonEvent.EventCollector
+    CalendarTokenRepo calendar.CalendarTokenRepository
+}
+
+// NewEventService は DefaultEventService の新しいインスタンスを作成します
+func NewEventService(auth auth.AuthService, stockRepo stock.UsStockRepository, marketPriceRepo marketPrice.MarketPriceRepository, calendarTokenRepo calendar.CalendarTokenRepository) EventService {
+    return &DefaultEventService{Auth: auth, EventCollector: commonEvent.NewEventCollector(stockRepo, marketPriceRepo), CalendarTokenRepo: calendarTokenRepo}
+}
+
+// UpcomingEvents はログインユーザーの保有銘柄の今後のイベントを取得します
+func (s *DefaultEventService) UpcomingEvents(ctx context.Context, days int) ([]*generated.CalendarEvent, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    events, err := s.FetchUpcomingEventsByUserId(ctx, userId, days)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return events, nil
+}
+
+// FetchUpcomingEventsByUserId は指定ユーザーの保有銘柄について、今日から指定日数以内の決算発表・権利落ち・配当支払のイベントを取得します
+func (s *DefaultEventService) FetchUpcomingEventsByUserId(ctx context.Context, userId uint, days int) ([]*generated.CalendarEvent, error) {
+    events, err := s.EventCollector.FetchUpcomingEvents(ctx, userId, days)
+    if err != nil {
+        return nil, err
+    }
+    return convertToCalendarEvents(events), nil
+}
+
+// IssueCalendarToken はiCalendarフィード購読用のトークンを発行します(再発行すると以前のトークンは無効になります)
+func (s *DefaultEventService) IssueCalendarToken(ctx context.Context) (string, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return "", utils.UnauthenticatedError("Invalid user ID")
+    }
+    bytes := make([]byte, 32)
+    if _, err := rand.Read(bytes); err != nil {
+        return "", utils.DefaultGraphQLError(err.Error())
+    }
+    calendarToken, err := s.CalendarTokenRepo.UpsertCalendarToken(ctx, userId, hex.EncodeToString(bytes))
+    if err != nil {
+        return "", utils.DefaultGraphQLError(err.Error())
+    }
+    return calendarToken.Token, nil
+}
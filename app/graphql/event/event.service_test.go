@@ -0,0 +1,123 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/repository/assets/stock"
+	"my-us-stock-backend/app/repository/calendar"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// 今日から指定日数後の日付を返す
+func daysLater(days int) string {
+    return time.Now().AddDate(0, 0, days).Format("2006-01-02")
+}
+
+// UpcomingEvents は期間内のイベントのみを日付順に返却する
+func TestUpcomingEventsService(t *testing.T) {
+    mockAuth := auth.NewMockAuthService()
+    mockStockRepo := stock.NewMockUsStockRepository()
+    mockMarketPriceRepo := marketPrice.NewMockMarketPriceRepository()
+    service := NewEventService(mockAuth, mockStockRepo, mockMarketPriceRepo, calendar.NewMockCalendarTokenRepository())
+
+    userId := uint(1)
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mockStockRepo.On("FetchUsStockListById", mock.Anything, userId).Return([]model.UsStock{
+        {Code: "AAPL", UserId: userId},
+        {Code: "KO", UserId: userId},
+        {Code: "AAPL", UserId: userId},
+    }, nil)
+    mockMarketPriceRepo.On("FetchMarketPriceList", mock.Anything, []string{"AAPL", "KO"}).Return([]marketPrice.MarketPriceDto{
+        {Ticker: "AAPL", EarningsAnnouncement: daysLater(10) + "T20:00:00.000+0000"},
+        // 期間外
+        {Ticker: "KO", EarningsAnnouncement: daysLater(60) + "T10:59:00.000+0000"},
+    }, nil)
+    mockMarketPriceRepo.On("FetchDividendHistory", mock.Anything, "AAPL").Return([]marketPrice.DividendHistoryDto{
+        {ExDate: daysLater(5), PaymentDate: daysLater(40), Amount: 0.24},
+        {ExDate: daysLater(-80), PaymentDate: daysLater(-70), Amount: 0.24},
+    }, nil)
+    // 配当情報が取得できない銘柄はスキップされる
+    mockMarketPriceRepo.On("FetchDividendHistory", mock.Anything, "KO").Return([]marketPrice.DividendHistoryDto(nil), errors.New("rate limit exceeded"))
+
+    events, err := service.UpcomingEvents(context.Background(), 30)
+
+    assert.NoError(t, err)
+    amount := 0.24
+    assert.Equal(t, []*generated.CalendarEvent{
+        {Ticker: "AAPL", Type: generated.CalendarEventTypeExDividend, Date: daysLater(5), Title: "AAPL 権利落ち日 ($0.24)", Amount: &amount},
+        {Ticker: "AAPL", Type: generated.CalendarEventTypeEarnings, Date: daysLater(10), Title: "AAPL 決算発表"},
+    }, events)
+    mockStockRepo.AssertExpectations(t)
+    mockMarketPriceRepo.AssertExpectations(t)
+}
+
+// 日数が範囲外の場合はエラー
+func TestUpcomingEventsServiceInvalidDays(t *testing.T) {
+    mockAuth := auth.NewMockAuthService()
+    mockStockRepo := stock.NewMockUsStockRepository()
+    service := NewEventService(mockAuth, mockStockRepo, marketPrice.NewMockMarketPriceRepository(), calendar.NewMockCalendarTokenRepository())
+
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+
+    _, err := service.UpcomingEvents(context.Background(), 0)
+    assert.Error(t, err)
+    _, err = service.UpcomingEvents(context.Background(), 366)
+    assert.Error(t, err)
+    mockStockRepo.AssertNotCalled(t, "FetchUsStockListById", mock.Anything, mock.Anything)
+}
+
+// 保有銘柄がない場合は外部APIを呼ばずに空配列を返却する
+func TestUpcomingEventsServiceNoHoldings(t *testing.T) {
+    mockAuth := auth.NewMockAuthService()
+    mockStockRepo := stock.NewMockUsStockRepository()
+    mockMarketPriceRepo := marketPrice.NewMockMarketPriceRepository()
+    service := NewEventService(mockAuth, mockStockRepo, mockMarketPriceRepo, calendar.NewMockCalendarTokenRepository())
+
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    mockStockRepo.On("FetchUsStockListById", mock.Anything, uint(1)).Return([]model.UsStock{}, nil)
+
+    events, err := service.UpcomingEvents(context.Background(), 30)
+
+    assert.NoError(t, err)
+    assert.Empty(t, events)
+    mockMarketPriceRepo.AssertNotCalled(t, "FetchMarketPriceList", mock.Anything, mock.Anything)
+}
+
+// 未ログインの場合はエラー
+func TestUpcomingEventsServiceUnauthenticated(t *testing.T) {
+    mockAuth := auth.NewMockAuthService()
+    service := NewEventService(mockAuth, stock.NewMockUsStockRepository(), marketPrice.NewMockMarketPriceRepository(), calendar.NewMockCalendarTokenRepository())
+
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(0), errors.New("unauthenticated"))
+
+    _, err := service.UpcomingEvents(context.Background(), 30)
+    assert.Error(t, err)
+}
+
+// IssueCalendarToken はランダムなトークンを発行して保存する
+func TestIssueCalendarTokenService(t *testing.T) {
+    mockAuth := auth.NewMockAuthService()
+    mockCalendarTokenRepo := calendar.NewMockCalendarTokenRepository()
+    service := NewEventService(mockAuth, stock.NewMockUsStockRepository(), marketPrice.NewMockMarketPriceRepository(), mockCalendarTokenRepo)
+
+    userId := uint(1)
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mockCalendarTokenRepo.On("UpsertCalendarToken", mock.Anything, userId, mock.AnythingOfType("string")).Return(&model.CalendarToken{UserId: userId, Token: "issued-token"}, nil)
+
+    token, err := service.IssueCalendarToken(context.Background())
+
+    assert.NoError(t, err)
+    assert.Equal(t, "issued-token", token)
+    // 64文字(32バイト)の16進数文字列で保存される
+    savedToken := mockCalendarTokenRepo.Calls[0].Arguments.Get(2).(string)
+    assert.Len(t, savedToken, 64)
+    mockCalendarTokenRepo.AssertExpectations(t)
+}
@@ -0,0 +1,185 @@
+package fixedincomeasset
+
+import (
+	"fmt"
+	"math"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	FixedIncome "my-us-stock-backend/app/repository/assets/fixed-income"
+	"sort"
+	"time"
+)
+
+// キャッシュフローの取得期間(月数)の上限
+const maxCashFlowMonths = 120
+
+// 日付(YYYY-MM-DD)を変換する(未指定の場合はnil)
+func parseDate(date *string) (*time.Time, error) {
+    if date == nil {
+        return nil, nil
+    }
+    parsed, err := time.Parse("2006-01-02", *date)
+    if err != nil {
+        return nil, fmt.Errorf("日付はYYYY-MM-DD形式で指定してください")
+    }
+    return &parsed, nil
+}
+
+// 日付をYYYY-MM-DD形式の文字列に変換する(未設定の場合はnil)
+func formatDate(date *time.Time) *string {
+    if date == nil {
+        return nil
+    }
+    formatted := date.UTC().Format("2006-01-02")
+    return &formatted
+}
+
+// 満期日・利払い頻度・償還金額の入力値を検証する
+func validateMaturityInput(issueDate *time.Time, maturityDate *time.Time, couponFrequency *generated.CouponFrequency, paymentMonth []int, redemptionAmount *float64) error {
+    if issueDate != nil && maturityDate != nil && !maturityDate.After(*issueDate) {
+        return fmt.Errorf("満期日は発行日より後の日付を指定してください")
+    }
+    if couponFrequency != nil {
+        if !couponFrequency.IsValid() {
+            return fmt.Errorf("利払い頻度が無効です")
+        }
+        if count, ok := FixedIncome.PaymentsPerYear[string(*couponFrequency)]; ok && len(paymentMonth) > 0 && len(paymentMonth) != count {
+            return fmt.Errorf("配当支払い月の数が利払い頻度と一致しません")
+        }
+    }
+    if redemptionAmount != nil && *redemptionAmount <= 0 {
+        return fmt.Errorf("償還金額は0より大きい値を指定してください")
+    }
+    return nil
+}
+
+// 評価方法・クリーン価格の入力値を検証する
+func validateValuationInput(valuationMode *generated.FixedIncomeValuationMode, cleanPrice *float64) error {
+    if valuationMode != nil && !valuationMode.IsValid() {
+        return fmt.Errorf("評価方法が無効です")
+    }
+    if cleanPrice != nil && *cleanPrice <= 0 {
+        return fmt.Errorf("時価は0より大きい値を指定してください")
+    }
+    return nil
+}
+
+// 固定利回り資産の通貨を返す
+func currencyOf(modelAsset model.FixedIncomeAsset) generated.FixedIncomeCurrency {
+    if FixedIncome.IsUsd(modelAsset) {
+        return generated.FixedIncomeCurrencyUsd
+    }
+    return generated.FixedIncomeCurrencyJpy
+}
+
+// 固定利回り資産をレスポンスの型に変換する
+// 評価額・経過利息は now 時点で計算し、ドル建ての資産は currentUsdJpy で円に換算する
+func convertToFixedIncomeAsset(modelAsset model.FixedIncomeAsset, currentUsdJpy float64, now time.Time) *generated.FixedIncomeAsset {
+    // pq.Int64Array to []int conversion
+    paymentMonths := make([]int, len(modelAsset.PaymentMonth))
+    for i, month := range modelAsset.PaymentMonth {
+        paymentMonths[i] = int(month)
+    }
+    var couponFrequency *generated.CouponFrequency
+    if modelAsset.CouponFrequency != "" {
+        frequency := generated.CouponFrequency(modelAsset.CouponFrequency)
+        couponFrequency = &frequency
+    }
+    valuationMode := generated.FixedIncomeValuationMode(modelAsset.ValuationMode)
+    if valuationMode == "" {
+        valuationMode = generated.FixedIncomeValuationModeAmortizedCost
+    }
+    status := generated.FixedIncomeStatus(modelAsset.Status)
+    if status == "" {
+        status = generated.FixedIncomeStatusActive
+    }
+    return &generated.FixedIncomeAsset{
+        ID: utils.ConvertIdToString(modelAsset.ID),
+        AccountID: utils.ConvertIdToString(modelAsset.AccountId),
+        Code: modelAsset.Code,
+        GetPriceTotal: modelAsset.GetPriceTotal,
+        DividendRate: modelAsset.DividendRate,
+        UsdJpy: modelAsset.UsdJpy,
+        PaymentMonth: paymentMonths,
+        IssueDate: formatDate(modelAsset.IssueDate),
+        MaturityDate: formatDate(modelAsset.MaturityDate),
+        CouponFrequency: couponFrequency,
+        Currency: currencyOf(modelAsset),
+        RedemptionAmount: FixedIncome.RedemptionAmount(modelAsset),
+        Status: status,
+        ValuationJpy: roundAmount(FixedIncome.ValuationJpy(modelAsset, currentUsdJpy, now)),
+        FxGainLoss: FixedIncome.FxGainLoss(modelAsset, currentUsdJpy),
+        ValuationMode: valuationMode,
+        CleanPrice: modelAsset.CleanPrice,
+        PurchaseDate: formatDate(modelAsset.PurchaseDate),
+        PrincipalValuation: roundAmount(FixedIncome.PrincipalValuation(modelAsset, now)),
+        AccruedInterest: roundAmount(FixedIncome.AccruedInterest(modelAsset, now)),
+    }
+}
+
+// 金額を小数点以下2桁に丸める
+func roundAmount(amount float64) float64 {
+    return math.Round(amount*100) / 100
+}
+
+// buildCashFlows は保有中の固定利回り資産の from から months ヶ月後までのキャッシュフローを日付順に作成します
+func buildCashFlows(modelAssets []model.FixedIncomeAsset, from time.Time, months int) []*generated.FixedIncomeCashFlow {
+    start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+    end := start.AddDate(0, months, 0)
+    cashFlows := []*generated.FixedIncomeCashFlow{}
+    for _, modelAsset := range modelAssets {
+        if modelAsset.Status == FixedIncome.StatusMatured {
+            continue
+        }
+        // キャッシュフローは資産の通貨建てで返す
+        newCashFlow := func(date time.Time, cashFlowType generated.FixedIncomeCashFlowType, amount float64) *generated.FixedIncomeCashFlow {
+            return &generated.FixedIncomeCashFlow{
+                AssetID: utils.ConvertIdToString(modelAsset.ID),
+                Code: modelAsset.Code,
+                Date: date.Format("2006-01-02"),
+                Type: cashFlowType,
+                Amount: amount,
+                Currency: currencyOf(modelAsset),
+            }
+        }
+        inPeriod := func(date time.Time) bool {
+            return !date.Before(start) && !date.After(end)
+        }
+
+        if modelAsset.CouponFrequency == FixedIncome.CouponFrequencyAtMaturity {
+            // 満期一括の場合は発行日から満期日までの利息を満期日に受け取る
+            if modelAsset.IssueDate != nil && modelAsset.MaturityDate != nil && inPeriod(*modelAsset.MaturityDate) {
+                amount := roundAmount(FixedIncome.AtMaturityInterest(modelAsset))
+                cashFlows = append(cashFlows, newCashFlow(*modelAsset.MaturityDate, generated.FixedIncomeCashFlowTypeCoupon, amount))
+            }
+        } else {
+            paymentMonths := FixedIncome.CouponMonths(modelAsset)
+            amount := roundAmount(FixedIncome.CouponAmount(modelAsset))
+            for month := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(end); month = month.AddDate(0, 1, 0) {
+                for _, couponMonth := range paymentMonths {
+                    if int(month.Month()) != couponMonth {
+                        continue
+                    }
+                    date := FixedIncome.CouponDate(modelAsset, month.Year(), month.Month())
+                    // 発行日以前・満期日以降の利払いは発生しない
+                    if !inPeriod(date) || amount == 0 ||
+                        (modelAsset.IssueDate != nil && !date.After(*modelAsset.IssueDate)) ||
+                        (modelAsset.MaturityDate != nil && date.After(*modelAsset.MaturityDate)) {
+                        continue
+                    }
+                    cashFlows = append(cashFlows, newCashFlow(date, generated.FixedIncomeCashFlowTypeCoupon, amount))
+                }
+            }
+        }
+
+        if modelAsset.MaturityDate != nil && inPeriod(*modelAsset.MaturityDate) {
+            cashFlows = append(cashFlows, newCashFlow(*modelAsset.MaturityDate, generated.FixedIncomeCashFlowTypeRedemption, FixedIncome.RedemptionAmount(modelAsset)))
+        }
+    }
+    // 日付順に並べる(同日の場合は資産の順、利払い・償還の順を保つ)
+    sort.SliceStable(cashFlows, func(i, j int) bool {
+        return cashFlows[i].Date < cashFlows[j].Date
+    })
+    return cashFlows
+}
@@ -13,8 +13,8 @@ func NewResolver(assetService AssetService) *Resolver {
     return &Resolver{AssetService: assetService}
 }
 
-func (r *Resolver) FixedIncomeAssets(ctx context.Context) ([]*generated.FixedIncomeAsset, error) {
-    return r.AssetService.FixedIncomeAssets(ctx)
+func (r *Resolver) FixedIncomeAssets(ctx context.Context, accountId *string, householdId *string) ([]*generated.FixedIncomeAsset, error) {
+    return r.AssetService.FixedIncomeAssets(ctx, accountId, householdId)
 }
 
 func (r *Resolver) CreateFixedIncomeAsset(ctx context.Context, input generated.CreateFixedIncomeAssetInput) (*generated.FixedIncomeAsset, error) {
@@ -37,4 +37,8 @@ func (r *Resolver) UpdateFixedIncomeAsset(ctx context.Context, input generated.U
 
 func (r *Resolver) DeleteFixedIncomeAsset(ctx context.Context, id string) (bool, error) {
     return r.AssetService.DeleteFixedIncomeAsset(ctx, id)
-}
\ No newline at end of file
+}
+
+func (r *Resolver) FixedIncomeCashFlows(ctx context.Context, months *int) ([]*generated.FixedIncomeCashFlow, error) {
+    return r.AssetService.FixedIncomeCashFlows(ctx, months)
+}
@@ -14,8 +14,8 @@ type MockAssetService struct {
     mock.Mock
 }
 
-func (m *MockAssetService) FixedIncomeAssets(ctx context.Context) ([]*generated.FixedIncomeAsset, error) {
-    args := m.Called(ctx)
+func (m *MockAssetService) FixedIncomeAssets(ctx context.Context, accountId *string, householdId *string) ([]*generated.FixedIncomeAsset, error) {
+    args := m.Called(ctx, accountId, householdId)
     return args.Get(0).([]*generated.FixedIncomeAsset), args.Error(1)
 }
 
@@ -34,6 +34,11 @@ func (m *MockAssetService) DeleteFixedIncomeAsset(ctx context.Context, id string
     return args.Get(0).(bool), args.Error(1)
 }
 
+func (m *MockAssetService) FixedIncomeCashFlows(ctx context.Context, months *int) ([]*generated.FixedIncomeCashFlow, error) {
+    args := m.Called(ctx, months)
+    return args.Get(0).([]*generated.FixedIncomeCashFlow), args.Error(1)
+}
+
 // UsStocks メソッドのテスト
 func TestFixedIncomeAssets(t *testing.T) {
     mockService := new(MockAssetService)
@@ -42,9 +47,9 @@ func TestFixedIncomeAssets(t *testing.T) {
     fixedIncomeAssets := []*generated.FixedIncomeAsset{
         {ID: "1",Code: "i-Bond", GetPriceTotal: 10000.0, DividendRate: 1.5, PaymentMonth: []int{11}},
     }
-    mockService.On("FixedIncomeAssets", mock.Anything).Return(fixedIncomeAssets, nil)
+    mockService.On("FixedIncomeAssets", mock.Anything, (*string)(nil), (*string)(nil)).Return(fixedIncomeAssets, nil)
 
-    result, err := resolver.FixedIncomeAssets(context.Background())
+    result, err := resolver.FixedIncomeAssets(context.Background(), nil, nil)
     
     assert.NoError(t, err)
     assert.Equal(t, fixedIncomeAssets, result)
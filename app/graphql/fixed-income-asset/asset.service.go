@@ -3,62 +3,87 @@ package fixedincomeasset
 import (
 	"context"
 	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/common/household"
+	"my-us-stock-backend/app/database/model"
 	"my-us-stock-backend/app/graphql/generated"
 	"my-us-stock-backend/app/graphql/utils"
 	FixedIncome "my-us-stock-backend/app/repository/assets/fixed-income"
+	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
+	"time"
 )
 
 // AssetService インターフェースの定義
 type AssetService interface {
-    FixedIncomeAssets(ctx context.Context) ([]*generated.FixedIncomeAsset, error)
+    FixedIncomeAssets(ctx context.Context, accountId *string, householdId *string) ([]*generated.FixedIncomeAsset, error)
     CreateFixedIncomeAsset(ctx context.Context, input generated.CreateFixedIncomeAssetInput) (*generated.FixedIncomeAsset, error)
 	UpdateFixedIncomeAsset(ctx context.Context, input generated.UpdateFixedIncomeAssetInput) (*generated.FixedIncomeAsset, error)
 	DeleteFixedIncomeAsset(ctx context.Context, id string) (bool, error)
+    FixedIncomeCashFlows(ctx context.Context, months *int) ([]*generated.FixedIncomeCashFlow, error)
 }
 
 // DefaultAssetService 構造体の定義
 type DefaultAssetService struct {
     Repo FixedIncome.FixedIncomeRepository // インターフェースを利用
     Auth auth.AuthService    // 認証サービスのインターフェース
+    HouseholdAccess household.HouseholdAccess
+    CurrencyRepo repoCurrency.CurrencyRepository
 }
 
 // NewAssetService は DefaultUserService の新しいインスタンスを作成します
-func NewAssetService(repo FixedIncome.FixedIncomeRepository, auth auth.AuthService) AssetService {
-    return &DefaultAssetService{Repo: repo, Auth: auth}
+func NewAssetService(repo FixedIncome.FixedIncomeRepository, auth auth.AuthService, householdAccess household.HouseholdAccess, currencyRepo repoCurrency.CurrencyRepository) AssetService {
+    return &DefaultAssetService{Repo: repo, Auth: auth, HouseholdAccess: householdAccess, CurrencyRepo: currencyRepo}
+}
+
+// ドル建ての資産の評価額・為替差損益の計算に用いる現在のドル円を取得する(ドル建ての資産がない場合は取得しない)
+func (s *DefaultAssetService) currentUsdJpy(ctx context.Context, modelAssets []model.FixedIncomeAsset) (float64, error) {
+    return FixedIncome.CurrentUsdJpy(ctx, s.CurrencyRepo, modelAssets)
 }
 
 // GetUserByID はユーザーをIDによって検索します
-func (s *DefaultAssetService) FixedIncomeAssets(ctx context.Context) ([]*generated.FixedIncomeAsset, error) {
+func (s *DefaultAssetService) FixedIncomeAssets(ctx context.Context, accountId *string, householdId *string) ([]*generated.FixedIncomeAsset, error) {
     // アクセストークンの検証
     userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
     if userId == 0 {
         return nil, utils.UnauthenticatedError("Invalid user ID")
     }
-    modelAssets, err := s.Repo.FetchFixedIncomeAssetListById(ctx, userId)
+    filterAccountId, convertError := utils.ConvertNullableIdToUint(accountId)
+    if convertError != nil {
+        return nil, utils.DefaultGraphQLError("入力された口座idが無効です")
+    }
+    // 世帯の指定がある場合は世帯の全メンバーの保有資産を対象にする
+    scopeUserIds, err := s.HouseholdAccess.ScopeUserIds(ctx, userId, householdId)
     if err != nil {
         return nil, utils.DefaultGraphQLError(err.Error())
     }
+    var modelAssets []model.FixedIncomeAsset
+    for _, scopeUserId := range scopeUserIds {
+        userAssets, err := s.Repo.FetchFixedIncomeAssetListById(ctx, scopeUserId)
+        if err != nil {
+            return nil, utils.DefaultGraphQLError(err.Error())
+        }
+        modelAssets = append(modelAssets, userAssets...)
+    }
+    // 口座の指定がある場合はその口座の保有資産のみ対象にする
+    filteredAssets := make([]model.FixedIncomeAsset, 0, len(modelAssets))
+    for _, modelAsset := range modelAssets {
+        if utils.InAccount(modelAsset.AccountId, filterAccountId) {
+            filteredAssets = append(filteredAssets, modelAsset)
+        }
+    }
+    modelAssets = filteredAssets
 	// modelAssetsが空の場合は空の配列を返却する
 	if len(modelAssets) == 0 {
 		return []*generated.FixedIncomeAsset{}, nil
 	}
 
+	currentUsdJpy, err := s.currentUsdJpy(ctx, modelAssets)
+	if err != nil {
+		return nil, utils.DefaultGraphQLError(err.Error())
+	}
+	now := time.Now()
 	assets := make([]*generated.FixedIncomeAsset, len(modelAssets))
 	for i, modelAsset := range modelAssets {
-		// pq.Int64Array to []int conversion
-		paymentMonths := make([]int, len(modelAsset.PaymentMonth))
-		for i, month := range modelAsset.PaymentMonth {
-			paymentMonths[i] = int(month)
-		}
-
-		assets[i] = &generated.FixedIncomeAsset{
-			ID: utils.ConvertIdToString(modelAsset.ID),
-			Code: modelAsset.Code,
-			GetPriceTotal: modelAsset.GetPriceTotal,
-			DividendRate: modelAsset.DividendRate,
-			UsdJpy: modelAsset.UsdJpy,
-			PaymentMonth: paymentMonths, // Updated field
-		}
+		assets[i] = convertToFixedIncomeAsset(modelAsset, currentUsdJpy, now)
 	}
 
     return assets, nil
@@ -75,6 +100,44 @@ func (s *DefaultAssetService) CreateFixedIncomeAsset(ctx context.Context, input
 		for _, month := range input.PaymentMonth {
 			paymentMonths = append(paymentMonths, int64(month))
 		}
+    createAccountId, convertError := utils.ConvertNullableIdToUint(input.AccountID)
+    if convertError != nil {
+        return nil, utils.DefaultGraphQLError("入力された口座idが無効です")
+    }
+    issueDate, err := parseDate(input.IssueDate)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    maturityDate, err := parseDate(input.MaturityDate)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    if err := validateMaturityInput(issueDate, maturityDate, input.CouponFrequency, input.PaymentMonth, input.RedemptionAmount); err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    purchaseDate, err := parseDate(input.PurchaseDate)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    if err := validateValuationInput(input.ValuationMode, input.CleanPrice); err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    var valuationMode string
+    if input.ValuationMode != nil {
+        valuationMode = string(*input.ValuationMode)
+    }
+    var couponFrequency string
+    if input.CouponFrequency != nil {
+        couponFrequency = string(*input.CouponFrequency)
+    }
+    // 通貨の指定がない場合、購入時為替の指定があればドル建てとする
+    currency := FixedIncome.CurrencyJpy
+    if input.UsdJpy != nil {
+        currency = FixedIncome.CurrencyUsd
+    }
+    if input.Currency != nil {
+        currency = string(*input.Currency)
+    }
 	// 更新用DTOの作成
     createDto := FixedIncome.CreateFixedIncomeDto{
         Code: input.Code,
@@ -82,25 +145,26 @@ func (s *DefaultAssetService) CreateFixedIncomeAsset(ctx context.Context, input
 		DividendRate: input.DividendRate,
 		UsdJpy: input.UsdJpy,
 		PaymentMonth: paymentMonths,
+		IssueDate: issueDate,
+		MaturityDate: maturityDate,
+		CouponFrequency: couponFrequency,
+		Currency: currency,
+		RedemptionAmount: input.RedemptionAmount,
+		ValuationMode: valuationMode,
+		CleanPrice: input.CleanPrice,
+		PurchaseDate: purchaseDate,
+		AccountId: createAccountId,
 		UserId: userId,
     }
     modelAsset, err := s.Repo.CreateFixedIncomeAsset(ctx, createDto)
     if err != nil {
         return nil, utils.DefaultGraphQLError(err.Error())
     }
-	// pq.Int64Array to []int conversion
-	var newPaymentMonths = make([]int, len(modelAsset.PaymentMonth))
-	for i, month := range modelAsset.PaymentMonth {
-		newPaymentMonths[i] = int(month)
-	}
-    return  &generated.FixedIncomeAsset{
-		ID: utils.ConvertIdToString(modelAsset.ID),
-		Code: modelAsset.Code,
-		GetPriceTotal: modelAsset.GetPriceTotal,
-		DividendRate: modelAsset.DividendRate,
-		UsdJpy: modelAsset.UsdJpy,
-		PaymentMonth: newPaymentMonths, // Updated field
-	}, nil
+    currentUsdJpy, err := s.currentUsdJpy(ctx, []model.FixedIncomeAsset{*modelAsset})
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return convertToFixedIncomeAsset(*modelAsset, currentUsdJpy, time.Now()), nil
 }
 
 func (s *DefaultAssetService) UpdateFixedIncomeAsset(ctx context.Context, input generated.UpdateFixedIncomeAssetInput) (*generated.FixedIncomeAsset, error) {
@@ -113,29 +177,60 @@ func (s *DefaultAssetService) UpdateFixedIncomeAsset(ctx context.Context, input
 	if convertError != nil || updateId == 0 {
         return nil, utils.DefaultGraphQLError("入力されたidが無効です")
        }
+    issueDate, err := parseDate(input.IssueDate)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    maturityDate, err := parseDate(input.MaturityDate)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    if err := validateMaturityInput(issueDate, maturityDate, input.CouponFrequency, nil, input.RedemptionAmount); err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    purchaseDate, err := parseDate(input.PurchaseDate)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    if err := validateValuationInput(input.ValuationMode, input.CleanPrice); err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
 	// 更新用DTOの作成
 	updateDto := FixedIncome.UpdateFixedIncomeDto{
 		ID: updateId,
 		GetPriceTotal: &input.GetPriceTotal,
 		UsdJpy: input.UsdJpy,
+		IssueDate: issueDate,
+		MaturityDate: maturityDate,
+		RedemptionAmount: input.RedemptionAmount,
+		CleanPrice: input.CleanPrice,
+		PurchaseDate: purchaseDate,
+	}
+	if input.CouponFrequency != nil {
+		couponFrequency := string(*input.CouponFrequency)
+		updateDto.CouponFrequency = &couponFrequency
+	}
+	if input.Currency != nil {
+		currency := string(*input.Currency)
+		updateDto.Currency = &currency
+	}
+	if input.ValuationMode != nil {
+		valuationMode := string(*input.ValuationMode)
+		updateDto.ValuationMode = &valuationMode
 	}
+    // 他のユーザーの保有資産は世帯で編集者以上の権限がある場合のみ更新できる
+    if err := s.HouseholdAccess.AuthorizeEdit(ctx, userId, &model.FixedIncomeAsset{}, updateId); err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
 	modelAsset, err := s.Repo.UpdateFixedIncomeAsset(ctx, updateDto)
     if err != nil {
         return nil, utils.DefaultGraphQLError(err.Error())
     }
-	// pq.Int64Array to []int conversion
-	var newPaymentMonths = make([]int, len(modelAsset.PaymentMonth))
-	for i, month := range modelAsset.PaymentMonth {
-		newPaymentMonths[i] = int(month)
+	currentUsdJpy, err := s.currentUsdJpy(ctx, []model.FixedIncomeAsset{*modelAsset})
+	if err != nil {
+	    return nil, utils.DefaultGraphQLError(err.Error())
 	}
-	return  &generated.FixedIncomeAsset{
-		ID: utils.ConvertIdToString(modelAsset.ID),
-		Code: modelAsset.Code,
-		GetPriceTotal: modelAsset.GetPriceTotal,
-		DividendRate: modelAsset.DividendRate,
-		UsdJpy: modelAsset.UsdJpy,
-		PaymentMonth: newPaymentMonths, // Updated field
-	}, nil
+	return convertToFixedIncomeAsset(*modelAsset, currentUsdJpy, time.Now()), nil
 }
 
 // 削除
@@ -151,10 +246,34 @@ func (s *DefaultAssetService) DeleteFixedIncomeAsset(ctx context.Context, id str
     if convertError != nil || deleteId == 0 {
         return false, utils.DefaultGraphQLError("入力されたidが無効です")
        }
+    // 他のユーザーの保有資産は世帯で編集者以上の権限がある場合のみ削除できる
+    if err := s.HouseholdAccess.AuthorizeEdit(ctx, userId, &model.FixedIncomeAsset{}, deleteId); err != nil {
+        return false, utils.DefaultGraphQLError(err.Error())
+    }
     var err = s.Repo.DeleteFixedIncomeAsset(ctx, deleteId)
 	// 市場情報を追加して返却
     if err != nil {
      return false, utils.DefaultGraphQLError(err.Error())
     }
 	return true, nil
-}
\ No newline at end of file
+}
+
+// FixedIncomeCashFlows はログインユーザーが保有中の固定利回り資産の今後 months ヶ月(省略時は12ヶ月)の利払い・満期償還を取得します
+func (s *DefaultAssetService) FixedIncomeCashFlows(ctx context.Context, months *int) ([]*generated.FixedIncomeCashFlow, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    period := 12
+    if months != nil {
+        period = *months
+    }
+    if period < 1 || period > maxCashFlowMonths {
+        return nil, utils.DefaultGraphQLError("取得期間は1ヶ月から120ヶ月の範囲で指定してください")
+    }
+    modelAssets, err := s.Repo.FetchFixedIncomeAssetListById(ctx, userId)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return buildCashFlows(modelAssets, time.Now().UTC(), period), nil
+}
@@ -2,12 +2,15 @@ package fixedincomeasset
 
 import (
 	"context"
+	"my-us-stock-backend/app/common/household"
 	"my-us-stock-backend/app/common/auth"
 	"my-us-stock-backend/app/database/model"
 	"my-us-stock-backend/app/graphql/generated"
 	"my-us-stock-backend/app/graphql/utils"
 	repo "my-us-stock-backend/app/repository/assets/fixed-income"
+	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
 	"testing"
+	"time"
 
 	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
@@ -19,11 +22,14 @@ import (
 func TestFixedIncomeAssetsService(t *testing.T) {
 	mockRepo := repo.NewMockFixedIncomeAssetRepository()
 	mockAuth := auth.NewMockAuthService()
-	service := NewAssetService(mockRepo, mockAuth)
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	mockCurrencyRepo := repoCurrency.NewMockCurrencyRepository()
+	service := NewAssetService(mockRepo, mockAuth, mockHouseholdAccess, mockCurrencyRepo)
 
 	// モックの期待値設定
 	userId := uint(1)
 	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+	mockHouseholdAccess.On("ScopeUserIds", mock.Anything, userId, (*string)(nil)).Return([]uint{userId}, nil)
 
 	mockAssets := []model.FixedIncomeAsset{
 		{Code: "Funds", UserId: 99, DividendRate: 3.5, GetPriceTotal: 100000.0, PaymentMonth: pq.Int64Array{6, 12}},
@@ -31,7 +37,7 @@ func TestFixedIncomeAssetsService(t *testing.T) {
 	mockRepo.On("FetchFixedIncomeAssetListById", mock.Anything, userId).Return(mockAssets, nil)
 
 	// テスト対象メソッドの実行
-	assets, err := service.FixedIncomeAssets(context.Background())
+	assets, err := service.FixedIncomeAssets(context.Background(), nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, assets)
 	assert.Len(t, assets, 1)
@@ -50,7 +56,9 @@ func TestFixedIncomeAssetsService(t *testing.T) {
 func TestCreateFixedIncomeAssetService(t *testing.T) {
 	mockRepo := repo.NewMockFixedIncomeAssetRepository()
 	mockAuth := auth.NewMockAuthService()
-	service := NewAssetService(mockRepo, mockAuth)
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	mockCurrencyRepo := repoCurrency.NewMockCurrencyRepository()
+	service := NewAssetService(mockRepo, mockAuth, mockHouseholdAccess, mockCurrencyRepo)
 
 	// モックの期待値設定
 	userId := uint(1)
@@ -65,6 +73,7 @@ func TestCreateFixedIncomeAssetService(t *testing.T) {
 		DividendRate: 3.5, 
 		GetPriceTotal: 100000.0,
         PaymentMonth: paymentMonth,
+		Currency: "JPY",
 		UserId: 1,
 	}
 	mockRepo.On("CreateFixedIncomeAsset", mock.Anything, input).Return(mockAsset, nil)
@@ -96,11 +105,14 @@ func TestCreateFixedIncomeAssetService(t *testing.T) {
 func TestUpdateFixedIncomeAssetService(t *testing.T) {
     mockRepo := repo.NewMockFixedIncomeAssetRepository()
     mockAuth := auth.NewMockAuthService()
-    service := NewAssetService(mockRepo, mockAuth)
+    mockHouseholdAccess := household.NewMockHouseholdAccess()
+    mockCurrencyRepo := repoCurrency.NewMockCurrencyRepository()
+    service := NewAssetService(mockRepo, mockAuth, mockHouseholdAccess, mockCurrencyRepo)
 
     // モックの期待値設定
     userId := uint(1)
     mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mockHouseholdAccess.On("AuthorizeEdit", mock.Anything, userId, mock.Anything, mock.Anything).Return(nil)
 
     updatedAsset := &model.FixedIncomeAsset{
 		Model: gorm.Model{
@@ -143,11 +155,14 @@ func TestUpdateFixedIncomeAssetService(t *testing.T) {
 func TestDeleteFixedIncomeAssetService(t *testing.T) {
 	mockRepo := repo.NewMockFixedIncomeAssetRepository()
 	mockAuth := auth.NewMockAuthService()
-	service := NewAssetService(mockRepo, mockAuth)
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	mockCurrencyRepo := repoCurrency.NewMockCurrencyRepository()
+	service := NewAssetService(mockRepo, mockAuth, mockHouseholdAccess, mockCurrencyRepo)
 
 	// モックの期待値設定
 	userId := uint(1)
 	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+	mockHouseholdAccess.On("AuthorizeEdit", mock.Anything, userId, mock.Anything, mock.Anything).Return(nil)
 
 	deleteId := uint(1)
 	mockRepo.On("DeleteFixedIncomeAsset", mock.Anything, deleteId).Return(nil)
@@ -161,3 +176,179 @@ func TestDeleteFixedIncomeAssetService(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 	mockAuth.AssertExpectations(t)
 }
+
+// 満期日・利払い頻度を指定して作成した場合、DTOに日付・通貨・償還金額が渡される
+func TestCreateFixedIncomeAssetWithMaturityService(t *testing.T) {
+	mockRepo := repo.NewMockFixedIncomeAssetRepository()
+	mockAuth := auth.NewMockAuthService()
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	mockCurrencyRepo := repoCurrency.NewMockCurrencyRepository()
+	service := NewAssetService(mockRepo, mockAuth, mockHouseholdAccess, mockCurrencyRepo)
+
+	userId := uint(1)
+	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+	issueDate := time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)
+	maturityDate := time.Date(2027, 4, 15, 0, 0, 0, 0, time.UTC)
+	redemptionAmount := 1000.0
+	mockAsset := &model.FixedIncomeAsset{Code: "US Treasury", DividendRate: 4.0, GetPriceTotal: 980, IssueDate: &issueDate, MaturityDate: &maturityDate, CouponFrequency: "SEMI_ANNUAL", Currency: "USD", RedemptionAmount: &redemptionAmount, Status: "ACTIVE", UserId: 1}
+	mockCurrencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(150.0, nil)
+	mockRepo.On("CreateFixedIncomeAsset", mock.Anything, repo.CreateFixedIncomeDto{
+		Code: "US Treasury",
+		DividendRate: 4.0,
+		GetPriceTotal: 980,
+		PaymentMonth: []int64{},
+		IssueDate: &issueDate,
+		MaturityDate: &maturityDate,
+		CouponFrequency: "SEMI_ANNUAL",
+		Currency: "USD",
+		RedemptionAmount: &redemptionAmount,
+		UserId: 1,
+	}).Return(mockAsset, nil)
+
+	issue := "2024-04-15"
+	maturity := "2027-04-15"
+	frequency := generated.CouponFrequencySemiAnnual
+	currency := generated.FixedIncomeCurrencyUsd
+	result, err := service.CreateFixedIncomeAsset(context.Background(), generated.CreateFixedIncomeAssetInput{
+		Code: "US Treasury",
+		DividendRate: 4.0,
+		GetPriceTotal: 980,
+		PaymentMonth: []int{},
+		IssueDate: &issue,
+		MaturityDate: &maturity,
+		CouponFrequency: &frequency,
+		Currency: &currency,
+		RedemptionAmount: &redemptionAmount,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "2027-04-15", *result.MaturityDate)
+	assert.Equal(t, "2024-04-15", *result.IssueDate)
+	assert.Equal(t, generated.CouponFrequencySemiAnnual, *result.CouponFrequency)
+	assert.Equal(t, generated.FixedIncomeCurrencyUsd, result.Currency)
+	assert.Equal(t, 1000.0, result.RedemptionAmount)
+	assert.Equal(t, generated.FixedIncomeStatusActive, result.Status)
+	assert.Equal(t, generated.FixedIncomeValuationModeAmortizedCost, result.ValuationMode)
+	assert.Nil(t, result.FxGainLoss)
+	mockRepo.AssertExpectations(t)
+}
+
+// 満期日・利払い頻度の入力値が不正な場合はエラーを返す
+func TestCreateFixedIncomeAssetInvalidMaturityService(t *testing.T) {
+	mockRepo := repo.NewMockFixedIncomeAssetRepository()
+	mockAuth := auth.NewMockAuthService()
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	mockCurrencyRepo := repoCurrency.NewMockCurrencyRepository()
+	service := NewAssetService(mockRepo, mockAuth, mockHouseholdAccess, mockCurrencyRepo)
+	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+
+	invalidDate := "2027/04/15"
+	issue := "2027-04-15"
+	maturity := "2024-04-15"
+	quarterly := generated.CouponFrequencyQuarterly
+	zero := 0.0
+	tests := []struct {
+		name  string
+		input generated.CreateFixedIncomeAssetInput
+		message string
+	}{
+		{"日付の形式が不正", generated.CreateFixedIncomeAssetInput{Code: "Bond", MaturityDate: &invalidDate}, "input: 日付はYYYY-MM-DD形式で指定してください"},
+		{"満期日が発行日以前", generated.CreateFixedIncomeAssetInput{Code: "Bond", IssueDate: &issue, MaturityDate: &maturity}, "input: 満期日は発行日より後の日付を指定してください"},
+		{"配当支払い月の数が利払い頻度と不一致", generated.CreateFixedIncomeAssetInput{Code: "Bond", PaymentMonth: []int{6, 12}, CouponFrequency: &quarterly}, "input: 配当支払い月の数が利払い頻度と一致しません"},
+		{"償還金額が0以下", generated.CreateFixedIncomeAssetInput{Code: "Bond", RedemptionAmount: &zero}, "input: 償還金額は0より大きい値を指定してください"},
+		{"時価が0以下", generated.CreateFixedIncomeAssetInput{Code: "Bond", CleanPrice: &zero}, "input: 時価は0より大きい値を指定してください"},
+		{"購入日の形式が不正", generated.CreateFixedIncomeAssetInput{Code: "Bond", PurchaseDate: &invalidDate}, "input: 日付はYYYY-MM-DD形式で指定してください"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.CreateFixedIncomeAsset(context.Background(), tt.input)
+			assert.Nil(t, result)
+			assert.EqualError(t, err, tt.message)
+		})
+	}
+	mockRepo.AssertNotCalled(t, "CreateFixedIncomeAsset", mock.Anything, mock.Anything)
+}
+
+// 取得期間が範囲外の場合はエラーを返す
+func TestFixedIncomeCashFlowsInvalidMonthsService(t *testing.T) {
+	mockRepo := repo.NewMockFixedIncomeAssetRepository()
+	mockAuth := auth.NewMockAuthService()
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	mockCurrencyRepo := repoCurrency.NewMockCurrencyRepository()
+	service := NewAssetService(mockRepo, mockAuth, mockHouseholdAccess, mockCurrencyRepo)
+	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+
+	months := 121
+	result, err := service.FixedIncomeCashFlows(context.Background(), &months)
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "input: 取得期間は1ヶ月から120ヶ月の範囲で指定してください")
+}
+
+// 保有中の資産の利払い・満期償還を日付順に作成し、償還済みの資産は対象外とする
+func TestBuildCashFlows(t *testing.T) {
+	from := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	issueDate := time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC)
+	maturityDate := time.Date(2026, 7, 31, 0, 0, 0, 0, time.UTC)
+	loanIssueDate := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	loanMaturityDate := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	redemptionAmount := 1000.0
+	modelAssets := []model.FixedIncomeAsset{
+		// 半年ごとの利払い(満期日の月を起点に1月・7月)
+		{Model: gorm.Model{ID: 1}, Code: "Treasury", DividendRate: 4.0, GetPriceTotal: 980, RedemptionAmount: &redemptionAmount, IssueDate: &issueDate, MaturityDate: &maturityDate, CouponFrequency: "SEMI_ANNUAL", Currency: "USD", Status: "ACTIVE"},
+		// 満期一括の利払い
+		{Model: gorm.Model{ID: 2}, Code: "Loan", DividendRate: 5.0, GetPriceTotal: 100000, IssueDate: &loanIssueDate, MaturityDate: &loanMaturityDate, CouponFrequency: "AT_MATURITY", Currency: "JPY", Status: "ACTIVE"},
+		// 満期日が未設定で配当支払い月のみ登録されている場合は月末に利払い
+		{Model: gorm.Model{ID: 3}, Code: "Fund", DividendRate: 3.0, GetPriceTotal: 200000, PaymentMonth: pq.Int64Array{6, 12}, Currency: "JPY", Status: "ACTIVE"},
+		// 償還済みの資産は対象外
+		{Model: gorm.Model{ID: 4}, Code: "Matured", DividendRate: 3.0, GetPriceTotal: 100000, PaymentMonth: pq.Int64Array{2}, Currency: "JPY", Status: "MATURED"},
+	}
+
+	cashFlows := buildCashFlows(modelAssets, from, 12)
+	expected := []generated.FixedIncomeCashFlow{
+		{AssetID: "1", Code: "Treasury", Date: "2026-01-31", Type: generated.FixedIncomeCashFlowTypeCoupon, Amount: 20, Currency: generated.FixedIncomeCurrencyUsd},
+		{AssetID: "2", Code: "Loan", Date: "2026-03-01", Type: generated.FixedIncomeCashFlowTypeCoupon, Amount: 5000, Currency: generated.FixedIncomeCurrencyJpy},
+		{AssetID: "2", Code: "Loan", Date: "2026-03-01", Type: generated.FixedIncomeCashFlowTypeRedemption, Amount: 100000, Currency: generated.FixedIncomeCurrencyJpy},
+		{AssetID: "3", Code: "Fund", Date: "2026-06-30", Type: generated.FixedIncomeCashFlowTypeCoupon, Amount: 3000, Currency: generated.FixedIncomeCurrencyJpy},
+		{AssetID: "1", Code: "Treasury", Date: "2026-07-31", Type: generated.FixedIncomeCashFlowTypeCoupon, Amount: 20, Currency: generated.FixedIncomeCurrencyUsd},
+		{AssetID: "1", Code: "Treasury", Date: "2026-07-31", Type: generated.FixedIncomeCashFlowTypeRedemption, Amount: 1000, Currency: generated.FixedIncomeCurrencyUsd},
+		{AssetID: "3", Code: "Fund", Date: "2026-12-31", Type: generated.FixedIncomeCashFlowTypeCoupon, Amount: 3000, Currency: generated.FixedIncomeCurrencyJpy},
+	}
+	assert.Len(t, cashFlows, len(expected))
+	for i, cashFlow := range cashFlows {
+		assert.Equal(t, expected[i], *cashFlow)
+	}
+}
+
+// ドル建ての資産は現在のドル円で円に換算し、購入時為替に対する為替差損益を返す
+func TestFixedIncomeAssetsUsdValuationService(t *testing.T) {
+	mockRepo := repo.NewMockFixedIncomeAssetRepository()
+	mockAuth := auth.NewMockAuthService()
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	mockCurrencyRepo := repoCurrency.NewMockCurrencyRepository()
+	service := NewAssetService(mockRepo, mockAuth, mockHouseholdAccess, mockCurrencyRepo)
+
+	userId := uint(1)
+	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+	mockHouseholdAccess.On("ScopeUserIds", mock.Anything, userId, (*string)(nil)).Return([]uint{userId}, nil)
+	purchaseUsdJpy := 140.0
+	mockRepo.On("FetchFixedIncomeAssetListById", mock.Anything, userId).Return([]model.FixedIncomeAsset{
+		{Code: "US Treasury", GetPriceTotal: 1000, UsdJpy: &purchaseUsdJpy, Currency: "USD", Status: "ACTIVE", UserId: 1},
+		// 通貨の項目追加前に登録された資産(通貨が未設定)は購入時為替があればドル建てとする
+		{Code: "Legacy Bond", GetPriceTotal: 500, UsdJpy: &purchaseUsdJpy, Status: "ACTIVE", UserId: 1},
+		{Code: "Loan", GetPriceTotal: 100000, Currency: "JPY", Status: "ACTIVE", UserId: 1},
+	}, nil)
+	mockCurrencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(150.0, nil)
+
+	assets, err := service.FixedIncomeAssets(context.Background(), nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, assets, 3)
+	assert.Equal(t, generated.FixedIncomeCurrencyUsd, assets[0].Currency)
+	assert.Equal(t, 150000.0, assets[0].ValuationJpy)
+	assert.Equal(t, 10000.0, *assets[0].FxGainLoss)
+	assert.Equal(t, generated.FixedIncomeCurrencyUsd, assets[1].Currency)
+	assert.Equal(t, 75000.0, assets[1].ValuationJpy)
+	assert.Equal(t, 5000.0, *assets[1].FxGainLoss)
+	assert.Equal(t, generated.FixedIncomeCurrencyJpy, assets[2].Currency)
+	assert.Equal(t, 100000.0, assets[2].ValuationJpy)
+	assert.Nil(t, assets[2].FxGainLoss)
+	mockCurrencyRepo.AssertNumberOfCalls(t, "FetchCurrentUsdJpy", 1)
+}
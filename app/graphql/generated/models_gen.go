@@ -2,6 +2,134 @@
 
 package generated
 
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+type AccountDeletion struct {
+	// ユーザーと全てのデータを削除済みの場合は true、猶予期間中の場合は false
+	Deleted bool `json:"deleted"`
+	// 完全に削除する日時(RFC3339)。即時に削除した場合は null
+	PurgeAt *string `json:"purgeAt,omitempty"`
+}
+
+type AddHouseholdMemberInput struct {
+	// 世帯のid
+	HouseholdID string `json:"householdId"`
+	// 招待するユーザーのメールアドレス
+	Email string `json:"email"`
+	// 権限(EDITOR / VIEWER)
+	Role HouseholdRole `json:"role"`
+}
+
+type AlertRule struct {
+	ID string `json:"id"`
+	// 監視対象
+	TargetType AlertTargetType `json:"targetType"`
+	// シンボル
+	Symbol string `json:"symbol"`
+	// 発生条件
+	Condition AlertCondition `json:"condition"`
+	// 閾値
+	Threshold float64 `json:"threshold"`
+	// 通知モード
+	Mode AlertMode `json:"mode"`
+	// 再通知までの間隔(分)
+	CooldownMinutes int `json:"cooldownMinutes"`
+	// 有効かどうか
+	Enabled bool `json:"enabled"`
+	// 最終発生日時
+	LastTriggeredAt *string `json:"lastTriggeredAt,omitempty"`
+}
+
+type AnnualTaxReport struct {
+	Year int `json:"year"`
+	// 銘柄別の集計(ティッカー順)
+	Securities        []*TaxReportSecurity `json:"securities"`
+	DividendTotal     float64              `json:"dividendTotal"`
+	ForeignTaxTotal   float64              `json:"foreignTaxTotal"`
+	DomesticTaxTotal  float64              `json:"domesticTaxTotal"`
+	ProceedsTotal     float64              `json:"proceedsTotal"`
+	CostTotal         float64              `json:"costTotal"`
+	FeeTotal          float64              `json:"feeTotal"`
+	RealizedGainTotal float64              `json:"realizedGainTotal"`
+}
+
+type AssetClassChange struct {
+	// 資産クラス
+	AssetClass AssetClass `json:"assetClass"`
+	// 評価額(円)
+	Amount float64 `json:"amount"`
+	// 前回の評価額(円)
+	PreviousAmount float64 `json:"previousAmount"`
+	// 増減額(円)
+	Change float64 `json:"change"`
+}
+
+type BrokerageAccount struct {
+	ID string `json:"id"`
+	// 口座名(例: SBI証券 NISA)
+	Name string `json:"name"`
+	// 口座の種類
+	Type AccountType `json:"type"`
+	// 既定の口座か(口座を指定せずに登録した保有資産の登録先)
+	IsDefault bool `json:"isDefault"`
+}
+
+type CalendarEvent struct {
+	// ティッカーシンボル
+	Ticker string `json:"ticker"`
+	// イベントの種類
+	Type CalendarEventType `json:"type"`
+	// 日付
+	Date string `json:"date"`
+	// 件名
+	Title string `json:"title"`
+	// 1株当たり配当額(配当イベントのみ)
+	Amount *float64 `json:"amount,omitempty"`
+}
+
+type ConvertWatchlistToUsStockInput struct {
+	// ウォッチリストのid
+	ID string `json:"id"`
+	// 取得価格
+	GetPrice float64 `json:"getPrice"`
+	// 保有株数
+	Quantity float64 `json:"quantity"`
+	// 購入時為替
+	UsdJpy float64 `json:"usdJpy"`
+	// セクター(企業情報から取得できない場合のみ使用)
+	Sector *string `json:"sector,omitempty"`
+	// 証券口座のid(省略時は既定の口座)
+	AccountID *string `json:"accountId,omitempty"`
+}
+
+type CreateAlertRuleInput struct {
+	// 監視対象
+	TargetType AlertTargetType `json:"targetType"`
+	// シンボル(米国株はティッカー、仮想通貨はコード、為替はUSDJPYのみ)
+	Symbol string `json:"symbol"`
+	// 発生条件(ABOVE: 閾値以上、BELOW: 閾値以下)
+	Condition AlertCondition `json:"condition"`
+	// 閾値(価格・為替レート、配当利回りは%)
+	Threshold float64 `json:"threshold"`
+	// 通知モード(ONCE: 一度だけ、COOLDOWN: 間隔を空けて繰り返し)
+	Mode AlertMode `json:"mode"`
+	// 再通知までの間隔(分)
+	CooldownMinutes int `json:"cooldownMinutes"`
+}
+
+type CreateBrokerageAccountInput struct {
+	// 口座名
+	Name string `json:"name"`
+	// 口座の種類
+	Type AccountType `json:"type"`
+}
+
 type CreateCryptoInput struct {
 	// ティッカーシンボル
 	Code string `json:"code"`
@@ -9,6 +137,23 @@ type CreateCryptoInput struct {
 	GetPrice float64 `json:"getPrice"`
 	// 保有株数
 	Quantity float64 `json:"quantity"`
+	// 証券口座のid(省略時は既定の口座)
+	AccountID *string `json:"accountId,omitempty"`
+}
+
+type CreateDividendReceiptInput struct {
+	// ティッカーシンボル
+	Code string `json:"code"`
+	// 支払日
+	PaymentDate string `json:"paymentDate"`
+	// 税引前の配当金(ドル)
+	Amount float64 `json:"amount"`
+	// 外国(米国)源泉徴収税額(ドル)。省略時は配当金の10%
+	ForeignTax *float64 `json:"foreignTax,omitempty"`
+	// 国内源泉徴収税額(円)。省略時は(配当金 - 外国源泉徴収税額)の円換算額に20.315%(所得税15.315%・住民税5%)を掛けて計算
+	DomesticTax *float64 `json:"domesticTax,omitempty"`
+	// 支払日のTTM。省略時は支払日(休日の場合は直前の営業日)のドル円の終値で代用
+	UsdJpy *float64 `json:"usdJpy,omitempty"`
 }
 
 type CreateFixedIncomeAssetInput struct {
@@ -22,6 +167,29 @@ type CreateFixedIncomeAssetInput struct {
 	UsdJpy *float64 `json:"usdJpy,omitempty"`
 	// 配当支払い月
 	PaymentMonth []int `json:"paymentMonth"`
+	// 発行日(YYYY-MM-DD)
+	IssueDate *string `json:"issueDate,omitempty"`
+	// 満期日(YYYY-MM-DD、満期を迎えると自動で償還済みになり償還金額が現金に移る)
+	MaturityDate *string `json:"maturityDate,omitempty"`
+	// 利払い頻度(省略時は配当支払い月から判断する)
+	CouponFrequency *CouponFrequency `json:"couponFrequency,omitempty"`
+	// 通貨(省略時はJPY)
+	Currency *FixedIncomeCurrency `json:"currency,omitempty"`
+	// 償還金額(省略時は取得価格合計)
+	RedemptionAmount *float64 `json:"redemptionAmount,omitempty"`
+	// 評価方法(省略時はAMORTIZED_COST)
+	ValuationMode *FixedIncomeValuationMode `json:"valuationMode,omitempty"`
+	// 額面100あたりの時価(クリーン価格、評価方法がMARKETの場合に用いる)
+	CleanPrice *float64 `json:"cleanPrice,omitempty"`
+	// 購入日(YYYY-MM-DD、省略時は登録日。償却原価の計算に用いる)
+	PurchaseDate *string `json:"purchaseDate,omitempty"`
+	// 証券口座のid(省略時は既定の口座)
+	AccountID *string `json:"accountId,omitempty"`
+}
+
+type CreateHouseholdInput struct {
+	// 世帯名
+	Name string `json:"name"`
 }
 
 type CreateJapanFundInput struct {
@@ -33,6 +201,45 @@ type CreateJapanFundInput struct {
 	GetPrice float64 `json:"getPrice"`
 	// 取得価格総額
 	GetPriceTotal float64 `json:"getPriceTotal"`
+	// 証券口座のid(省略時は既定の口座)
+	AccountID *string `json:"accountId,omitempty"`
+}
+
+type CreateNotificationChannelInput struct {
+	// 通知先の種類
+	Type NotificationChannelType `json:"type"`
+	// 表示名
+	Name string `json:"name"`
+	// 通知先のURL(EMAILの場合はメールアドレス)
+	Target string `json:"target"`
+	// 署名用のシークレット(WEBHOOKの場合は必須、16文字以上)
+	Secret *string `json:"secret,omitempty"`
+}
+
+type CreateShareTokenInput struct {
+	// 公開範囲
+	Privacy SharePrivacy `json:"privacy"`
+	// 有効日数(1〜365日)
+	ExpiresInDays int `json:"expiresInDays"`
+}
+
+type CreateStockSaleInput struct {
+	// ティッカーシンボル
+	Code string `json:"code"`
+	// 約定日
+	TradeDate string `json:"tradeDate"`
+	// 売却数量
+	Quantity float64 `json:"quantity"`
+	// 売却単価(ドル)
+	SalePrice float64 `json:"salePrice"`
+	// 取得単価(ドル)。省略時は保有している米国株の取得単価
+	GetPrice *float64 `json:"getPrice,omitempty"`
+	// 取得日のTTM。省略時は保有している米国株の取得時のドル円
+	GetUsdJpy *float64 `json:"getUsdJpy,omitempty"`
+	// 売却手数料(ドル)
+	Fee float64 `json:"fee"`
+	// 約定日のTTM。省略時は約定日(休日の場合は直前の営業日)のドル円の終値で代用
+	UsdJpy *float64 `json:"usdJpy,omitempty"`
 }
 
 type CreateUsStockInput struct {
@@ -42,10 +249,12 @@ type CreateUsStockInput struct {
 	GetPrice float64 `json:"getPrice"`
 	// 保有株数
 	Quantity float64 `json:"quantity"`
-	// セクター
-	Sector string `json:"sector"`
+	// セクター(省略時は企業情報から自動設定)
+	Sector *string `json:"sector,omitempty"`
 	// 購入時為替
 	UsdJpy float64 `json:"usdJpy"`
+	// 証券口座のid(省略時は既定の口座)
+	AccountID *string `json:"accountId,omitempty"`
 }
 
 type CreateUserInput struct {
@@ -53,6 +262,17 @@ type CreateUserInput struct {
 	Email string `json:"email"`
 }
 
+type CreateWatchlistInput struct {
+	// ティッカーシンボル
+	Ticker string `json:"ticker"`
+	// メモ
+	Note *string `json:"note,omitempty"`
+	// 目標購入価格
+	TargetPrice *float64 `json:"targetPrice,omitempty"`
+	// 目標配当利回り(%)
+	TargetYield *float64 `json:"targetYield,omitempty"`
+}
+
 type Crypto struct {
 	ID string `json:"id"`
 	// ティッカーシンボル
@@ -63,6 +283,133 @@ type Crypto struct {
 	Quantity float64 `json:"quantity"`
 	// 現在価格
 	CurrentPrice float64 `json:"currentPrice"`
+	// 証券口座のid
+	AccountID string `json:"accountId"`
+}
+
+type DailyReport struct {
+	// 対象日
+	Date string `json:"date"`
+	// 比較対象のスナップショットの日付(初回は null)
+	PreviousDate *string `json:"previousDate,omitempty"`
+	// 資産総額(円)
+	TotalAmount float64 `json:"totalAmount"`
+	// 資産総額の増減(円)
+	TotalChange float64 `json:"totalChange"`
+	// 資産総額の増減率(%)
+	TotalChangeRate float64 `json:"totalChangeRate"`
+	// 資産クラスごとの増減
+	AssetClassChanges []*AssetClassChange `json:"assetClassChanges"`
+	// ドル円
+	UsdJpy float64 `json:"usdJpy"`
+	// 前回スナップショット時点のドル円
+	PreviousUsdJpy float64 `json:"previousUsdJpy"`
+	// 為替変動による米ドル建て資産の評価額への影響(円、前回のドル円が不明な場合は null)
+	FxImpact *float64 `json:"fxImpact,omitempty"`
+	// 値上がり上位の保有銘柄(最大5件)
+	TopGainers []*HoldingMover `json:"topGainers"`
+	// 値下がり上位の保有銘柄(最大5件)
+	TopLosers []*HoldingMover `json:"topLosers"`
+	// 今後30日以内に受け取る予定の配当
+	UpcomingDividends []*ExpectedDividend `json:"upcomingDividends"`
+	// 今後30日以内に受け取る予定の配当の合計(円、税引前)
+	ExpectedDividendTotal float64 `json:"expectedDividendTotal"`
+}
+
+type DeleteAccountInput struct {
+	// 本人確認のためのパスワード
+	Password string `json:"password"`
+	// 完全に削除するまでの猶予期間(日、最大30日)。0の場合は即時に削除する
+	// 猶予期間中はログインできず、期間を過ぎるとバックグラウンドジョブで削除する
+	GracePeriodDays int `json:"gracePeriodDays"`
+}
+
+type DeletedHolding struct {
+	// 削除前のID(復元時に指定する)
+	ID string `json:"id"`
+	// 保有資産の種類
+	Type HoldingType `json:"type"`
+	// ティッカーシンボル・資産名称
+	Code string `json:"code"`
+	// 銘柄名(投資信託のみ)
+	Name *string `json:"name,omitempty"`
+	// 保有数量(米国株式・暗号通貨のみ)
+	Quantity *float64 `json:"quantity,omitempty"`
+	// 取得価格(米国株式・暗号通貨・投資信託のみ)
+	GetPrice *float64 `json:"getPrice,omitempty"`
+	// 取得価格合計(投資信託・固定利回り資産のみ)
+	GetPriceTotal *float64 `json:"getPriceTotal,omitempty"`
+	// 削除日時(RFC3339)
+	DeletedAt string `json:"deletedAt"`
+	// 完全に削除される日時(RFC3339)
+	PurgeAt string `json:"purgeAt"`
+}
+
+type DividendHistory struct {
+	// ティッカーシンボル
+	Ticker string `json:"ticker"`
+	// 配当支払履歴(権利落日の新しい順)
+	Payments []*DividendPayment `json:"payments"`
+	// 1年配当成長率(CAGR)。比較対象年の配当がない場合はnull
+	Cagr1y *float64 `json:"cagr1y,omitempty"`
+	// 3年配当成長率(CAGR)
+	Cagr3y *float64 `json:"cagr3y,omitempty"`
+	// 5年配当成長率(CAGR)
+	Cagr5y *float64 `json:"cagr5y,omitempty"`
+	// 10年配当成長率(CAGR)
+	Cagr10y *float64 `json:"cagr10y,omitempty"`
+	// 連続増配年数
+	ConsecutiveGrowthYears int `json:"consecutiveGrowthYears"`
+	// 直近の配当が前回より減配されているか
+	LatestPaymentCut bool `json:"latestPaymentCut"`
+}
+
+type DividendPayment struct {
+	// 権利落日
+	ExDate string `json:"exDate"`
+	// 権利確定日
+	RecordDate *string `json:"recordDate,omitempty"`
+	// 支払日
+	PaymentDate *string `json:"paymentDate,omitempty"`
+	// 発表日
+	DeclarationDate *string `json:"declarationDate,omitempty"`
+	// 1株当たり配当額
+	Amount float64 `json:"amount"`
+	// 株式分割調整後の1株当たり配当額
+	AdjustedAmount float64 `json:"adjustedAmount"`
+}
+
+type DividendReceipt struct {
+	ID string `json:"id"`
+	// ティッカーシンボル
+	Code string `json:"code"`
+	// 支払日
+	PaymentDate string `json:"paymentDate"`
+	// 税引前の配当金(ドル)
+	Amount float64 `json:"amount"`
+	// 外国(米国)源泉徴収税額(ドル)
+	ForeignTax float64 `json:"foreignTax"`
+	// 国内源泉徴収税額(円)
+	DomesticTax float64 `json:"domesticTax"`
+	// 支払日のTTM
+	UsdJpy float64 `json:"usdJpy"`
+	// 税引前の配当金(円)
+	AmountJpy float64 `json:"amountJpy"`
+	// 外国源泉徴収税額(円)
+	ForeignTaxJpy float64 `json:"foreignTaxJpy"`
+}
+
+type ExpectedDividend struct {
+	// ティッカーシンボル
+	Ticker string `json:"ticker"`
+	// 支払日
+	PaymentDate string `json:"paymentDate"`
+	// 1株当たり配当額(ドル)
+	AmountPerShare float64 `json:"amountPerShare"`
+	// 保有株数
+	Quantity float64 `json:"quantity"`
+	// 受取予定額(円、税引前)
+	Amount float64 `json:"amount"`
 }
 
 type FixedIncomeAsset struct {
@@ -77,6 +424,147 @@ type FixedIncomeAsset struct {
 	UsdJpy *float64 `json:"usdJpy,omitempty"`
 	// 配当支払い月
 	PaymentMonth []int `json:"paymentMonth"`
+	// 発行日(YYYY-MM-DD)
+	IssueDate *string `json:"issueDate,omitempty"`
+	// 満期日(YYYY-MM-DD、満期なしの場合は null)
+	MaturityDate *string `json:"maturityDate,omitempty"`
+	// 利払い頻度(未設定の場合は null)
+	CouponFrequency *CouponFrequency `json:"couponFrequency,omitempty"`
+	// 通貨
+	Currency FixedIncomeCurrency `json:"currency"`
+	// 償還金額
+	RedemptionAmount float64 `json:"redemptionAmount"`
+	// 状態
+	Status FixedIncomeStatus `json:"status"`
+	// 円ベースの評価額(評価方法による評価額と経過利息の合計。ドル建ての場合は現在のドル円で換算する)
+	ValuationJpy float64 `json:"valuationJpy"`
+	// 評価方法
+	ValuationMode FixedIncomeValuationMode `json:"valuationMode"`
+	// 額面100あたりの時価(クリーン価格、未登録の場合は null)
+	CleanPrice *float64 `json:"cleanPrice,omitempty"`
+	// 購入日(YYYY-MM-DD、未登録の場合は null)
+	PurchaseDate *string `json:"purchaseDate,omitempty"`
+	// 評価方法による元本部分の評価額(資産の通貨建て、経過利息を含まない)
+	PrincipalValuation float64 `json:"principalValuation"`
+	// 前回の利払い日から現在までの経過利息(資産の通貨建て)
+	AccruedInterest float64 `json:"accruedInterest"`
+	// 購入時為替に対する円ベースの為替差損益(円建て・購入時為替が未登録の場合は null)
+	FxGainLoss *float64 `json:"fxGainLoss,omitempty"`
+	// 証券口座のid
+	AccountID string `json:"accountId"`
+}
+
+type FixedIncomeCashFlow struct {
+	// 固定利回り資産のid
+	AssetID string `json:"assetId"`
+	// 資産名称
+	Code string `json:"code"`
+	// 受取日(YYYY-MM-DD)
+	Date string `json:"date"`
+	// 種類
+	Type FixedIncomeCashFlowType `json:"type"`
+	// 受取金額(資産の通貨建て)
+	Amount float64 `json:"amount"`
+	// 通貨
+	Currency FixedIncomeCurrency `json:"currency"`
+}
+
+type FundPricePoint struct {
+	// 日付(YYYY-MM-DD)
+	Date string `json:"date"`
+	// 価格(1万口あたりの基準価額)
+	Price float64 `json:"price"`
+}
+
+type HoldingMover struct {
+	// ティッカーシンボル
+	Code string `json:"code"`
+	// 保有株数
+	Quantity float64 `json:"quantity"`
+	// 現在価格(ドル)
+	CurrentPrice float64 `json:"currentPrice"`
+	// 前日比(%)
+	ChangeRate float64 `json:"changeRate"`
+	// 前日比の評価額の増減(円)
+	Change float64 `json:"change"`
+}
+
+type Household struct {
+	// id
+	ID string `json:"id"`
+	// 世帯名
+	Name string `json:"name"`
+	// ログインユーザーの権限
+	Role HouseholdRole `json:"role"`
+	// メンバー(招待を承認したユーザーのみ)
+	Members []*HouseholdMember `json:"members"`
+}
+
+type HouseholdInvitation struct {
+	// 世帯のid
+	HouseholdID string `json:"householdId"`
+	// 世帯名
+	Name string `json:"name"`
+	// 承認後の権限
+	Role HouseholdRole `json:"role"`
+}
+
+type HouseholdMember struct {
+	// ユーザーのid
+	UserID string `json:"userId"`
+	// ユーザー名
+	Name string `json:"name"`
+	// メールアドレス
+	Email string `json:"email"`
+	// 権限
+	Role HouseholdRole `json:"role"`
+}
+
+type ImportHoldingsInput struct {
+	// 証券会社からエクスポートしたCSVファイル(UTF-8またはShift_JIS)
+	File graphql.Upload `json:"file"`
+	// CSVの形式
+	Format ImportFormat `json:"format"`
+	// trueの場合は登録せず、処理内容のプレビューのみ返す
+	DryRun *bool `json:"dryRun,omitempty"`
+	// 取り込み先の証券口座のid(省略時は既定の口座の保有資産と突き合わせる)
+	AccountID *string `json:"accountId,omitempty"`
+}
+
+type ImportResult struct {
+	// プレビューのみかどうか
+	DryRun bool `json:"dryRun"`
+	// 登録が行われたかどうか(競合がある場合は登録しない)
+	Committed bool `json:"committed"`
+	// 新規登録の件数
+	CreateCount int `json:"createCount"`
+	// 更新の件数
+	UpdateCount int `json:"updateCount"`
+	// 競合の件数
+	ConflictCount int `json:"conflictCount"`
+	// 取り込み対象外の件数
+	SkipCount int `json:"skipCount"`
+	// 各行の処理内容
+	Rows []*ImportRow `json:"rows"`
+}
+
+type ImportRow struct {
+	// CSVの行番号(同じ銘柄が複数行ある場合は最初の行)
+	Line int `json:"line"`
+	// 資産の種類(取り込み対象外の行は null)
+	AssetType *ImportAssetType `json:"assetType,omitempty"`
+	// 処理内容
+	Action ImportAction `json:"action"`
+	// ティッカー・ファンドコード・通貨コード
+	Code string `json:"code"`
+	// 銘柄名
+	Name string `json:"name"`
+	// 保有数量(投資信託は口数)
+	Quantity float64 `json:"quantity"`
+	// 取得単価(米国株はドル、投資信託は1万口あたりの円、暗号通貨は円)
+	GetPrice float64 `json:"getPrice"`
+	// 競合・対象外の理由、または更新前の内容
+	Message *string `json:"message,omitempty"`
 }
 
 type JapanFund struct {
@@ -91,6 +579,8 @@ type JapanFund struct {
 	GetPriceTotal float64 `json:"getPriceTotal"`
 	// 現在価格
 	CurrentPrice float64 `json:"currentPrice"`
+	// 証券口座のid
+	AccountID string `json:"accountId"`
 }
 
 type MarketPrice struct {
@@ -102,6 +592,183 @@ type MarketPrice struct {
 	PriceGets float64 `json:"priceGets"`
 	// 変化率
 	CurrentRate float64 `json:"currentRate"`
+	// 当日安値
+	DayLow float64 `json:"dayLow"`
+	// 当日高値
+	DayHigh float64 `json:"dayHigh"`
+	// 52週高値
+	YearHigh float64 `json:"yearHigh"`
+	// 52週安値
+	YearLow float64 `json:"yearLow"`
+	// 時価総額
+	MarketCap float64 `json:"marketCap"`
+	// PER(株価収益率)
+	Pe float64 `json:"pe"`
+	// EPS(1株当たり利益)
+	Eps float64 `json:"eps"`
+	// 出来高
+	Volume float64 `json:"volume"`
+	// 50日移動平均
+	PriceAvg50 float64 `json:"priceAvg50"`
+	// 200日移動平均
+	PriceAvg200 float64 `json:"priceAvg200"`
+	// 次回決算発表日時
+	EarningsAnnouncement *string `json:"earningsAnnouncement,omitempty"`
+}
+
+type NisaAllowance struct {
+	// 年
+	Year int `json:"year"`
+	// 年間投資枠(成長投資枠・つみたて投資枠の合計)
+	Annual *NisaFrameUsage `json:"annual"`
+	// 成長投資枠の年間投資枠
+	GrowthAnnual *NisaFrameUsage `json:"growthAnnual"`
+	// つみたて投資枠の年間投資枠
+	TsumitateAnnual *NisaFrameUsage `json:"tsumitateAnnual"`
+	// 生涯投資枠
+	Lifetime *NisaFrameUsage `json:"lifetime"`
+	// 成長投資枠の生涯投資枠
+	GrowthLifetime *NisaFrameUsage `json:"growthLifetime"`
+}
+
+type NisaFrameUsage struct {
+	// 上限額(円)
+	Limit float64 `json:"limit"`
+	// 利用額(円)
+	Used float64 `json:"used"`
+	// 残りの投資枠(円)
+	Remaining float64 `json:"remaining"`
+}
+
+type NotificationChannel struct {
+	ID string `json:"id"`
+	// 通知先の種類
+	Type NotificationChannelType `json:"type"`
+	// 表示名
+	Name string `json:"name"`
+	// 通知先のURL(EMAILの場合はメールアドレス)
+	Target string `json:"target"`
+	// 有効かどうか
+	Enabled bool `json:"enabled"`
+}
+
+type NotificationDelivery struct {
+	ID string `json:"id"`
+	// 通知先のid
+	ChannelID string `json:"channelId"`
+	// 通知先の表示名
+	ChannelName string `json:"channelName"`
+	// 通知の種類(ALERT_TRIGGERED / SNAPSHOT_FAILED / DAILY_SUMMARY)
+	Event string `json:"event"`
+	// 件名
+	Subject string `json:"subject"`
+	// 送信状況
+	Status NotificationDeliveryStatus `json:"status"`
+	// 送信を試行した回数
+	Attempts int `json:"attempts"`
+	// 直近の送信エラー
+	LastError *string `json:"lastError,omitempty"`
+	// 次回の送信予定日時(送信待ちの場合のみ)
+	NextAttemptAt *string `json:"nextAttemptAt,omitempty"`
+	// 送信日時
+	SentAt *string `json:"sentAt,omitempty"`
+	// 登録日時
+	CreatedAt string `json:"createdAt"`
+}
+
+type PortfolioValue struct {
+	// 資産総額(円)
+	TotalAmount float64 `json:"totalAmount"`
+	// 米国株の評価額(円)
+	StockAmount float64 `json:"stockAmount"`
+	// 米国株の評価額の前日比(円)
+	StockDayChange float64 `json:"stockDayChange"`
+	// 米国株・ドル預金以外の資産(円、最新の資産スナップショットの値)
+	OtherAmount float64 `json:"otherAmount"`
+	// ドル円
+	UsdJpy float64 `json:"usdJpy"`
+	// 価格の取得日時
+	UpdatedAt string `json:"updatedAt"`
+}
+
+type PricePoint struct {
+	// 日付(週次・月次の場合は期間の最初の取引日)
+	Date string `json:"date"`
+	// 始値
+	Open float64 `json:"open"`
+	// 高値
+	High float64 `json:"high"`
+	// 安値
+	Low float64 `json:"low"`
+	// 終値
+	Close float64 `json:"close"`
+	// 出来高
+	Volume float64 `json:"volume"`
+}
+
+type ShareToken struct {
+	// id
+	ID string `json:"id"`
+	// 共有リンクのトークン(/api/v1/share/{token} で公開される)
+	// トークンはハッシュのみ保存するため、作成時にのみ返却する(一覧ではnull)
+	Token *string `json:"token,omitempty"`
+	// 公開範囲
+	Privacy SharePrivacy `json:"privacy"`
+	// 有効期限(RFC3339)
+	ExpiresAt string `json:"expiresAt"`
+	// 有効期限切れかどうか
+	Expired bool `json:"expired"`
+}
+
+type StockSale struct {
+	ID string `json:"id"`
+	// ティッカーシンボル
+	Code string `json:"code"`
+	// 約定日
+	TradeDate string `json:"tradeDate"`
+	// 売却数量
+	Quantity float64 `json:"quantity"`
+	// 売却単価(ドル)
+	SalePrice float64 `json:"salePrice"`
+	// 取得単価(ドル)
+	GetPrice float64 `json:"getPrice"`
+	// 売却手数料(ドル)
+	Fee float64 `json:"fee"`
+	// 約定日のTTM
+	UsdJpy float64 `json:"usdJpy"`
+	// 取得日のTTM
+	GetUsdJpy float64 `json:"getUsdJpy"`
+	// 譲渡価額(円、売却代金 × 約定日のTTM)
+	Proceeds float64 `json:"proceeds"`
+	// 取得費(円、取得価額 × 取得日のTTM)
+	Cost float64 `json:"cost"`
+	// 売却手数料(円)
+	FeeJpy float64 `json:"feeJpy"`
+	// 譲渡損益(円、譲渡価額 - 取得費 - 売却手数料)
+	RealizedGain float64 `json:"realizedGain"`
+}
+
+type TaxReportSecurity struct {
+	// ティッカーシンボル
+	Code string `json:"code"`
+	// 配当金(税引前)
+	DividendAmount float64 `json:"dividendAmount"`
+	// 外国源泉徴収税額(外国税額控除の対象)
+	ForeignTax float64 `json:"foreignTax"`
+	// 国内源泉徴収税額
+	DomesticTax float64 `json:"domesticTax"`
+	// 譲渡価額
+	Proceeds float64 `json:"proceeds"`
+	// 取得費
+	Cost float64 `json:"cost"`
+	// 売却手数料
+	Fee float64 `json:"fee"`
+	// 譲渡損益
+	RealizedGain float64 `json:"realizedGain"`
+	// 配当の受取記録
+	Dividends []*DividendReceipt `json:"dividends"`
+	// 売却記録
+	Sales []*StockSale `json:"sales"`
 }
 
 type TotalAsset struct {
@@ -120,6 +787,63 @@ type TotalAsset struct {
 	FixedIncomeAsset float64 `json:"fixedIncomeAsset"`
 	// 登録日時
 	CreatedAt string `json:"createdAt"`
+	// 口座別の内訳
+	Accounts []*TotalAssetAccount `json:"accounts"`
+}
+
+type TotalAssetAccount struct {
+	// 証券口座のid(口座が必須になる前に作成した内訳のうち、口座未設定の保有資産は null)
+	AccountID *string `json:"accountId,omitempty"`
+	// 保有株式
+	Stock float64 `json:"stock"`
+	// 保有投資信託
+	Fund float64 `json:"fund"`
+	// 保有仮想通貨
+	Crypto float64 `json:"crypto"`
+	// 保有固定利回り資産
+	FixedIncomeAsset float64 `json:"fixedIncomeAsset"`
+}
+
+type TriggeredAlert struct {
+	ID string `json:"id"`
+	// アラート条件のid
+	AlertRuleID string `json:"alertRuleId"`
+	// 監視対象
+	TargetType AlertTargetType `json:"targetType"`
+	// シンボル
+	Symbol string `json:"symbol"`
+	// 発生条件
+	Condition AlertCondition `json:"condition"`
+	// 閾値
+	Threshold float64 `json:"threshold"`
+	// 発生時の値
+	Value float64 `json:"value"`
+	// 発生日時
+	TriggeredAt string `json:"triggeredAt"`
+}
+
+type UpdateAlertRuleInput struct {
+	// id
+	ID string `json:"id"`
+	// 発生条件
+	Condition AlertCondition `json:"condition"`
+	// 閾値
+	Threshold float64 `json:"threshold"`
+	// 通知モード
+	Mode AlertMode `json:"mode"`
+	// 再通知までの間隔(分)
+	CooldownMinutes int `json:"cooldownMinutes"`
+	// 有効かどうか
+	Enabled bool `json:"enabled"`
+}
+
+type UpdateBrokerageAccountInput struct {
+	// id
+	ID string `json:"id"`
+	// 口座名
+	Name string `json:"name"`
+	// 口座の種類
+	Type AccountType `json:"type"`
 }
 
 type UpdateCryptoInput struct {
@@ -138,6 +862,31 @@ type UpdateFixedIncomeAssetInput struct {
 	GetPriceTotal float64 `json:"getPriceTotal"`
 	// 購入時為替
 	UsdJpy *float64 `json:"usdJpy,omitempty"`
+	// 発行日(YYYY-MM-DD)
+	IssueDate *string `json:"issueDate,omitempty"`
+	// 満期日(YYYY-MM-DD、満期を迎えると自動で償還済みになり償還金額が現金に移る)
+	MaturityDate *string `json:"maturityDate,omitempty"`
+	// 利払い頻度
+	CouponFrequency *CouponFrequency `json:"couponFrequency,omitempty"`
+	// 通貨
+	Currency *FixedIncomeCurrency `json:"currency,omitempty"`
+	// 償還金額
+	RedemptionAmount *float64 `json:"redemptionAmount,omitempty"`
+	// 評価方法
+	ValuationMode *FixedIncomeValuationMode `json:"valuationMode,omitempty"`
+	// 額面100あたりの時価(クリーン価格、評価方法がMARKETの場合に用いる)
+	CleanPrice *float64 `json:"cleanPrice,omitempty"`
+	// 購入日(YYYY-MM-DD。償却原価の計算に用いる)
+	PurchaseDate *string `json:"purchaseDate,omitempty"`
+}
+
+type UpdateHouseholdMemberInput struct {
+	// 世帯のid
+	HouseholdID string `json:"householdId"`
+	// ユーザーのid
+	UserID string `json:"userId"`
+	// 権限(EDITOR / VIEWER)
+	Role HouseholdRole `json:"role"`
 }
 
 type UpdateJapanFundInput struct {
@@ -149,6 +898,19 @@ type UpdateJapanFundInput struct {
 	GetPriceTotal float64 `json:"getPriceTotal"`
 }
 
+type UpdateNotificationChannelInput struct {
+	// id
+	ID string `json:"id"`
+	// 表示名
+	Name string `json:"name"`
+	// 通知先のURL(EMAILの場合はメールアドレス)
+	Target string `json:"target"`
+	// 署名用のシークレット(省略した場合は変更しない)
+	Secret *string `json:"secret,omitempty"`
+	// 有効かどうか
+	Enabled bool `json:"enabled"`
+}
+
 type UpdateTotalAssetInput struct {
 	ID string `json:"id"`
 	// 保有円
@@ -168,10 +930,23 @@ type UpdateUsStockInput struct {
 	UsdJpy float64 `json:"usdJpy"`
 }
 
+type UpdateWatchlistInput struct {
+	// id
+	ID string `json:"id"`
+	// メモ
+	Note *string `json:"note,omitempty"`
+	// 目標購入価格
+	TargetPrice *float64 `json:"targetPrice,omitempty"`
+	// 目標配当利回り(%)
+	TargetYield *float64 `json:"targetYield,omitempty"`
+}
+
 type UsStock struct {
 	ID string `json:"id"`
 	// ティッカーシンボル
 	Code string `json:"code"`
+	// 会社名
+	Name string `json:"name"`
 	// 取得価格
 	GetPrice float64 `json:"getPrice"`
 	// １年当たり配当
@@ -188,6 +963,30 @@ type UsStock struct {
 	PriceGets float64 `json:"priceGets"`
 	// 変化率
 	CurrentRate float64 `json:"currentRate"`
+	// 当日安値
+	DayLow float64 `json:"dayLow"`
+	// 当日高値
+	DayHigh float64 `json:"dayHigh"`
+	// 52週高値
+	YearHigh float64 `json:"yearHigh"`
+	// 52週安値
+	YearLow float64 `json:"yearLow"`
+	// 時価総額
+	MarketCap float64 `json:"marketCap"`
+	// PER(株価収益率)
+	Pe float64 `json:"pe"`
+	// EPS(1株当たり利益)
+	Eps float64 `json:"eps"`
+	// 出来高
+	Volume float64 `json:"volume"`
+	// 50日移動平均
+	PriceAvg50 float64 `json:"priceAvg50"`
+	// 200日移動平均
+	PriceAvg200 float64 `json:"priceAvg200"`
+	// 次回決算発表日時
+	EarningsAnnouncement *string `json:"earningsAnnouncement,omitempty"`
+	// 証券口座のid
+	AccountID string `json:"accountId"`
 }
 
 type User struct {
@@ -196,3 +995,926 @@ type User struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 }
+
+type Watchlist struct {
+	ID string `json:"id"`
+	// ティッカーシンボル
+	Ticker string `json:"ticker"`
+	// メモ
+	Note string `json:"note"`
+	// 目標購入価格
+	TargetPrice *float64 `json:"targetPrice,omitempty"`
+	// 目標配当利回り(%)
+	TargetYield *float64 `json:"targetYield,omitempty"`
+	// 現在価格
+	CurrentPrice float64 `json:"currentPrice"`
+	// 変化額
+	PriceGets float64 `json:"priceGets"`
+	// 変化率
+	CurrentRate float64 `json:"currentRate"`
+	// 52週高値
+	YearHigh float64 `json:"yearHigh"`
+	// 52週安値
+	YearLow float64 `json:"yearLow"`
+	// PER(株価収益率)
+	Pe float64 `json:"pe"`
+	// 次回決算発表日時
+	EarningsAnnouncement *string `json:"earningsAnnouncement,omitempty"`
+	// １年当たり配当
+	Dividend float64 `json:"dividend"`
+	// 配当利回り(%)
+	DividendYield float64 `json:"dividendYield"`
+	// 現在価格が目標購入価格以下か
+	ReachedTargetPrice bool `json:"reachedTargetPrice"`
+	// 配当利回りが目標配当利回り以上か
+	ReachedTargetYield bool `json:"reachedTargetYield"`
+}
+
+type AccountType string
+
+const (
+	// NISA(成長投資枠)
+	AccountTypeNisaGrowth AccountType = "NISA_GROWTH"
+	// NISA(つみたて投資枠)
+	AccountTypeNisaTsumitate AccountType = "NISA_TSUMITATE"
+	// 特定口座・一般口座
+	AccountTypeTaxable AccountType = "TAXABLE"
+	// iDeCo
+	AccountTypeIdeco AccountType = "IDECO"
+	// 海外証券口座
+	AccountTypeOverseas AccountType = "OVERSEAS"
+)
+
+var AllAccountType = []AccountType{
+	AccountTypeNisaGrowth,
+	AccountTypeNisaTsumitate,
+	AccountTypeTaxable,
+	AccountTypeIdeco,
+	AccountTypeOverseas,
+}
+
+func (e AccountType) IsValid() bool {
+	switch e {
+	case AccountTypeNisaGrowth, AccountTypeNisaTsumitate, AccountTypeTaxable, AccountTypeIdeco, AccountTypeOverseas:
+		return true
+	}
+	return false
+}
+
+func (e AccountType) String() string {
+	return string(e)
+}
+
+func (e *AccountType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AccountType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AccountType", str)
+	}
+	return nil
+}
+
+func (e AccountType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type AlertCondition string
+
+const (
+	AlertConditionAbove AlertCondition = "ABOVE"
+	AlertConditionBelow AlertCondition = "BELOW"
+)
+
+var AllAlertCondition = []AlertCondition{
+	AlertConditionAbove,
+	AlertConditionBelow,
+}
+
+func (e AlertCondition) IsValid() bool {
+	switch e {
+	case AlertConditionAbove, AlertConditionBelow:
+		return true
+	}
+	return false
+}
+
+func (e AlertCondition) String() string {
+	return string(e)
+}
+
+func (e *AlertCondition) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AlertCondition(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AlertCondition", str)
+	}
+	return nil
+}
+
+func (e AlertCondition) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type AlertMode string
+
+const (
+	AlertModeOnce     AlertMode = "ONCE"
+	AlertModeCooldown AlertMode = "COOLDOWN"
+)
+
+var AllAlertMode = []AlertMode{
+	AlertModeOnce,
+	AlertModeCooldown,
+}
+
+func (e AlertMode) IsValid() bool {
+	switch e {
+	case AlertModeOnce, AlertModeCooldown:
+		return true
+	}
+	return false
+}
+
+func (e AlertMode) String() string {
+	return string(e)
+}
+
+func (e *AlertMode) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AlertMode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AlertMode", str)
+	}
+	return nil
+}
+
+func (e AlertMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type AlertTargetType string
+
+const (
+	AlertTargetTypeStockPrice    AlertTargetType = "STOCK_PRICE"
+	AlertTargetTypeDividendYield AlertTargetType = "DIVIDEND_YIELD"
+	AlertTargetTypeFxRate        AlertTargetType = "FX_RATE"
+	AlertTargetTypeCryptoPrice   AlertTargetType = "CRYPTO_PRICE"
+)
+
+var AllAlertTargetType = []AlertTargetType{
+	AlertTargetTypeStockPrice,
+	AlertTargetTypeDividendYield,
+	AlertTargetTypeFxRate,
+	AlertTargetTypeCryptoPrice,
+}
+
+func (e AlertTargetType) IsValid() bool {
+	switch e {
+	case AlertTargetTypeStockPrice, AlertTargetTypeDividendYield, AlertTargetTypeFxRate, AlertTargetTypeCryptoPrice:
+		return true
+	}
+	return false
+}
+
+func (e AlertTargetType) String() string {
+	return string(e)
+}
+
+func (e *AlertTargetType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AlertTargetType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AlertTargetType", str)
+	}
+	return nil
+}
+
+func (e AlertTargetType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type AssetClass string
+
+const (
+	AssetClassCashJpy     AssetClass = "CASH_JPY"
+	AssetClassCashUsd     AssetClass = "CASH_USD"
+	AssetClassStock       AssetClass = "STOCK"
+	AssetClassFund        AssetClass = "FUND"
+	AssetClassCrypto      AssetClass = "CRYPTO"
+	AssetClassFixedIncome AssetClass = "FIXED_INCOME"
+)
+
+var AllAssetClass = []AssetClass{
+	AssetClassCashJpy,
+	AssetClassCashUsd,
+	AssetClassStock,
+	AssetClassFund,
+	AssetClassCrypto,
+	AssetClassFixedIncome,
+}
+
+func (e AssetClass) IsValid() bool {
+	switch e {
+	case AssetClassCashJpy, AssetClassCashUsd, AssetClassStock, AssetClassFund, AssetClassCrypto, AssetClassFixedIncome:
+		return true
+	}
+	return false
+}
+
+func (e AssetClass) String() string {
+	return string(e)
+}
+
+func (e *AssetClass) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AssetClass(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AssetClass", str)
+	}
+	return nil
+}
+
+func (e AssetClass) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type CalendarEventType string
+
+const (
+	CalendarEventTypeEarnings        CalendarEventType = "EARNINGS"
+	CalendarEventTypeExDividend      CalendarEventType = "EX_DIVIDEND"
+	CalendarEventTypeDividendPayment CalendarEventType = "DIVIDEND_PAYMENT"
+)
+
+var AllCalendarEventType = []CalendarEventType{
+	CalendarEventTypeEarnings,
+	CalendarEventTypeExDividend,
+	CalendarEventTypeDividendPayment,
+}
+
+func (e CalendarEventType) IsValid() bool {
+	switch e {
+	case CalendarEventTypeEarnings, CalendarEventTypeExDividend, CalendarEventTypeDividendPayment:
+		return true
+	}
+	return false
+}
+
+func (e CalendarEventType) String() string {
+	return string(e)
+}
+
+func (e *CalendarEventType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CalendarEventType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CalendarEventType", str)
+	}
+	return nil
+}
+
+func (e CalendarEventType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type CouponFrequency string
+
+const (
+	// 満期一括
+	CouponFrequencyAtMaturity CouponFrequency = "AT_MATURITY"
+	// 年1回
+	CouponFrequencyAnnual CouponFrequency = "ANNUAL"
+	// 年2回
+	CouponFrequencySemiAnnual CouponFrequency = "SEMI_ANNUAL"
+	// 年4回
+	CouponFrequencyQuarterly CouponFrequency = "QUARTERLY"
+	// 毎月
+	CouponFrequencyMonthly CouponFrequency = "MONTHLY"
+)
+
+var AllCouponFrequency = []CouponFrequency{
+	CouponFrequencyAtMaturity,
+	CouponFrequencyAnnual,
+	CouponFrequencySemiAnnual,
+	CouponFrequencyQuarterly,
+	CouponFrequencyMonthly,
+}
+
+func (e CouponFrequency) IsValid() bool {
+	switch e {
+	case CouponFrequencyAtMaturity, CouponFrequencyAnnual, CouponFrequencySemiAnnual, CouponFrequencyQuarterly, CouponFrequencyMonthly:
+		return true
+	}
+	return false
+}
+
+func (e CouponFrequency) String() string {
+	return string(e)
+}
+
+func (e *CouponFrequency) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CouponFrequency(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CouponFrequency", str)
+	}
+	return nil
+}
+
+func (e CouponFrequency) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type FixedIncomeCashFlowType string
+
+const (
+	// 利払い
+	FixedIncomeCashFlowTypeCoupon FixedIncomeCashFlowType = "COUPON"
+	// 満期償還
+	FixedIncomeCashFlowTypeRedemption FixedIncomeCashFlowType = "REDEMPTION"
+)
+
+var AllFixedIncomeCashFlowType = []FixedIncomeCashFlowType{
+	FixedIncomeCashFlowTypeCoupon,
+	FixedIncomeCashFlowTypeRedemption,
+}
+
+func (e FixedIncomeCashFlowType) IsValid() bool {
+	switch e {
+	case FixedIncomeCashFlowTypeCoupon, FixedIncomeCashFlowTypeRedemption:
+		return true
+	}
+	return false
+}
+
+func (e FixedIncomeCashFlowType) String() string {
+	return string(e)
+}
+
+func (e *FixedIncomeCashFlowType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FixedIncomeCashFlowType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FixedIncomeCashFlowType", str)
+	}
+	return nil
+}
+
+func (e FixedIncomeCashFlowType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type FixedIncomeCurrency string
+
+const (
+	FixedIncomeCurrencyJpy FixedIncomeCurrency = "JPY"
+	FixedIncomeCurrencyUsd FixedIncomeCurrency = "USD"
+)
+
+var AllFixedIncomeCurrency = []FixedIncomeCurrency{
+	FixedIncomeCurrencyJpy,
+	FixedIncomeCurrencyUsd,
+}
+
+func (e FixedIncomeCurrency) IsValid() bool {
+	switch e {
+	case FixedIncomeCurrencyJpy, FixedIncomeCurrencyUsd:
+		return true
+	}
+	return false
+}
+
+func (e FixedIncomeCurrency) String() string {
+	return string(e)
+}
+
+func (e *FixedIncomeCurrency) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FixedIncomeCurrency(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FixedIncomeCurrency", str)
+	}
+	return nil
+}
+
+func (e FixedIncomeCurrency) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type FixedIncomeStatus string
+
+const (
+	// 保有中
+	FixedIncomeStatusActive FixedIncomeStatus = "ACTIVE"
+	// 満期償還済み
+	FixedIncomeStatusMatured FixedIncomeStatus = "MATURED"
+)
+
+var AllFixedIncomeStatus = []FixedIncomeStatus{
+	FixedIncomeStatusActive,
+	FixedIncomeStatusMatured,
+}
+
+func (e FixedIncomeStatus) IsValid() bool {
+	switch e {
+	case FixedIncomeStatusActive, FixedIncomeStatusMatured:
+		return true
+	}
+	return false
+}
+
+func (e FixedIncomeStatus) String() string {
+	return string(e)
+}
+
+func (e *FixedIncomeStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FixedIncomeStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FixedIncomeStatus", str)
+	}
+	return nil
+}
+
+func (e FixedIncomeStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type FixedIncomeValuationMode string
+
+const (
+	// 額面(償還金額)
+	FixedIncomeValuationModeFace FixedIncomeValuationMode = "FACE"
+	// 償却原価(取得価格から満期日に向けて償還金額まで定額で償却する)
+	FixedIncomeValuationModeAmortizedCost FixedIncomeValuationMode = "AMORTIZED_COST"
+	// 時価(額面100あたりのクリーン価格、未登録の場合は償却原価)
+	FixedIncomeValuationModeMarket FixedIncomeValuationMode = "MARKET"
+)
+
+var AllFixedIncomeValuationMode = []FixedIncomeValuationMode{
+	FixedIncomeValuationModeFace,
+	FixedIncomeValuationModeAmortizedCost,
+	FixedIncomeValuationModeMarket,
+}
+
+func (e FixedIncomeValuationMode) IsValid() bool {
+	switch e {
+	case FixedIncomeValuationModeFace, FixedIncomeValuationModeAmortizedCost, FixedIncomeValuationModeMarket:
+		return true
+	}
+	return false
+}
+
+func (e FixedIncomeValuationMode) String() string {
+	return string(e)
+}
+
+func (e *FixedIncomeValuationMode) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FixedIncomeValuationMode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FixedIncomeValuationMode", str)
+	}
+	return nil
+}
+
+func (e FixedIncomeValuationMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type HoldingType string
+
+const (
+	HoldingTypeUsStock          HoldingType = "US_STOCK"
+	HoldingTypeCrypto           HoldingType = "CRYPTO"
+	HoldingTypeJapanFund        HoldingType = "JAPAN_FUND"
+	HoldingTypeFixedIncomeAsset HoldingType = "FIXED_INCOME_ASSET"
+)
+
+var AllHoldingType = []HoldingType{
+	HoldingTypeUsStock,
+	HoldingTypeCrypto,
+	HoldingTypeJapanFund,
+	HoldingTypeFixedIncomeAsset,
+}
+
+func (e HoldingType) IsValid() bool {
+	switch e {
+	case HoldingTypeUsStock, HoldingTypeCrypto, HoldingTypeJapanFund, HoldingTypeFixedIncomeAsset:
+		return true
+	}
+	return false
+}
+
+func (e HoldingType) String() string {
+	return string(e)
+}
+
+func (e *HoldingType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = HoldingType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid HoldingType", str)
+	}
+	return nil
+}
+
+func (e HoldingType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type HouseholdRole string
+
+const (
+	HouseholdRoleOwner  HouseholdRole = "OWNER"
+	HouseholdRoleEditor HouseholdRole = "EDITOR"
+	HouseholdRoleViewer HouseholdRole = "VIEWER"
+)
+
+var AllHouseholdRole = []HouseholdRole{
+	HouseholdRoleOwner,
+	HouseholdRoleEditor,
+	HouseholdRoleViewer,
+}
+
+func (e HouseholdRole) IsValid() bool {
+	switch e {
+	case HouseholdRoleOwner, HouseholdRoleEditor, HouseholdRoleViewer:
+		return true
+	}
+	return false
+}
+
+func (e HouseholdRole) String() string {
+	return string(e)
+}
+
+func (e *HouseholdRole) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = HouseholdRole(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid HouseholdRole", str)
+	}
+	return nil
+}
+
+func (e HouseholdRole) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ImportAction string
+
+const (
+	ImportActionCreate   ImportAction = "CREATE"
+	ImportActionUpdate   ImportAction = "UPDATE"
+	ImportActionConflict ImportAction = "CONFLICT"
+	ImportActionSkip     ImportAction = "SKIP"
+)
+
+var AllImportAction = []ImportAction{
+	ImportActionCreate,
+	ImportActionUpdate,
+	ImportActionConflict,
+	ImportActionSkip,
+}
+
+func (e ImportAction) IsValid() bool {
+	switch e {
+	case ImportActionCreate, ImportActionUpdate, ImportActionConflict, ImportActionSkip:
+		return true
+	}
+	return false
+}
+
+func (e ImportAction) String() string {
+	return string(e)
+}
+
+func (e *ImportAction) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ImportAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ImportAction", str)
+	}
+	return nil
+}
+
+func (e ImportAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ImportAssetType string
+
+const (
+	ImportAssetTypeUsStock   ImportAssetType = "US_STOCK"
+	ImportAssetTypeJapanFund ImportAssetType = "JAPAN_FUND"
+	ImportAssetTypeCrypto    ImportAssetType = "CRYPTO"
+)
+
+var AllImportAssetType = []ImportAssetType{
+	ImportAssetTypeUsStock,
+	ImportAssetTypeJapanFund,
+	ImportAssetTypeCrypto,
+}
+
+func (e ImportAssetType) IsValid() bool {
+	switch e {
+	case ImportAssetTypeUsStock, ImportAssetTypeJapanFund, ImportAssetTypeCrypto:
+		return true
+	}
+	return false
+}
+
+func (e ImportAssetType) String() string {
+	return string(e)
+}
+
+func (e *ImportAssetType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ImportAssetType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ImportAssetType", str)
+	}
+	return nil
+}
+
+func (e ImportAssetType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ImportFormat string
+
+const (
+	ImportFormatSbi     ImportFormat = "SBI"
+	ImportFormatRakuten ImportFormat = "RAKUTEN"
+	ImportFormatGeneric ImportFormat = "GENERIC"
+)
+
+var AllImportFormat = []ImportFormat{
+	ImportFormatSbi,
+	ImportFormatRakuten,
+	ImportFormatGeneric,
+}
+
+func (e ImportFormat) IsValid() bool {
+	switch e {
+	case ImportFormatSbi, ImportFormatRakuten, ImportFormatGeneric:
+		return true
+	}
+	return false
+}
+
+func (e ImportFormat) String() string {
+	return string(e)
+}
+
+func (e *ImportFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ImportFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ImportFormat", str)
+	}
+	return nil
+}
+
+func (e ImportFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type NotificationChannelType string
+
+const (
+	NotificationChannelTypeWebhook NotificationChannelType = "WEBHOOK"
+	NotificationChannelTypeSLACk   NotificationChannelType = "SLACK"
+	NotificationChannelTypeDiscord NotificationChannelType = "DISCORD"
+	NotificationChannelTypeEmail   NotificationChannelType = "EMAIL"
+)
+
+var AllNotificationChannelType = []NotificationChannelType{
+	NotificationChannelTypeWebhook,
+	NotificationChannelTypeSLACk,
+	NotificationChannelTypeDiscord,
+	NotificationChannelTypeEmail,
+}
+
+func (e NotificationChannelType) IsValid() bool {
+	switch e {
+	case NotificationChannelTypeWebhook, NotificationChannelTypeSLACk, NotificationChannelTypeDiscord, NotificationChannelTypeEmail:
+		return true
+	}
+	return false
+}
+
+func (e NotificationChannelType) String() string {
+	return string(e)
+}
+
+func (e *NotificationChannelType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationChannelType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationChannelType", str)
+	}
+	return nil
+}
+
+func (e NotificationChannelType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDeliveryStatusPending NotificationDeliveryStatus = "PENDING"
+	NotificationDeliveryStatusSent    NotificationDeliveryStatus = "SENT"
+	NotificationDeliveryStatusFailed  NotificationDeliveryStatus = "FAILED"
+)
+
+var AllNotificationDeliveryStatus = []NotificationDeliveryStatus{
+	NotificationDeliveryStatusPending,
+	NotificationDeliveryStatusSent,
+	NotificationDeliveryStatusFailed,
+}
+
+func (e NotificationDeliveryStatus) IsValid() bool {
+	switch e {
+	case NotificationDeliveryStatusPending, NotificationDeliveryStatusSent, NotificationDeliveryStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e NotificationDeliveryStatus) String() string {
+	return string(e)
+}
+
+func (e *NotificationDeliveryStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationDeliveryStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationDeliveryStatus", str)
+	}
+	return nil
+}
+
+func (e NotificationDeliveryStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type PriceInterval string
+
+const (
+	PriceIntervalDaily   PriceInterval = "DAILY"
+	PriceIntervalWeekly  PriceInterval = "WEEKLY"
+	PriceIntervalMonthly PriceInterval = "MONTHLY"
+)
+
+var AllPriceInterval = []PriceInterval{
+	PriceIntervalDaily,
+	PriceIntervalWeekly,
+	PriceIntervalMonthly,
+}
+
+func (e PriceInterval) IsValid() bool {
+	switch e {
+	case PriceIntervalDaily, PriceIntervalWeekly, PriceIntervalMonthly:
+		return true
+	}
+	return false
+}
+
+func (e PriceInterval) String() string {
+	return string(e)
+}
+
+func (e *PriceInterval) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PriceInterval(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PriceInterval", str)
+	}
+	return nil
+}
+
+func (e PriceInterval) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SharePrivacy string
+
+const (
+	// 構成比・損益率のみ公開する
+	SharePrivacyPercentage SharePrivacy = "PERCENTAGE"
+	// 金額も公開する
+	SharePrivacyAmount SharePrivacy = "AMOUNT"
+)
+
+var AllSharePrivacy = []SharePrivacy{
+	SharePrivacyPercentage,
+	SharePrivacyAmount,
+}
+
+func (e SharePrivacy) IsValid() bool {
+	switch e {
+	case SharePrivacyPercentage, SharePrivacyAmount:
+		return true
+	}
+	return false
+}
+
+func (e SharePrivacy) String() string {
+	return string(e)
+}
+
+func (e *SharePrivacy) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SharePrivacy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SharePrivacy", str)
+	}
+	return nil
+}
+
+func (e SharePrivacy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -0,0 +1,330 @@
+package holdingimport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"my-us-stock-backend/app/graphql/generated"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/width"
+)
+
+// CSVの1行(取り込み対象外の行は assetType が空)
+type csvRow struct {
+    line       int
+    assetType  generated.ImportAssetType
+    code       string
+    name       string
+    quantity   float64
+    getPrice   float64
+    usdJpy     *float64
+    sector     string
+    // 値が不正な場合の理由(競合として扱う)
+    invalid    string
+    // 取り込み対象外の理由
+    skipReason string
+}
+
+// CSVのレコードと行番号
+type csvRecord struct {
+    line   int
+    fields []string
+}
+
+// 列名の候補
+var (
+    codeColumns     = []string{"銘柄コード・ティッカー", "ティッカー", "銘柄コード", "コード", "code"}
+    nameColumns     = []string{"銘柄名", "銘柄", "ファンド名", "name"}
+    quantityColumns = []string{"保有数量", "保有口数", "数量", "口数", "quantity"}
+    priceColumns    = []string{"平均取得価額", "平均取得単価", "取得単価", "取得価額", "get_price"}
+)
+
+// 証券会社のCSVはShift_JISのことが多いため、UTF-8として読めない場合はShift_JISとして変換する
+func decodeCSV(data []byte) (string, error) {
+    data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+    if utf8.Valid(data) {
+        return string(data), nil
+    }
+    decoded, err := japanese.ShiftJIS.NewDecoder().Bytes(data)
+    if err != nil {
+        return "", errors.New("CSVの文字コードを判別できませんでした")
+    }
+    return string(decoded), nil
+}
+
+// CSVを行番号付きのレコードに分割する(列数は行ごとに異なってよい)
+// encoding/csv は空行を読み飛ばすが、証券会社のCSVでは空行が区分の区切りになるため空のレコードとして残す
+func readRecords(text string) ([]csvRecord, error) {
+    lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+    for i, line := range lines {
+        if strings.TrimSpace(line) == "" {
+            lines[i] = " "
+        }
+    }
+    reader := csv.NewReader(strings.NewReader(strings.Join(lines, "\n")))
+    reader.FieldsPerRecord = -1
+    reader.LazyQuotes = true
+    records := []csvRecord{}
+    for {
+        fields, err := reader.Read()
+        if err == io.EOF {
+            return records, nil
+        }
+        if err != nil {
+            return nil, errors.New("CSVを読み込めませんでした: " + err.Error())
+        }
+        line, _ := reader.FieldPos(0)
+        for i, field := range fields {
+            fields[i] = strings.TrimSpace(field)
+        }
+        records = append(records, csvRecord{line: line, fields: fields})
+    }
+}
+
+// 指定した形式のCSVを解析する
+func parseCSV(format generated.ImportFormat, text string) ([]csvRow, error) {
+    records, err := readRecords(text)
+    if err != nil {
+        return nil, err
+    }
+    switch format {
+    case generated.ImportFormatSbi:
+        return parseSBI(records)
+    case generated.ImportFormatRakuten:
+        return parseRakuten(records)
+    case generated.ImportFormatGeneric:
+        return parseGeneric(records)
+    }
+    return nil, errors.New("対応していないCSVの形式です")
+}
+
+// SBI証券「保有証券一覧」のCSVを解析する
+// 「米国株式(特定預り)」「投資信託(金額/特定預り)」のような見出し行ごとに、列名の行と保有銘柄の行が続く形式。
+// 見出しに「米国株式」「外国株式」を含む区分は米国株式、「投資信託」を含む区分は投資信託として取り込み、それ以外(国内株式等)は対象外とする。
+// 区分は空行または「合計」で始まる行で終わる。
+func parseSBI(records []csvRecord) ([]csvRow, error) {
+    rows := []csvRow{}
+    foundSection := false
+    for i := 0; i < len(records); i++ {
+        title, ok := sectionTitle(records[i].fields)
+        if !ok || !strings.Contains(title, "預り") {
+            continue
+        }
+        if i+1 >= len(records) {
+            break
+        }
+        foundSection = true
+        assetType := sbiAssetType(title)
+        header := records[i+1].fields
+        i += 2
+        for ; i < len(records) && !isSectionEnd(records[i].fields); i++ {
+            if assetType == "" {
+                rows = append(rows, skipRow(records[i], header, "取り込み対象外の区分です("+title+")"))
+                continue
+            }
+            rows = append(rows, newCSVRow(records[i], header, assetType))
+        }
+    }
+    if !foundSection {
+        return nil, errors.New("SBI証券の保有証券一覧のCSVではありません")
+    }
+    return rows, nil
+}
+
+func sbiAssetType(title string) generated.ImportAssetType {
+    switch {
+    case strings.Contains(title, "米国株式"), strings.Contains(title, "外国株式"):
+        return generated.ImportAssetTypeUsStock
+    case strings.Contains(title, "投資信託"):
+        return generated.ImportAssetTypeJapanFund
+    }
+    return ""
+}
+
+// 楽天証券「保有商品一覧」のCSVを解析する
+// 「種別」「銘柄コード・ティッカー」「銘柄」「口座」「保有数量」「平均取得価額」の列を持つ行以降を、空行まで取り込む。
+// 種別が「米国株式」の行は米国株式、「投資信託」の行は投資信託として取り込み、それ以外は対象外とする。
+func parseRakuten(records []csvRecord) ([]csvRow, error) {
+    for i, record := range records {
+        typeColumn := findColumn(record.fields, "種別")
+        if typeColumn < 0 || findColumn(record.fields, "銘柄コード・ティッカー") < 0 {
+            continue
+        }
+        header := record.fields
+        rows := []csvRow{}
+        for _, data := range records[i+1:] {
+            if isSectionEnd(data.fields) {
+                break
+            }
+            kind := field(data.fields, typeColumn)
+            switch {
+            case strings.Contains(kind, "米国株式"):
+                rows = append(rows, newCSVRow(data, header, generated.ImportAssetTypeUsStock))
+            case strings.Contains(kind, "投資信託"):
+                rows = append(rows, newCSVRow(data, header, generated.ImportAssetTypeJapanFund))
+            default:
+                rows = append(rows, skipRow(data, header, "取り込み対象外の種別です("+kind+")"))
+            }
+        }
+        return rows, nil
+    }
+    return nil, errors.New("楽天証券の保有商品一覧のCSVではありません")
+}
+
+// 汎用形式のCSVを解析する
+// 1行目は列名で、type(US_STOCK / JAPAN_FUND / CRYPTO)、code、quantity、get_price が必須、name、usd_jpy、sector は任意。
+// 投資信託の quantity は口数、get_price は1万口あたりの円で指定する。
+func parseGeneric(records []csvRecord) ([]csvRow, error) {
+    headerIndex := -1
+    for i, record := range records {
+        if !isBlank(record.fields) {
+            headerIndex = i
+            break
+        }
+    }
+    if headerIndex < 0 {
+        return nil, errors.New("CSVが空です")
+    }
+    header := records[headerIndex].fields
+    typeColumn := findColumn(header, "type")
+    for _, required := range []string{"type", "code", "quantity", "get_price"} {
+        if findColumn(header, required) < 0 {
+            return nil, errors.New(required + " 列がありません")
+        }
+    }
+    usdJpyColumn := findColumn(header, "usd_jpy")
+    sectorColumn := findColumn(header, "sector")
+
+    rows := []csvRow{}
+    for _, record := range records[headerIndex+1:] {
+        if isBlank(record.fields) {
+            continue
+        }
+        assetType := generated.ImportAssetType(strings.ToUpper(field(record.fields, typeColumn)))
+        if !assetType.IsValid() {
+            rows = append(rows, csvRow{
+                line:    record.line,
+                code:    field(record.fields, findColumn(header, "code")),
+                invalid: "type には US_STOCK・JAPAN_FUND・CRYPTO のいずれかを指定してください",
+            })
+            continue
+        }
+        row := newCSVRow(record, header, assetType)
+        if value := field(record.fields, usdJpyColumn); value != "" && row.invalid == "" {
+            usdJpy, err := parseNumber(value)
+            if err != nil || usdJpy <= 0 {
+                row.invalid = "usd_jpy が正しくありません"
+            } else {
+                row.usdJpy = &usdJpy
+            }
+        }
+        row.sector = field(record.fields, sectorColumn)
+        rows = append(rows, row)
+    }
+    return rows, nil
+}
+
+// 列名の行をもとに保有銘柄の行を解析する
+func newCSVRow(record csvRecord, header []string, assetType generated.ImportAssetType) csvRow {
+    row := csvRow{
+        line:      record.line,
+        assetType: assetType,
+        code:      field(record.fields, findColumn(header, codeColumns...)),
+        name:      field(record.fields, findColumn(header, nameColumns...)),
+    }
+    switch assetType {
+    case generated.ImportAssetTypeUsStock:
+        row.code = strings.ToUpper(row.code)
+    case generated.ImportAssetTypeCrypto:
+        row.code = strings.ToLower(row.code)
+    }
+    if row.code == "" && (assetType != generated.ImportAssetTypeJapanFund || row.name == "") {
+        row.invalid = "銘柄コードがありません"
+        return row
+    }
+
+    quantity, err := parseNumber(field(record.fields, findColumn(header, quantityColumns...)))
+    if err != nil || quantity <= 0 {
+        row.invalid = "保有数量が正しくありません"
+        return row
+    }
+    getPrice, err := parseNumber(field(record.fields, findColumn(header, priceColumns...)))
+    if err != nil || getPrice < 0 {
+        row.invalid = "取得単価が正しくありません"
+        return row
+    }
+    row.quantity = quantity
+    row.getPrice = getPrice
+    return row
+}
+
+func skipRow(record csvRecord, header []string, reason string) csvRow {
+    return csvRow{
+        line:       record.line,
+        code:       field(record.fields, findColumn(header, codeColumns...)),
+        name:       field(record.fields, findColumn(header, nameColumns...)),
+        skipReason: reason,
+    }
+}
+
+// 列名の候補のうち最初に見つかった列の位置を返す(見つからない場合は-1)
+func findColumn(header []string, names ...string) int {
+    for _, name := range names {
+        for i, column := range header {
+            if strings.EqualFold(normalizeText(column), normalizeText(name)) {
+                return i
+            }
+        }
+    }
+    return -1
+}
+
+func field(fields []string, index int) string {
+    if index < 0 || index >= len(fields) {
+        return ""
+    }
+    return fields[index]
+}
+
+// 見出し行(最初の列のみ値がある行)の場合はその値を返す
+func sectionTitle(fields []string) (string, bool) {
+    if len(fields) == 0 || fields[0] == "" {
+        return "", false
+    }
+    for _, value := range fields[1:] {
+        if value != "" {
+            return "", false
+        }
+    }
+    return normalizeText(fields[0]), true
+}
+
+func isBlank(fields []string) bool {
+    for _, value := range fields {
+        if value != "" {
+            return false
+        }
+    }
+    return true
+}
+
+func isSectionEnd(fields []string) bool {
+    return isBlank(fields) || strings.HasPrefix(fields[0], "合計") || strings.HasPrefix(fields[0], "評価額合計")
+}
+
+// 数値の列を解析する(桁区切りのカンマや単位は取り除く)
+func parseNumber(value string) (float64, error) {
+    value = normalizeText(value)
+    value = strings.NewReplacer(",", "", "円", "", "口", "", "株", "", "USD", "", "$", "", "+", "").Replace(value)
+    return strconv.ParseFloat(value, 64)
+}
+
+// 全角英数字・記号を半角にそろえ、空白を取り除く
+func normalizeText(value string) string {
+    return strings.Join(strings.Fields(width.Fold.String(value)), "")
+}
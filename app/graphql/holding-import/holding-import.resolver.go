@@ -0,0 +1,18 @@
+package holdingimport
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+)
+
+type Resolver struct {
+    HoldingImportService HoldingImportService
+}
+
+func NewResolver(holdingImportService HoldingImportService) *Resolver {
+    return &Resolver{HoldingImportService: holdingImportService}
+}
+
+func (r *Resolver) ImportHoldings(ctx context.Context, input generated.ImportHoldingsInput) (*generated.ImportResult, error) {
+    return r.HoldingImportService.ImportHoldings(ctx, input)
+}
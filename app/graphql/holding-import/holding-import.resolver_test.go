@@ -0,0 +1,35 @@
+package holdingimport
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockHoldingImportService は HoldingImportService のモックです。
+type MockHoldingImportService struct {
+    mock.Mock
+}
+
+func (m *MockHoldingImportService) ImportHoldings(ctx context.Context, input generated.ImportHoldingsInput) (*generated.ImportResult, error) {
+    args := m.Called(ctx, input)
+    return args.Get(0).(*generated.ImportResult), args.Error(1)
+}
+
+func TestImportHoldings(t *testing.T) {
+    mockService := new(MockHoldingImportService)
+    resolver := NewResolver(mockService)
+
+    input := generated.ImportHoldingsInput{Format: generated.ImportFormatSbi}
+    expected := &generated.ImportResult{DryRun: true, CreateCount: 2}
+    mockService.On("ImportHoldings", mock.Anything, input).Return(expected, nil)
+
+    result, err := resolver.ImportHoldings(context.Background(), input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, expected, result)
+    mockService.AssertExpectations(t)
+}
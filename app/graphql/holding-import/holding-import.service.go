@@ -0,0 +1,149 @@
+package holdingimport
+
+import (
+	"context"
+	"io"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	"my-us-stock-backend/app/repository/assets/crypto"
+	"my-us-stock-backend/app/repository/assets/fund"
+	"my-us-stock-backend/app/repository/assets/stock"
+	brokerageAccount "my-us-stock-backend/app/repository/brokerage-account"
+	holdingImport "my-us-stock-backend/app/repository/holding-import"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	"my-us-stock-backend/app/repository/market-price/currency"
+	fundPrice "my-us-stock-backend/app/repository/market-price/fund"
+	"my-us-stock-backend/app/repository/market-price/instrument"
+)
+
+// 取り込めるCSVの最大サイズ(1MB)
+const maxImportFileSize = 1 << 20
+
+// HoldingImportService インターフェースの定義
+type HoldingImportService interface {
+    ImportHoldings(ctx context.Context, input generated.ImportHoldingsInput) (*generated.ImportResult, error)
+}
+
+// DefaultHoldingImportService 構造体の定義
+type DefaultHoldingImportService struct {
+    HoldingImportRepo holdingImport.HoldingImportRepository
+    StockRepo stock.UsStockRepository
+    JapanFundRepo fund.JapanFundRepository
+    CryptoRepo crypto.CryptoRepository
+    FundPriceRepo fundPrice.FundPriceRepository
+    InstrumentRepo instrument.InstrumentRepository
+    MarketPriceRepo marketPrice.MarketPriceRepository
+    CurrencyRepo currency.CurrencyRepository
+    BrokerageAccountRepo brokerageAccount.BrokerageAccountRepository
+    Auth auth.AuthService
+}
+
+// NewHoldingImportService は DefaultHoldingImportService の新しいインスタンスを作成します
+func NewHoldingImportService(holdingImportRepo holdingImport.HoldingImportRepository, stockRepo stock.UsStockRepository, japanFundRepo fund.JapanFundRepository, cryptoRepo crypto.CryptoRepository, fundPriceRepo fundPrice.FundPriceRepository, instrumentRepo instrument.InstrumentRepository, marketPriceRepo marketPrice.MarketPriceRepository, currencyRepo currency.CurrencyRepository, brokerageAccountRepo brokerageAccount.BrokerageAccountRepository, auth auth.AuthService) HoldingImportService {
+    return &DefaultHoldingImportService{
+        HoldingImportRepo: holdingImportRepo,
+        StockRepo: stockRepo,
+        JapanFundRepo: japanFundRepo,
+        CryptoRepo: cryptoRepo,
+        FundPriceRepo: fundPriceRepo,
+        InstrumentRepo: instrumentRepo,
+        MarketPriceRepo: marketPriceRepo,
+        CurrencyRepo: currencyRepo,
+        BrokerageAccountRepo: brokerageAccountRepo,
+        Auth: auth,
+    }
+}
+
+// ImportHoldings は証券会社のCSVから保有資産を取り込みます
+// dryRun の場合は処理内容のプレビューのみ返し、そうでない場合は競合がなければ全ての行を1つのトランザクションで登録します
+func (s *DefaultHoldingImportService) ImportHoldings(ctx context.Context, input generated.ImportHoldingsInput) (*generated.ImportResult, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    accountId, convertError := utils.ConvertNullableIdToUint(input.AccountID)
+    if convertError != nil {
+        return nil, utils.DefaultGraphQLError("入力された口座idが無効です")
+    }
+    if input.File.Size > maxImportFileSize {
+        return nil, utils.DefaultGraphQLError("CSVファイルは1MB以下にしてください")
+    }
+    data, err := io.ReadAll(io.LimitReader(input.File.File, maxImportFileSize+1))
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    if len(data) > maxImportFileSize {
+        return nil, utils.DefaultGraphQLError("CSVファイルは1MB以下にしてください")
+    }
+    text, err := decodeCSV(data)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    rows, err := parseCSV(input.Format, text)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+
+    // 口座の指定がない場合は既定の口座に取り込む
+    if accountId == nil {
+        defaultAccountId, err := s.BrokerageAccountRepo.FetchDefaultAccountId(ctx, userId)
+        if err != nil {
+            return nil, utils.DefaultGraphQLError(err.Error())
+        }
+        accountId = &defaultAccountId
+    }
+    existing, err := s.fetchExistingHoldings(ctx, userId, *accountId)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    plan := s.createImportPlan(ctx, userId, mergeRows(rows), existing)
+    plan.dto.AccountId = accountId
+
+    dryRun := input.DryRun == nil || *input.DryRun
+    result := summarizeImportRows(plan.rows, dryRun)
+    if dryRun || result.ConflictCount > 0 || result.CreateCount+result.UpdateCount == 0 {
+        return result, nil
+    }
+    if err := s.HoldingImportRepo.ImportHoldings(ctx, plan.dto); err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    result.Committed = true
+    return result, nil
+}
+
+// 突き合わせに使う登録済みの保有資産(取り込み先の口座のもののみ)と投資信託の一覧を取得する
+func (s *DefaultHoldingImportService) fetchExistingHoldings(ctx context.Context, userId uint, accountId uint) (existingHoldings, error) {
+    var existing existingHoldings
+    usStocks, err := s.StockRepo.FetchUsStockListById(ctx, userId)
+    if err != nil {
+        return existing, err
+    }
+    for _, usStock := range usStocks {
+        if usStock.AccountId == accountId {
+            existing.usStocks = append(existing.usStocks, usStock)
+        }
+    }
+    japanFunds, err := s.JapanFundRepo.FetchJapanFundListById(ctx, userId)
+    if err != nil {
+        return existing, err
+    }
+    for _, japanFund := range japanFunds {
+        if japanFund.AccountId == accountId {
+            existing.japanFunds = append(existing.japanFunds, japanFund)
+        }
+    }
+    cryptos, err := s.CryptoRepo.FetchCryptoListById(ctx, userId)
+    if err != nil {
+        return existing, err
+    }
+    for _, crypto := range cryptos {
+        if crypto.AccountId == accountId {
+            existing.cryptos = append(existing.cryptos, crypto)
+        }
+    }
+    if existing.fundPrices, err = s.FundPriceRepo.FetchFundPriceList(ctx); err != nil {
+        return existing, err
+    }
+    return existing, nil
+}
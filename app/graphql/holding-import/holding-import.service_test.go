@@ -0,0 +1,313 @@
+package holdingimport
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/repository/assets/crypto"
+	"my-us-stock-backend/app/repository/assets/fund"
+	"my-us-stock-backend/app/repository/assets/stock"
+	brokerageAccount "my-us-stock-backend/app/repository/brokerage-account"
+	holdingImport "my-us-stock-backend/app/repository/holding-import"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	"my-us-stock-backend/app/repository/market-price/currency"
+	fundPrice "my-us-stock-backend/app/repository/market-price/fund"
+	"my-us-stock-backend/app/repository/market-price/instrument"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/text/encoding/japanese"
+	"gorm.io/gorm"
+)
+
+type testMocks struct {
+    holdingImportRepo *holdingImport.MockHoldingImportRepository
+    stockRepo         *stock.MockUsStockRepository
+    japanFundRepo     *fund.MockJapanFundRepository
+    cryptoRepo        *crypto.MockCryptoRepository
+    fundPriceRepo     *fundPrice.MockFundPriceRepository
+    instrumentRepo    *instrument.MockInstrumentRepository
+    marketPriceRepo   *marketPrice.MockMarketPriceRepository
+    currencyRepo      *currency.MockCurrencyRepository
+    accountRepo       *brokerageAccount.MockBrokerageAccountRepository
+    auth              *auth.MockAuthService
+}
+
+func newTestService() (HoldingImportService, testMocks) {
+    mocks := testMocks{
+        holdingImportRepo: holdingImport.NewMockHoldingImportRepository(),
+        stockRepo:         stock.NewMockUsStockRepository(),
+        japanFundRepo:     fund.NewMockJapanFundRepository(),
+        cryptoRepo:        crypto.NewMockCryptoRepository(),
+        fundPriceRepo:     fundPrice.NewMockFundPriceRepository(),
+        instrumentRepo:    instrument.NewMockInstrumentRepository(),
+        marketPriceRepo:   marketPrice.NewMockMarketPriceRepository(),
+        currencyRepo:      currency.NewMockCurrencyRepository(),
+        accountRepo:       brokerageAccount.NewMockBrokerageAccountRepository(),
+        auth:              auth.NewMockAuthService(),
+    }
+    service := NewHoldingImportService(mocks.holdingImportRepo, mocks.stockRepo, mocks.japanFundRepo, mocks.cryptoRepo, mocks.fundPriceRepo, mocks.instrumentRepo, mocks.marketPriceRepo, mocks.currencyRepo, mocks.accountRepo, mocks.auth)
+    return service, mocks
+}
+
+// 既定の口座(id: 1)に登録済みの保有資産(AAPLとeMAXIS Slim 米国株式)を返すように設定する
+func setupExistingHoldings(mocks testMocks, userId uint) {
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mocks.accountRepo.On("FetchDefaultAccountId", mock.Anything, userId).Return(uint(1), nil)
+    mocks.stockRepo.On("FetchUsStockListById", mock.Anything, userId).Return([]model.UsStock{
+        {Model: gorm.Model{ID: 1}, Code: "AAPL", Quantity: 5, GetPrice: 150, Sector: "Technology", UsdJpy: 140, AccountId: 1, UserId: userId},
+    }, nil)
+    mocks.japanFundRepo.On("FetchJapanFundListById", mock.Anything, userId).Return([]model.JapanFund{
+        {Model: gorm.Model{ID: 2}, Code: "0331418A", Name: "eMAXIS Slim 米国株式(S&P500)", GetPrice: 20000, GetPriceTotal: 200000, AccountId: 1, UserId: userId},
+    }, nil)
+    mocks.cryptoRepo.On("FetchCryptoListById", mock.Anything, userId).Return([]model.Crypto{}, nil)
+    mocks.fundPriceRepo.On("FetchFundPriceList", mock.Anything).Return([]model.FundPrice{
+        {Code: "0331418A", Name: "eMAXIS Slim 米国株式(S&P500)", Price: 25000},
+        {Code: "0331418B", Name: "eMAXIS Slim 全世界株式(オール・カントリー)", Price: 20000},
+    }, nil)
+}
+
+func newUpload(content string) graphql.Upload {
+    return graphql.Upload{File: strings.NewReader(content), Filename: "holdings.csv", Size: int64(len(content))}
+}
+
+func TestParseSBI(t *testing.T) {
+    text := strings.Join([]string{
+        "保有証券一覧",
+        "",
+        "株式(特定預り)",
+        "銘柄コード,銘柄名,保有数量,取得単価,現在値",
+        "7203,トヨタ自動車,100,\"2,000\",\"2,500\"",
+        "合計,,,,",
+        "",
+        "米国株式(特定預り)",
+        "ティッカー,銘柄名,保有数量,取得単価,現在値",
+        "aapl,アップル,10,150.5,190",
+        "MSFT,マイクロソフト,3,300,400",
+        "",
+        "投資信託(金額/NISA預り(つみたて投資枠))",
+        "ファンド名,保有口数,取得単価,基準価額",
+        "ｅＭＡＸＩＳ　Ｓｌｉｍ　全世界株式（オール・カントリー）,\"100,000\",\"18,000\",\"20,000\"",
+    }, "\n")
+    records, err := readRecords(text)
+    assert.NoError(t, err)
+
+    rows, err := parseSBI(records)
+
+    assert.NoError(t, err)
+    assert.Len(t, rows, 4)
+    assert.Equal(t, generated.ImportAssetType(""), rows[0].assetType)
+    assert.Equal(t, "取り込み対象外の区分です(株式(特定預り))", rows[0].skipReason)
+    assert.Equal(t, 10, rows[1].line)
+    assert.Equal(t, generated.ImportAssetTypeUsStock, rows[1].assetType)
+    assert.Equal(t, "AAPL", rows[1].code)
+    assert.Equal(t, 10.0, rows[1].quantity)
+    assert.Equal(t, 150.5, rows[1].getPrice)
+    assert.Equal(t, generated.ImportAssetTypeJapanFund, rows[3].assetType)
+    assert.Equal(t, "", rows[3].code)
+    assert.Equal(t, 100000.0, rows[3].quantity)
+    assert.Equal(t, 18000.0, rows[3].getPrice)
+
+    _, err = parseSBI([]csvRecord{{line: 1, fields: []string{"type", "code"}}})
+    assert.EqualError(t, err, "SBI証券の保有証券一覧のCSVではありません")
+}
+
+func TestParseRakuten(t *testing.T) {
+    text := strings.Join([]string{
+        "■特定口座",
+        "種別,銘柄コード・ティッカー,銘柄,口座,保有数量,［単位］,平均取得価額,［単位］",
+        "国内株式,7203,トヨタ自動車,特定,100,株,\"2,000\",円",
+        "米国株式,VTI,バンガード トータル ストック マーケット ETF,特定,4,口,200.25,USD",
+        "米国株式,VTI,バンガード トータル ストック マーケット ETF,NISA成長投資枠,6,口,210.25,USD",
+        "投資信託,0331418A,eMAXIS Slim 米国株式(S&P500),つみたてNISA,\"12,345\",口,\"20,100\",円",
+        "米国株式,,不明,特定,1,口,100,USD",
+        "",
+        "■合計",
+    }, "\n")
+    records, err := readRecords(text)
+    assert.NoError(t, err)
+
+    rows, err := parseRakuten(records)
+
+    assert.NoError(t, err)
+    assert.Len(t, rows, 5)
+    assert.Equal(t, "取り込み対象外の種別です(国内株式)", rows[0].skipReason)
+    assert.Equal(t, generated.ImportAssetTypeUsStock, rows[1].assetType)
+    assert.Equal(t, 200.25, rows[1].getPrice)
+    assert.Equal(t, generated.ImportAssetTypeJapanFund, rows[3].assetType)
+    assert.Equal(t, "0331418A", rows[3].code)
+    assert.Equal(t, 12345.0, rows[3].quantity)
+    assert.Equal(t, "銘柄コードがありません", rows[4].invalid)
+
+    merged := mergeRows(rows)
+    assert.Len(t, merged, 4)
+    assert.Equal(t, 10.0, merged[1].quantity)
+    // (4 × 200.25 + 6 × 210.25) ÷ 10
+    assert.InDelta(t, 206.25, merged[1].getPrice, 1e-9)
+    assert.Equal(t, 4, merged[1].line)
+}
+
+func TestParseGeneric(t *testing.T) {
+    text := strings.Join([]string{
+        "type,code,name,quantity,get_price,usd_jpy,sector",
+        "us_stock,voo,,2,400,145.5,",
+        "CRYPTO,BTC,,0.1,\"5,000,000\",,",
+        "STOCK,XXX,,1,1,,",
+        "US_STOCK,SPY,,-1,400,,",
+    }, "\n")
+
+    rows, err := parseCSV(generated.ImportFormatGeneric, text)
+
+    assert.NoError(t, err)
+    assert.Len(t, rows, 4)
+    assert.Equal(t, "VOO", rows[0].code)
+    assert.Equal(t, 145.5, *rows[0].usdJpy)
+    assert.Equal(t, "btc", rows[1].code)
+    assert.Equal(t, 5000000.0, rows[1].getPrice)
+    assert.Equal(t, "type には US_STOCK・JAPAN_FUND・CRYPTO のいずれかを指定してください", rows[2].invalid)
+    assert.Equal(t, "保有数量が正しくありません", rows[3].invalid)
+
+    _, err = parseCSV(generated.ImportFormatGeneric, "code,quantity\nVOO,1")
+    assert.EqualError(t, err, "type 列がありません")
+}
+
+func TestDecodeCSV(t *testing.T) {
+    shiftJIS, err := japanese.ShiftJIS.NewEncoder().String("種別,銘柄\n米国株式,アップル")
+    assert.NoError(t, err)
+
+    text, err := decodeCSV([]byte(shiftJIS))
+    assert.NoError(t, err)
+    assert.Equal(t, "種別,銘柄\n米国株式,アップル", text)
+
+    text, err = decodeCSV([]byte("\xef\xbb\xbftype,code"))
+    assert.NoError(t, err)
+    assert.Equal(t, "type,code", text)
+}
+
+// dryRun ではプレビューのみ返し、登録は行わない
+func TestImportHoldingsDryRun(t *testing.T) {
+    service, mocks := newTestService()
+    setupExistingHoldings(mocks, 1)
+    mocks.instrumentRepo.On("FindInstrumentByTicker", mock.Anything, "VOO").Return((*model.Instrument)(nil), gorm.ErrRecordNotFound)
+    mocks.marketPriceRepo.On("FetchCompanyProfile", mock.Anything, "VOO").Return(&marketPrice.CompanyProfileDto{Name: "Vanguard S&P 500 ETF", Sector: "ETF"}, nil)
+    mocks.instrumentRepo.On("CreateInstrument", mock.Anything, mock.Anything).Return(&model.Instrument{Ticker: "VOO", Sector: "ETF"}, nil)
+    mocks.currencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(150.0, nil)
+
+    csv := strings.Join([]string{
+        "type,code,name,quantity,get_price",
+        "US_STOCK,AAPL,,8,160",
+        "US_STOCK,VOO,,2,400",
+        "JAPAN_FUND,,eMAXIS Slim 全世界株式(オール・カントリー),10000,18000",
+        "JAPAN_FUND,9999999Z,,10000,10000",
+        "CRYPTO,ETH,,1,300000",
+    }, "\n")
+    result, err := service.ImportHoldings(context.Background(), generated.ImportHoldingsInput{File: newUpload(csv), Format: generated.ImportFormatGeneric})
+
+    assert.NoError(t, err)
+    assert.True(t, result.DryRun)
+    assert.False(t, result.Committed)
+    assert.Equal(t, 3, result.CreateCount)
+    assert.Equal(t, 1, result.UpdateCount)
+    assert.Equal(t, 1, result.ConflictCount)
+    assert.Equal(t, generated.ImportActionUpdate, result.Rows[0].Action)
+    assert.Equal(t, "登録済みの保有データ(数量 5、取得単価 150)を更新します", *result.Rows[0].Message)
+    assert.Equal(t, generated.ImportActionCreate, result.Rows[2].Action)
+    assert.Equal(t, "0331418B", result.Rows[2].Code)
+    assert.Equal(t, "登録されていない投資信託のため取り込めません", *result.Rows[3].Message)
+    mocks.holdingImportRepo.AssertNotCalled(t, "ImportHoldings", mock.Anything, mock.Anything)
+}
+
+// 競合がない場合は全ての行を1回で登録する
+func TestImportHoldingsCommit(t *testing.T) {
+    service, mocks := newTestService()
+    setupExistingHoldings(mocks, 1)
+    mocks.instrumentRepo.On("FindInstrumentByTicker", mock.Anything, "VOO").Return(&model.Instrument{Ticker: "VOO", Sector: "ETF"}, nil)
+    mocks.holdingImportRepo.On("ImportHoldings", mock.Anything, mock.Anything).Return(nil)
+
+    csv := strings.Join([]string{
+        "type,code,name,quantity,get_price,usd_jpy",
+        "US_STOCK,AAPL,,8,160,",
+        "US_STOCK,VOO,,2,400,145",
+        "JAPAN_FUND,0331418A,,12345,20100,",
+        "CRYPTO,ETH,,1,300000,",
+    }, "\n")
+    dryRun := false
+    result, err := service.ImportHoldings(context.Background(), generated.ImportHoldingsInput{File: newUpload(csv), Format: generated.ImportFormatGeneric, DryRun: &dryRun})
+
+    assert.NoError(t, err)
+    assert.True(t, result.Committed)
+    mocks.holdingImportRepo.AssertCalled(t, "ImportHoldings", mock.Anything, mock.MatchedBy(func(dto holdingImport.ImportHoldingsDto) bool {
+        return dto.UserId == 1 &&
+            len(dto.UpdateUsStocks) == 1 && dto.UpdateUsStocks[0].ID == 1 && dto.UpdateUsStocks[0].Quantity == 8 &&
+            len(dto.CreateUsStocks) == 1 && dto.CreateUsStocks[0].Sector == "ETF" && dto.CreateUsStocks[0].UsdJpy == 145 &&
+            len(dto.UpdateJapanFunds) == 1 && dto.UpdateJapanFunds[0].GetPriceTotal == 24813 &&
+            len(dto.CreateCryptos) == 1 && dto.CreateCryptos[0].Code == "eth"
+    }))
+    mocks.currencyRepo.AssertNotCalled(t, "FetchCurrentUsdJpy", mock.Anything)
+}
+
+// 口座を指定した場合はその口座の保有資産とのみ突き合わせ、作成する保有資産をその口座に登録する
+func TestImportHoldingsIntoAccount(t *testing.T) {
+    service, mocks := newTestService()
+    setupExistingHoldings(mocks, 1)
+    mocks.instrumentRepo.On("FindInstrumentByTicker", mock.Anything, "AAPL").Return(&model.Instrument{Ticker: "AAPL", Sector: "Technology"}, nil)
+    mocks.holdingImportRepo.On("ImportHoldings", mock.Anything, mock.Anything).Return(nil)
+
+    dryRun := false
+    accountId := "3"
+    csv := "type,code,quantity,get_price,usd_jpy\nUS_STOCK,AAPL,8,160,150"
+    result, err := service.ImportHoldings(context.Background(), generated.ImportHoldingsInput{File: newUpload(csv), Format: generated.ImportFormatGeneric, DryRun: &dryRun, AccountID: &accountId})
+
+    assert.NoError(t, err)
+    assert.True(t, result.Committed)
+    // 既定の口座のAAPLは更新せず、指定した口座に新規作成する
+    assert.Equal(t, generated.ImportActionCreate, result.Rows[0].Action)
+    mocks.holdingImportRepo.AssertCalled(t, "ImportHoldings", mock.Anything, mock.MatchedBy(func(dto holdingImport.ImportHoldingsDto) bool {
+        return dto.AccountId != nil && *dto.AccountId == 3 && len(dto.CreateUsStocks) == 1 && len(dto.UpdateUsStocks) == 0
+    }))
+}
+
+// 競合がある場合は dryRun でなくても登録しない
+func TestImportHoldingsConflict(t *testing.T) {
+    service, mocks := newTestService()
+    setupExistingHoldings(mocks, 1)
+    mocks.instrumentRepo.On("FindInstrumentByTicker", mock.Anything, "ZZZZ").Return((*model.Instrument)(nil), gorm.ErrRecordNotFound)
+    mocks.marketPriceRepo.On("FetchCompanyProfile", mock.Anything, "ZZZZ").Return((*marketPrice.CompanyProfileDto)(nil), errors.New("not found"))
+
+    dryRun := false
+    csv := "type,code,quantity,get_price\nCRYPTO,BTC,0.1,5000000\nUS_STOCK,ZZZZ,1,10"
+    result, err := service.ImportHoldings(context.Background(), generated.ImportHoldingsInput{File: newUpload(csv), Format: generated.ImportFormatGeneric, DryRun: &dryRun})
+
+    assert.NoError(t, err)
+    assert.False(t, result.Committed)
+    assert.Equal(t, 1, result.ConflictCount)
+    assert.Equal(t, "セクターを取得できなかったため、sector を指定してください", *result.Rows[1].Message)
+    mocks.holdingImportRepo.AssertNotCalled(t, "ImportHoldings", mock.Anything, mock.Anything)
+}
+
+func TestImportHoldingsInvalidFile(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+
+    _, err := service.ImportHoldings(context.Background(), generated.ImportHoldingsInput{File: newUpload("type,code\n"), Format: generated.ImportFormatRakuten})
+    assert.EqualError(t, err, "input: 楽天証券の保有商品一覧のCSVではありません")
+
+    large := graphql.Upload{File: strings.NewReader(""), Size: maxImportFileSize + 1}
+    _, err = service.ImportHoldings(context.Background(), generated.ImportHoldingsInput{File: large, Format: generated.ImportFormatGeneric})
+    assert.EqualError(t, err, "input: CSVファイルは1MB以下にしてください")
+}
+
+func TestImportHoldingsUnauthenticated(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(0), nil)
+
+    result, err := service.ImportHoldings(context.Background(), generated.ImportHoldingsInput{File: newUpload(""), Format: generated.ImportFormatGeneric})
+
+    assert.Nil(t, result)
+    assert.Error(t, err)
+}
@@ -0,0 +1,308 @@
+package holdingimport
+
+import (
+	"context"
+	"log"
+	"math"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	holdingImport "my-us-stock-backend/app/repository/holding-import"
+	"my-us-stock-backend/app/repository/market-price/instrument"
+	"strconv"
+)
+
+const multipleHoldingsMessage = "複数の保有データが登録されているため更新先を特定できません"
+
+// 取り込み計画(プレビューの各行と、確定時に保存する内容)
+type importPlan struct {
+    rows []*generated.ImportRow
+    dto  holdingImport.ImportHoldingsDto
+}
+
+// 登録済みの保有資産と現在値の参照先
+type existingHoldings struct {
+    usStocks   []model.UsStock
+    japanFunds []model.JapanFund
+    cryptos    []model.Crypto
+    fundPrices []model.FundPrice
+}
+
+// 同じ銘柄の行を1行にまとめる(数量は合計、取得単価は数量による加重平均)
+// 証券会社のCSVは口座区分(特定・NISA等)ごとに行が分かれるため、保有データ1件として取り込む
+func mergeRows(rows []csvRow) []csvRow {
+    merged := []csvRow{}
+    indexByKey := map[string]int{}
+    for _, row := range rows {
+        if row.assetType == "" || row.invalid != "" {
+            merged = append(merged, row)
+            continue
+        }
+        key := string(row.assetType) + ":" + row.code
+        if row.code == "" {
+            key = string(row.assetType) + ":" + normalizeText(row.name)
+        }
+        index, ok := indexByKey[key]
+        if !ok {
+            indexByKey[key] = len(merged)
+            merged = append(merged, row)
+            continue
+        }
+        base := &merged[index]
+        quantity := base.quantity + row.quantity
+        base.getPrice = (base.getPrice*base.quantity + row.getPrice*row.quantity) / quantity
+        if base.usdJpy != nil && row.usdJpy != nil {
+            usdJpy := (*base.usdJpy*base.quantity + *row.usdJpy*row.quantity) / quantity
+            base.usdJpy = &usdJpy
+        } else if base.usdJpy == nil {
+            base.usdJpy = row.usdJpy
+        }
+        if base.sector == "" {
+            base.sector = row.sector
+        }
+        base.quantity = quantity
+    }
+    return merged
+}
+
+// 各行を登録済みの保有資産と突き合わせ、作成・更新・競合・対象外に振り分ける
+func (s *DefaultHoldingImportService) createImportPlan(ctx context.Context, userId uint, rows []csvRow, existing existingHoldings) importPlan {
+    plan := importPlan{dto: holdingImport.ImportHoldingsDto{UserId: userId}}
+    var usdJpy *float64
+    for _, row := range rows {
+        importRow := &generated.ImportRow{
+            Line:     row.line,
+            Code:     row.code,
+            Name:     row.name,
+            Quantity: row.quantity,
+            GetPrice: row.getPrice,
+        }
+        if row.assetType != "" {
+            assetType := row.assetType
+            importRow.AssetType = &assetType
+        }
+        plan.rows = append(plan.rows, importRow)
+
+        switch {
+        case row.skipReason != "":
+            importRow.Action = generated.ImportActionSkip
+            importRow.Message = &row.skipReason
+            continue
+        case row.invalid != "":
+            conflict(importRow, row.invalid)
+            continue
+        }
+
+        switch row.assetType {
+        case generated.ImportAssetTypeUsStock:
+            planUsStock(importRow, row, existing.usStocks, &plan.dto, func() (string, string) {
+                return s.resolveSector(ctx, row)
+            }, func() (float64, string) {
+                if row.usdJpy != nil {
+                    return *row.usdJpy, ""
+                }
+                if usdJpy == nil {
+                    rate, err := s.CurrencyRepo.FetchCurrentUsdJpy(ctx)
+                    if err != nil {
+                        log.Printf("ドル円の取得に失敗しました: %v", err)
+                        return 0, "ドル円を取得できなかったため、usd_jpy を指定してください"
+                    }
+                    usdJpy = &rate
+                }
+                return *usdJpy, ""
+            })
+        case generated.ImportAssetTypeJapanFund:
+            planJapanFund(importRow, row, existing.japanFunds, existing.fundPrices, &plan.dto)
+        case generated.ImportAssetTypeCrypto:
+            planCrypto(importRow, row, existing.cryptos, &plan.dto)
+        }
+    }
+    return plan
+}
+
+func planUsStock(importRow *generated.ImportRow, row csvRow, usStocks []model.UsStock, dto *holdingImport.ImportHoldingsDto, sector func() (string, string), usdJpy func() (float64, string)) {
+    matches := []model.UsStock{}
+    for _, usStock := range usStocks {
+        if usStock.Code == row.code {
+            matches = append(matches, usStock)
+        }
+    }
+    switch len(matches) {
+    case 0:
+        if len(row.code) > 6 {
+            conflict(importRow, "ティッカーは6文字以内で指定してください")
+            return
+        }
+        resolvedSector, message := sector()
+        if message != "" {
+            conflict(importRow, message)
+            return
+        }
+        rate, message := usdJpy()
+        if message != "" {
+            conflict(importRow, message)
+            return
+        }
+        importRow.Action = generated.ImportActionCreate
+        dto.CreateUsStocks = append(dto.CreateUsStocks, model.UsStock{
+            Code:     row.code,
+            GetPrice: row.getPrice,
+            Quantity: row.quantity,
+            Sector:   resolvedSector,
+            UsdJpy:   rate,
+        })
+    case 1:
+        importRow.Action = generated.ImportActionUpdate
+        importRow.Message = updateMessage(matches[0].Quantity, matches[0].GetPrice)
+        updated := matches[0]
+        updated.Quantity = row.quantity
+        updated.GetPrice = row.getPrice
+        dto.UpdateUsStocks = append(dto.UpdateUsStocks, updated)
+    default:
+        conflict(importRow, multipleHoldingsMessage)
+    }
+}
+
+func planJapanFund(importRow *generated.ImportRow, row csvRow, japanFunds []model.JapanFund, fundPrices []model.FundPrice, dto *holdingImport.ImportHoldingsDto) {
+    fundPrice := findFundPrice(fundPrices, row.code, row.name)
+    if fundPrice == nil {
+        conflict(importRow, "登録されていない投資信託のため取り込めません")
+        return
+    }
+    importRow.Code = fundPrice.Code
+    importRow.Name = fundPrice.Name
+
+    matches := []model.JapanFund{}
+    for _, japanFund := range japanFunds {
+        if japanFund.Code == fundPrice.Code {
+            matches = append(matches, japanFund)
+        }
+    }
+    // 取得価額は1万口あたりのため、取得総額は 口数 × 取得価額 ÷ 10000
+    getPriceTotal := math.Round(row.quantity * row.getPrice / 10000)
+    switch len(matches) {
+    case 0:
+        importRow.Action = generated.ImportActionCreate
+        dto.CreateJapanFunds = append(dto.CreateJapanFunds, model.JapanFund{
+            Code:          fundPrice.Code,
+            Name:          fundPrice.Name,
+            GetPrice:      row.getPrice,
+            GetPriceTotal: getPriceTotal,
+        })
+    case 1:
+        importRow.Action = generated.ImportActionUpdate
+        importRow.Message = updateMessage(math.Round(matches[0].GetPriceTotal/matches[0].GetPrice*10000), matches[0].GetPrice)
+        updated := matches[0]
+        updated.GetPrice = row.getPrice
+        updated.GetPriceTotal = getPriceTotal
+        dto.UpdateJapanFunds = append(dto.UpdateJapanFunds, updated)
+    default:
+        conflict(importRow, multipleHoldingsMessage)
+    }
+}
+
+func planCrypto(importRow *generated.ImportRow, row csvRow, cryptos []model.Crypto, dto *holdingImport.ImportHoldingsDto) {
+    matches := []model.Crypto{}
+    for _, crypto := range cryptos {
+        if crypto.Code == row.code {
+            matches = append(matches, crypto)
+        }
+    }
+    switch len(matches) {
+    case 0:
+        importRow.Action = generated.ImportActionCreate
+        dto.CreateCryptos = append(dto.CreateCryptos, model.Crypto{
+            Code:     row.code,
+            GetPrice: row.getPrice,
+            Quantity: row.quantity,
+        })
+    case 1:
+        importRow.Action = generated.ImportActionUpdate
+        importRow.Message = updateMessage(matches[0].Quantity, matches[0].GetPrice)
+        updated := matches[0]
+        updated.Quantity = row.quantity
+        updated.GetPrice = row.getPrice
+        dto.UpdateCryptos = append(dto.UpdateCryptos, updated)
+    default:
+        conflict(importRow, multipleHoldingsMessage)
+    }
+}
+
+// 投資信託をコード、コードがない場合は名称(全角・半角や空白の違いは無視)で特定する
+func findFundPrice(fundPrices []model.FundPrice, code string, name string) *model.FundPrice {
+    for i := range fundPrices {
+        if code != "" && fundPrices[i].Code == code {
+            return &fundPrices[i]
+        }
+    }
+    if name == "" {
+        return nil
+    }
+    for i := range fundPrices {
+        if normalizeText(fundPrices[i].Name) == normalizeText(name) {
+            return &fundPrices[i]
+        }
+    }
+    return nil
+}
+
+// 新規作成する米国株のセクターを企業情報のキャッシュ、外部API、CSVの sector 列の順に解決する
+func (s *DefaultHoldingImportService) resolveSector(ctx context.Context, row csvRow) (string, string) {
+    cached, err := s.InstrumentRepo.FindInstrumentByTicker(ctx, row.code)
+    if err == nil && cached != nil && cached.Sector != "" {
+        return cached.Sector, ""
+    }
+    profile, err := s.MarketPriceRepo.FetchCompanyProfile(ctx, row.code)
+    if err == nil && profile.Sector != "" {
+        _, err := s.InstrumentRepo.CreateInstrument(ctx, instrument.CreateInstrumentDto{
+            Ticker:   row.code,
+            Name:     profile.Name,
+            Sector:   profile.Sector,
+            Industry: profile.Industry,
+            Country:  profile.Country,
+            Exchange: profile.Exchange,
+        })
+        if err != nil {
+            log.Printf("企業情報の保存に失敗しました(%s): %v", row.code, err)
+        }
+        return profile.Sector, ""
+    }
+    if err != nil {
+        log.Printf("企業情報の取得に失敗しました(%s): %v", row.code, err)
+    }
+    if row.sector != "" {
+        return row.sector, ""
+    }
+    return "", "セクターを取得できなかったため、sector を指定してください"
+}
+
+func conflict(importRow *generated.ImportRow, message string) {
+    importRow.Action = generated.ImportActionConflict
+    importRow.Message = &message
+}
+
+func updateMessage(quantity float64, getPrice float64) *string {
+    message := "登録済みの保有データ(数量 " + formatNumber(quantity) + "、取得単価 " + formatNumber(getPrice) + ")を更新します"
+    return &message
+}
+
+func formatNumber(value float64) string {
+    return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// プレビューの行から取り込み結果の件数を集計する
+func summarizeImportRows(rows []*generated.ImportRow, dryRun bool) *generated.ImportResult {
+    result := &generated.ImportResult{DryRun: dryRun, Rows: rows}
+    for _, row := range rows {
+        switch row.Action {
+        case generated.ImportActionCreate:
+            result.CreateCount++
+        case generated.ImportActionUpdate:
+            result.UpdateCount++
+        case generated.ImportActionConflict:
+            result.ConflictCount++
+        case generated.ImportActionSkip:
+            result.SkipCount++
+        }
+    }
+    return result
+}
@@ -0,0 +1,60 @@
+package household
+
+import (
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	repoHousehold "my-us-stock-backend/app/repository/household"
+)
+
+// オーナーは世帯の作成者のみのため、メンバーに指定できる権限は編集者・閲覧者のみ
+func validateMemberRole(role generated.HouseholdRole) error {
+    if role != generated.HouseholdRoleEditor && role != generated.HouseholdRoleViewer {
+        return utils.DefaultGraphQLError("メンバーの権限はEDITORまたはVIEWERを指定してください")
+    }
+    return nil
+}
+
+// 世帯のリストから指定したidの世帯を探す
+func findHousehold(households []model.Household, id uint) *model.Household {
+    for i := range households {
+        if households[i].ID == id {
+            return &households[i]
+        }
+    }
+    return nil
+}
+
+// モデルをGraphQLの型に変換する(roleはログインユーザーの権限)
+func convertToHousehold(modelHousehold *model.Household, members []repoHousehold.HouseholdMemberDto, userId uint) *generated.Household {
+    household := &generated.Household{
+        ID:      utils.ConvertIdToString(modelHousehold.ID),
+        Name:    modelHousehold.Name,
+        Members: make([]*generated.HouseholdMember, len(members)),
+    }
+    for i, member := range members {
+        household.Members[i] = &generated.HouseholdMember{
+            UserID: utils.ConvertIdToString(member.UserId),
+            Name:   member.Name,
+            Email:  member.Email,
+            Role:   generated.HouseholdRole(member.Role),
+        }
+        if member.UserId == userId {
+            household.Role = generated.HouseholdRole(member.Role)
+        }
+    }
+    return household
+}
+
+// 承認待ちの招待をGraphQLの型に変換する
+func convertToInvitations(invitations []repoHousehold.HouseholdInvitationDto) []*generated.HouseholdInvitation {
+    result := make([]*generated.HouseholdInvitation, len(invitations))
+    for i, invitation := range invitations {
+        result[i] = &generated.HouseholdInvitation{
+            HouseholdID: utils.ConvertIdToString(invitation.HouseholdId),
+            Name:        invitation.Name,
+            Role:        generated.HouseholdRole(invitation.Role),
+        }
+    }
+    return result
+}
@@ -0,0 +1,50 @@
+package household
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+)
+
+type Resolver struct {
+    HouseholdService HouseholdService
+}
+
+func NewResolver(householdService HouseholdService) *Resolver {
+    return &Resolver{HouseholdService: householdService}
+}
+
+func (r *Resolver) Households(ctx context.Context) ([]*generated.Household, error) {
+    return r.HouseholdService.Households(ctx)
+}
+
+func (r *Resolver) CreateHousehold(ctx context.Context, input generated.CreateHouseholdInput) (*generated.Household, error) {
+    return r.HouseholdService.CreateHousehold(ctx, input)
+}
+
+func (r *Resolver) AddHouseholdMember(ctx context.Context, input generated.AddHouseholdMemberInput) (*generated.Household, error) {
+    return r.HouseholdService.AddHouseholdMember(ctx, input)
+}
+
+func (r *Resolver) HouseholdInvitations(ctx context.Context) ([]*generated.HouseholdInvitation, error) {
+    return r.HouseholdService.HouseholdInvitations(ctx)
+}
+
+func (r *Resolver) AcceptHouseholdInvitation(ctx context.Context, householdId string) (*generated.Household, error) {
+    return r.HouseholdService.AcceptHouseholdInvitation(ctx, householdId)
+}
+
+func (r *Resolver) DeclineHouseholdInvitation(ctx context.Context, householdId string) (bool, error) {
+    return r.HouseholdService.DeclineHouseholdInvitation(ctx, householdId)
+}
+
+func (r *Resolver) UpdateHouseholdMember(ctx context.Context, input generated.UpdateHouseholdMemberInput) (*generated.Household, error) {
+    return r.HouseholdService.UpdateHouseholdMember(ctx, input)
+}
+
+func (r *Resolver) RemoveHouseholdMember(ctx context.Context, householdId string, userId string) (bool, error) {
+    return r.HouseholdService.RemoveHouseholdMember(ctx, householdId, userId)
+}
+
+func (r *Resolver) DeleteHousehold(ctx context.Context, id string) (bool, error) {
+    return r.HouseholdService.DeleteHousehold(ctx, id)
+}
@@ -0,0 +1,116 @@
+package household
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockHouseholdService は HouseholdService のモックです。
+type MockHouseholdService struct {
+    mock.Mock
+}
+
+func (m *MockHouseholdService) Households(ctx context.Context) ([]*generated.Household, error) {
+    args := m.Called(ctx)
+    return args.Get(0).([]*generated.Household), args.Error(1)
+}
+
+func (m *MockHouseholdService) CreateHousehold(ctx context.Context, input generated.CreateHouseholdInput) (*generated.Household, error) {
+    args := m.Called(ctx, input)
+    return args.Get(0).(*generated.Household), args.Error(1)
+}
+
+func (m *MockHouseholdService) AddHouseholdMember(ctx context.Context, input generated.AddHouseholdMemberInput) (*generated.Household, error) {
+    args := m.Called(ctx, input)
+    return args.Get(0).(*generated.Household), args.Error(1)
+}
+
+func (m *MockHouseholdService) HouseholdInvitations(ctx context.Context) ([]*generated.HouseholdInvitation, error) {
+    args := m.Called(ctx)
+    return args.Get(0).([]*generated.HouseholdInvitation), args.Error(1)
+}
+
+func (m *MockHouseholdService) AcceptHouseholdInvitation(ctx context.Context, householdId string) (*generated.Household, error) {
+    args := m.Called(ctx, householdId)
+    return args.Get(0).(*generated.Household), args.Error(1)
+}
+
+func (m *MockHouseholdService) DeclineHouseholdInvitation(ctx context.Context, householdId string) (bool, error) {
+    args := m.Called(ctx, householdId)
+    return args.Bool(0), args.Error(1)
+}
+
+func (m *MockHouseholdService) UpdateHouseholdMember(ctx context.Context, input generated.UpdateHouseholdMemberInput) (*generated.Household, error) {
+    args := m.Called(ctx, input)
+    return args.Get(0).(*generated.Household), args.Error(1)
+}
+
+func (m *MockHouseholdService) RemoveHouseholdMember(ctx context.Context, householdId string, userId string) (bool, error) {
+    args := m.Called(ctx, householdId, userId)
+    return args.Bool(0), args.Error(1)
+}
+
+func (m *MockHouseholdService) DeleteHousehold(ctx context.Context, id string) (bool, error) {
+    args := m.Called(ctx, id)
+    return args.Bool(0), args.Error(1)
+}
+
+func TestHouseholdsResolver(t *testing.T) {
+    mockService := new(MockHouseholdService)
+    resolver := NewResolver(mockService)
+
+    households := []*generated.Household{{ID: "1", Name: "山田家", Role: generated.HouseholdRoleOwner}}
+    mockService.On("Households", mock.Anything).Return(households, nil)
+
+    result, err := resolver.Households(context.Background())
+
+    assert.NoError(t, err)
+    assert.Equal(t, households, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestAddHouseholdMemberResolver(t *testing.T) {
+    mockService := new(MockHouseholdService)
+    resolver := NewResolver(mockService)
+
+    input := generated.AddHouseholdMemberInput{HouseholdID: "1", Email: "hanako@example.com", Role: generated.HouseholdRoleViewer}
+    household := &generated.Household{ID: "1", Name: "山田家", Role: generated.HouseholdRoleOwner}
+    mockService.On("AddHouseholdMember", mock.Anything, input).Return(household, nil)
+
+    result, err := resolver.AddHouseholdMember(context.Background(), input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, household, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestAcceptHouseholdInvitationResolver(t *testing.T) {
+    mockService := new(MockHouseholdService)
+    resolver := NewResolver(mockService)
+
+    household := &generated.Household{ID: "1", Name: "山田家", Role: generated.HouseholdRoleViewer}
+    mockService.On("AcceptHouseholdInvitation", mock.Anything, "1").Return(household, nil)
+
+    result, err := resolver.AcceptHouseholdInvitation(context.Background(), "1")
+
+    assert.NoError(t, err)
+    assert.Equal(t, household, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestRemoveHouseholdMemberResolver(t *testing.T) {
+    mockService := new(MockHouseholdService)
+    resolver := NewResolver(mockService)
+
+    mockService.On("RemoveHouseholdMember", mock.Anything, "1", "2").Return(true, nil)
+
+    result, err := resolver.RemoveHouseholdMember(context.Background(), "1", "2")
+
+    assert.NoError(t, err)
+    assert.True(t, result)
+    mockService.AssertExpectations(t)
+}
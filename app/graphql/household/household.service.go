@@ -0,0 +1,266 @@
+package household
+
+import (
+	"context"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	repoHousehold "my-us-stock-backend/app/repository/household"
+	repoUser "my-us-stock-backend/app/repository/user"
+	"strings"
+)
+
+// 世帯名の最大文字数
+const maxHouseholdNameLength = 255
+
+// HouseholdService インターフェースの定義
+type HouseholdService interface {
+    Households(ctx context.Context) ([]*generated.Household, error)
+    CreateHousehold(ctx context.Context, input generated.CreateHouseholdInput) (*generated.Household, error)
+    AddHouseholdMember(ctx context.Context, input generated.AddHouseholdMemberInput) (*generated.Household, error)
+    HouseholdInvitations(ctx context.Context) ([]*generated.HouseholdInvitation, error)
+    AcceptHouseholdInvitation(ctx context.Context, householdId string) (*generated.Household, error)
+    DeclineHouseholdInvitation(ctx context.Context, householdId string) (bool, error)
+    UpdateHouseholdMember(ctx context.Context, input generated.UpdateHouseholdMemberInput) (*generated.Household, error)
+    RemoveHouseholdMember(ctx context.Context, householdId string, userId string) (bool, error)
+    DeleteHousehold(ctx context.Context, id string) (bool, error)
+}
+
+// DefaultHouseholdService 構造体の定義
+type DefaultHouseholdService struct {
+    HouseholdRepo repoHousehold.HouseholdRepository
+    UserRepo repoUser.UserRepository
+    Auth auth.AuthService
+}
+
+// NewHouseholdService は DefaultHouseholdService の新しいインスタンスを作成します
+func NewHouseholdService(householdRepo repoHousehold.HouseholdRepository, userRepo repoUser.UserRepository, auth auth.AuthService) HouseholdService {
+    return &DefaultHouseholdService{HouseholdRepo: householdRepo, UserRepo: userRepo, Auth: auth}
+}
+
+// Households はログインユーザーが所属する世帯をメンバーと合わせて取得します
+func (s *DefaultHouseholdService) Households(ctx context.Context) ([]*generated.Household, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    modelHouseholds, err := s.HouseholdRepo.FetchHouseholdListByUserId(ctx, userId)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    households := make([]*generated.Household, len(modelHouseholds))
+    for i := range modelHouseholds {
+        household, err := s.convertToHousehold(ctx, &modelHouseholds[i], userId)
+        if err != nil {
+            return nil, err
+        }
+        households[i] = household
+    }
+    return households, nil
+}
+
+// CreateHousehold は世帯を作成します(作成したユーザーがオーナーになる)
+func (s *DefaultHouseholdService) CreateHousehold(ctx context.Context, input generated.CreateHouseholdInput) (*generated.Household, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    name := strings.TrimSpace(input.Name)
+    if name == "" {
+        return nil, utils.DefaultGraphQLError("世帯名を入力してください")
+    }
+    if len([]rune(name)) > maxHouseholdNameLength {
+        return nil, utils.DefaultGraphQLError("世帯名は255文字以内で入力してください")
+    }
+    modelHousehold, err := s.HouseholdRepo.CreateHousehold(ctx, repoHousehold.CreateHouseholdDto{Name: name, UserId: userId})
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return s.convertToHousehold(ctx, modelHousehold, userId)
+}
+
+// AddHouseholdMember はメールアドレスで指定したユーザーを世帯に招待します(オーナーのみ)
+// 招待されたユーザーが承認するまではメンバーにならず、資産も共有しない
+// メールアドレスの登録の有無を知られないよう、ユーザーが存在しない場合も同じ結果を返す
+func (s *DefaultHouseholdService) AddHouseholdMember(ctx context.Context, input generated.AddHouseholdMemberInput) (*generated.Household, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    modelHousehold, err := s.findOwnedHousehold(ctx, userId, input.HouseholdID)
+    if err != nil {
+        return nil, err
+    }
+    if err := validateMemberRole(input.Role); err != nil {
+        return nil, err
+    }
+    user, err := s.UserRepo.GetUserByEmail(ctx, strings.TrimSpace(input.Email))
+    if err == nil && user != nil {
+        if err := s.HouseholdRepo.InviteMember(ctx, repoHousehold.AddHouseholdMemberDto{HouseholdId: modelHousehold.ID, UserId: user.ID, Role: input.Role.String()}); err != nil {
+            return nil, utils.DefaultGraphQLError(err.Error())
+        }
+    }
+    return s.convertToHousehold(ctx, modelHousehold, userId)
+}
+
+// HouseholdInvitations はログインユーザーへの承認待ちの招待を取得します
+func (s *DefaultHouseholdService) HouseholdInvitations(ctx context.Context) ([]*generated.HouseholdInvitation, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    invitations, err := s.HouseholdRepo.FetchInvitations(ctx, userId)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return convertToInvitations(invitations), nil
+}
+
+// AcceptHouseholdInvitation はログインユーザーへの招待を承認し、世帯のメンバーになります
+func (s *DefaultHouseholdService) AcceptHouseholdInvitation(ctx context.Context, householdId string) (*generated.Household, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    id, convertError := utils.ConvertIdToUint(householdId)
+    if convertError != nil || id == 0 {
+        return nil, utils.DefaultGraphQLError("入力された世帯idが無効です")
+    }
+    if err := s.HouseholdRepo.AcceptInvitation(ctx, id, userId); err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    modelHouseholds, err := s.HouseholdRepo.FetchHouseholdListByUserId(ctx, userId)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    household := findHousehold(modelHouseholds, id)
+    if household == nil {
+        return nil, utils.DefaultGraphQLError("指定された世帯が見つかりません")
+    }
+    return s.convertToHousehold(ctx, household, userId)
+}
+
+// DeclineHouseholdInvitation はログインユーザーへの招待を辞退します
+func (s *DefaultHouseholdService) DeclineHouseholdInvitation(ctx context.Context, householdId string) (bool, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return false, utils.UnauthenticatedError("Invalid user ID")
+    }
+    id, convertError := utils.ConvertIdToUint(householdId)
+    if convertError != nil || id == 0 {
+        return false, utils.DefaultGraphQLError("入力された世帯idが無効です")
+    }
+    if err := s.HouseholdRepo.DeclineInvitation(ctx, id, userId); err != nil {
+        return false, utils.DefaultGraphQLError(err.Error())
+    }
+    return true, nil
+}
+
+// UpdateHouseholdMember はメンバーの権限を更新します(オーナーのみ)
+func (s *DefaultHouseholdService) UpdateHouseholdMember(ctx context.Context, input generated.UpdateHouseholdMemberInput) (*generated.Household, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    modelHousehold, err := s.findOwnedHousehold(ctx, userId, input.HouseholdID)
+    if err != nil {
+        return nil, err
+    }
+    memberId, convertError := utils.ConvertIdToUint(input.UserID)
+    if convertError != nil || memberId == 0 {
+        return nil, utils.DefaultGraphQLError("入力されたユーザーidが無効です")
+    }
+    if memberId == userId {
+        return nil, utils.DefaultGraphQLError("オーナーの権限は変更できません")
+    }
+    if err := validateMemberRole(input.Role); err != nil {
+        return nil, err
+    }
+    if err := s.HouseholdRepo.UpdateMemberRole(ctx, modelHousehold.ID, memberId, input.Role.String()); err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return s.convertToHousehold(ctx, modelHousehold, userId)
+}
+
+// RemoveHouseholdMember はメンバーを世帯から外します(オーナーは他のメンバーを、メンバーは自分自身を外せる)
+func (s *DefaultHouseholdService) RemoveHouseholdMember(ctx context.Context, householdId string, memberUserId string) (bool, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return false, utils.UnauthenticatedError("Invalid user ID")
+    }
+    id, convertError := utils.ConvertIdToUint(householdId)
+    if convertError != nil || id == 0 {
+        return false, utils.DefaultGraphQLError("入力された世帯idが無効です")
+    }
+    memberId, convertError := utils.ConvertIdToUint(memberUserId)
+    if convertError != nil || memberId == 0 {
+        return false, utils.DefaultGraphQLError("入力されたユーザーidが無効です")
+    }
+    member, err := s.HouseholdRepo.FetchMember(ctx, id, userId)
+    if err != nil {
+        return false, utils.DefaultGraphQLError(err.Error())
+    }
+    if member == nil {
+        return false, utils.DefaultGraphQLError("指定された世帯が見つかりません")
+    }
+    if memberId == userId && member.Role == repoHousehold.RoleOwner {
+        return false, utils.DefaultGraphQLError("オーナーは世帯から外れることができません。世帯を削除してください")
+    }
+    if memberId != userId && member.Role != repoHousehold.RoleOwner {
+        return false, utils.DefaultGraphQLError("世帯のメンバーを管理する権限がありません")
+    }
+    if err := s.HouseholdRepo.RemoveMember(ctx, id, memberId); err != nil {
+        return false, utils.DefaultGraphQLError(err.Error())
+    }
+    return true, nil
+}
+
+// DeleteHousehold は世帯を削除します(オーナーのみ、メンバーの保有資産は削除しない)
+func (s *DefaultHouseholdService) DeleteHousehold(ctx context.Context, id string) (bool, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return false, utils.UnauthenticatedError("Invalid user ID")
+    }
+    modelHousehold, err := s.findOwnedHousehold(ctx, userId, id)
+    if err != nil {
+        return false, err
+    }
+    if err := s.HouseholdRepo.DeleteHousehold(ctx, modelHousehold.ID); err != nil {
+        return false, utils.DefaultGraphQLError(err.Error())
+    }
+    return true, nil
+}
+
+// ログインユーザーがオーナーの世帯を取得する
+func (s *DefaultHouseholdService) findOwnedHousehold(ctx context.Context, userId uint, householdId string) (*model.Household, error) {
+    id, convertError := utils.ConvertIdToUint(householdId)
+    if convertError != nil || id == 0 {
+        return nil, utils.DefaultGraphQLError("入力された世帯idが無効です")
+    }
+    modelHouseholds, err := s.HouseholdRepo.FetchHouseholdListByUserId(ctx, userId)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    household := findHousehold(modelHouseholds, id)
+    if household == nil {
+        return nil, utils.DefaultGraphQLError("指定された世帯が見つかりません")
+    }
+    member, err := s.HouseholdRepo.FetchMember(ctx, id, userId)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    if member == nil || member.Role != repoHousehold.RoleOwner {
+        return nil, utils.DefaultGraphQLError("世帯のメンバーを管理する権限がありません")
+    }
+    return household, nil
+}
+
+// メンバーと合わせてGraphQLの型に変換する
+func (s *DefaultHouseholdService) convertToHousehold(ctx context.Context, modelHousehold *model.Household, userId uint) (*generated.Household, error) {
+    members, err := s.HouseholdRepo.FetchMembers(ctx, modelHousehold.ID)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return convertToHousehold(modelHousehold, members, userId), nil
+}
@@ -0,0 +1,302 @@
+package household
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	repoHousehold "my-us-stock-backend/app/repository/household"
+	repoUser "my-us-stock-backend/app/repository/user"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockUserRepository は UserRepository のモックです。
+type MockUserRepository struct {
+    mock.Mock
+}
+
+func (m *MockUserRepository) FindUserByID(ctx context.Context, id uint) (*model.User, error) {
+    args := m.Called(ctx, id)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) CreateUser(ctx context.Context, createDto repoUser.CreateUserDto) (*model.User, error) {
+    args := m.Called(ctx, createDto)
+    return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+    args := m.Called(ctx, email)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetAllUserByEmail(ctx context.Context, email string) ([]*model.User, error) {
+    args := m.Called(ctx, email)
+    return args.Get(0).([]*model.User), args.Error(1)
+}
+
+type testMocks struct {
+    householdRepo *repoHousehold.MockHouseholdRepository
+    userRepo      *MockUserRepository
+    auth          *auth.MockAuthService
+}
+
+func newTestService() (HouseholdService, testMocks) {
+    mocks := testMocks{
+        householdRepo: repoHousehold.NewMockHouseholdRepository(),
+        userRepo:      new(MockUserRepository),
+        auth:          auth.NewMockAuthService(),
+    }
+    service := NewHouseholdService(mocks.householdRepo, mocks.userRepo, mocks.auth)
+    return service, mocks
+}
+
+// ユーザー1がオーナー、ユーザー2が閲覧者の世帯
+var testHousehold = model.Household{Model: gorm.Model{ID: 1}, Name: "山田家", UserId: 1}
+
+var testMembers = []repoHousehold.HouseholdMemberDto{
+    {UserId: 1, Role: repoHousehold.RoleOwner, Name: "太郎", Email: "taro@example.com"},
+    {UserId: 2, Role: repoHousehold.RoleViewer, Name: "花子", Email: "hanako@example.com"},
+}
+
+func setupHousehold(mocks testMocks, userId uint, role string) {
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mocks.householdRepo.On("FetchHouseholdListByUserId", mock.Anything, userId).Return([]model.Household{testHousehold}, nil)
+    mocks.householdRepo.On("FetchMember", mock.Anything, uint(1), userId).Return(&model.HouseholdMember{HouseholdId: 1, UserId: userId, Role: role}, nil)
+    mocks.householdRepo.On("FetchMembers", mock.Anything, uint(1)).Return(testMembers, nil)
+}
+
+func TestHouseholds(t *testing.T) {
+    service, mocks := newTestService()
+    setupHousehold(mocks, 2, repoHousehold.RoleViewer)
+
+    households, err := service.Households(context.Background())
+
+    assert.NoError(t, err)
+    assert.Equal(t, []*generated.Household{
+        {
+            ID:   "1",
+            Name: "山田家",
+            Role: generated.HouseholdRoleViewer,
+            Members: []*generated.HouseholdMember{
+                {UserID: "1", Name: "太郎", Email: "taro@example.com", Role: generated.HouseholdRoleOwner},
+                {UserID: "2", Name: "花子", Email: "hanako@example.com", Role: generated.HouseholdRoleViewer},
+            },
+        },
+    }, households)
+}
+
+func TestHouseholdsUnauthenticated(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(0), errors.New("invalid token"))
+
+    households, err := service.Households(context.Background())
+
+    assert.Nil(t, households)
+    assert.Error(t, err)
+    mocks.householdRepo.AssertNotCalled(t, "FetchHouseholdListByUserId", mock.Anything, mock.Anything)
+}
+
+func TestCreateHousehold(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    mocks.householdRepo.On("CreateHousehold", mock.Anything, repoHousehold.CreateHouseholdDto{Name: "山田家", UserId: 1}).Return(&testHousehold, nil)
+    mocks.householdRepo.On("FetchMembers", mock.Anything, uint(1)).Return(testMembers[:1], nil)
+
+    household, err := service.CreateHousehold(context.Background(), generated.CreateHouseholdInput{Name: " 山田家 "})
+
+    assert.NoError(t, err)
+    assert.Equal(t, "1", household.ID)
+    assert.Equal(t, generated.HouseholdRoleOwner, household.Role)
+    assert.Len(t, household.Members, 1)
+}
+
+func TestCreateHouseholdInvalidName(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+
+    _, err := service.CreateHousehold(context.Background(), generated.CreateHouseholdInput{Name: " "})
+    assert.EqualError(t, err, "input: 世帯名を入力してください")
+
+    _, err = service.CreateHousehold(context.Background(), generated.CreateHouseholdInput{Name: strings.Repeat("あ", 256)})
+    assert.EqualError(t, err, "input: 世帯名は255文字以内で入力してください")
+    mocks.householdRepo.AssertNotCalled(t, "CreateHousehold", mock.Anything, mock.Anything)
+}
+
+func TestAddHouseholdMember(t *testing.T) {
+    service, mocks := newTestService()
+    setupHousehold(mocks, 1, repoHousehold.RoleOwner)
+    mocks.userRepo.On("GetUserByEmail", mock.Anything, "hanako@example.com").Return(&model.User{Model: gorm.Model{ID: 2}}, nil)
+    mocks.householdRepo.On("InviteMember", mock.Anything, repoHousehold.AddHouseholdMemberDto{HouseholdId: 1, UserId: 2, Role: repoHousehold.RoleViewer}).Return(nil)
+
+    household, err := service.AddHouseholdMember(context.Background(), generated.AddHouseholdMemberInput{HouseholdID: "1", Email: "hanako@example.com", Role: generated.HouseholdRoleViewer})
+
+    assert.NoError(t, err)
+    assert.Len(t, household.Members, 2)
+    mocks.householdRepo.AssertExpectations(t)
+}
+
+func TestAddHouseholdMemberByNonOwner(t *testing.T) {
+    service, mocks := newTestService()
+    setupHousehold(mocks, 2, repoHousehold.RoleEditor)
+
+    _, err := service.AddHouseholdMember(context.Background(), generated.AddHouseholdMemberInput{HouseholdID: "1", Email: "jiro@example.com", Role: generated.HouseholdRoleViewer})
+
+    assert.EqualError(t, err, "input: 世帯のメンバーを管理する権限がありません")
+    mocks.householdRepo.AssertNotCalled(t, "InviteMember", mock.Anything, mock.Anything)
+}
+
+// 登録されていないメールアドレスでも、登録済みの場合と同じ結果を返す
+func TestAddHouseholdMemberUnknownEmail(t *testing.T) {
+    service, mocks := newTestService()
+    setupHousehold(mocks, 1, repoHousehold.RoleOwner)
+    mocks.userRepo.On("GetUserByEmail", mock.Anything, "unknown@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+    household, err := service.AddHouseholdMember(context.Background(), generated.AddHouseholdMemberInput{HouseholdID: "1", Email: "unknown@example.com", Role: generated.HouseholdRoleEditor})
+
+    assert.NoError(t, err)
+    assert.Equal(t, "山田家", household.Name)
+    mocks.householdRepo.AssertNotCalled(t, "InviteMember", mock.Anything, mock.Anything)
+}
+
+func TestAddHouseholdMemberInvalidInput(t *testing.T) {
+    service, mocks := newTestService()
+    setupHousehold(mocks, 1, repoHousehold.RoleOwner)
+
+    _, err := service.AddHouseholdMember(context.Background(), generated.AddHouseholdMemberInput{HouseholdID: "1", Email: "hanako@example.com", Role: generated.HouseholdRoleOwner})
+    assert.EqualError(t, err, "input: メンバーの権限はEDITORまたはVIEWERを指定してください")
+
+    _, err = service.AddHouseholdMember(context.Background(), generated.AddHouseholdMemberInput{HouseholdID: "9", Email: "hanako@example.com", Role: generated.HouseholdRoleEditor})
+    assert.EqualError(t, err, "input: 指定された世帯が見つかりません")
+    mocks.householdRepo.AssertNotCalled(t, "InviteMember", mock.Anything, mock.Anything)
+}
+
+func TestHouseholdInvitations(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(2), nil)
+    mocks.householdRepo.On("FetchInvitations", mock.Anything, uint(2)).Return([]repoHousehold.HouseholdInvitationDto{{HouseholdId: 1, Name: "山田家", Role: repoHousehold.RoleViewer}}, nil)
+
+    invitations, err := service.HouseholdInvitations(context.Background())
+
+    assert.NoError(t, err)
+    assert.Equal(t, []*generated.HouseholdInvitation{{HouseholdID: "1", Name: "山田家", Role: generated.HouseholdRoleViewer}}, invitations)
+}
+
+func TestAcceptHouseholdInvitation(t *testing.T) {
+    service, mocks := newTestService()
+    setupHousehold(mocks, 2, repoHousehold.RoleViewer)
+    mocks.householdRepo.On("AcceptInvitation", mock.Anything, uint(1), uint(2)).Return(nil)
+
+    household, err := service.AcceptHouseholdInvitation(context.Background(), "1")
+
+    assert.NoError(t, err)
+    assert.Equal(t, generated.HouseholdRoleViewer, household.Role)
+    mocks.householdRepo.AssertCalled(t, "AcceptInvitation", mock.Anything, uint(1), uint(2))
+}
+
+func TestAcceptHouseholdInvitationNotFound(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(3), nil)
+    mocks.householdRepo.On("AcceptInvitation", mock.Anything, uint(1), uint(3)).Return(errors.New("指定された招待が見つかりません"))
+
+    _, err := service.AcceptHouseholdInvitation(context.Background(), "1")
+
+    assert.EqualError(t, err, "input: 指定された招待が見つかりません")
+}
+
+func TestDeclineHouseholdInvitation(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(2), nil)
+    mocks.householdRepo.On("DeclineInvitation", mock.Anything, uint(1), uint(2)).Return(nil)
+
+    result, err := service.DeclineHouseholdInvitation(context.Background(), "1")
+
+    assert.NoError(t, err)
+    assert.True(t, result)
+}
+
+func TestUpdateHouseholdMember(t *testing.T) {
+    service, mocks := newTestService()
+    setupHousehold(mocks, 1, repoHousehold.RoleOwner)
+    mocks.householdRepo.On("UpdateMemberRole", mock.Anything, uint(1), uint(2), repoHousehold.RoleEditor).Return(nil)
+
+    _, err := service.UpdateHouseholdMember(context.Background(), generated.UpdateHouseholdMemberInput{HouseholdID: "1", UserID: "2", Role: generated.HouseholdRoleEditor})
+
+    assert.NoError(t, err)
+    mocks.householdRepo.AssertExpectations(t)
+}
+
+func TestUpdateHouseholdMemberOwnerRole(t *testing.T) {
+    service, mocks := newTestService()
+    setupHousehold(mocks, 1, repoHousehold.RoleOwner)
+
+    _, err := service.UpdateHouseholdMember(context.Background(), generated.UpdateHouseholdMemberInput{HouseholdID: "1", UserID: "1", Role: generated.HouseholdRoleViewer})
+
+    assert.EqualError(t, err, "input: オーナーの権限は変更できません")
+    mocks.householdRepo.AssertNotCalled(t, "UpdateMemberRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRemoveHouseholdMember(t *testing.T) {
+    service, mocks := newTestService()
+    setupHousehold(mocks, 2, repoHousehold.RoleViewer)
+    mocks.householdRepo.On("RemoveMember", mock.Anything, uint(1), uint(2)).Return(nil)
+
+    // メンバーは自分自身を世帯から外せる
+    result, err := service.RemoveHouseholdMember(context.Background(), "1", "2")
+
+    assert.NoError(t, err)
+    assert.True(t, result)
+}
+
+func TestRemoveHouseholdMemberForbidden(t *testing.T) {
+    service, mocks := newTestService()
+    setupHousehold(mocks, 2, repoHousehold.RoleEditor)
+
+    _, err := service.RemoveHouseholdMember(context.Background(), "1", "1")
+
+    assert.EqualError(t, err, "input: 世帯のメンバーを管理する権限がありません")
+    mocks.householdRepo.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRemoveHouseholdMemberOwnerLeaving(t *testing.T) {
+    service, mocks := newTestService()
+    setupHousehold(mocks, 1, repoHousehold.RoleOwner)
+
+    _, err := service.RemoveHouseholdMember(context.Background(), "1", "1")
+
+    assert.EqualError(t, err, "input: オーナーは世帯から外れることができません。世帯を削除してください")
+}
+
+func TestDeleteHousehold(t *testing.T) {
+    service, mocks := newTestService()
+    setupHousehold(mocks, 1, repoHousehold.RoleOwner)
+    mocks.householdRepo.On("DeleteHousehold", mock.Anything, uint(1)).Return(nil)
+
+    result, err := service.DeleteHousehold(context.Background(), "1")
+
+    assert.NoError(t, err)
+    assert.True(t, result)
+}
+
+func TestDeleteHouseholdByNonOwner(t *testing.T) {
+    service, mocks := newTestService()
+    setupHousehold(mocks, 2, repoHousehold.RoleViewer)
+
+    result, err := service.DeleteHousehold(context.Background(), "1")
+
+    assert.False(t, result)
+    assert.EqualError(t, err, "input: 世帯のメンバーを管理する権限がありません")
+    mocks.householdRepo.AssertNotCalled(t, "DeleteHousehold", mock.Anything, mock.Anything)
+}
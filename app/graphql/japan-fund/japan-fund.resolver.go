@@ -13,8 +13,8 @@ func NewResolver(usStockService JapanFundService) *Resolver {
     return &Resolver{JapanFundService: usStockService}
 }
 
-func (r *Resolver) JapanFunds(ctx context.Context) ([]*generated.JapanFund, error) {
-    return r.JapanFundService.JapanFunds(ctx)
+func (r *Resolver) JapanFunds(ctx context.Context, accountId *string, householdId *string) ([]*generated.JapanFund, error) {
+    return r.JapanFundService.JapanFunds(ctx, accountId, householdId)
 }
 
 func (r *Resolver) CreateJapanFund(ctx context.Context, input generated.CreateJapanFundInput) (*generated.JapanFund, error) {
@@ -37,4 +37,8 @@ func (r *Resolver) UpdateJapanFund(ctx context.Context, input generated.UpdateJa
 
 func (r *Resolver) DeleteJapanFund(ctx context.Context, id string) (bool, error) {
     return r.JapanFundService.DeleteJapanFund(ctx, id)
-}
\ No newline at end of file
+}
+
+func (r *Resolver) FundPriceHistory(ctx context.Context, code string, days *int) ([]*generated.FundPricePoint, error) {
+    return r.JapanFundService.FundPriceHistory(ctx, code, days)
+}
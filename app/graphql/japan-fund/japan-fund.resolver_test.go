@@ -14,8 +14,8 @@ type MockJapanFundService struct {
     mock.Mock
 }
 
-func (m *MockJapanFundService) JapanFunds(ctx context.Context) ([]*generated.JapanFund, error) {
-    args := m.Called(ctx)
+func (m *MockJapanFundService) JapanFunds(ctx context.Context, accountId *string, householdId *string) ([]*generated.JapanFund, error) {
+    args := m.Called(ctx, accountId, householdId)
     return args.Get(0).([]*generated.JapanFund), args.Error(1)
 }
 
@@ -34,6 +34,11 @@ func (m *MockJapanFundService) DeleteJapanFund(ctx context.Context, id string) (
     return args.Get(0).(bool), args.Error(1)
 }
 
+func (m *MockJapanFundService) FundPriceHistory(ctx context.Context, code string, days *int) ([]*generated.FundPricePoint, error) {
+    args := m.Called(ctx, code, days)
+    return args.Get(0).([]*generated.FundPricePoint), args.Error(1)
+}
+
 // UsStocks メソッドのテスト
 func TestJapanFunds(t *testing.T) {
     mockService := new(MockJapanFundService)
@@ -42,9 +47,9 @@ func TestJapanFunds(t *testing.T) {
     japanFunds := []*generated.JapanFund{
         {ID: "1",Code: "SP500", Name:"ｅＭＡＸＩＳ Ｓｌｉｍ 米国株式（Ｓ＆Ｐ５００）", GetPrice: 15523.81, GetPriceTotal: 761157,CurrentPrice: 24281},
     }
-    mockService.On("JapanFunds", mock.Anything).Return(japanFunds, nil)
+    mockService.On("JapanFunds", mock.Anything, (*string)(nil), (*string)(nil)).Return(japanFunds, nil)
 
-    result, err := resolver.JapanFunds(context.Background())
+    result, err := resolver.JapanFunds(context.Background(), nil, nil)
     
     assert.NoError(t, err)
     assert.Equal(t, japanFunds, result)
@@ -3,42 +3,73 @@ package japanfund
 import (
 	"context"
 	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/common/household"
 	"my-us-stock-backend/app/database/model"
 	"my-us-stock-backend/app/graphql/generated"
 	"my-us-stock-backend/app/graphql/utils"
 	JapanFund "my-us-stock-backend/app/repository/assets/fund"
 	marketPrice "my-us-stock-backend/app/repository/market-price/fund"
+	"time"
 )
 
 // JapanFundService インターフェースの定義
 type JapanFundService interface {
-    JapanFunds(ctx context.Context) ([]*generated.JapanFund, error)
+    JapanFunds(ctx context.Context, accountId *string, householdId *string) ([]*generated.JapanFund, error)
 	CreateJapanFund(ctx context.Context, input generated.CreateJapanFundInput) (*generated.JapanFund, error)
 	UpdateJapanFund(ctx context.Context, input generated.UpdateJapanFundInput) (*generated.JapanFund, error)
 	DeleteJapanFund(ctx context.Context, id string) (bool, error)
+    FundPriceHistory(ctx context.Context, code string, days *int) ([]*generated.FundPricePoint, error)
 }
 
+// 価格履歴の取得期間(日数)
+const (
+    defaultFundPriceHistoryDays = 30
+    maxFundPriceHistoryDays = 3650
+)
+
 // DefaultJapanFundService 構造体の定義
 type DefaultJapanFundService struct {
     Repo JapanFund.JapanFundRepository // インターフェースを利用
     Auth auth.AuthService    // 認証サービスのインターフェース
 	MarketPriceRepo marketPrice.FundPriceRepository
+    HouseholdAccess household.HouseholdAccess
 }
 
 // NewJapanFundService は DefaultUserService の新しいインスタンスを作成します
-func NewJapanFundService(repo JapanFund.JapanFundRepository, auth auth.AuthService, marketPriceRepo marketPrice.FundPriceRepository) JapanFundService {
-    return &DefaultJapanFundService{Repo: repo, Auth: auth, MarketPriceRepo: marketPriceRepo}
+func NewJapanFundService(repo JapanFund.JapanFundRepository, auth auth.AuthService, marketPriceRepo marketPrice.FundPriceRepository, householdAccess household.HouseholdAccess) JapanFundService {
+    return &DefaultJapanFundService{Repo: repo, Auth: auth, MarketPriceRepo: marketPriceRepo, HouseholdAccess: householdAccess}
 }
 
-func (s *DefaultJapanFundService) JapanFunds(ctx context.Context) ([]*generated.JapanFund, error) {
+func (s *DefaultJapanFundService) JapanFunds(ctx context.Context, accountId *string, householdId *string) ([]*generated.JapanFund, error) {
     userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
     if userId == 0 {
         return nil, utils.UnauthenticatedError("Invalid user ID")
     }
-    modelFunds, err := s.Repo.FetchJapanFundListById(ctx, userId)
+    filterAccountId, convertError := utils.ConvertNullableIdToUint(accountId)
+    if convertError != nil {
+        return nil, utils.DefaultGraphQLError("入力された口座idが無効です")
+    }
+    // 世帯の指定がある場合は世帯の全メンバーの保有資産を対象にする
+    scopeUserIds, err := s.HouseholdAccess.ScopeUserIds(ctx, userId, householdId)
     if err != nil {
         return nil, utils.DefaultGraphQLError(err.Error())
     }
+    var modelFunds []model.JapanFund
+    for _, scopeUserId := range scopeUserIds {
+        userFunds, err := s.Repo.FetchJapanFundListById(ctx, scopeUserId)
+        if err != nil {
+            return nil, utils.DefaultGraphQLError(err.Error())
+        }
+        modelFunds = append(modelFunds, userFunds...)
+    }
+    // 口座の指定がある場合はその口座の保有資産のみ対象にする
+    filteredFunds := make([]model.JapanFund, 0, len(modelFunds))
+    for _, modelFund := range modelFunds {
+        if utils.InAccount(modelFund.AccountId, filterAccountId) {
+            filteredFunds = append(filteredFunds, modelFund)
+        }
+    }
+    modelFunds = filteredFunds
     if len(modelFunds) == 0 {
         return []*generated.JapanFund{}, nil
     }
@@ -69,6 +100,7 @@ func (s *DefaultJapanFundService) JapanFunds(ctx context.Context) ([]*generated.
         case result := <-resultChan:
             funds[result.Index] = &generated.JapanFund{
                 ID: utils.ConvertIdToString(modelFunds[result.Index].ID),
+                AccountID: utils.ConvertIdToString(modelFunds[result.Index].AccountId),
                 Code: modelFunds[result.Index].Code,
                 Name: modelFunds[result.Index].Name,
                 GetPrice: modelFunds[result.Index].GetPrice,
@@ -90,12 +122,17 @@ func (s *DefaultJapanFundService) CreateJapanFund(ctx context.Context, input gen
 	if userId == 0 {
 		return nil, utils.UnauthenticatedError("Invalid user ID")
 	}
+    createAccountId, convertError := utils.ConvertNullableIdToUint(input.AccountID)
+    if convertError != nil {
+        return nil, utils.DefaultGraphQLError("入力された口座idが無効です")
+    }
 	// 更新用DTOの作成
     createDto := JapanFund.CreateJapanFundDto{
         Code: input.Code,
 		Name: input.Name,
 		GetPriceTotal: input.GetPriceTotal,
 		GetPrice: input.GetPrice,
+		AccountId: createAccountId,
 		UserId: userId,
     }
     modelFund, err := s.Repo.CreateJapanFund(ctx, createDto)
@@ -108,6 +145,7 @@ func (s *DefaultJapanFundService) CreateJapanFund(ctx context.Context, input gen
     }
     return  &generated.JapanFund{
 		ID: utils.ConvertIdToString(modelFund.ID),
+		AccountID: utils.ConvertIdToString(modelFund.AccountId),
 		Code: modelFund.Code,
 		Name: modelFund.Name,
 		GetPriceTotal: modelFund.GetPriceTotal,
@@ -133,6 +171,10 @@ func (s *DefaultJapanFundService) UpdateJapanFund(ctx context.Context, input gen
         GetPrice: &input.GetPrice,
 		GetPriceTotal: &input.GetPriceTotal,
 	}
+    // 他のユーザーの保有資産は世帯で編集者以上の権限がある場合のみ更新できる
+    if err := s.HouseholdAccess.AuthorizeEdit(ctx, userId, &model.JapanFund{}, updateId); err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
     modelFund, err := s.Repo.UpdateJapanFund(ctx, updateDto)
     if err != nil {
         return nil, utils.DefaultGraphQLError(err.Error())
@@ -143,6 +185,7 @@ func (s *DefaultJapanFundService) UpdateJapanFund(ctx context.Context, input gen
     }
 	return  &generated.JapanFund{
 		ID: utils.ConvertIdToString(modelFund.ID),
+		AccountID: utils.ConvertIdToString(modelFund.AccountId),
 		Code: modelFund.Code,
 		Name: modelFund.Name,
 		GetPriceTotal: modelFund.GetPriceTotal,
@@ -164,10 +207,44 @@ func (s *DefaultJapanFundService) DeleteJapanFund(ctx context.Context, id string
     if convertError != nil || deleteId == 0 {
         return false, utils.DefaultGraphQLError("入力されたidが無効です")
        }
+    // 他のユーザーの保有資産は世帯で編集者以上の権限がある場合のみ削除できる
+    if err := s.HouseholdAccess.AuthorizeEdit(ctx, userId, &model.JapanFund{}, deleteId); err != nil {
+        return false, utils.DefaultGraphQLError(err.Error())
+    }
     var err = s.Repo.DeleteJapanFund(ctx, deleteId)
 	// 市場情報を追加して返却
     if err != nil {
      return false, utils.DefaultGraphQLError(err.Error())
     }
 	return true, nil
-}
\ No newline at end of file
+}
+
+// FundPriceHistory は指定された投資信託の直近 days 日間の日次価格を日付の昇順で取得します
+func (s *DefaultJapanFundService) FundPriceHistory(ctx context.Context, code string, days *int) ([]*generated.FundPricePoint, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    historyDays := defaultFundPriceHistoryDays
+    if days != nil {
+        historyDays = *days
+    }
+    if historyDays < 1 || historyDays > maxFundPriceHistoryDays {
+        return nil, utils.DefaultGraphQLError("取得日数は1〜3650の範囲で指定してください")
+    }
+
+    to := time.Now().UTC()
+    from := to.AddDate(0, 0, -historyDays)
+    histories, err := s.MarketPriceRepo.FetchFundPriceHistoryList(ctx, code, from, to)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    points := make([]*generated.FundPricePoint, len(histories))
+    for i, history := range histories {
+        points[i] = &generated.FundPricePoint{
+            Date: history.Date.UTC().Format("2006-01-02"),
+            Price: history.Price,
+        }
+    }
+    return points, nil
+}
@@ -2,6 +2,7 @@ package japanfund
 
 import (
 	"context"
+	"my-us-stock-backend/app/common/household"
 	"my-us-stock-backend/app/common/auth"
 	"my-us-stock-backend/app/database/model"
 	"my-us-stock-backend/app/graphql/generated"
@@ -9,6 +10,7 @@ import (
 	marketPrice "my-us-stock-backend/app/repository/market-price/fund"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -20,11 +22,13 @@ func TestJapanFundsService(t *testing.T) {
 	mockRepo := repo.NewMockJapanFundRepository()
 	mockAuth := auth.NewMockAuthService()
 	mockMarketRepo := marketPrice.NewMockFundPriceRepository()
-	service := NewJapanFundService(mockRepo, mockAuth, mockMarketRepo)
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewJapanFundService(mockRepo, mockAuth, mockMarketRepo, mockHouseholdAccess)
 
 	// モックの期待値設定
 	userId := uint(1)
 	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+	mockHouseholdAccess.On("ScopeUserIds", mock.Anything, userId, (*string)(nil)).Return([]uint{userId}, nil)
 
 	mockFunds := []model.JapanFund{
 		{Code: "SP500", Name:"ｅＭＡＸＩＳ Ｓｌｉｍ 米国株式（Ｓ＆Ｐ５００）", GetPrice: 15523.81, GetPriceTotal: 761157.0,UserId: 1},
@@ -34,7 +38,7 @@ func TestJapanFundsService(t *testing.T) {
 	expectedPrice := &model.FundPrice{Name: mockFunds[0].Name, Code: mockFunds[0].Code, Price: 27000.0}
 	mockMarketRepo.On("FindFundPriceByCode", mock.Anything, "SP500").Return(expectedPrice, nil)
 	// テスト対象メソッドの実行
-	funds, err := service.JapanFunds(context.Background())
+	funds, err := service.JapanFunds(context.Background(), nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, funds)
 	assert.Len(t, funds, 1)
@@ -56,7 +60,8 @@ func TestCreateJapanFundService(t *testing.T) {
 	mockRepo := repo.NewMockJapanFundRepository()
 	mockAuth := auth.NewMockAuthService()
 	mockMarketRepo := marketPrice.NewMockFundPriceRepository()
-	service := NewJapanFundService(mockRepo, mockAuth, mockMarketRepo)
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewJapanFundService(mockRepo, mockAuth, mockMarketRepo, mockHouseholdAccess)
 
 	// モックの期待値設定
 	userId := uint(1)
@@ -103,11 +108,13 @@ func TestUpdateJapanFundService(t *testing.T) {
     mockRepo := repo.NewMockJapanFundRepository()
     mockAuth := auth.NewMockAuthService()
 	mockMarketRepo := marketPrice.NewMockFundPriceRepository()
-	service := NewJapanFundService(mockRepo, mockAuth, mockMarketRepo)
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewJapanFundService(mockRepo, mockAuth, mockMarketRepo, mockHouseholdAccess)
 
     // モックの期待値設定
     userId := uint(1)
     mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mockHouseholdAccess.On("AuthorizeEdit", mock.Anything, userId, mock.Anything, mock.Anything).Return(nil)
 
     updateId := uint(1)
     updatedMockFund := &model.JapanFund{
@@ -158,11 +165,13 @@ func TestDeleteJapanFundService(t *testing.T) {
 	mockRepo := repo.NewMockJapanFundRepository()
 	mockAuth := auth.NewMockAuthService()
 	mockMarkeCryptoRepo := marketPrice.NewMockFundPriceRepository()
-	service := NewJapanFundService(mockRepo, mockAuth, mockMarkeCryptoRepo)
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewJapanFundService(mockRepo, mockAuth, mockMarkeCryptoRepo, mockHouseholdAccess)
 
 	// モックの期待値設定
 	userId := uint(1)
 	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+	mockHouseholdAccess.On("AuthorizeEdit", mock.Anything, userId, mock.Anything, mock.Anything).Return(nil)
 
 	deleteId := uint(1)
 	mockRepo.On("DeleteJapanFund", mock.Anything, deleteId).Return(nil)
@@ -176,3 +185,33 @@ func TestDeleteJapanFundService(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 	mockAuth.AssertExpectations(t)
 }
+
+// 直近の日次価格を日付の昇順で返し、取得日数の範囲外はエラーにする
+func TestFundPriceHistoryService(t *testing.T) {
+	mockRepo := repo.NewMockJapanFundRepository()
+	mockAuth := auth.NewMockAuthService()
+	mockMarketRepo := marketPrice.NewMockFundPriceRepository()
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewJapanFundService(mockRepo, mockAuth, mockMarketRepo, mockHouseholdAccess)
+
+	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+	mockMarketRepo.On("FetchFundPriceHistoryList", mock.Anything, "SP500", mock.Anything, mock.Anything).Return([]model.FundPriceHistory{
+		{Code: "SP500", Date: time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), Price: 33800},
+		{Code: "SP500", Date: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), Price: 34100},
+	}, nil)
+
+	points, err := service.FundPriceHistory(context.Background(), "SP500", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*generated.FundPricePoint{
+		{Date: "2026-10-15", Price: 33800},
+		{Date: "2026-10-16", Price: 34100},
+	}, points)
+	// 未指定の場合は直近30日間
+	from := mockMarketRepo.Calls[0].Arguments.Get(2).(time.Time)
+	to := mockMarketRepo.Calls[0].Arguments.Get(3).(time.Time)
+	assert.Equal(t, to.AddDate(0, 0, -30), from)
+
+	days := 0
+	_, err = service.FundPriceHistory(context.Background(), "SP500", &days)
+	assert.EqualError(t, err, "input: 取得日数は1〜3650の範囲で指定してください")
+}
@@ -0,0 +1,101 @@
+package marketprice
+
+import (
+	"math"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	repository "my-us-stock-backend/app/repository/market-price"
+	"time"
+)
+
+// 配当成長率(CAGR)を算出する年数
+var dividendCagrYears = []int{1, 3, 5, 10}
+
+// 配当履歴から配当成長指標を算出する
+// 年間配当は権利落日の暦年で集計し、集計途中の当年は成長率・連続増配年数の計算に含めない
+func createDividendHistory(ticker string, histories []repository.DividendHistoryDto, now time.Time) *generated.DividendHistory {
+	payments := make([]*generated.DividendPayment, len(histories))
+	for i, history := range histories {
+		payments[i] = &generated.DividendPayment{
+			ExDate:          history.ExDate,
+			RecordDate:      utils.ConvertDateToNullable(history.RecordDate),
+			PaymentDate:     utils.ConvertDateToNullable(history.PaymentDate),
+			DeclarationDate: utils.ConvertDateToNullable(history.DeclarationDate),
+			Amount:          history.Amount,
+			AdjustedAmount:  history.AdjustedAmount,
+		}
+	}
+
+	annualTotals, latestYear := calculateAnnualDividends(histories, now)
+	cagr := make(map[int]*float64, len(dividendCagrYears))
+	for _, years := range dividendCagrYears {
+		cagr[years] = calculateDividendCagr(annualTotals, latestYear, years)
+	}
+
+	return &generated.DividendHistory{
+		Ticker:                 ticker,
+		Payments:               payments,
+		Cagr1y:                 cagr[1],
+		Cagr3y:                 cagr[3],
+		Cagr5y:                 cagr[5],
+		Cagr10y:                cagr[10],
+		ConsecutiveGrowthYears: calculateConsecutiveGrowthYears(annualTotals, latestYear),
+		LatestPaymentCut:       isLatestPaymentCut(histories),
+	}
+}
+
+// 確定済みの年ごとの配当総額(分割調整後)と、その最新年を取得する
+func calculateAnnualDividends(histories []repository.DividendHistoryDto, now time.Time) (map[int]float64, int) {
+	annualTotals := make(map[int]float64)
+	latestYear := 0
+	for _, history := range histories {
+		exDate, err := time.Parse("2006-01-02", history.ExDate)
+		if err != nil || exDate.Year() >= now.Year() {
+			continue
+		}
+		annualTotals[exDate.Year()] += history.AdjustedAmount
+		if exDate.Year() > latestYear {
+			latestYear = exDate.Year()
+		}
+	}
+	return annualTotals, latestYear
+}
+
+// 指定年数の配当成長率(CAGR)を算出する(比較対象年の配当がない場合はnil)
+func calculateDividendCagr(annualTotals map[int]float64, latestYear int, years int) *float64 {
+	latest, ok := annualTotals[latestYear]
+	if !ok || latest <= 0 {
+		return nil
+	}
+	base, ok := annualTotals[latestYear-years]
+	if !ok || base <= 0 {
+		return nil
+	}
+	cagr := math.Round((math.Pow(latest/base, 1/float64(years))-1)*10000) / 10000
+	return &cagr
+}
+
+// 最新年から遡って前年より配当総額が増えている年数を数える
+func calculateConsecutiveGrowthYears(annualTotals map[int]float64, latestYear int) int {
+	growthYears := 0
+	for year := latestYear; ; year-- {
+		current, ok := annualTotals[year]
+		if !ok {
+			break
+		}
+		previous, ok := annualTotals[year-1]
+		if !ok || current <= previous {
+			break
+		}
+		growthYears++
+	}
+	return growthYears
+}
+
+// 直近の配当が前回の配当より減っているかを判定する(履歴は新しい順)
+func isLatestPaymentCut(histories []repository.DividendHistoryDto) bool {
+	if len(histories) < 2 {
+		return false
+	}
+	return histories[0].AdjustedAmount < histories[1].AdjustedAmount
+}
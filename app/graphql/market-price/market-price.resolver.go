@@ -16,3 +16,11 @@ func NewResolver(marketPriceService MarketPriceService) *Resolver {
 func (r *Resolver) MarketPrices(ctx context.Context, tickers []string) ([]*generated.MarketPrice, error) {
     return r.MarketPriceService.FetchMarketPriceList(ctx, tickers)
 }
+
+func (r *Resolver) PriceHistory(ctx context.Context, ticker string, from *string, to *string, interval *generated.PriceInterval) ([]*generated.PricePoint, error) {
+    return r.MarketPriceService.FetchPriceHistory(ctx, ticker, from, to, interval)
+}
+
+func (r *Resolver) DividendHistory(ctx context.Context, ticker string) (*generated.DividendHistory, error) {
+    return r.MarketPriceService.FetchDividendHistory(ctx, ticker)
+}
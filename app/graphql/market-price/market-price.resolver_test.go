@@ -19,6 +19,16 @@ func (m *MockMarketPriceService) FetchMarketPriceList(ctx context.Context, ticke
     return args.Get(0).([]*generated.MarketPrice), args.Error(1)
 }
 
+func (m *MockMarketPriceService) FetchPriceHistory(ctx context.Context, ticker string, from *string, to *string, interval *generated.PriceInterval) ([]*generated.PricePoint, error) {
+    args := m.Called(ctx, ticker, from, to, interval)
+    return args.Get(0).([]*generated.PricePoint), args.Error(1)
+}
+
+func (m *MockMarketPriceService) FetchDividendHistory(ctx context.Context, ticker string) (*generated.DividendHistory, error) {
+    args := m.Called(ctx, ticker)
+    return args.Get(0).(*generated.DividendHistory), args.Error(1)
+}
+
 // GetMarketPrices メソッドのテスト
 func TestGetMarketPrices(t *testing.T) {
     mockService := new(MockMarketPriceService)
@@ -37,4 +47,50 @@ func TestGetMarketPrices(t *testing.T) {
     assert.Equal(t, mockMarketPrices, result)
 
     mockService.AssertExpectations(t)
-}
\ No newline at end of file
+}
+
+// PriceHistory メソッドのテスト
+func TestPriceHistory(t *testing.T) {
+    mockService := new(MockMarketPriceService)
+    resolver := NewResolver(mockService)
+
+    from := "2024-01-02"
+    to := "2024-01-03"
+    interval := generated.PriceIntervalDaily
+    mockPricePoints := []*generated.PricePoint{
+        {Date: "2024-01-02", Open: 187.15, High: 188.44, Low: 183.89, Close: 185.64, Volume: 82488700},
+        {Date: "2024-01-03", Open: 184.22, High: 185.88, Low: 183.43, Close: 184.25, Volume: 58414500},
+    }
+    mockService.On("FetchPriceHistory", mock.Anything, "AAPL", &from, &to, &interval).Return(mockPricePoints, nil)
+
+    result, err := resolver.PriceHistory(context.Background(), "AAPL", &from, &to, &interval)
+
+    assert.NoError(t, err)
+    assert.Equal(t, mockPricePoints, result)
+
+    mockService.AssertExpectations(t)
+}
+
+// DividendHistory メソッドのテスト
+func TestDividendHistory(t *testing.T) {
+    mockService := new(MockMarketPriceService)
+    resolver := NewResolver(mockService)
+
+    cagr := 0.0435
+    mockDividendHistory := &generated.DividendHistory{
+        Ticker: "KO",
+        Payments: []*generated.DividendPayment{
+            {ExDate: "2023-11-30", Amount: 0.46, AdjustedAmount: 0.46},
+        },
+        Cagr1y: &cagr,
+        ConsecutiveGrowthYears: 3,
+    }
+    mockService.On("FetchDividendHistory", mock.Anything, "KO").Return(mockDividendHistory, nil)
+
+    result, err := resolver.DividendHistory(context.Background(), "KO")
+
+    assert.NoError(t, err)
+    assert.Equal(t, mockDividendHistory, result)
+
+    mockService.AssertExpectations(t)
+}
@@ -5,18 +5,23 @@ import (
 	"my-us-stock-backend/app/graphql/generated"
 	"my-us-stock-backend/app/graphql/utils"
 	repository "my-us-stock-backend/app/repository/market-price"
+	pricehistory "my-us-stock-backend/app/repository/market-price/price-history"
+	"time"
 )
 
 type MarketPriceService interface {
     FetchMarketPriceList(ctx context.Context, tickers []string) ([]*generated.MarketPrice, error)
+    FetchPriceHistory(ctx context.Context, ticker string, from *string, to *string, interval *generated.PriceInterval) ([]*generated.PricePoint, error)
+    FetchDividendHistory(ctx context.Context, ticker string) (*generated.DividendHistory, error)
 }
 
 type DefaultMarketPriceService struct {
     MarketPriceRepo repository.MarketPriceRepository
+    PriceHistoryRepo pricehistory.PriceHistoryRepository
 }
 
-func NewMarketPriceService(marketPriceRepo repository.MarketPriceRepository) MarketPriceService {
-    return &DefaultMarketPriceService{MarketPriceRepo: marketPriceRepo}
+func NewMarketPriceService(marketPriceRepo repository.MarketPriceRepository, priceHistoryRepo pricehistory.PriceHistoryRepository) MarketPriceService {
+    return &DefaultMarketPriceService{MarketPriceRepo: marketPriceRepo, PriceHistoryRepo: priceHistoryRepo}
 }
 
 func (s *DefaultMarketPriceService) FetchMarketPriceList(ctx context.Context, tickers []string) ([]*generated.MarketPrice, error) {
@@ -32,7 +37,45 @@ func (s *DefaultMarketPriceService) FetchMarketPriceList(ctx context.Context, ti
             CurrentPrice: dto.CurrentPrice,
             PriceGets:    dto.PriceGets,
             CurrentRate:  dto.CurrentRate,
+            DayLow:       dto.DayLow,
+            DayHigh:      dto.DayHigh,
+            YearHigh:     dto.YearHigh,
+            YearLow:      dto.YearLow,
+            MarketCap:    dto.MarketCap,
+            Pe:           dto.Pe,
+            Eps:          dto.Eps,
+            Volume:       dto.Volume,
+            PriceAvg50:   dto.PriceAvg50,
+            PriceAvg200:  dto.PriceAvg200,
+            EarningsAnnouncement: utils.ConvertDateToNullable(dto.EarningsAnnouncement),
         }
     }    
     return marketPrices, nil
 }
+
+// FetchPriceHistory は指定された銘柄の価格履歴を取得します
+func (s *DefaultMarketPriceService) FetchPriceHistory(ctx context.Context, ticker string, from *string, to *string, interval *generated.PriceInterval) ([]*generated.PricePoint, error) {
+    fromDate, toDate, err := parsePriceHistoryRange(from, to)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    histories, err := fetchDailyPrices(ctx, s, ticker, fromDate, toDate)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    // 集計単位が未指定の場合は日次とする
+    priceInterval := generated.PriceIntervalDaily
+    if interval != nil {
+        priceInterval = *interval
+    }
+    return aggregatePriceHistory(histories, priceInterval), nil
+}
+
+// FetchDividendHistory は指定された銘柄の全ての配当履歴と配当成長指標を取得します
+func (s *DefaultMarketPriceService) FetchDividendHistory(ctx context.Context, ticker string) (*generated.DividendHistory, error) {
+    histories, err := s.MarketPriceRepo.FetchDividendHistory(ctx, ticker)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return createDividendHistory(ticker, histories, time.Now()), nil
+}
@@ -3,9 +3,13 @@ package marketprice
 import (
 	"context"
 	"errors"
+	"fmt"
+	"my-us-stock-backend/app/database/model"
 	"my-us-stock-backend/app/graphql/generated"
 	marketPrice "my-us-stock-backend/app/repository/market-price"
+	pricehistory "my-us-stock-backend/app/repository/market-price/price-history"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -14,7 +18,7 @@ import (
 // FetchMarketPriceList のテスト
 func TestFetchMarketPriceList(t *testing.T) {
     mockRepo := marketPrice.NewMockMarketPriceRepository()
-    service := NewMarketPriceService(mockRepo)
+    service := NewMarketPriceService(mockRepo, pricehistory.NewMockPriceHistoryRepository())
 
     mockResponseBody := []marketPrice.MarketPriceDto{
         {Ticker: "AAPL", CurrentPrice: 189.84, PriceGets: 0.0685, CurrentRate: 0.13},
@@ -35,10 +39,37 @@ func TestFetchMarketPriceList(t *testing.T) {
     mockRepo.AssertExpectations(t)
 }
 
+// 株価指標が変換されることのテスト
+func TestFetchMarketPriceListQuoteDetail(t *testing.T) {
+    mockRepo := marketPrice.NewMockMarketPriceRepository()
+    service := NewMarketPriceService(mockRepo, pricehistory.NewMockPriceHistoryRepository())
+
+    mockResponseBody := []marketPrice.MarketPriceDto{
+        {Ticker: "AAPL", CurrentPrice: 192.53, YearHigh: 199.62, YearLow: 124.17, MarketCap: 2994380584000, Pe: 31.46, Eps: 6.12, EarningsAnnouncement: "2024-01-31T10:59:00.000+0000"},
+        {Ticker: "VTI", CurrentPrice: 238.55},
+    }
+    tickers := []string{"AAPL", "VTI"}
+    mockRepo.On("FetchMarketPriceList", mock.Anything, tickers).Return(mockResponseBody, nil)
+
+    result, err := service.FetchMarketPriceList(context.Background(), tickers)
+
+    assert.NoError(t, err)
+    assert.Equal(t, 199.62, result[0].YearHigh)
+    assert.Equal(t, 124.17, result[0].YearLow)
+    assert.Equal(t, 2994380584000.0, result[0].MarketCap)
+    assert.Equal(t, 31.46, result[0].Pe)
+    assert.Equal(t, 6.12, result[0].Eps)
+    assert.Equal(t, "2024-01-31T10:59:00.000+0000", *result[0].EarningsAnnouncement)
+    // 決算発表日が未定の場合はnull
+    assert.Nil(t, result[1].EarningsAnnouncement)
+
+    mockRepo.AssertExpectations(t)
+}
+
 // エラー発生時のテスト
 func TestFetchMarketPriceListError(t *testing.T) {
     mockRepo := marketPrice.NewMockMarketPriceRepository()
-    service := NewMarketPriceService(mockRepo)
+    service := NewMarketPriceService(mockRepo, pricehistory.NewMockPriceHistoryRepository())
 
     tickers := []string{"INVALID"}
     mockRepo.On("FetchMarketPriceList", mock.Anything, tickers).Return([]marketPrice.MarketPriceDto(nil), errors.New("error fetching market prices"))
@@ -50,3 +81,213 @@ func TestFetchMarketPriceListError(t *testing.T) {
     mockRepo.AssertExpectations(t)
 }
 
+// 価格履歴がキャッシュに揃っている場合は外部APIを呼ばないことのテスト
+func TestFetchPriceHistoryCacheHit(t *testing.T) {
+    mockRepo := marketPrice.NewMockMarketPriceRepository()
+    mockPriceHistoryRepo := pricehistory.NewMockPriceHistoryRepository()
+    service := NewMarketPriceService(mockRepo, mockPriceHistoryRepo)
+
+    from := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+    to := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+    cached := []model.PriceHistory{
+        {Ticker: "AAPL", Date: from, Open: 187.15, High: 188.44, Low: 183.89, Close: 185.64, Volume: 82488700},
+        {Ticker: "AAPL", Date: to, Open: 184.22, High: 185.88, Low: 183.43, Close: 184.25, Volume: 58414500},
+    }
+    mockPriceHistoryRepo.On("FetchPriceHistoryList", mock.Anything, "AAPL", from, to).Return(cached, nil)
+
+    fromInput := "2024-01-02"
+    toInput := "2024-01-03"
+    result, err := service.FetchPriceHistory(context.Background(), "AAPL", &fromInput, &toInput, nil)
+
+    assert.NoError(t, err)
+    assert.Equal(t, []*generated.PricePoint{
+        {Date: "2024-01-02", Open: 187.15, High: 188.44, Low: 183.89, Close: 185.64, Volume: 82488700},
+        {Date: "2024-01-03", Open: 184.22, High: 185.88, Low: 183.43, Close: 184.25, Volume: 58414500},
+    }, result)
+
+    mockRepo.AssertNotCalled(t, "FetchHistoricalPrices", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+    mockPriceHistoryRepo.AssertExpectations(t)
+}
+
+// 価格履歴がキャッシュにない場合は外部APIから取得して保存することのテスト
+func TestFetchPriceHistoryCacheMiss(t *testing.T) {
+    mockRepo := marketPrice.NewMockMarketPriceRepository()
+    mockPriceHistoryRepo := pricehistory.NewMockPriceHistoryRepository()
+    service := NewMarketPriceService(mockRepo, mockPriceHistoryRepo)
+
+    from := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+    to := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+    fetched := []marketPrice.HistoricalPriceDto{
+        {Ticker: "AAPL", Date: from, Open: 187.15, High: 188.44, Low: 183.89, Close: 185.64, Volume: 82488700},
+        {Ticker: "AAPL", Date: to, Open: 184.22, High: 185.88, Low: 183.43, Close: 184.25, Volume: 58414500},
+    }
+    mockPriceHistoryRepo.On("FetchPriceHistoryList", mock.Anything, "AAPL", from, to).Return([]model.PriceHistory{}, nil)
+    mockRepo.On("FetchHistoricalPrices", mock.Anything, "AAPL", from, to).Return(fetched, nil)
+    mockPriceHistoryRepo.On("UpsertPriceHistories", mock.Anything, mock.MatchedBy(func(dtos []pricehistory.CreatePriceHistoryDto) bool {
+        return len(dtos) == 2 && dtos[0].Ticker == "AAPL" && dtos[1].Close == 184.25
+    })).Return(nil)
+
+    fromInput := "2024-01-02"
+    toInput := "2024-01-03"
+    result, err := service.FetchPriceHistory(context.Background(), "AAPL", &fromInput, &toInput, nil)
+
+    assert.NoError(t, err)
+    assert.Len(t, result, 2)
+    assert.Equal(t, 185.64, result[0].Close)
+
+    mockRepo.AssertExpectations(t)
+    mockPriceHistoryRepo.AssertExpectations(t)
+}
+
+// キャッシュの途中に欠落がある場合は外部APIから取得し直すことのテスト
+func TestFetchPriceHistoryCacheGap(t *testing.T) {
+    mockRepo := marketPrice.NewMockMarketPriceRepository()
+    mockPriceHistoryRepo := pricehistory.NewMockPriceHistoryRepository()
+    service := NewMarketPriceService(mockRepo, mockPriceHistoryRepo)
+
+    from := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+    to := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+    // 1/3 から 1/29 までが欠落している
+    cached := []model.PriceHistory{
+        {Ticker: "AAPL", Date: from, Close: 185.64},
+        {Ticker: "AAPL", Date: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), Close: 184.25},
+        {Ticker: "AAPL", Date: time.Date(2024, 1, 29, 0, 0, 0, 0, time.UTC), Close: 191.73},
+        {Ticker: "AAPL", Date: to, Close: 184.40},
+    }
+    fetched := []marketPrice.HistoricalPriceDto{
+        {Ticker: "AAPL", Date: from, Close: 185.64},
+        {Ticker: "AAPL", Date: time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC), Close: 183.63},
+        {Ticker: "AAPL", Date: to, Close: 184.40},
+    }
+    mockPriceHistoryRepo.On("FetchPriceHistoryList", mock.Anything, "AAPL", from, to).Return(cached, nil)
+    mockRepo.On("FetchHistoricalPrices", mock.Anything, "AAPL", from, to).Return(fetched, nil)
+    mockPriceHistoryRepo.On("UpsertPriceHistories", mock.Anything, mock.Anything).Return(nil)
+
+    fromInput := "2024-01-02"
+    toInput := "2024-01-31"
+    result, err := service.FetchPriceHistory(context.Background(), "AAPL", &fromInput, &toInput, nil)
+
+    assert.NoError(t, err)
+    assert.Len(t, result, 3)
+    assert.Equal(t, "2024-01-16", result[1].Date)
+    mockRepo.AssertExpectations(t)
+}
+
+// 週次で集計されることのテスト
+func TestFetchPriceHistoryWeekly(t *testing.T) {
+    mockRepo := marketPrice.NewMockMarketPriceRepository()
+    mockPriceHistoryRepo := pricehistory.NewMockPriceHistoryRepository()
+    service := NewMarketPriceService(mockRepo, mockPriceHistoryRepo)
+
+    from := time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)
+    to := time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)
+    cached := []model.PriceHistory{
+        {Ticker: "AAPL", Date: time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), Open: 182.15, High: 183.09, Low: 180.88, Close: 181.91, Volume: 100},
+        {Ticker: "AAPL", Date: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), Open: 181.99, High: 182.76, Low: 180.17, Close: 181.18, Volume: 200},
+        {Ticker: "AAPL", Date: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), Open: 182.09, High: 185.60, Low: 181.50, Close: 185.56, Volume: 300},
+        {Ticker: "AAPL", Date: time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC), Open: 183.92, High: 185.15, Low: 182.73, Close: 185.14, Volume: 400},
+    }
+    mockPriceHistoryRepo.On("FetchPriceHistoryList", mock.Anything, "AAPL", from, to).Return(cached, nil)
+
+    fromInput := "2024-01-04"
+    toInput := "2024-01-09"
+    interval := generated.PriceIntervalWeekly
+    result, err := service.FetchPriceHistory(context.Background(), "AAPL", &fromInput, &toInput, &interval)
+
+    assert.NoError(t, err)
+    assert.Equal(t, []*generated.PricePoint{
+        {Date: "2024-01-04", Open: 182.15, High: 183.09, Low: 180.17, Close: 181.18, Volume: 300},
+        {Date: "2024-01-08", Open: 182.09, High: 185.60, Low: 181.50, Close: 185.14, Volume: 700},
+    }, result)
+}
+
+// 開始日が終了日より後の場合のテスト
+func TestFetchPriceHistoryInvalidRange(t *testing.T) {
+    mockRepo := marketPrice.NewMockMarketPriceRepository()
+    mockPriceHistoryRepo := pricehistory.NewMockPriceHistoryRepository()
+    service := NewMarketPriceService(mockRepo, mockPriceHistoryRepo)
+
+    fromInput := "2024-02-01"
+    toInput := "2024-01-01"
+    _, err := service.FetchPriceHistory(context.Background(), "AAPL", &fromInput, &toInput, nil)
+
+    assert.Error(t, err)
+    mockPriceHistoryRepo.AssertNotCalled(t, "FetchPriceHistoryList", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// 配当履歴と配当成長指標のテスト
+func TestFetchDividendHistory(t *testing.T) {
+    mockRepo := marketPrice.NewMockMarketPriceRepository()
+    service := NewMarketPriceService(mockRepo, pricehistory.NewMockPriceHistoryRepository())
+
+    // 当年は集計途中のため成長指標の計算対象外とする
+    // 昨年まで: 10年前=1.0、その後は1年前まで毎年増配(4年前のみ据え置き)
+    lastYear := time.Now().Year() - 1
+    annualAmounts := map[int]float64{
+        lastYear + 1: 0.1,
+        lastYear: 2.0,
+        lastYear - 1: 1.8,
+        lastYear - 2: 1.6,
+        lastYear - 3: 1.5,
+        lastYear - 4: 1.5,
+        lastYear - 5: 1.4,
+        lastYear - 10: 1.0,
+    }
+    histories := []marketPrice.DividendHistoryDto{}
+    for year := lastYear + 1; year >= lastYear-10; year-- {
+        amount, ok := annualAmounts[year]
+        if !ok {
+            continue
+        }
+        // 当年は1回のみ、それ以外は年2回に分けて支払う
+        if year > lastYear {
+            histories = append(histories, marketPrice.DividendHistoryDto{ExDate: fmt.Sprintf("%d-01-05", year), PaymentDate: fmt.Sprintf("%d-01-20", year), Amount: amount, AdjustedAmount: amount})
+            continue
+        }
+        histories = append(histories,
+            marketPrice.DividendHistoryDto{ExDate: fmt.Sprintf("%d-06-01", year), Amount: amount / 2, AdjustedAmount: amount / 2},
+            marketPrice.DividendHistoryDto{ExDate: fmt.Sprintf("%d-01-05", year), Amount: amount / 2, AdjustedAmount: amount / 2},
+        )
+    }
+    mockRepo.On("FetchDividendHistory", mock.Anything, "KO").Return(histories, nil)
+
+    result, err := service.FetchDividendHistory(context.Background(), "KO")
+
+    assert.NoError(t, err)
+    assert.Equal(t, "KO", result.Ticker)
+    assert.Len(t, result.Payments, len(histories))
+    assert.Equal(t, fmt.Sprintf("%d-01-20", lastYear+1), *result.Payments[0].PaymentDate)
+    assert.Nil(t, result.Payments[1].PaymentDate)
+    assert.Equal(t, 0.1111, *result.Cagr1y)
+    assert.Equal(t, 0.1006, *result.Cagr3y)
+    assert.Equal(t, 0.0739, *result.Cagr5y)
+    assert.Equal(t, 0.0718, *result.Cagr10y)
+    assert.Equal(t, 3, result.ConsecutiveGrowthYears)
+    // 当年の配当(0.1)は前回(1.0)より少ない
+    assert.True(t, result.LatestPaymentCut)
+
+    mockRepo.AssertExpectations(t)
+}
+
+// 比較対象年の配当がない場合は成長率がnullになることのテスト
+func TestFetchDividendHistoryNoComparison(t *testing.T) {
+    mockRepo := marketPrice.NewMockMarketPriceRepository()
+    service := NewMarketPriceService(mockRepo, pricehistory.NewMockPriceHistoryRepository())
+
+    lastYear := time.Now().Year() - 1
+    histories := []marketPrice.DividendHistoryDto{
+        {ExDate: fmt.Sprintf("%d-06-01", lastYear), Amount: 0.5, AdjustedAmount: 0.5},
+        {ExDate: fmt.Sprintf("%d-06-01", lastYear-1), Amount: 0.4, AdjustedAmount: 0.4},
+    }
+    mockRepo.On("FetchDividendHistory", mock.Anything, "VTI").Return(histories, nil)
+
+    result, err := service.FetchDividendHistory(context.Background(), "VTI")
+
+    assert.NoError(t, err)
+    assert.Equal(t, 0.25, *result.Cagr1y)
+    assert.Nil(t, result.Cagr3y)
+    assert.Nil(t, result.Cagr5y)
+    assert.Nil(t, result.Cagr10y)
+    assert.Equal(t, 1, result.ConsecutiveGrowthYears)
+    assert.False(t, result.LatestPaymentCut)
+}
@@ -0,0 +1,146 @@
+package marketprice
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	pricehistory "my-us-stock-backend/app/repository/market-price/price-history"
+	"time"
+)
+
+// 休場日(土日・祝日)を考慮し、キャッシュの先頭・末尾およびキャッシュ内の日付の間隔がこの日数以内であれば取得済みとみなす
+const priceHistoryCacheToleranceDays = 4
+
+// 取得期間を解析する(未指定の場合は直近1年間とする)
+func parsePriceHistoryRange(from *string, to *string) (time.Time, time.Time, error) {
+	now := time.Now().UTC()
+	toDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if to != nil {
+		parsed, err := time.Parse("2006-01-02", *to)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("日付の形式が不正です(YYYY-MM-DD): %s", *to)
+		}
+		toDate = parsed
+	}
+	fromDate := toDate.AddDate(-1, 0, 0)
+	if from != nil {
+		parsed, err := time.Parse("2006-01-02", *from)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("日付の形式が不正です(YYYY-MM-DD): %s", *from)
+		}
+		fromDate = parsed
+	}
+	if fromDate.After(toDate) {
+		return time.Time{}, time.Time{}, fmt.Errorf("開始日は終了日以前の日付を指定してください")
+	}
+	return fromDate, toDate, nil
+}
+
+// 日次価格を取得する
+// 期間内のデータがキャッシュ(price_historiesテーブル)に揃っている場合は外部APIを呼ばない
+func fetchDailyPrices(ctx context.Context, s *DefaultMarketPriceService, ticker string, from time.Time, to time.Time) ([]model.PriceHistory, error) {
+	cached, err := s.PriceHistoryRepo.FetchPriceHistoryList(ctx, ticker, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if isPriceHistoryCovered(cached, from, to) {
+		return cached, nil
+	}
+
+	fetched, err := s.MarketPriceRepo.FetchHistoricalPrices(ctx, ticker, from, to)
+	if err != nil {
+		return nil, err
+	}
+	dtos := make([]pricehistory.CreatePriceHistoryDto, len(fetched))
+	histories := make([]model.PriceHistory, len(fetched))
+	for i, price := range fetched {
+		dtos[i] = pricehistory.CreatePriceHistoryDto{
+			Ticker: ticker,
+			Date:   price.Date,
+			Open:   price.Open,
+			High:   price.High,
+			Low:    price.Low,
+			Close:  price.Close,
+			Volume: price.Volume,
+		}
+		histories[i] = model.PriceHistory{
+			Ticker: ticker,
+			Date:   price.Date,
+			Open:   price.Open,
+			High:   price.High,
+			Low:    price.Low,
+			Close:  price.Close,
+			Volume: price.Volume,
+		}
+	}
+	// キャッシュの保存に失敗しても取得結果は返却する
+	if err := s.PriceHistoryRepo.UpsertPriceHistories(ctx, dtos); err != nil {
+		log.Printf("価格履歴の保存に失敗しました(%s): %v", ticker, err)
+	}
+	return histories, nil
+}
+
+// キャッシュが取得期間全体を網羅しているかを判定する
+// 期間の途中に休場日では説明できない欠落がある場合も未取得とみなす
+func isPriceHistoryCovered(cached []model.PriceHistory, from time.Time, to time.Time) bool {
+	if len(cached) == 0 {
+		return false
+	}
+	for i := 1; i < len(cached); i++ {
+		if cached[i].Date.After(cached[i-1].Date.AddDate(0, 0, priceHistoryCacheToleranceDays)) {
+			return false
+		}
+	}
+	first := cached[0].Date
+	last := cached[len(cached)-1].Date
+	return !first.After(from.AddDate(0, 0, priceHistoryCacheToleranceDays)) &&
+		!last.Before(to.AddDate(0, 0, -priceHistoryCacheToleranceDays))
+}
+
+// 日次価格を指定された単位で集計する
+// 週次・月次の場合、始値は期間の最初の取引日、終値は最後の取引日の値とする
+func aggregatePriceHistory(histories []model.PriceHistory, interval generated.PriceInterval) []*generated.PricePoint {
+	points := make([]*generated.PricePoint, 0, len(histories))
+	var currentKey string
+	var current *generated.PricePoint
+	for _, history := range histories {
+		key := priceIntervalKey(history.Date, interval)
+		if current == nil || key != currentKey {
+			current = &generated.PricePoint{
+				Date:   history.Date.Format("2006-01-02"),
+				Open:   history.Open,
+				High:   history.High,
+				Low:    history.Low,
+				Close:  history.Close,
+				Volume: history.Volume,
+			}
+			currentKey = key
+			points = append(points, current)
+			continue
+		}
+		if history.High > current.High {
+			current.High = history.High
+		}
+		if history.Low < current.Low {
+			current.Low = history.Low
+		}
+		current.Close = history.Close
+		current.Volume += history.Volume
+	}
+	return points
+}
+
+// 集計単位ごとのキーを作成する
+func priceIntervalKey(date time.Time, interval generated.PriceInterval) string {
+	switch interval {
+	case generated.PriceIntervalWeekly:
+		year, week := date.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case generated.PriceIntervalMonthly:
+		return date.Format("2006-01")
+	default:
+		return date.Format("2006-01-02")
+	}
+}
@@ -2,13 +2,24 @@ package graphql
 
 import (
 	"context"
+	"my-us-stock-backend/app/graphql/alert"
 	"my-us-stock-backend/app/graphql/crypto"
+	"my-us-stock-backend/app/graphql/event"
 	FixedIncomeAsset "my-us-stock-backend/app/graphql/fixed-income-asset"
 	"my-us-stock-backend/app/graphql/generated"
+	holdingImport "my-us-stock-backend/app/graphql/holding-import"
 	JapanFund "my-us-stock-backend/app/graphql/japan-fund"
+	"my-us-stock-backend/app/graphql/notification"
 	"my-us-stock-backend/app/graphql/stock"
+	taxReport "my-us-stock-backend/app/graphql/tax-report"
+	deletedHolding "my-us-stock-backend/app/graphql/deleted-holding"
+	brokerageAccount "my-us-stock-backend/app/graphql/brokerage-account"
+	"my-us-stock-backend/app/graphql/account"
+	"my-us-stock-backend/app/graphql/household"
+	shareToken "my-us-stock-backend/app/graphql/share-token"
 	TotalAsset "my-us-stock-backend/app/graphql/total-asset"
 	"my-us-stock-backend/app/graphql/user"
+	"my-us-stock-backend/app/graphql/watchlist"
 )
 
 // MutationResolver インターフェースを実装します
@@ -19,6 +30,17 @@ type CustomMutationResolver struct {
 	FIxedIncomeAssetResolver *FixedIncomeAsset.Resolver
 	JapanFundResolver *JapanFund.Resolver
 	TotalAssetResolver *TotalAsset.Resolver
+	EventResolver *event.Resolver
+	WatchlistResolver *watchlist.Resolver
+	AlertResolver *alert.Resolver
+	NotificationResolver *notification.Resolver
+	HoldingImportResolver *holdingImport.Resolver
+	TaxReportResolver *taxReport.Resolver
+	AccountResolver *account.Resolver
+	DeletedHoldingResolver *deletedHolding.Resolver
+	BrokerageAccountResolver *brokerageAccount.Resolver
+	HouseholdResolver *household.Resolver
+	ShareTokenResolver *shareToken.Resolver
 }
 
 // Mutationメソッドの実装
@@ -80,4 +102,136 @@ func (r *CustomMutationResolver) DeleteJapanFund(ctx context.Context, id string)
 
 func (r *CustomMutationResolver) UpdateTotalAsset(ctx context.Context, input generated.UpdateTotalAssetInput) (*generated.TotalAsset, error) {
 	return r.TotalAssetResolver.UpdateTotalAsset(ctx, input)
-}
\ No newline at end of file
+}
+
+func (r *CustomMutationResolver) IssueCalendarToken(ctx context.Context) (string, error) {
+	return r.EventResolver.IssueCalendarToken(ctx)
+}
+
+func (r *CustomMutationResolver) CreateWatchlist(ctx context.Context, input generated.CreateWatchlistInput) (*generated.Watchlist, error) {
+	return r.WatchlistResolver.CreateWatchlist(ctx, input)
+}
+
+func (r *CustomMutationResolver) UpdateWatchlist(ctx context.Context, input generated.UpdateWatchlistInput) (*generated.Watchlist, error) {
+	return r.WatchlistResolver.UpdateWatchlist(ctx, input)
+}
+
+func (r *CustomMutationResolver) DeleteWatchlist(ctx context.Context, id string) (bool, error) {
+	return r.WatchlistResolver.DeleteWatchlist(ctx, id)
+}
+
+func (r *CustomMutationResolver) ConvertWatchlistToUsStock(ctx context.Context, input generated.ConvertWatchlistToUsStockInput) (*generated.UsStock, error) {
+	return r.WatchlistResolver.ConvertWatchlistToUsStock(ctx, input)
+}
+
+func (r *CustomMutationResolver) CreateAlertRule(ctx context.Context, input generated.CreateAlertRuleInput) (*generated.AlertRule, error) {
+	return r.AlertResolver.CreateAlertRule(ctx, input)
+}
+
+func (r *CustomMutationResolver) UpdateAlertRule(ctx context.Context, input generated.UpdateAlertRuleInput) (*generated.AlertRule, error) {
+	return r.AlertResolver.UpdateAlertRule(ctx, input)
+}
+
+func (r *CustomMutationResolver) DeleteAlertRule(ctx context.Context, id string) (bool, error) {
+	return r.AlertResolver.DeleteAlertRule(ctx, id)
+}
+
+func (r *CustomMutationResolver) CreateNotificationChannel(ctx context.Context, input generated.CreateNotificationChannelInput) (*generated.NotificationChannel, error) {
+	return r.NotificationResolver.CreateNotificationChannel(ctx, input)
+}
+
+func (r *CustomMutationResolver) UpdateNotificationChannel(ctx context.Context, input generated.UpdateNotificationChannelInput) (*generated.NotificationChannel, error) {
+	return r.NotificationResolver.UpdateNotificationChannel(ctx, input)
+}
+
+func (r *CustomMutationResolver) DeleteNotificationChannel(ctx context.Context, id string) (bool, error) {
+	return r.NotificationResolver.DeleteNotificationChannel(ctx, id)
+}
+
+func (r *CustomMutationResolver) ImportHoldings(ctx context.Context, input generated.ImportHoldingsInput) (*generated.ImportResult, error) {
+	return r.HoldingImportResolver.ImportHoldings(ctx, input)
+}
+
+func (r *CustomMutationResolver) CreateDividendReceipt(ctx context.Context, input generated.CreateDividendReceiptInput) (*generated.DividendReceipt, error) {
+	return r.TaxReportResolver.CreateDividendReceipt(ctx, input)
+}
+
+func (r *CustomMutationResolver) DeleteDividendReceipt(ctx context.Context, id string) (bool, error) {
+	return r.TaxReportResolver.DeleteDividendReceipt(ctx, id)
+}
+
+func (r *CustomMutationResolver) CreateStockSale(ctx context.Context, input generated.CreateStockSaleInput) (*generated.StockSale, error) {
+	return r.TaxReportResolver.CreateStockSale(ctx, input)
+}
+
+func (r *CustomMutationResolver) DeleteStockSale(ctx context.Context, id string) (bool, error) {
+	return r.TaxReportResolver.DeleteStockSale(ctx, id)
+}
+
+func (r *CustomMutationResolver) DeleteAccount(ctx context.Context, input generated.DeleteAccountInput) (*generated.AccountDeletion, error) {
+	return r.AccountResolver.DeleteAccount(ctx, input)
+}
+
+func (r *CustomMutationResolver) RestoreUsStock(ctx context.Context, id string) (bool, error) {
+	return r.DeletedHoldingResolver.RestoreUsStock(ctx, id)
+}
+
+func (r *CustomMutationResolver) RestoreCrypto(ctx context.Context, id string) (bool, error) {
+	return r.DeletedHoldingResolver.RestoreCrypto(ctx, id)
+}
+
+func (r *CustomMutationResolver) RestoreJapanFund(ctx context.Context, id string) (bool, error) {
+	return r.DeletedHoldingResolver.RestoreJapanFund(ctx, id)
+}
+
+func (r *CustomMutationResolver) RestoreFixedIncomeAsset(ctx context.Context, id string) (bool, error) {
+	return r.DeletedHoldingResolver.RestoreFixedIncomeAsset(ctx, id)
+}
+
+func (r *CustomMutationResolver) CreateBrokerageAccount(ctx context.Context, input generated.CreateBrokerageAccountInput) (*generated.BrokerageAccount, error) {
+	return r.BrokerageAccountResolver.CreateBrokerageAccount(ctx, input)
+}
+
+func (r *CustomMutationResolver) UpdateBrokerageAccount(ctx context.Context, input generated.UpdateBrokerageAccountInput) (*generated.BrokerageAccount, error) {
+	return r.BrokerageAccountResolver.UpdateBrokerageAccount(ctx, input)
+}
+
+func (r *CustomMutationResolver) DeleteBrokerageAccount(ctx context.Context, id string) (bool, error) {
+	return r.BrokerageAccountResolver.DeleteBrokerageAccount(ctx, id)
+}
+
+func (r *CustomMutationResolver) CreateHousehold(ctx context.Context, input generated.CreateHouseholdInput) (*generated.Household, error) {
+	return r.HouseholdResolver.CreateHousehold(ctx, input)
+}
+
+func (r *CustomMutationResolver) AddHouseholdMember(ctx context.Context, input generated.AddHouseholdMemberInput) (*generated.Household, error) {
+	return r.HouseholdResolver.AddHouseholdMember(ctx, input)
+}
+
+func (r *CustomMutationResolver) AcceptHouseholdInvitation(ctx context.Context, householdID string) (*generated.Household, error) {
+	return r.HouseholdResolver.AcceptHouseholdInvitation(ctx, householdID)
+}
+
+func (r *CustomMutationResolver) DeclineHouseholdInvitation(ctx context.Context, householdID string) (bool, error) {
+	return r.HouseholdResolver.DeclineHouseholdInvitation(ctx, householdID)
+}
+
+func (r *CustomMutationResolver) UpdateHouseholdMember(ctx context.Context, input generated.UpdateHouseholdMemberInput) (*generated.Household, error) {
+	return r.HouseholdResolver.UpdateHouseholdMember(ctx, input)
+}
+
+func (r *CustomMutationResolver) RemoveHouseholdMember(ctx context.Context, householdID string, userID string) (bool, error) {
+	return r.HouseholdResolver.RemoveHouseholdMember(ctx, householdID, userID)
+}
+
+func (r *CustomMutationResolver) DeleteHousehold(ctx context.Context, id string) (bool, error) {
+	return r.HouseholdResolver.DeleteHousehold(ctx, id)
+}
+
+func (r *CustomMutationResolver) CreateShareToken(ctx context.Context, input generated.CreateShareTokenInput) (*generated.ShareToken, error) {
+	return r.ShareTokenResolver.CreateShareToken(ctx, input)
+}
+
+func (r *CustomMutationResolver) RevokeShareToken(ctx context.Context, id string) (bool, error) {
+	return r.ShareTokenResolver.RevokeShareToken(ctx, id)
+}
@@ -0,0 +1,29 @@
+package nisa
+
+import (
+	"math"
+	"my-us-stock-backend/app/graphql/generated"
+	repoNisa "my-us-stock-backend/app/repository/nisa"
+)
+
+// 投資枠の利用額をGraphQLの型に変換する
+func convertToNisaAllowance(year int, usage *repoNisa.NisaUsage) *generated.NisaAllowance {
+    return &generated.NisaAllowance{
+        Year: year,
+        Annual: frameUsage(repoNisa.AnnualLimit, usage.GrowthAnnual+usage.TsumitateAnnual),
+        GrowthAnnual: frameUsage(repoNisa.GrowthAnnualLimit, usage.GrowthAnnual),
+        TsumitateAnnual: frameUsage(repoNisa.TsumitateAnnualLimit, usage.TsumitateAnnual),
+        Lifetime: frameUsage(repoNisa.LifetimeLimit, usage.GrowthLifetime+usage.TsumitateLifetime),
+        GrowthLifetime: frameUsage(repoNisa.GrowthLifetimeLimit, usage.GrowthLifetime),
+    }
+}
+
+// 残りの投資枠は0未満にしない(上限導入前に登録された保有資産で超えている場合がある)
+func frameUsage(limit float64, used float64) *generated.NisaFrameUsage {
+    used = math.Round(used)
+    return &generated.NisaFrameUsage{
+        Limit: limit,
+        Used: used,
+        Remaining: math.Max(limit-used, 0),
+    }
+}
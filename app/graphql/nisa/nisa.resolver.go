@@ -0,0 +1,18 @@
+package nisa
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+)
+
+type Resolver struct {
+    NisaService NisaService
+}
+
+func NewResolver(nisaService NisaService) *Resolver {
+    return &Resolver{NisaService: nisaService}
+}
+
+func (r *Resolver) NisaAllowance(ctx context.Context, year int) (*generated.NisaAllowance, error) {
+    return r.NisaService.NisaAllowance(ctx, year)
+}
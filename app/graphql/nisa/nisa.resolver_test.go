@@ -0,0 +1,34 @@
+package nisa
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockNisaService は NisaService のモックです。
+type MockNisaService struct {
+    mock.Mock
+}
+
+func (m *MockNisaService) NisaAllowance(ctx context.Context, year int) (*generated.NisaAllowance, error) {
+    args := m.Called(ctx, year)
+    return args.Get(0).(*generated.NisaAllowance), args.Error(1)
+}
+
+func TestNisaAllowanceResolver(t *testing.T) {
+    mockService := new(MockNisaService)
+    resolver := NewResolver(mockService)
+
+    expected := &generated.NisaAllowance{Year: 2025}
+    mockService.On("NisaAllowance", mock.Anything, 2025).Return(expected, nil)
+
+    allowance, err := resolver.NisaAllowance(context.Background(), 2025)
+
+    assert.NoError(t, err)
+    assert.Equal(t, expected, allowance)
+    mockService.AssertExpectations(t)
+}
@@ -0,0 +1,48 @@
+package nisa
+
+import (
+	"context"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	repoNisa "my-us-stock-backend/app/repository/nisa"
+)
+
+// 新しいNISA制度が始まった年
+const nisaStartYear = 2024
+
+// NisaService インターフェースの定義
+type NisaService interface {
+    NisaAllowance(ctx context.Context, year int) (*generated.NisaAllowance, error)
+}
+
+// DefaultNisaService 構造体の定義
+type DefaultNisaService struct {
+    NisaRepo repoNisa.NisaRepository
+    Auth auth.AuthService
+}
+
+// NewNisaService は DefaultNisaService の新しいインスタンスを作成します
+func NewNisaService(nisaRepo repoNisa.NisaRepository, auth auth.AuthService) NisaService {
+    return &DefaultNisaService{
+        NisaRepo: nisaRepo,
+        Auth: auth,
+    }
+}
+
+// NisaAllowance はログインユーザーの指定した年のNISA投資枠の利用状況を取得します
+func (s *DefaultNisaService) NisaAllowance(ctx context.Context, year int) (*generated.NisaAllowance, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    if year < nisaStartYear || year > 9999 {
+        return nil, utils.DefaultGraphQLError("年は2024年以降を指定してください")
+    }
+
+    usage, err := s.NisaRepo.FetchNisaUsage(ctx, userId, year)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return convertToNisaAllowance(year, usage), nil
+}
@@ -0,0 +1,70 @@
+package nisa
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/graphql/generated"
+	repoNisa "my-us-stock-backend/app/repository/nisa"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testMocks struct {
+    nisaRepo *repoNisa.MockNisaRepository
+    auth     *auth.MockAuthService
+}
+
+func newTestService() (NisaService, testMocks) {
+    mocks := testMocks{
+        nisaRepo: repoNisa.NewMockNisaRepository(),
+        auth:     auth.NewMockAuthService(),
+    }
+    service := NewNisaService(mocks.nisaRepo, mocks.auth)
+    return service, mocks
+}
+
+func TestNisaAllowance(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    mocks.nisaRepo.On("FetchNisaUsage", mock.Anything, uint(1), 2025).Return(&repoNisa.NisaUsage{
+        GrowthAnnual: 1500000,
+        TsumitateAnnual: 1200000,
+        GrowthLifetime: 12500000,
+        TsumitateLifetime: 2000000,
+    }, nil)
+
+    allowance, err := service.NisaAllowance(context.Background(), 2025)
+
+    assert.NoError(t, err)
+    assert.Equal(t, 2025, allowance.Year)
+    assert.Equal(t, &generated.NisaFrameUsage{Limit: 3600000, Used: 2700000, Remaining: 900000}, allowance.Annual)
+    assert.Equal(t, &generated.NisaFrameUsage{Limit: 2400000, Used: 1500000, Remaining: 900000}, allowance.GrowthAnnual)
+    assert.Equal(t, &generated.NisaFrameUsage{Limit: 1200000, Used: 1200000, Remaining: 0}, allowance.TsumitateAnnual)
+    assert.Equal(t, &generated.NisaFrameUsage{Limit: 18000000, Used: 14500000, Remaining: 3500000}, allowance.Lifetime)
+    // 上限を超えている場合も残りは0
+    assert.Equal(t, &generated.NisaFrameUsage{Limit: 12000000, Used: 12500000, Remaining: 0}, allowance.GrowthLifetime)
+}
+
+func TestNisaAllowanceInvalidYear(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+
+    allowance, err := service.NisaAllowance(context.Background(), 2023)
+
+    assert.Nil(t, allowance)
+    assert.EqualError(t, err, "input: 年は2024年以降を指定してください")
+    mocks.nisaRepo.AssertNotCalled(t, "FetchNisaUsage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestNisaAllowanceUnauthenticated(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(0), errors.New("invalid token"))
+
+    allowance, err := service.NisaAllowance(context.Background(), 2025)
+
+    assert.Nil(t, allowance)
+    assert.Error(t, err)
+}
@@ -0,0 +1,90 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	commonNotification "my-us-stock-backend/app/common/notification"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	"my-us-stock-backend/app/repository/notification"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 署名用シークレットの最小文字数
+const minSecretLength = 16
+
+// validateNotificationChannel は表示名と通知先を検証し、前後の空白を除いた値を返します
+func validateNotificationChannel(ctx context.Context, channelType generated.NotificationChannelType, name string, target string) (string, string, error) {
+    trimmedName := strings.TrimSpace(name)
+    if trimmedName == "" || utf8.RuneCountInString(trimmedName) > 100 {
+        return "", "", fmt.Errorf("表示名は1〜100文字で入力してください")
+    }
+
+    trimmedTarget := strings.TrimSpace(target)
+    if channelType == generated.NotificationChannelTypeEmail {
+        address, err := mail.ParseAddress(trimmedTarget)
+        if err != nil {
+            return "", "", fmt.Errorf("メールアドレスの形式が不正です")
+        }
+        return trimmedName, address.Address, nil
+    }
+    parsed, err := url.Parse(trimmedTarget)
+    if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+        return "", "", fmt.Errorf("通知先のURLの形式が不正です")
+    }
+    if err := commonNotification.ValidateWebhookTarget(ctx, trimmedTarget); err != nil {
+        return "", "", commonNotification.ErrDisallowedWebhookTarget
+    }
+    return trimmedName, trimmedTarget, nil
+}
+
+func validateSecret(secret string) error {
+    if len(secret) < minSecretLength {
+        return fmt.Errorf("署名用のシークレットは16文字以上で入力してください")
+    }
+    return nil
+}
+
+func convertToGeneratedNotificationChannel(channel model.NotificationChannel) *generated.NotificationChannel {
+    return &generated.NotificationChannel{
+        ID:      utils.ConvertIdToString(channel.ID),
+        Type:    generated.NotificationChannelType(channel.Type),
+        Name:    channel.Name,
+        Target:  channel.Target,
+        Enabled: channel.Enabled,
+    }
+}
+
+func convertToGeneratedNotificationDelivery(delivery model.NotificationDelivery) *generated.NotificationDelivery {
+    var lastError, nextAttemptAt, sentAt *string
+    if delivery.LastError != "" {
+        lastError = &delivery.LastError
+    }
+    // 次回の送信予定は送信待ちの場合のみ返す
+    if delivery.Status == notification.StatusPending {
+        formatted := delivery.NextAttemptAt.Format(time.RFC3339)
+        nextAttemptAt = &formatted
+    }
+    if delivery.SentAt != nil {
+        formatted := delivery.SentAt.Format(time.RFC3339)
+        sentAt = &formatted
+    }
+    return &generated.NotificationDelivery{
+        ID:            utils.ConvertIdToString(delivery.ID),
+        ChannelID:     utils.ConvertIdToString(delivery.ChannelId),
+        ChannelName:   delivery.Channel.Name,
+        Event:         delivery.Event,
+        Subject:       delivery.Subject,
+        Status:        generated.NotificationDeliveryStatus(delivery.Status),
+        Attempts:      delivery.Attempts,
+        LastError:     lastError,
+        NextAttemptAt: nextAttemptAt,
+        SentAt:        sentAt,
+        CreatedAt:     delivery.CreatedAt.Format(time.RFC3339),
+    }
+}
@@ -0,0 +1,34 @@
+package notification
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+)
+
+type Resolver struct {
+    NotificationService NotificationService
+}
+
+func NewResolver(notificationService NotificationService) *Resolver {
+    return &Resolver{NotificationService: notificationService}
+}
+
+func (r *Resolver) NotificationChannels(ctx context.Context) ([]*generated.NotificationChannel, error) {
+    return r.NotificationService.NotificationChannels(ctx)
+}
+
+func (r *Resolver) NotificationDeliveries(ctx context.Context, limit *int) ([]*generated.NotificationDelivery, error) {
+    return r.NotificationService.NotificationDeliveries(ctx, limit)
+}
+
+func (r *Resolver) CreateNotificationChannel(ctx context.Context, input generated.CreateNotificationChannelInput) (*generated.NotificationChannel, error) {
+    return r.NotificationService.CreateNotificationChannel(ctx, input)
+}
+
+func (r *Resolver) UpdateNotificationChannel(ctx context.Context, input generated.UpdateNotificationChannelInput) (*generated.NotificationChannel, error) {
+    return r.NotificationService.UpdateNotificationChannel(ctx, input)
+}
+
+func (r *Resolver) DeleteNotificationChannel(ctx context.Context, id string) (bool, error) {
+    return r.NotificationService.DeleteNotificationChannel(ctx, id)
+}
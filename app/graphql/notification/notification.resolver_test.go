@@ -0,0 +1,116 @@
+package notification
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockNotificationService は NotificationService のモックです。
+type MockNotificationService struct {
+    mock.Mock
+}
+
+func (m *MockNotificationService) NotificationChannels(ctx context.Context) ([]*generated.NotificationChannel, error) {
+    args := m.Called(ctx)
+    return args.Get(0).([]*generated.NotificationChannel), args.Error(1)
+}
+
+func (m *MockNotificationService) NotificationDeliveries(ctx context.Context, limit *int) ([]*generated.NotificationDelivery, error) {
+    args := m.Called(ctx, limit)
+    return args.Get(0).([]*generated.NotificationDelivery), args.Error(1)
+}
+
+func (m *MockNotificationService) CreateNotificationChannel(ctx context.Context, input generated.CreateNotificationChannelInput) (*generated.NotificationChannel, error) {
+    args := m.Called(ctx, input)
+    return args.Get(0).(*generated.NotificationChannel), args.Error(1)
+}
+
+func (m *MockNotificationService) UpdateNotificationChannel(ctx context.Context, input generated.UpdateNotificationChannelInput) (*generated.NotificationChannel, error) {
+    args := m.Called(ctx, input)
+    return args.Get(0).(*generated.NotificationChannel), args.Error(1)
+}
+
+func (m *MockNotificationService) DeleteNotificationChannel(ctx context.Context, id string) (bool, error) {
+    args := m.Called(ctx, id)
+    return args.Get(0).(bool), args.Error(1)
+}
+
+func TestNotificationChannels(t *testing.T) {
+    mockService := new(MockNotificationService)
+    resolver := NewResolver(mockService)
+
+    channels := []*generated.NotificationChannel{
+        {ID: "1", Type: generated.NotificationChannelTypeSLACk, Name: "slack", Target: "https://hooks.example.com/a", Enabled: true},
+    }
+    mockService.On("NotificationChannels", mock.Anything).Return(channels, nil)
+
+    result, err := resolver.NotificationChannels(context.Background())
+
+    assert.NoError(t, err)
+    assert.Equal(t, channels, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestNotificationDeliveries(t *testing.T) {
+    mockService := new(MockNotificationService)
+    resolver := NewResolver(mockService)
+
+    limit := 10
+    deliveries := []*generated.NotificationDelivery{
+        {ID: "1", ChannelID: "1", ChannelName: "slack", Event: "ALERT_TRIGGERED", Subject: "件名", Status: generated.NotificationDeliveryStatusSent, Attempts: 1, CreatedAt: "2024-01-05T09:00:00Z"},
+    }
+    mockService.On("NotificationDeliveries", mock.Anything, &limit).Return(deliveries, nil)
+
+    result, err := resolver.NotificationDeliveries(context.Background(), &limit)
+
+    assert.NoError(t, err)
+    assert.Equal(t, deliveries, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestCreateNotificationChannel(t *testing.T) {
+    mockService := new(MockNotificationService)
+    resolver := NewResolver(mockService)
+
+    input := generated.CreateNotificationChannelInput{Type: generated.NotificationChannelTypeEmail, Name: "メール", Target: "user@example.com"}
+    channel := &generated.NotificationChannel{ID: "1", Type: input.Type, Name: input.Name, Target: input.Target, Enabled: true}
+    mockService.On("CreateNotificationChannel", mock.Anything, input).Return(channel, nil)
+
+    result, err := resolver.CreateNotificationChannel(context.Background(), input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, channel, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestUpdateNotificationChannel(t *testing.T) {
+    mockService := new(MockNotificationService)
+    resolver := NewResolver(mockService)
+
+    input := generated.UpdateNotificationChannelInput{ID: "1", Name: "メール", Target: "user@example.com", Enabled: false}
+    channel := &generated.NotificationChannel{ID: "1", Type: generated.NotificationChannelTypeEmail, Name: input.Name, Target: input.Target, Enabled: false}
+    mockService.On("UpdateNotificationChannel", mock.Anything, input).Return(channel, nil)
+
+    result, err := resolver.UpdateNotificationChannel(context.Background(), input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, channel, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestDeleteNotificationChannel(t *testing.T) {
+    mockService := new(MockNotificationService)
+    resolver := NewResolver(mockService)
+
+    mockService.On("DeleteNotificationChannel", mock.Anything, "1").Return(true, nil)
+
+    result, err := resolver.DeleteNotificationChannel(context.Background(), "1")
+
+    assert.NoError(t, err)
+    assert.True(t, result)
+    mockService.AssertExpectations(t)
+}
@@ -0,0 +1,171 @@
+package notification
+
+import (
+	"context"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	"my-us-stock-backend/app/repository/notification"
+)
+
+// 送信履歴の取得件数の上限
+const maxNotificationDeliveryLimit = 500
+
+// NotificationService インターフェースの定義
+type NotificationService interface {
+    NotificationChannels(ctx context.Context) ([]*generated.NotificationChannel, error)
+    NotificationDeliveries(ctx context.Context, limit *int) ([]*generated.NotificationDelivery, error)
+    CreateNotificationChannel(ctx context.Context, input generated.CreateNotificationChannelInput) (*generated.NotificationChannel, error)
+    UpdateNotificationChannel(ctx context.Context, input generated.UpdateNotificationChannelInput) (*generated.NotificationChannel, error)
+    DeleteNotificationChannel(ctx context.Context, id string) (bool, error)
+}
+
+// DefaultNotificationService 構造体の定義
+type DefaultNotificationService struct {
+    NotificationRepo notification.NotificationRepository
+    Auth auth.AuthService
+}
+
+// NewNotificationService は DefaultNotificationService の新しいインスタンスを作成します
+func NewNotificationService(notificationRepo notification.NotificationRepository, auth auth.AuthService) NotificationService {
+    return &DefaultNotificationService{NotificationRepo: notificationRepo, Auth: auth}
+}
+
+// NotificationChannels はユーザーの通知先を取得します
+func (s *DefaultNotificationService) NotificationChannels(ctx context.Context) ([]*generated.NotificationChannel, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+
+    modelChannels, err := s.NotificationRepo.FetchNotificationChannelListById(ctx, userId)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    channels := make([]*generated.NotificationChannel, 0, len(modelChannels))
+    for _, modelChannel := range modelChannels {
+        channels = append(channels, convertToGeneratedNotificationChannel(modelChannel))
+    }
+    return channels, nil
+}
+
+// NotificationDeliveries はユーザーの通知の送信履歴を新しい順に取得します
+func (s *DefaultNotificationService) NotificationDeliveries(ctx context.Context, limit *int) ([]*generated.NotificationDelivery, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+
+    fetchLimit := 50
+    if limit != nil {
+        fetchLimit = *limit
+    }
+    if fetchLimit < 1 || fetchLimit > maxNotificationDeliveryLimit {
+        return nil, utils.DefaultGraphQLError("取得件数は1〜500の範囲で指定してください")
+    }
+
+    modelDeliveries, err := s.NotificationRepo.FetchNotificationDeliveryListById(ctx, userId, fetchLimit)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    deliveries := make([]*generated.NotificationDelivery, 0, len(modelDeliveries))
+    for _, modelDelivery := range modelDeliveries {
+        deliveries = append(deliveries, convertToGeneratedNotificationDelivery(modelDelivery))
+    }
+    return deliveries, nil
+}
+
+// 通知先を登録します
+func (s *DefaultNotificationService) CreateNotificationChannel(ctx context.Context, input generated.CreateNotificationChannelInput) (*generated.NotificationChannel, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+
+    name, target, err := validateNotificationChannel(ctx, input.Type, input.Name, input.Target)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    secret := ""
+    if input.Type == generated.NotificationChannelTypeWebhook {
+        if input.Secret == nil {
+            return nil, utils.DefaultGraphQLError("署名用のシークレットを入力してください")
+        }
+        if err := validateSecret(*input.Secret); err != nil {
+            return nil, utils.DefaultGraphQLError(err.Error())
+        }
+        secret = *input.Secret
+    }
+
+    createDto := notification.CreateNotificationChannelDto{
+        Type:   string(input.Type),
+        Name:   name,
+        Target: target,
+        Secret: secret,
+        UserId: userId,
+    }
+    modelChannel, err := s.NotificationRepo.CreateNotificationChannel(ctx, createDto)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return convertToGeneratedNotificationChannel(*modelChannel), nil
+}
+
+// 通知先を更新します(通知先の種類は変更できない)
+func (s *DefaultNotificationService) UpdateNotificationChannel(ctx context.Context, input generated.UpdateNotificationChannelInput) (*generated.NotificationChannel, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+
+    id, err := utils.ConvertIdToUint(input.ID)
+    if err != nil || id == 0 {
+        return nil, utils.DefaultGraphQLError("入力されたidが無効です")
+    }
+    channel, err := s.NotificationRepo.FindNotificationChannelById(ctx, id, userId)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    name, target, err := validateNotificationChannel(ctx, generated.NotificationChannelType(channel.Type), input.Name, input.Target)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    var secret *string
+    if input.Secret != nil && channel.Type == string(generated.NotificationChannelTypeWebhook) {
+        if err := validateSecret(*input.Secret); err != nil {
+            return nil, utils.DefaultGraphQLError(err.Error())
+        }
+        secret = input.Secret
+    }
+
+    updateDto := notification.UpdateNotificationChannelDto{
+        ID:      id,
+        Name:    name,
+        Target:  target,
+        Secret:  secret,
+        Enabled: input.Enabled,
+        UserId:  userId,
+    }
+    modelChannel, err := s.NotificationRepo.UpdateNotificationChannel(ctx, updateDto)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return convertToGeneratedNotificationChannel(*modelChannel), nil
+}
+
+// 通知先を削除します
+func (s *DefaultNotificationService) DeleteNotificationChannel(ctx context.Context, id string) (bool, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return false, utils.UnauthenticatedError("Invalid user ID")
+    }
+
+    deleteId, err := utils.ConvertIdToUint(id)
+    if err != nil || deleteId == 0 {
+        return false, utils.DefaultGraphQLError("入力されたidが無効です")
+    }
+    if err := s.NotificationRepo.DeleteNotificationChannel(ctx, deleteId, userId); err != nil {
+        return false, utils.DefaultGraphQLError(err.Error())
+    }
+    return true, nil
+}
@@ -0,0 +1,158 @@
+package notification
+
+import (
+	"context"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	repo "my-us-stock-backend/app/repository/notification"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCreateNotificationChannelService(t *testing.T) {
+    mockRepo := repo.NewMockNotificationRepository()
+    mockAuth := auth.NewMockAuthService()
+    service := NewNotificationService(mockRepo, mockAuth)
+
+    userId := uint(1)
+    secret := "secret-0123456789"
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    expectedDto := repo.CreateNotificationChannelDto{Type: "WEBHOOK", Name: "自宅サーバー", Target: "https://example.com/hook", Secret: secret, UserId: userId}
+    mockRepo.On("CreateNotificationChannel", mock.Anything, expectedDto).Return(&model.NotificationChannel{
+        Model: gorm.Model{ID: 1}, Type: "WEBHOOK", Name: "自宅サーバー", Target: "https://example.com/hook", Secret: secret, Enabled: true, UserId: userId,
+    }, nil)
+
+    input := generated.CreateNotificationChannelInput{Type: generated.NotificationChannelTypeWebhook, Name: " 自宅サーバー ", Target: "https://example.com/hook", Secret: &secret}
+    result, err := service.CreateNotificationChannel(context.Background(), input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "自宅サーバー", result.Name)
+    assert.True(t, result.Enabled)
+    mockRepo.AssertExpectations(t)
+}
+
+// メールアドレスは表示名を除いたアドレスのみ保存し、シークレットは保存しない
+func TestCreateNotificationChannelServiceEmail(t *testing.T) {
+    mockRepo := repo.NewMockNotificationRepository()
+    mockAuth := auth.NewMockAuthService()
+    service := NewNotificationService(mockRepo, mockAuth)
+
+    userId := uint(1)
+    secret := "secret-0123456789"
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    expectedDto := repo.CreateNotificationChannelDto{Type: "EMAIL", Name: "メール", Target: "user@example.com", UserId: userId}
+    mockRepo.On("CreateNotificationChannel", mock.Anything, expectedDto).Return(&model.NotificationChannel{
+        Model: gorm.Model{ID: 1}, Type: "EMAIL", Name: "メール", Target: "user@example.com", Enabled: true, UserId: userId,
+    }, nil)
+
+    input := generated.CreateNotificationChannelInput{Type: generated.NotificationChannelTypeEmail, Name: "メール", Target: "Taro <user@example.com>", Secret: &secret}
+    result, err := service.CreateNotificationChannel(context.Background(), input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "user@example.com", result.Target)
+    mockRepo.AssertExpectations(t)
+}
+
+func TestCreateNotificationChannelServiceInvalidInput(t *testing.T) {
+    mockRepo := repo.NewMockNotificationRepository()
+    mockAuth := auth.NewMockAuthService()
+    service := NewNotificationService(mockRepo, mockAuth)
+
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+
+    shortSecret := "short"
+    inputs := []generated.CreateNotificationChannelInput{
+        // シークレットが未指定
+        {Type: generated.NotificationChannelTypeWebhook, Name: "hook", Target: "https://example.com/hook"},
+        // シークレットが短い
+        {Type: generated.NotificationChannelTypeWebhook, Name: "hook", Target: "https://example.com/hook", Secret: &shortSecret},
+        // URLの形式が不正
+        {Type: generated.NotificationChannelTypeSLACk, Name: "slack", Target: "ftp://example.com"},
+        // メールアドレスの形式が不正
+        {Type: generated.NotificationChannelTypeEmail, Name: "mail", Target: "user@"},
+        // 表示名が空
+        {Type: generated.NotificationChannelTypeDiscord, Name: " ", Target: "https://discord.example.com/api/webhooks/1"},
+        // 内部ネットワーク(クラウドのメタデータサービス)宛て
+        {Type: generated.NotificationChannelTypeSLACk, Name: "slack", Target: "http://169.254.169.254/latest/meta-data/"},
+        // ループバック宛て
+        {Type: generated.NotificationChannelTypeDiscord, Name: "discord", Target: "http://127.0.0.1:8080/hook"},
+    }
+    for _, input := range inputs {
+        _, err := service.CreateNotificationChannel(context.Background(), input)
+        assert.Error(t, err)
+    }
+    mockRepo.AssertNotCalled(t, "CreateNotificationChannel", mock.Anything, mock.Anything)
+}
+
+// 通知先の種類は登録済みのものを使って検証する
+func TestUpdateNotificationChannelService(t *testing.T) {
+    mockRepo := repo.NewMockNotificationRepository()
+    mockAuth := auth.NewMockAuthService()
+    service := NewNotificationService(mockRepo, mockAuth)
+
+    userId := uint(1)
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mockRepo.On("FindNotificationChannelById", mock.Anything, uint(1), userId).Return(&model.NotificationChannel{
+        Model: gorm.Model{ID: 1}, Type: "SLACK", Name: "slack", Target: "https://hooks.example.com/a", Enabled: true, UserId: userId,
+    }, nil)
+    expectedDto := repo.UpdateNotificationChannelDto{ID: 1, Name: "slack2", Target: "https://hooks.example.com/b", Enabled: false, UserId: userId}
+    mockRepo.On("UpdateNotificationChannel", mock.Anything, expectedDto).Return(&model.NotificationChannel{
+        Model: gorm.Model{ID: 1}, Type: "SLACK", Name: "slack2", Target: "https://hooks.example.com/b", Enabled: false, UserId: userId,
+    }, nil)
+
+    input := generated.UpdateNotificationChannelInput{ID: "1", Name: "slack2", Target: "https://hooks.example.com/b", Enabled: false}
+    result, err := service.UpdateNotificationChannel(context.Background(), input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "slack2", result.Name)
+    assert.False(t, result.Enabled)
+    mockRepo.AssertExpectations(t)
+}
+
+func TestDeleteNotificationChannelService(t *testing.T) {
+    mockRepo := repo.NewMockNotificationRepository()
+    mockAuth := auth.NewMockAuthService()
+    service := NewNotificationService(mockRepo, mockAuth)
+
+    userId := uint(1)
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mockRepo.On("DeleteNotificationChannel", mock.Anything, uint(1), userId).Return(nil)
+
+    result, err := service.DeleteNotificationChannel(context.Background(), "1")
+
+    assert.NoError(t, err)
+    assert.True(t, result)
+    mockRepo.AssertExpectations(t)
+}
+
+// 送信待ちの通知のみ次回の送信予定日時を返す
+func TestNotificationDeliveriesService(t *testing.T) {
+    mockRepo := repo.NewMockNotificationRepository()
+    mockAuth := auth.NewMockAuthService()
+    service := NewNotificationService(mockRepo, mockAuth)
+
+    userId := uint(1)
+    now := time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mockRepo.On("FetchNotificationDeliveryListById", mock.Anything, userId, 50).Return([]model.NotificationDelivery{
+        {Model: gorm.Model{ID: 2, CreatedAt: now}, ChannelId: 1, Channel: model.NotificationChannel{Name: "slack"}, Event: "ALERT_TRIGGERED", Subject: "件名", Status: "PENDING", Attempts: 1, NextAttemptAt: now.Add(time.Minute), LastError: "timeout", UserId: userId},
+        {Model: gorm.Model{ID: 1, CreatedAt: now}, ChannelId: 1, Channel: model.NotificationChannel{Name: "slack"}, Event: "SNAPSHOT_FAILED", Subject: "件名", Status: "SENT", Attempts: 1, NextAttemptAt: now, SentAt: &now, UserId: userId},
+    }, nil)
+
+    result, err := service.NotificationDeliveries(context.Background(), nil)
+
+    assert.NoError(t, err)
+    assert.Len(t, result, 2)
+    assert.Equal(t, "slack", result[0].ChannelName)
+    assert.Equal(t, "timeout", *result[0].LastError)
+    assert.Equal(t, "2024-01-05T09:01:00Z", *result[0].NextAttemptAt)
+    assert.Nil(t, result[0].SentAt)
+    assert.Nil(t, result[1].NextAttemptAt)
+    assert.Nil(t, result[1].LastError)
+    assert.Equal(t, "2024-01-05T09:00:00Z", *result[1].SentAt)
+}
@@ -2,15 +2,26 @@ package graphql
 
 import (
 	"context"
+	"my-us-stock-backend/app/graphql/alert"
 	"my-us-stock-backend/app/graphql/crypto"
+	"my-us-stock-backend/app/graphql/event"
 	"my-us-stock-backend/app/graphql/currency"
+	dailyReport "my-us-stock-backend/app/graphql/daily-report"
 	FixedIncomeAsset "my-us-stock-backend/app/graphql/fixed-income-asset"
 	"my-us-stock-backend/app/graphql/generated"
 	JapanFund "my-us-stock-backend/app/graphql/japan-fund"
 	marketPrice "my-us-stock-backend/app/graphql/market-price"
+	"my-us-stock-backend/app/graphql/notification"
 	"my-us-stock-backend/app/graphql/stock"
+	taxReport "my-us-stock-backend/app/graphql/tax-report"
+	deletedHolding "my-us-stock-backend/app/graphql/deleted-holding"
+	brokerageAccount "my-us-stock-backend/app/graphql/brokerage-account"
+	nisa "my-us-stock-backend/app/graphql/nisa"
+	"my-us-stock-backend/app/graphql/household"
+	shareToken "my-us-stock-backend/app/graphql/share-token"
 	TotalAsset "my-us-stock-backend/app/graphql/total-asset"
 	"my-us-stock-backend/app/graphql/user"
+	"my-us-stock-backend/app/graphql/watchlist"
 )
 
 // QueryResolverインターフェースを実装します
@@ -23,6 +34,17 @@ type CustomQueryResolver struct {
 	FIxedIncomeAssetResolver *FixedIncomeAsset.Resolver
 	JapanFundResolver *JapanFund.Resolver
 	TotalAssetResolver *TotalAsset.Resolver
+	EventResolver *event.Resolver
+	WatchlistResolver *watchlist.Resolver
+	AlertResolver *alert.Resolver
+	NotificationResolver *notification.Resolver
+	DailyReportResolver *dailyReport.Resolver
+	TaxReportResolver *taxReport.Resolver
+	DeletedHoldingResolver *deletedHolding.Resolver
+	BrokerageAccountResolver *brokerageAccount.Resolver
+	NisaResolver *nisa.Resolver
+	HouseholdResolver *household.Resolver
+	ShareTokenResolver *shareToken.Resolver
 }
 
 // Queryメソッドの実装
@@ -49,22 +71,94 @@ func (r *CustomQueryResolver) MarketPrices(ctx context.Context, tickers []*strin
     return r.MarketPriceResolver.MarketPrices(ctx, tickerStrs)
 }
 
-func (r *CustomQueryResolver) UsStocks(ctx context.Context) ([]*generated.UsStock, error) {
-	return r.UsStockResolver.UsStocks(ctx)
+func (r *CustomQueryResolver) PriceHistory(ctx context.Context, ticker string, from *string, to *string, interval *generated.PriceInterval) ([]*generated.PricePoint, error) {
+	return r.MarketPriceResolver.PriceHistory(ctx, ticker, from, to, interval)
 }
 
-func (r *CustomQueryResolver) Cryptos(ctx context.Context) ([]*generated.Crypto, error) {
-	return r.CryptoResolver.Cryptos(ctx)
+func (r *CustomQueryResolver) DividendHistory(ctx context.Context, ticker string) (*generated.DividendHistory, error) {
+	return r.MarketPriceResolver.DividendHistory(ctx, ticker)
 }
 
-func (r *CustomQueryResolver) FixedIncomeAssets(ctx context.Context) ([]*generated.FixedIncomeAsset, error) {
-	return r.FIxedIncomeAssetResolver.FixedIncomeAssets(ctx)
+func (r *CustomQueryResolver) UsStocks(ctx context.Context, accountID *string, householdID *string) ([]*generated.UsStock, error) {
+	return r.UsStockResolver.UsStocks(ctx, accountID, householdID)
 }
 
-func (r *CustomQueryResolver) JapanFunds(ctx context.Context) ([]*generated.JapanFund, error) {
-	return r.JapanFundResolver.JapanFunds (ctx)
+func (r *CustomQueryResolver) Cryptos(ctx context.Context, accountID *string, householdID *string) ([]*generated.Crypto, error) {
+	return r.CryptoResolver.Cryptos(ctx, accountID, householdID)
 }
 
-func (r *CustomQueryResolver) TotalAssets(ctx context.Context, day int) ([]*generated.TotalAsset, error) {
-	return r.TotalAssetResolver.TotalAssets (ctx, day)
+func (r *CustomQueryResolver) FixedIncomeAssets(ctx context.Context, accountID *string, householdID *string) ([]*generated.FixedIncomeAsset, error) {
+	return r.FIxedIncomeAssetResolver.FixedIncomeAssets(ctx, accountID, householdID)
+}
+
+func (r *CustomQueryResolver) JapanFunds(ctx context.Context, accountID *string, householdID *string) ([]*generated.JapanFund, error) {
+	return r.JapanFundResolver.JapanFunds (ctx, accountID, householdID)
+}
+
+func (r *CustomQueryResolver) TotalAssets(ctx context.Context, day int, accountID *string, householdID *string) ([]*generated.TotalAsset, error) {
+	return r.TotalAssetResolver.TotalAssets (ctx, day, accountID, householdID)
+}
+
+func (r *CustomQueryResolver) UpcomingEvents(ctx context.Context, days int) ([]*generated.CalendarEvent, error) {
+	return r.EventResolver.UpcomingEvents(ctx, days)
+}
+
+func (r *CustomQueryResolver) Watchlists(ctx context.Context) ([]*generated.Watchlist, error) {
+	return r.WatchlistResolver.Watchlists(ctx)
+}
+
+func (r *CustomQueryResolver) AlertRules(ctx context.Context) ([]*generated.AlertRule, error) {
+	return r.AlertResolver.AlertRules(ctx)
+}
+
+func (r *CustomQueryResolver) TriggeredAlerts(ctx context.Context, limit *int) ([]*generated.TriggeredAlert, error) {
+	return r.AlertResolver.TriggeredAlerts(ctx, limit)
+}
+
+func (r *CustomQueryResolver) NotificationChannels(ctx context.Context) ([]*generated.NotificationChannel, error) {
+	return r.NotificationResolver.NotificationChannels(ctx)
+}
+
+func (r *CustomQueryResolver) NotificationDeliveries(ctx context.Context, limit *int) ([]*generated.NotificationDelivery, error) {
+	return r.NotificationResolver.NotificationDeliveries(ctx, limit)
+}
+
+func (r *CustomQueryResolver) DailyReport(ctx context.Context, date string) (*generated.DailyReport, error) {
+	return r.DailyReportResolver.DailyReport(ctx, date)
+}
+
+func (r *CustomQueryResolver) AnnualTaxReport(ctx context.Context, year int) (*generated.AnnualTaxReport, error) {
+	return r.TaxReportResolver.AnnualTaxReport(ctx, year)
+}
+
+func (r *CustomQueryResolver) DeletedHoldings(ctx context.Context, typeArg *generated.HoldingType) ([]*generated.DeletedHolding, error) {
+	return r.DeletedHoldingResolver.DeletedHoldings(ctx, typeArg)
+}
+
+func (r *CustomQueryResolver) BrokerageAccounts(ctx context.Context) ([]*generated.BrokerageAccount, error) {
+	return r.BrokerageAccountResolver.BrokerageAccounts(ctx)
+}
+
+func (r *CustomQueryResolver) NisaAllowance(ctx context.Context, year int) (*generated.NisaAllowance, error) {
+	return r.NisaResolver.NisaAllowance(ctx, year)
+}
+
+func (r *CustomQueryResolver) Households(ctx context.Context) ([]*generated.Household, error) {
+	return r.HouseholdResolver.Households(ctx)
+}
+
+func (r *CustomQueryResolver) HouseholdInvitations(ctx context.Context) ([]*generated.HouseholdInvitation, error) {
+	return r.HouseholdResolver.HouseholdInvitations(ctx)
+}
+
+func (r *CustomQueryResolver) ShareTokens(ctx context.Context) ([]*generated.ShareToken, error) {
+	return r.ShareTokenResolver.ShareTokens(ctx)
+}
+
+func (r *CustomQueryResolver) FixedIncomeCashFlows(ctx context.Context, months *int) ([]*generated.FixedIncomeCashFlow, error) {
+	return r.FIxedIncomeAssetResolver.FixedIncomeCashFlows(ctx, months)
+}
+
+func (r *CustomQueryResolver) FundPriceHistory(ctx context.Context, code string, days *int) ([]*generated.FundPricePoint, error) {
+	return r.JapanFundResolver.FundPriceHistory(ctx, code, days)
 }
@@ -3,42 +3,81 @@ package graphql
 import (
 	authService "my-us-stock-backend/app/common/auth"
 	"my-us-stock-backend/app/common/auth/logic"
+	commonHousehold "my-us-stock-backend/app/common/household"
+	"my-us-stock-backend/app/common/housekeeping"
+	"my-us-stock-backend/app/graphql/alert"
 	"my-us-stock-backend/app/graphql/crypto"
 	"my-us-stock-backend/app/graphql/currency"
+	dailyReport "my-us-stock-backend/app/graphql/daily-report"
+	"my-us-stock-backend/app/graphql/event"
 	fixedIncomeAsset "my-us-stock-backend/app/graphql/fixed-income-asset"
 	"my-us-stock-backend/app/graphql/generated"
+	holdingImport "my-us-stock-backend/app/graphql/holding-import"
 	japanFund "my-us-stock-backend/app/graphql/japan-fund"
 	marketPrice "my-us-stock-backend/app/graphql/market-price"
+	"my-us-stock-backend/app/graphql/notification"
 	"my-us-stock-backend/app/graphql/stock"
+	"my-us-stock-backend/app/graphql/subscription"
+	taxReport "my-us-stock-backend/app/graphql/tax-report"
+	deletedHolding "my-us-stock-backend/app/graphql/deleted-holding"
+	brokerageAccount "my-us-stock-backend/app/graphql/brokerage-account"
+	nisa "my-us-stock-backend/app/graphql/nisa"
+	"my-us-stock-backend/app/graphql/household"
+	shareToken "my-us-stock-backend/app/graphql/share-token"
+	"my-us-stock-backend/app/graphql/account"
 	totalAsset "my-us-stock-backend/app/graphql/total-asset"
 	"my-us-stock-backend/app/graphql/user"
+	"my-us-stock-backend/app/graphql/watchlist"
 
+	repoAlert "my-us-stock-backend/app/repository/alert"
 	repoCrypto "my-us-stock-backend/app/repository/assets/crypto"
 	repoFixedIncome "my-us-stock-backend/app/repository/assets/fixed-income"
 	repoJapanFund "my-us-stock-backend/app/repository/assets/fund"
 	repoStock "my-us-stock-backend/app/repository/assets/stock"
+	repoCalendar "my-us-stock-backend/app/repository/calendar"
+	repoDailyReport "my-us-stock-backend/app/repository/daily-report"
+	repoHoldingImport "my-us-stock-backend/app/repository/holding-import"
 	repoMarketPrice "my-us-stock-backend/app/repository/market-price"
 	repoMarketCrypto "my-us-stock-backend/app/repository/market-price/crypto"
 	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
 	repoFundPrice "my-us-stock-backend/app/repository/market-price/fund"
+	repoInstrument "my-us-stock-backend/app/repository/market-price/instrument"
+	repoPriceHistory "my-us-stock-backend/app/repository/market-price/price-history"
+	repoNotification "my-us-stock-backend/app/repository/notification"
+	repoTaxReport "my-us-stock-backend/app/repository/tax-report"
+	repoAccount "my-us-stock-backend/app/repository/account"
+	repoDeletedHolding "my-us-stock-backend/app/repository/deleted-holding"
+	repoBrokerageAccount "my-us-stock-backend/app/repository/brokerage-account"
+	repoNisa "my-us-stock-backend/app/repository/nisa"
+	repoHousehold "my-us-stock-backend/app/repository/household"
+	repoShareToken "my-us-stock-backend/app/repository/share-token"
 	repoTotalAsset "my-us-stock-backend/app/repository/total-assets"
 	repoUser "my-us-stock-backend/app/repository/user"
+	repoWatchlist "my-us-stock-backend/app/repository/watchlist"
+
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-// CombinedResolverは、クエリとミューテーションの両方を処理するリゾルバです。
+// CombinedResolverは、クエリ・ミューテーション・サブスクリプションを処理するリゾルバです。
 type CombinedResolver struct {
     *CustomQueryResolver
     *CustomMutationResolver
+    *CustomSubscriptionResolver
 }
 
 
 // Handler は GraphQL ハンドラをセットアップし、gin.HandlerFunc を返します
-func Handler(userResolver *user.Resolver, currencyResolver *currency.Resolver,marketPriceResolver *marketPrice.Resolver, usStockResolver *stock.Resolver, cryptoResolver *crypto.Resolver, fixedIncomeAssetResolver *fixedIncomeAsset.Resolver, japanFundResolver *japanFund.Resolver, totalAssetResolver *totalAsset.Resolver) gin.HandlerFunc {
+func Handler(userResolver *user.Resolver, currencyResolver *currency.Resolver,marketPriceResolver *marketPrice.Resolver, usStockResolver *stock.Resolver, cryptoResolver *crypto.Resolver, fixedIncomeAssetResolver *fixedIncomeAsset.Resolver, japanFundResolver *japanFund.Resolver, totalAssetResolver *totalAsset.Resolver, eventResolver *event.Resolver, watchlistResolver *watchlist.Resolver, alertResolver *alert.Resolver, notificationResolver *notification.Resolver, dailyReportResolver *dailyReport.Resolver, subscriptionResolver *subscription.Resolver, holdingImportResolver *holdingImport.Resolver, taxReportResolver *taxReport.Resolver, accountResolver *account.Resolver, deletedHoldingResolver *deletedHolding.Resolver, brokerageAccountResolver *brokerageAccount.Resolver, nisaResolver *nisa.Resolver, householdResolver *household.Resolver, shareTokenResolver *shareToken.Resolver) gin.HandlerFunc {
     queryResolver := &CustomQueryResolver{
         UserResolver:     userResolver,
         CurrencyResolver: currencyResolver,
@@ -48,6 +87,17 @@ func Handler(userResolver *user.Resolver, currencyResolver *currency.Resolver,ma
         FIxedIncomeAssetResolver: fixedIncomeAssetResolver,
         JapanFundResolver: japanFundResolver,
         TotalAssetResolver: totalAssetResolver,
+        EventResolver: eventResolver,
+        WatchlistResolver: watchlistResolver,
+        AlertResolver: alertResolver,
+        NotificationResolver: notificationResolver,
+        DailyReportResolver: dailyReportResolver,
+        TaxReportResolver: taxReportResolver,
+        DeletedHoldingResolver: deletedHoldingResolver,
+        BrokerageAccountResolver: brokerageAccountResolver,
+        NisaResolver: nisaResolver,
+        HouseholdResolver: householdResolver,
+        ShareTokenResolver: shareTokenResolver,
     }
     mutationResolver := &CustomMutationResolver{
         UserResolver: userResolver,
@@ -56,12 +106,35 @@ func Handler(userResolver *user.Resolver, currencyResolver *currency.Resolver,ma
         FIxedIncomeAssetResolver: fixedIncomeAssetResolver,
         JapanFundResolver: japanFundResolver,
         TotalAssetResolver: totalAssetResolver,
+        EventResolver: eventResolver,
+        WatchlistResolver: watchlistResolver,
+        AlertResolver: alertResolver,
+        NotificationResolver: notificationResolver,
+        HoldingImportResolver: holdingImportResolver,
+        TaxReportResolver: taxReportResolver,
+        AccountResolver: accountResolver,
+        DeletedHoldingResolver: deletedHoldingResolver,
+        BrokerageAccountResolver: brokerageAccountResolver,
+        HouseholdResolver: householdResolver,
+        ShareTokenResolver: shareTokenResolver,
     }
     combinedResolver := &CombinedResolver{
         CustomQueryResolver: queryResolver,
         CustomMutationResolver: mutationResolver,
+        CustomSubscriptionResolver: &CustomSubscriptionResolver{SubscriptionResolver: subscriptionResolver},
     }
-    srv := handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: combinedResolver}))
+    // handler.NewDefaultServer をもとに WebSocket のオリジンチェックを差し替え、
+    // CSRF で mutation を実行されないよう GET での実行は受け付けない
+    srv := handler.New(generated.NewExecutableSchema(generated.Config{Resolvers: combinedResolver}))
+    srv.AddTransport(newWebsocketTransport())
+    srv.AddTransport(transport.Options{})
+    srv.AddTransport(transport.POST{})
+    srv.AddTransport(transport.MultipartForm{})
+    srv.SetQueryCache(lru.New(1000))
+    srv.Use(extension.Introspection{})
+    srv.Use(extension.AutomaticPersistedQuery{
+        Cache: lru.New(100),
+    })
 
     return func(c *gin.Context) {
         // ここでGinのContextをGraphQLのContextに変換
@@ -84,6 +157,21 @@ func SetupGraphQL(r *gin.Engine, db *gorm.DB) {
     fixedIncomeAssetRepo := repoFixedIncome.NewFixedIncomeRepository(db)
     totalAssetRepo := repoTotalAsset.NewTotalAssetRepository(db)
     fundPriceRepo := repoFundPrice.NewFetchFundRepository(db)
+    instrumentRepo := repoInstrument.NewInstrumentRepository(db)
+    priceHistoryRepo := repoPriceHistory.NewPriceHistoryRepository(db)
+    calendarTokenRepo := repoCalendar.NewCalendarTokenRepository(db)
+    watchlistRepo := repoWatchlist.NewWatchlistRepository(db)
+    alertRepo := repoAlert.NewAlertRepository(db)
+    notificationRepo := repoNotification.NewNotificationRepository(db)
+    dailyReportRepo := repoDailyReport.NewDailyReportRepository(db)
+    holdingImportRepo := repoHoldingImport.NewHoldingImportRepository(db)
+    taxReportRepo := repoTaxReport.NewTaxReportRepository(db)
+    accountRepo := repoAccount.NewAccountRepository(db)
+    deletedHoldingRepo := repoDeletedHolding.NewDeletedHoldingRepository(db)
+    brokerageAccountRepo := repoBrokerageAccount.NewBrokerageAccountRepository(db)
+    nisaRepo := repoNisa.NewNisaRepository(db)
+    householdRepo := repoHousehold.NewHouseholdRepository(db)
+    shareTokenRepo := repoShareToken.NewShareTokenRepository(db)
 
     // 認証機能
     userLogic := logic.NewUserLogic()
@@ -94,34 +182,92 @@ func SetupGraphQL(r *gin.Engine, db *gorm.DB) {
     // 認証サービスの初期化
     authService := authService.NewAuthService(userRepo, userLogic, responseLogic, jwtLogic, authValidation)
 
+    // 世帯メンバーの保有資産へのアクセス制御
+    householdAccess := commonHousehold.NewHouseholdAccess(householdRepo)
+
     // GraphQLサービス、リゾルバの初期化
     currencyService := currency.NewCurrencyService(currencyRepo)
     currencyResolver := currency.NewResolver(currencyService)
 
-    marketPriceService := marketPrice.NewMarketPriceService(marketPriceRepo)
+    marketPriceService := marketPrice.NewMarketPriceService(marketPriceRepo, priceHistoryRepo)
     marketPriceResolver := marketPrice.NewResolver(marketPriceService)
 
     userService := user.NewUserService(userRepo,authService)
     userResolver := user.NewResolver(userService)
     
-    usStockService := stock.NewUsStockService(usStockRepo, authService, marketPriceRepo)
+    usStockService := stock.NewUsStockService(usStockRepo, authService, marketPriceRepo, instrumentRepo, householdAccess)
     usStockResolver := stock.NewResolver(usStockService)
 
-    cryptoService := crypto.NewCryptoService(cryptoRepo, authService, marketCryptoRepo)
+    cryptoService := crypto.NewCryptoService(cryptoRepo, authService, marketCryptoRepo, householdAccess)
     cryptoResolver := crypto.NewResolver(cryptoService)
 
-    fixedIncomeAssetService := fixedIncomeAsset.NewAssetService(fixedIncomeAssetRepo, authService)
+    fixedIncomeAssetService := fixedIncomeAsset.NewAssetService(fixedIncomeAssetRepo, authService, householdAccess, currencyRepo)
     fixedIncomeAssetResolver := fixedIncomeAsset.NewResolver(fixedIncomeAssetService)
 
-    japanFundService := japanFund.NewJapanFundService(japanFundRepo, authService, fundPriceRepo)
+    japanFundService := japanFund.NewJapanFundService(japanFundRepo, authService, fundPriceRepo, householdAccess)
     japanFundResolver := japanFund.NewResolver(japanFundService)
 
-    totalAssetService := totalAsset.NewTotalAssetService(authService,totalAssetRepo, usStockRepo, marketPriceRepo, currencyRepo, japanFundRepo, cryptoRepo, fixedIncomeAssetRepo, marketCryptoRepo, fundPriceRepo)
+    totalAssetService := totalAsset.NewTotalAssetService(authService,totalAssetRepo, usStockRepo, marketPriceRepo, currencyRepo, japanFundRepo, cryptoRepo, fixedIncomeAssetRepo, marketCryptoRepo, fundPriceRepo, brokerageAccountRepo, householdAccess)
     totalAssetResolver := totalAsset.NewResolver(totalAssetService)
 
+    eventService := event.NewEventService(authService, usStockRepo, marketPriceRepo, calendarTokenRepo)
+    eventResolver := event.NewResolver(eventService)
+
+    watchlistService := watchlist.NewWatchlistService(watchlistRepo, authService, marketPriceRepo, usStockService)
+    watchlistResolver := watchlist.NewResolver(watchlistService)
+
+    alertService := alert.NewAlertService(alertRepo, authService)
+    alertResolver := alert.NewResolver(alertService)
+
+    notificationService := notification.NewNotificationService(notificationRepo, authService)
+    notificationResolver := notification.NewResolver(notificationService)
+
+    dailyReportService := dailyReport.NewDailyReportService(dailyReportRepo, authService)
+    dailyReportResolver := dailyReport.NewResolver(dailyReportService)
+
+    // 価格のポーリングは全ての購読者で共有する
+    pricePoller := subscription.NewPricePoller(marketPriceRepo, currencyRepo, quotePollInterval())
+    subscriptionService := subscription.NewSubscriptionService(pricePoller, usStockRepo, totalAssetRepo, authService)
+    subscriptionResolver := subscription.NewResolver(subscriptionService)
+
+    holdingImportService := holdingImport.NewHoldingImportService(holdingImportRepo, usStockRepo, japanFundRepo, cryptoRepo, fundPriceRepo, instrumentRepo, marketPriceRepo, currencyRepo, brokerageAccountRepo, authService)
+    holdingImportResolver := holdingImport.NewResolver(holdingImportService)
+
+    taxReportService := taxReport.NewTaxReportService(taxReportRepo, usStockRepo, marketPriceRepo, authService)
+    taxReportResolver := taxReport.NewResolver(taxReportService)
+
+    accountService := account.NewAccountService(userRepo, accountRepo, authService)
+    accountResolver := account.NewResolver(accountService)
+
+    deletedHoldingService := deletedHolding.NewDeletedHoldingService(deletedHoldingRepo, authService, housekeeping.RetentionFromEnv())
+    deletedHoldingResolver := deletedHolding.NewResolver(deletedHoldingService)
+
+    brokerageAccountService := brokerageAccount.NewBrokerageAccountService(brokerageAccountRepo, authService)
+    brokerageAccountResolver := brokerageAccount.NewResolver(brokerageAccountService)
+
+    nisaService := nisa.NewNisaService(nisaRepo, authService)
+    nisaResolver := nisa.NewResolver(nisaService)
+
+    householdService := household.NewHouseholdService(householdRepo, userRepo, authService)
+    householdResolver := household.NewResolver(householdService)
+
+    shareTokenService := shareToken.NewShareTokenService(shareTokenRepo, authService)
+    shareTokenResolver := shareToken.NewResolver(shareTokenService)
+
     // GraphQLエンドポイントへのルート設定
-    r.POST("/graphql", GinContextToGraphQLMiddleware(), Handler(userResolver, currencyResolver, marketPriceResolver,usStockResolver, cryptoResolver,fixedIncomeAssetResolver, japanFundResolver,totalAssetResolver))
-    r.GET("/graphql", PlaygroundHandler())
+    graphQLHandler := Handler(userResolver, currencyResolver, marketPriceResolver,usStockResolver, cryptoResolver,fixedIncomeAssetResolver, japanFundResolver,totalAssetResolver,eventResolver,watchlistResolver,alertResolver,notificationResolver,dailyReportResolver,subscriptionResolver,holdingImportResolver,taxReportResolver,accountResolver,deletedHoldingResolver,brokerageAccountResolver,nisaResolver,householdResolver,shareTokenResolver)
+    r.POST("/graphql", GinContextToGraphQLMiddleware(), graphQLHandler)
+    // SubscriptionのWebSocket接続もPlaygroundと同じURLで受け付ける
+    r.GET("/graphql", GinContextToGraphQLMiddleware(), WebsocketOrPlaygroundHandler(graphQLHandler))
+}
+
+// 価格のポーリング間隔(環境変数 QUOTE_POLL_INTERVAL_SECONDS、未設定の場合は15秒)
+func quotePollInterval() time.Duration {
+    seconds, err := strconv.Atoi(os.Getenv("QUOTE_POLL_INTERVAL_SECONDS"))
+    if err != nil || seconds <= 0 {
+        seconds = 15
+    }
+    return time.Duration(seconds) * time.Second
 }
 // Playgroundハンドラ関数
 func PlaygroundHandler() gin.HandlerFunc {
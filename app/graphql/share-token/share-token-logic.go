@@ -0,0 +1,19 @@
+package sharetoken
+
+import (
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	"time"
+)
+
+// モデルをGraphQLの型に変換する(トークンは作成時のみ指定する)
+func convertToShareToken(modelShareToken *model.ShareToken, token *string, now time.Time) *generated.ShareToken {
+    return &generated.ShareToken{
+        ID:        utils.ConvertIdToString(modelShareToken.ID),
+        Token:     token,
+        Privacy:   generated.SharePrivacy(modelShareToken.Privacy),
+        ExpiresAt: modelShareToken.ExpiresAt.Format(time.RFC3339),
+        Expired:   !modelShareToken.ExpiresAt.After(now),
+    }
+}
@@ -0,0 +1,26 @@
+package sharetoken
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+)
+
+type Resolver struct {
+    ShareTokenService ShareTokenService
+}
+
+func NewResolver(shareTokenService ShareTokenService) *Resolver {
+    return &Resolver{ShareTokenService: shareTokenService}
+}
+
+func (r *Resolver) ShareTokens(ctx context.Context) ([]*generated.ShareToken, error) {
+    return r.ShareTokenService.ShareTokens(ctx)
+}
+
+func (r *Resolver) CreateShareToken(ctx context.Context, input generated.CreateShareTokenInput) (*generated.ShareToken, error) {
+    return r.ShareTokenService.CreateShareToken(ctx, input)
+}
+
+func (r *Resolver) RevokeShareToken(ctx context.Context, id string) (bool, error) {
+    return r.ShareTokenService.RevokeShareToken(ctx, id)
+}
@@ -0,0 +1,73 @@
+package sharetoken
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockShareTokenService は ShareTokenService のモックです。
+type MockShareTokenService struct {
+    mock.Mock
+}
+
+func (m *MockShareTokenService) ShareTokens(ctx context.Context) ([]*generated.ShareToken, error) {
+    args := m.Called(ctx)
+    return args.Get(0).([]*generated.ShareToken), args.Error(1)
+}
+
+func (m *MockShareTokenService) CreateShareToken(ctx context.Context, input generated.CreateShareTokenInput) (*generated.ShareToken, error) {
+    args := m.Called(ctx, input)
+    return args.Get(0).(*generated.ShareToken), args.Error(1)
+}
+
+func (m *MockShareTokenService) RevokeShareToken(ctx context.Context, id string) (bool, error) {
+    args := m.Called(ctx, id)
+    return args.Bool(0), args.Error(1)
+}
+
+func TestShareTokensResolver(t *testing.T) {
+    mockService := new(MockShareTokenService)
+    resolver := NewResolver(mockService)
+
+    shareTokens := []*generated.ShareToken{{ID: "1", Privacy: generated.SharePrivacyPercentage}}
+    mockService.On("ShareTokens", mock.Anything).Return(shareTokens, nil)
+
+    result, err := resolver.ShareTokens(context.Background())
+
+    assert.NoError(t, err)
+    assert.Equal(t, shareTokens, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestCreateShareTokenResolver(t *testing.T) {
+    mockService := new(MockShareTokenService)
+    resolver := NewResolver(mockService)
+
+    input := generated.CreateShareTokenInput{Privacy: generated.SharePrivacyAmount, ExpiresInDays: 30}
+    token := "token"
+    shareToken := &generated.ShareToken{ID: "1", Token: &token, Privacy: generated.SharePrivacyAmount}
+    mockService.On("CreateShareToken", mock.Anything, input).Return(shareToken, nil)
+
+    result, err := resolver.CreateShareToken(context.Background(), input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, shareToken, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestRevokeShareTokenResolver(t *testing.T) {
+    mockService := new(MockShareTokenService)
+    resolver := NewResolver(mockService)
+
+    mockService.On("RevokeShareToken", mock.Anything, "1").Return(true, nil)
+
+    result, err := resolver.RevokeShareToken(context.Background(), "1")
+
+    assert.NoError(t, err)
+    assert.True(t, result)
+    mockService.AssertExpectations(t)
+}
@@ -0,0 +1,97 @@
+package sharetoken
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	repoShareToken "my-us-stock-backend/app/repository/share-token"
+	"time"
+)
+
+// 共有リンクの最大有効日数
+const maxShareTokenDays = 365
+
+// ShareTokenService インターフェースの定義
+type ShareTokenService interface {
+    ShareTokens(ctx context.Context) ([]*generated.ShareToken, error)
+    CreateShareToken(ctx context.Context, input generated.CreateShareTokenInput) (*generated.ShareToken, error)
+    RevokeShareToken(ctx context.Context, id string) (bool, error)
+}
+
+// DefaultShareTokenService 構造体の定義
+type DefaultShareTokenService struct {
+    ShareTokenRepo repoShareToken.ShareTokenRepository
+    Auth auth.AuthService
+}
+
+// NewShareTokenService は DefaultShareTokenService の新しいインスタンスを作成します
+func NewShareTokenService(shareTokenRepo repoShareToken.ShareTokenRepository, auth auth.AuthService) ShareTokenService {
+    return &DefaultShareTokenService{ShareTokenRepo: shareTokenRepo, Auth: auth}
+}
+
+// ShareTokens はログインユーザーが作成した共有リンクを新しい順に取得します
+func (s *DefaultShareTokenService) ShareTokens(ctx context.Context) ([]*generated.ShareToken, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    modelShareTokens, err := s.ShareTokenRepo.FetchShareTokenListByUserId(ctx, userId)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    now := time.Now()
+    shareTokens := make([]*generated.ShareToken, len(modelShareTokens))
+    for i := range modelShareTokens {
+        shareTokens[i] = convertToShareToken(&modelShareTokens[i], nil, now)
+    }
+    return shareTokens, nil
+}
+
+// CreateShareToken はポートフォリオを閲覧専用で公開する共有リンクを作成します(トークンを返却するのはこの時のみ)
+func (s *DefaultShareTokenService) CreateShareToken(ctx context.Context, input generated.CreateShareTokenInput) (*generated.ShareToken, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    if input.ExpiresInDays < 1 || input.ExpiresInDays > maxShareTokenDays {
+        return nil, utils.DefaultGraphQLError("有効日数は1日から365日の範囲で指定してください")
+    }
+    if !input.Privacy.IsValid() {
+        return nil, utils.DefaultGraphQLError("公開範囲が無効です")
+    }
+    bytes := make([]byte, 32)
+    if _, err := rand.Read(bytes); err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    now := time.Now()
+    token := hex.EncodeToString(bytes)
+    modelShareToken, err := s.ShareTokenRepo.CreateShareToken(ctx, repoShareToken.CreateShareTokenDto{
+        Token:     token,
+        Privacy:   input.Privacy.String(),
+        ExpiresAt: now.AddDate(0, 0, input.ExpiresInDays),
+        UserId:    userId,
+    })
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return convertToShareToken(modelShareToken, &token, now), nil
+}
+
+// RevokeShareToken は共有リンクを取り消します
+func (s *DefaultShareTokenService) RevokeShareToken(ctx context.Context, id string) (bool, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return false, utils.UnauthenticatedError("Invalid user ID")
+    }
+    revokeId, convertError := utils.ConvertIdToUint(id)
+    if convertError != nil || revokeId == 0 {
+        return false, utils.DefaultGraphQLError("入力された共有リンクidが無効です")
+    }
+    if err := s.ShareTokenRepo.DeleteShareToken(ctx, revokeId, userId); err != nil {
+        return false, utils.DefaultGraphQLError(err.Error())
+    }
+    return true, nil
+}
@@ -0,0 +1,111 @@
+package sharetoken
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	repoShareToken "my-us-stock-backend/app/repository/share-token"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type testMocks struct {
+    shareTokenRepo *repoShareToken.MockShareTokenRepository
+    auth           *auth.MockAuthService
+}
+
+func newTestService() (ShareTokenService, testMocks) {
+    mocks := testMocks{
+        shareTokenRepo: repoShareToken.NewMockShareTokenRepository(),
+        auth:           auth.NewMockAuthService(),
+    }
+    service := NewShareTokenService(mocks.shareTokenRepo, mocks.auth)
+    return service, mocks
+}
+
+func TestShareTokens(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    expiresAt := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+    expiredAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+    mocks.shareTokenRepo.On("FetchShareTokenListByUserId", mock.Anything, uint(1)).Return([]model.ShareToken{
+        {Model: gorm.Model{ID: 2}, TokenHash: "hash-2", Privacy: "AMOUNT", ExpiresAt: expiresAt, UserId: 1},
+        {Model: gorm.Model{ID: 1}, TokenHash: "hash-1", Privacy: "PERCENTAGE", ExpiresAt: expiredAt, UserId: 1},
+    }, nil)
+
+    shareTokens, err := service.ShareTokens(context.Background())
+
+    assert.NoError(t, err)
+    assert.Equal(t, []*generated.ShareToken{
+        // トークンはハッシュのみ保存しているため一覧では返さない
+        {ID: "2", Privacy: generated.SharePrivacyAmount, ExpiresAt: "2099-01-01T00:00:00Z", Expired: false},
+        {ID: "1", Privacy: generated.SharePrivacyPercentage, ExpiresAt: "2020-01-01T00:00:00Z", Expired: true},
+    }, shareTokens)
+}
+
+func TestShareTokensUnauthenticated(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(0), errors.New("invalid token"))
+
+    shareTokens, err := service.ShareTokens(context.Background())
+
+    assert.Nil(t, shareTokens)
+    assert.Error(t, err)
+    mocks.shareTokenRepo.AssertNotCalled(t, "FetchShareTokenListByUserId", mock.Anything, mock.Anything)
+}
+
+func TestCreateShareToken(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    var created repoShareToken.CreateShareTokenDto
+    mocks.shareTokenRepo.On("CreateShareToken", mock.Anything, mock.AnythingOfType("sharetoken.CreateShareTokenDto")).Run(func(args mock.Arguments) {
+        created = args.Get(1).(repoShareToken.CreateShareTokenDto)
+    }).Return(&model.ShareToken{Model: gorm.Model{ID: 1}, TokenHash: "hash", Privacy: "PERCENTAGE", ExpiresAt: time.Now().AddDate(0, 0, 7), UserId: 1}, nil)
+
+    shareToken, err := service.CreateShareToken(context.Background(), generated.CreateShareTokenInput{Privacy: generated.SharePrivacyPercentage, ExpiresInDays: 7})
+
+    assert.NoError(t, err)
+    assert.Equal(t, "1", shareToken.ID)
+    assert.False(t, shareToken.Expired)
+    assert.Len(t, created.Token, 64)
+    // 作成時のみトークンを返す
+    assert.Equal(t, created.Token, *shareToken.Token)
+    assert.Equal(t, "PERCENTAGE", created.Privacy)
+    assert.Equal(t, uint(1), created.UserId)
+    assert.WithinDuration(t, time.Now().AddDate(0, 0, 7), created.ExpiresAt, time.Minute)
+}
+
+func TestCreateShareTokenInvalidInput(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+
+    _, err := service.CreateShareToken(context.Background(), generated.CreateShareTokenInput{Privacy: generated.SharePrivacyAmount, ExpiresInDays: 0})
+    assert.EqualError(t, err, "input: 有効日数は1日から365日の範囲で指定してください")
+
+    _, err = service.CreateShareToken(context.Background(), generated.CreateShareTokenInput{Privacy: generated.SharePrivacyAmount, ExpiresInDays: 366})
+    assert.EqualError(t, err, "input: 有効日数は1日から365日の範囲で指定してください")
+
+    _, err = service.CreateShareToken(context.Background(), generated.CreateShareTokenInput{Privacy: "ALL", ExpiresInDays: 7})
+    assert.EqualError(t, err, "input: 公開範囲が無効です")
+    mocks.shareTokenRepo.AssertNotCalled(t, "CreateShareToken", mock.Anything, mock.Anything)
+}
+
+func TestRevokeShareToken(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    mocks.shareTokenRepo.On("DeleteShareToken", mock.Anything, uint(3), uint(1)).Return(nil)
+
+    result, err := service.RevokeShareToken(context.Background(), "3")
+
+    assert.NoError(t, err)
+    assert.True(t, result)
+
+    _, err = service.RevokeShareToken(context.Background(), "abc")
+    assert.EqualError(t, err, "input: 入力された共有リンクidが無効です")
+}
@@ -0,0 +1,136 @@
+package stock
+
+import (
+	"context"
+	"errors"
+	"log"
+	"my-us-stock-backend/app/database/model"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	"my-us-stock-backend/app/repository/market-price/instrument"
+	"sync"
+	"time"
+)
+
+const (
+	// 外部APIで企業情報が見つからなかった銘柄を再取得しない期間
+	instrumentNotFoundTTL = 24 * time.Hour
+	// 外部APIから企業情報を同時に取得する最大数
+	instrumentFetchConcurrency = 5
+)
+
+// 外部APIで企業情報が見つからなかった銘柄を一定期間記録し、リクエストのたびに再取得しないようにする
+type instrumentMissCache struct {
+	mu        sync.Mutex
+	expiresAt map[string]time.Time
+}
+
+func newInstrumentMissCache() *instrumentMissCache {
+	return &instrumentMissCache{expiresAt: map[string]time.Time{}}
+}
+
+// 見つからなかったことが記録されており、有効期限内かどうかを判定する
+func (c *instrumentMissCache) has(code string, now time.Time) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expiresAt, ok := c.expiresAt[code]
+	if !ok {
+		return false
+	}
+	if now.After(expiresAt) {
+		delete(c.expiresAt, code)
+		return false
+	}
+	return true
+}
+
+func (c *instrumentMissCache) add(code string, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expiresAt[code] = now.Add(instrumentNotFoundTTL)
+}
+
+// 銘柄の企業情報を取得する
+// キャッシュ(instrumentsテーブル)に存在しない場合は外部APIから取得して保存する
+// 取得に失敗した場合はnilを返却する(企業情報は補助的な情報のため、処理は継続させる)
+func findOrFetchInstrument(ctx context.Context, s *DefaultUsStockService, code string) *model.Instrument {
+	cached, err := s.InstrumentRepo.FindInstrumentByTicker(ctx, code)
+	if err == nil && cached != nil {
+		return cached
+	}
+	return fetchAndCacheInstrument(ctx, s, code)
+}
+
+// 複数銘柄の企業情報をティッカーをキーとしたマップで取得する
+func fetchInstrumentMap(ctx context.Context, s *DefaultUsStockService, codes []string) map[string]*model.Instrument {
+	instrumentMap := make(map[string]*model.Instrument, len(codes))
+	cachedInstruments, err := s.InstrumentRepo.FetchInstrumentListByTickers(ctx, codes)
+	if err != nil {
+		log.Printf("企業情報の取得に失敗しました: %v", err)
+	}
+	for _, cached := range cachedInstruments {
+		cachedCopy := cached
+		instrumentMap[cached.Ticker] = &cachedCopy
+	}
+	// キャッシュに存在しない銘柄のみ外部APIから取得する(同時に取得する数は制限する)
+	missingCodes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		if _, ok := instrumentMap[code]; !ok {
+			missingCodes = append(missingCodes, code)
+		}
+	}
+	fetched := make([]*model.Instrument, len(missingCodes))
+	semaphore := make(chan struct{}, instrumentFetchConcurrency)
+	var wg sync.WaitGroup
+	for i, code := range missingCodes {
+		wg.Add(1)
+		go func(i int, code string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			fetched[i] = fetchAndCacheInstrument(ctx, s, code)
+		}(i, code)
+	}
+	wg.Wait()
+	for i, code := range missingCodes {
+		if fetched[i] != nil {
+			instrumentMap[code] = fetched[i]
+		}
+	}
+	return instrumentMap
+}
+
+// 外部APIから企業情報を取得し、キャッシュに保存する
+// 企業情報が見つからなかった銘柄は一定期間外部APIを呼ばずにnilを返却する
+func fetchAndCacheInstrument(ctx context.Context, s *DefaultUsStockService, code string) *model.Instrument {
+	now := time.Now()
+	if s.instrumentMisses.has(code, now) {
+		return nil
+	}
+	profile, err := s.MarketPriceRepo.FetchCompanyProfile(ctx, code)
+	if err != nil {
+		if errors.Is(err, marketPrice.ErrCompanyProfileNotFound) {
+			s.instrumentMisses.add(code, now)
+		}
+		log.Printf("企業情報の取得に失敗しました(%s): %v", code, err)
+		return nil
+	}
+	created, err := s.InstrumentRepo.CreateInstrument(ctx, instrument.CreateInstrumentDto{
+		Ticker:   code,
+		Name:     profile.Name,
+		Sector:   profile.Sector,
+		Industry: profile.Industry,
+		Country:  profile.Country,
+		Exchange: profile.Exchange,
+	})
+	if err != nil {
+		log.Printf("企業情報の保存に失敗しました(%s): %v", code, err)
+		return nil
+	}
+	return created
+}
@@ -13,8 +13,8 @@ func NewResolver(usStockService UsStockService) *Resolver {
     return &Resolver{UsStockService: usStockService}
 }
 
-func (r *Resolver) UsStocks(ctx context.Context) ([]*generated.UsStock, error) {
-    return r.UsStockService.UsStocks(ctx)
+func (r *Resolver) UsStocks(ctx context.Context, accountId *string, householdId *string) ([]*generated.UsStock, error) {
+    return r.UsStockService.UsStocks(ctx, accountId, householdId)
 }
 
 func (r *Resolver) CreateUsStock(ctx context.Context, input generated.CreateUsStockInput) (*generated.UsStock, error) {
@@ -14,8 +14,8 @@ type MockUsStockService struct {
     mock.Mock
 }
 
-func (m *MockUsStockService) UsStocks(ctx context.Context) ([]*generated.UsStock, error) {
-    args := m.Called(ctx)
+func (m *MockUsStockService) UsStocks(ctx context.Context, accountId *string, householdId *string) ([]*generated.UsStock, error) {
+    args := m.Called(ctx, accountId, householdId)
     return args.Get(0).([]*generated.UsStock), args.Error(1)
 }
 
@@ -43,9 +43,9 @@ func TestUsStocks(t *testing.T) {
         {ID: "1",Code: "AAPL", GetPrice: 180.0, Dividend: 1.22, Quantity: 2, Sector: "IT", UsdJpy: 130.2,CurrentPrice: 189.84, PriceGets: 0.0685, CurrentRate: 0.13},
         {ID: "2",Code: "KO",  GetPrice: 50.0, Dividend: 1.22, Quantity: 2, Sector: "Consumer Staples", UsdJpy: 130.2,CurrentPrice: 57.205, PriceGets: 0.0962, CurrentRate: 0.055},
     }
-    mockService.On("UsStocks", mock.Anything).Return(usStocks, nil)
+    mockService.On("UsStocks", mock.Anything, (*string)(nil), (*string)(nil)).Return(usStocks, nil)
 
-    result, err := resolver.UsStocks(context.Background())
+    result, err := resolver.UsStocks(context.Background(), nil, nil)
     
     assert.NoError(t, err)
     assert.Equal(t, usStocks, result)
@@ -58,11 +58,12 @@ func TestCreateUsStock(t *testing.T) {
     mockService := new(MockUsStockService)
     resolver := NewResolver(mockService)
 
+	sector := "IT"
 	input := generated.CreateUsStockInput{
 		Code: "AAPL",
 		GetPrice: 180.0, 
 		Quantity: 2, 
-		Sector: "IT", 
+		Sector: &sector, 
 		UsdJpy: 130.2,
 
 	}
@@ -3,16 +3,18 @@ package stock
 import (
 	"context"
 	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/common/household"
 	"my-us-stock-backend/app/database/model"
 	"my-us-stock-backend/app/graphql/generated"
 	"my-us-stock-backend/app/graphql/utils"
 	"my-us-stock-backend/app/repository/assets/stock"
 	marketPrice "my-us-stock-backend/app/repository/market-price"
+	"my-us-stock-backend/app/repository/market-price/instrument"
 )
 
 // UsStockService インターフェースの定義
 type UsStockService interface {
-    UsStocks(ctx context.Context) ([]*generated.UsStock, error)
+    UsStocks(ctx context.Context, accountId *string, householdId *string) ([]*generated.UsStock, error)
 	CreateUsStock(ctx context.Context, input generated.CreateUsStockInput) (*generated.UsStock, error)
     UpdateUsStock(ctx context.Context, input generated.UpdateUsStockInput) (*generated.UsStock, error)
     DeleteUsStock(ctx context.Context, id string) (bool, error)
@@ -23,25 +25,49 @@ type DefaultUsStockService struct {
     StockRepo stock.UsStockRepository // インターフェースを利用
 	MarketPriceRepo marketPrice.MarketPriceRepository
     Auth auth.AuthService        // 認証サービスのインターフェース
+	InstrumentRepo instrument.InstrumentRepository
+    HouseholdAccess household.HouseholdAccess
+    instrumentMisses *instrumentMissCache // 企業情報が見つからなかった銘柄の記録
 }
 
 // NewUsStockService は DefaultUsStockService の新しいインスタンスを作成します
-func NewUsStockService(stockRepo stock.UsStockRepository, auth auth.AuthService, marketPriceRepo marketPrice.MarketPriceRepository) UsStockService {
-    return &DefaultUsStockService{StockRepo: stockRepo, Auth: auth, MarketPriceRepo: marketPriceRepo}
+func NewUsStockService(stockRepo stock.UsStockRepository, auth auth.AuthService, marketPriceRepo marketPrice.MarketPriceRepository, instrumentRepo instrument.InstrumentRepository, householdAccess household.HouseholdAccess) UsStockService {
+    return &DefaultUsStockService{StockRepo: stockRepo, Auth: auth, MarketPriceRepo: marketPriceRepo, InstrumentRepo: instrumentRepo, HouseholdAccess: householdAccess, instrumentMisses: newInstrumentMissCache()}
 }
 
 // UsStocks はユーザーの米国株式情報リストを取得します
-func (s *DefaultUsStockService) UsStocks(ctx context.Context) ([]*generated.UsStock, error) {
+func (s *DefaultUsStockService) UsStocks(ctx context.Context, accountId *string, householdId *string) ([]*generated.UsStock, error) {
     // アクセストークンの検証（コメントアウトされている部分は必要に応じて実装してください）
     userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
     if userId == 0 {
         return nil, utils.UnauthenticatedError("Invalid user ID")
     }
+    filterAccountId, convertError := utils.ConvertNullableIdToUint(accountId)
+    if convertError != nil {
+        return nil, utils.DefaultGraphQLError("入力された口座idが無効です")
+    }
 
-    modelStocks, err := s.StockRepo.FetchUsStockListById(ctx, userId)
+    // 世帯の指定がある場合は世帯の全メンバーの保有資産を対象にする
+    scopeUserIds, err := s.HouseholdAccess.ScopeUserIds(ctx, userId, householdId)
     if err != nil {
         return nil, utils.DefaultGraphQLError(err.Error())
     }
+    var modelStocks []model.UsStock
+    for _, scopeUserId := range scopeUserIds {
+        userStocks, err := s.StockRepo.FetchUsStockListById(ctx, scopeUserId)
+        if err != nil {
+            return nil, utils.DefaultGraphQLError(err.Error())
+        }
+        modelStocks = append(modelStocks, userStocks...)
+    }
+    // 口座の指定がある場合はその口座の保有資産のみ対象にする
+    filteredStocks := make([]model.UsStock, 0, len(modelStocks))
+    for _, modelStock := range modelStocks {
+        if utils.InAccount(modelStock.AccountId, filterAccountId) {
+            filteredStocks = append(filteredStocks, modelStock)
+        }
+    }
+    modelStocks = filteredStocks
     // modelStocksが空の場合は空の配列を返却する
 	if len(modelStocks) == 0 {
 		return []*generated.UsStock{}, nil
@@ -56,6 +82,8 @@ func (s *DefaultUsStockService) UsStocks(ctx context.Context) ([]*generated.UsSt
     if err != nil {
         return nil, utils.DefaultGraphQLError(err.Error())
     }
+    // 企業情報取得
+    instrumentMap := fetchInstrumentMap(ctx, s, usStockCodes)
     type stockWithMarketPrice struct {
         stock      *model.UsStock
         marketPrice *marketPrice.MarketPriceDto
@@ -107,10 +135,17 @@ func (s *DefaultUsStockService) UsStocks(ctx context.Context) ([]*generated.UsSt
                 priceGets = 0.0
                 currentRate = 0.0
             }
+            // 会社名が取得できない場合は空文字とする
+            var name string
+            if instrument, ok := instrumentMap[result.stock.Code]; ok {
+                name = instrument.Name
+            }
     
             usStocks[i] = &generated.UsStock{
                 ID:           utils.ConvertIdToString(result.stock.ID),
+                AccountID:    utils.ConvertIdToString(result.stock.AccountId),
                 Code:         result.stock.Code,
+                Name:         name,
                 GetPrice:     result.stock.GetPrice,
                 Dividend:     result.dividend.DividendTotal,
                 Quantity:     result.stock.Quantity,
@@ -120,6 +155,10 @@ func (s *DefaultUsStockService) UsStocks(ctx context.Context) ([]*generated.UsSt
                 PriceGets:    priceGets,
                 CurrentRate:  currentRate,
             }
+            // 市場価格が取得できた場合は株価指標を追加
+            if result.marketPrice != nil {
+                setQuoteDetail(usStocks[i], result.marketPrice)
+            }
         case err := <-errChan:
             return nil, utils.DefaultGraphQLError(err.Error())
         }
@@ -137,13 +176,32 @@ func (s *DefaultUsStockService) CreateUsStock(ctx context.Context, input generat
         return nil, utils.UnauthenticatedError("Invalid user ID")
     }
 	
+	// 企業情報取得(セクターは表記揺れを防ぐため企業情報の値を優先する)
+	var name, sector string
+	if input.Sector != nil {
+		sector = *input.Sector
+	}
+	if instrument := findOrFetchInstrument(ctx, s, input.Code); instrument != nil {
+		name = instrument.Name
+		if instrument.Sector != "" {
+			sector = instrument.Sector
+		}
+	}
+	if sector == "" {
+		return nil, utils.DefaultGraphQLError("セクターを取得できませんでした。セクターを入力してください")
+	}
+    createAccountId, convertError := utils.ConvertNullableIdToUint(input.AccountID)
+    if convertError != nil {
+        return nil, utils.DefaultGraphQLError("入力された口座idが無効です")
+    }
 	// 値入れ直し
 	createDto := stock.CreateUsStockDto{
 		Code: input.Code,
 		GetPrice: input.GetPrice,
 		Quantity: input.Quantity,
 		UsdJpy: input.UsdJpy,
-		Sector: input.Sector,
+		Sector: sector,
+		AccountId: createAccountId,
 		UserId: userId,
 	}
 
@@ -164,9 +222,11 @@ func (s *DefaultUsStockService) CreateUsStock(ctx context.Context, input generat
         return nil, utils.DefaultGraphQLError(err.Error())
     }
 	// 市場情報を追加して返却
-	return &generated.UsStock{
+	usStock := &generated.UsStock{
         ID: utils.ConvertIdToString(modelStock.ID),
+        AccountID: utils.ConvertIdToString(modelStock.AccountId),
 		Code: modelStock.Code,
+		Name: name,
 		GetPrice: modelStock.GetPrice,
 		Dividend: dividend.DividendTotal,
 		Quantity:     modelStock.Quantity,
@@ -175,7 +235,9 @@ func (s *DefaultUsStockService) CreateUsStock(ctx context.Context, input generat
 		CurrentPrice: marketPrices[0].CurrentPrice,
 		PriceGets:    marketPrices[0].PriceGets,
 		CurrentRate:  marketPrices[0].CurrentRate,
-	}, err
+	}
+	setQuoteDetail(usStock, &marketPrices[0])
+	return usStock, err
 }
 
 // ユーザーの米国株式情報を新規作成します
@@ -199,6 +261,10 @@ func (s *DefaultUsStockService) UpdateUsStock(ctx context.Context, input generat
         UsdJpy: &input.UsdJpy,
 	}
 
+    // 他のユーザーの保有資産は世帯で編集者以上の権限がある場合のみ更新できる
+    if err := s.HouseholdAccess.AuthorizeEdit(ctx, userId, &model.UsStock{}, updateId); err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
     modelStock, err := s.StockRepo.UpdateUsStock(ctx, updateDto)
     if err != nil {
         return nil, utils.DefaultGraphQLError(err.Error())
@@ -213,11 +279,18 @@ func (s *DefaultUsStockService) UpdateUsStock(ctx context.Context, input generat
     dividend, err := s.MarketPriceRepo.FetchDividend(ctx, modelStock.Code)
     if err != nil {
         return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    // 企業情報取得
+    var name string
+    if instrument := findOrFetchInstrument(ctx, s, modelStock.Code); instrument != nil {
+        name = instrument.Name
     }
 	// 市場情報を追加して返却
-	return &generated.UsStock{
+	usStock := &generated.UsStock{
         ID: utils.ConvertIdToString(modelStock.ID),
+        AccountID: utils.ConvertIdToString(modelStock.AccountId),
 		Code: modelStock.Code,
+		Name: name,
 		GetPrice: modelStock.GetPrice,
 		Dividend: dividend.DividendTotal,
 		Quantity:     modelStock.Quantity,
@@ -226,7 +299,9 @@ func (s *DefaultUsStockService) UpdateUsStock(ctx context.Context, input generat
 		CurrentPrice: marketPrices[0].CurrentPrice,
 		PriceGets:    marketPrices[0].PriceGets,
 		CurrentRate:  marketPrices[0].CurrentRate,
-	}, err
+	}
+	setQuoteDetail(usStock, &marketPrices[0])
+	return usStock, err
 }
 
 // 削除
@@ -242,10 +317,29 @@ func (s *DefaultUsStockService) DeleteUsStock(ctx context.Context, id string) (b
     if convertError != nil || deleteId == 0 {
         return false, utils.DefaultGraphQLError("入力されたidが無効です")
        }
+    // 他のユーザーの保有資産は世帯で編集者以上の権限がある場合のみ削除できる
+    if err := s.HouseholdAccess.AuthorizeEdit(ctx, userId, &model.UsStock{}, deleteId); err != nil {
+        return false, utils.DefaultGraphQLError(err.Error())
+    }
     var err = s.StockRepo.DeleteUsStock(ctx, deleteId)
 	// 市場情報を追加して返却
     if err != nil {
      return false, utils.DefaultGraphQLError(err.Error())
     }
 	return true, nil
+}
+
+// 株価指標(高値・安値、PER、EPSなど)を米国株情報に設定します
+func setQuoteDetail(usStock *generated.UsStock, mp *marketPrice.MarketPriceDto) {
+    usStock.DayLow = mp.DayLow
+    usStock.DayHigh = mp.DayHigh
+    usStock.YearHigh = mp.YearHigh
+    usStock.YearLow = mp.YearLow
+    usStock.MarketCap = mp.MarketCap
+    usStock.Pe = mp.Pe
+    usStock.Eps = mp.Eps
+    usStock.Volume = mp.Volume
+    usStock.PriceAvg50 = mp.PriceAvg50
+    usStock.PriceAvg200 = mp.PriceAvg200
+    usStock.EarningsAnnouncement = utils.ConvertDateToNullable(mp.EarningsAnnouncement)
 }
\ No newline at end of file
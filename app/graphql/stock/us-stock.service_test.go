@@ -2,11 +2,14 @@ package stock
 
 import (
 	"context"
+	"my-us-stock-backend/app/common/household"
+	"errors"
 	"my-us-stock-backend/app/common/auth"
 	"my-us-stock-backend/app/database/model"
 	"my-us-stock-backend/app/graphql/generated"
 	"my-us-stock-backend/app/repository/assets/stock"
 	marketPrice "my-us-stock-backend/app/repository/market-price"
+	"my-us-stock-backend/app/repository/market-price/instrument"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -19,11 +22,14 @@ func TestUsStocksService(t *testing.T) {
 	mockStockRepo := stock.NewMockUsStockRepository()
     mockMarketPriceRepo := marketPrice.NewMockMarketPriceRepository()
 	mockAuth := auth.NewMockAuthService()
-	service := NewUsStockService(mockStockRepo, mockAuth, mockMarketPriceRepo)
+	mockInstrumentRepo := instrument.NewMockInstrumentRepository()
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewUsStockService(mockStockRepo, mockAuth, mockMarketPriceRepo, mockInstrumentRepo, mockHouseholdAccess)
 
 	// モックの期待値設定
 	userId := uint(1)
 	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+	mockHouseholdAccess.On("ScopeUserIds", mock.Anything, userId, (*string)(nil)).Return([]uint{userId}, nil)
 
 	mockStocks := []model.UsStock{
 		{Code: "AAPL", GetPrice: 150, Quantity: 10, Sector: "IT"},
@@ -38,14 +44,20 @@ func TestUsStocksService(t *testing.T) {
 	mockDividend := &marketPrice.DividendEntity{DividendTotal: 1.5}
 	mockMarketPriceRepo.On("FetchDividend", mock.Anything, "AAPL").Return(mockDividend, nil)
 
+	mockInstruments := []model.Instrument{
+		{Ticker: "AAPL", Name: "Apple Inc.", Sector: "Technology"},
+	}
+	mockInstrumentRepo.On("FetchInstrumentListByTickers", mock.Anything, []string{"AAPL"}).Return(mockInstruments, nil)
+
 	// テスト対象メソッドの実行
-	usStocks, err := service.UsStocks(context.Background())
+	usStocks, err := service.UsStocks(context.Background(), nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, usStocks)
 	assert.Len(t, usStocks, 1)
 
 	assert.Equal(t, "0", usStocks[0].ID)
 	assert.Equal(t, "AAPL", usStocks[0].Code)
+	assert.Equal(t, "Apple Inc.", usStocks[0].Name)
 	assert.Equal(t, 150.0, usStocks[0].GetPrice)
 	assert.Equal(t, 10.0, usStocks[0].Quantity)
 	assert.Equal(t, "IT", usStocks[0].Sector)
@@ -60,12 +72,40 @@ func TestUsStocksService(t *testing.T) {
 	mockAuth.AssertExpectations(t)
 }
 
+// 企業情報が見つからなかった銘柄は一覧を取得するたびに外部APIを呼ばない
+func TestUsStocksServiceCachesNotFoundInstrument(t *testing.T) {
+	mockStockRepo := stock.NewMockUsStockRepository()
+	mockMarketPriceRepo := marketPrice.NewMockMarketPriceRepository()
+	mockAuth := auth.NewMockAuthService()
+	mockInstrumentRepo := instrument.NewMockInstrumentRepository()
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewUsStockService(mockStockRepo, mockAuth, mockMarketPriceRepo, mockInstrumentRepo, mockHouseholdAccess)
+
+	userId := uint(1)
+	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+	mockHouseholdAccess.On("ScopeUserIds", mock.Anything, userId, (*string)(nil)).Return([]uint{userId}, nil)
+	mockStockRepo.On("FetchUsStockListById", mock.Anything, userId).Return([]model.UsStock{{Code: "UNKN", GetPrice: 10, Quantity: 1, Sector: "IT"}}, nil)
+	mockMarketPriceRepo.On("FetchMarketPriceList", mock.Anything, []string{"UNKN"}).Return([]marketPrice.MarketPriceDto{{Ticker: "UNKN", CurrentPrice: 11}}, nil)
+	mockMarketPriceRepo.On("FetchDividend", mock.Anything, "UNKN").Return(&marketPrice.DividendEntity{}, nil)
+	mockInstrumentRepo.On("FetchInstrumentListByTickers", mock.Anything, []string{"UNKN"}).Return([]model.Instrument{}, nil)
+	mockMarketPriceRepo.On("FetchCompanyProfile", mock.Anything, "UNKN").Return((*marketPrice.CompanyProfileDto)(nil), marketPrice.ErrCompanyProfileNotFound)
+
+	for i := 0; i < 2; i++ {
+		usStocks, err := service.UsStocks(context.Background(), nil, nil)
+		assert.NoError(t, err)
+		assert.Len(t, usStocks, 1)
+	}
+	mockMarketPriceRepo.AssertNumberOfCalls(t, "FetchCompanyProfile", 1)
+}
+
 // TestCreateUsStockService は TestCreateUsStock メソッドのテストです。
 func TestCreateUsStockService(t *testing.T) {
 	mockStockRepo := stock.NewMockUsStockRepository()
     mockMarketPriceRepo := marketPrice.NewMockMarketPriceRepository()
 	mockAuth := auth.NewMockAuthService()
-	service := NewUsStockService(mockStockRepo, mockAuth, mockMarketPriceRepo)
+	mockInstrumentRepo := instrument.NewMockInstrumentRepository()
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewUsStockService(mockStockRepo, mockAuth, mockMarketPriceRepo, mockInstrumentRepo, mockHouseholdAccess)
 
 	// モックの期待値設定
 	userId := uint(1)
@@ -91,12 +131,17 @@ func TestCreateUsStockService(t *testing.T) {
 	mockDividend := &marketPrice.DividendEntity{DividendTotal: 1.5}
 	mockMarketPriceRepo.On("FetchDividend", mock.Anything, "AAPL").Return(mockDividend, nil)
 
+	// 企業情報が取得できない場合は入力されたセクターを利用する
+	mockInstrumentRepo.On("FindInstrumentByTicker", mock.Anything, "AAPL").Return((*model.Instrument)(nil), gorm.ErrRecordNotFound)
+	mockMarketPriceRepo.On("FetchCompanyProfile", mock.Anything, "AAPL").Return((*marketPrice.CompanyProfileDto)(nil), errors.New("the specified ticker was not found"))
+
 	// テスト対象メソッドの実行
+	sector := "IT"
 	serviceInput := generated.CreateUsStockInput{
 		Code: "AAPL",
 		GetPrice: 150, 
 		Quantity: 10, 
-		Sector: "IT",
+		Sector: &sector,
 		UsdJpy: 133.0,
 	}
 	usStock, err := service.CreateUsStock(context.Background(), serviceInput)
@@ -119,16 +164,106 @@ func TestCreateUsStockService(t *testing.T) {
 	mockAuth.AssertExpectations(t)
 }
 
+// TestCreateUsStockServiceWithCompanyProfile は企業情報からセクター・会社名が自動設定されることのテストです。
+func TestCreateUsStockServiceWithCompanyProfile(t *testing.T) {
+	mockStockRepo := stock.NewMockUsStockRepository()
+	mockMarketPriceRepo := marketPrice.NewMockMarketPriceRepository()
+	mockAuth := auth.NewMockAuthService()
+	mockInstrumentRepo := instrument.NewMockInstrumentRepository()
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewUsStockService(mockStockRepo, mockAuth, mockMarketPriceRepo, mockInstrumentRepo, mockHouseholdAccess)
+
+	// モックの期待値設定
+	userId := uint(1)
+	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+
+	// キャッシュに存在しないため外部APIから取得して保存する
+	mockInstrumentRepo.On("FindInstrumentByTicker", mock.Anything, "AAPL").Return((*model.Instrument)(nil), gorm.ErrRecordNotFound)
+	mockProfile := &marketPrice.CompanyProfileDto{Ticker: "AAPL", Name: "Apple Inc.", Sector: "Technology", Industry: "Consumer Electronics", Country: "US", Exchange: "NASDAQ"}
+	mockMarketPriceRepo.On("FetchCompanyProfile", mock.Anything, "AAPL").Return(mockProfile, nil)
+	createInstrumentDto := instrument.CreateInstrumentDto{Ticker: "AAPL", Name: "Apple Inc.", Sector: "Technology", Industry: "Consumer Electronics", Country: "US", Exchange: "NASDAQ"}
+	mockInstrumentRepo.On("CreateInstrument", mock.Anything, createInstrumentDto).Return(&model.Instrument{Ticker: "AAPL", Name: "Apple Inc.", Sector: "Technology"}, nil)
+
+	// 入力されたセクターではなく企業情報のセクターで登録される
+	input := stock.CreateUsStockDto{
+		Code: "AAPL",
+		GetPrice: 150,
+		Quantity: 10,
+		Sector: "Technology",
+		UsdJpy: 133.0,
+		UserId: 1,
+	}
+	mockStock := &model.UsStock{Code: "AAPL", GetPrice: 150, Quantity: 10, Sector: "Technology", UsdJpy: 133.0}
+	mockStockRepo.On("CreateUsStock", mock.Anything, input).Return(mockStock, nil)
+
+	mockMarketPrices := []marketPrice.MarketPriceDto{
+		{Ticker: "AAPL", CurrentPrice: 155, PriceGets: 5, CurrentRate: 0.0333},
+	}
+	mockMarketPriceRepo.On("FetchMarketPriceList", mock.Anything, []string{"AAPL"}).Return(mockMarketPrices, nil)
+	mockMarketPriceRepo.On("FetchDividend", mock.Anything, "AAPL").Return(&marketPrice.DividendEntity{DividendTotal: 1.5}, nil)
+
+	// テスト対象メソッドの実行
+	sector := "IT"
+	serviceInput := generated.CreateUsStockInput{
+		Code: "AAPL",
+		GetPrice: 150,
+		Quantity: 10,
+		Sector: &sector,
+		UsdJpy: 133.0,
+	}
+	usStock, err := service.CreateUsStock(context.Background(), serviceInput)
+	assert.NoError(t, err)
+	assert.Equal(t, "Apple Inc.", usStock.Name)
+	assert.Equal(t, "Technology", usStock.Sector)
+
+	// モックの呼び出しを検証
+	mockStockRepo.AssertExpectations(t)
+	mockMarketPriceRepo.AssertExpectations(t)
+	mockInstrumentRepo.AssertExpectations(t)
+}
+
+// セクターが入力されておらず、企業情報も取得できない場合はエラーとなる
+func TestCreateUsStockServiceNoSector(t *testing.T) {
+	mockStockRepo := stock.NewMockUsStockRepository()
+	mockMarketPriceRepo := marketPrice.NewMockMarketPriceRepository()
+	mockAuth := auth.NewMockAuthService()
+	mockInstrumentRepo := instrument.NewMockInstrumentRepository()
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewUsStockService(mockStockRepo, mockAuth, mockMarketPriceRepo, mockInstrumentRepo, mockHouseholdAccess)
+
+	// モックの期待値設定
+	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+	mockInstrumentRepo.On("FindInstrumentByTicker", mock.Anything, "AAPL").Return((*model.Instrument)(nil), gorm.ErrRecordNotFound)
+	mockMarketPriceRepo.On("FetchCompanyProfile", mock.Anything, "AAPL").Return((*marketPrice.CompanyProfileDto)(nil), errors.New("the specified ticker was not found"))
+
+	// テスト対象メソッドの実行
+	serviceInput := generated.CreateUsStockInput{
+		Code: "AAPL",
+		GetPrice: 150,
+		Quantity: 10,
+		UsdJpy: 133.0,
+	}
+	_, err := service.CreateUsStock(context.Background(), serviceInput)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "セクターを取得できませんでした")
+
+	// 登録処理は呼ばれない
+	mockStockRepo.AssertNotCalled(t, "CreateUsStock", mock.Anything, mock.Anything)
+}
+
 // TestUpdateUsStockService は UpdateUsStock メソッドのテストです。
 func TestUpdateUsStockService(t *testing.T) {
 	mockStockRepo := stock.NewMockUsStockRepository()
 	mockMarketPriceRepo := marketPrice.NewMockMarketPriceRepository()
 	mockAuth := auth.NewMockAuthService()
-	service := NewUsStockService(mockStockRepo, mockAuth, mockMarketPriceRepo)
+	mockInstrumentRepo := instrument.NewMockInstrumentRepository()
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewUsStockService(mockStockRepo, mockAuth, mockMarketPriceRepo, mockInstrumentRepo, mockHouseholdAccess)
 
 	// モックの期待値設定
 	userId := uint(1)
 	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+	mockHouseholdAccess.On("AuthorizeEdit", mock.Anything, userId, mock.Anything, mock.Anything).Return(nil)
 
 	updateInput := stock.UpdateUsStockDto{
 		ID:        1,
@@ -160,6 +295,9 @@ func TestUpdateUsStockService(t *testing.T) {
 	mockDividend := &marketPrice.DividendEntity{DividendTotal: 2.0}
 	mockMarketPriceRepo.On("FetchDividend", mock.Anything, "AAPL").Return(mockDividend, nil)
 
+	mockInstrument := &model.Instrument{Ticker: "AAPL", Name: "Apple Inc.", Sector: "Technology"}
+	mockInstrumentRepo.On("FindInstrumentByTicker", mock.Anything, "AAPL").Return(mockInstrument, nil)
+
 	// テスト対象メソッドの実行
 	serviceInput := generated.UpdateUsStockInput{
 		ID:        "1",
@@ -173,6 +311,7 @@ func TestUpdateUsStockService(t *testing.T) {
 
 	assert.Equal(t, "1", updatedUsStock.ID)
 	assert.Equal(t, "AAPL", updatedUsStock.Code)
+	assert.Equal(t, "Apple Inc.", updatedUsStock.Name)
 	assert.Equal(t, 160.0, updatedUsStock.GetPrice)
 	assert.Equal(t, 20.0, updatedUsStock.Quantity)
 	assert.Equal(t, "IT", updatedUsStock.Sector)
@@ -194,11 +333,14 @@ func TestDeleteUsStockService(t *testing.T) {
 	mockStockRepo := stock.NewMockUsStockRepository()
     mockMarketPriceRepo := marketPrice.NewMockMarketPriceRepository()
 	mockAuth := auth.NewMockAuthService()
-	service := NewUsStockService(mockStockRepo, mockAuth, mockMarketPriceRepo)
+	mockInstrumentRepo := instrument.NewMockInstrumentRepository()
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewUsStockService(mockStockRepo, mockAuth, mockMarketPriceRepo, mockInstrumentRepo, mockHouseholdAccess)
 
 	// モックの期待値設定
 	userId := uint(1)
 	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+	mockHouseholdAccess.On("AuthorizeEdit", mock.Anything, userId, mock.Anything, mock.Anything).Return(nil)
 
 	// 成功時のテスト
 	stockID := uint(1)
@@ -0,0 +1,25 @@
+package graphql
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/subscription"
+)
+
+// CustomSubscriptionResolver は SubscriptionResolver インターフェースを実装します
+type CustomSubscriptionResolver struct {
+	SubscriptionResolver *subscription.Resolver
+}
+
+// Subscriptionメソッドの実装
+func (r *CustomSubscriptionResolver) Subscription() generated.SubscriptionResolver {
+	return r
+}
+
+func (r *CustomSubscriptionResolver) PortfolioValue(ctx context.Context) (<-chan *generated.PortfolioValue, error) {
+	return r.SubscriptionResolver.PortfolioValue(ctx)
+}
+
+func (r *CustomSubscriptionResolver) Quotes(ctx context.Context, tickers []string) (<-chan []*generated.MarketPrice, error) {
+	return r.SubscriptionResolver.Quotes(ctx, tickers)
+}
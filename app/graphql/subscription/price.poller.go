@@ -0,0 +1,158 @@
+package subscription
+
+import (
+	"context"
+	"log"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	"my-us-stock-backend/app/repository/market-price/currency"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PriceUpdate は1回のポーリングで取得した価格情報です
+type PriceUpdate struct {
+    Quotes    map[string]marketPrice.MarketPriceDto
+    UsdJpy    float64
+    UpdatedAt time.Time
+}
+
+// 購読者ごとの配信先
+type priceSubscriber struct {
+    tickers []string
+    updates chan PriceUpdate
+}
+
+// PricePoller は全購読者が必要とする銘柄の価格とドル円をまとめて定期取得し、各購読者へ配信します。
+// 購読者が何人いても1回のポーリングでの外部APIの呼び出しは1セットのみで、購読者がいない間は停止します。
+type PricePoller struct {
+    MarketPriceRepo marketPrice.MarketPriceRepository
+    CurrencyRepo    currency.CurrencyRepository
+    Interval        time.Duration
+
+    mu          sync.Mutex
+    subscribers map[*priceSubscriber]struct{}
+    refresh     chan struct{}
+    stop        context.CancelFunc
+}
+
+// NewPricePoller は PricePoller の新しいインスタンスを作成します
+func NewPricePoller(marketPriceRepo marketPrice.MarketPriceRepository, currencyRepo currency.CurrencyRepository, interval time.Duration) *PricePoller {
+    return &PricePoller{
+        MarketPriceRepo: marketPriceRepo,
+        CurrencyRepo:    currencyRepo,
+        Interval:        interval,
+        subscribers:     make(map[*priceSubscriber]struct{}),
+    }
+}
+
+// Subscribe は指定した銘柄の価格更新の購読を開始します(ctxが終了すると購読を解除しチャネルを閉じます)
+func (p *PricePoller) Subscribe(ctx context.Context, tickers []string) <-chan PriceUpdate {
+    subscriber := &priceSubscriber{tickers: tickers, updates: make(chan PriceUpdate, 1)}
+
+    p.mu.Lock()
+    p.subscribers[subscriber] = struct{}{}
+    if p.stop == nil {
+        pollCtx, stop := context.WithCancel(context.Background())
+        p.stop = stop
+        p.refresh = make(chan struct{}, 1)
+        go p.run(pollCtx, p.refresh)
+    }
+    // 新しい購読者にすぐ価格を届けるため、次のポーリングを前倒しする
+    select {
+    case p.refresh <- struct{}{}:
+    default:
+    }
+    p.mu.Unlock()
+
+    go func() {
+        <-ctx.Done()
+        p.unsubscribe(subscriber)
+    }()
+    return subscriber.updates
+}
+
+// SubscriberCount は現在の購読者数を返します
+func (p *PricePoller) SubscriberCount() int {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    return len(p.subscribers)
+}
+
+func (p *PricePoller) unsubscribe(subscriber *priceSubscriber) {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    delete(p.subscribers, subscriber)
+    close(subscriber.updates)
+    // 購読者がいなくなったらポーリングを停止する
+    if len(p.subscribers) == 0 && p.stop != nil {
+        p.stop()
+        p.stop = nil
+    }
+}
+
+func (p *PricePoller) run(ctx context.Context, refresh <-chan struct{}) {
+    ticker := time.NewTicker(p.Interval)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-refresh:
+        case <-ticker.C:
+        }
+        p.poll(ctx)
+    }
+}
+
+// 全購読者の銘柄をまとめて価格を取得し、配信する
+func (p *PricePoller) poll(ctx context.Context) {
+    tickers := p.subscribedTickers()
+
+    update := PriceUpdate{Quotes: make(map[string]marketPrice.MarketPriceDto, len(tickers)), UpdatedAt: time.Now()}
+    if len(tickers) != 0 {
+        quotes, err := p.MarketPriceRepo.FetchMarketPriceList(ctx, tickers)
+        if err != nil {
+            log.Printf("価格の取得に失敗しました: %v", err)
+            return
+        }
+        for _, quote := range quotes {
+            update.Quotes[quote.Ticker] = quote
+        }
+    }
+    usdJpy, err := p.CurrencyRepo.FetchCurrentUsdJpy(ctx)
+    if err != nil {
+        log.Printf("ドル円の取得に失敗しました: %v", err)
+        return
+    }
+    update.UsdJpy = usdJpy
+
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    for subscriber := range p.subscribers {
+        // 購読者が前回の更新を受け取っていない場合は最新の更新に置き換える
+        select {
+        case <-subscriber.updates:
+        default:
+        }
+        subscriber.updates <- update
+    }
+}
+
+// 全購読者が必要とする銘柄を重複なく並べる
+func (p *PricePoller) subscribedTickers() []string {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    set := make(map[string]struct{})
+    for subscriber := range p.subscribers {
+        for _, ticker := range subscriber.tickers {
+            set[ticker] = struct{}{}
+        }
+    }
+    tickers := make([]string, 0, len(set))
+    for ticker := range set {
+        tickers = append(tickers, ticker)
+    }
+    sort.Strings(tickers)
+    return tickers
+}
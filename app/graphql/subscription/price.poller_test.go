@@ -0,0 +1,103 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	"my-us-stock-backend/app/repository/market-price/currency"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// 購読者が複数いても1回のポーリングでの価格取得は1回にまとめる
+func TestPricePollerPollFanOut(t *testing.T) {
+    mockMarketPriceRepo := marketPrice.NewMockMarketPriceRepository()
+    mockCurrencyRepo := currency.NewMockCurrencyRepository()
+    poller := NewPricePoller(mockMarketPriceRepo, mockCurrencyRepo, time.Hour)
+
+    subscribers := []*priceSubscriber{
+        {tickers: []string{"AAPL", "KO"}, updates: make(chan PriceUpdate, 1)},
+        {tickers: []string{"KO", "MSFT"}, updates: make(chan PriceUpdate, 1)},
+        {tickers: []string{"AAPL"}, updates: make(chan PriceUpdate, 1)},
+    }
+    for _, subscriber := range subscribers {
+        poller.subscribers[subscriber] = struct{}{}
+    }
+    mockMarketPriceRepo.On("FetchMarketPriceList", mock.Anything, []string{"AAPL", "KO", "MSFT"}).Return([]marketPrice.MarketPriceDto{
+        {Ticker: "AAPL", CurrentPrice: 180}, {Ticker: "KO", CurrentPrice: 58}, {Ticker: "MSFT", CurrentPrice: 400},
+    }, nil)
+    mockCurrencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(150.0, nil)
+
+    poller.poll(context.Background())
+
+    mockMarketPriceRepo.AssertNumberOfCalls(t, "FetchMarketPriceList", 1)
+    mockCurrencyRepo.AssertNumberOfCalls(t, "FetchCurrentUsdJpy", 1)
+    for _, subscriber := range subscribers {
+        update := <-subscriber.updates
+        assert.Equal(t, 150.0, update.UsdJpy)
+        assert.Len(t, update.Quotes, 3)
+    }
+}
+
+// 受け取られていない更新は最新の更新に置き換える
+func TestPricePollerPollReplacesStaleUpdate(t *testing.T) {
+    mockMarketPriceRepo := marketPrice.NewMockMarketPriceRepository()
+    mockCurrencyRepo := currency.NewMockCurrencyRepository()
+    poller := NewPricePoller(mockMarketPriceRepo, mockCurrencyRepo, time.Hour)
+
+    subscriber := &priceSubscriber{tickers: []string{}, updates: make(chan PriceUpdate, 1)}
+    poller.subscribers[subscriber] = struct{}{}
+    mockCurrencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(150.0, nil).Once()
+    mockCurrencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(151.0, nil).Once()
+
+    poller.poll(context.Background())
+    poller.poll(context.Background())
+
+    update := <-subscriber.updates
+    assert.Equal(t, 151.0, update.UsdJpy)
+    mockMarketPriceRepo.AssertNotCalled(t, "FetchMarketPriceList", mock.Anything, mock.Anything)
+}
+
+// 価格の取得に失敗した場合は配信しない
+func TestPricePollerPollError(t *testing.T) {
+    mockMarketPriceRepo := marketPrice.NewMockMarketPriceRepository()
+    mockCurrencyRepo := currency.NewMockCurrencyRepository()
+    poller := NewPricePoller(mockMarketPriceRepo, mockCurrencyRepo, time.Hour)
+
+    subscriber := &priceSubscriber{tickers: []string{"AAPL"}, updates: make(chan PriceUpdate, 1)}
+    poller.subscribers[subscriber] = struct{}{}
+    mockMarketPriceRepo.On("FetchMarketPriceList", mock.Anything, []string{"AAPL"}).Return([]marketPrice.MarketPriceDto{}, errors.New("api error"))
+
+    poller.poll(context.Background())
+
+    assert.Len(t, subscriber.updates, 0)
+}
+
+// 購読を開始するとすぐに価格が届き、購読者がいなくなるとチャネルが閉じられる
+func TestPricePollerSubscribe(t *testing.T) {
+    mockMarketPriceRepo := marketPrice.NewMockMarketPriceRepository()
+    mockCurrencyRepo := currency.NewMockCurrencyRepository()
+    poller := NewPricePoller(mockMarketPriceRepo, mockCurrencyRepo, time.Hour)
+
+    mockMarketPriceRepo.On("FetchMarketPriceList", mock.Anything, []string{"AAPL"}).Return([]marketPrice.MarketPriceDto{{Ticker: "AAPL", CurrentPrice: 180}}, nil)
+    mockCurrencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(150.0, nil)
+
+    ctx, cancel := context.WithCancel(context.Background())
+    updates := poller.Subscribe(ctx, []string{"AAPL"})
+
+    select {
+    case update := <-updates:
+        assert.Equal(t, 180.0, update.Quotes["AAPL"].CurrentPrice)
+    case <-time.After(time.Second):
+        t.Fatal("価格の更新が届きませんでした")
+    }
+    assert.Equal(t, 1, poller.SubscriberCount())
+
+    cancel()
+    for range updates {
+    }
+    assert.Equal(t, 0, poller.SubscriberCount())
+}
@@ -0,0 +1,119 @@
+package subscription
+
+import (
+	"context"
+	"math"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	"strings"
+	"time"
+)
+
+// 価格の更新を購読者向けの型に変換して配信する(更新のチャネルが閉じられたら配信も終了する)
+func forwardUpdates[T any](ctx context.Context, updates <-chan PriceUpdate, convert func(PriceUpdate) T) <-chan T {
+    out := make(chan T, 1)
+    go func() {
+        defer close(out)
+        for update := range updates {
+            select {
+            case out <- convert(update):
+            case <-ctx.Done():
+                return
+            }
+        }
+    }()
+    return out
+}
+
+// 銘柄を大文字にそろえ、空文字と重複を取り除く
+func normalizeTickers(tickers []string) []string {
+    normalized := []string{}
+    seen := make(map[string]struct{}, len(tickers))
+    for _, ticker := range tickers {
+        ticker = strings.ToUpper(strings.TrimSpace(ticker))
+        if ticker == "" {
+            continue
+        }
+        if _, ok := seen[ticker]; ok {
+            continue
+        }
+        seen[ticker] = struct{}{}
+        normalized = append(normalized, ticker)
+    }
+    return normalized
+}
+
+// 保有銘柄のティッカーを重複なく並べる
+func stockCodes(modelStocks []model.UsStock) []string {
+    codes := make([]string, 0, len(modelStocks))
+    for _, modelStock := range modelStocks {
+        codes = append(codes, modelStock.Code)
+    }
+    return normalizeTickers(codes)
+}
+
+// 保有銘柄と最新の資産スナップショット、取得した価格から資産評価額を計算する
+// 価格を取得できなかった銘柄は取得価格で評価する
+func calculatePortfolioValue(modelStocks []model.UsStock, snapshot *model.TotalAsset, update PriceUpdate) *generated.PortfolioValue {
+    stockAmount := 0.0
+    stockDayChange := 0.0
+    for _, modelStock := range modelStocks {
+        quote, ok := update.Quotes[modelStock.Code]
+        if !ok {
+            stockAmount += modelStock.GetPrice * modelStock.Quantity * update.UsdJpy
+            continue
+        }
+        stockAmount += quote.CurrentPrice * modelStock.Quantity * update.UsdJpy
+        stockDayChange += quote.PriceGets * modelStock.Quantity * update.UsdJpy
+    }
+
+    cashUsdAmount := 0.0
+    otherAmount := 0.0
+    if snapshot != nil {
+        cashUsdAmount = snapshot.CashUsd * update.UsdJpy
+        otherAmount = snapshot.CashJpy + snapshot.Fund + snapshot.Crypto + snapshot.FixedIncomeAsset
+    }
+    return &generated.PortfolioValue{
+        TotalAmount:    math.Round(stockAmount + cashUsdAmount + otherAmount),
+        StockAmount:    math.Round(stockAmount),
+        StockDayChange: math.Round(stockDayChange),
+        OtherAmount:    otherAmount,
+        UsdJpy:         update.UsdJpy,
+        UpdatedAt:      update.UpdatedAt.UTC().Format(time.RFC3339),
+    }
+}
+
+// 指定された順に銘柄の価格を並べる(取得できなかった銘柄は含めない)
+func createQuotes(tickers []string, update PriceUpdate) []*generated.MarketPrice {
+    quotes := make([]*generated.MarketPrice, 0, len(tickers))
+    for _, ticker := range tickers {
+        dto, ok := update.Quotes[ticker]
+        if !ok {
+            continue
+        }
+        quotes = append(quotes, convertToGeneratedMarketPrice(dto))
+    }
+    return quotes
+}
+
+func convertToGeneratedMarketPrice(dto marketPrice.MarketPriceDto) *generated.MarketPrice {
+    return &generated.MarketPrice{
+        Ticker:       dto.Ticker,
+        CurrentPrice: dto.CurrentPrice,
+        PriceGets:    dto.PriceGets,
+        CurrentRate:  dto.CurrentRate,
+        DayLow:       dto.DayLow,
+        DayHigh:      dto.DayHigh,
+        YearHigh:     dto.YearHigh,
+        YearLow:      dto.YearLow,
+        MarketCap:    dto.MarketCap,
+        Pe:           dto.Pe,
+        Eps:          dto.Eps,
+        Volume:       dto.Volume,
+        PriceAvg50:   dto.PriceAvg50,
+        PriceAvg200:  dto.PriceAvg200,
+        EarningsAnnouncement: utils.ConvertDateToNullable(dto.EarningsAnnouncement),
+    }
+}
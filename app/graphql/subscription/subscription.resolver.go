@@ -0,0 +1,22 @@
+package subscription
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+)
+
+type Resolver struct {
+    SubscriptionService SubscriptionService
+}
+
+func NewResolver(subscriptionService SubscriptionService) *Resolver {
+    return &Resolver{SubscriptionService: subscriptionService}
+}
+
+func (r *Resolver) PortfolioValue(ctx context.Context) (<-chan *generated.PortfolioValue, error) {
+    return r.SubscriptionService.PortfolioValue(ctx)
+}
+
+func (r *Resolver) Quotes(ctx context.Context, tickers []string) (<-chan []*generated.MarketPrice, error) {
+    return r.SubscriptionService.Quotes(ctx, tickers)
+}
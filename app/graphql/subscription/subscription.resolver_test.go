@@ -0,0 +1,55 @@
+package subscription
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSubscriptionService は SubscriptionService のモックです。
+type MockSubscriptionService struct {
+    mock.Mock
+}
+
+func (m *MockSubscriptionService) PortfolioValue(ctx context.Context) (<-chan *generated.PortfolioValue, error) {
+    args := m.Called(ctx)
+    return args.Get(0).(<-chan *generated.PortfolioValue), args.Error(1)
+}
+
+func (m *MockSubscriptionService) Quotes(ctx context.Context, tickers []string) (<-chan []*generated.MarketPrice, error) {
+    args := m.Called(ctx, tickers)
+    return args.Get(0).(<-chan []*generated.MarketPrice), args.Error(1)
+}
+
+func TestPortfolioValue(t *testing.T) {
+    mockService := new(MockSubscriptionService)
+    resolver := NewResolver(mockService)
+
+    values := make(chan *generated.PortfolioValue, 1)
+    values <- &generated.PortfolioValue{TotalAmount: 1000000}
+    mockService.On("PortfolioValue", mock.Anything).Return((<-chan *generated.PortfolioValue)(values), nil)
+
+    result, err := resolver.PortfolioValue(context.Background())
+
+    assert.NoError(t, err)
+    assert.Equal(t, 1000000.0, (<-result).TotalAmount)
+    mockService.AssertExpectations(t)
+}
+
+func TestQuotes(t *testing.T) {
+    mockService := new(MockSubscriptionService)
+    resolver := NewResolver(mockService)
+
+    quotes := make(chan []*generated.MarketPrice, 1)
+    quotes <- []*generated.MarketPrice{{Ticker: "AAPL", CurrentPrice: 180}}
+    mockService.On("Quotes", mock.Anything, []string{"AAPL"}).Return((<-chan []*generated.MarketPrice)(quotes), nil)
+
+    result, err := resolver.Quotes(context.Background(), []string{"AAPL"})
+
+    assert.NoError(t, err)
+    assert.Equal(t, "AAPL", (<-result)[0].Ticker)
+    mockService.AssertExpectations(t)
+}
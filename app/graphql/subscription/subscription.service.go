@@ -0,0 +1,81 @@
+package subscription
+
+import (
+	"context"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	"my-us-stock-backend/app/repository/assets/stock"
+	totalAssets "my-us-stock-backend/app/repository/total-assets"
+)
+
+// 1つの購読で指定できる銘柄数の上限
+const maxQuoteTickers = 20
+
+// SubscriptionService インターフェースの定義
+type SubscriptionService interface {
+    PortfolioValue(ctx context.Context) (<-chan *generated.PortfolioValue, error)
+    Quotes(ctx context.Context, tickers []string) (<-chan []*generated.MarketPrice, error)
+}
+
+// DefaultSubscriptionService 構造体の定義
+type DefaultSubscriptionService struct {
+    PricePoller *PricePoller
+    StockRepo stock.UsStockRepository
+    TotalAssetRepo totalAssets.TotalAssetRepository
+    Auth auth.AuthService
+}
+
+// NewSubscriptionService は DefaultSubscriptionService の新しいインスタンスを作成します
+func NewSubscriptionService(pricePoller *PricePoller, stockRepo stock.UsStockRepository, totalAssetRepo totalAssets.TotalAssetRepository, auth auth.AuthService) SubscriptionService {
+    return &DefaultSubscriptionService{PricePoller: pricePoller, StockRepo: stockRepo, TotalAssetRepo: totalAssetRepo, Auth: auth}
+}
+
+// PortfolioValue はログインユーザーの資産評価額を価格の更新ごとに配信します
+func (s *DefaultSubscriptionService) PortfolioValue(ctx context.Context) (<-chan *generated.PortfolioValue, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+
+    modelStocks, err := s.StockRepo.FetchUsStockListById(ctx, userId)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    // 株式以外の資産は最新の資産スナップショットの値を使う
+    modelAssets, err := s.TotalAssetRepo.FetchTotalAssetListById(ctx, userId, 1)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    var snapshot *model.TotalAsset
+    if len(modelAssets) != 0 {
+        snapshot = &modelAssets[0]
+    }
+
+    updates := s.PricePoller.Subscribe(ctx, stockCodes(modelStocks))
+    return forwardUpdates(ctx, updates, func(update PriceUpdate) *generated.PortfolioValue {
+        return calculatePortfolioValue(modelStocks, snapshot, update)
+    }), nil
+}
+
+// Quotes は指定した銘柄の価格を更新ごとに配信します
+func (s *DefaultSubscriptionService) Quotes(ctx context.Context, tickers []string) (<-chan []*generated.MarketPrice, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+
+    normalizedTickers := normalizeTickers(tickers)
+    if len(normalizedTickers) == 0 {
+        return nil, utils.DefaultGraphQLError("銘柄を1つ以上指定してください")
+    }
+    if len(normalizedTickers) > maxQuoteTickers {
+        return nil, utils.DefaultGraphQLError("指定できる銘柄は20件までです")
+    }
+
+    updates := s.PricePoller.Subscribe(ctx, normalizedTickers)
+    return forwardUpdates(ctx, updates, func(update PriceUpdate) []*generated.MarketPrice {
+        return createQuotes(normalizedTickers, update)
+    }), nil
+}
@@ -0,0 +1,153 @@
+package subscription
+
+import (
+	"context"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/repository/assets/stock"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	"my-us-stock-backend/app/repository/market-price/currency"
+	totalAssets "my-us-stock-backend/app/repository/total-assets"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTotalAssetRepository は TotalAssetRepository のモックです。
+type MockTotalAssetRepository struct {
+    mock.Mock
+}
+
+func (m *MockTotalAssetRepository) FetchTotalAssetListById(ctx context.Context, userId uint, day int) ([]model.TotalAsset, error) {
+    args := m.Called(ctx, userId, day)
+    return args.Get(0).([]model.TotalAsset), args.Error(1)
+}
+
+func (m *MockTotalAssetRepository) FindTodayTotalAsset(ctx context.Context, userId uint) (*model.TotalAsset, error) {
+    args := m.Called(ctx, userId)
+    return args.Get(0).(*model.TotalAsset), args.Error(1)
+}
+
+func (m *MockTotalAssetRepository) UpdateTotalAsset(ctx context.Context, dto totalAssets.UpdateTotalAssetDto) (*model.TotalAsset, error) {
+    args := m.Called(ctx, dto)
+    return args.Get(0).(*model.TotalAsset), args.Error(1)
+}
+
+func (m *MockTotalAssetRepository) CreateTodayTotalAsset(ctx context.Context, dto totalAssets.CreateTotalAssetDto) (*model.TotalAsset, error) {
+    args := m.Called(ctx, dto)
+    return args.Get(0).(*model.TotalAsset), args.Error(1)
+}
+
+func TestCalculatePortfolioValue(t *testing.T) {
+    modelStocks := []model.UsStock{
+        {Code: "AAPL", Quantity: 10, GetPrice: 150},
+        {Code: "KO", Quantity: 20, GetPrice: 55},
+        {Code: "XYZ", Quantity: 1, GetPrice: 10},
+    }
+    snapshot := &model.TotalAsset{CashJpy: 100000, CashUsd: 1000, Fund: 300000, Crypto: 50000, FixedIncomeAsset: 200000}
+    updatedAt := time.Date(2024, 1, 11, 1, 0, 0, 0, time.UTC)
+    update := PriceUpdate{
+        Quotes: map[string]marketPrice.MarketPriceDto{
+            "AAPL": {Ticker: "AAPL", CurrentPrice: 180, PriceGets: 2},
+            "KO":   {Ticker: "KO", CurrentPrice: 58, PriceGets: -0.5},
+        },
+        UsdJpy:    150,
+        UpdatedAt: updatedAt,
+    }
+
+    value := calculatePortfolioValue(modelStocks, snapshot, update)
+
+    // (180×10 + 58×20 + 10×1) × 150
+    assert.Equal(t, 445500.0, value.StockAmount)
+    // (2×10 - 0.5×20) × 150
+    assert.Equal(t, 1500.0, value.StockDayChange)
+    assert.Equal(t, 650000.0, value.OtherAmount)
+    assert.Equal(t, 445500.0+150000.0+650000.0, value.TotalAmount)
+    assert.Equal(t, "2024-01-11T01:00:00Z", value.UpdatedAt)
+
+    // 資産スナップショットがない場合は株式のみ
+    value = calculatePortfolioValue(modelStocks, nil, update)
+    assert.Equal(t, 445500.0, value.TotalAmount)
+}
+
+func TestCreateQuotes(t *testing.T) {
+    update := PriceUpdate{Quotes: map[string]marketPrice.MarketPriceDto{
+        "AAPL": {Ticker: "AAPL", CurrentPrice: 180},
+        "KO":   {Ticker: "KO", CurrentPrice: 58},
+    }}
+
+    quotes := createQuotes([]string{"KO", "MSFT", "AAPL"}, update)
+
+    assert.Len(t, quotes, 2)
+    assert.Equal(t, "KO", quotes[0].Ticker)
+    assert.Equal(t, "AAPL", quotes[1].Ticker)
+}
+
+func TestNormalizeTickers(t *testing.T) {
+    assert.Equal(t, []string{"AAPL", "KO"}, normalizeTickers([]string{" aapl", "KO", "", "AAPL"}))
+}
+
+func TestQuotesService(t *testing.T) {
+    mockMarketPriceRepo := marketPrice.NewMockMarketPriceRepository()
+    mockCurrencyRepo := currency.NewMockCurrencyRepository()
+    mockAuth := auth.NewMockAuthService()
+    poller := NewPricePoller(mockMarketPriceRepo, mockCurrencyRepo, time.Hour)
+    service := NewSubscriptionService(poller, stock.NewMockUsStockRepository(), new(MockTotalAssetRepository), mockAuth)
+
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    mockMarketPriceRepo.On("FetchMarketPriceList", mock.Anything, []string{"AAPL", "KO"}).Return([]marketPrice.MarketPriceDto{
+        {Ticker: "KO", CurrentPrice: 58}, {Ticker: "AAPL", CurrentPrice: 180},
+    }, nil)
+    mockCurrencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(150.0, nil)
+
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    quotes, err := service.Quotes(ctx, []string{"aapl", "KO"})
+    assert.NoError(t, err)
+
+    select {
+    case result := <-quotes:
+        assert.Len(t, result, 2)
+        assert.Equal(t, "AAPL", result[0].Ticker)
+        assert.Equal(t, 180.0, result[0].CurrentPrice)
+    case <-time.After(time.Second):
+        t.Fatal("価格の更新が届きませんでした")
+    }
+}
+
+func TestQuotesServiceValidation(t *testing.T) {
+    mockAuth := auth.NewMockAuthService()
+    poller := NewPricePoller(marketPrice.NewMockMarketPriceRepository(), currency.NewMockCurrencyRepository(), time.Hour)
+    service := NewSubscriptionService(poller, stock.NewMockUsStockRepository(), new(MockTotalAssetRepository), mockAuth)
+
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+
+    _, err := service.Quotes(context.Background(), []string{" "})
+    assert.Error(t, err)
+
+    tickers := []string{}
+    for i := 0; i < 21; i++ {
+        tickers = append(tickers, "T"+strconv.Itoa(i))
+    }
+    _, err = service.Quotes(context.Background(), tickers)
+    assert.Error(t, err)
+    assert.Equal(t, 0, poller.SubscriberCount())
+}
+
+func TestPortfolioValueServiceUnauthenticated(t *testing.T) {
+    mockAuth := auth.NewMockAuthService()
+    mockStockRepo := stock.NewMockUsStockRepository()
+    poller := NewPricePoller(marketPrice.NewMockMarketPriceRepository(), currency.NewMockCurrencyRepository(), time.Hour)
+    service := NewSubscriptionService(poller, mockStockRepo, new(MockTotalAssetRepository), mockAuth)
+
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(0), nil)
+
+    _, err := service.PortfolioValue(context.Background())
+
+    assert.Error(t, err)
+    mockStockRepo.AssertNotCalled(t, "FetchUsStockListById", mock.Anything, mock.Anything)
+    assert.Equal(t, 0, poller.SubscriberCount())
+}
@@ -0,0 +1,134 @@
+package taxreport
+
+import (
+	"math"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	taxReport "my-us-stock-backend/app/repository/tax-report"
+	"strconv"
+	"time"
+)
+
+// 米国の配当に対する源泉徴収税率(日米租税条約)
+const foreignTaxRate = 0.10
+
+// 配当の国内源泉徴収税率(所得税・復興特別所得税 15.315%、住民税 5%)
+const (
+    incomeTaxRate   = 0.15315
+    residentTaxRate = 0.05
+)
+
+// 国内源泉徴収税額を計算する(外国税額を差し引いた後の配当に、所得税・住民税をそれぞれ円未満切り捨てで課税)
+func calculateDomesticTax(amountJpy float64, foreignTaxJpy float64) float64 {
+    base := amountJpy - foreignTaxJpy
+    if base <= 0 {
+        return 0
+    }
+    return math.Floor(base*incomeTaxRate) + math.Floor(base*residentTaxRate)
+}
+
+// 指定日(休日の場合は直前の営業日)の終値をTTMの代わりとして返す
+func findRateOnOrBefore(prices []marketPrice.HistoricalPriceDto, date time.Time) (float64, bool) {
+    rate := 0.0
+    for _, price := range prices {
+        if price.Date.After(date) {
+            break
+        }
+        rate = price.Close
+    }
+    return rate, rate > 0
+}
+
+// 保有している米国株の取得単価・取得時のドル円を数量で加重平均する
+func averageHolding(usStocks []model.UsStock, code string) (float64, float64, bool) {
+    var quantity, getPriceTotal, usdJpyTotal float64
+    for _, usStock := range usStocks {
+        if usStock.Code != code {
+            continue
+        }
+        quantity += usStock.Quantity
+        getPriceTotal += usStock.GetPrice * usStock.Quantity
+        usdJpyTotal += usStock.UsdJpy * usStock.Quantity
+    }
+    if quantity == 0 {
+        return 0, 0, false
+    }
+    return getPriceTotal / quantity, usdJpyTotal / quantity, true
+}
+
+func convertToGeneratedDividendReceipt(receipt model.DividendReceipt) *generated.DividendReceipt {
+    amountJpy, foreignTaxJpy := taxReport.DividendJpy(receipt)
+    return &generated.DividendReceipt{
+        ID:            strconv.FormatUint(uint64(receipt.ID), 10),
+        Code:          receipt.Code,
+        PaymentDate:   receipt.PaymentDate,
+        Amount:        receipt.Amount,
+        ForeignTax:    receipt.ForeignTax,
+        DomesticTax:   receipt.DomesticTax,
+        UsdJpy:        receipt.UsdJpy,
+        AmountJpy:     amountJpy,
+        ForeignTaxJpy: foreignTaxJpy,
+    }
+}
+
+func convertToGeneratedStockSale(sale model.StockSale) *generated.StockSale {
+    proceeds, cost, fee := taxReport.StockSaleJpy(sale)
+    return &generated.StockSale{
+        ID:           strconv.FormatUint(uint64(sale.ID), 10),
+        Code:         sale.Code,
+        TradeDate:    sale.TradeDate,
+        Quantity:     sale.Quantity,
+        SalePrice:    sale.SalePrice,
+        GetPrice:     sale.GetPrice,
+        Fee:          sale.Fee,
+        UsdJpy:       sale.UsdJpy,
+        GetUsdJpy:    sale.GetUsdJpy,
+        Proceeds:     proceeds,
+        Cost:         cost,
+        FeeJpy:       fee,
+        RealizedGain: proceeds - cost - fee,
+    }
+}
+
+// 配当・売却の記録を銘柄別に集計し、銘柄ごとに個別の記録を添える(銘柄はティッカー順)
+func createAnnualTaxReport(year int, receipts []model.DividendReceipt, sales []model.StockSale) *generated.AnnualTaxReport {
+    summary := taxReport.SummarizeAnnualTax(year, receipts, sales)
+    report := &generated.AnnualTaxReport{
+        Year:              summary.Year,
+        Securities:        make([]*generated.TaxReportSecurity, 0, len(summary.Securities)),
+        DividendTotal:     summary.DividendTotal,
+        ForeignTaxTotal:   summary.ForeignTaxTotal,
+        DomesticTaxTotal:  summary.DomesticTaxTotal,
+        ProceedsTotal:     summary.ProceedsTotal,
+        CostTotal:         summary.CostTotal,
+        FeeTotal:          summary.FeeTotal,
+        RealizedGainTotal: summary.RealizedGainTotal,
+    }
+    securityMap := make(map[string]*generated.TaxReportSecurity, len(summary.Securities))
+    for _, security := range summary.Securities {
+        generatedSecurity := &generated.TaxReportSecurity{
+            Code:           security.Code,
+            DividendAmount: security.DividendAmount,
+            ForeignTax:     security.ForeignTax,
+            DomesticTax:    security.DomesticTax,
+            Proceeds:       security.Proceeds,
+            Cost:           security.Cost,
+            Fee:            security.Fee,
+            RealizedGain:   security.RealizedGain,
+            Dividends:      []*generated.DividendReceipt{},
+            Sales:          []*generated.StockSale{},
+        }
+        securityMap[security.Code] = generatedSecurity
+        report.Securities = append(report.Securities, generatedSecurity)
+    }
+    for _, receipt := range receipts {
+        security := securityMap[receipt.Code]
+        security.Dividends = append(security.Dividends, convertToGeneratedDividendReceipt(receipt))
+    }
+    for _, sale := range sales {
+        security := securityMap[sale.Code]
+        security.Sales = append(security.Sales, convertToGeneratedStockSale(sale))
+    }
+    return report
+}
@@ -0,0 +1,34 @@
+package taxreport
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+)
+
+type Resolver struct {
+    TaxReportService TaxReportService
+}
+
+func NewResolver(taxReportService TaxReportService) *Resolver {
+    return &Resolver{TaxReportService: taxReportService}
+}
+
+func (r *Resolver) AnnualTaxReport(ctx context.Context, year int) (*generated.AnnualTaxReport, error) {
+    return r.TaxReportService.AnnualTaxReport(ctx, year)
+}
+
+func (r *Resolver) CreateDividendReceipt(ctx context.Context, input generated.CreateDividendReceiptInput) (*generated.DividendReceipt, error) {
+    return r.TaxReportService.CreateDividendReceipt(ctx, input)
+}
+
+func (r *Resolver) DeleteDividendReceipt(ctx context.Context, id string) (bool, error) {
+    return r.TaxReportService.DeleteDividendReceipt(ctx, id)
+}
+
+func (r *Resolver) CreateStockSale(ctx context.Context, input generated.CreateStockSaleInput) (*generated.StockSale, error) {
+    return r.TaxReportService.CreateStockSale(ctx, input)
+}
+
+func (r *Resolver) DeleteStockSale(ctx context.Context, id string) (bool, error) {
+    return r.TaxReportService.DeleteStockSale(ctx, id)
+}
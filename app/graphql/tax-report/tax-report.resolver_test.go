@@ -0,0 +1,82 @@
+package taxreport
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTaxReportService は TaxReportService のモックです。
+type MockTaxReportService struct {
+    mock.Mock
+}
+
+func (m *MockTaxReportService) AnnualTaxReport(ctx context.Context, year int) (*generated.AnnualTaxReport, error) {
+    args := m.Called(ctx, year)
+    return args.Get(0).(*generated.AnnualTaxReport), args.Error(1)
+}
+
+func (m *MockTaxReportService) CreateDividendReceipt(ctx context.Context, input generated.CreateDividendReceiptInput) (*generated.DividendReceipt, error) {
+    args := m.Called(ctx, input)
+    return args.Get(0).(*generated.DividendReceipt), args.Error(1)
+}
+
+func (m *MockTaxReportService) DeleteDividendReceipt(ctx context.Context, id string) (bool, error) {
+    args := m.Called(ctx, id)
+    return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTaxReportService) CreateStockSale(ctx context.Context, input generated.CreateStockSaleInput) (*generated.StockSale, error) {
+    args := m.Called(ctx, input)
+    return args.Get(0).(*generated.StockSale), args.Error(1)
+}
+
+func (m *MockTaxReportService) DeleteStockSale(ctx context.Context, id string) (bool, error) {
+    args := m.Called(ctx, id)
+    return args.Bool(0), args.Error(1)
+}
+
+func TestAnnualTaxReportResolver(t *testing.T) {
+    mockService := new(MockTaxReportService)
+    resolver := NewResolver(mockService)
+
+    report := &generated.AnnualTaxReport{Year: 2024, DividendTotal: 6911}
+    mockService.On("AnnualTaxReport", mock.Anything, 2024).Return(report, nil)
+
+    result, err := resolver.AnnualTaxReport(context.Background(), 2024)
+
+    assert.NoError(t, err)
+    assert.Equal(t, report, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestCreateDividendReceiptResolver(t *testing.T) {
+    mockService := new(MockTaxReportService)
+    resolver := NewResolver(mockService)
+
+    input := generated.CreateDividendReceiptInput{Code: "KO", PaymentDate: "2024-04-01", Amount: 46}
+    receipt := &generated.DividendReceipt{ID: "1", Code: "KO"}
+    mockService.On("CreateDividendReceipt", mock.Anything, input).Return(receipt, nil)
+
+    result, err := resolver.CreateDividendReceipt(context.Background(), input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, receipt, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestDeleteDividendReceiptResolver(t *testing.T) {
+    mockService := new(MockTaxReportService)
+    resolver := NewResolver(mockService)
+
+    mockService.On("DeleteDividendReceipt", mock.Anything, "1").Return(true, nil)
+
+    result, err := resolver.DeleteDividendReceipt(context.Background(), "1")
+
+    assert.NoError(t, err)
+    assert.True(t, result)
+    mockService.AssertExpectations(t)
+}
@@ -0,0 +1,244 @@
+package taxreport
+
+import (
+	"context"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	"my-us-stock-backend/app/repository/assets/stock"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	taxReport "my-us-stock-backend/app/repository/tax-report"
+	"strings"
+	"time"
+)
+
+// TTMの代わりに使うドル円のシンボル
+const usdJpySymbol = "USDJPY"
+
+// TaxReportService インターフェースの定義
+type TaxReportService interface {
+    AnnualTaxReport(ctx context.Context, year int) (*generated.AnnualTaxReport, error)
+    CreateDividendReceipt(ctx context.Context, input generated.CreateDividendReceiptInput) (*generated.DividendReceipt, error)
+    DeleteDividendReceipt(ctx context.Context, id string) (bool, error)
+    CreateStockSale(ctx context.Context, input generated.CreateStockSaleInput) (*generated.StockSale, error)
+    DeleteStockSale(ctx context.Context, id string) (bool, error)
+}
+
+// DefaultTaxReportService 構造体の定義
+type DefaultTaxReportService struct {
+    TaxReportRepo taxReport.TaxReportRepository
+    StockRepo stock.UsStockRepository
+    MarketPriceRepo marketPrice.MarketPriceRepository
+    Auth auth.AuthService
+}
+
+// NewTaxReportService は DefaultTaxReportService の新しいインスタンスを作成します
+func NewTaxReportService(taxReportRepo taxReport.TaxReportRepository, stockRepo stock.UsStockRepository, marketPriceRepo marketPrice.MarketPriceRepository, auth auth.AuthService) TaxReportService {
+    return &DefaultTaxReportService{
+        TaxReportRepo: taxReportRepo,
+        StockRepo: stockRepo,
+        MarketPriceRepo: marketPriceRepo,
+        Auth: auth,
+    }
+}
+
+// AnnualTaxReport はログインユーザーの指定した年の配当・譲渡損益を銘柄別に集計します
+func (s *DefaultTaxReportService) AnnualTaxReport(ctx context.Context, year int) (*generated.AnnualTaxReport, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    if year < 2000 || year > 9999 {
+        return nil, utils.DefaultGraphQLError("年が正しくありません")
+    }
+
+    receipts, err := s.TaxReportRepo.FetchDividendReceiptListByYear(ctx, userId, year)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    sales, err := s.TaxReportRepo.FetchStockSaleListByYear(ctx, userId, year)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return createAnnualTaxReport(year, receipts, sales), nil
+}
+
+// CreateDividendReceipt は配当の受取記録を登録します
+// 外国源泉徴収税額・国内源泉徴収税額・TTMを省略した場合は計算・取得した値で登録します
+func (s *DefaultTaxReportService) CreateDividendReceipt(ctx context.Context, input generated.CreateDividendReceiptInput) (*generated.DividendReceipt, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    code := strings.ToUpper(strings.TrimSpace(input.Code))
+    if code == "" {
+        return nil, utils.DefaultGraphQLError("ティッカーを入力してください")
+    }
+    paymentDate, err := parseDate(input.PaymentDate)
+    if err != nil {
+        return nil, err
+    }
+    if input.Amount <= 0 {
+        return nil, utils.DefaultGraphQLError("配当金は0より大きい値を入力してください")
+    }
+    foreignTax := input.Amount * foreignTaxRate
+    if input.ForeignTax != nil {
+        foreignTax = *input.ForeignTax
+    }
+    if foreignTax < 0 || foreignTax > input.Amount {
+        return nil, utils.DefaultGraphQLError("外国源泉徴収税額は0以上、配当金以下の値を入力してください")
+    }
+    usdJpy, err := s.resolveUsdJpy(ctx, input.UsdJpy, paymentDate)
+    if err != nil {
+        return nil, err
+    }
+    domesticTax := calculateDomesticTax(taxReport.ConvertToJpy(input.Amount, usdJpy), taxReport.ConvertToJpy(foreignTax, usdJpy))
+    if input.DomesticTax != nil {
+        domesticTax = *input.DomesticTax
+    }
+    if domesticTax < 0 {
+        return nil, utils.DefaultGraphQLError("国内源泉徴収税額は0以上の値を入力してください")
+    }
+
+    receipt, err := s.TaxReportRepo.CreateDividendReceipt(ctx, taxReport.CreateDividendReceiptDto{
+        UserId:      userId,
+        Code:        code,
+        PaymentDate: input.PaymentDate,
+        Amount:      input.Amount,
+        ForeignTax:  foreignTax,
+        DomesticTax: domesticTax,
+        UsdJpy:      usdJpy,
+    })
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return convertToGeneratedDividendReceipt(*receipt), nil
+}
+
+// DeleteDividendReceipt は配当の受取記録を削除します
+func (s *DefaultTaxReportService) DeleteDividendReceipt(ctx context.Context, id string) (bool, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return false, utils.UnauthenticatedError("Invalid user ID")
+    }
+    deleteId, err := utils.ConvertIdToUint(id)
+    if err != nil || deleteId == 0 {
+        return false, utils.DefaultGraphQLError("入力されたidが無効です")
+    }
+    if err := s.TaxReportRepo.DeleteDividendReceipt(ctx, deleteId, userId); err != nil {
+        return false, utils.DefaultGraphQLError(err.Error())
+    }
+    return true, nil
+}
+
+// CreateStockSale は売却記録を登録します
+// 取得単価・取得時のドル円を省略した場合は保有している米国株の値を使います
+func (s *DefaultTaxReportService) CreateStockSale(ctx context.Context, input generated.CreateStockSaleInput) (*generated.StockSale, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+    code := strings.ToUpper(strings.TrimSpace(input.Code))
+    if code == "" {
+        return nil, utils.DefaultGraphQLError("ティッカーを入力してください")
+    }
+    tradeDate, err := parseDate(input.TradeDate)
+    if err != nil {
+        return nil, err
+    }
+    if input.Quantity <= 0 {
+        return nil, utils.DefaultGraphQLError("売却数量は0より大きい値を入力してください")
+    }
+    if input.SalePrice < 0 || input.Fee < 0 {
+        return nil, utils.DefaultGraphQLError("売却単価・手数料は0以上の値を入力してください")
+    }
+
+    getPrice, getUsdJpy := input.GetPrice, input.GetUsdJpy
+    if getPrice == nil || getUsdJpy == nil {
+        usStocks, err := s.StockRepo.FetchUsStockListById(ctx, userId)
+        if err != nil {
+            return nil, utils.DefaultGraphQLError(err.Error())
+        }
+        averagePrice, averageUsdJpy, ok := averageHolding(usStocks, code)
+        if !ok {
+            return nil, utils.DefaultGraphQLError("保有していない銘柄のため、取得単価と取得日のTTMを入力してください")
+        }
+        if getPrice == nil {
+            getPrice = &averagePrice
+        }
+        if getUsdJpy == nil {
+            getUsdJpy = &averageUsdJpy
+        }
+    }
+    if *getPrice < 0 || *getUsdJpy <= 0 {
+        return nil, utils.DefaultGraphQLError("取得単価は0以上、取得日のTTMは0より大きい値を入力してください")
+    }
+    usdJpy, err := s.resolveUsdJpy(ctx, input.UsdJpy, tradeDate)
+    if err != nil {
+        return nil, err
+    }
+
+    sale, err := s.TaxReportRepo.CreateStockSale(ctx, taxReport.CreateStockSaleDto{
+        UserId:    userId,
+        Code:      code,
+        TradeDate: input.TradeDate,
+        Quantity:  input.Quantity,
+        SalePrice: input.SalePrice,
+        GetPrice:  *getPrice,
+        Fee:       input.Fee,
+        UsdJpy:    usdJpy,
+        GetUsdJpy: *getUsdJpy,
+    })
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return convertToGeneratedStockSale(*sale), nil
+}
+
+// DeleteStockSale は売却記録を削除します
+func (s *DefaultTaxReportService) DeleteStockSale(ctx context.Context, id string) (bool, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return false, utils.UnauthenticatedError("Invalid user ID")
+    }
+    deleteId, err := utils.ConvertIdToUint(id)
+    if err != nil || deleteId == 0 {
+        return false, utils.DefaultGraphQLError("入力されたidが無効です")
+    }
+    if err := s.TaxReportRepo.DeleteStockSale(ctx, deleteId, userId); err != nil {
+        return false, utils.DefaultGraphQLError(err.Error())
+    }
+    return true, nil
+}
+
+// 入力されたTTMを検証し、省略された場合は指定日のドル円の終値で代用する
+func (s *DefaultTaxReportService) resolveUsdJpy(ctx context.Context, usdJpy *float64, date time.Time) (float64, error) {
+    if usdJpy != nil {
+        if *usdJpy <= 0 {
+            return 0, utils.DefaultGraphQLError("TTMは0より大きい値を入力してください")
+        }
+        return *usdJpy, nil
+    }
+    // 休日の場合に直前の営業日の値を使えるよう、1週間前から取得する
+    prices, err := s.MarketPriceRepo.FetchHistoricalPrices(ctx, usdJpySymbol, date.AddDate(0, 0, -7), date)
+    if err != nil {
+        return 0, utils.DefaultGraphQLError("TTMを取得できなかったため、usdJpy を入力してください")
+    }
+    rate, ok := findRateOnOrBefore(prices, date)
+    if !ok {
+        return 0, utils.DefaultGraphQLError("TTMを取得できなかったため、usdJpy を入力してください")
+    }
+    return rate, nil
+}
+
+// 日付(YYYY-MM-DD)を検証する(未来日は不可)
+func parseDate(value string) (time.Time, error) {
+    date, err := time.Parse("2006-01-02", value)
+    if err != nil {
+        return time.Time{}, utils.DefaultGraphQLError("日付はYYYY-MM-DD形式で入力してください")
+    }
+    if date.After(time.Now()) {
+        return time.Time{}, utils.DefaultGraphQLError("未来の日付は入力できません")
+    }
+    return date, nil
+}
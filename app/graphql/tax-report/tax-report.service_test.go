@@ -0,0 +1,198 @@
+package taxreport
+
+import (
+	"context"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/repository/assets/stock"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	taxReport "my-us-stock-backend/app/repository/tax-report"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type testMocks struct {
+    taxReportRepo   *taxReport.MockTaxReportRepository
+    stockRepo       *stock.MockUsStockRepository
+    marketPriceRepo *marketPrice.MockMarketPriceRepository
+    auth            *auth.MockAuthService
+}
+
+func newTestService() (TaxReportService, testMocks) {
+    mocks := testMocks{
+        taxReportRepo:   taxReport.NewMockTaxReportRepository(),
+        stockRepo:       stock.NewMockUsStockRepository(),
+        marketPriceRepo: marketPrice.NewMockMarketPriceRepository(),
+        auth:            auth.NewMockAuthService(),
+    }
+    service := NewTaxReportService(mocks.taxReportRepo, mocks.stockRepo, mocks.marketPriceRepo, mocks.auth)
+    return service, mocks
+}
+
+func TestCalculateDomesticTax(t *testing.T) {
+    // 1332円に対して 所得税 203円 + 住民税 66円
+    assert.Equal(t, 269.0, calculateDomesticTax(1480, 148))
+    assert.Equal(t, 0.0, calculateDomesticTax(100, 100))
+}
+
+func TestCreateAnnualTaxReport(t *testing.T) {
+    receipts := []model.DividendReceipt{
+        {Model: gorm.Model{ID: 1}, Code: "KO", PaymentDate: "2024-04-01", Amount: 46, ForeignTax: 4.6, DomesticTax: 1053, UsdJpy: 150.25},
+        {Model: gorm.Model{ID: 2}, Code: "AAPL", PaymentDate: "2024-05-16", Amount: 2.4, ForeignTax: 0.24, DomesticTax: 67, UsdJpy: 155},
+        {Model: gorm.Model{ID: 3}, Code: "KO", PaymentDate: "2024-07-01", Amount: 46, ForeignTax: 4.6, DomesticTax: 1119, UsdJpy: 160},
+    }
+    sales := []model.StockSale{
+        {Model: gorm.Model{ID: 1}, Code: "AAPL", TradeDate: "2024-06-03", Quantity: 10, SalePrice: 190, GetPrice: 150, Fee: 2, UsdJpy: 150, GetUsdJpy: 130},
+    }
+
+    report := createAnnualTaxReport(2024, receipts, sales)
+
+    assert.Equal(t, 2024, report.Year)
+    assert.Len(t, report.Securities, 2)
+    aapl, ko := report.Securities[0], report.Securities[1]
+    assert.Equal(t, "AAPL", aapl.Code)
+    assert.Equal(t, 372.0, aapl.DividendAmount)
+    assert.Equal(t, 37.0, aapl.ForeignTax)
+    assert.Equal(t, 285000.0, aapl.Proceeds)
+    assert.Equal(t, 195000.0, aapl.Cost)
+    assert.Equal(t, 300.0, aapl.Fee)
+    assert.Equal(t, 89700.0, aapl.RealizedGain)
+    assert.Len(t, aapl.Sales, 1)
+    assert.Equal(t, "KO", ko.Code)
+    assert.Len(t, ko.Dividends, 2)
+    // 6911 + 7360
+    assert.Equal(t, 14271.0, ko.DividendAmount)
+    // 691 + 736
+    assert.Equal(t, 1427.0, ko.ForeignTax)
+    assert.Equal(t, 2172.0, ko.DomesticTax)
+    assert.Empty(t, ko.Sales)
+    assert.Equal(t, 14643.0, report.DividendTotal)
+    assert.Equal(t, 1464.0, report.ForeignTaxTotal)
+    assert.Equal(t, 2239.0, report.DomesticTaxTotal)
+    assert.Equal(t, 89700.0, report.RealizedGainTotal)
+}
+
+func TestAnnualTaxReport(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    mocks.taxReportRepo.On("FetchDividendReceiptListByYear", mock.Anything, uint(1), 2024).Return([]model.DividendReceipt{
+        {Code: "KO", PaymentDate: "2024-04-01", Amount: 46, ForeignTax: 4.6, DomesticTax: 1053, UsdJpy: 150.25},
+    }, nil)
+    mocks.taxReportRepo.On("FetchStockSaleListByYear", mock.Anything, uint(1), 2024).Return([]model.StockSale{}, nil)
+
+    report, err := service.AnnualTaxReport(context.Background(), 2024)
+
+    assert.NoError(t, err)
+    assert.Len(t, report.Securities, 1)
+    assert.Equal(t, 6911.0, report.DividendTotal)
+
+    _, err = service.AnnualTaxReport(context.Background(), 1999)
+    assert.EqualError(t, err, "input: 年が正しくありません")
+}
+
+func TestAnnualTaxReportUnauthenticated(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(0), nil)
+
+    report, err := service.AnnualTaxReport(context.Background(), 2024)
+
+    assert.Nil(t, report)
+    assert.Error(t, err)
+    mocks.taxReportRepo.AssertNotCalled(t, "FetchDividendReceiptListByYear", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// 外国源泉徴収税額・国内源泉徴収税額・TTMを省略した場合
+func TestCreateDividendReceiptDefaults(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    // 支払日が土曜日の場合は直前の営業日の終値を使う
+    paymentDate := time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC)
+    mocks.marketPriceRepo.On("FetchHistoricalPrices", mock.Anything, "USDJPY", paymentDate.AddDate(0, 0, -7), paymentDate).Return([]marketPrice.HistoricalPriceDto{
+        {Date: time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC), Close: 149},
+        {Date: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), Close: 148},
+    }, nil)
+    expectedDto := taxReport.CreateDividendReceiptDto{UserId: 1, Code: "KO", PaymentDate: "2024-03-16", Amount: 10, ForeignTax: 1, DomesticTax: 269, UsdJpy: 148}
+    mocks.taxReportRepo.On("CreateDividendReceipt", mock.Anything, expectedDto).Return(&model.DividendReceipt{
+        Model: gorm.Model{ID: 5}, Code: "KO", PaymentDate: "2024-03-16", Amount: 10, ForeignTax: 1, DomesticTax: 269, UsdJpy: 148, UserId: 1,
+    }, nil)
+
+    receipt, err := service.CreateDividendReceipt(context.Background(), generated.CreateDividendReceiptInput{Code: " ko ", PaymentDate: "2024-03-16", Amount: 10})
+
+    assert.NoError(t, err)
+    assert.Equal(t, "5", receipt.ID)
+    assert.Equal(t, 1480.0, receipt.AmountJpy)
+    assert.Equal(t, 148.0, receipt.ForeignTaxJpy)
+    mocks.taxReportRepo.AssertExpectations(t)
+}
+
+func TestCreateDividendReceiptValidation(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    foreignTax := 20.0
+
+    _, err := service.CreateDividendReceipt(context.Background(), generated.CreateDividendReceiptInput{Code: "KO", PaymentDate: "2024/03/16", Amount: 10})
+    assert.EqualError(t, err, "input: 日付はYYYY-MM-DD形式で入力してください")
+
+    _, err = service.CreateDividendReceipt(context.Background(), generated.CreateDividendReceiptInput{Code: "KO", PaymentDate: time.Now().AddDate(0, 0, 2).Format("2006-01-02"), Amount: 10})
+    assert.EqualError(t, err, "input: 未来の日付は入力できません")
+
+    _, err = service.CreateDividendReceipt(context.Background(), generated.CreateDividendReceiptInput{Code: "KO", PaymentDate: "2024-03-16", Amount: 10, ForeignTax: &foreignTax})
+    assert.EqualError(t, err, "input: 外国源泉徴収税額は0以上、配当金以下の値を入力してください")
+    mocks.taxReportRepo.AssertNotCalled(t, "CreateDividendReceipt", mock.Anything, mock.Anything)
+}
+
+// 取得単価・取得時のドル円を省略した場合は保有している米国株の加重平均を使う
+func TestCreateStockSaleUsesHolding(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    mocks.stockRepo.On("FetchUsStockListById", mock.Anything, uint(1)).Return([]model.UsStock{
+        {Code: "AAPL", Quantity: 10, GetPrice: 150, UsdJpy: 140},
+        {Code: "AAPL", Quantity: 10, GetPrice: 170, UsdJpy: 150},
+        {Code: "KO", Quantity: 5, GetPrice: 60, UsdJpy: 130},
+    }, nil)
+    usdJpy := 155.0
+    expectedDto := taxReport.CreateStockSaleDto{UserId: 1, Code: "AAPL", TradeDate: "2024-06-03", Quantity: 5, SalePrice: 190, GetPrice: 160, Fee: 1, UsdJpy: 155, GetUsdJpy: 145}
+    mocks.taxReportRepo.On("CreateStockSale", mock.Anything, expectedDto).Return(&model.StockSale{
+        Model: gorm.Model{ID: 3}, Code: "AAPL", TradeDate: "2024-06-03", Quantity: 5, SalePrice: 190, GetPrice: 160, Fee: 1, UsdJpy: 155, GetUsdJpy: 145, UserId: 1,
+    }, nil)
+
+    sale, err := service.CreateStockSale(context.Background(), generated.CreateStockSaleInput{Code: "AAPL", TradeDate: "2024-06-03", Quantity: 5, SalePrice: 190, Fee: 1, UsdJpy: &usdJpy})
+
+    assert.NoError(t, err)
+    assert.Equal(t, 147250.0, sale.Proceeds)
+    assert.Equal(t, 116000.0, sale.Cost)
+    assert.Equal(t, 155.0, sale.FeeJpy)
+    assert.Equal(t, 31095.0, sale.RealizedGain)
+    mocks.taxReportRepo.AssertExpectations(t)
+}
+
+func TestCreateStockSaleNotHeld(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    mocks.stockRepo.On("FetchUsStockListById", mock.Anything, uint(1)).Return([]model.UsStock{}, nil)
+    usdJpy := 155.0
+
+    _, err := service.CreateStockSale(context.Background(), generated.CreateStockSaleInput{Code: "AAPL", TradeDate: "2024-06-03", Quantity: 5, SalePrice: 190, UsdJpy: &usdJpy})
+
+    assert.EqualError(t, err, "input: 保有していない銘柄のため、取得単価と取得日のTTMを入力してください")
+    mocks.taxReportRepo.AssertNotCalled(t, "CreateStockSale", mock.Anything, mock.Anything)
+}
+
+func TestDeleteStockSale(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    mocks.taxReportRepo.On("DeleteStockSale", mock.Anything, uint(3), uint(1)).Return(nil)
+
+    result, err := service.DeleteStockSale(context.Background(), "3")
+
+    assert.NoError(t, err)
+    assert.True(t, result)
+
+    _, err = service.DeleteStockSale(context.Background(), "abc")
+    assert.EqualError(t, err, "input: 入力されたidが無効です")
+}
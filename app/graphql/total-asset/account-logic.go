@@ -0,0 +1,43 @@
+package totalasset
+
+import (
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+)
+
+// 口座別の内訳を資産総額のidごとにまとめる
+func groupTotalAssetAccounts(modelAccounts []model.TotalAssetAccount) map[uint][]*generated.TotalAssetAccount {
+	accountsByTotalAssetId := map[uint][]*generated.TotalAssetAccount{}
+	for _, modelAccount := range modelAccounts {
+		accountsByTotalAssetId[modelAccount.TotalAssetId] = append(accountsByTotalAssetId[modelAccount.TotalAssetId], &generated.TotalAssetAccount{
+			AccountID: utils.ConvertNullableIdToString(modelAccount.AccountId),
+			Stock: modelAccount.Stock,
+			Fund: modelAccount.Fund,
+			Crypto: modelAccount.Crypto,
+			FixedIncomeAsset: modelAccount.FixedIncomeAsset,
+		})
+	}
+	return accountsByTotalAssetId
+}
+
+// 資産総額を指定した口座の評価額に置き換える(その口座の保有資産がなかった場合は0とする)
+func filterByAccount(asset *generated.TotalAsset, accountId string) {
+	asset.CashJpy = 0
+	asset.CashUsd = 0
+	asset.Stock = 0
+	asset.Fund = 0
+	asset.Crypto = 0
+	asset.FixedIncomeAsset = 0
+	accounts := []*generated.TotalAssetAccount{}
+	for _, account := range asset.Accounts {
+		if account.AccountID != nil && *account.AccountID == accountId {
+			asset.Stock = account.Stock
+			asset.Fund = account.Fund
+			asset.Crypto = account.Crypto
+			asset.FixedIncomeAsset = account.FixedIncomeAsset
+			accounts = append(accounts, account)
+		}
+	}
+	asset.Accounts = accounts
+}
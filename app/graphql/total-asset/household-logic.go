@@ -0,0 +1,36 @@
+package totalasset
+
+import (
+	"my-us-stock-backend/app/graphql/generated"
+)
+
+// 世帯のメンバーの資産総額を登録日ごとに合算する
+// (idとCreatedAtはその日に最初に登録されたものを使う。登録していないメンバーがいる日は登録済みのメンバーのみの合計になる)
+func mergeHouseholdAssets(assets []*generated.TotalAsset) []*generated.TotalAsset {
+	merged := []*generated.TotalAsset{}
+	assetByDate := map[string]*generated.TotalAsset{}
+	for _, asset := range assets {
+		// CreatedAtはRFC3339形式のため先頭10文字が日付
+		date := asset.CreatedAt[:10]
+		current, ok := assetByDate[date]
+		if !ok {
+			copied := *asset
+			copied.Accounts = append([]*generated.TotalAssetAccount{}, asset.Accounts...)
+			assetByDate[date] = &copied
+			merged = append(merged, &copied)
+			continue
+		}
+		if asset.CreatedAt < current.CreatedAt {
+			current.ID = asset.ID
+			current.CreatedAt = asset.CreatedAt
+		}
+		current.CashJpy += asset.CashJpy
+		current.CashUsd += asset.CashUsd
+		current.Stock += asset.Stock
+		current.Fund += asset.Fund
+		current.Crypto += asset.Crypto
+		current.FixedIncomeAsset += asset.FixedIncomeAsset
+		current.Accounts = append(current.Accounts, asset.Accounts...)
+	}
+	return merged
+}
@@ -13,8 +13,8 @@ func NewResolver(totalAssetService TotalAssetService) *Resolver {
     return &Resolver{TotalAssetService: totalAssetService}
 }
 
-func (r *Resolver) TotalAssets(ctx context.Context, day int) ([]*generated.TotalAsset, error) {
-    return r.TotalAssetService.TotalAssets(ctx, day)
+func (r *Resolver) TotalAssets(ctx context.Context, day int, accountId *string, householdId *string) ([]*generated.TotalAsset, error) {
+    return r.TotalAssetService.TotalAssets(ctx, day, accountId, householdId)
 }
 
 func (r *Resolver) UpdateTotalAsset(ctx context.Context, input generated.UpdateTotalAssetInput) (*generated.TotalAsset, error) {
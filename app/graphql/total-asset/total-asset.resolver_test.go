@@ -14,8 +14,8 @@ type MockTotalAssetService struct {
 	mock.Mock
 }
 
-func (m *MockTotalAssetService) TotalAssets(ctx context.Context, day int) ([]*generated.TotalAsset, error) {
-	args := m.Called(ctx, day)
+func (m *MockTotalAssetService) TotalAssets(ctx context.Context, day int, accountId *string, householdId *string) ([]*generated.TotalAsset, error) {
+	args := m.Called(ctx, day, accountId, householdId)
 	return args.Get(0).([]*generated.TotalAsset), args.Error(1)
 }
 
@@ -41,9 +41,9 @@ func TestTotalAssets(t *testing.T) {
 			CreatedAt:       "2021-01-01",
 		},
 	}
-	mockService.On("TotalAssets", mock.Anything, 30).Return(totalAssets, nil)
+	mockService.On("TotalAssets", mock.Anything, 30, (*string)(nil), (*string)(nil)).Return(totalAssets, nil)
 
-	result, err := resolver.TotalAssets(context.Background(), 30)
+	result, err := resolver.TotalAssets(context.Background(), 30, nil, nil)
 
 	assert.NoError(t, err)
 	assert.Equal(t, totalAssets, result)
@@ -5,6 +5,7 @@ import (
 	"log"
 	"math"
 	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/common/household"
 	"my-us-stock-backend/app/graphql/generated"
 	"my-us-stock-backend/app/graphql/utils"
 	repoCrypto "my-us-stock-backend/app/repository/assets/crypto"
@@ -15,6 +16,7 @@ import (
 	repoMarketCrypto "my-us-stock-backend/app/repository/market-price/crypto"
 	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
 	repoFundPrice "my-us-stock-backend/app/repository/market-price/fund"
+	repoBrokerageAccount "my-us-stock-backend/app/repository/brokerage-account"
 	repoTotalAsset "my-us-stock-backend/app/repository/total-assets"
 	"sort"
 	"time"
@@ -22,7 +24,7 @@ import (
 
 // TotalAssetService インターフェースの定義
 type TotalAssetService interface {
-    TotalAssets(ctx context.Context, day int) ([]*generated.TotalAsset, error)
+    TotalAssets(ctx context.Context, day int, accountId *string, householdId *string) ([]*generated.TotalAsset, error)
 	UpdateTotalAsset(ctx context.Context, input generated.UpdateTotalAssetInput) (*generated.TotalAsset, error)
 }
 
@@ -38,33 +40,78 @@ type DefaultTotalAssetService struct {
 	FixedIncomeRepo repoFixedIncome.FixedIncomeRepository
 	MarketCryptoRepo repoMarketCrypto.CryptoRepository
 	FundPriceRepo repoFundPrice.FundPriceRepository
+	BrokerageAccountRepo repoBrokerageAccount.BrokerageAccountRepository
+	HouseholdAccess household.HouseholdAccess
 }
 
 // NewTotalAssetService は DefaultUserService の新しいインスタンスを作成します
-func NewTotalAssetService(auth auth.AuthService, totalAssetRepo repoTotalAsset.TotalAssetRepository, stockRepo stock.UsStockRepository, marketPriceRepo marketPrice.MarketPriceRepository, currencyRepo repoCurrency.CurrencyRepository, japanFundRepo repoJapanFund.JapanFundRepository,	cryptoRepo repoCrypto.CryptoRepository,fixedIncomeRepo repoFixedIncome.FixedIncomeRepository, marketCryptoRepo repoMarketCrypto.CryptoRepository, fundPriceRepo repoFundPrice.FundPriceRepository) TotalAssetService {
-	return &DefaultTotalAssetService{auth, totalAssetRepo, stockRepo, marketPriceRepo, currencyRepo, japanFundRepo, cryptoRepo, fixedIncomeRepo, marketCryptoRepo, fundPriceRepo}
+func NewTotalAssetService(auth auth.AuthService, totalAssetRepo repoTotalAsset.TotalAssetRepository, stockRepo stock.UsStockRepository, marketPriceRepo marketPrice.MarketPriceRepository, currencyRepo repoCurrency.CurrencyRepository, japanFundRepo repoJapanFund.JapanFundRepository,	cryptoRepo repoCrypto.CryptoRepository,fixedIncomeRepo repoFixedIncome.FixedIncomeRepository, marketCryptoRepo repoMarketCrypto.CryptoRepository, fundPriceRepo repoFundPrice.FundPriceRepository, brokerageAccountRepo repoBrokerageAccount.BrokerageAccountRepository, householdAccess household.HouseholdAccess) TotalAssetService {
+	return &DefaultTotalAssetService{auth, totalAssetRepo, stockRepo, marketPriceRepo, currencyRepo, japanFundRepo, cryptoRepo, fixedIncomeRepo, marketCryptoRepo, fundPriceRepo, brokerageAccountRepo, householdAccess}
 }
 
-// GetUserByID はユーザーをIDによって検索します
-func (s *DefaultTotalAssetService) TotalAssets(ctx context.Context, day int) ([]*generated.TotalAsset, error) {
+// TotalAssets は資産総額の推移を取得します
+// accountId を指定した場合は、その口座の評価額のみを返します(現金は口座に含まれないため0とし、口座別の内訳がない登録分は除く)
+// householdId を指定した場合は、世帯の全メンバーの資産総額を日付ごとに合算して返します
+func (s *DefaultTotalAssetService) TotalAssets(ctx context.Context, day int, accountId *string, householdId *string) ([]*generated.TotalAsset, error) {
     // アクセストークンの検証
     userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
     if userId == 0 {
         return nil, utils.UnauthenticatedError("Invalid user ID")
     }
-    modelAssets, err := s.TotalAssetRepo.FetchTotalAssetListById(ctx, userId, day)
+    filterAccountId, convertError := utils.ConvertNullableIdToUint(accountId)
+    if convertError != nil {
+        return nil, utils.DefaultGraphQLError("入力された口座idが無効です")
+    }
+    scopeUserIds, err := s.HouseholdAccess.ScopeUserIds(ctx, userId, householdId)
     if err != nil {
         return nil, utils.DefaultGraphQLError(err.Error())
     }
+
+	assets := []*generated.TotalAsset{}
+	for _, scopeUserId := range scopeUserIds {
+		userAssets, err := s.fetchUserTotalAssets(ctx, scopeUserId, day, filterAccountId)
+		if err != nil {
+			return nil, utils.DefaultGraphQLError(err.Error())
+		}
+		assets = append(assets, userAssets...)
+	}
+	if householdId != nil {
+		assets = mergeHouseholdAssets(assets)
+	}
+
+	// assetsをCreatedAtで昇順にソート
+	sort.Slice(assets, func(i, j int) bool {
+		return assets[i].CreatedAt < assets[j].CreatedAt
+	})
+
+    return assets, nil
+}
+
+// 指定したユーザーの資産総額の推移を口座別の内訳と合わせて取得する
+func (s *DefaultTotalAssetService) fetchUserTotalAssets(ctx context.Context, userId uint, day int, filterAccountId *uint) ([]*generated.TotalAsset, error) {
+    modelAssets, err := s.TotalAssetRepo.FetchTotalAssetListById(ctx, userId, day)
+    if err != nil {
+        return nil, err
+    }
 	// modelAssetsが空の場合は空の配列を返却する
 	if len(modelAssets) == 0 {
 		return []*generated.TotalAsset{}, nil
 	}
-
-	assets := make([]*generated.TotalAsset, len(modelAssets))
+	// 口座別の内訳を取得
+	totalAssetIds := make([]uint, len(modelAssets))
 	for i, modelAsset := range modelAssets {
+		totalAssetIds[i] = modelAsset.ID
+	}
+	modelAccounts, err := s.BrokerageAccountRepo.FetchTotalAssetAccounts(ctx, userId, totalAssetIds)
+	if err != nil {
+		return nil, err
+	}
+	accountsByTotalAssetId := groupTotalAssetAccounts(modelAccounts)
 
-		assets[i] = &generated.TotalAsset{
+	assets := make([]*generated.TotalAsset, 0, len(modelAssets))
+	for _, modelAsset := range modelAssets {
+		accounts := accountsByTotalAssetId[modelAsset.ID]
+		asset := &generated.TotalAsset{
 			ID: utils.ConvertIdToString(modelAsset.ID),
 			CashJpy: modelAsset.CashJpy,
 			CashUsd: modelAsset.CashUsd,
@@ -72,16 +119,19 @@ func (s *DefaultTotalAssetService) TotalAssets(ctx context.Context, day int) ([]
 			Fund: modelAsset.Fund,
 			Crypto: modelAsset.Crypto,
 			FixedIncomeAsset: modelAsset.FixedIncomeAsset,
+			Accounts: accounts,
 			CreatedAt: modelAsset.CreatedAt.Format(time.RFC3339),
 		}
+		if filterAccountId != nil {
+			// 口座別の内訳がない登録分は口座の評価額がわからないため除く
+			if len(accounts) == 0 {
+				continue
+			}
+			filterByAccount(asset, utils.ConvertIdToString(*filterAccountId))
+		}
+		assets = append(assets, asset)
 	}
-
-	// assetsをCreatedAtで昇順にソート
-	sort.Slice(assets, func(i, j int) bool {
-		return assets[i].CreatedAt < assets[j].CreatedAt
-	})
-
-    return assets, nil
+	return assets, nil
 }
 
 func (s *DefaultTotalAssetService) UpdateTotalAsset(ctx context.Context, input generated.UpdateTotalAssetInput) (*generated.TotalAsset, error) {
@@ -155,12 +205,17 @@ func (s *DefaultTotalAssetService) UpdateTotalAsset(ctx context.Context, input g
 		log.Fatalf("エラーが発生しました: %v", err)
 		return nil, utils.DefaultGraphQLError("エラーが発生しました")
 	   }
+	   // 満期償還済みの資産は現金に移っているため評価額に含めない
+	   modelAssets = repoFixedIncome.ExcludeMatured(modelAssets)
 	   // 空の場合は計算処理をスキップする
 	   if len(modelAssets) != 0 {
-		   // 仮想通貨の評価総額を計算
-		   for _, modelAsset := range modelAssets {
-			   amountOfFixedIncomeAsset += modelAsset.GetPriceTotal
+		   // 固定利回り資産の評価総額を計算(ドル建ての資産は円に換算する)
+		   fixedIncomeTotal, err := repoFixedIncome.TotalValuationJpy(ctx, s.CurrencyRepo, modelAssets, time.Now())
+		   if err != nil {
+			log.Fatalf("エラーが発生しました: %v", err)
+			return nil, utils.DefaultGraphQLError("エラーが発生しました")
 		   }
+		   amountOfFixedIncomeAsset += fixedIncomeTotal
 	   }
 	   roundedAmountOfStock := math.Round(amountOfStock)
 	   roundedAmountOfFund := math.Round(amountOfFund)
@@ -189,6 +244,8 @@ func (s *DefaultTotalAssetService) UpdateTotalAsset(ctx context.Context, input g
 		Fund: updatedAsset.Fund,
 		Crypto: updatedAsset.Crypto,
 		FixedIncomeAsset: updatedAsset.FixedIncomeAsset,
+		// 更新時は口座別の内訳を再計算しないため返さない
+		Accounts: []*generated.TotalAssetAccount{},
 		CreatedAt: updatedAsset.CreatedAt.Format(time.RFC3339),
 	}, nil
 }
\ No newline at end of file
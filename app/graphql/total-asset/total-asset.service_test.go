@@ -2,6 +2,7 @@ package totalasset
 
 import (
 	"context"
+	"my-us-stock-backend/app/common/household"
 	"my-us-stock-backend/app/common/auth"
 	"my-us-stock-backend/app/database/model"
 	"my-us-stock-backend/app/graphql/generated"
@@ -20,6 +21,7 @@ import (
 	"my-us-stock-backend/app/repository/market-price/currency"
 	"my-us-stock-backend/app/repository/market-price/fund"
 	totalAssetRepo "my-us-stock-backend/app/repository/total-assets"
+	brokerageAccountRepo "my-us-stock-backend/app/repository/brokerage-account"
 )
 
 // MockTotalAssetRepository は TotalAssetRepository のモックです。
@@ -59,27 +61,73 @@ func TestTotalAssetsService(t *testing.T) {
 	mockFixedIncomeAssetRepo := fixedIncomeAssetRepo.NewMockFixedIncomeAssetRepository()
 	mockMarketCryptoRepo := marketCryptoRepo.NewMockCryptoRepository()
 	mockFundPriceRepo := fund.NewMockFundPriceRepository()
-	service := NewTotalAssetService(mockAuth, mockTotalAssetRepo, mockStockRepo, mockMarketPriceRepo, mockCurrencyRepo, mockJapanFundRepo, mockCryptoRepo, mockFixedIncomeAssetRepo,mockMarketCryptoRepo, mockFundPriceRepo)
+	mockBrokerageAccountRepo := brokerageAccountRepo.NewMockBrokerageAccountRepository()
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewTotalAssetService(mockAuth, mockTotalAssetRepo, mockStockRepo, mockMarketPriceRepo, mockCurrencyRepo, mockJapanFundRepo, mockCryptoRepo, mockFixedIncomeAssetRepo,mockMarketCryptoRepo, mockFundPriceRepo, mockBrokerageAccountRepo, mockHouseholdAccess)
 
 	// モックの期待値設定
 	userId := uint(1)
 	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+	mockHouseholdAccess.On("ScopeUserIds", mock.Anything, userId, (*string)(nil)).Return([]uint{userId}, nil)
 
 	mockAssets := []model.TotalAsset{
 		{CashJpy: 10000, CashUsd: 100, Stock: 50000},
 	}
 	mockTotalAssetRepo.On("FetchTotalAssetListById", mock.Anything, userId, 30).Return(mockAssets, nil)
+	mockBrokerageAccountRepo.On("FetchTotalAssetAccounts", mock.Anything, userId, []uint{0}).Return([]model.TotalAssetAccount{}, nil)
 
 	// テスト対象メソッドの実行
-	assets, err := service.TotalAssets(context.Background(), 30)
+	assets, err := service.TotalAssets(context.Background(), 30, nil, nil)
 	assert.NoError(t, err)
 	assert.Len(t, assets, 1)
+	assert.Empty(t, assets[0].Accounts)
 
 	// モックの呼び出しを検証
 	mockTotalAssetRepo.AssertExpectations(t)
 	mockAuth.AssertExpectations(t)
 }
 
+// 口座を指定した場合はその口座の評価額のみを返すことを確認
+func TestTotalAssetsServiceFilterByAccount(t *testing.T) {
+	mockAuth := auth.NewMockAuthService()
+	mockTotalAssetRepo := new(MockTotalAssetRepository)
+	mockBrokerageAccountRepo := brokerageAccountRepo.NewMockBrokerageAccountRepository()
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewTotalAssetService(mockAuth, mockTotalAssetRepo, stock.NewMockUsStockRepository(), marketPrice.NewMockMarketPriceRepository(), currency.NewMockCurrencyRepository(), fundRepo.NewMockJapanFundRepository(), cryptoRepo.NewMockCryptoRepository(), fixedIncomeAssetRepo.NewMockFixedIncomeAssetRepository(), marketCryptoRepo.NewMockCryptoRepository(), fund.NewMockFundPriceRepository(), mockBrokerageAccountRepo, mockHouseholdAccess)
+
+	userId := uint(1)
+	mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+	mockHouseholdAccess.On("ScopeUserIds", mock.Anything, userId, (*string)(nil)).Return([]uint{userId}, nil)
+
+	// id:1 は口座別の内訳がない登録分
+	oldAsset := model.TotalAsset{CashJpy: 10000, Stock: 30000}
+	oldAsset.ID = 1
+	newAsset := model.TotalAsset{CashJpy: 10000, Stock: 50000, Fund: 20000}
+	newAsset.ID = 2
+	mockTotalAssetRepo.On("FetchTotalAssetListById", mock.Anything, userId, 30).Return([]model.TotalAsset{oldAsset, newAsset}, nil)
+
+	nisaAccountId := uint(5)
+	mockBrokerageAccountRepo.On("FetchTotalAssetAccounts", mock.Anything, userId, []uint{1, 2}).Return([]model.TotalAssetAccount{
+		{TotalAssetId: 2, AccountId: nil, Stock: 20000},
+		{TotalAssetId: 2, AccountId: &nisaAccountId, Stock: 30000, Fund: 20000},
+	}, nil)
+
+	accountId := "5"
+	assets, err := service.TotalAssets(context.Background(), 30, &accountId, nil)
+	assert.NoError(t, err)
+	assert.Len(t, assets, 1)
+	assert.Equal(t, "2", assets[0].ID)
+	assert.Equal(t, 30000.0, assets[0].Stock)
+	assert.Equal(t, 20000.0, assets[0].Fund)
+	assert.Equal(t, 0.0, assets[0].CashJpy)
+	assert.Len(t, assets[0].Accounts, 1)
+
+	// 無効な口座idはエラーになる
+	invalidId := "abc"
+	_, err = service.TotalAssets(context.Background(), 30, &invalidId, nil)
+	assert.Error(t, err)
+}
+
 // UpdateTotalAsset メソッドのテスト
 func TestUpdateTotalAssetService(t *testing.T) {
 	mockAuth := auth.NewMockAuthService()
@@ -92,7 +140,9 @@ func TestUpdateTotalAssetService(t *testing.T) {
 	mockFixedIncomeAssetRepo := fixedIncomeAssetRepo.NewMockFixedIncomeAssetRepository()
 	mockMarketCryptoRepo := marketCryptoRepo.NewMockCryptoRepository()
 	mockFundPriceRepo := fund.NewMockFundPriceRepository()
-	service := NewTotalAssetService(mockAuth, mockTotalAssetRepo, mockStockRepo, mockMarketPriceRepo, mockCurrencyRepo, mockJapanFundRepo, mockCryptoRepo, mockFixedIncomeAssetRepo,mockMarketCryptoRepo, mockFundPriceRepo)
+	mockBrokerageAccountRepo := brokerageAccountRepo.NewMockBrokerageAccountRepository()
+	mockHouseholdAccess := household.NewMockHouseholdAccess()
+	service := NewTotalAssetService(mockAuth, mockTotalAssetRepo, mockStockRepo, mockMarketPriceRepo, mockCurrencyRepo, mockJapanFundRepo, mockCryptoRepo, mockFixedIncomeAssetRepo,mockMarketCryptoRepo, mockFundPriceRepo, mockBrokerageAccountRepo, mockHouseholdAccess)
 
 	// モックの期待値設定
 	userId := uint(1)
@@ -0,0 +1,10 @@
+package utils
+
+// 保有資産を口座で絞り込む際に用いる関数
+// 絞り込む口座が指定されていない場合は全ての保有資産を対象とする
+func InAccount(accountId uint, filterAccountId *uint) bool {
+	if filterAccountId == nil {
+		return true
+	}
+	return accountId == *filterAccountId
+}
@@ -0,0 +1,11 @@
+package utils
+
+// GraphQLレスポンスで任意項目の日付を扱う際に用いる関数
+// 外部APIは日付が未定の場合にnull(GOの内部では空文字)を返すため、
+// 空文字の場合はクライアントへのレスポンスもnullとする
+func ConvertDateToNullable(date string) *string {
+	if date == "" {
+		return nil
+	}
+	return &date
+}
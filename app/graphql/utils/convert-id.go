@@ -20,4 +20,24 @@ func ConvertIdToUint(id string)(uint, error) {
 	}
 
 	return uint(u64), nil
-}
\ No newline at end of file
+}
+// 省略可能なIDをGO内部で扱う際に用いる関数(nilの場合はnilを返す)
+func ConvertNullableIdToUint(id *string) (*uint, error) {
+	if id == nil {
+		return nil, nil
+	}
+	u, err := ConvertIdToUint(*id)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// 省略可能なIDをクライアントへのレスポンスで扱う際に用いる関数(nilの場合はnilを返す)
+func ConvertNullableIdToString(id *uint) *string {
+	if id == nil {
+		return nil
+	}
+	s := ConvertIdToString(*id)
+	return &s
+}
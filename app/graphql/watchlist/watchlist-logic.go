@@ -0,0 +1,102 @@
+package watchlist
+
+import (
+	"context"
+	"math"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/utils"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+)
+
+// ウォッチリストに市場価格・配当情報を付与する
+func enrichWatchlists(ctx context.Context, s *DefaultWatchlistService, modelWatchlists []model.Watchlist) ([]*generated.Watchlist, error) {
+    // 外部APIコール数削減のため市場価格は一度に取得する
+    tickers := make([]string, len(modelWatchlists))
+    for i, modelWatchlist := range modelWatchlists {
+        tickers[i] = modelWatchlist.Ticker
+    }
+    marketPrices, err := s.MarketPriceRepo.FetchMarketPriceList(ctx, tickers)
+    if err != nil {
+        return nil, err
+    }
+    marketPriceMap := make(map[string]marketPrice.MarketPriceDto, len(marketPrices))
+    for _, mp := range marketPrices {
+        marketPriceMap[mp.Ticker] = mp
+    }
+
+    // 配当情報は銘柄ごとに並行して取得する
+    type dividendResult struct {
+        index    int
+        dividend *marketPrice.DividendEntity
+        err      error
+    }
+    results := make(chan dividendResult, len(modelWatchlists))
+    for i, modelWatchlist := range modelWatchlists {
+        go func(index int, ticker string) {
+            dividend, err := s.MarketPriceRepo.FetchDividend(ctx, ticker)
+            results <- dividendResult{index: index, dividend: dividend, err: err}
+        }(i, modelWatchlist.Ticker)
+    }
+    dividends := make([]*marketPrice.DividendEntity, len(modelWatchlists))
+    for i := 0; i < len(modelWatchlists); i++ {
+        result := <-results
+        if result.err != nil {
+            return nil, result.err
+        }
+        dividends[result.index] = result.dividend
+    }
+
+    watchlists := make([]*generated.Watchlist, len(modelWatchlists))
+    for i, modelWatchlist := range modelWatchlists {
+        var mp *marketPrice.MarketPriceDto
+        if found, ok := marketPriceMap[modelWatchlist.Ticker]; ok {
+            mp = &found
+        }
+        watchlists[i] = convertToGeneratedWatchlist(modelWatchlist, mp, dividends[i])
+    }
+    return watchlists, nil
+}
+
+// ウォッチリストをレスポンスの型に変換し、目標値への到達状況を判定する
+func convertToGeneratedWatchlist(modelWatchlist model.Watchlist, mp *marketPrice.MarketPriceDto, dividend *marketPrice.DividendEntity) *generated.Watchlist {
+    watchlist := &generated.Watchlist{
+        ID:          utils.ConvertIdToString(modelWatchlist.ID),
+        Ticker:      modelWatchlist.Ticker,
+        Note:        modelWatchlist.Note,
+        TargetPrice: modelWatchlist.TargetPrice,
+        TargetYield: modelWatchlist.TargetYield,
+    }
+    if dividend != nil {
+        watchlist.Dividend = dividend.DividendTotal
+    }
+    // 市場価格が取得できない場合は価格関連の項目を0とする
+    if mp == nil {
+        return watchlist
+    }
+    watchlist.CurrentPrice = mp.CurrentPrice
+    watchlist.PriceGets = mp.PriceGets
+    watchlist.CurrentRate = mp.CurrentRate
+    watchlist.YearHigh = mp.YearHigh
+    watchlist.YearLow = mp.YearLow
+    watchlist.Pe = mp.Pe
+    watchlist.EarningsAnnouncement = utils.ConvertDateToNullable(mp.EarningsAnnouncement)
+    if mp.CurrentPrice > 0 {
+        watchlist.DividendYield = math.Round(watchlist.Dividend/mp.CurrentPrice*10000) / 100
+    }
+    if modelWatchlist.TargetPrice != nil && mp.CurrentPrice > 0 {
+        watchlist.ReachedTargetPrice = mp.CurrentPrice <= *modelWatchlist.TargetPrice
+    }
+    if modelWatchlist.TargetYield != nil && mp.CurrentPrice > 0 {
+        watchlist.ReachedTargetYield = watchlist.DividendYield >= *modelWatchlist.TargetYield
+    }
+    return watchlist
+}
+
+// メモが未入力の場合は空文字とする
+func convertNote(note *string) string {
+    if note == nil {
+        return ""
+    }
+    return *note
+}
@@ -0,0 +1,34 @@
+package watchlist
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+)
+
+type Resolver struct {
+    WatchlistService WatchlistService
+}
+
+func NewResolver(watchlistService WatchlistService) *Resolver {
+    return &Resolver{WatchlistService: watchlistService}
+}
+
+func (r *Resolver) Watchlists(ctx context.Context) ([]*generated.Watchlist, error) {
+    return r.WatchlistService.Watchlists(ctx)
+}
+
+func (r *Resolver) CreateWatchlist(ctx context.Context, input generated.CreateWatchlistInput) (*generated.Watchlist, error) {
+    return r.WatchlistService.CreateWatchlist(ctx, input)
+}
+
+func (r *Resolver) UpdateWatchlist(ctx context.Context, input generated.UpdateWatchlistInput) (*generated.Watchlist, error) {
+    return r.WatchlistService.UpdateWatchlist(ctx, input)
+}
+
+func (r *Resolver) DeleteWatchlist(ctx context.Context, id string) (bool, error) {
+    return r.WatchlistService.DeleteWatchlist(ctx, id)
+}
+
+func (r *Resolver) ConvertWatchlistToUsStock(ctx context.Context, input generated.ConvertWatchlistToUsStockInput) (*generated.UsStock, error) {
+    return r.WatchlistService.ConvertWatchlistToUsStock(ctx, input)
+}
@@ -0,0 +1,115 @@
+package watchlist
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/generated"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWatchlistService は WatchlistService のモックです。
+type MockWatchlistService struct {
+    mock.Mock
+}
+
+func (m *MockWatchlistService) Watchlists(ctx context.Context) ([]*generated.Watchlist, error) {
+    args := m.Called(ctx)
+    return args.Get(0).([]*generated.Watchlist), args.Error(1)
+}
+
+func (m *MockWatchlistService) CreateWatchlist(ctx context.Context, input generated.CreateWatchlistInput) (*generated.Watchlist, error) {
+    args := m.Called(ctx, input)
+    return args.Get(0).(*generated.Watchlist), args.Error(1)
+}
+
+func (m *MockWatchlistService) UpdateWatchlist(ctx context.Context, input generated.UpdateWatchlistInput) (*generated.Watchlist, error) {
+    args := m.Called(ctx, input)
+    return args.Get(0).(*generated.Watchlist), args.Error(1)
+}
+
+func (m *MockWatchlistService) DeleteWatchlist(ctx context.Context, id string) (bool, error) {
+    args := m.Called(ctx, id)
+    return args.Get(0).(bool), args.Error(1)
+}
+
+func (m *MockWatchlistService) ConvertWatchlistToUsStock(ctx context.Context, input generated.ConvertWatchlistToUsStockInput) (*generated.UsStock, error) {
+    args := m.Called(ctx, input)
+    return args.Get(0).(*generated.UsStock), args.Error(1)
+}
+
+func TestWatchlists(t *testing.T) {
+    mockService := new(MockWatchlistService)
+    resolver := NewResolver(mockService)
+
+    watchlists := []*generated.Watchlist{
+        {ID: "1", Ticker: "KO", Note: "高配当", CurrentPrice: 57.205, Dividend: 1.84},
+    }
+    mockService.On("Watchlists", mock.Anything).Return(watchlists, nil)
+
+    result, err := resolver.Watchlists(context.Background())
+
+    assert.NoError(t, err)
+    assert.Equal(t, watchlists, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestCreateWatchlist(t *testing.T) {
+    mockService := new(MockWatchlistService)
+    resolver := NewResolver(mockService)
+
+    input := generated.CreateWatchlistInput{Ticker: "KO"}
+    mockResponse := &generated.Watchlist{ID: "1", Ticker: "KO"}
+    mockService.On("CreateWatchlist", mock.Anything, input).Return(mockResponse, nil)
+
+    result, err := resolver.CreateWatchlist(context.Background(), input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, mockResponse, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestUpdateWatchlist(t *testing.T) {
+    mockService := new(MockWatchlistService)
+    resolver := NewResolver(mockService)
+
+    note := "決算後に判断"
+    input := generated.UpdateWatchlistInput{ID: "1", Note: &note}
+    mockResponse := &generated.Watchlist{ID: "1", Ticker: "KO", Note: note}
+    mockService.On("UpdateWatchlist", mock.Anything, input).Return(mockResponse, nil)
+
+    result, err := resolver.UpdateWatchlist(context.Background(), input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, mockResponse, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestDeleteWatchlist(t *testing.T) {
+    mockService := new(MockWatchlistService)
+    resolver := NewResolver(mockService)
+
+    mockService.On("DeleteWatchlist", mock.Anything, "1").Return(true, nil)
+
+    result, err := resolver.DeleteWatchlist(context.Background(), "1")
+
+    assert.NoError(t, err)
+    assert.True(t, result)
+    mockService.AssertExpectations(t)
+}
+
+func TestConvertWatchlistToUsStock(t *testing.T) {
+    mockService := new(MockWatchlistService)
+    resolver := NewResolver(mockService)
+
+    input := generated.ConvertWatchlistToUsStockInput{ID: "1", GetPrice: 57.0, Quantity: 10, UsdJpy: 145.0}
+    mockResponse := &generated.UsStock{ID: "5", Code: "KO", GetPrice: 57.0, Quantity: 10, UsdJpy: 145.0}
+    mockService.On("ConvertWatchlistToUsStock", mock.Anything, input).Return(mockResponse, nil)
+
+    result, err := resolver.ConvertWatchlistToUsStock(context.Background(), input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, mockResponse, result)
+    mockService.AssertExpectations(t)
+}
@@ -0,0 +1,163 @@
+package watchlist
+
+import (
+	"context"
+	"log"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	"my-us-stock-backend/app/graphql/stock"
+	"my-us-stock-backend/app/graphql/utils"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	"my-us-stock-backend/app/repository/watchlist"
+)
+
+// WatchlistService インターフェースの定義
+type WatchlistService interface {
+    Watchlists(ctx context.Context) ([]*generated.Watchlist, error)
+    CreateWatchlist(ctx context.Context, input generated.CreateWatchlistInput) (*generated.Watchlist, error)
+    UpdateWatchlist(ctx context.Context, input generated.UpdateWatchlistInput) (*generated.Watchlist, error)
+    DeleteWatchlist(ctx context.Context, id string) (bool, error)
+    ConvertWatchlistToUsStock(ctx context.Context, input generated.ConvertWatchlistToUsStockInput) (*generated.UsStock, error)
+}
+
+// DefaultWatchlistService 構造体の定義
+type DefaultWatchlistService struct {
+    WatchlistRepo watchlist.WatchlistRepository
+    MarketPriceRepo marketPrice.MarketPriceRepository
+    Auth auth.AuthService
+    UsStockService stock.UsStockService
+}
+
+// NewWatchlistService は DefaultWatchlistService の新しいインスタンスを作成します
+func NewWatchlistService(watchlistRepo watchlist.WatchlistRepository, auth auth.AuthService, marketPriceRepo marketPrice.MarketPriceRepository, usStockService stock.UsStockService) WatchlistService {
+    return &DefaultWatchlistService{WatchlistRepo: watchlistRepo, Auth: auth, MarketPriceRepo: marketPriceRepo, UsStockService: usStockService}
+}
+
+// Watchlists はユーザーのウォッチリストを市場価格・配当情報付きで取得します
+func (s *DefaultWatchlistService) Watchlists(ctx context.Context) ([]*generated.Watchlist, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+
+    modelWatchlists, err := s.WatchlistRepo.FetchWatchlistListById(ctx, userId)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    // modelWatchlistsが空の場合は空の配列を返却する
+    if len(modelWatchlists) == 0 {
+        return []*generated.Watchlist{}, nil
+    }
+    watchlists, err := enrichWatchlists(ctx, s, modelWatchlists)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return watchlists, nil
+}
+
+// ウォッチリストに銘柄を追加します
+func (s *DefaultWatchlistService) CreateWatchlist(ctx context.Context, input generated.CreateWatchlistInput) (*generated.Watchlist, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+
+    createDto := watchlist.CreateWatchlistDto{
+        Ticker:      input.Ticker,
+        Note:        convertNote(input.Note),
+        TargetPrice: input.TargetPrice,
+        TargetYield: input.TargetYield,
+        UserId:      userId,
+    }
+    modelWatchlist, err := s.WatchlistRepo.CreateWatchlist(ctx, createDto)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    watchlists, err := enrichWatchlists(ctx, s, []model.Watchlist{*modelWatchlist})
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return watchlists[0], nil
+}
+
+// ウォッチリストのメモ・目標値を更新します
+func (s *DefaultWatchlistService) UpdateWatchlist(ctx context.Context, input generated.UpdateWatchlistInput) (*generated.Watchlist, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+
+    updateId, convertError := utils.ConvertIdToUint(input.ID)
+    if convertError != nil || updateId == 0 {
+        return nil, utils.DefaultGraphQLError("入力されたidが無効です")
+    }
+    updateDto := watchlist.UpdateWatchlistDto{
+        ID:          updateId,
+        Note:        convertNote(input.Note),
+        TargetPrice: input.TargetPrice,
+        TargetYield: input.TargetYield,
+        UserId:      userId,
+    }
+    modelWatchlist, err := s.WatchlistRepo.UpdateWatchlist(ctx, updateDto)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    watchlists, err := enrichWatchlists(ctx, s, []model.Watchlist{*modelWatchlist})
+    if err != nil {
+        return nil, utils.DefaultGraphQLError(err.Error())
+    }
+    return watchlists[0], nil
+}
+
+// ウォッチリストから銘柄を削除します
+func (s *DefaultWatchlistService) DeleteWatchlist(ctx context.Context, id string) (bool, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return false, utils.UnauthenticatedError("Invalid user ID")
+    }
+
+    deleteId, convertError := utils.ConvertIdToUint(id)
+    if convertError != nil || deleteId == 0 {
+        return false, utils.DefaultGraphQLError("入力されたidが無効です")
+    }
+    if err := s.WatchlistRepo.DeleteWatchlist(ctx, deleteId, userId); err != nil {
+        return false, utils.DefaultGraphQLError(err.Error())
+    }
+    return true, nil
+}
+
+// ConvertWatchlistToUsStock はウォッチリストの銘柄を保有銘柄として登録し、ウォッチリストから削除します
+func (s *DefaultWatchlistService) ConvertWatchlistToUsStock(ctx context.Context, input generated.ConvertWatchlistToUsStockInput) (*generated.UsStock, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, utils.UnauthenticatedError("Invalid user ID")
+    }
+
+    watchlistId, convertError := utils.ConvertIdToUint(input.ID)
+    if convertError != nil || watchlistId == 0 {
+        return nil, utils.DefaultGraphQLError("入力されたidが無効です")
+    }
+    modelWatchlist, err := s.WatchlistRepo.FindWatchlistById(ctx, watchlistId, userId)
+    if err != nil {
+        return nil, utils.DefaultGraphQLError("指定されたウォッチリストが見つかりません")
+    }
+
+    // 保有銘柄の登録(企業情報の補完・重複チェックは米国株の登録処理に任せる)
+    usStock, err := s.UsStockService.CreateUsStock(ctx, generated.CreateUsStockInput{
+        Code:      modelWatchlist.Ticker,
+        GetPrice:  input.GetPrice,
+        Quantity:  input.Quantity,
+        UsdJpy:    input.UsdJpy,
+        Sector:    input.Sector,
+        AccountID: input.AccountID,
+    })
+    if err != nil {
+        return nil, err
+    }
+    // 保有銘柄は登録済みのため、ウォッチリストの削除に失敗してもエラーにはしない
+    if err := s.WatchlistRepo.DeleteWatchlist(ctx, watchlistId, userId); err != nil {
+        log.Printf("ウォッチリストの削除に失敗しました(id: %d): %v", watchlistId, err)
+    }
+    return usStock, nil
+}
@@ -0,0 +1,179 @@
+package watchlist
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/graphql/generated"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	repo "my-us-stock-backend/app/repository/watchlist"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockUsStockService は UsStockService のモックです。
+type MockUsStockService struct {
+    mock.Mock
+}
+
+func (m *MockUsStockService) UsStocks(ctx context.Context, accountId *string, householdId *string) ([]*generated.UsStock, error) {
+    args := m.Called(ctx, accountId, householdId)
+    return args.Get(0).([]*generated.UsStock), args.Error(1)
+}
+
+func (m *MockUsStockService) CreateUsStock(ctx context.Context, input generated.CreateUsStockInput) (*generated.UsStock, error) {
+    args := m.Called(ctx, input)
+    return args.Get(0).(*generated.UsStock), args.Error(1)
+}
+
+func (m *MockUsStockService) UpdateUsStock(ctx context.Context, input generated.UpdateUsStockInput) (*generated.UsStock, error) {
+    args := m.Called(ctx, input)
+    return args.Get(0).(*generated.UsStock), args.Error(1)
+}
+
+func (m *MockUsStockService) DeleteUsStock(ctx context.Context, id string) (bool, error) {
+    args := m.Called(ctx, id)
+    return args.Get(0).(bool), args.Error(1)
+}
+
+// Watchlists は市場価格・配当情報と目標値の到達状況を付与して返却する
+func TestWatchlistsService(t *testing.T) {
+    mockRepo := repo.NewMockWatchlistRepository()
+    mockAuth := auth.NewMockAuthService()
+    mockMarketPriceRepo := marketPrice.NewMockMarketPriceRepository()
+    service := NewWatchlistService(mockRepo, mockAuth, mockMarketPriceRepo, new(MockUsStockService))
+
+    userId := uint(1)
+    targetPrice := 60.0
+    targetYield := 3.5
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mockRepo.On("FetchWatchlistListById", mock.Anything, userId).Return([]model.Watchlist{
+        {Model: gorm.Model{ID: 1}, Ticker: "KO", Note: "高配当", TargetPrice: &targetPrice, TargetYield: &targetYield, UserId: userId},
+        {Model: gorm.Model{ID: 2}, Ticker: "MSFT", UserId: userId},
+    }, nil)
+    mockMarketPriceRepo.On("FetchMarketPriceList", mock.Anything, []string{"KO", "MSFT"}).Return([]marketPrice.MarketPriceDto{
+        {Ticker: "KO", CurrentPrice: 50.0, PriceGets: 0.1, CurrentRate: 0.2, YearHigh: 64.99, YearLow: 51.55, Pe: 24.16},
+        {Ticker: "MSFT", CurrentPrice: 374.58, EarningsAnnouncement: "2024-01-30T21:00:00.000+0000"},
+    }, nil)
+    mockMarketPriceRepo.On("FetchDividend", mock.Anything, "KO").Return(&marketPrice.DividendEntity{Ticker: "KO", DividendTotal: 1.84}, nil)
+    mockMarketPriceRepo.On("FetchDividend", mock.Anything, "MSFT").Return(&marketPrice.DividendEntity{Ticker: "MSFT", DividendTotal: 2.84}, nil)
+
+    watchlists, err := service.Watchlists(context.Background())
+
+    assert.NoError(t, err)
+    assert.Len(t, watchlists, 2)
+    assert.Equal(t, "1", watchlists[0].ID)
+    assert.Equal(t, "高配当", watchlists[0].Note)
+    assert.Equal(t, 50.0, watchlists[0].CurrentPrice)
+    assert.Equal(t, 1.84, watchlists[0].Dividend)
+    assert.Equal(t, 3.68, watchlists[0].DividendYield)
+    assert.True(t, watchlists[0].ReachedTargetPrice)
+    assert.True(t, watchlists[0].ReachedTargetYield)
+    // 目標値が未設定の場合は到達しない
+    assert.Equal(t, "MSFT", watchlists[1].Ticker)
+    assert.Equal(t, 0.76, watchlists[1].DividendYield)
+    assert.False(t, watchlists[1].ReachedTargetPrice)
+    assert.False(t, watchlists[1].ReachedTargetYield)
+    assert.Equal(t, "2024-01-30T21:00:00.000+0000", *watchlists[1].EarningsAnnouncement)
+
+    mockRepo.AssertExpectations(t)
+    mockMarketPriceRepo.AssertExpectations(t)
+}
+
+func TestCreateWatchlistService(t *testing.T) {
+    mockRepo := repo.NewMockWatchlistRepository()
+    mockAuth := auth.NewMockAuthService()
+    mockMarketPriceRepo := marketPrice.NewMockMarketPriceRepository()
+    service := NewWatchlistService(mockRepo, mockAuth, mockMarketPriceRepo, new(MockUsStockService))
+
+    userId := uint(1)
+    targetPrice := 350.0
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mockRepo.On("CreateWatchlist", mock.Anything, repo.CreateWatchlistDto{Ticker: "MSFT", TargetPrice: &targetPrice, UserId: userId}).Return(&model.Watchlist{Model: gorm.Model{ID: 3}, Ticker: "MSFT", TargetPrice: &targetPrice, UserId: userId}, nil)
+    mockMarketPriceRepo.On("FetchMarketPriceList", mock.Anything, []string{"MSFT"}).Return([]marketPrice.MarketPriceDto{{Ticker: "MSFT", CurrentPrice: 374.58}}, nil)
+    mockMarketPriceRepo.On("FetchDividend", mock.Anything, "MSFT").Return(&marketPrice.DividendEntity{Ticker: "MSFT", DividendTotal: 2.84}, nil)
+
+    watchlist, err := service.CreateWatchlist(context.Background(), generated.CreateWatchlistInput{Ticker: "MSFT", TargetPrice: &targetPrice})
+
+    assert.NoError(t, err)
+    assert.Equal(t, "3", watchlist.ID)
+    assert.Equal(t, "", watchlist.Note)
+    assert.Equal(t, 374.58, watchlist.CurrentPrice)
+    assert.False(t, watchlist.ReachedTargetPrice)
+    mockRepo.AssertExpectations(t)
+}
+
+// 不正なidの場合はエラー
+func TestUpdateWatchlistServiceInvalidId(t *testing.T) {
+    mockRepo := repo.NewMockWatchlistRepository()
+    mockAuth := auth.NewMockAuthService()
+    service := NewWatchlistService(mockRepo, mockAuth, marketPrice.NewMockMarketPriceRepository(), new(MockUsStockService))
+
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+
+    _, err := service.UpdateWatchlist(context.Background(), generated.UpdateWatchlistInput{ID: "abc"})
+
+    assert.Error(t, err)
+    mockRepo.AssertNotCalled(t, "UpdateWatchlist", mock.Anything, mock.Anything)
+}
+
+func TestDeleteWatchlistService(t *testing.T) {
+    mockRepo := repo.NewMockWatchlistRepository()
+    mockAuth := auth.NewMockAuthService()
+    service := NewWatchlistService(mockRepo, mockAuth, marketPrice.NewMockMarketPriceRepository(), new(MockUsStockService))
+
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    mockRepo.On("DeleteWatchlist", mock.Anything, uint(3), uint(1)).Return(nil)
+
+    result, err := service.DeleteWatchlist(context.Background(), "3")
+
+    assert.NoError(t, err)
+    assert.True(t, result)
+    mockRepo.AssertExpectations(t)
+}
+
+// ウォッチリストの銘柄が指定した口座の保有銘柄として登録され、ウォッチリストからは削除される
+func TestConvertWatchlistToUsStockService(t *testing.T) {
+    mockRepo := repo.NewMockWatchlistRepository()
+    mockAuth := auth.NewMockAuthService()
+    mockUsStockService := new(MockUsStockService)
+    service := NewWatchlistService(mockRepo, mockAuth, marketPrice.NewMockMarketPriceRepository(), mockUsStockService)
+
+    userId := uint(1)
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mockRepo.On("FindWatchlistById", mock.Anything, uint(3), userId).Return(&model.Watchlist{Model: gorm.Model{ID: 3}, Ticker: "MSFT", UserId: userId}, nil)
+    accountId := "5"
+    createInput := generated.CreateUsStockInput{Code: "MSFT", GetPrice: 370.0, Quantity: 2, UsdJpy: 145.0, AccountID: &accountId}
+    usStock := &generated.UsStock{ID: "10", Code: "MSFT", GetPrice: 370.0, Quantity: 2, UsdJpy: 145.0, Sector: "Technology"}
+    mockUsStockService.On("CreateUsStock", mock.Anything, createInput).Return(usStock, nil)
+    mockRepo.On("DeleteWatchlist", mock.Anything, uint(3), userId).Return(nil)
+
+    result, err := service.ConvertWatchlistToUsStock(context.Background(), generated.ConvertWatchlistToUsStockInput{ID: "3", GetPrice: 370.0, Quantity: 2, UsdJpy: 145.0, AccountID: &accountId})
+
+    assert.NoError(t, err)
+    assert.Equal(t, usStock, result)
+    mockRepo.AssertExpectations(t)
+    mockUsStockService.AssertExpectations(t)
+}
+
+// 保有銘柄の登録に失敗した場合はウォッチリストを削除しない
+func TestConvertWatchlistToUsStockServiceCreateError(t *testing.T) {
+    mockRepo := repo.NewMockWatchlistRepository()
+    mockAuth := auth.NewMockAuthService()
+    mockUsStockService := new(MockUsStockService)
+    service := NewWatchlistService(mockRepo, mockAuth, marketPrice.NewMockMarketPriceRepository(), mockUsStockService)
+
+    userId := uint(1)
+    mockAuth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mockRepo.On("FindWatchlistById", mock.Anything, uint(3), userId).Return(&model.Watchlist{Model: gorm.Model{ID: 3}, Ticker: "MSFT", UserId: userId}, nil)
+    mockUsStockService.On("CreateUsStock", mock.Anything, mock.Anything).Return((*generated.UsStock)(nil), errors.New("この銘柄は既に登録されています"))
+
+    _, err := service.ConvertWatchlistToUsStock(context.Background(), generated.ConvertWatchlistToUsStockInput{ID: "3", GetPrice: 370.0, Quantity: 2, UsdJpy: 145.0})
+
+    assert.EqualError(t, err, "この銘柄は既に登録されています")
+    mockRepo.AssertNotCalled(t, "DeleteWatchlist", mock.Anything, mock.Anything, mock.Anything)
+}
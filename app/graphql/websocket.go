@@ -0,0 +1,57 @@
+package graphql
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// Subscription用のWebSocketトランスポートを作成します
+// 認証はHTTPと同じく接続時の access_token クッキーで行うため、許可していないオリジンからの接続は拒否します
+func newWebsocketTransport() transport.Websocket {
+    return transport.Websocket{
+        Upgrader: websocket.Upgrader{
+            CheckOrigin: checkWebsocketOrigin,
+        },
+        KeepAlivePingInterval: 10 * time.Second,
+    }
+}
+
+// Originヘッダーがない(ブラウザ以外)、同一オリジン、または CLIENT_URL に含まれるオリジンのみ許可する
+func checkWebsocketOrigin(r *http.Request) bool {
+    origin := r.Header.Get("Origin")
+    if origin == "" {
+        return true
+    }
+    originURL, err := url.Parse(origin)
+    if err != nil {
+        return false
+    }
+    if strings.EqualFold(originURL.Host, r.Host) {
+        return true
+    }
+    for _, clientURL := range strings.Split(os.Getenv("CLIENT_URL"), ",") {
+        if strings.TrimRight(strings.TrimSpace(clientURL), "/") == origin {
+            return true
+        }
+    }
+    return false
+}
+
+// WebSocketのアップグレード要求はGraphQLハンドラへ、それ以外はPlaygroundへ振り分けます
+func WebsocketOrPlaygroundHandler(graphQLHandler gin.HandlerFunc) gin.HandlerFunc {
+    playgroundHandler := PlaygroundHandler()
+    return func(c *gin.Context) {
+        if websocket.IsWebSocketUpgrade(c.Request) {
+            graphQLHandler(c)
+            return
+        }
+        playgroundHandler(c)
+    }
+}
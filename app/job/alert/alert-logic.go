@@ -0,0 +1,197 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"my-us-stock-backend/app/database/model"
+	repoAlert "my-us-stock-backend/app/repository/alert"
+	"sync"
+	"time"
+)
+
+// 監視対象
+const (
+    targetTypeStockPrice    = "STOCK_PRICE"
+    targetTypeDividendYield = "DIVIDEND_YIELD"
+    targetTypeFxRate        = "FX_RATE"
+    targetTypeCryptoPrice   = "CRYPTO_PRICE"
+)
+
+// 発生条件
+const (
+    conditionAbove = "ABOVE"
+    conditionBelow = "BELOW"
+)
+
+// filterEvaluableRules は再通知までの間隔が経過していないアラート条件を除外します
+func filterEvaluableRules(alertRules []model.AlertRule, now time.Time) []model.AlertRule {
+    evaluableRules := make([]model.AlertRule, 0, len(alertRules))
+    for _, alertRule := range alertRules {
+        if isInCooldown(alertRule, now) {
+            continue
+        }
+        evaluableRules = append(evaluableRules, alertRule)
+    }
+    return evaluableRules
+}
+
+func isInCooldown(alertRule model.AlertRule, now time.Time) bool {
+    if alertRule.Mode != repoAlert.ModeCooldown || alertRule.LastTriggeredAt == nil {
+        return false
+    }
+    cooldownEnd := alertRule.LastTriggeredAt.Add(time.Duration(alertRule.CooldownMinutes) * time.Minute)
+    return now.Before(cooldownEnd)
+}
+
+// isTriggered は現在値が閾値の条件を満たしているか判定します(閾値と同じ値の場合も満たすとする)
+func isTriggered(condition string, value float64, threshold float64) bool {
+    switch condition {
+    case conditionAbove:
+        return value >= threshold
+    case conditionBelow:
+        return value <= threshold
+    default:
+        return false
+    }
+}
+
+// createAlertMessage は通知用の件名と本文を作成します
+func createAlertMessage(alertRule model.AlertRule, value float64) (string, string) {
+    var label string
+    switch alertRule.TargetType {
+    case targetTypeStockPrice:
+        label = alertRule.Symbol + " の株価"
+    case targetTypeDividendYield:
+        label = alertRule.Symbol + " の配当利回り(%)"
+    case targetTypeFxRate:
+        label = alertRule.Symbol + " の為替レート"
+    case targetTypeCryptoPrice:
+        label = alertRule.Symbol + " の価格(円)"
+    }
+    conditionLabel := "以上"
+    if alertRule.Condition == conditionBelow {
+        conditionLabel = "以下"
+    }
+    subject := fmt.Sprintf("[アラート] %sが %g %sになりました", label, alertRule.Threshold, conditionLabel)
+    body := fmt.Sprintf("現在値: %g\n条件: %g %s", value, alertRule.Threshold, conditionLabel)
+    return subject, body
+}
+
+func valueKey(targetType string, symbol string) string {
+    return targetType + ":" + symbol
+}
+
+// fetchCurrentValues はアラート条件の判定に必要な現在値を監視対象ごとにまとめて取得します
+// 取得に失敗した監視対象はログを出力して結果に含めません
+func fetchCurrentValues(ctx context.Context, e *DefaultAlertEvaluator, alertRules []model.AlertRule) map[string]float64 {
+    currentValues := make(map[string]float64)
+
+    var stockTickers, yieldTickers, cryptoCodes []string
+    needsUsdJpy := false
+    seen := make(map[string]bool)
+    for _, alertRule := range alertRules {
+        key := valueKey(alertRule.TargetType, alertRule.Symbol)
+        if seen[key] {
+            continue
+        }
+        seen[key] = true
+        switch alertRule.TargetType {
+        case targetTypeStockPrice:
+            stockTickers = append(stockTickers, alertRule.Symbol)
+        case targetTypeDividendYield:
+            yieldTickers = append(yieldTickers, alertRule.Symbol)
+        case targetTypeFxRate:
+            needsUsdJpy = true
+        case targetTypeCryptoPrice:
+            cryptoCodes = append(cryptoCodes, alertRule.Symbol)
+        }
+    }
+
+    // 株価は配当利回りの計算にも使うため、まとめて取得する
+    prices := fetchStockPrices(ctx, e, uniqueStrings(append(append([]string{}, stockTickers...), yieldTickers...)))
+    for _, ticker := range stockTickers {
+        if price, ok := prices[ticker]; ok {
+            currentValues[valueKey(targetTypeStockPrice, ticker)] = price
+        }
+    }
+    for ticker, yield := range fetchDividendYields(ctx, e, yieldTickers, prices) {
+        currentValues[valueKey(targetTypeDividendYield, ticker)] = yield
+    }
+
+    if needsUsdJpy {
+        usdJpy, err := e.CurrencyRepo.FetchCurrentUsdJpy(ctx)
+        if err != nil {
+            log.Printf("為替レートの取得に失敗しました: %v", err)
+        } else {
+            currentValues[valueKey(targetTypeFxRate, "USDJPY")] = usdJpy
+        }
+    }
+
+    for _, code := range cryptoCodes {
+        crypto, err := e.MarketCryptoRepo.FetchCryptoPrice(code)
+        if err != nil {
+            log.Printf("仮想通貨(%s)の価格の取得に失敗しました: %v", code, err)
+            continue
+        }
+        currentValues[valueKey(targetTypeCryptoPrice, code)] = crypto.Price
+    }
+    return currentValues
+}
+
+func fetchStockPrices(ctx context.Context, e *DefaultAlertEvaluator, tickers []string) map[string]float64 {
+    prices := make(map[string]float64)
+    if len(tickers) == 0 {
+        return prices
+    }
+    marketPrices, err := e.MarketPriceRepo.FetchMarketPriceList(ctx, tickers)
+    if err != nil {
+        log.Printf("株価の取得に失敗しました: %v", err)
+        return prices
+    }
+    for _, mp := range marketPrices {
+        prices[mp.Ticker] = mp.CurrentPrice
+    }
+    return prices
+}
+
+// fetchDividendYields は年間配当と株価から配当利回り(%)を計算します
+func fetchDividendYields(ctx context.Context, e *DefaultAlertEvaluator, tickers []string, prices map[string]float64) map[string]float64 {
+    yields := make(map[string]float64)
+    var mu sync.Mutex
+    var wg sync.WaitGroup
+
+    for _, ticker := range tickers {
+        price, ok := prices[ticker]
+        if !ok || price <= 0 {
+            continue
+        }
+        wg.Add(1)
+        go func(ticker string, price float64) {
+            defer wg.Done()
+            dividend, err := e.MarketPriceRepo.FetchDividend(ctx, ticker)
+            if err != nil {
+                log.Printf("配当情報(%s)の取得に失敗しました: %v", ticker, err)
+                return
+            }
+            mu.Lock()
+            yields[ticker] = math.Round(dividend.DividendTotal/price*10000) / 100
+            mu.Unlock()
+        }(ticker, price)
+    }
+    wg.Wait()
+    return yields
+}
+
+func uniqueStrings(values []string) []string {
+    seen := make(map[string]bool)
+    var unique []string
+    for _, v := range values {
+        if !seen[v] {
+            seen[v] = true
+            unique = append(unique, v)
+        }
+    }
+    return unique
+}
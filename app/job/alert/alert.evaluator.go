@@ -0,0 +1,77 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"log"
+	"my-us-stock-backend/app/common/notification"
+	repoAlert "my-us-stock-backend/app/repository/alert"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	marketCrypto "my-us-stock-backend/app/repository/market-price/crypto"
+	"my-us-stock-backend/app/repository/market-price/currency"
+	"time"
+)
+
+// AlertEvaluator インターフェースの定義
+type AlertEvaluator interface {
+    EvaluateAlertRules(ctx context.Context) error
+}
+
+// DefaultAlertEvaluator 構造体の定義
+type DefaultAlertEvaluator struct {
+    AlertRepo repoAlert.AlertRepository
+    MarketPriceRepo marketPrice.MarketPriceRepository
+    CurrencyRepo currency.CurrencyRepository
+    MarketCryptoRepo marketCrypto.CryptoRepository
+    NotificationService notification.NotificationService
+}
+
+// NewAlertEvaluator は DefaultAlertEvaluator の新しいインスタンスを作成します
+func NewAlertEvaluator(alertRepo repoAlert.AlertRepository, marketPriceRepo marketPrice.MarketPriceRepository, currencyRepo currency.CurrencyRepository, marketCryptoRepo marketCrypto.CryptoRepository, notificationService notification.NotificationService) AlertEvaluator {
+    return &DefaultAlertEvaluator{AlertRepo: alertRepo, MarketPriceRepo: marketPriceRepo, CurrencyRepo: currencyRepo, MarketCryptoRepo: marketCryptoRepo, NotificationService: notificationService}
+}
+
+// EvaluateAlertRules は有効なアラート条件を現在の価格・為替・配当利回りと比較し、条件を満たしたものを記録します
+func (e *DefaultAlertEvaluator) EvaluateAlertRules(ctx context.Context) error {
+    return e.evaluateAlertRules(ctx, time.Now())
+}
+
+func (e *DefaultAlertEvaluator) evaluateAlertRules(ctx context.Context, now time.Time) error {
+    alertRules, err := e.AlertRepo.FetchEnabledAlertRuleList(ctx)
+    if err != nil {
+        return err
+    }
+
+    // 再通知までの間隔が経過していないものは判定しない
+    targetRules := filterEvaluableRules(alertRules, now)
+    if len(targetRules) == 0 {
+        return nil
+    }
+
+    currentValues := fetchCurrentValues(ctx, e, targetRules)
+    for _, alertRule := range targetRules {
+        value, ok := currentValues[valueKey(alertRule.TargetType, alertRule.Symbol)]
+        if !ok || !isTriggered(alertRule.Condition, value, alertRule.Threshold) {
+            continue
+        }
+        _, err := e.AlertRepo.RecordTriggeredAlert(ctx, repoAlert.CreateTriggeredAlertDto{
+            AlertRuleId: alertRule.ID,
+            Value:       value,
+            TriggeredAt: now,
+        })
+        // 他の実行が先に記録した場合は通知しない
+        if errors.Is(err, repoAlert.ErrAlertAlreadyTriggered) {
+            continue
+        }
+        // 記録に失敗しても他のアラートの判定は続ける
+        if err != nil {
+            log.Printf("アラート(id: %d)の記録に失敗しました: %v", alertRule.ID, err)
+            continue
+        }
+        subject, body := createAlertMessage(alertRule, value)
+        if err := e.NotificationService.Notify(ctx, alertRule.UserId, notification.EventAlertTriggered, subject, body); err != nil {
+            log.Printf("アラート(id: %d)の通知に失敗しました: %v", alertRule.ID, err)
+        }
+    }
+    return nil
+}
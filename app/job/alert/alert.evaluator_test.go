@@ -0,0 +1,134 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/common/notification"
+	"my-us-stock-backend/app/database/model"
+	repoAlert "my-us-stock-backend/app/repository/alert"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	marketCrypto "my-us-stock-backend/app/repository/market-price/crypto"
+	"my-us-stock-backend/app/repository/market-price/currency"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func newTestEvaluator() (*DefaultAlertEvaluator, *repoAlert.MockAlertRepository, *marketPrice.MockMarketPriceRepository, *currency.MockCurrencyRepository, *marketCrypto.MockCryptoRepository, *notification.MockNotificationService) {
+    mockAlertRepo := repoAlert.NewMockAlertRepository()
+    mockMarketPriceRepo := marketPrice.NewMockMarketPriceRepository()
+    mockCurrencyRepo := currency.NewMockCurrencyRepository()
+    mockCryptoRepo := marketCrypto.NewMockCryptoRepository()
+    mockNotificationService := notification.NewMockNotificationService()
+    evaluator := &DefaultAlertEvaluator{AlertRepo: mockAlertRepo, MarketPriceRepo: mockMarketPriceRepo, CurrencyRepo: mockCurrencyRepo, MarketCryptoRepo: mockCryptoRepo, NotificationService: mockNotificationService}
+    return evaluator, mockAlertRepo, mockMarketPriceRepo, mockCurrencyRepo, mockCryptoRepo, mockNotificationService
+}
+
+// 株価・配当利回り・為替・仮想通貨の各条件を判定し、満たしたものだけを記録する
+func TestEvaluateAlertRules(t *testing.T) {
+    evaluator, mockAlertRepo, mockMarketPriceRepo, mockCurrencyRepo, mockCryptoRepo, mockNotificationService := newTestEvaluator()
+
+    now := time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)
+    mockAlertRepo.On("FetchEnabledAlertRuleList", mock.Anything).Return([]model.AlertRule{
+        {Model: gorm.Model{ID: 1}, TargetType: "STOCK_PRICE", Symbol: "AAPL", Condition: "BELOW", Threshold: 170, Mode: "ONCE", Enabled: true, UserId: 1},
+        {Model: gorm.Model{ID: 2}, TargetType: "DIVIDEND_YIELD", Symbol: "KO", Condition: "ABOVE", Threshold: 4, Mode: "COOLDOWN", CooldownMinutes: 60, Enabled: true},
+        {Model: gorm.Model{ID: 3}, TargetType: "FX_RATE", Symbol: "USDJPY", Condition: "ABOVE", Threshold: 150, Mode: "ONCE", Enabled: true},
+        {Model: gorm.Model{ID: 4}, TargetType: "CRYPTO_PRICE", Symbol: "btc", Condition: "ABOVE", Threshold: 10000000, Mode: "ONCE", Enabled: true},
+    }, nil)
+    mockMarketPriceRepo.On("FetchMarketPriceList", mock.Anything, []string{"AAPL", "KO"}).Return([]marketPrice.MarketPriceDto{
+        {Ticker: "AAPL", CurrentPrice: 168.5},
+        {Ticker: "KO", CurrentPrice: 45.0},
+    }, nil)
+    mockMarketPriceRepo.On("FetchDividend", mock.Anything, "KO").Return(&marketPrice.DividendEntity{Ticker: "KO", DividendTotal: 1.84}, nil)
+    mockCurrencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(149.5, nil)
+    mockCryptoRepo.On("FetchCryptoPrice", "btc").Return(&marketCrypto.Crypto{Name: "btc", Price: 10500000}, nil)
+    mockAlertRepo.On("RecordTriggeredAlert", mock.Anything, mock.Anything).Return(&model.TriggeredAlert{}, nil)
+    mockNotificationService.On("Notify", mock.Anything, mock.Anything, notification.EventAlertTriggered, mock.Anything, mock.Anything).Return(nil)
+
+    err := evaluator.evaluateAlertRules(context.Background(), now)
+
+    assert.NoError(t, err)
+    mockAlertRepo.AssertCalled(t, "RecordTriggeredAlert", mock.Anything, repoAlert.CreateTriggeredAlertDto{AlertRuleId: 1, Value: 168.5, TriggeredAt: now})
+    mockNotificationService.AssertCalled(t, "Notify", mock.Anything, uint(1), notification.EventAlertTriggered, "[アラート] AAPL の株価が 170 以下になりました", "現在値: 168.5\n条件: 170 以下")
+    mockNotificationService.AssertNumberOfCalls(t, "Notify", 3)
+    mockAlertRepo.AssertCalled(t, "RecordTriggeredAlert", mock.Anything, repoAlert.CreateTriggeredAlertDto{AlertRuleId: 2, Value: 4.09, TriggeredAt: now})
+    mockAlertRepo.AssertCalled(t, "RecordTriggeredAlert", mock.Anything, repoAlert.CreateTriggeredAlertDto{AlertRuleId: 4, Value: 10500000, TriggeredAt: now})
+    // 為替は閾値に届いていない
+    mockAlertRepo.AssertNumberOfCalls(t, "RecordTriggeredAlert", 3)
+}
+
+// 再通知までの間隔が経過していないアラートは価格を取得せずに判定を省く
+func TestEvaluateAlertRulesCooldown(t *testing.T) {
+    evaluator, mockAlertRepo, mockMarketPriceRepo, _, _, _ := newTestEvaluator()
+
+    now := time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)
+    lastTriggeredAt := now.Add(-30 * time.Minute)
+    mockAlertRepo.On("FetchEnabledAlertRuleList", mock.Anything).Return([]model.AlertRule{
+        {Model: gorm.Model{ID: 1}, TargetType: "STOCK_PRICE", Symbol: "AAPL", Condition: "BELOW", Threshold: 170, Mode: "COOLDOWN", CooldownMinutes: 60, Enabled: true, LastTriggeredAt: &lastTriggeredAt},
+    }, nil)
+
+    err := evaluator.evaluateAlertRules(context.Background(), now)
+
+    assert.NoError(t, err)
+    mockMarketPriceRepo.AssertNotCalled(t, "FetchMarketPriceList", mock.Anything, mock.Anything)
+    mockAlertRepo.AssertNotCalled(t, "RecordTriggeredAlert", mock.Anything, mock.Anything)
+}
+
+// 価格の取得に失敗した監視対象は判定せず、他の監視対象の判定は続ける
+func TestEvaluateAlertRulesFetchError(t *testing.T) {
+    evaluator, mockAlertRepo, mockMarketPriceRepo, mockCurrencyRepo, _, mockNotificationService := newTestEvaluator()
+
+    now := time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)
+    mockAlertRepo.On("FetchEnabledAlertRuleList", mock.Anything).Return([]model.AlertRule{
+        {Model: gorm.Model{ID: 1}, TargetType: "STOCK_PRICE", Symbol: "AAPL", Condition: "BELOW", Threshold: 170, Mode: "ONCE", Enabled: true},
+        {Model: gorm.Model{ID: 2}, TargetType: "FX_RATE", Symbol: "USDJPY", Condition: "ABOVE", Threshold: 150, Mode: "ONCE", Enabled: true},
+    }, nil)
+    mockMarketPriceRepo.On("FetchMarketPriceList", mock.Anything, []string{"AAPL"}).Return([]marketPrice.MarketPriceDto{}, errors.New("api error"))
+    mockCurrencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(151.0, nil)
+    mockAlertRepo.On("RecordTriggeredAlert", mock.Anything, mock.Anything).Return(&model.TriggeredAlert{}, nil)
+    mockNotificationService.On("Notify", mock.Anything, mock.Anything, notification.EventAlertTriggered, mock.Anything, mock.Anything).Return(nil)
+
+    err := evaluator.evaluateAlertRules(context.Background(), now)
+
+    assert.NoError(t, err)
+    mockAlertRepo.AssertCalled(t, "RecordTriggeredAlert", mock.Anything, repoAlert.CreateTriggeredAlertDto{AlertRuleId: 2, Value: 151.0, TriggeredAt: now})
+    mockAlertRepo.AssertNumberOfCalls(t, "RecordTriggeredAlert", 1)
+}
+
+func TestIsTriggered(t *testing.T) {
+    assert.True(t, isTriggered("ABOVE", 150, 150))
+    assert.False(t, isTriggered("ABOVE", 149.9, 150))
+    assert.True(t, isTriggered("BELOW", 170, 170))
+    assert.False(t, isTriggered("BELOW", 170.1, 170))
+    assert.False(t, isTriggered("UNKNOWN", 1, 1))
+}
+
+func TestIsInCooldown(t *testing.T) {
+    now := time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)
+    lastTriggeredAt := now.Add(-60 * time.Minute)
+
+    assert.False(t, isInCooldown(model.AlertRule{Mode: "COOLDOWN", CooldownMinutes: 60}, now))
+    assert.False(t, isInCooldown(model.AlertRule{Mode: "COOLDOWN", CooldownMinutes: 60, LastTriggeredAt: &lastTriggeredAt}, now))
+    assert.True(t, isInCooldown(model.AlertRule{Mode: "COOLDOWN", CooldownMinutes: 61, LastTriggeredAt: &lastTriggeredAt}, now))
+    assert.False(t, isInCooldown(model.AlertRule{Mode: "ONCE", LastTriggeredAt: &lastTriggeredAt}, now))
+}
+
+// 他の実行が先に記録したアラートは通知しない
+func TestEvaluateAlertRulesAlreadyTriggered(t *testing.T) {
+    evaluator, mockAlertRepo, _, mockCurrencyRepo, _, mockNotificationService := newTestEvaluator()
+
+    now := time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)
+    mockAlertRepo.On("FetchEnabledAlertRuleList", mock.Anything).Return([]model.AlertRule{
+        {Model: gorm.Model{ID: 1}, TargetType: "FX_RATE", Symbol: "USDJPY", Condition: "ABOVE", Threshold: 150, Mode: "ONCE", Enabled: true, UserId: 1},
+    }, nil)
+    mockCurrencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(151.0, nil)
+    mockAlertRepo.On("RecordTriggeredAlert", mock.Anything, mock.Anything).Return((*model.TriggeredAlert)(nil), repoAlert.ErrAlertAlreadyTriggered)
+
+    err := evaluator.evaluateAlertRules(context.Background(), now)
+
+    assert.NoError(t, err)
+    mockNotificationService.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
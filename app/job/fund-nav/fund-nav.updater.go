@@ -0,0 +1,100 @@
+package fundnav
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"my-us-stock-backend/app/repository/market-price/fund"
+	repoFundNav "my-us-stock-backend/app/repository/market-price/fund-nav"
+	"sort"
+	"time"
+)
+
+// FundNavUpdater インターフェースの定義
+type FundNavUpdater interface {
+    UpdateFundNavs(ctx context.Context) error
+}
+
+// DefaultFundNavUpdater 構造体の定義
+type DefaultFundNavUpdater struct {
+    FundPriceRepo fund.FundPriceRepository
+    FundNavRepo repoFundNav.FundNavRepository
+}
+
+// NewFundNavUpdater は DefaultFundNavUpdater の新しいインスタンスを作成します
+func NewFundNavUpdater(fundPriceRepo fund.FundPriceRepository, fundNavRepo repoFundNav.FundNavRepository) FundNavUpdater {
+    return &DefaultFundNavUpdater{FundPriceRepo: fundPriceRepo, FundNavRepo: fundNavRepo}
+}
+
+// UpdateFundNavs は登録済み・保有中の投資信託の基準価額を取得して価格を更新します
+// 取得に失敗した投資信託は前回の価格のまま、失敗したことを記録します
+func (u *DefaultFundNavUpdater) UpdateFundNavs(ctx context.Context) error {
+    return u.updateFundNavs(ctx, time.Now())
+}
+
+func (u *DefaultFundNavUpdater) updateFundNavs(ctx context.Context, now time.Time) error {
+    fundPrices, err := u.FundPriceRepo.FetchFundPriceList(ctx)
+    if err != nil {
+        return err
+    }
+    heldCodes, err := u.FundPriceRepo.FetchHeldFundCodes(ctx)
+    if err != nil {
+        return err
+    }
+
+    // 登録済みの投資信託と、価格が未登録の保有中の投資信託を対象とする
+    names := map[string]string{}
+    for _, fundPrice := range fundPrices {
+        names[fundPrice.Code] = fundPrice.Name
+    }
+    codes := make([]string, 0, len(fundPrices)+len(heldCodes))
+    for code := range names {
+        codes = append(codes, code)
+    }
+    for _, code := range heldCodes {
+        if _, ok := names[code]; !ok {
+            codes = append(codes, code)
+        }
+    }
+    sort.Strings(codes)
+
+    updatedCount := 0
+    failedCodes := []string{}
+    for _, code := range codes {
+        // 1件の失敗で他の投資信託の更新は止めない
+        if err := u.updateFundNav(ctx, code, names[code], now); err != nil {
+            log.Printf("投資信託(code: %s)の基準価額の取得に失敗しました: %v", code, err)
+            failedCodes = append(failedCodes, code)
+            if err := u.FundPriceRepo.RecordFundNavFailure(ctx, code, err.Error(), now); err != nil {
+                log.Printf("投資信託(code: %s)の取得失敗の記録に失敗しました: %v", code, err)
+            }
+            continue
+        }
+        updatedCount++
+    }
+
+    log.Printf("投資信託の基準価額を%d件更新しました", updatedCount)
+    if len(failedCodes) > 0 {
+        return fmt.Errorf("%d件の投資信託の基準価額を取得できませんでした: %v", len(failedCodes), failedCodes)
+    }
+    return nil
+}
+
+func (u *DefaultFundNavUpdater) updateFundNav(ctx context.Context, code string, name string, now time.Time) error {
+    nav, err := u.FundNavRepo.FetchFundNav(ctx, code)
+    if err != nil {
+        return err
+    }
+    // 登録済みの名称は管理画面で設定されたものを優先する
+    if name == "" {
+        name = nav.Name
+    }
+    _, err = u.FundPriceRepo.UpsertFundNav(ctx, fund.UpsertFundNavDto{
+        Name: name,
+        Code: code,
+        Price: nav.Nav,
+        NavDate: nav.Date,
+        FetchedAt: now,
+    })
+    return err
+}
@@ -0,0 +1,45 @@
+package fundnav
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/repository/market-price/fund"
+	repoFundNav "my-us-stock-backend/app/repository/market-price/fund-nav"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// 取得できた基準価額で価格を更新し、取得できなかった投資信託は失敗を記録する
+func TestUpdateFundNavs(t *testing.T) {
+    mockFundPriceRepo := fund.NewMockFundPriceRepository()
+    mockFundNavRepo := repoFundNav.NewMockFundNavRepository()
+    updater := &DefaultFundNavUpdater{FundPriceRepo: mockFundPriceRepo, FundNavRepo: mockFundNavRepo}
+
+    now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+    navDate := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+    mockFundPriceRepo.On("FetchFundPriceList", mock.Anything).Return([]model.FundPrice{
+        {Name: "登録済みファンド", Code: "FUND0001", Price: 20000},
+        {Name: "取得できないファンド", Code: "FUND0002", Price: 15000},
+    }, nil)
+    mockFundPriceRepo.On("FetchHeldFundCodes", mock.Anything).Return([]string{"FUND0001", "FUND0003"}, nil)
+    mockFundNavRepo.On("FetchFundNav", mock.Anything, "FUND0001").Return(&repoFundNav.FundNav{Code: "FUND0001", Name: "Provider Name", Nav: 20150, Date: navDate}, nil)
+    mockFundNavRepo.On("FetchFundNav", mock.Anything, "FUND0002").Return(nil, errors.New("unexpected status code: 404"))
+    mockFundNavRepo.On("FetchFundNav", mock.Anything, "FUND0003").Return(&repoFundNav.FundNav{Code: "FUND0003", Name: "未登録ファンド", Nav: 12000, Date: navDate}, nil)
+    mockFundPriceRepo.On("UpsertFundNav", mock.Anything, mock.Anything).Return(&model.FundPrice{}, nil)
+    mockFundPriceRepo.On("RecordFundNavFailure", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+    err := updater.updateFundNavs(context.Background(), now)
+
+    assert.Error(t, err)
+    assert.Contains(t, err.Error(), "FUND0002")
+    mockFundPriceRepo.AssertCalled(t, "UpsertFundNav", mock.Anything, fund.UpsertFundNavDto{Name: "登録済みファンド", Code: "FUND0001", Price: 20150, NavDate: navDate, FetchedAt: now})
+    // 価格が未登録の保有中の投資信託は取得元の名称で登録する
+    mockFundPriceRepo.AssertCalled(t, "UpsertFundNav", mock.Anything, fund.UpsertFundNavDto{Name: "未登録ファンド", Code: "FUND0003", Price: 12000, NavDate: navDate, FetchedAt: now})
+    mockFundPriceRepo.AssertNumberOfCalls(t, "UpsertFundNav", 2)
+    mockFundPriceRepo.AssertCalled(t, "RecordFundNavFailure", mock.Anything, "FUND0002", "unexpected status code: 404", now)
+    mockFundPriceRepo.AssertNumberOfCalls(t, "RecordFundNavFailure", 1)
+}
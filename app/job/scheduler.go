@@ -0,0 +1,37 @@
+package job
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// RunEvery は ctx がキャンセルされるまで interval ごとに fn を実行します
+// 起動直後に1回実行し、失敗してもログを出力して次回の実行を続けます
+func RunEvery(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+    ticker := time.NewTicker(interval)
+    defer ticker.Stop()
+
+    for ctx.Err() == nil {
+        if err := fn(ctx); err != nil {
+            log.Printf("%s の実行に失敗しました: %v", name, err)
+        }
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+        }
+    }
+}
+
+// intervalFromEnv は環境変数から実行間隔(分)を読み込みます
+// 未設定・不正な値の場合は defaultMinutes を使用します
+func intervalFromEnv(key string, defaultMinutes int) time.Duration {
+    minutes, err := strconv.Atoi(os.Getenv(key))
+    if err != nil || minutes <= 0 {
+        minutes = defaultMinutes
+    }
+    return time.Duration(minutes) * time.Minute
+}
@@ -0,0 +1,46 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 失敗しても実行を続け、キャンセルされると終了する
+func TestRunEvery(t *testing.T) {
+    ctx, cancel := context.WithCancel(context.Background())
+    count := 0
+    done := make(chan struct{})
+
+    go func() {
+        RunEvery(ctx, "test", time.Millisecond, func(ctx context.Context) error {
+            count++
+            if count == 3 {
+                cancel()
+            }
+            return errors.New("failed")
+        })
+        close(done)
+    }()
+
+    select {
+    case <-done:
+    case <-time.After(time.Second):
+        t.Fatal("RunEvery did not stop after cancel")
+    }
+    assert.Equal(t, 3, count)
+}
+
+func TestIntervalFromEnv(t *testing.T) {
+    t.Setenv("TEST_INTERVAL_MINUTES", "10")
+    assert.Equal(t, 10*time.Minute, intervalFromEnv("TEST_INTERVAL_MINUTES", 5))
+
+    t.Setenv("TEST_INTERVAL_MINUTES", "0")
+    assert.Equal(t, 5*time.Minute, intervalFromEnv("TEST_INTERVAL_MINUTES", 5))
+
+    t.Setenv("TEST_INTERVAL_MINUTES", "abc")
+    assert.Equal(t, 5*time.Minute, intervalFromEnv("TEST_INTERVAL_MINUTES", 5))
+}
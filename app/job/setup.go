@@ -0,0 +1,83 @@
+package job
+
+import (
+	"context"
+	"log"
+	"my-us-stock-backend/app/common/housekeeping"
+	"my-us-stock-backend/app/common/notification"
+	"my-us-stock-backend/app/job/alert"
+	fundNav "my-us-stock-backend/app/job/fund-nav"
+	repoAccount "my-us-stock-backend/app/repository/account"
+	repoAlert "my-us-stock-backend/app/repository/alert"
+	repoFixedIncome "my-us-stock-backend/app/repository/assets/fixed-income"
+	repoDeletedHolding "my-us-stock-backend/app/repository/deleted-holding"
+	repoMarketPrice "my-us-stock-backend/app/repository/market-price"
+	repoMarketCrypto "my-us-stock-backend/app/repository/market-price/crypto"
+	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
+	repoFundPrice "my-us-stock-backend/app/repository/market-price/fund"
+	repoFundNav "my-us-stock-backend/app/repository/market-price/fund-nav"
+	repoNotification "my-us-stock-backend/app/repository/notification"
+	"os"
+
+	"gorm.io/gorm"
+)
+
+// 実行間隔(分)のデフォルト値
+const (
+    defaultAlertEvaluationIntervalMinutes = 5
+    defaultNotificationDispatchIntervalMinutes = 1
+    defaultAccountPurgeIntervalMinutes = 60
+    defaultDeletedHoldingPurgeIntervalMinutes = 60
+    defaultFixedIncomeMaturityIntervalMinutes = 60
+    defaultFundNavUpdateIntervalMinutes = 60
+)
+
+// SetupJobs はバックグラウンドで定期実行するジョブを起動します
+func SetupJobs(ctx context.Context, db *gorm.DB) {
+    // リポジトリの初期化
+    alertRepo := repoAlert.NewAlertRepository(db)
+    marketPriceRepo := repoMarketPrice.NewMarketPriceRepository(nil)
+    currencyRepo := repoCurrency.NewCurrencyRepository(nil)
+    marketCryptoRepo := repoMarketCrypto.NewCryptoRepository(nil)
+    notificationRepo := repoNotification.NewNotificationRepository(db)
+    accountRepo := repoAccount.NewAccountRepository(db)
+    deletedHoldingRepo := repoDeletedHolding.NewDeletedHoldingRepository(db)
+    fixedIncomeAssetRepo := repoFixedIncome.NewFixedIncomeRepository(db)
+    fundPriceRepo := repoFundPrice.NewFetchFundRepository(db)
+    fundNavRepo := repoFundNav.NewFundNavRepository(nil)
+
+    notificationService := notification.NewNotificationService(notificationRepo, nil)
+
+    // アラート条件の判定
+    alertEvaluator := alert.NewAlertEvaluator(alertRepo, marketPriceRepo, currencyRepo, marketCryptoRepo, notificationService)
+    alertInterval := intervalFromEnv("ALERT_EVALUATION_INTERVAL_MINUTES", defaultAlertEvaluationIntervalMinutes)
+    go RunEvery(ctx, "アラート判定", alertInterval, alertEvaluator.EvaluateAlertRules)
+
+    // 送信キューに登録された通知の送信・再送
+    notificationInterval := intervalFromEnv("NOTIFICATION_DISPATCH_INTERVAL_MINUTES", defaultNotificationDispatchIntervalMinutes)
+    go RunEvery(ctx, "通知送信", notificationInterval, notificationService.DispatchPendingNotifications)
+
+    housekeeper := housekeeping.NewHousekeeper(accountRepo, deletedHoldingRepo, fixedIncomeAssetRepo, currencyRepo, housekeeping.RetentionFromEnv())
+
+    // 猶予期間が終了した退会ユーザーのデータ削除
+    accountPurgeInterval := intervalFromEnv("ACCOUNT_PURGE_INTERVAL_MINUTES", defaultAccountPurgeIntervalMinutes)
+    go RunEvery(ctx, "退会ユーザーの削除", accountPurgeInterval, housekeeper.PurgeScheduledAccounts)
+
+    // 保持期間(DELETED_HOLDING_RETENTION_DAYS)を過ぎた削除済みの保有資産の完全削除
+    deletedHoldingPurgeInterval := intervalFromEnv("DELETED_HOLDING_PURGE_INTERVAL_MINUTES", defaultDeletedHoldingPurgeIntervalMinutes)
+    go RunEvery(ctx, "削除済みの保有資産の完全削除", deletedHoldingPurgeInterval, housekeeper.PurgeExpiredHoldings)
+
+    // 満期日を迎えた固定利回り資産の償還(償還金額を現金に移す)
+    fixedIncomeMaturityInterval := intervalFromEnv("FIXED_INCOME_MATURITY_INTERVAL_MINUTES", defaultFixedIncomeMaturityIntervalMinutes)
+    go RunEvery(ctx, "固定利回り資産の満期償還", fixedIncomeMaturityInterval, housekeeper.MatureFixedIncomeAssets)
+
+    // 投資信託の基準価額の取得(FUND_NAV_URL)と価格の更新
+    // 取得先が未設定の場合は毎回すべての投資信託が取得失敗になるため起動しない
+    if os.Getenv("FUND_NAV_URL") == "" {
+        log.Println("FUND_NAV_URL が未設定のため投資信託の基準価額の更新ジョブを起動しません")
+    } else {
+        fundNavUpdater := fundNav.NewFundNavUpdater(fundPriceRepo, fundNavRepo)
+        fundNavUpdateInterval := intervalFromEnv("FUND_NAV_UPDATE_INTERVAL_MINUTES", defaultFundNavUpdateIntervalMinutes)
+        go RunEvery(ctx, "投資信託の基準価額の更新", fundNavUpdateInterval, fundNavUpdater.UpdateFundNavs)
+    }
+}
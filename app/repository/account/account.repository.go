@@ -0,0 +1,100 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/database/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ユーザーに紐づく全てのテーブル(外部キーで参照される側を後に削除する)
+var userDataTables = []interface{}{
+    &model.UsStock{},
+    &model.Crypto{},
+    &model.JapanFund{},
+    &model.FixedIncomeAsset{},
+    &model.BrokerageAccount{},
+    &model.TotalAssetAccount{},
+    &model.NisaPurchase{},
+    &model.HouseholdMember{},
+    &model.Household{},
+    &model.ShareToken{},
+    &model.TotalAsset{},
+    &model.Watchlist{},
+    &model.TriggeredAlert{},
+    &model.AlertRule{},
+    &model.NotificationDelivery{},
+    &model.NotificationChannel{},
+    &model.CalendarToken{},
+    &model.DailyReport{},
+    &model.DividendReceipt{},
+    &model.StockSale{},
+}
+
+// AccountRepository インターフェースの定義
+type AccountRepository interface {
+    DeleteAccount(ctx context.Context, userId uint) error
+    ScheduleAccountDeletion(ctx context.Context, userId uint, purgeAt time.Time) error
+    FetchAccountIdsToPurge(ctx context.Context, now time.Time) ([]uint, error)
+}
+
+// DefaultAccountRepository 構造体の定義
+type DefaultAccountRepository struct {
+    DB *gorm.DB
+}
+
+// NewAccountRepository は DefaultAccountRepository の新しいインスタンスを作成します
+func NewAccountRepository(db *gorm.DB) AccountRepository {
+    return &DefaultAccountRepository{DB: db}
+}
+
+// DeleteAccount はユーザーと紐づく全てのデータを1つのトランザクションで物理削除します(論理削除済みのデータも含む)
+func (r *DefaultAccountRepository) DeleteAccount(ctx context.Context, userId uint) error {
+    return r.DB.Transaction(func(tx *gorm.DB) error {
+        // 作成した世帯は他のメンバーの所属も含めて削除する
+        ownedHouseholds := tx.Unscoped().Model(&model.Household{}).Select("id").Where("user_id = ?", userId)
+        if err := tx.Unscoped().Where("household_id IN (?)", ownedHouseholds).Delete(&model.HouseholdMember{}).Error; err != nil {
+            return err
+        }
+        for _, table := range userDataTables {
+            if err := tx.Unscoped().Where("user_id = ?", userId).Delete(table).Error; err != nil {
+                return err
+            }
+        }
+        result := tx.Unscoped().Delete(&model.User{}, userId)
+        if result.Error != nil {
+            return result.Error
+        }
+        if result.RowsAffected == 0 {
+            return errors.New("ユーザーが見つかりません")
+        }
+        return nil
+    })
+}
+
+// ScheduleAccountDeletion はユーザーを論理削除し、猶予期間の終了日時を記録します
+// 猶予期間中はログインできず、データは終了日時を過ぎてから DeleteAccount で削除します
+func (r *DefaultAccountRepository) ScheduleAccountDeletion(ctx context.Context, userId uint, purgeAt time.Time) error {
+    return r.DB.Transaction(func(tx *gorm.DB) error {
+        result := tx.Model(&model.User{}).Where("id = ?", userId).Update("purge_at", purgeAt)
+        if result.Error != nil {
+            return result.Error
+        }
+        if result.RowsAffected == 0 {
+            return errors.New("ユーザーが見つかりません")
+        }
+        return tx.Delete(&model.User{}, userId).Error
+    })
+}
+
+// FetchAccountIdsToPurge は猶予期間が終了したユーザーのIDを取得します
+func (r *DefaultAccountRepository) FetchAccountIdsToPurge(ctx context.Context, now time.Time) ([]uint, error) {
+    var userIds []uint
+    err := r.DB.Unscoped().Model(&model.User{}).Where("purge_at IS NOT NULL AND purge_at <= ?", now).Order("id").Pluck("id", &userIds).Error
+    if err != nil {
+        return nil, err
+    }
+    return userIds, nil
+}
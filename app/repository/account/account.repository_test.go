@@ -0,0 +1,96 @@
+package account
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// テスト用のデータベース設定
+func setupTestDB() *gorm.DB {
+    db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+    if err != nil {
+        panic("failed to connect database")
+    }
+    // テスト用のテーブルを準備
+    db.AutoMigrate(append([]interface{}{&model.User{}}, userDataTables...)...)
+    return db
+}
+
+func countRows(db *gorm.DB, userId uint) int64 {
+    var total int64
+    for _, table := range userDataTables {
+        var count int64
+        db.Unscoped().Model(table).Where("user_id = ?", userId).Count(&count)
+        total += count
+    }
+    return total
+}
+
+func TestDeleteAccount(t *testing.T) {
+    db := setupTestDB()
+    repo := NewAccountRepository(db)
+
+    db.Create(&model.User{Model: gorm.Model{ID: 1}, Name: "taro", Email: "taro@example.com", Password: "hashed"})
+    db.Create(&model.User{Model: gorm.Model{ID: 2}, Name: "hanako", Email: "hanako@example.com", Password: "hashed"})
+    for _, userId := range []uint{1, 2} {
+        db.Create(&model.UsStock{Code: "AAPL", Quantity: 10, Sector: "Technology", UserId: userId})
+        db.Create(&model.TotalAsset{CashJpy: 100, UserId: userId})
+        channel := model.NotificationChannel{Type: "WEBHOOK", Name: "hook", Target: "https://example.com", UserId: userId}
+        db.Create(&channel)
+        db.Create(&model.NotificationDelivery{ChannelId: channel.ID, Event: "ALERT", Subject: "s", Status: "SENT", UserId: userId})
+        db.Create(&model.DividendReceipt{Code: "KO", PaymentDate: "2024-04-01", Amount: 46, UserId: userId})
+    }
+    // 論理削除済みのデータも削除する
+    deleted := model.Crypto{Code: "btc", Quantity: 1, UserId: 1}
+    db.Create(&deleted)
+    db.Delete(&deleted)
+
+    err := repo.DeleteAccount(context.Background(), 1)
+
+    assert.NoError(t, err)
+    assert.Equal(t, int64(0), countRows(db, 1))
+    var count int64
+    db.Unscoped().Model(&model.User{}).Where("id = ?", 1).Count(&count)
+    assert.Equal(t, int64(0), count)
+    // 他のユーザーのデータは残る
+    assert.Equal(t, int64(5), countRows(db, 2))
+
+    err = repo.DeleteAccount(context.Background(), 1)
+    assert.EqualError(t, err, "ユーザーが見つかりません")
+}
+
+func TestScheduleAccountDeletion(t *testing.T) {
+    db := setupTestDB()
+    repo := NewAccountRepository(db)
+
+    db.Create(&model.User{Model: gorm.Model{ID: 1}, Name: "taro", Email: "taro@example.com", Password: "hashed"})
+    db.Create(&model.User{Model: gorm.Model{ID: 2}, Name: "hanako", Email: "hanako@example.com", Password: "hashed"})
+    db.Create(&model.UsStock{Code: "AAPL", Quantity: 10, Sector: "Technology", UserId: 1})
+    now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+    err := repo.ScheduleAccountDeletion(context.Background(), 1, now.AddDate(0, 0, 7))
+    assert.NoError(t, err)
+    err = repo.ScheduleAccountDeletion(context.Background(), 2, now.AddDate(0, 0, 30))
+    assert.NoError(t, err)
+
+    // 猶予期間中はユーザーを取得できないが、データは残る
+    var user model.User
+    assert.Error(t, db.First(&user, 1).Error)
+    assert.Equal(t, int64(1), countRows(db, 1))
+
+    ids, err := repo.FetchAccountIdsToPurge(context.Background(), now.AddDate(0, 0, 6))
+    assert.NoError(t, err)
+    assert.Empty(t, ids)
+    ids, err = repo.FetchAccountIdsToPurge(context.Background(), now.AddDate(0, 0, 7))
+    assert.NoError(t, err)
+    assert.Equal(t, []uint{1}, ids)
+
+    err = repo.ScheduleAccountDeletion(context.Background(), 3, now)
+    assert.EqualError(t, err, "ユーザーが見つかりません")
+}
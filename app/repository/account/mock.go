@@ -0,0 +1,33 @@
+package account
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAccountRepository は AccountRepository のモックです。
+type MockAccountRepository struct {
+	mock.Mock
+}
+
+// NewMockAccountRepository は新しい MockAccountRepository を作成し、初期設定を行います。
+func NewMockAccountRepository() *MockAccountRepository {
+	return &MockAccountRepository{}
+}
+
+func (m *MockAccountRepository) DeleteAccount(ctx context.Context, userId uint) error {
+	args := m.Called(ctx, userId)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) ScheduleAccountDeletion(ctx context.Context, userId uint, purgeAt time.Time) error {
+	args := m.Called(ctx, userId, purgeAt)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) FetchAccountIdsToPurge(ctx context.Context, now time.Time) ([]uint, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).([]uint), args.Error(1)
+}
@@ -0,0 +1,168 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"my-us-stock-backend/app/database/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// アラートの通知モード
+const (
+    ModeOnce     = "ONCE"
+    ModeCooldown = "COOLDOWN"
+)
+
+// ErrAlertAlreadyTriggered は他の実行(別インスタンスなど)が先にアラートの発生を記録した場合のエラーです
+var ErrAlertAlreadyTriggered = errors.New("アラートは既に記録されています")
+
+// AlertRepository インターフェースの定義
+type AlertRepository interface {
+    FetchAlertRuleListById(ctx context.Context, userId uint) ([]model.AlertRule, error)
+    FetchEnabledAlertRuleList(ctx context.Context) ([]model.AlertRule, error)
+    CreateAlertRule(ctx context.Context, dto CreateAlertRuleDto) (*model.AlertRule, error)
+    UpdateAlertRule(ctx context.Context, dto UpdateAlertRuleDto) (*model.AlertRule, error)
+    DeleteAlertRule(ctx context.Context, id uint, userId uint) error
+    RecordTriggeredAlert(ctx context.Context, dto CreateTriggeredAlertDto) (*model.TriggeredAlert, error)
+    FetchTriggeredAlertListById(ctx context.Context, userId uint, limit int) ([]model.TriggeredAlert, error)
+}
+
+// DefaultAlertRepository 構造体の定義
+type DefaultAlertRepository struct {
+    DB *gorm.DB
+}
+
+// NewAlertRepository は DefaultAlertRepository の新しいインスタンスを作成します
+func NewAlertRepository(db *gorm.DB) AlertRepository {
+    return &DefaultAlertRepository{DB: db}
+}
+
+// 指定したuserIdのユーザーのアラート条件を登録順に取得する
+func (r *DefaultAlertRepository) FetchAlertRuleListById(ctx context.Context, userId uint) ([]model.AlertRule, error) {
+    var alertRules []model.AlertRule
+    if err := r.DB.Where("user_id = ?", userId).Order("id").Find(&alertRules).Error; err != nil {
+        return nil, err
+    }
+    return alertRules, nil
+}
+
+// 全ユーザーの有効なアラート条件を取得する(バックグラウンドでの判定用)
+// 退会の猶予期間中のユーザー(論理削除済み)のアラート条件は判定しない
+func (r *DefaultAlertRepository) FetchEnabledAlertRuleList(ctx context.Context) ([]model.AlertRule, error) {
+    var alertRules []model.AlertRule
+    if err := r.DB.Where("enabled = ? AND user_id IN (?)", true, r.DB.Model(&model.User{}).Select("id")).Order("id").Find(&alertRules).Error; err != nil {
+        return nil, err
+    }
+    return alertRules, nil
+}
+
+// アラート条件を作成します(作成時は有効な状態とする)
+func (r *DefaultAlertRepository) CreateAlertRule(ctx context.Context, dto CreateAlertRuleDto) (*model.AlertRule, error) {
+    alertRule := &model.AlertRule{
+        TargetType:      dto.TargetType,
+        Symbol:          dto.Symbol,
+        Condition:       dto.Condition,
+        Threshold:       dto.Threshold,
+        Mode:            dto.Mode,
+        CooldownMinutes: dto.CooldownMinutes,
+        Enabled:         true,
+        UserId:          dto.UserId,
+    }
+    if err := r.DB.Create(alertRule).Error; err != nil {
+        return nil, err
+    }
+    return alertRule, nil
+}
+
+// アラート条件を更新します
+func (r *DefaultAlertRepository) UpdateAlertRule(ctx context.Context, dto UpdateAlertRuleDto) (*model.AlertRule, error) {
+    newAlertRule := map[string]interface{}{
+        "condition":        dto.Condition,
+        "threshold":        dto.Threshold,
+        "mode":             dto.Mode,
+        "cooldown_minutes": dto.CooldownMinutes,
+        "enabled":          dto.Enabled,
+    }
+    result := r.DB.Model(&model.AlertRule{}).Where("id = ? AND user_id = ?", dto.ID, dto.UserId).Updates(newAlertRule)
+    if result.Error != nil {
+        return nil, result.Error
+    }
+    if result.RowsAffected == 0 {
+        return nil, fmt.Errorf("指定されたアラートが見つかりません")
+    }
+
+    var alertRule model.AlertRule
+    if err := r.DB.Where("id = ?", dto.ID).First(&alertRule).Error; err != nil {
+        return nil, err
+    }
+    return &alertRule, nil
+}
+
+// アラート条件を削除します
+func (r *DefaultAlertRepository) DeleteAlertRule(ctx context.Context, id uint, userId uint) error {
+    result := r.DB.Where("id = ? AND user_id = ?", id, userId).Delete(&model.AlertRule{})
+    if result.Error != nil {
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        return fmt.Errorf("指定されたアラートが見つかりません")
+    }
+    return nil
+}
+
+// RecordTriggeredAlert はアラートの発生履歴を保存し、アラート条件の最終通知日時を更新します
+// 通知モードがONCEの場合はアラート条件を無効化します
+// 最終通知日時の更新は有効かつ再通知までの間隔が経過している場合のみ行い、更新できなかった場合は
+// 他の実行が先に記録したものとして ErrAlertAlreadyTriggered を返します(同じアラートを二重に通知しない)
+func (r *DefaultAlertRepository) RecordTriggeredAlert(ctx context.Context, dto CreateTriggeredAlertDto) (*model.TriggeredAlert, error) {
+    var triggeredAlert *model.TriggeredAlert
+    err := r.DB.Transaction(func(tx *gorm.DB) error {
+        var alertRule model.AlertRule
+        if err := tx.Where("id = ?", dto.AlertRuleId).First(&alertRule).Error; err != nil {
+            return err
+        }
+        updates := map[string]interface{}{"last_triggered_at": dto.TriggeredAt}
+        if alertRule.Mode == ModeOnce {
+            updates["enabled"] = false
+        }
+        claim := tx.Model(&model.AlertRule{}).Where("id = ? AND enabled = ?", alertRule.ID, true)
+        if alertRule.Mode == ModeCooldown {
+            cooldownStart := dto.TriggeredAt.Add(-time.Duration(alertRule.CooldownMinutes) * time.Minute)
+            claim = claim.Where("last_triggered_at IS NULL OR last_triggered_at <= ?", cooldownStart)
+        }
+        result := claim.Updates(updates)
+        if result.Error != nil {
+            return result.Error
+        }
+        if result.RowsAffected == 0 {
+            return ErrAlertAlreadyTriggered
+        }
+        triggeredAlert = &model.TriggeredAlert{
+            AlertRuleId: alertRule.ID,
+            TargetType:  alertRule.TargetType,
+            Symbol:      alertRule.Symbol,
+            Condition:   alertRule.Condition,
+            Threshold:   alertRule.Threshold,
+            Value:       dto.Value,
+            TriggeredAt: dto.TriggeredAt,
+            UserId:      alertRule.UserId,
+        }
+        return tx.Create(triggeredAlert).Error
+    })
+    if err != nil {
+        return nil, err
+    }
+    return triggeredAlert, nil
+}
+
+// 指定したuserIdのユーザーのアラート発生履歴を新しい順に取得する
+func (r *DefaultAlertRepository) FetchTriggeredAlertListById(ctx context.Context, userId uint, limit int) ([]model.TriggeredAlert, error) {
+    var triggeredAlerts []model.TriggeredAlert
+    if err := r.DB.Where("user_id = ?", userId).Order("triggered_at desc").Order("id desc").Limit(limit).Find(&triggeredAlerts).Error; err != nil {
+        return nil, err
+    }
+    return triggeredAlerts, nil
+}
@@ -0,0 +1,121 @@
+package alert
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// テスト用のデータベース設定
+func setupTestDB() *gorm.DB {
+    db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+    if err != nil {
+        panic("failed to connect database")
+    }
+    // テスト用のテーブルを準備
+    db.AutoMigrate(&model.AlertRule{}, &model.User{})
+    db.AutoMigrate(&model.TriggeredAlert{})
+    return db
+}
+
+func TestCreateAndFetchAlertRule(t *testing.T) {
+    db := setupTestDB()
+    repo := NewAlertRepository(db)
+
+    created, err := repo.CreateAlertRule(context.Background(), CreateAlertRuleDto{TargetType: "STOCK_PRICE", Symbol: "AAPL", Condition: "BELOW", Threshold: 170, Mode: ModeCooldown, CooldownMinutes: 60, UserId: 1})
+    assert.NoError(t, err)
+    assert.True(t, created.Enabled)
+    repo.CreateAlertRule(context.Background(), CreateAlertRuleDto{TargetType: "FX_RATE", Symbol: "USDJPY", Condition: "ABOVE", Threshold: 150, Mode: ModeOnce, UserId: 2})
+
+    alertRules, err := repo.FetchAlertRuleListById(context.Background(), 1)
+    assert.NoError(t, err)
+    assert.Len(t, alertRules, 1)
+    assert.Equal(t, "AAPL", alertRules[0].Symbol)
+    assert.Equal(t, 170.0, alertRules[0].Threshold)
+}
+
+// 無効化されたアラートは判定対象に含まれない
+func TestFetchEnabledAlertRuleList(t *testing.T) {
+    db := setupTestDB()
+    repo := NewAlertRepository(db)
+
+    db.Create(&model.User{Model: gorm.Model{ID: 1}, Email: "alert-1@example.com", Password: "hashed"})
+    db.Create(&model.User{Model: gorm.Model{ID: 2}, Email: "alert-2@example.com", Password: "hashed"})
+    deletedUser := model.User{Model: gorm.Model{ID: 3}, Email: "alert-3@example.com", Password: "hashed"}
+    db.Create(&deletedUser)
+    db.Delete(&deletedUser)
+    db.Create(&model.AlertRule{TargetType: "STOCK_PRICE", Symbol: "AAPL", Condition: "BELOW", Threshold: 170, Mode: ModeOnce, Enabled: true, UserId: 1})
+    db.Create(&model.AlertRule{TargetType: "STOCK_PRICE", Symbol: "KO", Condition: "BELOW", Threshold: 50, Mode: ModeOnce, Enabled: false, UserId: 2})
+    // 退会の猶予期間中のユーザーのアラートは判定しない
+    db.Create(&model.AlertRule{TargetType: "STOCK_PRICE", Symbol: "MSFT", Condition: "BELOW", Threshold: 400, Mode: ModeOnce, Enabled: true, UserId: 3})
+
+    alertRules, err := repo.FetchEnabledAlertRuleList(context.Background())
+    assert.NoError(t, err)
+    assert.Len(t, alertRules, 1)
+    assert.Equal(t, "AAPL", alertRules[0].Symbol)
+}
+
+// 他のユーザーのアラートは更新・削除できない
+func TestUpdateAndDeleteAlertRule(t *testing.T) {
+    db := setupTestDB()
+    repo := NewAlertRepository(db)
+
+    alertRule := model.AlertRule{TargetType: "STOCK_PRICE", Symbol: "AAPL", Condition: "BELOW", Threshold: 170, Mode: ModeOnce, Enabled: true, UserId: 1}
+    db.Create(&alertRule)
+
+    updated, err := repo.UpdateAlertRule(context.Background(), UpdateAlertRuleDto{ID: alertRule.ID, Condition: "ABOVE", Threshold: 200, Mode: ModeCooldown, CooldownMinutes: 30, Enabled: false, UserId: 1})
+    assert.NoError(t, err)
+    assert.Equal(t, "ABOVE", updated.Condition)
+    assert.Equal(t, 200.0, updated.Threshold)
+    assert.Equal(t, 30, updated.CooldownMinutes)
+    assert.False(t, updated.Enabled)
+
+    _, err = repo.UpdateAlertRule(context.Background(), UpdateAlertRuleDto{ID: alertRule.ID, Condition: "BELOW", UserId: 2})
+    assert.Error(t, err)
+    assert.Error(t, repo.DeleteAlertRule(context.Background(), alertRule.ID, 2))
+    assert.NoError(t, repo.DeleteAlertRule(context.Background(), alertRule.ID, 1))
+}
+
+// ONCEモードのアラートは発生後に無効化される
+func TestRecordTriggeredAlert(t *testing.T) {
+    db := setupTestDB()
+    repo := NewAlertRepository(db)
+
+    onceRule := model.AlertRule{TargetType: "FX_RATE", Symbol: "USDJPY", Condition: "ABOVE", Threshold: 150, Mode: ModeOnce, Enabled: true, UserId: 1}
+    cooldownRule := model.AlertRule{TargetType: "STOCK_PRICE", Symbol: "AAPL", Condition: "BELOW", Threshold: 170, Mode: ModeCooldown, CooldownMinutes: 60, Enabled: true, UserId: 1}
+    db.Create(&onceRule)
+    db.Create(&cooldownRule)
+
+    triggeredAt := time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)
+    triggered, err := repo.RecordTriggeredAlert(context.Background(), CreateTriggeredAlertDto{AlertRuleId: onceRule.ID, Value: 151.2, TriggeredAt: triggeredAt})
+    assert.NoError(t, err)
+    assert.Equal(t, "USDJPY", triggered.Symbol)
+    assert.Equal(t, 150.0, triggered.Threshold)
+    assert.Equal(t, 151.2, triggered.Value)
+    _, err = repo.RecordTriggeredAlert(context.Background(), CreateTriggeredAlertDto{AlertRuleId: cooldownRule.ID, Value: 168.0, TriggeredAt: triggeredAt.Add(time.Minute)})
+    assert.NoError(t, err)
+
+    var foundOnce, foundCooldown model.AlertRule
+    db.First(&foundOnce, onceRule.ID)
+    db.First(&foundCooldown, cooldownRule.ID)
+    assert.False(t, foundOnce.Enabled)
+    assert.True(t, foundCooldown.Enabled)
+    assert.True(t, foundCooldown.LastTriggeredAt.Equal(triggeredAt.Add(time.Minute)))
+
+    // 既に記録済み(無効化済み・再通知の間隔内)のアラートは重ねて記録しない
+    _, err = repo.RecordTriggeredAlert(context.Background(), CreateTriggeredAlertDto{AlertRuleId: onceRule.ID, Value: 151.5, TriggeredAt: triggeredAt.Add(time.Minute)})
+    assert.ErrorIs(t, err, ErrAlertAlreadyTriggered)
+    _, err = repo.RecordTriggeredAlert(context.Background(), CreateTriggeredAlertDto{AlertRuleId: cooldownRule.ID, Value: 167.0, TriggeredAt: triggeredAt.Add(30 * time.Minute)})
+    assert.ErrorIs(t, err, ErrAlertAlreadyTriggered)
+
+    // 新しい順に取得される
+    triggeredAlerts, err := repo.FetchTriggeredAlertListById(context.Background(), 1, 1)
+    assert.NoError(t, err)
+    assert.Len(t, triggeredAlerts, 1)
+    assert.Equal(t, "AAPL", triggeredAlerts[0].Symbol)
+}
@@ -0,0 +1,11 @@
+package alert
+
+type CreateAlertRuleDto struct {
+    TargetType      string  `json:"targetType"`
+    Symbol          string  `json:"symbol"`
+    Condition       string  `json:"condition"`
+    Threshold       float64 `json:"threshold"`
+    Mode            string  `json:"mode"`
+    CooldownMinutes int     `json:"cooldownMinutes"`
+    UserId          uint    `json:"userId"`
+}
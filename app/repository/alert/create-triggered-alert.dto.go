@@ -0,0 +1,9 @@
+package alert
+
+import "time"
+
+type CreateTriggeredAlertDto struct {
+    AlertRuleId uint      `json:"alertRuleId"`
+    Value       float64   `json:"value"`
+    TriggeredAt time.Time `json:"triggeredAt"`
+}
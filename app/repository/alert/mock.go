@@ -0,0 +1,53 @@
+package alert
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAlertRepository は AlertRepository のモックです。
+type MockAlertRepository struct {
+	mock.Mock
+}
+
+// NewMockAlertRepository は新しい MockAlertRepository を作成し、初期設定を行います。
+func NewMockAlertRepository() *MockAlertRepository {
+	return &MockAlertRepository{}
+}
+
+func (m *MockAlertRepository) FetchAlertRuleListById(ctx context.Context, userId uint) ([]model.AlertRule, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]model.AlertRule), args.Error(1)
+}
+
+func (m *MockAlertRepository) FetchEnabledAlertRuleList(ctx context.Context) ([]model.AlertRule, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]model.AlertRule), args.Error(1)
+}
+
+func (m *MockAlertRepository) CreateAlertRule(ctx context.Context, dto CreateAlertRuleDto) (*model.AlertRule, error) {
+	args := m.Called(ctx, dto)
+	return args.Get(0).(*model.AlertRule), args.Error(1)
+}
+
+func (m *MockAlertRepository) UpdateAlertRule(ctx context.Context, dto UpdateAlertRuleDto) (*model.AlertRule, error) {
+	args := m.Called(ctx, dto)
+	return args.Get(0).(*model.AlertRule), args.Error(1)
+}
+
+func (m *MockAlertRepository) DeleteAlertRule(ctx context.Context, id uint, userId uint) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockAlertRepository) RecordTriggeredAlert(ctx context.Context, dto CreateTriggeredAlertDto) (*model.TriggeredAlert, error) {
+	args := m.Called(ctx, dto)
+	return args.Get(0).(*model.TriggeredAlert), args.Error(1)
+}
+
+func (m *MockAlertRepository) FetchTriggeredAlertListById(ctx context.Context, userId uint, limit int) ([]model.TriggeredAlert, error) {
+	args := m.Called(ctx, userId, limit)
+	return args.Get(0).([]model.TriggeredAlert), args.Error(1)
+}
@@ -0,0 +1,11 @@
+package alert
+
+type UpdateAlertRuleDto struct {
+    ID              uint    `json:"id"`
+    Condition       string  `json:"condition"`
+    Threshold       float64 `json:"threshold"`
+    Mode            string  `json:"mode"`
+    CooldownMinutes int     `json:"cooldownMinutes"`
+    Enabled         bool    `json:"enabled"`
+    UserId          uint    `json:"userId"`
+}
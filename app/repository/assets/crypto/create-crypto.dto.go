@@ -4,5 +4,6 @@ type CreateCryptDto struct {
     Code   string  `json:"code"`
     GetPrice float64 `json:"getPrice"`
     Quantity float64 `json:"quantity"`
+    AccountId *uint `json:"accountId"`
     UserId   uint  `json:"userId"`
 }
\ No newline at end of file
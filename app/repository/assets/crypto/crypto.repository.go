@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"my-us-stock-backend/app/database/model"
+	brokerageaccount "my-us-stock-backend/app/repository/brokerage-account"
 
 	"gorm.io/gorm"
 )
@@ -23,7 +24,7 @@ type DefaultCryptoRepository struct {
 
 // 共通フィールドを選択するためのヘルパー関数です。
 func selectBaseQuery(db *gorm.DB) *gorm.DB {
-    return db.Select("id", "get_price", "quantity", "code", "user_id")
+    return db.Select("id", "get_price", "quantity", "code", "account_id", "user_id")
 }
 
 // NewCryptoRepository は DefaultStrategyRepository の新しいインスタンスを作成します
@@ -69,9 +70,14 @@ func (r *DefaultCryptoRepository) UpdateCrypto(ctx context.Context, dto UpdateCr
 
 // 米国株式情報を作成します
 func (r *DefaultCryptoRepository) CreateCrypto(ctx context.Context, dto CreateCryptDto) (*model.Crypto, error) {
-    // 既に同じ銘柄が存在するかを確認
+    // 口座の指定がない場合は既定の口座に登録する(他のユーザーの口座には登録できない)
+    accountId, err := brokerageaccount.ResolveAccount(r.DB, dto.AccountId, dto.UserId)
+    if err != nil {
+        return nil, err
+    }
+    // 同じ口座に既に同じ銘柄が存在するかを確認
     var existingUsStock model.Crypto
-    if err := selectBaseQuery(r.DB).Where("code = ? AND user_id = ?", dto.Code, dto.UserId).First(&existingUsStock).Error; err == nil {
+    if err := brokerageaccount.ScopeAccount(selectBaseQuery(r.DB), accountId).Where("code = ? AND user_id = ?", dto.Code, dto.UserId).First(&existingUsStock).Error; err == nil {
         return nil, fmt.Errorf("この銘柄は既に登録されています")
     }
 
@@ -80,6 +86,7 @@ func (r *DefaultCryptoRepository) CreateCrypto(ctx context.Context, dto CreateCr
         Code:   dto.Code,
         GetPrice: dto.GetPrice,
         Quantity: dto.Quantity,
+        AccountId: accountId,
         UserId:   dto.UserId,
     }
 
@@ -3,6 +3,7 @@ package crypto
 import (
 	"context"
 	"my-us-stock-backend/app/database/model"
+	brokerageaccount "my-us-stock-backend/app/repository/brokerage-account"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -18,7 +19,7 @@ func setupTestDB() *gorm.DB {
     }
 
     // テスト用のテーブルを準備
-    db.AutoMigrate(&model.Crypto{})
+    db.AutoMigrate(&model.Crypto{}, &model.BrokerageAccount{})
 
     return db
 }
@@ -112,8 +113,9 @@ func TestCreateCryptoAlreadyExists(t *testing.T) {
     db := setupTestDB()
     repo := NewCryptoRepository(db)
 
-    // 既存の銘柄をデータベースに登録
-    existingCrypto := model.Crypto{Code: "xrp", UserId: 99, Quantity: 100, GetPrice: 80}
+    // 口座を指定しない場合は既定の口座に登録するため、既存の銘柄を既定の口座に登録
+    defaultAccountId, _ := brokerageaccount.FindOrCreateDefaultAccount(db, 99)
+    existingCrypto := model.Crypto{Code: "xrp", UserId: 99, AccountId: defaultAccountId, Quantity: 100, GetPrice: 80}
     db.Create(&existingCrypto)
 
     // 同じ銘柄で新しい株式情報を作成
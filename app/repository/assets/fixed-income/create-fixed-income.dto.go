@@ -1,10 +1,21 @@
 package fixedincome
 
+import "time"
+
 type CreateFixedIncomeDto struct {
     Code   string  `json:"code"`
     GetPriceTotal float64 `json:"getPriceTotal"`
     DividendRate float64 `json:"dividendRate"`
     UsdJpy   *float64 `json:"usdjpy"`
     PaymentMonth []int64 `json:"paymentMonth"`
+    IssueDate *time.Time `json:"issueDate"`
+    MaturityDate *time.Time `json:"maturityDate"`
+    CouponFrequency string `json:"couponFrequency"`
+    Currency string `json:"currency"`
+    RedemptionAmount *float64 `json:"redemptionAmount"`
+    ValuationMode string `json:"valuationMode"`
+    CleanPrice *float64 `json:"cleanPrice"`
+    PurchaseDate *time.Time `json:"purchaseDate"`
+    AccountId *uint `json:"accountId"`
     UserId   uint  `json:"userId"`
-}
\ No newline at end of file
+}
@@ -3,17 +3,33 @@ package fixedincome
 import (
 	"context"
 	"fmt"
+	"math"
 	"my-us-stock-backend/app/database/model"
+	brokerageaccount "my-us-stock-backend/app/repository/brokerage-account"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+// 固定利回り資産の状態
+const (
+    StatusActive = "ACTIVE"
+    StatusMatured = "MATURED"
+)
+
+// 固定利回り資産の通貨
+const (
+    CurrencyJpy = "JPY"
+    CurrencyUsd = "USD"
+)
+
 // FixedIncomeRepository インターフェースの定義
 type FixedIncomeRepository interface {
 	FetchFixedIncomeAssetListById(ctx context.Context, userId uint) ([]model.FixedIncomeAsset, error)
     UpdateFixedIncomeAsset(ctx context.Context, dto UpdateFixedIncomeDto) (*model.FixedIncomeAsset, error)
 	CreateFixedIncomeAsset(ctx context.Context, dto CreateFixedIncomeDto) (*model.FixedIncomeAsset, error)
 	DeleteFixedIncomeAsset(ctx context.Context, id uint) error
+	MatureFixedIncomeAssets(ctx context.Context, now time.Time, currentUsdJpy float64) (int64, error)
 }
 
 // DefaultFixedIncomeRepository 構造体の定義
@@ -23,7 +39,7 @@ type DefaultFixedIncomeRepository struct {
 
 // 共通フィールドを選択するためのヘルパー関数です。
 func selectBaseQuery(db *gorm.DB) *gorm.DB {
-    return db.Select("id", "get_price_total", "dividend_rate", "code", "usd_jpy", "payment_month", "user_id")
+    return db.Select("id", "created_at", "get_price_total", "dividend_rate", "code", "usd_jpy", "payment_month", "issue_date", "maturity_date", "coupon_frequency", "currency", "redemption_amount", "status", "valuation_mode", "clean_price", "purchase_date", "account_id", "user_id")
 }
 
 // NewCryptoRepository は DefaultStrategyRepository の新しいインスタンスを作成します
@@ -53,7 +69,31 @@ func (r *DefaultFixedIncomeRepository) UpdateFixedIncomeAsset(ctx context.Contex
         newFixedIncomeAsset["dividend_rate"] = dto.DividendRate
     }
 	if dto.UsdJpy != nil {
-        newFixedIncomeAsset["usdjpy"] = dto.UsdJpy
+        newFixedIncomeAsset["usd_jpy"] = dto.UsdJpy
+    }
+    if dto.IssueDate != nil {
+        newFixedIncomeAsset["issue_date"] = dto.IssueDate
+    }
+    if dto.MaturityDate != nil {
+        newFixedIncomeAsset["maturity_date"] = dto.MaturityDate
+    }
+    if dto.CouponFrequency != nil {
+        newFixedIncomeAsset["coupon_frequency"] = dto.CouponFrequency
+    }
+    if dto.Currency != nil {
+        newFixedIncomeAsset["currency"] = dto.Currency
+    }
+    if dto.RedemptionAmount != nil {
+        newFixedIncomeAsset["redemption_amount"] = dto.RedemptionAmount
+    }
+    if dto.ValuationMode != nil {
+        newFixedIncomeAsset["valuation_mode"] = dto.ValuationMode
+    }
+    if dto.CleanPrice != nil {
+        newFixedIncomeAsset["clean_price"] = dto.CleanPrice
+    }
+    if dto.PurchaseDate != nil {
+        newFixedIncomeAsset["purchase_date"] = dto.PurchaseDate
     }
 
     // 指定されたIDの株式情報を更新します
@@ -72,12 +112,28 @@ func (r *DefaultFixedIncomeRepository) UpdateFixedIncomeAsset(ctx context.Contex
 
 // 米国株式情報を作成します
 func (r *DefaultFixedIncomeRepository) CreateFixedIncomeAsset(ctx context.Context, dto CreateFixedIncomeDto) (*model.FixedIncomeAsset, error) {
-    // 既に同じ銘柄が存在するかを確認
+    // 口座の指定がない場合は既定の口座に登録する(他のユーザーの口座には登録できない)
+    accountId, err := brokerageaccount.ResolveAccount(r.DB, dto.AccountId, dto.UserId)
+    if err != nil {
+        return nil, err
+    }
+    // 同じ口座に既に同じ銘柄が存在するかを確認
     var existingUsStock model.FixedIncomeAsset
-    if err := selectBaseQuery(r.DB).Where("code = ? AND user_id = ?", dto.Code, dto.UserId).First(&existingUsStock).Error; err == nil {
+    if err := brokerageaccount.ScopeAccount(selectBaseQuery(r.DB), accountId).Where("code = ? AND user_id = ?", dto.Code, dto.UserId).First(&existingUsStock).Error; err == nil {
         return nil, fmt.Errorf("この銘柄は既に登録されています")
     }
 
+    // 通貨の指定がない場合は円とする
+    currency := dto.Currency
+    if currency == "" {
+        currency = CurrencyJpy
+    }
+    // 評価方法の指定がない場合は償却原価とする
+    valuationMode := dto.ValuationMode
+    if valuationMode == "" {
+        valuationMode = ValuationModeAmortizedCost
+    }
+
     // 新しい米国株式情報を作成
     fixedIncomeAsset := &model.FixedIncomeAsset{
         Code:   dto.Code,
@@ -85,6 +141,16 @@ func (r *DefaultFixedIncomeRepository) CreateFixedIncomeAsset(ctx context.Contex
         DividendRate: dto.DividendRate,
 		UsdJpy: dto.UsdJpy,
         PaymentMonth: dto.PaymentMonth,
+        IssueDate: dto.IssueDate,
+        MaturityDate: dto.MaturityDate,
+        CouponFrequency: dto.CouponFrequency,
+        Currency: currency,
+        RedemptionAmount: dto.RedemptionAmount,
+        Status: StatusActive,
+        ValuationMode: valuationMode,
+        CleanPrice: dto.CleanPrice,
+        PurchaseDate: dto.PurchaseDate,
+        AccountId: accountId,
         UserId:   dto.UserId,
     }
 
@@ -104,3 +170,56 @@ func (r *DefaultFixedIncomeRepository) DeleteFixedIncomeAsset(ctx context.Contex
     return nil
 }
 
+// MatureFixedIncomeAssets は満期日を迎えた保有中の固定利回り資産を満期償還済みにし、償還金額を現金に移します
+// 償還金額はユーザーの最新の資産総額の現金(通貨がUSDの場合はドル現金)に加算し、同じ資産総額の固定利回り資産から評価額(currentUsdJpyで円換算)を差し引きます
+func (r *DefaultFixedIncomeRepository) MatureFixedIncomeAssets(ctx context.Context, now time.Time, currentUsdJpy float64) (int64, error) {
+    var matured int64
+    err := r.DB.Transaction(func(tx *gorm.DB) error {
+        var fixedIncomeAssets []model.FixedIncomeAsset
+        if err := tx.Where("status = ? AND maturity_date IS NOT NULL AND maturity_date <= ?", StatusActive, now).Find(&fixedIncomeAssets).Error; err != nil {
+            return err
+        }
+        for _, fixedIncomeAsset := range fixedIncomeAssets {
+            // 他の処理で既に償還済みにされている場合は二重に加算しない
+            result := tx.Model(&model.FixedIncomeAsset{}).Where("id = ? AND status = ?", fixedIncomeAsset.ID, StatusActive).Update("status", StatusMatured)
+            if result.Error != nil {
+                return result.Error
+            }
+            if result.RowsAffected == 0 {
+                continue
+            }
+            if err := addRedemptionToCash(tx, fixedIncomeAsset, currentUsdJpy, now); err != nil {
+                return err
+            }
+            matured++
+        }
+        return nil
+    })
+    if err != nil {
+        return 0, err
+    }
+    return matured, nil
+}
+
+// 償還金額をユーザーの最新の資産総額の現金に加算する
+// 同じ資産総額で二重に計上しないよう、償還した資産の評価額を固定利回り資産の金額から差し引く
+func addRedemptionToCash(tx *gorm.DB, fixedIncomeAsset model.FixedIncomeAsset, currentUsdJpy float64, now time.Time) error {
+    redemptionAmount := RedemptionAmount(fixedIncomeAsset)
+    column := "cash_jpy"
+    if IsUsd(fixedIncomeAsset) {
+        column = "cash_usd"
+    }
+    var totalAssets []model.TotalAsset
+    if err := tx.Where("user_id = ?", fixedIncomeAsset.UserId).Order("created_at desc").Limit(1).Find(&totalAssets).Error; err != nil {
+        return err
+    }
+    // 資産総額が未登録の場合は現金残高も未登録のため、株式等が0の資産総額は作らずに加算しない
+    if len(totalAssets) == 0 {
+        return nil
+    }
+    valuationJpy := math.Round(ValuationJpy(fixedIncomeAsset, currentUsdJpy, now))
+    return tx.Model(&model.TotalAsset{}).Where("id = ?", totalAssets[0].ID).Updates(map[string]interface{}{
+        column: gorm.Expr(column+" + ?", redemptionAmount),
+        "fixed_income_asset": gorm.Expr("CASE WHEN fixed_income_asset > ? THEN fixed_income_asset - ? ELSE 0 END", valuationJpy, valuationJpy),
+    }).Error
+}
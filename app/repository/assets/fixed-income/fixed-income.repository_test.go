@@ -3,7 +3,9 @@ package fixedincome
 import (
 	"context"
 	"my-us-stock-backend/app/database/model"
+	brokerageaccount "my-us-stock-backend/app/repository/brokerage-account"
 	"testing"
+	"time"
 
 	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
@@ -19,7 +21,7 @@ func setupTestDB() *gorm.DB {
     }
 
     // テスト用のテーブルを準備
-    db.AutoMigrate(&model.FixedIncomeAsset{})
+    db.AutoMigrate(&model.FixedIncomeAsset{}, &model.TotalAsset{}, &model.BrokerageAccount{})
 
     return db
 }
@@ -126,8 +128,9 @@ func TestCreateFixedIncomeAssetAlreadyExists(t *testing.T) {
     db := setupTestDB()
     repo := NewFixedIncomeRepository(db)
 
-    // 既存の銘柄をデータベースに登録
-    existingFixedIncomeAsset := model.FixedIncomeAsset{Code: "Funds", UserId: 99, DividendRate: 3.5, GetPriceTotal: 100000, PaymentMonth: pq.Int64Array{6, 12}}
+    // 口座を指定しない場合は既定の口座に登録するため、既存の銘柄を既定の口座に登録
+    defaultAccountId, _ := brokerageaccount.FindOrCreateDefaultAccount(db, 99)
+    existingFixedIncomeAsset := model.FixedIncomeAsset{Code: "Funds", UserId: 99, AccountId: defaultAccountId, DividendRate: 3.5, GetPriceTotal: 100000, PaymentMonth: pq.Int64Array{6, 12}}
     db.Create(&existingFixedIncomeAsset)
 
     // 同じ銘柄で新しい株式情報を作成
@@ -161,3 +164,98 @@ func TestDeleteFixedIncomeAsset(t *testing.T) {
     db.First(&result, fixedIncomeAsset.ID)
     assert.Empty(t, result)
 }
+
+// 満期日・利払い頻度・通貨・償還金額を指定して作成できる
+func TestCreateFixedIncomeAssetWithMaturity(t *testing.T) {
+    db := setupTestDB()
+    repo := NewFixedIncomeRepository(db)
+    issueDate := time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)
+    maturityDate := time.Date(2027, 4, 15, 0, 0, 0, 0, time.UTC)
+    redemptionAmount := 1000.0
+
+    createDto := CreateFixedIncomeDto{
+        Code: "US Treasury 2027",
+        UserId: 89,
+        DividendRate: 4.0,
+        GetPriceTotal: 980,
+        IssueDate: &issueDate,
+        MaturityDate: &maturityDate,
+        CouponFrequency: "SEMI_ANNUAL",
+        Currency: CurrencyUsd,
+        RedemptionAmount: &redemptionAmount,
+    }
+    created, err := repo.CreateFixedIncomeAsset(context.Background(), createDto)
+    assert.NoError(t, err)
+    assert.Equal(t, StatusActive, created.Status)
+    assert.Equal(t, ValuationModeAmortizedCost, created.ValuationMode)
+
+    fixedIncomeAssetList, err := repo.FetchFixedIncomeAssetListById(context.Background(), 89)
+    assert.NoError(t, err)
+    assert.Len(t, fixedIncomeAssetList, 1)
+    assert.True(t, maturityDate.Equal(*fixedIncomeAssetList[0].MaturityDate))
+    assert.True(t, issueDate.Equal(*fixedIncomeAssetList[0].IssueDate))
+    assert.Equal(t, "SEMI_ANNUAL", fixedIncomeAssetList[0].CouponFrequency)
+    assert.Equal(t, CurrencyUsd, fixedIncomeAssetList[0].Currency)
+    assert.Equal(t, redemptionAmount, *fixedIncomeAssetList[0].RedemptionAmount)
+    assert.Equal(t, StatusActive, fixedIncomeAssetList[0].Status)
+}
+
+// 満期日を迎えた資産は満期償還済みになり、償還金額が最新の資産総額の現金に加算され評価額が固定利回り資産から差し引かれる
+func TestMatureFixedIncomeAssets(t *testing.T) {
+    db := setupTestDB()
+    repo := NewFixedIncomeRepository(db)
+    now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+    past := now.AddDate(0, 0, -1)
+    future := now.AddDate(1, 0, 0)
+    redemptionAmount := 500.0
+
+    db.Create(&model.TotalAsset{CashJpy: 10000, CashUsd: 100, FixedIncomeAsset: 472000, UserId: 88})
+    jpyAsset := model.FixedIncomeAsset{Code: "Loan", UserId: 88, GetPriceTotal: 300000, MaturityDate: &past, Currency: CurrencyJpy, Status: StatusActive}
+    usdAsset := model.FixedIncomeAsset{Code: "Treasury", UserId: 88, GetPriceTotal: 480, RedemptionAmount: &redemptionAmount, MaturityDate: &past, Currency: CurrencyUsd, Status: StatusActive}
+    activeAsset := model.FixedIncomeAsset{Code: "Bond", UserId: 88, GetPriceTotal: 100000, MaturityDate: &future, Currency: CurrencyJpy, Status: StatusActive}
+    db.Create(&jpyAsset)
+    db.Create(&usdAsset)
+    db.Create(&activeAsset)
+
+    matured, err := repo.MatureFixedIncomeAssets(context.Background(), now, 150)
+    assert.NoError(t, err)
+    assert.Equal(t, int64(2), matured)
+
+    var totalAsset model.TotalAsset
+    db.Where("user_id = ?", 88).First(&totalAsset)
+    assert.Equal(t, 310000.0, totalAsset.CashJpy)
+    assert.Equal(t, 600.0, totalAsset.CashUsd)
+    assert.Equal(t, 100000.0, totalAsset.FixedIncomeAsset)
+
+    var maturedResult, activeResult model.FixedIncomeAsset
+    db.First(&maturedResult, jpyAsset.ID)
+    assert.Equal(t, StatusMatured, maturedResult.Status)
+    db.First(&activeResult, activeAsset.ID)
+    assert.Equal(t, StatusActive, activeResult.Status)
+
+    // 償還済みの資産は再度加算されない
+    matured, err = repo.MatureFixedIncomeAssets(context.Background(), now, 150)
+    assert.NoError(t, err)
+    assert.Equal(t, int64(0), matured)
+    db.Where("user_id = ?", 88).First(&totalAsset)
+    assert.Equal(t, 310000.0, totalAsset.CashJpy)
+    assert.Equal(t, 100000.0, totalAsset.FixedIncomeAsset)
+}
+
+// 資産総額が未登録の場合は株式等が0の資産総額を登録しない
+func TestMatureFixedIncomeAssetsWithoutTotalAsset(t *testing.T) {
+    db := setupTestDB()
+    repo := NewFixedIncomeRepository(db)
+    now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+    past := now.AddDate(0, -1, 0)
+
+    db.Create(&model.FixedIncomeAsset{Code: "Loan", UserId: 87, GetPriceTotal: 50000, MaturityDate: &past, Currency: CurrencyJpy, Status: StatusActive})
+
+    matured, err := repo.MatureFixedIncomeAssets(context.Background(), now, 150)
+    assert.NoError(t, err)
+    assert.Equal(t, int64(1), matured)
+
+    var totalAssets []model.TotalAsset
+    db.Where("user_id = ?", 87).Find(&totalAssets)
+    assert.Len(t, totalAssets, 0)
+}
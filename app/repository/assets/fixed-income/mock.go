@@ -3,6 +3,7 @@ package fixedincome
 import (
 	"context"
 	"my-us-stock-backend/app/database/model"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -34,3 +35,8 @@ func (m *MockFixedIncomeAssetRepository) DeleteFixedIncomeAsset(ctx context.Cont
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *MockFixedIncomeAssetRepository) MatureFixedIncomeAssets(ctx context.Context, now time.Time, currentUsdJpy float64) (int64, error) {
+	args := m.Called(ctx, now, currentUsdJpy)
+	return args.Get(0).(int64), args.Error(1)
+}
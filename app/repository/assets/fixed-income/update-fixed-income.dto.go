@@ -1,8 +1,18 @@
 package fixedincome
 
+import "time"
+
 type UpdateFixedIncomeDto struct {
     ID       uint     `json:"id"`
     GetPriceTotal *float64 `json:"getPriceTotal,omitempty"`
     DividendRate *float64     `json:"dividendRate,omitempty"`
     UsdJpy   *float64 `json:"usdjpy,omitempty"`
+    IssueDate *time.Time `json:"issueDate,omitempty"`
+    MaturityDate *time.Time `json:"maturityDate,omitempty"`
+    CouponFrequency *string `json:"couponFrequency,omitempty"`
+    Currency *string `json:"currency,omitempty"`
+    RedemptionAmount *float64 `json:"redemptionAmount,omitempty"`
+    ValuationMode *string `json:"valuationMode,omitempty"`
+    CleanPrice *float64 `json:"cleanPrice,omitempty"`
+    PurchaseDate *time.Time `json:"purchaseDate,omitempty"`
 }
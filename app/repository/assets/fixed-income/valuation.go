@@ -0,0 +1,264 @@
+package fixedincome
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
+	"time"
+)
+
+// 利払い頻度
+const (
+    CouponFrequencyAtMaturity = "AT_MATURITY"
+    CouponFrequencyAnnual = "ANNUAL"
+    CouponFrequencySemiAnnual = "SEMI_ANNUAL"
+    CouponFrequencyQuarterly = "QUARTERLY"
+    CouponFrequencyMonthly = "MONTHLY"
+)
+
+// 評価方法
+const (
+    ValuationModeFace = "FACE"// 額面(償還金額)で評価する
+    ValuationModeAmortizedCost = "AMORTIZED_COST"// 取得価格から満期日に向けて償還金額まで定額で償却した償却原価で評価する
+    ValuationModeMarket = "MARKET"// 額面100あたりの時価(クリーン価格)で評価する
+)
+
+// PaymentsPerYear は利払い頻度ごとの年間の利払い回数です(満期一括は含まない)
+var PaymentsPerYear = map[string]int{
+    CouponFrequencyAnnual: 1,
+    CouponFrequencySemiAnnual: 2,
+    CouponFrequencyQuarterly: 4,
+    CouponFrequencyMonthly: 12,
+}
+
+// ExcludeMatured は満期償還済みの固定利回り資産を除外します(償還金額は現金として計上されるため評価額に含めない)
+func ExcludeMatured(fixedIncomeAssets []model.FixedIncomeAsset) []model.FixedIncomeAsset {
+    activeAssets := make([]model.FixedIncomeAsset, 0, len(fixedIncomeAssets))
+    for _, fixedIncomeAsset := range fixedIncomeAssets {
+        if fixedIncomeAsset.Status != StatusMatured {
+            activeAssets = append(activeAssets, fixedIncomeAsset)
+        }
+    }
+    return activeAssets
+}
+
+// IsUsd はドル建ての固定利回り資産かどうかを判定します
+// 通貨の項目を追加する前に登録された資産(通貨が未設定)のみ購入時為替の有無でドル建てかどうかを判断する
+func IsUsd(fixedIncomeAsset model.FixedIncomeAsset) bool {
+    return fixedIncomeAsset.Currency == CurrencyUsd || (fixedIncomeAsset.Currency == "" && fixedIncomeAsset.UsdJpy != nil)
+}
+
+// RedemptionAmount は償還金額(額面)を返します(未設定の場合は取得価格合計)
+func RedemptionAmount(fixedIncomeAsset model.FixedIncomeAsset) float64 {
+    if fixedIncomeAsset.RedemptionAmount != nil {
+        return *fixedIncomeAsset.RedemptionAmount
+    }
+    return fixedIncomeAsset.GetPriceTotal
+}
+
+// CouponMonths は利払い月を返します
+// 配当支払い月が登録されている場合はその月、未登録の場合は利払い頻度から満期日(満期日がない場合は発行日)の月を起点に求める
+func CouponMonths(fixedIncomeAsset model.FixedIncomeAsset) []int {
+    if len(fixedIncomeAsset.PaymentMonth) > 0 {
+        months := make([]int, len(fixedIncomeAsset.PaymentMonth))
+        for i, month := range fixedIncomeAsset.PaymentMonth {
+            months[i] = int(month)
+        }
+        return months
+    }
+    count, ok := PaymentsPerYear[fixedIncomeAsset.CouponFrequency]
+    anchor := fixedIncomeAsset.MaturityDate
+    if anchor == nil {
+        anchor = fixedIncomeAsset.IssueDate
+    }
+    if !ok || anchor == nil {
+        return nil
+    }
+    months := make([]int, count)
+    for i := range months {
+        months[i] = (int(anchor.Month())-1+i*12/count)%12 + 1
+    }
+    return months
+}
+
+// CouponDate は指定した年月の利払い日を返します(満期日・発行日の日付に合わせ、どちらも未設定の場合は月末とする)
+func CouponDate(fixedIncomeAsset model.FixedIncomeAsset, year int, month time.Month) time.Time {
+    lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+    day := lastDay
+    if fixedIncomeAsset.MaturityDate != nil {
+        day = fixedIncomeAsset.MaturityDate.Day()
+    } else if fixedIncomeAsset.IssueDate != nil {
+        day = fixedIncomeAsset.IssueDate.Day()
+    }
+    if day > lastDay {
+        day = lastDay
+    }
+    return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// CouponAmount は1回あたりの利払い金額を返します(償還金額 × 年利 ÷ 年間の利払い回数)
+func CouponAmount(fixedIncomeAsset model.FixedIncomeAsset) float64 {
+    count, ok := PaymentsPerYear[fixedIncomeAsset.CouponFrequency]
+    if !ok {
+        count = len(CouponMonths(fixedIncomeAsset))
+    }
+    if count == 0 {
+        return 0
+    }
+    return RedemptionAmount(fixedIncomeAsset) * fixedIncomeAsset.DividendRate / 100 / float64(count)
+}
+
+// 満期一括の利息を計算する(償還金額 × 年利 × 経過日数 ÷ 365)
+func atMaturityInterest(fixedIncomeAsset model.FixedIncomeAsset, from time.Time, to time.Time) float64 {
+    years := to.Sub(from).Hours() / 24 / 365
+    return RedemptionAmount(fixedIncomeAsset) * fixedIncomeAsset.DividendRate / 100 * years
+}
+
+// AtMaturityInterest は満期一括の資産が満期日に受け取る利息を返します(発行日・満期日が未設定の場合は0)
+func AtMaturityInterest(fixedIncomeAsset model.FixedIncomeAsset) float64 {
+    if fixedIncomeAsset.IssueDate == nil || fixedIncomeAsset.MaturityDate == nil {
+        return 0
+    }
+    return atMaturityInterest(fixedIncomeAsset, *fixedIncomeAsset.IssueDate, *fixedIncomeAsset.MaturityDate)
+}
+
+// 基準日の直前・直後の利払い日を求める(利払い月がない場合は ok が false)
+func couponPeriod(fixedIncomeAsset model.FixedIncomeAsset, now time.Time) (previous time.Time, next time.Time, ok bool) {
+    months := CouponMonths(fixedIncomeAsset)
+    if len(months) == 0 {
+        return time.Time{}, time.Time{}, false
+    }
+    isCouponMonth := map[int]bool{}
+    for _, month := range months {
+        isCouponMonth[month] = true
+    }
+    base := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+    var foundPrevious, foundNext bool
+    // 利払い月は1年周期のため前後13ヶ月を探せば必ず見つかる
+    for i := 0; i <= 13 && !(foundPrevious && foundNext); i++ {
+        before := base.AddDate(0, -i, 0)
+        if date := CouponDate(fixedIncomeAsset, before.Year(), before.Month()); !foundPrevious && isCouponMonth[int(before.Month())] && !date.After(now) {
+            previous, foundPrevious = date, true
+        }
+        after := base.AddDate(0, i, 0)
+        if date := CouponDate(fixedIncomeAsset, after.Year(), after.Month()); !foundNext && isCouponMonth[int(after.Month())] && date.After(now) {
+            next, foundNext = date, true
+        }
+    }
+    return previous, next, foundPrevious && foundNext
+}
+
+// AccruedInterest は基準日時点の経過利息(前回の利払い日から基準日までに発生した未受取の利息)を資産の通貨建てで返します
+// 発行日前・満期日以降は0とし、発行後最初の利払いまでは発行日から日割りで計算する
+// 利払い頻度が未設定の資産(不動産・クラウドファンディングの分配金など)は経過利息を計上しない
+func AccruedInterest(fixedIncomeAsset model.FixedIncomeAsset, now time.Time) float64 {
+    if fixedIncomeAsset.CouponFrequency == "" || fixedIncomeAsset.Status == StatusMatured || fixedIncomeAsset.DividendRate == 0 {
+        return 0
+    }
+    if fixedIncomeAsset.IssueDate != nil && !now.After(*fixedIncomeAsset.IssueDate) {
+        return 0
+    }
+    if fixedIncomeAsset.MaturityDate != nil && !now.Before(*fixedIncomeAsset.MaturityDate) {
+        return 0
+    }
+    if fixedIncomeAsset.CouponFrequency == CouponFrequencyAtMaturity {
+        if fixedIncomeAsset.IssueDate == nil {
+            return 0
+        }
+        return atMaturityInterest(fixedIncomeAsset, *fixedIncomeAsset.IssueDate, now)
+    }
+    previous, next, ok := couponPeriod(fixedIncomeAsset, now)
+    if !ok {
+        return 0
+    }
+    accrualStart := previous
+    if fixedIncomeAsset.IssueDate != nil && fixedIncomeAsset.IssueDate.After(previous) {
+        accrualStart = *fixedIncomeAsset.IssueDate
+    }
+    return CouponAmount(fixedIncomeAsset) * now.Sub(accrualStart).Hours() / next.Sub(previous).Hours()
+}
+
+// AmortizedCost は基準日時点の償却原価を資産の通貨建てで返します
+// 購入日(未設定の場合は登録日)から満期日まで取得価格合計を償還金額に向けて定額で償却し、満期日が未設定の場合は取得価格合計とする
+func AmortizedCost(fixedIncomeAsset model.FixedIncomeAsset, now time.Time) float64 {
+    cost := fixedIncomeAsset.GetPriceTotal
+    if fixedIncomeAsset.MaturityDate == nil {
+        return cost
+    }
+    purchaseDate := fixedIncomeAsset.CreatedAt
+    if fixedIncomeAsset.PurchaseDate != nil {
+        purchaseDate = *fixedIncomeAsset.PurchaseDate
+    }
+    total := fixedIncomeAsset.MaturityDate.Sub(purchaseDate)
+    if total <= 0 {
+        return cost
+    }
+    elapsed := now.Sub(purchaseDate)
+    if elapsed <= 0 {
+        return cost
+    }
+    if elapsed >= total {
+        return RedemptionAmount(fixedIncomeAsset)
+    }
+    return cost + (RedemptionAmount(fixedIncomeAsset)-cost)*elapsed.Hours()/total.Hours()
+}
+
+// PrincipalValuation は評価方法に従った元本部分の評価額を資産の通貨建てで返します(経過利息は含まない)
+// 時価評価でクリーン価格が未登録の場合は償却原価で評価する
+func PrincipalValuation(fixedIncomeAsset model.FixedIncomeAsset, now time.Time) float64 {
+    switch fixedIncomeAsset.ValuationMode {
+    case ValuationModeFace:
+        return RedemptionAmount(fixedIncomeAsset)
+    case ValuationModeMarket:
+        if fixedIncomeAsset.CleanPrice != nil {
+            return RedemptionAmount(fixedIncomeAsset) * *fixedIncomeAsset.CleanPrice / 100
+        }
+    }
+    return AmortizedCost(fixedIncomeAsset, now)
+}
+
+// Valuation は評価方法に従った元本部分の評価額と経過利息の合計を資産の通貨建てで返します
+func Valuation(fixedIncomeAsset model.FixedIncomeAsset, now time.Time) float64 {
+    return PrincipalValuation(fixedIncomeAsset, now) + AccruedInterest(fixedIncomeAsset, now)
+}
+
+// ValuationJpy は固定利回り資産の円ベースの評価額を計算します(ドル建ての場合は現在のドル円で換算する)
+func ValuationJpy(fixedIncomeAsset model.FixedIncomeAsset, currentUsdJpy float64, now time.Time) float64 {
+    if IsUsd(fixedIncomeAsset) {
+        return Valuation(fixedIncomeAsset, now) * currentUsdJpy
+    }
+    return Valuation(fixedIncomeAsset, now)
+}
+
+// CurrentUsdJpy はドル建ての資産がある場合のみ現在のドル円を取得します(ドル建ての資産がない場合は0を返す)
+func CurrentUsdJpy(ctx context.Context, currencyRepo repoCurrency.CurrencyRepository, fixedIncomeAssets []model.FixedIncomeAsset) (float64, error) {
+    for _, fixedIncomeAsset := range fixedIncomeAssets {
+        if IsUsd(fixedIncomeAsset) {
+            return currencyRepo.FetchCurrentUsdJpy(ctx)
+        }
+    }
+    return 0, nil
+}
+
+// TotalValuationJpy は固定利回り資産の円ベースの評価総額を計算します(ドル建ての資産は現在のドル円で円に換算する)
+func TotalValuationJpy(ctx context.Context, currencyRepo repoCurrency.CurrencyRepository, fixedIncomeAssets []model.FixedIncomeAsset, now time.Time) (float64, error) {
+    currentUsdJpy, err := CurrentUsdJpy(ctx, currencyRepo, fixedIncomeAssets)
+    if err != nil {
+        return 0, err
+    }
+    var total float64
+    for _, fixedIncomeAsset := range fixedIncomeAssets {
+        total += ValuationJpy(fixedIncomeAsset, currentUsdJpy, now)
+    }
+    return total, nil
+}
+
+// FxGainLoss はドル建ての固定利回り資産の購入時為替に対する円ベースの為替差損益を計算します
+// 円建ての資産・購入時為替が未登録の資産は nil を返します
+func FxGainLoss(fixedIncomeAsset model.FixedIncomeAsset, currentUsdJpy float64) *float64 {
+    if !IsUsd(fixedIncomeAsset) || fixedIncomeAsset.UsdJpy == nil {
+        return nil
+    }
+    gainLoss := fixedIncomeAsset.GetPriceTotal * (currentUsdJpy - *fixedIncomeAsset.UsdJpy)
+    return &gainLoss
+}
@@ -0,0 +1,137 @@
+package fixedincome
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) *time.Time {
+    d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+    return &d
+}
+
+// 前回の利払い日から基準日までの利息を利払い期間で日割りする
+func TestAccruedInterest(t *testing.T) {
+    redemptionAmount := 1000.0
+    bond := model.FixedIncomeAsset{
+        GetPriceTotal: 950, RedemptionAmount: &redemptionAmount, DividendRate: 4.0,
+        IssueDate: date(2025, 1, 15), MaturityDate: date(2028, 1, 15), CouponFrequency: CouponFrequencySemiAnnual,
+    }
+
+    // 2026-01-15 から 2026-07-15 までの181日間のうち90日分
+    assert.InDelta(t, 20.0*90/181, AccruedInterest(bond, *date(2026, 4, 15)), 0.0001)
+    // 利払い日当日は0
+    assert.Equal(t, 0.0, AccruedInterest(bond, *date(2026, 7, 15)))
+    // 満期日以降は0
+    assert.Equal(t, 0.0, AccruedInterest(bond, *date(2028, 2, 1)))
+
+    // 発行後最初の利払いまでは発行日から日割りで計算する
+    newBond := bond
+    newBond.IssueDate = date(2026, 3, 1)
+    assert.InDelta(t, 20.0*45/181, AccruedInterest(newBond, *date(2026, 4, 15)), 0.0001)
+    assert.Equal(t, 0.0, AccruedInterest(newBond, *date(2026, 2, 1)))
+
+    // 満期一括の場合は発行日からの経過日数で計算する
+    loan := model.FixedIncomeAsset{
+        GetPriceTotal: 100000, DividendRate: 5.0,
+        IssueDate: date(2026, 1, 1), MaturityDate: date(2027, 1, 1), CouponFrequency: CouponFrequencyAtMaturity,
+    }
+    assert.InDelta(t, 100000*0.05*181/365, AccruedInterest(loan, *date(2026, 7, 1)), 0.0001)
+
+    // 利払い頻度が未設定の資産は経過利息を計上しない
+    fund := model.FixedIncomeAsset{GetPriceTotal: 200000, DividendRate: 3.0, PaymentMonth: pq.Int64Array{6, 12}}
+    assert.Equal(t, 0.0, AccruedInterest(fund, *date(2026, 4, 15)))
+}
+
+// 購入日から満期日まで取得価格合計を償還金額に向けて定額で償却する
+func TestAmortizedCost(t *testing.T) {
+    redemptionAmount := 1000.0
+    bond := model.FixedIncomeAsset{
+        GetPriceTotal: 950, RedemptionAmount: &redemptionAmount,
+        PurchaseDate: date(2026, 1, 1), MaturityDate: date(2028, 1, 1),
+    }
+
+    assert.Equal(t, 950.0, AmortizedCost(bond, *date(2025, 12, 1)))
+    assert.InDelta(t, 950+50*365.0/730, AmortizedCost(bond, *date(2027, 1, 1)), 0.0001)
+    assert.Equal(t, 1000.0, AmortizedCost(bond, *date(2028, 6, 1)))
+
+    // 購入日が未設定の場合は登録日から償却する
+    registered := bond
+    registered.PurchaseDate = nil
+    registered.CreatedAt = *date(2026, 1, 1)
+    assert.InDelta(t, 950+50*365.0/730, AmortizedCost(registered, *date(2027, 1, 1)), 0.0001)
+
+    // 満期日が未設定の場合は取得価格合計
+    perpetual := bond
+    perpetual.MaturityDate = nil
+    assert.Equal(t, 950.0, AmortizedCost(perpetual, *date(2027, 1, 1)))
+}
+
+// 評価方法ごとに元本部分の評価額を計算し、ドル建ては現在のドル円で円に換算する
+func TestValuation(t *testing.T) {
+    now := *date(2027, 1, 1)
+    redemptionAmount := 1000.0
+    cleanPrice := 98.5
+    bond := model.FixedIncomeAsset{
+        GetPriceTotal: 950, RedemptionAmount: &redemptionAmount,
+        PurchaseDate: date(2026, 1, 1), MaturityDate: date(2028, 1, 1), Currency: CurrencyUsd,
+    }
+
+    face := bond
+    face.ValuationMode = ValuationModeFace
+    assert.Equal(t, 1000.0, PrincipalValuation(face, now))
+
+    market := bond
+    market.ValuationMode = ValuationModeMarket
+    market.CleanPrice = &cleanPrice
+    assert.Equal(t, 985.0, PrincipalValuation(market, now))
+    assert.Equal(t, 147750.0, ValuationJpy(market, 150, now))
+
+    // 時価が未登録の場合は償却原価で評価する
+    market.CleanPrice = nil
+    assert.Equal(t, AmortizedCost(bond, now), PrincipalValuation(market, now))
+
+    // 評価額には経過利息を含める
+    coupon := face
+    coupon.DividendRate = 4.0
+    coupon.CouponFrequency = CouponFrequencyAnnual
+    assert.InDelta(t, 1000+AccruedInterest(coupon, *date(2027, 7, 1)), Valuation(coupon, *date(2027, 7, 1)), 0.0001)
+    assert.Greater(t, AccruedInterest(coupon, *date(2027, 7, 1)), 0.0)
+}
+
+// 通貨が円の資産は購入時為替が登録されていてもドル建てにしない
+func TestIsUsd(t *testing.T) {
+    usdJpy := 140.0
+    assert.True(t, IsUsd(model.FixedIncomeAsset{Currency: CurrencyUsd}))
+    assert.True(t, IsUsd(model.FixedIncomeAsset{UsdJpy: &usdJpy}))
+    assert.False(t, IsUsd(model.FixedIncomeAsset{Currency: CurrencyJpy, UsdJpy: &usdJpy}))
+    assert.False(t, IsUsd(model.FixedIncomeAsset{}))
+}
+
+// ドル建ての資産がある場合のみドル円を取得して評価総額を円に換算する
+func TestTotalValuationJpy(t *testing.T) {
+    now := *date(2027, 1, 1)
+    mockCurrencyRepo := repoCurrency.NewMockCurrencyRepository()
+    mockCurrencyRepo.On("FetchCurrentUsdJpy", context.Background()).Return(150.0, nil)
+
+    total, err := TotalValuationJpy(context.Background(), mockCurrencyRepo, []model.FixedIncomeAsset{
+        {GetPriceTotal: 100000, Currency: CurrencyJpy},
+    }, now)
+    assert.NoError(t, err)
+    assert.Equal(t, 100000.0, total)
+    mockCurrencyRepo.AssertNotCalled(t, "FetchCurrentUsdJpy", context.Background())
+
+    total, err = TotalValuationJpy(context.Background(), mockCurrencyRepo, []model.FixedIncomeAsset{
+        {GetPriceTotal: 100000, Currency: CurrencyJpy},
+        {GetPriceTotal: 1000, Currency: CurrencyUsd},
+    }, now)
+    assert.NoError(t, err)
+    assert.Equal(t, 250000.0, total)
+    mockCurrencyRepo.AssertNumberOfCalls(t, "FetchCurrentUsdJpy", 1)
+}
@@ -5,5 +5,6 @@ type CreateJapanFundDto struct {
     Code   string  `json:"code"`
     GetPriceTotal float64 `json:"getPriceTotal"`
     GetPrice float64 `json:"getPrice"`
+    AccountId *uint `json:"accountId"`
     UserId   uint  `json:"userId"`
 }
\ No newline at end of file
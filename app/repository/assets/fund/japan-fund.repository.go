@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"my-us-stock-backend/app/database/model"
+	brokerageaccount "my-us-stock-backend/app/repository/brokerage-account"
+	"my-us-stock-backend/app/repository/nisa"
 
 	"gorm.io/gorm"
 )
@@ -23,7 +25,7 @@ type DefaultJapanFundRepository struct {
 
 // 共通フィールドを選択するためのヘルパー関数です。
 func selectBaseQuery(db *gorm.DB) *gorm.DB {
-    return db.Select("id", "get_price_total", "get_price", "code", "name", "user_id")
+    return db.Select("id", "get_price_total", "get_price", "code", "name", "account_id", "user_id")
 }
 
 // NewJapanFundRepository は DefaultStrategyRepository の新しいインスタンスを作成します
@@ -53,14 +55,34 @@ func (r *DefaultJapanFundRepository) UpdateJapanFund(ctx context.Context, dto Up
         newFund["get_price_total"] = dto.GetPriceTotal
     }
 
-    // 指定されたIDの株式情報を更新します
-    if err := r.DB.Model(&model.JapanFund{}).Where("id = ?", dto.ID).Updates(newFund).Error; err != nil {
-        return nil, err
-    }
-
-    // 更新された情報を取得します
     var JapanFund model.JapanFund
-    if err := selectBaseQuery(r.DB).Where("id = ?", dto.ID).Find(&JapanFund).Error; err != nil {
+    err := r.DB.Transaction(func(tx *gorm.DB) error {
+        var currentJapanFund model.JapanFund
+        if err := selectBaseQuery(tx).Where("id = ?", dto.ID).Find(&currentJapanFund).Error; err != nil {
+            return err
+        }
+
+        // NISA口座で買い増した場合は増えた取得価額を投資枠から差し引く(更新前の保有額で生涯投資枠を計算するため書き込み前に確認する)
+        updatedJapanFund := currentJapanFund
+        if dto.GetPrice != nil {
+            updatedJapanFund.GetPrice = *dto.GetPrice
+        }
+        if dto.GetPriceTotal != nil {
+            updatedJapanFund.GetPriceTotal = *dto.GetPriceTotal
+        }
+        if err := nisa.ReserveJapanFundPurchase(tx, updatedJapanFund, updatedJapanFund.GetPriceTotal-currentJapanFund.GetPriceTotal); err != nil {
+            return err
+        }
+
+        // 指定されたIDの株式情報を更新します
+        if err := tx.Model(&model.JapanFund{}).Where("id = ?", dto.ID).Updates(newFund).Error; err != nil {
+            return err
+        }
+
+        // 更新された情報を取得します
+        return selectBaseQuery(tx).Where("id = ?", dto.ID).Find(&JapanFund).Error
+    })
+    if err != nil {
         return nil, err
     }
 
@@ -69,9 +91,14 @@ func (r *DefaultJapanFundRepository) UpdateJapanFund(ctx context.Context, dto Up
 
 // 日本投資信託情報を作成します
 func (r *DefaultJapanFundRepository) CreateJapanFund(ctx context.Context, dto CreateJapanFundDto) (*model.JapanFund, error) {
-    // 既に同じ銘柄が存在するかを確認
+    // 口座の指定がない場合は既定の口座に登録する(他のユーザーの口座には登録できない)
+    accountId, err := brokerageaccount.ResolveAccount(r.DB, dto.AccountId, dto.UserId)
+    if err != nil {
+        return nil, err
+    }
+    // 同じ口座に既に同じ銘柄が存在するかを確認
     var existingJapanFund model.JapanFund
-    if err := selectBaseQuery(r.DB).Where("code = ? AND user_id = ? AND name = ?", dto.Code, dto.UserId, dto.Name).First(&existingJapanFund).Error; err == nil {
+    if err := brokerageaccount.ScopeAccount(selectBaseQuery(r.DB), accountId).Where("code = ? AND user_id = ? AND name = ?", dto.Code, dto.UserId, dto.Name).First(&existingJapanFund).Error; err == nil {
         return nil, fmt.Errorf("この銘柄は既に登録されています")
     }
 
@@ -81,10 +108,18 @@ func (r *DefaultJapanFundRepository) CreateJapanFund(ctx context.Context, dto Cr
         Code:   dto.Code,
         GetPriceTotal: dto.GetPriceTotal,
         GetPrice: dto.GetPrice,
+        AccountId: accountId,
         UserId:   dto.UserId,
     }
 
-    if err := r.DB.Create(&japanFund).Error; err != nil {
+    err = r.DB.Transaction(func(tx *gorm.DB) error {
+        // NISA口座の場合は書き込み前に投資枠と照合する(作成後に照合すると取得価額が二重に計上される)
+        if err := nisa.ReserveJapanFundPurchase(tx, *japanFund, japanFund.GetPriceTotal); err != nil {
+            return err
+        }
+        return tx.Create(&japanFund).Error
+    })
+    if err != nil {
         return nil, err
     }
 
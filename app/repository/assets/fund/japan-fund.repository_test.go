@@ -3,6 +3,7 @@ package fund
 import (
 	"context"
 	"my-us-stock-backend/app/database/model"
+	brokerageaccount "my-us-stock-backend/app/repository/brokerage-account"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -18,7 +19,7 @@ func setupTestDB() *gorm.DB {
     }
 
     // テスト用のテーブルを準備
-    db.AutoMigrate(&model.JapanFund{})
+    db.AutoMigrate(&model.JapanFund{}, &model.BrokerageAccount{})
 
     return db
 }
@@ -127,8 +128,9 @@ func TestCreateJapanFundAlreadyExists(t *testing.T) {
     db := setupTestDB()
     repo := NewJapanFundRepository(db)
 
-    // 既存の銘柄をデータベースに登録
-    existingFund := model.JapanFund{Name: "ｅＭＡＸＩＳ Ｓｌｉｍ 米国株式（Ｓ＆Ｐ５００）1", UserId: 99, Code: "253266", GetPrice: 15523.81, GetPriceTotal: 761157}
+    // 口座を指定しない場合は既定の口座に登録するため、既存の銘柄を既定の口座に登録
+    defaultAccountId, _ := brokerageaccount.FindOrCreateDefaultAccount(db, 99)
+    existingFund := model.JapanFund{Name: "ｅＭＡＸＩＳ Ｓｌｉｍ 米国株式（Ｓ＆Ｐ５００）1", UserId: 99, AccountId: defaultAccountId, Code: "253266", GetPrice: 15523.81, GetPriceTotal: 761157}
     db.Create(&existingFund)
 
     // 同じ銘柄で新しい情報を作成
@@ -7,4 +7,5 @@ type CreateUsStockDto struct {
     UserId   uint  `json:"userId"`
     Sector   string  `json:"sector"`
     UsdJpy   float64 `json:"usdjpy"`
+    AccountId *uint `json:"accountId"`
 }
\ No newline at end of file
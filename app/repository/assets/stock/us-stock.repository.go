@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"my-us-stock-backend/app/database/model"
+	brokerageaccount "my-us-stock-backend/app/repository/brokerage-account"
+	"my-us-stock-backend/app/repository/nisa"
 
 	"gorm.io/gorm"
 )
@@ -23,7 +25,7 @@ type DefaultUsStockRepository struct {
 
 // 共通フィールドを選択するためのヘルパー関数です。
 func selectBaseQuery(db *gorm.DB) *gorm.DB {
-    return db.Select("id", "sector", "quantity", "get_price", "code", "usd_jpy", "user_id")
+    return db.Select("id", "sector", "quantity", "get_price", "code", "usd_jpy", "account_id", "user_id")
 }
 
 // NewUsStockRepository は DefaultStrategyRepository の新しいインスタンスを作成します
@@ -56,14 +58,38 @@ func (r *DefaultUsStockRepository) UpdateUsStock(ctx context.Context, dto Update
         newStock["usd_jpy"] = dto.UsdJpy
     }
 
-    // 指定されたIDの株式情報を更新します
-    if err := r.DB.Model(&model.UsStock{}).Where("id = ?", dto.ID).Updates(newStock).Error; err != nil {
-        return nil, err
-    }
-
-    // 更新された情報を取得します
     var usStock model.UsStock
-    if err := selectBaseQuery(r.DB).Where("id = ?", dto.ID).Find(&usStock).Error; err != nil {
+    err := r.DB.Transaction(func(tx *gorm.DB) error {
+        var currentUsStock model.UsStock
+        if err := selectBaseQuery(tx).Where("id = ?", dto.ID).Find(&currentUsStock).Error; err != nil {
+            return err
+        }
+
+        // NISA口座で買い増した場合は増えた取得価額を投資枠から差し引く(更新前の保有額で生涯投資枠を計算するため書き込み前に確認する)
+        updatedUsStock := currentUsStock
+        if dto.GetPrice != nil {
+            updatedUsStock.GetPrice = *dto.GetPrice
+        }
+        if dto.Quantity != nil {
+            updatedUsStock.Quantity = *dto.Quantity
+        }
+        if dto.UsdJpy != nil {
+            updatedUsStock.UsdJpy = *dto.UsdJpy
+        }
+        increase := updatedUsStock.GetPrice*updatedUsStock.Quantity*updatedUsStock.UsdJpy - currentUsStock.GetPrice*currentUsStock.Quantity*currentUsStock.UsdJpy
+        if err := nisa.ReserveUsStockPurchase(tx, updatedUsStock, increase); err != nil {
+            return err
+        }
+
+        // 指定されたIDの株式情報を更新します
+        if err := tx.Model(&model.UsStock{}).Where("id = ?", dto.ID).Updates(newStock).Error; err != nil {
+            return err
+        }
+
+        // 更新された情報を取得します
+        return selectBaseQuery(tx).Where("id = ?", dto.ID).Find(&usStock).Error
+    })
+    if err != nil {
         return nil, err
     }
 
@@ -72,9 +98,14 @@ func (r *DefaultUsStockRepository) UpdateUsStock(ctx context.Context, dto Update
 
 // 米国株式情報を作成します
 func (r *DefaultUsStockRepository) CreateUsStock(ctx context.Context, dto CreateUsStockDto) (*model.UsStock, error) {
-    // 既に同じ銘柄が存在するかを確認
+    // 口座の指定がない場合は既定の口座に登録する(他のユーザーの口座には登録できない)
+    accountId, err := brokerageaccount.ResolveAccount(r.DB, dto.AccountId, dto.UserId)
+    if err != nil {
+        return nil, err
+    }
+    // 同じ口座に既に同じ銘柄が存在するかを確認
     var existingUsStock model.UsStock
-    if err := selectBaseQuery(r.DB).Where("code = ? AND user_id = ?", dto.Code, dto.UserId).First(&existingUsStock).Error; err == nil {
+    if err := brokerageaccount.ScopeAccount(selectBaseQuery(r.DB), accountId).Where("code = ? AND user_id = ?", dto.Code, dto.UserId).First(&existingUsStock).Error; err == nil {
         return nil, fmt.Errorf("この銘柄は既に登録されています")
     }
 
@@ -83,12 +114,20 @@ func (r *DefaultUsStockRepository) CreateUsStock(ctx context.Context, dto Create
         Code:   dto.Code,
         GetPrice: dto.GetPrice,
         Quantity: dto.Quantity,
+        AccountId: accountId,
         UserId:   dto.UserId,
         Sector:   dto.Sector,
         UsdJpy:   dto.UsdJpy,
     }
 
-    if err := r.DB.Create(&usStock).Error; err != nil {
+    err = r.DB.Transaction(func(tx *gorm.DB) error {
+        // NISA口座の場合は書き込み前に投資枠と照合する(作成後に照合すると取得価額が二重に計上される)
+        if err := nisa.ReserveUsStockPurchase(tx, *usStock, usStock.GetPrice*usStock.Quantity*usStock.UsdJpy); err != nil {
+            return err
+        }
+        return tx.Create(&usStock).Error
+    })
+    if err != nil {
         return nil, err
     }
 
@@ -3,6 +3,7 @@ package stock
 import (
 	"context"
 	"my-us-stock-backend/app/database/model"
+	brokerageaccount "my-us-stock-backend/app/repository/brokerage-account"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -18,7 +19,7 @@ func setupTestDB() *gorm.DB {
     }
 
     // テスト用のテーブルを準備
-    db.AutoMigrate(&model.UsStock{})
+    db.AutoMigrate(&model.UsStock{}, &model.BrokerageAccount{}, &model.JapanFund{}, &model.NisaPurchase{})
 
     return db
 }
@@ -109,8 +110,9 @@ func TestCreateUsStockAlreadyExists(t *testing.T) {
     db := setupTestDB()
     repo := NewUsStockRepository(db)
 
-    // 既存の銘柄をデータベースに登録
-    existingStock := model.UsStock{Code: "AAPL", UserId: 99, Quantity: 10, GetPrice: 150.0, Sector: "Tech", UsdJpy: 110.0}
+    // 口座を指定しない場合は既定の口座に登録するため、既存の銘柄を既定の口座に登録
+    defaultAccountId, _ := brokerageaccount.FindOrCreateDefaultAccount(db, 99)
+    existingStock := model.UsStock{Code: "AAPL", UserId: 99, AccountId: defaultAccountId, Quantity: 10, GetPrice: 150.0, Sector: "Tech", UsdJpy: 110.0}
     db.Create(&existingStock)
 
     // 同じ銘柄で新しい株式情報を作成
@@ -129,6 +131,86 @@ func TestCreateUsStockAlreadyExists(t *testing.T) {
     assert.Contains(t, err.Error(), "この銘柄は既に登録されています")
 }
 
+// 口座が異なれば同じ銘柄を登録できる
+func TestCreateUsStockInAnotherAccount(t *testing.T) {
+    db := setupTestDB()
+    repo := NewUsStockRepository(db)
+
+    nisa := model.BrokerageAccount{Name: "SBI NISA", Type: "NISA_GROWTH", UserId: 98}
+    db.Create(&nisa)
+    db.Create(&model.UsStock{Code: "AAPL", UserId: 98, Quantity: 10, GetPrice: 150.0, Sector: "Tech", UsdJpy: 110.0})
+
+    created, err := repo.CreateUsStock(context.Background(), CreateUsStockDto{Code: "AAPL", UserId: 98, Quantity: 5, GetPrice: 180.0, Sector: "Tech", UsdJpy: 150.0, AccountId: &nisa.ID})
+    assert.NoError(t, err)
+    assert.Equal(t, nisa.ID, created.AccountId)
+
+    // 同じ口座には登録できない
+    _, err = repo.CreateUsStock(context.Background(), CreateUsStockDto{Code: "AAPL", UserId: 98, Quantity: 5, GetPrice: 180.0, Sector: "Tech", UsdJpy: 150.0, AccountId: &nisa.ID})
+    assert.EqualError(t, err, "この銘柄は既に登録されています")
+
+    // 他のユーザーの口座には登録できない
+    _, err = repo.CreateUsStock(context.Background(), CreateUsStockDto{Code: "MSFT", UserId: 97, Quantity: 5, GetPrice: 400.0, Sector: "Tech", UsdJpy: 150.0, AccountId: &nisa.ID})
+    assert.EqualError(t, err, "指定された口座が見つかりません")
+}
+
+func TestCreateUsStockExceedingNisaAllowance(t *testing.T) {
+    db := setupTestDB()
+    repo := NewUsStockRepository(db)
+
+    nisa := model.BrokerageAccount{Name: "SBI NISA", Type: "NISA_GROWTH", UserId: 96}
+    db.Create(&nisa)
+
+    // 200万円分の購入は成長投資枠に収まる
+    created, err := repo.CreateUsStock(context.Background(), CreateUsStockDto{Code: "AAPL", UserId: 96, Quantity: 100, GetPrice: 200.0, Sector: "Tech", UsdJpy: 100.0, AccountId: &nisa.ID})
+    assert.NoError(t, err)
+    var purchase model.NisaPurchase
+    db.Where("user_id = ?", 96).First(&purchase)
+    assert.Equal(t, 2000000.0, purchase.Amount)
+    assert.Equal(t, "GROWTH", purchase.Frame)
+
+    // 買い増して年間投資枠(240万円)を超える場合は更新しない
+    quantity := 130.0
+    _, err = repo.UpdateUsStock(context.Background(), UpdateUsStockDto{ID: created.ID, Quantity: &quantity})
+    assert.EqualError(t, err, "成長投資枠の年間投資枠(240万円)を超えるため登録できません")
+    var stock model.UsStock
+    db.Where("user_id = ? AND code = ?", 96, "AAPL").First(&stock)
+    assert.Equal(t, 100.0, stock.Quantity)
+
+    _, err = repo.CreateUsStock(context.Background(), CreateUsStockDto{Code: "MSFT", UserId: 96, Quantity: 10, GetPrice: 400.0, Sector: "Tech", UsdJpy: 150.0, AccountId: &nisa.ID})
+    assert.EqualError(t, err, "成長投資枠の年間投資枠(240万円)を超えるため登録できません")
+    var count int64
+    db.Model(&model.UsStock{}).Where("user_id = ?", 96).Count(&count)
+    assert.Equal(t, int64(1), count)
+
+    // 購入時為替がない場合は円換算できないため登録できない
+    _, err = repo.CreateUsStock(context.Background(), CreateUsStockDto{Code: "KO", UserId: 96, Quantity: 1, GetPrice: 60.0, Sector: "Food", AccountId: &nisa.ID})
+    assert.EqualError(t, err, "NISA口座に登録する場合は購入時為替を入力してください")
+}
+
+func TestCreateUsStockNisaLifetimeNotDoubleCounted(t *testing.T) {
+    db := setupTestDB()
+    repo := NewUsStockRepository(db)
+
+    nisa := model.BrokerageAccount{Name: "楽天 NISA", Type: "NISA_GROWTH", UserId: 95}
+    db.Create(&nisa)
+    // 過去の年に購入した1,100万円分を保有している
+    db.Create(&model.UsStock{Code: "VOO", UserId: 95, Quantity: 1000, GetPrice: 110.0, Sector: "ETF", UsdJpy: 100.0, AccountId: nisa.ID})
+
+    // 100万円の購入で生涯投資枠(1,200万円)ちょうどになるため登録できる(作成した行が二重に計上されない)
+    created, err := repo.CreateUsStock(context.Background(), CreateUsStockDto{Code: "AAPL", UserId: 95, Quantity: 100, GetPrice: 100.0, Sector: "Tech", UsdJpy: 100.0, AccountId: &nisa.ID})
+    assert.NoError(t, err)
+
+    // 更新でも増えた分のみ計上されるため、取得価額を変えない更新はできる
+    usdJpy := 100.0
+    _, err = repo.UpdateUsStock(context.Background(), UpdateUsStockDto{ID: created.ID, UsdJpy: &usdJpy})
+    assert.NoError(t, err)
+
+    // 上限を超える買い増しはできない
+    quantity := 101.0
+    _, err = repo.UpdateUsStock(context.Background(), UpdateUsStockDto{ID: created.ID, Quantity: &quantity})
+    assert.EqualError(t, err, "成長投資枠の生涯投資枠(1,200万円)を超えるため登録できません")
+}
+
 func TestDeleteUsStock(t *testing.T) {
     db := setupTestDB()
     repo := NewUsStockRepository(db)
@@ -0,0 +1,24 @@
+package backup
+
+import "my-us-stock-backend/app/database/model"
+
+// BackupDto はユーザーのバックアップ対象のデータです
+// 復元時はIDを振り直し、UserId は復元先のユーザーに置き換えます
+// 保有資産・資産推移の口座別の内訳・NISAの購入記録の口座、内訳の資産推移は振り直したIDに置き換えます
+// 配当の受取記録・売却記録は確定申告の集計に使うため、保有資産と合わせて復元します
+// UserEmail はログインに使うため復元せず、取得時のみ設定します
+type BackupDto struct {
+    UserId             uint
+    UserName           string
+    UserEmail          string
+    BrokerageAccounts  []model.BrokerageAccount
+    UsStocks           []model.UsStock
+    Cryptos            []model.Crypto
+    JapanFunds         []model.JapanFund
+    FixedIncomeAssets  []model.FixedIncomeAsset
+    TotalAssets        []model.TotalAsset
+    TotalAssetAccounts []model.TotalAssetAccount
+    NisaPurchases      []model.NisaPurchase
+    DividendReceipts   []model.DividendReceipt
+    StockSales         []model.StockSale
+}
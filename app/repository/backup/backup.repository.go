@@ -0,0 +1,219 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/database/model"
+	brokerageaccount "my-us-stock-backend/app/repository/brokerage-account"
+	"my-us-stock-backend/app/repository/nisa"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrAccountNotEmpty は復元先のアカウントに既にデータがある場合のエラーです
+var ErrAccountNotEmpty = errors.New("復元先のアカウントに既にデータがあります")
+
+// BackupRepository インターフェースの定義
+type BackupRepository interface {
+    FetchBackup(ctx context.Context, userId uint) (*BackupDto, error)
+    RestoreBackup(ctx context.Context, dto BackupDto) (*BackupDto, error)
+}
+
+// DefaultBackupRepository 構造体の定義
+type DefaultBackupRepository struct {
+    DB *gorm.DB
+}
+
+// NewBackupRepository は DefaultBackupRepository の新しいインスタンスを作成します
+func NewBackupRepository(db *gorm.DB) BackupRepository {
+    return &DefaultBackupRepository{DB: db}
+}
+
+// FetchBackup はユーザーの口座・保有資産・資産推移・NISAの購入記録・配当の受取記録・売却記録をまとめて取得します(削除済みのデータは含まない)
+func (r *DefaultBackupRepository) FetchBackup(ctx context.Context, userId uint) (*BackupDto, error) {
+    dto := &BackupDto{UserId: userId}
+    err := r.DB.Transaction(func(tx *gorm.DB) error {
+        var user model.User
+        if err := tx.First(&user, userId).Error; err != nil {
+            return err
+        }
+        dto.UserName, dto.UserEmail = user.Name, user.Email
+        if err := tx.Where("user_id = ?", userId).Order("id").Find(&dto.BrokerageAccounts).Error; err != nil {
+            return err
+        }
+        if err := tx.Where("user_id = ?", userId).Order("id").Find(&dto.UsStocks).Error; err != nil {
+            return err
+        }
+        if err := tx.Where("user_id = ?", userId).Order("id").Find(&dto.Cryptos).Error; err != nil {
+            return err
+        }
+        if err := tx.Where("user_id = ?", userId).Order("id").Find(&dto.JapanFunds).Error; err != nil {
+            return err
+        }
+        if err := tx.Where("user_id = ?", userId).Order("id").Find(&dto.FixedIncomeAssets).Error; err != nil {
+            return err
+        }
+        if err := tx.Where("user_id = ?", userId).Order("created_at, id").Find(&dto.TotalAssets).Error; err != nil {
+            return err
+        }
+        if err := tx.Where("user_id = ?", userId).Order("id").Find(&dto.TotalAssetAccounts).Error; err != nil {
+            return err
+        }
+        if err := tx.Where("user_id = ?", userId).Order("id").Find(&dto.NisaPurchases).Error; err != nil {
+            return err
+        }
+        if err := tx.Where("user_id = ?", userId).Order("id").Find(&dto.DividendReceipts).Error; err != nil {
+            return err
+        }
+        return tx.Where("user_id = ?", userId).Order("id").Find(&dto.StockSales).Error
+    })
+    if err != nil {
+        return nil, err
+    }
+    return dto, nil
+}
+
+// RestoreBackup はバックアップを空のアカウントに1つのトランザクションで復元し、新しいIDを振った結果を返します
+// IDは振り直し、作成日時はバックアップの値を引き継ぎます(資産推移の日付として使われるため)
+func (r *DefaultBackupRepository) RestoreBackup(ctx context.Context, dto BackupDto) (*BackupDto, error) {
+    restored := &BackupDto{UserId: dto.UserId, UserName: dto.UserName}
+    err := r.DB.Transaction(func(tx *gorm.DB) error {
+        for _, table := range []interface{}{&model.BrokerageAccount{}, &model.UsStock{}, &model.Crypto{}, &model.JapanFund{}, &model.FixedIncomeAsset{}, &model.TotalAsset{}, &model.TotalAssetAccount{}, &model.NisaPurchase{}, &model.DividendReceipt{}, &model.StockSale{}} {
+            var count int64
+            if err := tx.Model(table).Where("user_id = ?", dto.UserId).Count(&count).Error; err != nil {
+                return err
+            }
+            if count > 0 {
+                return ErrAccountNotEmpty
+            }
+        }
+        if err := tx.Model(&model.User{}).Where("id = ?", dto.UserId).Update("name", dto.UserName).Error; err != nil {
+            return err
+        }
+        // 口座を先に復元し、元の口座のIDから振り直したIDへの対応を作る
+        accountIds := map[uint]uint{}
+        for _, account := range dto.BrokerageAccounts {
+            originalId := account.ID
+            account.ID, account.UserId = 0, dto.UserId
+            if err := tx.Create(&account).Error; err != nil {
+                return err
+            }
+            accountIds[originalId] = account.ID
+            restored.BrokerageAccounts = append(restored.BrokerageAccounts, account)
+        }
+        // バックアップに口座がない保有資産は既定の口座に復元する
+        resolveAccount := func(originalId uint) (uint, error) {
+            if accountId, ok := accountIds[originalId]; ok {
+                return accountId, nil
+            }
+            return brokerageaccount.FindOrCreateDefaultAccount(tx, dto.UserId)
+        }
+        for _, usStock := range dto.UsStocks {
+            accountId, err := resolveAccount(usStock.AccountId)
+            if err != nil {
+                return err
+            }
+            usStock.ID, usStock.AccountId, usStock.UserId = 0, accountId, dto.UserId
+            if err := tx.Create(&usStock).Error; err != nil {
+                return err
+            }
+            restored.UsStocks = append(restored.UsStocks, usStock)
+        }
+        for _, crypto := range dto.Cryptos {
+            accountId, err := resolveAccount(crypto.AccountId)
+            if err != nil {
+                return err
+            }
+            crypto.ID, crypto.AccountId, crypto.UserId = 0, accountId, dto.UserId
+            if err := tx.Create(&crypto).Error; err != nil {
+                return err
+            }
+            restored.Cryptos = append(restored.Cryptos, crypto)
+        }
+        for _, japanFund := range dto.JapanFunds {
+            accountId, err := resolveAccount(japanFund.AccountId)
+            if err != nil {
+                return err
+            }
+            japanFund.ID, japanFund.AccountId, japanFund.UserId = 0, accountId, dto.UserId
+            if err := tx.Create(&japanFund).Error; err != nil {
+                return err
+            }
+            restored.JapanFunds = append(restored.JapanFunds, japanFund)
+        }
+        for _, fixedIncomeAsset := range dto.FixedIncomeAssets {
+            accountId, err := resolveAccount(fixedIncomeAsset.AccountId)
+            if err != nil {
+                return err
+            }
+            fixedIncomeAsset.ID, fixedIncomeAsset.AccountId, fixedIncomeAsset.UserId = 0, accountId, dto.UserId
+            if err := tx.Create(&fixedIncomeAsset).Error; err != nil {
+                return err
+            }
+            restored.FixedIncomeAssets = append(restored.FixedIncomeAssets, fixedIncomeAsset)
+        }
+        totalAssetIds := map[uint]uint{}
+        for _, totalAsset := range dto.TotalAssets {
+            originalId := totalAsset.ID
+            totalAsset.ID, totalAsset.UserId = 0, dto.UserId
+            if err := tx.Create(&totalAsset).Error; err != nil {
+                return err
+            }
+            totalAssetIds[originalId] = totalAsset.ID
+            restored.TotalAssets = append(restored.TotalAssets, totalAsset)
+        }
+        // 資産推移の口座別の内訳は、口座未設定の内訳(口座が必須になる前のもの)を除き口座を振り直す
+        for _, totalAssetAccount := range dto.TotalAssetAccounts {
+            totalAssetId, ok := totalAssetIds[totalAssetAccount.TotalAssetId]
+            if !ok {
+                return errors.New("口座別の内訳に対応する資産推移がありません")
+            }
+            if totalAssetAccount.AccountId != nil {
+                accountId, err := resolveAccount(*totalAssetAccount.AccountId)
+                if err != nil {
+                    return err
+                }
+                totalAssetAccount.AccountId = &accountId
+            }
+            totalAssetAccount.ID, totalAssetAccount.TotalAssetId, totalAssetAccount.UserId = 0, totalAssetId, dto.UserId
+            if err := tx.Create(&totalAssetAccount).Error; err != nil {
+                return err
+            }
+            restored.TotalAssetAccounts = append(restored.TotalAssetAccounts, totalAssetAccount)
+        }
+        years := []int{time.Now().Year()}
+        for _, nisaPurchase := range dto.NisaPurchases {
+            accountId, err := resolveAccount(nisaPurchase.AccountId)
+            if err != nil {
+                return err
+            }
+            nisaPurchase.ID, nisaPurchase.AccountId, nisaPurchase.UserId = 0, accountId, dto.UserId
+            if err := tx.Create(&nisaPurchase).Error; err != nil {
+                return err
+            }
+            restored.NisaPurchases = append(restored.NisaPurchases, nisaPurchase)
+            years = append(years, nisaPurchase.Year)
+        }
+        for _, dividendReceipt := range dto.DividendReceipts {
+            dividendReceipt.ID, dividendReceipt.UserId = 0, dto.UserId
+            if err := tx.Create(&dividendReceipt).Error; err != nil {
+                return err
+            }
+            restored.DividendReceipts = append(restored.DividendReceipts, dividendReceipt)
+        }
+        for _, stockSale := range dto.StockSales {
+            stockSale.ID, stockSale.UserId = 0, dto.UserId
+            if err := tx.Create(&stockSale).Error; err != nil {
+                return err
+            }
+            restored.StockSales = append(restored.StockSales, stockSale)
+        }
+        // 復元した保有資産・購入記録がNISAの投資枠を超えていないか確認する(超える場合は全て取り消す)
+        return nisa.ValidateUsage(tx, dto.UserId, years)
+    })
+    if err != nil {
+        return nil, err
+    }
+    return restored, nil
+}
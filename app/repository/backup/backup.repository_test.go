@@ -0,0 +1,161 @@
+package backup
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// テスト用のデータベース設定
+func setupTestDB() *gorm.DB {
+    db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+    if err != nil {
+        panic("failed to connect database")
+    }
+    // テスト用のテーブルを準備
+    db.AutoMigrate(&model.User{}, &model.UsStock{}, &model.Crypto{}, &model.JapanFund{}, &model.FixedIncomeAsset{}, &model.TotalAsset{}, &model.BrokerageAccount{}, &model.TotalAssetAccount{}, &model.NisaPurchase{}, &model.DividendReceipt{}, &model.StockSale{})
+    return db
+}
+
+func TestFetchBackup(t *testing.T) {
+    db := setupTestDB()
+    repo := NewBackupRepository(db)
+
+    db.Create(&model.User{Model: gorm.Model{ID: 1}, Name: "taro", Email: "taro@example.com", Password: "hashed"})
+    db.Create(&model.UsStock{Code: "AAPL", GetPrice: 150, Quantity: 10, Sector: "Technology", UsdJpy: 140, UserId: 1})
+    db.Create(&model.UsStock{Code: "KO", GetPrice: 55, Quantity: 20, Sector: "Consumer Defensive", UsdJpy: 140, UserId: 2})
+    deleted := model.Crypto{Code: "eth", GetPrice: 300000, Quantity: 1, UserId: 1}
+    db.Create(&deleted)
+    db.Delete(&deleted)
+    db.Create(&model.TotalAsset{Model: gorm.Model{CreatedAt: time.Date(2024, 1, 11, 1, 0, 0, 0, time.UTC)}, CashJpy: 200, UserId: 1})
+    db.Create(&model.TotalAsset{Model: gorm.Model{CreatedAt: time.Date(2024, 1, 10, 1, 0, 0, 0, time.UTC)}, CashJpy: 100, UserId: 1})
+
+    dto, err := repo.FetchBackup(context.Background(), 1)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "taro", dto.UserName)
+    assert.Len(t, dto.UsStocks, 1)
+    assert.Equal(t, "AAPL", dto.UsStocks[0].Code)
+    // 削除済みのデータは含まない
+    assert.Empty(t, dto.Cryptos)
+    assert.Len(t, dto.TotalAssets, 2)
+    assert.Equal(t, 100.0, dto.TotalAssets[0].CashJpy)
+}
+
+func TestRestoreBackup(t *testing.T) {
+    db := setupTestDB()
+    repo := NewBackupRepository(db)
+
+    db.Create(&model.User{Model: gorm.Model{ID: 2}, Name: "", Email: "hanako@example.com", Password: "hashed"})
+    // 他のユーザーのデータと同じIDでも新しいIDが振られる
+    db.Create(&model.UsStock{Model: gorm.Model{ID: 1}, Code: "KO", GetPrice: 55, Quantity: 20, Sector: "Consumer Defensive", UsdJpy: 140, UserId: 1})
+    createdAt := time.Date(2024, 1, 10, 1, 0, 0, 0, time.UTC)
+
+    restored, err := repo.RestoreBackup(context.Background(), BackupDto{
+        UserId:      2,
+        UserName:    "hanako",
+        UsStocks:    []model.UsStock{{Model: gorm.Model{ID: 1}, Code: "AAPL", GetPrice: 150, Quantity: 10, Sector: "Technology", UsdJpy: 140, UserId: 1}},
+        Cryptos:     []model.Crypto{{Model: gorm.Model{ID: 7}, Code: "btc", GetPrice: 5000000, Quantity: 0.1}},
+        TotalAssets: []model.TotalAsset{{Model: gorm.Model{ID: 3, CreatedAt: createdAt}, CashJpy: 100}},
+    })
+
+    assert.NoError(t, err)
+    assert.NotEqual(t, uint(1), restored.UsStocks[0].ID)
+    assert.Equal(t, uint(2), restored.UsStocks[0].UserId)
+    assert.NotZero(t, restored.Cryptos[0].ID)
+
+    var user model.User
+    db.First(&user, 2)
+    assert.Equal(t, "hanako", user.Name)
+    var totalAsset model.TotalAsset
+    db.Where("user_id = ?", 2).First(&totalAsset)
+    assert.True(t, createdAt.Equal(totalAsset.CreatedAt))
+    var otherStock model.UsStock
+    db.First(&otherStock, 1)
+    assert.Equal(t, "KO", otherStock.Code)
+}
+
+// 口座を先に復元し、保有資産・資産推移の内訳・NISAの購入記録の口座を振り直したIDに置き換える
+func TestRestoreBackupAccounts(t *testing.T) {
+    db := setupTestDB()
+    repo := NewBackupRepository(db)
+
+    db.Create(&model.User{Model: gorm.Model{ID: 3}, Name: "", Email: "saburo@example.com", Password: "hashed"})
+    // 他のユーザーの口座と同じIDでも新しいIDが振られる
+    db.Create(&model.BrokerageAccount{Model: gorm.Model{ID: 10}, Name: "他のユーザーの口座", Type: "TAXABLE", UserId: 1})
+    createdAt := time.Date(2024, 1, 10, 1, 0, 0, 0, time.UTC)
+    accountId := uint(10)
+
+    restored, err := repo.RestoreBackup(context.Background(), BackupDto{
+        UserId:             3,
+        UserName:           "saburo",
+        BrokerageAccounts:  []model.BrokerageAccount{{Model: gorm.Model{ID: 10}, Name: "SBI NISA", Type: "NISA_GROWTH"}},
+        UsStocks:           []model.UsStock{{Model: gorm.Model{ID: 1}, Code: "AAPL", GetPrice: 150, Quantity: 10, Sector: "Technology", UsdJpy: 140, AccountId: 10}},
+        TotalAssets:        []model.TotalAsset{{Model: gorm.Model{ID: 3, CreatedAt: createdAt}, Stock: 210000}},
+        TotalAssetAccounts: []model.TotalAssetAccount{{TotalAssetId: 3, AccountId: &accountId, Stock: 210000}, {TotalAssetId: 3}},
+        NisaPurchases:      []model.NisaPurchase{{Year: 2024, Frame: "GROWTH", HoldingType: "US_STOCK", Code: "AAPL", Amount: 210000, AccountId: 10}},
+    })
+
+    assert.NoError(t, err)
+    newAccountId := restored.BrokerageAccounts[0].ID
+    assert.NotEqual(t, uint(10), newAccountId)
+    assert.Equal(t, uint(3), restored.BrokerageAccounts[0].UserId)
+    assert.Equal(t, newAccountId, restored.UsStocks[0].AccountId)
+    assert.Equal(t, newAccountId, restored.NisaPurchases[0].AccountId)
+
+    var totalAssetAccounts []model.TotalAssetAccount
+    db.Where("user_id = ?", 3).Order("id").Find(&totalAssetAccounts)
+    assert.Len(t, totalAssetAccounts, 2)
+    assert.Equal(t, restored.TotalAssets[0].ID, totalAssetAccounts[0].TotalAssetId)
+    assert.Equal(t, newAccountId, *totalAssetAccounts[0].AccountId)
+    // 口座未設定の内訳はそのまま復元する
+    assert.Nil(t, totalAssetAccounts[1].AccountId)
+}
+
+// 復元するとNISAの投資枠を超える場合は何も復元しない
+func TestRestoreBackupExceedingNisaAllowance(t *testing.T) {
+    db := setupTestDB()
+    repo := NewBackupRepository(db)
+
+    db.Create(&model.User{Model: gorm.Model{ID: 5}, Name: "", Email: "goro@example.com", Password: "hashed"})
+
+    _, err := repo.RestoreBackup(context.Background(), BackupDto{
+        UserId:            5,
+        UserName:          "goro",
+        BrokerageAccounts: []model.BrokerageAccount{{Model: gorm.Model{ID: 1}, Name: "SBI NISA", Type: "NISA_GROWTH"}},
+        JapanFunds:        []model.JapanFund{{Model: gorm.Model{ID: 1}, Code: "0331418A", Name: "eMAXIS Slim 全世界株式(オール・カントリー)", GetPrice: 15000, GetPriceTotal: 13000000, AccountId: 1}},
+    })
+    assert.EqualError(t, err, "成長投資枠の生涯投資枠(1,200万円)を超えるため登録できません")
+
+    var count int64
+    db.Model(&model.BrokerageAccount{}).Where("user_id = ?", 5).Count(&count)
+    assert.Equal(t, int64(0), count)
+}
+
+// 復元先のアカウントにデータがある場合は何も復元しない
+func TestRestoreBackupAccountNotEmpty(t *testing.T) {
+    db := setupTestDB()
+    repo := NewBackupRepository(db)
+
+    db.Create(&model.User{Model: gorm.Model{ID: 1}, Name: "taro", Email: "taro@example.com", Password: "hashed"})
+    db.Create(&model.TotalAsset{CashJpy: 100, UserId: 1})
+
+    _, err := repo.RestoreBackup(context.Background(), BackupDto{
+        UserId:   1,
+        UserName: "jiro",
+        UsStocks: []model.UsStock{{Code: "AAPL", GetPrice: 150, Quantity: 10, Sector: "Technology"}},
+    })
+
+    assert.ErrorIs(t, err, ErrAccountNotEmpty)
+    var count int64
+    db.Model(&model.UsStock{}).Count(&count)
+    assert.Equal(t, int64(0), count)
+    var user model.User
+    db.First(&user, 1)
+    assert.Equal(t, "taro", user.Name)
+}
@@ -0,0 +1,33 @@
+package backup
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBackupRepository は BackupRepository のモックです。
+type MockBackupRepository struct {
+	mock.Mock
+}
+
+// NewMockBackupRepository は新しい MockBackupRepository を作成し、初期設定を行います。
+func NewMockBackupRepository() *MockBackupRepository {
+	return &MockBackupRepository{}
+}
+
+func (m *MockBackupRepository) FetchBackup(ctx context.Context, userId uint) (*BackupDto, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*BackupDto), args.Error(1)
+}
+
+func (m *MockBackupRepository) RestoreBackup(ctx context.Context, dto BackupDto) (*BackupDto, error) {
+	args := m.Called(ctx, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*BackupDto), args.Error(1)
+}
@@ -0,0 +1,14 @@
+package brokerageaccount
+
+type CreateBrokerageAccountDto struct {
+    Name   string `json:"name"`
+    Type   string `json:"type"`
+    UserId uint   `json:"userId"`
+}
+
+type UpdateBrokerageAccountDto struct {
+    ID     uint   `json:"id"`
+    Name   string `json:"name"`
+    Type   string `json:"type"`
+    UserId uint   `json:"userId"`
+}
@@ -0,0 +1,174 @@
+package brokerageaccount
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/database/model"
+
+	"gorm.io/gorm"
+)
+
+// ErrAccountNotFound は指定された口座がユーザーのものではない場合のエラーです
+var ErrAccountNotFound = errors.New("指定された口座が見つかりません")
+
+// 口座を参照している保有資産のテーブル
+var holdingTables = []interface{}{
+    &model.UsStock{},
+    &model.Crypto{},
+    &model.JapanFund{},
+    &model.FixedIncomeAsset{},
+}
+
+// BrokerageAccountRepository インターフェースの定義
+type BrokerageAccountRepository interface {
+    FetchBrokerageAccountListById(ctx context.Context, userId uint) ([]model.BrokerageAccount, error)
+    CreateBrokerageAccount(ctx context.Context, dto CreateBrokerageAccountDto) (*model.BrokerageAccount, error)
+    UpdateBrokerageAccount(ctx context.Context, dto UpdateBrokerageAccountDto) (*model.BrokerageAccount, error)
+    DeleteBrokerageAccount(ctx context.Context, id uint, userId uint) error
+    FetchDefaultAccountId(ctx context.Context, userId uint) (uint, error)
+    CreateTotalAssetAccounts(ctx context.Context, totalAssetAccounts []model.TotalAssetAccount) error
+    FetchTotalAssetAccounts(ctx context.Context, userId uint, totalAssetIds []uint) ([]model.TotalAssetAccount, error)
+}
+
+// DefaultBrokerageAccountRepository 構造体の定義
+type DefaultBrokerageAccountRepository struct {
+    DB *gorm.DB
+}
+
+// NewBrokerageAccountRepository は DefaultBrokerageAccountRepository の新しいインスタンスを作成します
+func NewBrokerageAccountRepository(db *gorm.DB) BrokerageAccountRepository {
+    return &DefaultBrokerageAccountRepository{DB: db}
+}
+
+// ScopeAccount は保有資産の検索条件に口座を追加します
+func ScopeAccount(db *gorm.DB, accountId uint) *gorm.DB {
+    return db.Where("account_id = ?", accountId)
+}
+
+// ValidateAccount は指定された口座がユーザーのものか確認します
+func ValidateAccount(db *gorm.DB, accountId uint, userId uint) error {
+    var count int64
+    if err := db.Model(&model.BrokerageAccount{}).Where("id = ? AND user_id = ?", accountId, userId).Count(&count).Error; err != nil {
+        return err
+    }
+    if count == 0 {
+        return ErrAccountNotFound
+    }
+    return nil
+}
+
+// ResolveAccount は保有資産の登録先の口座を返します
+// 口座が指定されていない場合はユーザーの既定の口座(なければ作成する)、指定されている場合はユーザーの口座か確認した上でその口座を返す
+func ResolveAccount(db *gorm.DB, accountId *uint, userId uint) (uint, error) {
+    if accountId == nil {
+        return FindOrCreateDefaultAccount(db, userId)
+    }
+    if err := ValidateAccount(db, *accountId, userId); err != nil {
+        return 0, err
+    }
+    return *accountId, nil
+}
+
+// FindOrCreateDefaultAccount はユーザーの既定の口座のIDを返します(まだない場合は作成する)
+func FindOrCreateDefaultAccount(db *gorm.DB, userId uint) (uint, error) {
+    var account model.BrokerageAccount
+    err := db.Where("user_id = ? AND is_default = ?", userId, true).Order("id").Limit(1).Find(&account).Error
+    if err != nil {
+        return 0, err
+    }
+    if account.ID != 0 {
+        return account.ID, nil
+    }
+    account = model.BrokerageAccount{Name: model.DefaultBrokerageAccountName, Type: model.DefaultBrokerageAccountType, IsDefault: true, UserId: userId}
+    if err := db.Create(&account).Error; err != nil {
+        return 0, err
+    }
+    return account.ID, nil
+}
+
+// 指定したuserIdのユーザーの口座のリストを登録順に取得する
+func (r *DefaultBrokerageAccountRepository) FetchBrokerageAccountListById(ctx context.Context, userId uint) ([]model.BrokerageAccount, error) {
+    var accounts []model.BrokerageAccount
+    if err := r.DB.Where("user_id = ?", userId).Order("id").Find(&accounts).Error; err != nil {
+        return nil, err
+    }
+    return accounts, nil
+}
+
+// 口座を作成します
+func (r *DefaultBrokerageAccountRepository) CreateBrokerageAccount(ctx context.Context, dto CreateBrokerageAccountDto) (*model.BrokerageAccount, error) {
+    account := &model.BrokerageAccount{
+        Name:   dto.Name,
+        Type:   dto.Type,
+        UserId: dto.UserId,
+    }
+    if err := r.DB.Create(account).Error; err != nil {
+        return nil, err
+    }
+    return account, nil
+}
+
+// 口座の名称・種類を更新します
+func (r *DefaultBrokerageAccountRepository) UpdateBrokerageAccount(ctx context.Context, dto UpdateBrokerageAccountDto) (*model.BrokerageAccount, error) {
+    result := r.DB.Model(&model.BrokerageAccount{}).Where("id = ? AND user_id = ?", dto.ID, dto.UserId).Updates(map[string]interface{}{"name": dto.Name, "type": dto.Type})
+    if result.Error != nil {
+        return nil, result.Error
+    }
+    var account model.BrokerageAccount
+    if err := r.DB.Where("id = ? AND user_id = ?", dto.ID, dto.UserId).First(&account).Error; err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return nil, errors.New("指定された口座が見つかりません")
+        }
+        return nil, err
+    }
+    return &account, nil
+}
+
+// ユーザーの既定の口座のIDを取得します(まだない場合は作成する)
+func (r *DefaultBrokerageAccountRepository) FetchDefaultAccountId(ctx context.Context, userId uint) (uint, error) {
+    return FindOrCreateDefaultAccount(r.DB, userId)
+}
+
+// 口座を削除します(保有資産が登録されている口座は削除できない)
+// 論理削除済みの保有資産も復元できるよう、口座を参照している間は削除できない
+func (r *DefaultBrokerageAccountRepository) DeleteBrokerageAccount(ctx context.Context, id uint, userId uint) error {
+    return r.DB.Transaction(func(tx *gorm.DB) error {
+        for _, table := range holdingTables {
+            var count int64
+            if err := tx.Unscoped().Model(table).Where("account_id = ? AND user_id = ?", id, userId).Count(&count).Error; err != nil {
+                return err
+            }
+            if count > 0 {
+                return errors.New("保有資産が登録されている口座は削除できません")
+            }
+        }
+        result := tx.Where("id = ? AND user_id = ?", id, userId).Delete(&model.BrokerageAccount{})
+        if result.Error != nil {
+            return result.Error
+        }
+        if result.RowsAffected == 0 {
+            return errors.New("指定された口座が見つかりません")
+        }
+        return nil
+    })
+}
+
+// 資産スナップショットの口座別の内訳を登録します
+func (r *DefaultBrokerageAccountRepository) CreateTotalAssetAccounts(ctx context.Context, totalAssetAccounts []model.TotalAssetAccount) error {
+    if len(totalAssetAccounts) == 0 {
+        return nil
+    }
+    return r.DB.Create(&totalAssetAccounts).Error
+}
+
+// 指定した資産スナップショットの口座別の内訳を取得する
+func (r *DefaultBrokerageAccountRepository) FetchTotalAssetAccounts(ctx context.Context, userId uint, totalAssetIds []uint) ([]model.TotalAssetAccount, error) {
+    var totalAssetAccounts []model.TotalAssetAccount
+    if len(totalAssetIds) == 0 {
+        return totalAssetAccounts, nil
+    }
+    if err := r.DB.Where("user_id = ? AND total_asset_id IN ?", userId, totalAssetIds).Order("id").Find(&totalAssetAccounts).Error; err != nil {
+        return nil, err
+    }
+    return totalAssetAccounts, nil
+}
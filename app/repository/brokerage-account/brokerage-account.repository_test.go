@@ -0,0 +1,145 @@
+package brokerageaccount
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// テスト用のデータベース設定
+func setupTestDB() *gorm.DB {
+    db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+    if err != nil {
+        panic("failed to connect database")
+    }
+    // テスト用のテーブルを準備
+    db.AutoMigrate(&model.BrokerageAccount{}, &model.TotalAssetAccount{}, &model.UsStock{}, &model.Crypto{}, &model.JapanFund{}, &model.FixedIncomeAsset{})
+    return db
+}
+
+func TestCreateAndFetchBrokerageAccounts(t *testing.T) {
+    db := setupTestDB()
+    repo := NewBrokerageAccountRepository(db)
+
+    nisa, err := repo.CreateBrokerageAccount(context.Background(), CreateBrokerageAccountDto{Name: "SBI NISA", Type: "NISA_GROWTH", UserId: 1})
+    assert.NoError(t, err)
+    _, err = repo.CreateBrokerageAccount(context.Background(), CreateBrokerageAccountDto{Name: "SBI 特定", Type: "TAXABLE", UserId: 1})
+    assert.NoError(t, err)
+    _, err = repo.CreateBrokerageAccount(context.Background(), CreateBrokerageAccountDto{Name: "楽天 特定", Type: "TAXABLE", UserId: 2})
+    assert.NoError(t, err)
+
+    accounts, err := repo.FetchBrokerageAccountListById(context.Background(), 1)
+
+    assert.NoError(t, err)
+    assert.Len(t, accounts, 2)
+    assert.Equal(t, nisa.ID, accounts[0].ID)
+    assert.Equal(t, "SBI 特定", accounts[1].Name)
+}
+
+func TestUpdateBrokerageAccount(t *testing.T) {
+    db := setupTestDB()
+    repo := NewBrokerageAccountRepository(db)
+    account := model.BrokerageAccount{Name: "SBI NISA", Type: "NISA_GROWTH", UserId: 1}
+    db.Create(&account)
+
+    updated, err := repo.UpdateBrokerageAccount(context.Background(), UpdateBrokerageAccountDto{ID: account.ID, Name: "SBI つみたて", Type: "NISA_TSUMITATE", UserId: 1})
+    assert.NoError(t, err)
+    assert.Equal(t, "SBI つみたて", updated.Name)
+    assert.Equal(t, "NISA_TSUMITATE", updated.Type)
+
+    // 他のユーザーの口座は更新できない
+    _, err = repo.UpdateBrokerageAccount(context.Background(), UpdateBrokerageAccountDto{ID: account.ID, Name: "変更", Type: "TAXABLE", UserId: 2})
+    assert.EqualError(t, err, "指定された口座が見つかりません")
+}
+
+func TestDeleteBrokerageAccount(t *testing.T) {
+    db := setupTestDB()
+    repo := NewBrokerageAccountRepository(db)
+    account := model.BrokerageAccount{Name: "SBI NISA", Type: "NISA_GROWTH", UserId: 1}
+    db.Create(&account)
+    usStock := model.UsStock{Code: "AAPL", Quantity: 10, Sector: "Technology", AccountId: account.ID, UserId: 1}
+    db.Create(&usStock)
+
+    // 保有資産が登録されている場合は削除できない
+    err := repo.DeleteBrokerageAccount(context.Background(), account.ID, 1)
+    assert.EqualError(t, err, "保有資産が登録されている口座は削除できません")
+
+    // 論理削除済みの保有資産は復元できるため、口座を参照している間は削除できない
+    db.Delete(&usStock)
+    err = repo.DeleteBrokerageAccount(context.Background(), account.ID, 1)
+    assert.EqualError(t, err, "保有資産が登録されている口座は削除できません")
+
+    db.Unscoped().Delete(&usStock)
+    err = repo.DeleteBrokerageAccount(context.Background(), account.ID, 2)
+    assert.EqualError(t, err, "指定された口座が見つかりません")
+    err = repo.DeleteBrokerageAccount(context.Background(), account.ID, 1)
+    assert.NoError(t, err)
+
+    var count int64
+    db.Model(&model.BrokerageAccount{}).Count(&count)
+    assert.Equal(t, int64(0), count)
+}
+
+func TestValidateAccount(t *testing.T) {
+    db := setupTestDB()
+    account := model.BrokerageAccount{Name: "SBI NISA", Type: "NISA_GROWTH", UserId: 1}
+    db.Create(&account)
+
+    assert.NoError(t, ValidateAccount(db, account.ID, 1))
+    assert.EqualError(t, ValidateAccount(db, account.ID, 2), "指定された口座が見つかりません")
+}
+
+func TestResolveAccount(t *testing.T) {
+    db := setupTestDB()
+    account := model.BrokerageAccount{Name: "SBI NISA", Type: "NISA_GROWTH", UserId: 1}
+    db.Create(&account)
+
+    accountId, err := ResolveAccount(db, &account.ID, 1)
+    assert.NoError(t, err)
+    assert.Equal(t, account.ID, accountId)
+    _, err = ResolveAccount(db, &account.ID, 2)
+    assert.ErrorIs(t, err, ErrAccountNotFound)
+
+    // 口座の指定がない場合は既定の口座を作成し、以降は同じ口座を返す
+    defaultAccountId, err := ResolveAccount(db, nil, 1)
+    assert.NoError(t, err)
+    assert.NotEqual(t, account.ID, defaultAccountId)
+    accountId, err = ResolveAccount(db, nil, 1)
+    assert.NoError(t, err)
+    assert.Equal(t, defaultAccountId, accountId)
+
+    var defaultAccount model.BrokerageAccount
+    db.First(&defaultAccount, defaultAccountId)
+    assert.True(t, defaultAccount.IsDefault)
+    assert.Equal(t, model.DefaultBrokerageAccountName, defaultAccount.Name)
+    assert.Equal(t, model.DefaultBrokerageAccountType, defaultAccount.Type)
+}
+
+func TestTotalAssetAccounts(t *testing.T) {
+    db := setupTestDB()
+    repo := NewBrokerageAccountRepository(db)
+    accountId := uint(3)
+
+    err := repo.CreateTotalAssetAccounts(context.Background(), []model.TotalAssetAccount{
+        {TotalAssetId: 1, AccountId: &accountId, Stock: 100000, UserId: 1},
+        {TotalAssetId: 1, Stock: 50000, Fund: 20000, UserId: 1},
+        {TotalAssetId: 2, AccountId: &accountId, Stock: 110000, UserId: 1},
+        {TotalAssetId: 1, Stock: 10000, UserId: 2},
+    })
+    assert.NoError(t, err)
+
+    totalAssetAccounts, err := repo.FetchTotalAssetAccounts(context.Background(), 1, []uint{1})
+    assert.NoError(t, err)
+    assert.Len(t, totalAssetAccounts, 2)
+    assert.Equal(t, accountId, *totalAssetAccounts[0].AccountId)
+    assert.Nil(t, totalAssetAccounts[1].AccountId)
+    assert.Equal(t, 20000.0, totalAssetAccounts[1].Fund)
+
+    totalAssetAccounts, err = repo.FetchTotalAssetAccounts(context.Background(), 1, []uint{})
+    assert.NoError(t, err)
+    assert.Empty(t, totalAssetAccounts)
+}
@@ -0,0 +1,58 @@
+package brokerageaccount
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBrokerageAccountRepository は BrokerageAccountRepository のモックです。
+type MockBrokerageAccountRepository struct {
+	mock.Mock
+}
+
+func NewMockBrokerageAccountRepository() *MockBrokerageAccountRepository {
+	return &MockBrokerageAccountRepository{}
+}
+
+func (m *MockBrokerageAccountRepository) FetchBrokerageAccountListById(ctx context.Context, userId uint) ([]model.BrokerageAccount, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]model.BrokerageAccount), args.Error(1)
+}
+
+func (m *MockBrokerageAccountRepository) CreateBrokerageAccount(ctx context.Context, dto CreateBrokerageAccountDto) (*model.BrokerageAccount, error) {
+	args := m.Called(ctx, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.BrokerageAccount), args.Error(1)
+}
+
+func (m *MockBrokerageAccountRepository) UpdateBrokerageAccount(ctx context.Context, dto UpdateBrokerageAccountDto) (*model.BrokerageAccount, error) {
+	args := m.Called(ctx, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.BrokerageAccount), args.Error(1)
+}
+
+func (m *MockBrokerageAccountRepository) DeleteBrokerageAccount(ctx context.Context, id uint, userId uint) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockBrokerageAccountRepository) FetchDefaultAccountId(ctx context.Context, userId uint) (uint, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockBrokerageAccountRepository) CreateTotalAssetAccounts(ctx context.Context, totalAssetAccounts []model.TotalAssetAccount) error {
+	args := m.Called(ctx, totalAssetAccounts)
+	return args.Error(0)
+}
+
+func (m *MockBrokerageAccountRepository) FetchTotalAssetAccounts(ctx context.Context, userId uint, totalAssetIds []uint) ([]model.TotalAssetAccount, error) {
+	args := m.Called(ctx, userId, totalAssetIds)
+	return args.Get(0).([]model.TotalAssetAccount), args.Error(1)
+}
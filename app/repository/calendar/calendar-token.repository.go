@@ -0,0 +1,48 @@
+package calendar
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+
+	"gorm.io/gorm"
+)
+
+// CalendarTokenRepository インターフェースの定義
+type CalendarTokenRepository interface {
+    FindCalendarTokenByToken(ctx context.Context, token string) (*model.CalendarToken, error)
+    UpsertCalendarToken(ctx context.Context, userId uint, token string) (*model.CalendarToken, error)
+}
+
+// DefaultCalendarTokenRepository 構造体の定義
+type DefaultCalendarTokenRepository struct {
+    DB *gorm.DB
+}
+
+// NewCalendarTokenRepository は DefaultCalendarTokenRepository の新しいインスタンスを作成します
+func NewCalendarTokenRepository(db *gorm.DB) CalendarTokenRepository {
+    return &DefaultCalendarTokenRepository{DB: db}
+}
+
+// FindCalendarTokenByToken はトークンに紐づくカレンダートークン情報を取得します
+func (r *DefaultCalendarTokenRepository) FindCalendarTokenByToken(ctx context.Context, token string) (*model.CalendarToken, error) {
+    var calendarToken model.CalendarToken
+    // 退会の猶予期間中のユーザー(論理削除済み)のトークンは無効とする
+    if err := r.DB.Where("token = ? AND user_id IN (?)", token, r.DB.Model(&model.User{}).Select("id")).First(&calendarToken).Error; err != nil {
+        return nil, err
+    }
+    return &calendarToken, nil
+}
+
+// UpsertCalendarToken はユーザーのトークンを登録します。既に登録されている場合は新しいトークンで置き換えます
+func (r *DefaultCalendarTokenRepository) UpsertCalendarToken(ctx context.Context, userId uint, token string) (*model.CalendarToken, error) {
+    var calendarToken model.CalendarToken
+    if err := r.DB.Where("user_id = ?", userId).First(&calendarToken).Error; err != nil && err != gorm.ErrRecordNotFound {
+        return nil, err
+    }
+    calendarToken.UserId = userId
+    calendarToken.Token = token
+    if err := r.DB.Save(&calendarToken).Error; err != nil {
+        return nil, err
+    }
+    return &calendarToken, nil
+}
@@ -0,0 +1,71 @@
+package calendar
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// テスト用のデータベース設定
+func setupTestDB() *gorm.DB {
+    db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+    if err != nil {
+        panic("failed to connect database")
+    }
+    // テスト用のテーブルを準備
+    db.AutoMigrate(&model.CalendarToken{}, &model.User{})
+    return db
+}
+
+func TestFindCalendarTokenByToken(t *testing.T) {
+    db := setupTestDB()
+    repo := NewCalendarTokenRepository(db)
+
+    // テスト用データを作成
+    db.Create(&model.User{Model: gorm.Model{ID: 1}, Email: "calendar-1@example.com", Password: "hashed"})
+    db.Create(&model.CalendarToken{UserId: 1, Token: "token-1"})
+    deletedUser := model.User{Model: gorm.Model{ID: 2}, Email: "calendar-2@example.com", Password: "hashed"}
+    db.Create(&deletedUser)
+    db.Delete(&deletedUser)
+    db.Create(&model.CalendarToken{UserId: 2, Token: "token-2"})
+
+    calendarToken, err := repo.FindCalendarTokenByToken(context.Background(), "token-1")
+    assert.NoError(t, err)
+    assert.Equal(t, uint(1), calendarToken.UserId)
+
+    // 退会の猶予期間中のユーザーのトークンはエラー
+    _, err = repo.FindCalendarTokenByToken(context.Background(), "token-2")
+    assert.Equal(t, gorm.ErrRecordNotFound, err)
+
+    // 登録されていないトークンの場合はエラー
+    _, err = repo.FindCalendarTokenByToken(context.Background(), "unknown")
+    assert.Equal(t, gorm.ErrRecordNotFound, err)
+}
+
+// 再発行した場合は古いトークンが使えなくなる
+func TestUpsertCalendarToken(t *testing.T) {
+    db := setupTestDB()
+    repo := NewCalendarTokenRepository(db)
+    db.Create(&model.User{Model: gorm.Model{ID: 1}, Email: "calendar-1@example.com", Password: "hashed"})
+
+    created, err := repo.UpsertCalendarToken(context.Background(), 1, "token-1")
+    assert.NoError(t, err)
+    assert.Equal(t, "token-1", created.Token)
+
+    updated, err := repo.UpsertCalendarToken(context.Background(), 1, "token-2")
+    assert.NoError(t, err)
+    assert.Equal(t, created.ID, updated.ID)
+
+    var count int64
+    db.Model(&model.CalendarToken{}).Count(&count)
+    assert.Equal(t, int64(1), count)
+    _, err = repo.FindCalendarTokenByToken(context.Background(), "token-1")
+    assert.Equal(t, gorm.ErrRecordNotFound, err)
+    found, err := repo.FindCalendarTokenByToken(context.Background(), "token-2")
+    assert.NoError(t, err)
+    assert.Equal(t, uint(1), found.UserId)
+}
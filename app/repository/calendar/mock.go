@@ -0,0 +1,28 @@
+package calendar
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCalendarTokenRepository は CalendarTokenRepository のモックです。
+type MockCalendarTokenRepository struct {
+	mock.Mock
+}
+
+// NewMockCalendarTokenRepository は新しい MockCalendarTokenRepository を作成し、初期設定を行います。
+func NewMockCalendarTokenRepository() *MockCalendarTokenRepository {
+	return &MockCalendarTokenRepository{}
+}
+
+func (m *MockCalendarTokenRepository) FindCalendarTokenByToken(ctx context.Context, token string) (*model.CalendarToken, error) {
+	args := m.Called(ctx, token)
+	return args.Get(0).(*model.CalendarToken), args.Error(1)
+}
+
+func (m *MockCalendarTokenRepository) UpsertCalendarToken(ctx context.Context, userId uint, token string) (*model.CalendarToken, error) {
+	args := m.Called(ctx, userId, token)
+	return args.Get(0).(*model.CalendarToken), args.Error(1)
+}
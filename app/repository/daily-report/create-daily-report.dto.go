@@ -0,0 +1,7 @@
+package dailyreport
+
+type CreateDailyReportDto struct {
+    UserId  uint   `json:"userId"`
+    Date    string `json:"date"`
+    Content string `json:"content"`
+}
@@ -0,0 +1,59 @@
+package dailyreport
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/database/model"
+
+	"gorm.io/gorm"
+)
+
+// DailyReportRepository インターフェースの定義
+type DailyReportRepository interface {
+    FindDailyReport(ctx context.Context, userId uint, date string) (*model.DailyReport, error)
+    UpsertDailyReport(ctx context.Context, dto CreateDailyReportDto) (*model.DailyReport, error)
+}
+
+// DefaultDailyReportRepository 構造体の定義
+type DefaultDailyReportRepository struct {
+    DB *gorm.DB
+}
+
+// NewDailyReportRepository は DefaultDailyReportRepository の新しいインスタンスを作成します
+func NewDailyReportRepository(db *gorm.DB) DailyReportRepository {
+    return &DefaultDailyReportRepository{DB: db}
+}
+
+// 指定したuserIdのユーザーの指定日のサマリーを取得する(存在しない場合はnil)
+func (r *DefaultDailyReportRepository) FindDailyReport(ctx context.Context, userId uint, date string) (*model.DailyReport, error) {
+    var dailyReport model.DailyReport
+    err := r.DB.Where("user_id = ? AND date = ?", userId, date).First(&dailyReport).Error
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, err
+    }
+    return &dailyReport, nil
+}
+
+// サマリーを保存します(同じ日のサマリーが存在する場合は内容を置き換える)
+func (r *DefaultDailyReportRepository) UpsertDailyReport(ctx context.Context, dto CreateDailyReportDto) (*model.DailyReport, error) {
+    var dailyReport model.DailyReport
+    err := r.DB.Transaction(func(tx *gorm.DB) error {
+        err := tx.Where("user_id = ? AND date = ?", dto.UserId, dto.Date).First(&dailyReport).Error
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            dailyReport = model.DailyReport{UserId: dto.UserId, Date: dto.Date, Content: dto.Content}
+            return tx.Create(&dailyReport).Error
+        }
+        if err != nil {
+            return err
+        }
+        dailyReport.Content = dto.Content
+        return tx.Model(&dailyReport).Update("content", dto.Content).Error
+    })
+    if err != nil {
+        return nil, err
+    }
+    return &dailyReport, nil
+}
@@ -0,0 +1,58 @@
+package dailyreport
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// テスト用のデータベース設定
+func setupTestDB() *gorm.DB {
+    db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+    if err != nil {
+        panic("failed to connect database")
+    }
+    // テスト用のテーブルを準備
+    db.AutoMigrate(&model.DailyReport{})
+    return db
+}
+
+func TestFindDailyReport(t *testing.T) {
+    db := setupTestDB()
+    repo := NewDailyReportRepository(db)
+
+    db.Create(&model.DailyReport{UserId: 1, Date: "2024-01-10", Content: `{"date":"2024-01-10"}`})
+    db.Create(&model.DailyReport{UserId: 2, Date: "2024-01-11", Content: `{"date":"2024-01-11"}`})
+
+    dailyReport, err := repo.FindDailyReport(context.Background(), 1, "2024-01-10")
+    assert.NoError(t, err)
+    assert.Equal(t, `{"date":"2024-01-10"}`, dailyReport.Content)
+
+    // 他のユーザーのサマリーは取得できない
+    dailyReport, err = repo.FindDailyReport(context.Background(), 1, "2024-01-11")
+    assert.NoError(t, err)
+    assert.Nil(t, dailyReport)
+}
+
+// 同じ日のサマリーは上書きされる
+func TestUpsertDailyReport(t *testing.T) {
+    db := setupTestDB()
+    repo := NewDailyReportRepository(db)
+
+    created, err := repo.UpsertDailyReport(context.Background(), CreateDailyReportDto{UserId: 1, Date: "2024-01-10", Content: "first"})
+    assert.NoError(t, err)
+    assert.Equal(t, "first", created.Content)
+
+    updated, err := repo.UpsertDailyReport(context.Background(), CreateDailyReportDto{UserId: 1, Date: "2024-01-10", Content: "second"})
+    assert.NoError(t, err)
+    assert.Equal(t, created.ID, updated.ID)
+    assert.Equal(t, "second", updated.Content)
+
+    var count int64
+    db.Model(&model.DailyReport{}).Where("user_id = ?", 1).Count(&count)
+    assert.Equal(t, int64(1), count)
+}
@@ -0,0 +1,34 @@
+package dailyreport
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDailyReportRepository は DailyReportRepository のモックです。
+type MockDailyReportRepository struct {
+	mock.Mock
+}
+
+// NewMockDailyReportRepository は新しい MockDailyReportRepository を作成し、初期設定を行います。
+func NewMockDailyReportRepository() *MockDailyReportRepository {
+	return &MockDailyReportRepository{}
+}
+
+func (m *MockDailyReportRepository) FindDailyReport(ctx context.Context, userId uint, date string) (*model.DailyReport, error) {
+	args := m.Called(ctx, userId, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DailyReport), args.Error(1)
+}
+
+func (m *MockDailyReportRepository) UpsertDailyReport(ctx context.Context, dto CreateDailyReportDto) (*model.DailyReport, error) {
+	args := m.Called(ctx, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DailyReport), args.Error(1)
+}
@@ -0,0 +1,148 @@
+package deletedholding
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/database/model"
+	brokerageaccount "my-us-stock-backend/app/repository/brokerage-account"
+	"my-us-stock-backend/app/repository/nisa"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DeletedHoldingRepository インターフェースの定義
+type DeletedHoldingRepository interface {
+    FetchDeletedHoldings(ctx context.Context, userId uint) (*DeletedHoldingsDto, error)
+    RestoreUsStock(ctx context.Context, id uint, userId uint) error
+    RestoreCrypto(ctx context.Context, id uint, userId uint) error
+    RestoreJapanFund(ctx context.Context, id uint, userId uint) error
+    RestoreFixedIncomeAsset(ctx context.Context, id uint, userId uint) error
+    PurgeDeletedHoldings(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
+
+// DefaultDeletedHoldingRepository 構造体の定義
+type DefaultDeletedHoldingRepository struct {
+    DB *gorm.DB
+}
+
+// NewDeletedHoldingRepository は DefaultDeletedHoldingRepository の新しいインスタンスを作成します
+func NewDeletedHoldingRepository(db *gorm.DB) DeletedHoldingRepository {
+    return &DefaultDeletedHoldingRepository{DB: db}
+}
+
+// 論理削除済みのデータのみを対象にする
+func deletedQuery(db *gorm.DB) *gorm.DB {
+    return db.Unscoped().Where("deleted_at IS NOT NULL")
+}
+
+// FetchDeletedHoldings はユーザーの論理削除済みの保有資産を取得します(削除日時の新しい順)
+func (r *DefaultDeletedHoldingRepository) FetchDeletedHoldings(ctx context.Context, userId uint) (*DeletedHoldingsDto, error) {
+    dto := &DeletedHoldingsDto{}
+    if err := deletedQuery(r.DB).Where("user_id = ?", userId).Order("deleted_at desc").Find(&dto.UsStocks).Error; err != nil {
+        return nil, err
+    }
+    if err := deletedQuery(r.DB).Where("user_id = ?", userId).Order("deleted_at desc").Find(&dto.Cryptos).Error; err != nil {
+        return nil, err
+    }
+    if err := deletedQuery(r.DB).Where("user_id = ?", userId).Order("deleted_at desc").Find(&dto.JapanFunds).Error; err != nil {
+        return nil, err
+    }
+    if err := deletedQuery(r.DB).Where("user_id = ?", userId).Order("deleted_at desc").Find(&dto.FixedIncomeAssets).Error; err != nil {
+        return nil, err
+    }
+    return dto, nil
+}
+
+// RestoreUsStock は論理削除済みの米国株式を復元します
+func (r *DefaultDeletedHoldingRepository) RestoreUsStock(ctx context.Context, id uint, userId uint) error {
+    var usStock model.UsStock
+    return r.restore(&usStock, &usStock.AccountId, id, userId, func(tx *gorm.DB) *gorm.DB {
+        return brokerageaccount.ScopeAccount(tx, usStock.AccountId).Where("code = ?", usStock.Code)
+    }, func() float64 {
+        return usStock.GetPrice * usStock.Quantity * usStock.UsdJpy
+    })
+}
+
+// RestoreCrypto は論理削除済みの暗号通貨を復元します
+func (r *DefaultDeletedHoldingRepository) RestoreCrypto(ctx context.Context, id uint, userId uint) error {
+    var crypto model.Crypto
+    return r.restore(&crypto, &crypto.AccountId, id, userId, func(tx *gorm.DB) *gorm.DB {
+        return brokerageaccount.ScopeAccount(tx, crypto.AccountId).Where("code = ?", crypto.Code)
+    }, nil)
+}
+
+// RestoreJapanFund は論理削除済みの投資信託を復元します
+func (r *DefaultDeletedHoldingRepository) RestoreJapanFund(ctx context.Context, id uint, userId uint) error {
+    var japanFund model.JapanFund
+    return r.restore(&japanFund, &japanFund.AccountId, id, userId, func(tx *gorm.DB) *gorm.DB {
+        return brokerageaccount.ScopeAccount(tx, japanFund.AccountId).Where("code = ? AND name = ?", japanFund.Code, japanFund.Name)
+    }, func() float64 {
+        return japanFund.GetPriceTotal
+    })
+}
+
+// RestoreFixedIncomeAsset は論理削除済みの固定利回り資産を復元します
+func (r *DefaultDeletedHoldingRepository) RestoreFixedIncomeAsset(ctx context.Context, id uint, userId uint) error {
+    var fixedIncomeAsset model.FixedIncomeAsset
+    return r.restore(&fixedIncomeAsset, &fixedIncomeAsset.AccountId, id, userId, func(tx *gorm.DB) *gorm.DB {
+        return brokerageaccount.ScopeAccount(tx, fixedIncomeAsset.AccountId).Where("code = ?", fixedIncomeAsset.Code)
+    }, nil)
+}
+
+// 論理削除済みのデータを復元する
+// 口座がユーザーのものでなくなっている場合は既定の口座に復元する
+// 登録時と同じく、同じ口座に同じ銘柄が既に登録されている場合は重複するため復元しない
+// NISA口座に戻す場合は取得価額(nisaCost、NISAの対象外の資産はnil)が生涯投資枠を超えないか確認する
+func (r *DefaultDeletedHoldingRepository) restore(holding interface{}, accountId *uint, id uint, userId uint, sameHolding func(tx *gorm.DB) *gorm.DB, nisaCost func() float64) error {
+    return r.DB.Transaction(func(tx *gorm.DB) error {
+        if err := deletedQuery(tx).Where("id = ? AND user_id = ?", id, userId).First(holding).Error; err != nil {
+            if errors.Is(err, gorm.ErrRecordNotFound) {
+                return errors.New("削除済みの保有資産が見つかりません")
+            }
+            return err
+        }
+        if err := brokerageaccount.ValidateAccount(tx, *accountId, userId); err != nil {
+            if !errors.Is(err, brokerageaccount.ErrAccountNotFound) {
+                return err
+            }
+            defaultAccountId, err := brokerageaccount.FindOrCreateDefaultAccount(tx, userId)
+            if err != nil {
+                return err
+            }
+            *accountId = defaultAccountId
+        }
+        var count int64
+        if err := sameHolding(tx.Model(holding)).Where("user_id = ?", userId).Count(&count).Error; err != nil {
+            return err
+        }
+        if count > 0 {
+            return errors.New("同じ銘柄が既に登録されているため復元できません")
+        }
+        if nisaCost != nil {
+            if err := nisa.CheckLifetimeCapacity(tx, *accountId, userId, nisaCost()); err != nil {
+                return err
+            }
+        }
+        return tx.Unscoped().Model(holding).Where("id = ?", id).Updates(map[string]interface{}{"deleted_at": nil, "account_id": *accountId}).Error
+    })
+}
+
+// PurgeDeletedHoldings は指定日時より前に論理削除された保有資産を物理削除し、削除した件数を返します
+func (r *DefaultDeletedHoldingRepository) PurgeDeletedHoldings(ctx context.Context, deletedBefore time.Time) (int64, error) {
+    var purged int64
+    err := r.DB.Transaction(func(tx *gorm.DB) error {
+        for _, table := range []interface{}{&model.UsStock{}, &model.Crypto{}, &model.JapanFund{}, &model.FixedIncomeAsset{}} {
+            result := tx.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).Delete(table)
+            if result.Error != nil {
+                return result.Error
+            }
+            purged += result.RowsAffected
+        }
+        return nil
+    })
+    if err != nil {
+        return 0, err
+    }
+    return purged, nil
+}
@@ -0,0 +1,152 @@
+package deletedholding
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// テスト用のデータベース設定
+func setupTestDB() *gorm.DB {
+    db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+    if err != nil {
+        panic("failed to connect database")
+    }
+    // テスト用のテーブルを準備
+    db.AutoMigrate(&model.UsStock{}, &model.Crypto{}, &model.JapanFund{}, &model.FixedIncomeAsset{}, &model.BrokerageAccount{}, &model.NisaPurchase{})
+    return db
+}
+
+// 指定日時に論理削除したデータを作成する
+func createDeleted(db *gorm.DB, holding interface{}, deletedAt time.Time) {
+    db.Create(holding)
+    db.Model(holding).Update("deleted_at", deletedAt)
+}
+
+func TestFetchDeletedHoldings(t *testing.T) {
+    db := setupTestDB()
+    repo := NewDeletedHoldingRepository(db)
+
+    now := time.Now()
+    db.Create(&model.UsStock{Code: "KO", Quantity: 10, Sector: "Consumer Defensive", UserId: 1})
+    createDeleted(db, &model.UsStock{Code: "AAPL", Quantity: 10, Sector: "Technology", UserId: 1}, now.Add(-2*time.Hour))
+    createDeleted(db, &model.UsStock{Code: "MSFT", Quantity: 5, Sector: "Technology", UserId: 1}, now.Add(-time.Hour))
+    createDeleted(db, &model.UsStock{Code: "PG", Quantity: 5, Sector: "Consumer Defensive", UserId: 2}, now)
+    createDeleted(db, &model.JapanFund{Code: "0331418A", Name: "eMAXIS Slim 米国株式(S&P500)", UserId: 1}, now)
+
+    dto, err := repo.FetchDeletedHoldings(context.Background(), 1)
+
+    assert.NoError(t, err)
+    assert.Len(t, dto.UsStocks, 2)
+    assert.Equal(t, "MSFT", dto.UsStocks[0].Code)
+    assert.True(t, dto.UsStocks[0].DeletedAt.Valid)
+    assert.Len(t, dto.JapanFunds, 1)
+    assert.Empty(t, dto.Cryptos)
+}
+
+func TestRestoreUsStock(t *testing.T) {
+    db := setupTestDB()
+    repo := NewDeletedHoldingRepository(db)
+
+    deleted := model.UsStock{Code: "AAPL", Quantity: 10, Sector: "Technology", UserId: 1}
+    createDeleted(db, &deleted, time.Now())
+
+    // 他のユーザーのデータは復元できない
+    err := repo.RestoreUsStock(context.Background(), deleted.ID, 2)
+    assert.EqualError(t, err, "削除済みの保有資産が見つかりません")
+
+    err = repo.RestoreUsStock(context.Background(), deleted.ID, 1)
+    assert.NoError(t, err)
+    var restored model.UsStock
+    assert.NoError(t, db.First(&restored, deleted.ID).Error)
+    assert.Equal(t, "AAPL", restored.Code)
+
+    // 削除されていないデータは対象外
+    err = repo.RestoreUsStock(context.Background(), deleted.ID, 1)
+    assert.EqualError(t, err, "削除済みの保有資産が見つかりません")
+}
+
+// 同じ銘柄が登録済みの場合は復元しない
+func TestRestoreDuplicated(t *testing.T) {
+    db := setupTestDB()
+    repo := NewDeletedHoldingRepository(db)
+
+    account := model.BrokerageAccount{Name: "SBI 特定", Type: "TAXABLE", UserId: 1}
+    db.Create(&account)
+    db.Create(&model.Crypto{Code: "btc", Quantity: 0.1, AccountId: account.ID, UserId: 1})
+    deletedCrypto := model.Crypto{Code: "btc", Quantity: 0.5, AccountId: account.ID, UserId: 1}
+    createDeleted(db, &deletedCrypto, time.Now())
+    db.Create(&model.JapanFund{Code: "0331418A", Name: "eMAXIS Slim 米国株式(S&P500)", AccountId: account.ID, UserId: 1})
+    deletedFund := model.JapanFund{Code: "0331418A", Name: "別の名称", AccountId: account.ID, UserId: 1}
+    createDeleted(db, &deletedFund, time.Now())
+
+    err := repo.RestoreCrypto(context.Background(), deletedCrypto.ID, 1)
+    assert.EqualError(t, err, "同じ銘柄が既に登録されているため復元できません")
+
+    // 投資信託は銘柄名も一致する場合のみ重複とみなす
+    err = repo.RestoreJapanFund(context.Background(), deletedFund.ID, 1)
+    assert.NoError(t, err)
+}
+
+// 口座がユーザーのものでない場合は既定の口座に復元する
+func TestRestoreIntoDefaultAccount(t *testing.T) {
+    db := setupTestDB()
+    repo := NewDeletedHoldingRepository(db)
+
+    otherAccount := model.BrokerageAccount{Name: "他のユーザーの口座", Type: "TAXABLE", UserId: 2}
+    db.Create(&otherAccount)
+    deleted := model.FixedIncomeAsset{Code: "社債A", GetPriceTotal: 100000, AccountId: otherAccount.ID, UserId: 1}
+    createDeleted(db, &deleted, time.Now())
+
+    err := repo.RestoreFixedIncomeAsset(context.Background(), deleted.ID, 1)
+    assert.NoError(t, err)
+
+    var defaultAccount model.BrokerageAccount
+    assert.NoError(t, db.Where("user_id = ? AND is_default = ?", 1, true).First(&defaultAccount).Error)
+    var restored model.FixedIncomeAsset
+    assert.NoError(t, db.First(&restored, deleted.ID).Error)
+    assert.Equal(t, defaultAccount.ID, restored.AccountId)
+}
+
+// NISA口座に戻すと生涯投資枠を超える場合は復元しない
+func TestRestoreExceedingNisaAllowance(t *testing.T) {
+    db := setupTestDB()
+    repo := NewDeletedHoldingRepository(db)
+
+    nisa := model.BrokerageAccount{Name: "SBI NISA", Type: "NISA_GROWTH", UserId: 4}
+    db.Create(&nisa)
+    db.Create(&model.JapanFund{Code: "0331418A", Name: "eMAXIS Slim 全世界株式(オール・カントリー)", GetPrice: 15000, GetPriceTotal: 11500000, AccountId: nisa.ID, UserId: 4})
+    deleted := model.JapanFund{Code: "03311187", Name: "eMAXIS Slim 米国株式(S&P500)", GetPrice: 20000, GetPriceTotal: 1000000, AccountId: nisa.ID, UserId: 4}
+    createDeleted(db, &deleted, time.Now())
+
+    err := repo.RestoreJapanFund(context.Background(), deleted.ID, 4)
+    assert.EqualError(t, err, "成長投資枠の生涯投資枠(1,200万円)を超えるため登録できません")
+
+    var count int64
+    db.Model(&model.JapanFund{}).Where("user_id = ?", 4).Count(&count)
+    assert.Equal(t, int64(1), count)
+}
+
+func TestPurgeDeletedHoldings(t *testing.T) {
+    db := setupTestDB()
+    repo := NewDeletedHoldingRepository(db)
+
+    now := time.Now()
+    db.Create(&model.UsStock{Code: "KO", Quantity: 10, Sector: "Consumer Defensive", UserId: 1})
+    createDeleted(db, &model.UsStock{Code: "AAPL", Quantity: 10, Sector: "Technology", UserId: 1}, now.AddDate(0, 0, -31))
+    createDeleted(db, &model.UsStock{Code: "MSFT", Quantity: 5, Sector: "Technology", UserId: 1}, now.AddDate(0, 0, -29))
+    createDeleted(db, &model.FixedIncomeAsset{Code: "社債A", UserId: 1}, now.AddDate(0, 0, -40))
+
+    purged, err := repo.PurgeDeletedHoldings(context.Background(), now.AddDate(0, 0, -30))
+
+    assert.NoError(t, err)
+    assert.Equal(t, int64(2), purged)
+    var codes []string
+    db.Unscoped().Model(&model.UsStock{}).Order("code").Pluck("code", &codes)
+    assert.Equal(t, []string{"KO", "MSFT"}, codes)
+}
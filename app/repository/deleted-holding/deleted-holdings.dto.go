@@ -0,0 +1,11 @@
+package deletedholding
+
+import "my-us-stock-backend/app/database/model"
+
+// DeletedHoldingsDto は論理削除済み(ゴミ箱)の保有資産です
+type DeletedHoldingsDto struct {
+    UsStocks          []model.UsStock
+    Cryptos           []model.Crypto
+    JapanFunds        []model.JapanFund
+    FixedIncomeAssets []model.FixedIncomeAsset
+}
@@ -0,0 +1,51 @@
+package deletedholding
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDeletedHoldingRepository は DeletedHoldingRepository のモックです。
+type MockDeletedHoldingRepository struct {
+	mock.Mock
+}
+
+// NewMockDeletedHoldingRepository は新しい MockDeletedHoldingRepository を作成し、初期設定を行います。
+func NewMockDeletedHoldingRepository() *MockDeletedHoldingRepository {
+	return &MockDeletedHoldingRepository{}
+}
+
+func (m *MockDeletedHoldingRepository) FetchDeletedHoldings(ctx context.Context, userId uint) (*DeletedHoldingsDto, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*DeletedHoldingsDto), args.Error(1)
+}
+
+func (m *MockDeletedHoldingRepository) RestoreUsStock(ctx context.Context, id uint, userId uint) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockDeletedHoldingRepository) RestoreCrypto(ctx context.Context, id uint, userId uint) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockDeletedHoldingRepository) RestoreJapanFund(ctx context.Context, id uint, userId uint) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockDeletedHoldingRepository) RestoreFixedIncomeAsset(ctx context.Context, id uint, userId uint) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockDeletedHoldingRepository) PurgeDeletedHoldings(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	args := m.Called(ctx, deletedBefore)
+	return args.Get(0).(int64), args.Error(1)
+}
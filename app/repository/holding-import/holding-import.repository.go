@@ -0,0 +1,128 @@
+package holdingimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"my-us-stock-backend/app/database/model"
+	brokerageaccount "my-us-stock-backend/app/repository/brokerage-account"
+	"my-us-stock-backend/app/repository/nisa"
+
+	"gorm.io/gorm"
+)
+
+// HoldingImportRepository インターフェースの定義
+type HoldingImportRepository interface {
+    ImportHoldings(ctx context.Context, dto ImportHoldingsDto) error
+}
+
+// DefaultHoldingImportRepository 構造体の定義
+type DefaultHoldingImportRepository struct {
+    DB *gorm.DB
+}
+
+// NewHoldingImportRepository は DefaultHoldingImportRepository の新しいインスタンスを作成します
+func NewHoldingImportRepository(db *gorm.DB) HoldingImportRepository {
+    return &DefaultHoldingImportRepository{DB: db}
+}
+
+// 保有資産の作成・更新を1つのトランザクションで行う(1件でも失敗した場合は全て取り消す)
+func (r *DefaultHoldingImportRepository) ImportHoldings(ctx context.Context, dto ImportHoldingsDto) error {
+    return r.DB.Transaction(func(tx *gorm.DB) error {
+        accountId, err := brokerageaccount.ResolveAccount(tx, dto.AccountId, dto.UserId)
+        if err != nil {
+            return err
+        }
+        for _, usStock := range dto.CreateUsStocks {
+            usStock.UserId = dto.UserId
+            usStock.AccountId = accountId
+            // NISA口座の場合は書き込み前に投資枠と照合する
+            if err := nisa.ReserveUsStockPurchase(tx, usStock, usStock.GetPrice*usStock.Quantity*usStock.UsdJpy); err != nil {
+                return err
+            }
+            if err := tx.Create(&usStock).Error; err != nil {
+                return err
+            }
+        }
+        for _, usStock := range dto.UpdateUsStocks {
+            var current model.UsStock
+            if err := fetchOwned(tx, &current, usStock.ID, dto.UserId); err != nil {
+                return err
+            }
+            updated := current
+            updated.Quantity = usStock.Quantity
+            updated.GetPrice = usStock.GetPrice
+            increase := updated.GetPrice*updated.Quantity*updated.UsdJpy - current.GetPrice*current.Quantity*current.UsdJpy
+            if err := nisa.ReserveUsStockPurchase(tx, updated, increase); err != nil {
+                return err
+            }
+            values := map[string]interface{}{"quantity": usStock.Quantity, "get_price": usStock.GetPrice}
+            if err := updateOwned(tx, &model.UsStock{}, usStock.ID, dto.UserId, values); err != nil {
+                return err
+            }
+        }
+        for _, japanFund := range dto.CreateJapanFunds {
+            japanFund.UserId = dto.UserId
+            japanFund.AccountId = accountId
+            if err := nisa.ReserveJapanFundPurchase(tx, japanFund, japanFund.GetPriceTotal); err != nil {
+                return err
+            }
+            if err := tx.Create(&japanFund).Error; err != nil {
+                return err
+            }
+        }
+        for _, japanFund := range dto.UpdateJapanFunds {
+            var current model.JapanFund
+            if err := fetchOwned(tx, &current, japanFund.ID, dto.UserId); err != nil {
+                return err
+            }
+            updated := current
+            updated.GetPrice = japanFund.GetPrice
+            updated.GetPriceTotal = japanFund.GetPriceTotal
+            if err := nisa.ReserveJapanFundPurchase(tx, updated, updated.GetPriceTotal-current.GetPriceTotal); err != nil {
+                return err
+            }
+            values := map[string]interface{}{"get_price": japanFund.GetPrice, "get_price_total": japanFund.GetPriceTotal}
+            if err := updateOwned(tx, &model.JapanFund{}, japanFund.ID, dto.UserId, values); err != nil {
+                return err
+            }
+        }
+        for _, crypto := range dto.CreateCryptos {
+            crypto.UserId = dto.UserId
+            crypto.AccountId = accountId
+            if err := tx.Create(&crypto).Error; err != nil {
+                return err
+            }
+        }
+        for _, crypto := range dto.UpdateCryptos {
+            values := map[string]interface{}{"quantity": crypto.Quantity, "get_price": crypto.GetPrice}
+            if err := updateOwned(tx, &model.Crypto{}, crypto.ID, dto.UserId, values); err != nil {
+                return err
+            }
+        }
+        return nil
+    })
+}
+
+// 指定ユーザーの保有資産を取得する(対象が見つからない場合はエラー)
+func fetchOwned(tx *gorm.DB, holding interface{}, id uint, userId uint) error {
+    if err := tx.Where("id = ? AND user_id = ?", id, userId).First(holding).Error; err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return fmt.Errorf("更新対象の保有資産が見つかりません(id: %d)", id)
+        }
+        return err
+    }
+    return nil
+}
+
+// 指定ユーザーの保有資産のみ更新する(対象が見つからない場合はエラー)
+func updateOwned(tx *gorm.DB, holding interface{}, id uint, userId uint, values map[string]interface{}) error {
+    result := tx.Model(holding).Where("id = ? AND user_id = ?", id, userId).Updates(values)
+    if result.Error != nil {
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        return fmt.Errorf("更新対象の保有資産が見つかりません(id: %d)", id)
+    }
+    return nil
+}
@@ -0,0 +1,115 @@
+package holdingimport
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// テスト用のデータベース設定
+func setupTestDB() *gorm.DB {
+    db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+    if err != nil {
+        panic("failed to connect database")
+    }
+    // テスト用のテーブルを準備
+    db.AutoMigrate(&model.UsStock{}, &model.JapanFund{}, &model.Crypto{}, &model.BrokerageAccount{}, &model.NisaPurchase{})
+    return db
+}
+
+func TestImportHoldings(t *testing.T) {
+    db := setupTestDB()
+    repo := NewHoldingImportRepository(db)
+
+    existingStock := model.UsStock{Code: "KO", GetPrice: 55, Quantity: 10, Sector: "Consumer Defensive", UsdJpy: 140, UserId: 1}
+    existingFund := model.JapanFund{Code: "0331418A", Name: "eMAXIS Slim 全世界株式(オール・カントリー)", GetPrice: 15000, GetPriceTotal: 150000, UserId: 1}
+    db.Create(&existingStock)
+    db.Create(&existingFund)
+
+    err := repo.ImportHoldings(context.Background(), ImportHoldingsDto{
+        UserId:           1,
+        CreateUsStocks:   []model.UsStock{{Code: "AAPL", GetPrice: 150, Quantity: 5, Sector: "Technology", UsdJpy: 148}},
+        UpdateUsStocks:   []model.UsStock{{Model: gorm.Model{ID: existingStock.ID}, GetPrice: 56, Quantity: 20}},
+        UpdateJapanFunds: []model.JapanFund{{Model: gorm.Model{ID: existingFund.ID}, GetPrice: 16000, GetPriceTotal: 320000}},
+        CreateCryptos:    []model.Crypto{{Code: "btc", GetPrice: 6000000, Quantity: 0.1}},
+    })
+    assert.NoError(t, err)
+
+    var stocks []model.UsStock
+    db.Where("user_id = ?", 1).Order("id").Find(&stocks)
+    assert.Len(t, stocks, 2)
+    assert.Equal(t, 20.0, stocks[0].Quantity)
+    assert.Equal(t, 56.0, stocks[0].GetPrice)
+    assert.Equal(t, "AAPL", stocks[1].Code)
+
+    var fund model.JapanFund
+    db.First(&fund, existingFund.ID)
+    assert.Equal(t, 320000.0, fund.GetPriceTotal)
+    assert.Equal(t, "eMAXIS Slim 全世界株式(オール・カントリー)", fund.Name)
+
+    var cryptos []model.Crypto
+    db.Where("user_id = ?", 1).Find(&cryptos)
+    assert.Len(t, cryptos, 1)
+}
+
+// 1件でも失敗した場合は全ての変更を取り消す
+func TestImportHoldingsRollback(t *testing.T) {
+    db := setupTestDB()
+    repo := NewHoldingImportRepository(db)
+
+    otherStock := model.UsStock{Code: "KO", GetPrice: 55, Quantity: 10, Sector: "Consumer Defensive", UserId: 2}
+    db.Create(&otherStock)
+
+    err := repo.ImportHoldings(context.Background(), ImportHoldingsDto{
+        UserId:         1,
+        CreateUsStocks: []model.UsStock{{Code: "AAPL", GetPrice: 150, Quantity: 5, Sector: "Technology"}},
+        // 他のユーザーの保有株式は更新できない
+        UpdateUsStocks: []model.UsStock{{Model: gorm.Model{ID: otherStock.ID}, GetPrice: 1, Quantity: 1}},
+    })
+    assert.Error(t, err)
+
+    var count int64
+    db.Model(&model.UsStock{}).Where("user_id = ?", 1).Count(&count)
+    assert.Equal(t, int64(0), count)
+    var unchanged model.UsStock
+    db.First(&unchanged, otherStock.ID)
+    assert.Equal(t, 10.0, unchanged.Quantity)
+}
+
+// NISA口座への取り込みは投資枠と照合し、上限を超える場合は全ての変更を取り消す
+func TestImportHoldingsNisaAllowance(t *testing.T) {
+    db := setupTestDB()
+    repo := NewHoldingImportRepository(db)
+
+    nisa := model.BrokerageAccount{Name: "SBI NISA", Type: "NISA_GROWTH", UserId: 3}
+    db.Create(&nisa)
+    existingFund := model.JapanFund{Code: "0331418A", Name: "eMAXIS Slim 全世界株式(オール・カントリー)", GetPrice: 15000, GetPriceTotal: 1000000, AccountId: nisa.ID, UserId: 3}
+    db.Create(&existingFund)
+
+    // 買い増した100万円分のみ購入記録に登録する
+    err := repo.ImportHoldings(context.Background(), ImportHoldingsDto{
+        UserId:           3,
+        AccountId:        &nisa.ID,
+        UpdateJapanFunds: []model.JapanFund{{Model: gorm.Model{ID: existingFund.ID}, GetPrice: 16000, GetPriceTotal: 2000000}},
+    })
+    assert.NoError(t, err)
+    var purchases []model.NisaPurchase
+    db.Where("user_id = ?", 3).Find(&purchases)
+    assert.Len(t, purchases, 1)
+    assert.Equal(t, 1000000.0, purchases[0].Amount)
+
+    // 年間投資枠(240万円)を超える場合は取り込まない
+    err = repo.ImportHoldings(context.Background(), ImportHoldingsDto{
+        UserId:         3,
+        AccountId:      &nisa.ID,
+        CreateUsStocks: []model.UsStock{{Code: "AAPL", GetPrice: 100, Quantity: 150, Sector: "Technology", UsdJpy: 100}},
+    })
+    assert.EqualError(t, err, "成長投資枠の年間投資枠(240万円)を超えるため登録できません")
+    var count int64
+    db.Model(&model.UsStock{}).Where("user_id = ?", 3).Count(&count)
+    assert.Equal(t, int64(0), count)
+}
@@ -0,0 +1,17 @@
+package holdingimport
+
+import "my-us-stock-backend/app/database/model"
+
+// ImportHoldingsDto は一括取り込みで作成・更新する保有資産です
+// 更新対象はIDを指定し、米国株式・暗号通貨は保有数量と取得価格、投資信託は取得価格と取得総額のみ更新します
+// 作成する保有資産は AccountId の口座に登録します(nilの場合は既定の口座)
+type ImportHoldingsDto struct {
+    UserId           uint
+    AccountId        *uint
+    CreateUsStocks   []model.UsStock
+    UpdateUsStocks   []model.UsStock
+    CreateJapanFunds []model.JapanFund
+    UpdateJapanFunds []model.JapanFund
+    CreateCryptos    []model.Crypto
+    UpdateCryptos    []model.Crypto
+}
@@ -0,0 +1,22 @@
+package holdingimport
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockHoldingImportRepository は HoldingImportRepository のモックです。
+type MockHoldingImportRepository struct {
+	mock.Mock
+}
+
+// NewMockHoldingImportRepository は新しい MockHoldingImportRepository を作成し、初期設定を行います。
+func NewMockHoldingImportRepository() *MockHoldingImportRepository {
+	return &MockHoldingImportRepository{}
+}
+
+func (m *MockHoldingImportRepository) ImportHoldings(ctx context.Context, dto ImportHoldingsDto) error {
+	args := m.Called(ctx, dto)
+	return args.Error(0)
+}
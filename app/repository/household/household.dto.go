@@ -0,0 +1,27 @@
+package household
+
+type CreateHouseholdDto struct {
+    Name   string `json:"name"`
+    UserId uint   `json:"userId"`
+}
+
+type AddHouseholdMemberDto struct {
+    HouseholdId uint   `json:"householdId"`
+    UserId      uint   `json:"userId"`
+    Role        string `json:"role"`
+}
+
+// HouseholdMemberDto は世帯のメンバーをユーザー情報と合わせて表します
+type HouseholdMemberDto struct {
+    UserId uint   `json:"userId"`
+    Role   string `json:"role"`
+    Name   string `json:"name"`
+    Email  string `json:"email"`
+}
+
+// HouseholdInvitationDto は承認待ちの世帯への招待を表します
+type HouseholdInvitationDto struct {
+    HouseholdId uint   `json:"householdId"`
+    Name        string `json:"name"`
+    Role        string `json:"role"`
+}
@@ -0,0 +1,208 @@
+package household
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/database/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 世帯の権限
+const (
+    RoleOwner  = "OWNER"
+    RoleEditor = "EDITOR"
+    RoleViewer = "VIEWER"
+)
+
+// HouseholdRepository インターフェースの定義
+type HouseholdRepository interface {
+    FetchHouseholdListByUserId(ctx context.Context, userId uint) ([]model.Household, error)
+    FetchMembers(ctx context.Context, householdId uint) ([]HouseholdMemberDto, error)
+    FetchMember(ctx context.Context, householdId uint, userId uint) (*model.HouseholdMember, error)
+    CreateHousehold(ctx context.Context, dto CreateHouseholdDto) (*model.Household, error)
+    InviteMember(ctx context.Context, dto AddHouseholdMemberDto) error
+    FetchInvitations(ctx context.Context, userId uint) ([]HouseholdInvitationDto, error)
+    AcceptInvitation(ctx context.Context, householdId uint, userId uint) error
+    DeclineInvitation(ctx context.Context, householdId uint, userId uint) error
+    UpdateMemberRole(ctx context.Context, householdId uint, userId uint, role string) error
+    RemoveMember(ctx context.Context, householdId uint, userId uint) error
+    DeleteHousehold(ctx context.Context, householdId uint) error
+    FetchSharedRoles(ctx context.Context, userId uint, memberId uint) ([]string, error)
+    FetchHoldingOwnerId(ctx context.Context, holding interface{}, id uint) (uint, error)
+}
+
+// DefaultHouseholdRepository 構造体の定義
+type DefaultHouseholdRepository struct {
+    DB *gorm.DB
+}
+
+// NewHouseholdRepository は DefaultHouseholdRepository の新しいインスタンスを作成します
+func NewHouseholdRepository(db *gorm.DB) HouseholdRepository {
+    return &DefaultHouseholdRepository{DB: db}
+}
+
+// 招待を承認したメンバーのみに絞り込む(承認待ちの招待では資産を共有しない)
+func scopeAccepted(db *gorm.DB) *gorm.DB {
+    return db.Where("household_members.accepted_at IS NOT NULL")
+}
+
+// 指定したuserIdのユーザーが所属する世帯のリストを作成順に取得する
+func (r *DefaultHouseholdRepository) FetchHouseholdListByUserId(ctx context.Context, userId uint) ([]model.Household, error) {
+    var households []model.Household
+    err := r.DB.Joins("JOIN household_members ON household_members.household_id = households.id AND household_members.deleted_at IS NULL AND household_members.accepted_at IS NOT NULL").
+        Where("household_members.user_id = ?", userId).Order("households.id").Find(&households).Error
+    if err != nil {
+        return nil, err
+    }
+    return households, nil
+}
+
+// 世帯のメンバーをユーザー情報と合わせて追加順に取得する(承認待ちの招待は含まない)
+func (r *DefaultHouseholdRepository) FetchMembers(ctx context.Context, householdId uint) ([]HouseholdMemberDto, error) {
+    var members []HouseholdMemberDto
+    err := scopeAccepted(r.DB.Model(&model.HouseholdMember{})).
+        Select("household_members.user_id, household_members.role, users.name, users.email").
+        Joins("JOIN users ON users.id = household_members.user_id AND users.deleted_at IS NULL").
+        Where("household_members.household_id = ?", householdId).Order("household_members.id").Scan(&members).Error
+    if err != nil {
+        return nil, err
+    }
+    return members, nil
+}
+
+// 世帯のメンバーを取得する(メンバーでない場合・招待を承認していない場合はnil)
+func (r *DefaultHouseholdRepository) FetchMember(ctx context.Context, householdId uint, userId uint) (*model.HouseholdMember, error) {
+    var member model.HouseholdMember
+    if err := scopeAccepted(r.DB).Where("household_id = ? AND user_id = ?", householdId, userId).First(&member).Error; err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return nil, nil
+        }
+        return nil, err
+    }
+    return &member, nil
+}
+
+// 世帯を作成し、作成したユーザーをオーナーとして登録します
+func (r *DefaultHouseholdRepository) CreateHousehold(ctx context.Context, dto CreateHouseholdDto) (*model.Household, error) {
+    household := &model.Household{Name: dto.Name, UserId: dto.UserId}
+    err := r.DB.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Create(household).Error; err != nil {
+            return err
+        }
+        acceptedAt := time.Now()
+        return tx.Create(&model.HouseholdMember{HouseholdId: household.ID, Role: RoleOwner, UserId: dto.UserId, AcceptedAt: &acceptedAt}).Error
+    })
+    if err != nil {
+        return nil, err
+    }
+    return household, nil
+}
+
+// 世帯にユーザーを招待します(招待されたユーザーが承認するまでメンバーにはならない)
+// 既にメンバーの場合・招待済みの場合は何もしない(招待したユーザーにメンバーの状態を知らせないため)
+func (r *DefaultHouseholdRepository) InviteMember(ctx context.Context, dto AddHouseholdMemberDto) error {
+    var count int64
+    if err := r.DB.Model(&model.HouseholdMember{}).Where("household_id = ? AND user_id = ?", dto.HouseholdId, dto.UserId).Count(&count).Error; err != nil {
+        return err
+    }
+    if count > 0 {
+        return nil
+    }
+    return r.DB.Create(&model.HouseholdMember{HouseholdId: dto.HouseholdId, Role: dto.Role, UserId: dto.UserId}).Error
+}
+
+// 指定したuserIdのユーザーへの承認待ちの招待を招待順に取得する
+func (r *DefaultHouseholdRepository) FetchInvitations(ctx context.Context, userId uint) ([]HouseholdInvitationDto, error) {
+    var invitations []HouseholdInvitationDto
+    err := r.DB.Model(&model.HouseholdMember{}).
+        Select("households.id AS household_id, households.name, household_members.role").
+        Joins("JOIN households ON households.id = household_members.household_id AND households.deleted_at IS NULL").
+        Where("household_members.user_id = ? AND household_members.accepted_at IS NULL", userId).Order("household_members.id").Scan(&invitations).Error
+    if err != nil {
+        return nil, err
+    }
+    return invitations, nil
+}
+
+// 世帯への招待を承認します
+func (r *DefaultHouseholdRepository) AcceptInvitation(ctx context.Context, householdId uint, userId uint) error {
+    result := r.DB.Model(&model.HouseholdMember{}).Where("household_id = ? AND user_id = ? AND accepted_at IS NULL", householdId, userId).Update("accepted_at", time.Now())
+    if result.Error != nil {
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        return errors.New("指定された招待が見つかりません")
+    }
+    return nil
+}
+
+// 世帯への招待を辞退します
+func (r *DefaultHouseholdRepository) DeclineInvitation(ctx context.Context, householdId uint, userId uint) error {
+    result := r.DB.Where("household_id = ? AND user_id = ? AND accepted_at IS NULL", householdId, userId).Delete(&model.HouseholdMember{})
+    if result.Error != nil {
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        return errors.New("指定された招待が見つかりません")
+    }
+    return nil
+}
+
+// メンバーの権限を更新します
+func (r *DefaultHouseholdRepository) UpdateMemberRole(ctx context.Context, householdId uint, userId uint, role string) error {
+    result := scopeAccepted(r.DB.Model(&model.HouseholdMember{})).Where("household_id = ? AND user_id = ?", householdId, userId).Update("role", role)
+    if result.Error != nil {
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        return errors.New("指定されたメンバーが見つかりません")
+    }
+    return nil
+}
+
+// メンバーを世帯から外します
+func (r *DefaultHouseholdRepository) RemoveMember(ctx context.Context, householdId uint, userId uint) error {
+    result := scopeAccepted(r.DB).Where("household_id = ? AND user_id = ?", householdId, userId).Delete(&model.HouseholdMember{})
+    if result.Error != nil {
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        return errors.New("指定されたメンバーが見つかりません")
+    }
+    return nil
+}
+
+// 世帯とメンバーを削除します
+func (r *DefaultHouseholdRepository) DeleteHousehold(ctx context.Context, householdId uint) error {
+    return r.DB.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Where("household_id = ?", householdId).Delete(&model.HouseholdMember{}).Error; err != nil {
+            return err
+        }
+        return tx.Delete(&model.Household{}, householdId).Error
+    })
+}
+
+// userIdのユーザーが、memberIdのユーザーと共に所属する世帯での権限を取得する(双方が招待を承認している世帯のみ)
+func (r *DefaultHouseholdRepository) FetchSharedRoles(ctx context.Context, userId uint, memberId uint) ([]string, error) {
+    var roles []string
+    sharedHouseholds := scopeAccepted(r.DB.Model(&model.HouseholdMember{})).Select("household_id").Where("user_id = ?", memberId)
+    err := scopeAccepted(r.DB.Model(&model.HouseholdMember{})).Where("user_id = ? AND household_id IN (?)", userId, sharedHouseholds).Pluck("role", &roles).Error
+    if err != nil {
+        return nil, err
+    }
+    return roles, nil
+}
+
+// 保有資産を所有するユーザーのidを取得する
+func (r *DefaultHouseholdRepository) FetchHoldingOwnerId(ctx context.Context, holding interface{}, id uint) (uint, error) {
+    var userIds []uint
+    if err := r.DB.Model(holding).Where("id = ?", id).Pluck("user_id", &userIds).Error; err != nil {
+        return 0, err
+    }
+    if len(userIds) == 0 {
+        return 0, errors.New("指定された保有資産が見つかりません")
+    }
+    return userIds[0], nil
+}
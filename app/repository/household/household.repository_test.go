@@ -0,0 +1,131 @@
+package household
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// テスト用のデータベース設定
+func setupTestDB() *gorm.DB {
+    db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+    if err != nil {
+        panic("failed to connect database")
+    }
+    // テスト用のテーブルを準備
+    db.AutoMigrate(&model.User{}, &model.Household{}, &model.HouseholdMember{}, &model.UsStock{})
+    return db
+}
+
+func TestCreateHouseholdAndMembers(t *testing.T) {
+    db := setupTestDB()
+    repo := NewHouseholdRepository(db)
+    owner := model.User{Name: "太郎", Email: "taro@example.com", Password: "password"}
+    db.Create(&owner)
+    partner := model.User{Name: "花子", Email: "hanako@example.com", Password: "password"}
+    db.Create(&partner)
+
+    household, err := repo.CreateHousehold(context.Background(), CreateHouseholdDto{Name: "山田家", UserId: owner.ID})
+    assert.NoError(t, err)
+    assert.NoError(t, repo.InviteMember(context.Background(), AddHouseholdMemberDto{HouseholdId: household.ID, UserId: partner.ID, Role: RoleViewer}))
+    // 招待済みの場合は何もしない
+    assert.NoError(t, repo.InviteMember(context.Background(), AddHouseholdMemberDto{HouseholdId: household.ID, UserId: partner.ID, Role: RoleEditor}))
+
+    // 承認するまではメンバーとして扱わない
+    members, err := repo.FetchMembers(context.Background(), household.ID)
+    assert.NoError(t, err)
+    assert.Len(t, members, 1)
+    member, err := repo.FetchMember(context.Background(), household.ID, partner.ID)
+    assert.NoError(t, err)
+    assert.Nil(t, member)
+    roles, err := repo.FetchSharedRoles(context.Background(), partner.ID, owner.ID)
+    assert.NoError(t, err)
+    assert.Empty(t, roles)
+    invitations, err := repo.FetchInvitations(context.Background(), partner.ID)
+    assert.NoError(t, err)
+    assert.Equal(t, []HouseholdInvitationDto{{HouseholdId: household.ID, Name: "山田家", Role: RoleViewer}}, invitations)
+
+    // 招待を承認するとメンバーになる
+    assert.NoError(t, repo.AcceptInvitation(context.Background(), household.ID, partner.ID))
+    assert.EqualError(t, repo.AcceptInvitation(context.Background(), household.ID, partner.ID), "指定された招待が見つかりません")
+    invitations, err = repo.FetchInvitations(context.Background(), partner.ID)
+    assert.NoError(t, err)
+    assert.Empty(t, invitations)
+
+    members, err = repo.FetchMembers(context.Background(), household.ID)
+    assert.NoError(t, err)
+    assert.Equal(t, []HouseholdMemberDto{
+        {UserId: owner.ID, Role: RoleOwner, Name: "太郎", Email: "taro@example.com"},
+        {UserId: partner.ID, Role: RoleViewer, Name: "花子", Email: "hanako@example.com"},
+    }, members)
+
+    households, err := repo.FetchHouseholdListByUserId(context.Background(), partner.ID)
+    assert.NoError(t, err)
+    assert.Len(t, households, 1)
+    assert.Equal(t, "山田家", households[0].Name)
+
+    // 共に所属する世帯での権限
+    assert.NoError(t, repo.UpdateMemberRole(context.Background(), household.ID, partner.ID, RoleEditor))
+    roles, err = repo.FetchSharedRoles(context.Background(), partner.ID, owner.ID)
+    assert.NoError(t, err)
+    assert.Equal(t, []string{RoleEditor}, roles)
+    roles, err = repo.FetchSharedRoles(context.Background(), partner.ID, 999)
+    assert.NoError(t, err)
+    assert.Empty(t, roles)
+
+    // 世帯から外れると権限がなくなる
+    assert.NoError(t, repo.RemoveMember(context.Background(), household.ID, partner.ID))
+    member, err = repo.FetchMember(context.Background(), household.ID, partner.ID)
+    assert.NoError(t, err)
+    assert.Nil(t, member)
+    assert.EqualError(t, repo.RemoveMember(context.Background(), household.ID, partner.ID), "指定されたメンバーが見つかりません")
+}
+
+func TestDeclineInvitation(t *testing.T) {
+    db := setupTestDB()
+    repo := NewHouseholdRepository(db)
+
+    household, err := repo.CreateHousehold(context.Background(), CreateHouseholdDto{Name: "佐藤家", UserId: 11})
+    assert.NoError(t, err)
+    assert.NoError(t, repo.InviteMember(context.Background(), AddHouseholdMemberDto{HouseholdId: household.ID, UserId: 12, Role: RoleEditor}))
+
+    // 承認前の招待はオーナーからもメンバーとして操作できない
+    assert.EqualError(t, repo.UpdateMemberRole(context.Background(), household.ID, 12, RoleViewer), "指定されたメンバーが見つかりません")
+    assert.EqualError(t, repo.RemoveMember(context.Background(), household.ID, 12), "指定されたメンバーが見つかりません")
+
+    assert.NoError(t, repo.DeclineInvitation(context.Background(), household.ID, 12))
+    assert.EqualError(t, repo.AcceptInvitation(context.Background(), household.ID, 12), "指定された招待が見つかりません")
+    // 承認済みのメンバーは辞退できない
+    assert.EqualError(t, repo.DeclineInvitation(context.Background(), household.ID, 11), "指定された招待が見つかりません")
+}
+
+func TestDeleteHousehold(t *testing.T) {
+    db := setupTestDB()
+    repo := NewHouseholdRepository(db)
+
+    household, err := repo.CreateHousehold(context.Background(), CreateHouseholdDto{Name: "山田家", UserId: 1})
+    assert.NoError(t, err)
+    assert.NoError(t, repo.DeleteHousehold(context.Background(), household.ID))
+
+    households, err := repo.FetchHouseholdListByUserId(context.Background(), 1)
+    assert.NoError(t, err)
+    assert.Empty(t, households)
+}
+
+func TestFetchHoldingOwnerId(t *testing.T) {
+    db := setupTestDB()
+    repo := NewHouseholdRepository(db)
+    stock := model.UsStock{Code: "AAPL", Quantity: 1, GetPrice: 100, Sector: "Tech", UserId: 5}
+    db.Create(&stock)
+
+    ownerId, err := repo.FetchHoldingOwnerId(context.Background(), &model.UsStock{}, stock.ID)
+    assert.NoError(t, err)
+    assert.Equal(t, uint(5), ownerId)
+
+    _, err = repo.FetchHoldingOwnerId(context.Background(), &model.UsStock{}, 999)
+    assert.EqualError(t, err, "指定された保有資産が見つかりません")
+}
@@ -0,0 +1,89 @@
+package household
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockHouseholdRepository は HouseholdRepository のモックです。
+type MockHouseholdRepository struct {
+	mock.Mock
+}
+
+// NewMockHouseholdRepository は新しい MockHouseholdRepository を作成し、初期設定を行います。
+func NewMockHouseholdRepository() *MockHouseholdRepository {
+	return &MockHouseholdRepository{}
+}
+
+func (m *MockHouseholdRepository) FetchHouseholdListByUserId(ctx context.Context, userId uint) ([]model.Household, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]model.Household), args.Error(1)
+}
+
+func (m *MockHouseholdRepository) FetchMembers(ctx context.Context, householdId uint) ([]HouseholdMemberDto, error) {
+	args := m.Called(ctx, householdId)
+	return args.Get(0).([]HouseholdMemberDto), args.Error(1)
+}
+
+func (m *MockHouseholdRepository) FetchMember(ctx context.Context, householdId uint, userId uint) (*model.HouseholdMember, error) {
+	args := m.Called(ctx, householdId, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.HouseholdMember), args.Error(1)
+}
+
+func (m *MockHouseholdRepository) CreateHousehold(ctx context.Context, dto CreateHouseholdDto) (*model.Household, error) {
+	args := m.Called(ctx, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Household), args.Error(1)
+}
+
+func (m *MockHouseholdRepository) InviteMember(ctx context.Context, dto AddHouseholdMemberDto) error {
+	args := m.Called(ctx, dto)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) FetchInvitations(ctx context.Context, userId uint) ([]HouseholdInvitationDto, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]HouseholdInvitationDto), args.Error(1)
+}
+
+func (m *MockHouseholdRepository) AcceptInvitation(ctx context.Context, householdId uint, userId uint) error {
+	args := m.Called(ctx, householdId, userId)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) DeclineInvitation(ctx context.Context, householdId uint, userId uint) error {
+	args := m.Called(ctx, householdId, userId)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) UpdateMemberRole(ctx context.Context, householdId uint, userId uint, role string) error {
+	args := m.Called(ctx, householdId, userId, role)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) RemoveMember(ctx context.Context, householdId uint, userId uint) error {
+	args := m.Called(ctx, householdId, userId)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) DeleteHousehold(ctx context.Context, householdId uint) error {
+	args := m.Called(ctx, householdId)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) FetchSharedRoles(ctx context.Context, userId uint, memberId uint) ([]string, error) {
+	args := m.Called(ctx, userId, memberId)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockHouseholdRepository) FetchHoldingOwnerId(ctx context.Context, holding interface{}, id uint) (uint, error) {
+	args := m.Called(ctx, holding, id)
+	return args.Get(0).(uint), args.Error(1)
+}
@@ -0,0 +1,10 @@
+package marketprice
+
+type CompanyProfileDto struct {
+    Ticker   string `json:"ticker"`
+    Name     string `json:"name"`
+    Sector   string `json:"sector"`
+    Industry string `json:"industry"`
+    Country  string `json:"country"`
+    Exchange string `json:"exchange"`
+}
@@ -0,0 +1,12 @@
+package marketprice
+
+// CompanyProfileResponse はAPIからの企業情報レスポンスを表します。
+type CompanyProfileResponse struct {
+	Symbol            string `json:"symbol"`            // ティッカー名
+	CompanyName       string `json:"companyName"`       // 会社名
+	Sector            string `json:"sector"`            // セクター
+	Industry          string `json:"industry"`          // 業種
+	Country           string `json:"country"`           // 国
+	Exchange          string `json:"exchange"`
+	ExchangeShortName string `json:"exchangeShortName"` // 上場市場
+}
@@ -0,0 +1,11 @@
+package marketprice
+
+// DividendHistoryDto は1回分の配当支払情報を表します。
+type DividendHistoryDto struct {
+    ExDate          string  `json:"exDate"`          // 権利落日
+    RecordDate      string  `json:"recordDate"`      // 権利確定日
+    PaymentDate     string  `json:"paymentDate"`     // 支払日
+    DeclarationDate string  `json:"declarationDate"` // 発表日
+    Amount          float64 `json:"amount"`          // 1株当たり配当額
+    AdjustedAmount  float64 `json:"adjustedAmount"`  // 株式分割調整後の1株当たり配当額
+}
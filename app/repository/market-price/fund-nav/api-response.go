@@ -0,0 +1,9 @@
+package fundnav
+
+// ApiResponse は基準価額の取得元APIのレスポンスを表します
+type ApiResponse struct {
+    FundCode string  `json:"fundCode"`
+    FundName string  `json:"fundName"`
+    BaseDate string  `json:"baseDate"` // YYYY-MM-DD
+    Nav      float64 `json:"nav"`      // 1万口あたりの基準価額(円)
+}
@@ -0,0 +1,11 @@
+package fundnav
+
+import "time"
+
+// FundNav は投資信託の基準価額を表します
+type FundNav struct {
+    Code string    `json:"code"`
+    Name string    `json:"name"`
+    Nav  float64   `json:"nav"`
+    Date time.Time `json:"date"` // 基準日
+}
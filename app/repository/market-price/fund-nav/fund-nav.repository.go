@@ -0,0 +1,83 @@
+package fundnav
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// FundNavRepository は投資信託の基準価額を取得するためのインターフェースです。
+type FundNavRepository interface {
+    FetchFundNav(ctx context.Context, code string) (*FundNav, error)
+}
+
+// DefaultFundNavRepository は FundNavRepository のデフォルト実装です。
+type DefaultFundNavRepository struct {
+    httpClient *http.Client
+    fundNavURL string
+}
+
+// NewFundNavRepository は新しい DefaultFundNavRepository インスタンスを作成します。
+func NewFundNavRepository(client *http.Client) *DefaultFundNavRepository {
+    if client == nil {
+        client = http.DefaultClient
+    }
+    fundNavURL := os.Getenv("FUND_NAV_URL")
+    return &DefaultFundNavRepository{
+        httpClient: client,
+        fundNavURL: fundNavURL,
+    }
+}
+
+// FetchFundNav は指定されたファンドコードの最新の基準価額を取得します。
+func (repo *DefaultFundNavRepository) FetchFundNav(ctx context.Context, code string) (*FundNav, error) {
+    if repo.fundNavURL == "" {
+        return nil, fmt.Errorf("FUND_NAV_URL is not set")
+    }
+    requestURL := fmt.Sprintf("%s/funds/%s/nav", repo.fundNavURL, url.PathEscape(code))
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+    if err != nil {
+        return nil, err
+    }
+    resp, err := repo.httpClient.Do(req)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+    }
+
+    body, err := io.ReadAll(resp.Body)
+    if err != nil {
+        return nil, err
+    }
+
+    var apiResp ApiResponse
+    if err := json.Unmarshal(body, &apiResp); err != nil {
+        return nil, err
+    }
+    if apiResp.FundCode != code {
+        return nil, fmt.Errorf("fund code mismatch: %s", apiResp.FundCode)
+    }
+    if apiResp.Nav <= 0 {
+        return nil, fmt.Errorf("invalid nav: %v", apiResp.Nav)
+    }
+    date, err := time.Parse("2006-01-02", apiResp.BaseDate)
+    if err != nil {
+        return nil, fmt.Errorf("invalid base date: %s", apiResp.BaseDate)
+    }
+
+    return &FundNav{
+        Code: apiResp.FundCode,
+        Name: apiResp.FundName,
+        Nav:  apiResp.Nav,
+        Date: date,
+    }, nil
+}
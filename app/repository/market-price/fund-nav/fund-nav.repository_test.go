@@ -0,0 +1,56 @@
+package fundnav
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 基準価額の取得元APIの代わりにローカルのサーバーを起動する
+func setupFundNavServer(t *testing.T) *DefaultFundNavRepository {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/funds/0331418A/nav":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"fundCode": "0331418A", "fundName": "eMAXIS Slim 米国株式(S&P500)", "baseDate": "2026-10-16", "nav": 34567}`))
+		case "/funds/BROKEN/nav":
+			w.Write([]byte(`{"fundCode": "BROKEN", "baseDate": "2026-10-16", "nav": 0}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("FUND_NAV_URL", server.URL)
+	return NewFundNavRepository(server.Client())
+}
+
+func TestFundNavRepository_FetchFundNav(t *testing.T) {
+	repo := setupFundNavServer(t)
+
+	t.Run("正常に基準価額を取得", func(t *testing.T) {
+		result, err := repo.FetchFundNav(context.Background(), "0331418A")
+		assert.NoError(t, err)
+		assert.Equal(t, &FundNav{
+			Code: "0331418A",
+			Name: "eMAXIS Slim 米国株式(S&P500)",
+			Nav:  34567,
+			Date: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+		}, result)
+	})
+
+	t.Run("存在しないファンドコード", func(t *testing.T) {
+		result, err := repo.FetchFundNav(context.Background(), "UNKNOWN")
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("基準価額が不正", func(t *testing.T) {
+		result, err := repo.FetchFundNav(context.Background(), "BROKEN")
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,25 @@
+package fundnav
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockFundNavRepository の定義
+type MockFundNavRepository struct {
+	mock.Mock
+}
+
+// NewMockFundNavRepository は新しい MockFundNavRepository を作成し、初期設定を行います。
+func NewMockFundNavRepository() *MockFundNavRepository {
+	return &MockFundNavRepository{}
+}
+
+func (m *MockFundNavRepository) FetchFundNav(ctx context.Context, code string) (*FundNav, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*FundNav), args.Error(1)
+}
@@ -4,16 +4,24 @@ import (
 	"context"
 	"fmt"
 	"my-us-stock-backend/app/database/model"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // FundPriceRepository インターフェースの定義
 type FundPriceRepository interface {
 	FetchFundPriceList(ctx context.Context) ([]model.FundPrice, error)
     FindFundPriceByCode(ctx context.Context, code string) (*model.FundPrice, error)
+    FindFundPriceByCodeAsOf(ctx context.Context, code string, asOf time.Time) (*model.FundPrice, error)
+    FetchFundPriceHistoryList(ctx context.Context, code string, from time.Time, to time.Time) ([]model.FundPriceHistory, error)
     UpdateFundPrice(ctx context.Context, dto UpdateFundPriceDto) (*model.FundPrice, error)
 	CreateFundPrice(ctx context.Context, dto CreateFundPriceDto) (*model.FundPrice, error)
+    FetchHeldFundCodes(ctx context.Context) ([]string, error)
+    UpsertFundNav(ctx context.Context, dto UpsertFundNavDto) (*model.FundPrice, error)
+    RecordFundNavFailure(ctx context.Context, code string, reason string, failedAt time.Time) error
+    FetchFundNavFailureList(ctx context.Context) ([]model.FundNavFailure, error)
 }
 
 // DefaultFundPriceRepository 構造体の定義
@@ -23,7 +31,7 @@ type DefaultFundPriceRepository struct {
 
 // 共通フィールドを選択するためのヘルパー関数です。
 func selectBaseQuery(db *gorm.DB) *gorm.DB {
-    return db.Select("id", "price","code", "name")
+    return db.Select("id", "price","code", "name", "nav_date", "nav_fetched_at", "nav_fetch_error", "nav_fetch_failed_at")
 }
 
 // NewFetchFundRepository は DefaultStrategyRepository の新しいインスタンスを作成します
@@ -55,6 +63,55 @@ func (r *DefaultFundPriceRepository) FindFundPriceByCode(ctx context.Context, co
 	return &fundPrice, nil
 }
 
+// FindFundPriceByCodeAsOf は指定されたcodeのファンドの asOf 時点の価格情報を取得します
+// 価格は asOf 以前で最も新しい履歴の価格とし、NavDate にはその日付を設定します
+func (r *DefaultFundPriceRepository) FindFundPriceByCodeAsOf(ctx context.Context, code string, asOf time.Time) (*model.FundPrice, error) {
+    fundPrice, err := r.FindFundPriceByCode(ctx, code)
+    if err != nil {
+        return nil, err
+    }
+
+    var histories []model.FundPriceHistory
+    if err := r.DB.Select("code", "date", "price").Where("code = ? AND date <= ?", code, historyDate(asOf)).Order("date desc").Limit(1).Find(&histories).Error; err != nil {
+        return nil, err
+    }
+    if len(histories) == 0 {
+        return nil, gorm.ErrRecordNotFound
+    }
+
+    fundPrice.Price = histories[0].Price
+    fundPrice.NavDate = &histories[0].Date
+    return fundPrice, nil
+}
+
+// 指定したファンドの期間内の日次価格を日付の昇順で取得する
+func (r *DefaultFundPriceRepository) FetchFundPriceHistoryList(ctx context.Context, code string, from time.Time, to time.Time) ([]model.FundPriceHistory, error) {
+    var histories []model.FundPriceHistory
+    err := r.DB.Select("id", "code", "date", "price").Where("code = ? AND date >= ? AND date <= ?", code, historyDate(from), historyDate(to)).Order("date asc").Find(&histories).Error
+    if err != nil {
+        return nil, err
+    }
+    return histories, nil
+}
+
+// 履歴の日付(UTCの0時)に変換する
+func historyDate(date time.Time) time.Time {
+    return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// 日次価格を履歴に記録します。同じファンド・日付のデータが存在する場合は上書きします
+func upsertFundPriceHistory(tx *gorm.DB, code string, date time.Time, price float64) error {
+    history := model.FundPriceHistory{
+        Code: code,
+        Date: historyDate(date),
+        Price: price,
+    }
+    return tx.Clauses(clause.OnConflict{
+        Columns:   []clause.Column{{Name: "code"}, {Name: "date"}},
+        DoUpdates: clause.AssignmentColumns([]string{"price", "updated_at"}),
+    }).Create(&history).Error
+}
+
 // 日本投資信託情報を更新します
 func (r *DefaultFundPriceRepository) UpdateFundPrice(ctx context.Context, dto UpdateFundPriceDto) (*model.FundPrice, error) {
     // 更新用のマップを作成します
@@ -62,14 +119,23 @@ func (r *DefaultFundPriceRepository) UpdateFundPrice(ctx context.Context, dto Up
 
 	newFund["price"] = dto.Price
 
-    // 指定されたIDの株式情報を更新します
-    if err := r.DB.Model(&model.FundPrice{}).Where("id = ?", dto.ID).Updates(newFund).Error; err != nil {
-        return nil, err
-    }
-
-    // 更新された情報を取得します
+    // 指定されたIDの株式情報を更新し、当日の価格として履歴に記録します
     var FundPrice model.FundPrice
-    if err := selectBaseQuery(r.DB).Where("id = ?", dto.ID).Find(&FundPrice).Error; err != nil {
+    err := r.DB.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Model(&model.FundPrice{}).Where("id = ?", dto.ID).Updates(newFund).Error; err != nil {
+            return err
+        }
+
+        // 更新された情報を取得します
+        if err := selectBaseQuery(tx).Where("id = ?", dto.ID).Find(&FundPrice).Error; err != nil {
+            return err
+        }
+        if FundPrice.ID == 0 {
+            return nil
+        }
+        return upsertFundPriceHistory(tx, FundPrice.Code, time.Now(), FundPrice.Price)
+    })
+    if err != nil {
         return nil, err
     }
 
@@ -91,9 +157,106 @@ func (r *DefaultFundPriceRepository) CreateFundPrice(ctx context.Context, dto Cr
         Price: dto.Price,
     }
 
-    if err := r.DB.Create(&fundPrice).Error; err != nil {
+    err := r.DB.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Create(&fundPrice).Error; err != nil {
+            return err
+        }
+        return upsertFundPriceHistory(tx, fundPrice.Code, time.Now(), fundPrice.Price)
+    })
+    if err != nil {
         return nil, err
     }
 
     return fundPrice, nil
-}
\ No newline at end of file
+}
+
+// FetchHeldFundCodes はいずれかのユーザーが保有している日本投資信託のファンドコードを重複なく取得します
+func (r *DefaultFundPriceRepository) FetchHeldFundCodes(ctx context.Context) ([]string, error) {
+    var codes []string
+    if err := r.DB.Model(&model.JapanFund{}).Distinct().Order("code").Pluck("code", &codes).Error; err != nil {
+        return nil, err
+    }
+    return codes, nil
+}
+
+// UpsertFundNav は自動取得した基準価額で投資信託の価格を更新します(未登録の場合は作成します)
+// 取得に成功したため、直近の取得失敗の記録は解除します
+func (r *DefaultFundPriceRepository) UpsertFundNav(ctx context.Context, dto UpsertFundNavDto) (*model.FundPrice, error) {
+    var fundPrices []model.FundPrice
+    if err := selectBaseQuery(r.DB).Where("code = ?", dto.Code).Limit(1).Find(&fundPrices).Error; err != nil {
+        return nil, err
+    }
+
+    // 基準価額は基準日の価格として履歴に記録します
+    var fundPrice model.FundPrice
+    err := r.DB.Transaction(func(tx *gorm.DB) error {
+        if err := upsertFundPriceHistory(tx, dto.Code, dto.NavDate, dto.Price); err != nil {
+            return err
+        }
+        // 価格が未登録の間に記録した取得失敗は解除する
+        if err := tx.Unscoped().Where("code = ?", dto.Code).Delete(&model.FundNavFailure{}).Error; err != nil {
+            return err
+        }
+
+        if len(fundPrices) == 0 {
+            fundPrice = model.FundPrice{
+                Name: dto.Name,
+                Code: dto.Code,
+                Price: dto.Price,
+                NavDate: &dto.NavDate,
+                NavFetchedAt: &dto.FetchedAt,
+            }
+            return tx.Create(&fundPrice).Error
+        }
+
+        newFund := map[string]interface{}{
+            "price": dto.Price,
+            "nav_date": dto.NavDate,
+            "nav_fetched_at": dto.FetchedAt,
+            "nav_fetch_error": "",
+            "nav_fetch_failed_at": nil,
+        }
+        if err := tx.Model(&model.FundPrice{}).Where("id = ?", fundPrices[0].ID).Updates(newFund).Error; err != nil {
+            return err
+        }
+        return selectBaseQuery(tx).Where("id = ?", fundPrices[0].ID).Find(&fundPrice).Error
+    })
+    if err != nil {
+        return nil, err
+    }
+    return &fundPrice, nil
+}
+
+// RecordFundNavFailure は基準価額の自動取得に失敗したことを記録します(価格は前回の値のまま)
+// 価格が未登録の投資信託は価格を作成せず、取得失敗のみを記録します
+func (r *DefaultFundPriceRepository) RecordFundNavFailure(ctx context.Context, code string, reason string, failedAt time.Time) error {
+    // 保存できる長さに切り詰める
+    if runes := []rune(reason); len(runes) > 255 {
+        reason = string(runes[:255])
+    }
+    result := r.DB.Model(&model.FundPrice{}).Where("code = ?", code).Updates(map[string]interface{}{
+        "nav_fetch_error": reason,
+        "nav_fetch_failed_at": failedAt,
+    })
+    if result.Error != nil {
+        return result.Error
+    }
+    if result.RowsAffected > 0 {
+        return nil
+    }
+    failure := model.FundNavFailure{Code: code, Reason: reason, FailedAt: failedAt}
+    return r.DB.Clauses(clause.OnConflict{
+        Columns:   []clause.Column{{Name: "code"}},
+        DoUpdates: clause.AssignmentColumns([]string{"reason", "failed_at", "updated_at"}),
+    }).Create(&failure).Error
+}
+
+// FetchFundNavFailureList は価格が未登録の投資信託の基準価額の取得失敗をファンドコード順に取得します
+// 取得失敗の後に価格が登録された投資信託は価格に記録するため除きます
+func (r *DefaultFundPriceRepository) FetchFundNavFailureList(ctx context.Context) ([]model.FundNavFailure, error) {
+    var failures []model.FundNavFailure
+    if err := r.DB.Where("code NOT IN (?)", r.DB.Model(&model.FundPrice{}).Select("code")).Order("code").Find(&failures).Error; err != nil {
+        return nil, err
+    }
+    return failures, nil
+}
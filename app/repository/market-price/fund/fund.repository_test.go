@@ -4,6 +4,7 @@ import (
 	"context"
 	"my-us-stock-backend/app/database/model"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/sqlite"
@@ -16,7 +17,7 @@ func setupTestDB() *gorm.DB {
     if err != nil {
         panic("failed to connect database")
     }
-    db.AutoMigrate(&model.FundPrice{})
+    db.AutoMigrate(&model.FundPrice{}, &model.FundPriceHistory{}, &model.FundNavFailure{}, &model.JapanFund{})
     return db
 }
 
@@ -104,3 +105,121 @@ func TestCreateFundPriceAlreadyExists(t *testing.T) {
     assert.Error(t, err)
     assert.Contains(t, err.Error(), "この銘柄は既に登録されています")
 }
+
+// 保有されている日本投資信託のファンドコードを重複なく取得する
+func TestFetchHeldFundCodes(t *testing.T) {
+    db := setupTestDB()
+    repo := NewFetchFundRepository(db)
+
+    db.Create(&model.JapanFund{Name: "Held Fund", Code: "HF001", UserId: 1})
+    db.Create(&model.JapanFund{Name: "Held Fund", Code: "HF001", UserId: 2})
+    deleted := model.JapanFund{Name: "Deleted Fund", Code: "HF002", UserId: 1}
+    db.Create(&deleted)
+    db.Delete(&deleted)
+
+    codes, err := repo.FetchHeldFundCodes(context.Background())
+    assert.NoError(t, err)
+    assert.Contains(t, codes, "HF001")
+    assert.NotContains(t, codes, "HF002")
+    count := 0
+    for _, code := range codes {
+        if code == "HF001" {
+            count++
+        }
+    }
+    assert.Equal(t, 1, count)
+}
+
+// 基準価額の自動取得に失敗した場合は記録し、次に成功した場合は価格を更新して記録を解除する
+func TestUpsertFundNav(t *testing.T) {
+    db := setupTestDB()
+    repo := NewFetchFundRepository(db)
+    navDate := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+    fetchedAt := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+
+    // 未登録の場合は作成する
+    created, err := repo.UpsertFundNav(context.Background(), UpsertFundNavDto{Name: "Nav Fund", Code: "NAV001", Price: 20000, NavDate: navDate, FetchedAt: fetchedAt})
+    assert.NoError(t, err)
+    assert.Equal(t, 20000.0, created.Price)
+
+    err = repo.RecordFundNavFailure(context.Background(), "NAV001", "unexpected status code: 404", fetchedAt.Add(time.Hour))
+    assert.NoError(t, err)
+    failed, err := repo.FindFundPriceByCode(context.Background(), "NAV001")
+    assert.NoError(t, err)
+    assert.Equal(t, "unexpected status code: 404", failed.NavFetchError)
+    assert.NotNil(t, failed.NavFetchFailedAt)
+    assert.Equal(t, 20000.0, failed.Price)
+
+    // 登録済みの場合は価格を更新する
+    updated, err := repo.UpsertFundNav(context.Background(), UpsertFundNavDto{Name: "Nav Fund", Code: "NAV001", Price: 20100, NavDate: navDate.AddDate(0, 0, 3), FetchedAt: fetchedAt.AddDate(0, 0, 3)})
+    assert.NoError(t, err)
+    assert.Equal(t, created.ID, updated.ID)
+    assert.Equal(t, 20100.0, updated.Price)
+    assert.Equal(t, "", updated.NavFetchError)
+    assert.Nil(t, updated.NavFetchFailedAt)
+    assert.True(t, updated.NavDate.Equal(navDate.AddDate(0, 0, 3)))
+}
+
+// 価格が未登録の投資信託は価格を作成せずに取得失敗のみ記録し、取得に成功すると解除する
+func TestRecordFundNavFailureUnregistered(t *testing.T) {
+    db := setupTestDB()
+    repo := NewFetchFundRepository(db)
+    failedAt := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+    err := repo.RecordFundNavFailure(context.Background(), "UNREG01", "unexpected status code: 404", failedAt)
+    assert.NoError(t, err)
+    // 再度失敗した場合は理由・日時を更新する
+    err = repo.RecordFundNavFailure(context.Background(), "UNREG01", "unexpected status code: 500", failedAt.Add(time.Hour))
+    assert.NoError(t, err)
+
+    var count int64
+    db.Model(&model.FundPrice{}).Where("code = ?", "UNREG01").Count(&count)
+    assert.Equal(t, int64(0), count)
+    failures, err := repo.FetchFundNavFailureList(context.Background())
+    assert.NoError(t, err)
+    assert.Len(t, failures, 1)
+    assert.Equal(t, "unexpected status code: 500", failures[0].Reason)
+    assert.True(t, failures[0].FailedAt.Equal(failedAt.Add(time.Hour)))
+
+    _, err = repo.UpsertFundNav(context.Background(), UpsertFundNavDto{Name: "Unregistered Fund", Code: "UNREG01", Price: 10000, NavDate: failedAt, FetchedAt: failedAt.Add(2 * time.Hour)})
+    assert.NoError(t, err)
+    failures, err = repo.FetchFundNavFailureList(context.Background())
+    assert.NoError(t, err)
+    assert.Len(t, failures, 0)
+}
+
+// 価格の登録・更新のたびに日次価格の履歴を記録し、指定した時点の価格を取得できる
+func TestFundPriceHistory(t *testing.T) {
+    db := setupTestDB()
+    repo := NewFetchFundRepository(db)
+    ctx := context.Background()
+
+    created, err := repo.CreateFundPrice(ctx, CreateFundPriceDto{Name: "History Fund", Code: "HIST001", Price: 10000})
+    assert.NoError(t, err)
+    // 同じ日に更新した場合は当日の履歴を上書きする
+    _, err = repo.UpdateFundPrice(ctx, UpdateFundPriceDto{ID: created.ID, Price: 10100})
+    assert.NoError(t, err)
+    _, err = repo.UpsertFundNav(ctx, UpsertFundNavDto{Name: "History Fund", Code: "HIST001", Price: 9800, NavDate: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), FetchedAt: time.Now()})
+    assert.NoError(t, err)
+    _, err = repo.UpsertFundNav(ctx, UpsertFundNavDto{Name: "History Fund", Code: "HIST001", Price: 9900, NavDate: time.Date(2026, 1, 7, 0, 0, 0, 0, time.UTC), FetchedAt: time.Now()})
+    assert.NoError(t, err)
+
+    today := time.Now()
+    histories, err := repo.FetchFundPriceHistoryList(ctx, "HIST001", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), today)
+    assert.NoError(t, err)
+    assert.Len(t, histories, 3)
+    assert.Equal(t, 9800.0, histories[0].Price)
+    assert.Equal(t, 9900.0, histories[1].Price)
+    assert.Equal(t, 10100.0, histories[2].Price)
+
+    // 指定した日付に履歴がない場合は直前の価格
+    asOf, err := repo.FindFundPriceByCodeAsOf(ctx, "HIST001", time.Date(2026, 1, 6, 12, 0, 0, 0, time.UTC))
+    assert.NoError(t, err)
+    assert.Equal(t, 9800.0, asOf.Price)
+    assert.True(t, asOf.NavDate.Equal(time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)))
+    assert.Equal(t, "History Fund", asOf.Name)
+
+    // 履歴より前の日付は見つからない
+    _, err = repo.FindFundPriceByCodeAsOf(ctx, "HIST001", time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC))
+    assert.Equal(t, gorm.ErrRecordNotFound, err)
+}
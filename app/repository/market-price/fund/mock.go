@@ -3,6 +3,7 @@ package fund
 import (
 	"context"
 	"my-us-stock-backend/app/database/model"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -35,4 +36,36 @@ func (m *MockFundPriceRepository) UpdateFundPrice(ctx context.Context, dto Updat
 func (m *MockFundPriceRepository) CreateFundPrice(ctx context.Context, dto CreateFundPriceDto) (*model.FundPrice, error) {
 	args := m.Called(ctx, dto)
 	return args.Get(0).(*model.FundPrice), args.Error(1)
-}
\ No newline at end of file
+}
+func (m *MockFundPriceRepository) FetchHeldFundCodes(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockFundPriceRepository) UpsertFundNav(ctx context.Context, dto UpsertFundNavDto) (*model.FundPrice, error) {
+	args := m.Called(ctx, dto)
+	return args.Get(0).(*model.FundPrice), args.Error(1)
+}
+
+func (m *MockFundPriceRepository) RecordFundNavFailure(ctx context.Context, code string, reason string, failedAt time.Time) error {
+	args := m.Called(ctx, code, reason, failedAt)
+	return args.Error(0)
+}
+
+func (m *MockFundPriceRepository) FetchFundNavFailureList(ctx context.Context) ([]model.FundNavFailure, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]model.FundNavFailure), args.Error(1)
+}
+
+func (m *MockFundPriceRepository) FindFundPriceByCodeAsOf(ctx context.Context, code string, asOf time.Time) (*model.FundPrice, error) {
+	args := m.Called(ctx, code, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.FundPrice), args.Error(1)
+}
+
+func (m *MockFundPriceRepository) FetchFundPriceHistoryList(ctx context.Context, code string, from time.Time, to time.Time) ([]model.FundPriceHistory, error) {
+	args := m.Called(ctx, code, from, to)
+	return args.Get(0).([]model.FundPriceHistory), args.Error(1)
+}
@@ -0,0 +1,11 @@
+package fund
+
+import "time"
+
+type UpsertFundNavDto struct {
+    Name      string
+    Code      string
+    Price     float64
+    NavDate   time.Time
+    FetchedAt time.Time
+}
@@ -0,0 +1,13 @@
+package marketprice
+
+import "time"
+
+type HistoricalPriceDto struct {
+    Ticker string    `json:"ticker"`
+    Date   time.Time `json:"date"`
+    Open   float64   `json:"open"`
+    High   float64   `json:"high"`
+    Low    float64   `json:"low"`
+    Close  float64   `json:"close"`
+    Volume float64   `json:"volume"`
+}
@@ -0,0 +1,18 @@
+package marketprice
+
+// HistoricalPrice は日次価格(四本値)の履歴を表します。
+type HistoricalPrice struct {
+	Date     string  `json:"date"`
+	Open     float64 `json:"open"`     // 始値
+	High     float64 `json:"high"`     // 高値
+	Low      float64 `json:"low"`      // 安値
+	Close    float64 `json:"close"`    // 終値
+	AdjClose float64 `json:"adjClose"`
+	Volume   float64 `json:"volume"`   // 出来高
+}
+
+// HistoricalPriceResponse はAPIからの価格履歴レスポンスを表します。
+type HistoricalPriceResponse struct {
+	Symbol     string            `json:"symbol"`
+	Historical []HistoricalPrice `json:"historical"`
+}
@@ -0,0 +1,67 @@
+package instrument
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+
+	"gorm.io/gorm"
+)
+
+// InstrumentRepository インターフェースの定義
+type InstrumentRepository interface {
+    FindInstrumentByTicker(ctx context.Context, ticker string) (*model.Instrument, error)
+    FetchInstrumentListByTickers(ctx context.Context, tickers []string) ([]model.Instrument, error)
+    CreateInstrument(ctx context.Context, dto CreateInstrumentDto) (*model.Instrument, error)
+}
+
+// DefaultInstrumentRepository 構造体の定義
+type DefaultInstrumentRepository struct {
+    DB *gorm.DB
+}
+
+// 共通フィールドを選択するためのヘルパー関数です。
+func selectBaseQuery(db *gorm.DB) *gorm.DB {
+    return db.Select("id", "ticker", "name", "sector", "industry", "country", "exchange")
+}
+
+// NewInstrumentRepository は DefaultInstrumentRepository の新しいインスタンスを作成します
+func NewInstrumentRepository(db *gorm.DB) InstrumentRepository {
+    return &DefaultInstrumentRepository{DB: db}
+}
+
+// FindInstrumentByTicker は指定されたティッカーの企業情報を取得します
+func (r *DefaultInstrumentRepository) FindInstrumentByTicker(ctx context.Context, ticker string) (*model.Instrument, error) {
+    var instrument model.Instrument
+    if err := selectBaseQuery(r.DB).Where("ticker = ?", ticker).First(&instrument).Error; err != nil {
+        return nil, err
+    }
+    return &instrument, nil
+}
+
+// FetchInstrumentListByTickers は指定されたティッカーのうち、登録済みの企業情報のリストを取得します
+func (r *DefaultInstrumentRepository) FetchInstrumentListByTickers(ctx context.Context, tickers []string) ([]model.Instrument, error) {
+    var instruments []model.Instrument
+    err := selectBaseQuery(r.DB).Where("ticker IN ?", tickers).Find(&instruments).Error
+    if err != nil {
+        return nil, err
+    }
+    return instruments, nil
+}
+
+// CreateInstrument は企業情報を登録します。既に登録されている場合は最新の内容で更新します
+func (r *DefaultInstrumentRepository) CreateInstrument(ctx context.Context, dto CreateInstrumentDto) (*model.Instrument, error) {
+    var instrument model.Instrument
+    if err := r.DB.Where("ticker = ?", dto.Ticker).First(&instrument).Error; err != nil && err != gorm.ErrRecordNotFound {
+        return nil, err
+    }
+    instrument.Ticker = dto.Ticker
+    instrument.Name = dto.Name
+    instrument.Sector = dto.Sector
+    instrument.Industry = dto.Industry
+    instrument.Country = dto.Country
+    instrument.Exchange = dto.Exchange
+    if err := r.DB.Save(&instrument).Error; err != nil {
+        return nil, err
+    }
+    return &instrument, nil
+}
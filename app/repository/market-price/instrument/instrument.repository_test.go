@@ -0,0 +1,76 @@
+package instrument
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// テスト用のデータベース設定
+func setupTestDB() *gorm.DB {
+    db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+    if err != nil {
+        panic("failed to connect database")
+    }
+    // テスト用のテーブルを準備
+    db.AutoMigrate(&model.Instrument{})
+    return db
+}
+
+func TestFindInstrumentByTicker(t *testing.T) {
+    db := setupTestDB()
+    repo := NewInstrumentRepository(db)
+
+    // テスト用データを作成
+    db.Create(&model.Instrument{Ticker: "AAPL", Name: "Apple Inc.", Sector: "Technology"})
+
+    instrument, err := repo.FindInstrumentByTicker(context.Background(), "AAPL")
+    assert.NoError(t, err)
+    assert.Equal(t, "Apple Inc.", instrument.Name)
+    assert.Equal(t, "Technology", instrument.Sector)
+
+    // 登録されていないティッカーの場合はエラー
+    _, err = repo.FindInstrumentByTicker(context.Background(), "KO")
+    assert.Equal(t, gorm.ErrRecordNotFound, err)
+}
+
+func TestFetchInstrumentListByTickers(t *testing.T) {
+    db := setupTestDB()
+    repo := NewInstrumentRepository(db)
+
+    // テスト用データを作成
+    db.Create(&model.Instrument{Ticker: "AAPL", Name: "Apple Inc."})
+    db.Create(&model.Instrument{Ticker: "KO", Name: "The Coca-Cola Company"})
+
+    instruments, err := repo.FetchInstrumentListByTickers(context.Background(), []string{"AAPL", "MSFT"})
+    assert.NoError(t, err)
+    assert.Len(t, instruments, 1)
+    assert.Equal(t, "AAPL", instruments[0].Ticker)
+}
+
+// 既に登録されている場合は上書きされる
+func TestCreateInstrument(t *testing.T) {
+    db := setupTestDB()
+    repo := NewInstrumentRepository(db)
+
+    createDto := CreateInstrumentDto{Ticker: "AAPL", Name: "Apple", Sector: "IT"}
+    created, err := repo.CreateInstrument(context.Background(), createDto)
+    assert.NoError(t, err)
+    assert.Equal(t, "Apple", created.Name)
+
+    updateDto := CreateInstrumentDto{Ticker: "AAPL", Name: "Apple Inc.", Sector: "Technology", Exchange: "NASDAQ"}
+    updated, err := repo.CreateInstrument(context.Background(), updateDto)
+    assert.NoError(t, err)
+    assert.Equal(t, created.ID, updated.ID)
+
+    var count int64
+    db.Model(&model.Instrument{}).Count(&count)
+    assert.Equal(t, int64(1), count)
+    found, _ := repo.FindInstrumentByTicker(context.Background(), "AAPL")
+    assert.Equal(t, "Technology", found.Sector)
+    assert.Equal(t, "NASDAQ", found.Exchange)
+}
@@ -0,0 +1,33 @@
+package instrument
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockInstrumentRepository は InstrumentRepository のモックです。
+type MockInstrumentRepository struct {
+	mock.Mock
+}
+
+// NewMockInstrumentRepository は新しい MockInstrumentRepository を作成し、初期設定を行います。
+func NewMockInstrumentRepository() *MockInstrumentRepository {
+	return &MockInstrumentRepository{}
+}
+
+func (m *MockInstrumentRepository) FindInstrumentByTicker(ctx context.Context, ticker string) (*model.Instrument, error) {
+	args := m.Called(ctx, ticker)
+	return args.Get(0).(*model.Instrument), args.Error(1)
+}
+
+func (m *MockInstrumentRepository) FetchInstrumentListByTickers(ctx context.Context, tickers []string) ([]model.Instrument, error) {
+	args := m.Called(ctx, tickers)
+	return args.Get(0).([]model.Instrument), args.Error(1)
+}
+
+func (m *MockInstrumentRepository) CreateInstrument(ctx context.Context, dto CreateInstrumentDto) (*model.Instrument, error) {
+	args := m.Called(ctx, dto)
+	return args.Get(0).(*model.Instrument), args.Error(1)
+}
@@ -5,4 +5,15 @@ type MarketPriceDto struct {
     CurrentPrice  float64 `json:"currentPrice"`
     PriceGets  float64 `json:"priceGets"`
     CurrentRate float64 `json:"currentRate"`
+    DayLow        float64 `json:"dayLow"`
+    DayHigh       float64 `json:"dayHigh"`
+    YearHigh      float64 `json:"yearHigh"`
+    YearLow       float64 `json:"yearLow"`
+    MarketCap     float64 `json:"marketCap"`
+    Pe            float64 `json:"pe"`
+    Eps           float64 `json:"eps"`
+    Volume        float64 `json:"volume"`
+    PriceAvg50    float64 `json:"priceAvg50"`
+    PriceAvg200   float64 `json:"priceAvg200"`
+    EarningsAnnouncement string `json:"earningsAnnouncement"` // 決算発表日時(未定の場合は空文字)
 }
\ No newline at end of file
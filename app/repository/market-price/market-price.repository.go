@@ -3,19 +3,27 @@ package marketprice
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 	"time"
 )
 
+// ErrCompanyProfileNotFound は指定された銘柄の企業情報が存在しない場合のエラーです。
+var ErrCompanyProfileNotFound = errors.New("the specified ticker was not found")
+
 // MarketPriceRepository は仮想通貨の価格を取得するためのインターフェースです。
 type MarketPriceRepository interface {
 	FetchMarketPriceList(ctx context.Context, tickers []string) ([]MarketPriceDto, error)
     FetchDividend(ctx context.Context, ticker string) (*DividendEntity, error)
+    FetchCompanyProfile(ctx context.Context, ticker string) (*CompanyProfileDto, error)
+    FetchHistoricalPrices(ctx context.Context, ticker string, from time.Time, to time.Time) ([]HistoricalPriceDto, error)
+    FetchDividendHistory(ctx context.Context, ticker string) ([]DividendHistoryDto, error)
 }
 
 // DefaultMarketPriceRepository は CryptoRepository のデフォルト実装です。
@@ -84,6 +92,17 @@ func (repo *DefaultMarketPriceRepository) FetchMarketPriceList(ctx context.Conte
             CurrentPrice: price.Price,
             PriceGets:    price.Change,
             CurrentRate:  price.ChangesPercentage,
+            DayLow:       price.DayLow,
+            DayHigh:      price.DayHigh,
+            YearHigh:     price.YearHigh,
+            YearLow:      price.YearLow,
+            MarketCap:    price.MarketCap,
+            Pe:           price.PE,
+            Eps:          price.EPS,
+            Volume:       price.Volume,
+            PriceAvg50:   price.PriceAvg50,
+            PriceAvg200:  price.PriceAvg200,
+            EarningsAnnouncement: price.EarningsAnnouncement,
         })
     }
 
@@ -92,6 +111,38 @@ func (repo *DefaultMarketPriceRepository) FetchMarketPriceList(ctx context.Conte
 
 
 func (repo *DefaultMarketPriceRepository) FetchDividend(ctx context.Context, ticker string) (*DividendEntity, error) {
+    res, err := repo.fetchDividendResponse(ctx, ticker)
+    if err != nil {
+        return nil, err
+    }
+    return repo.createDividendEntity(res), nil
+}
+
+// FetchDividendHistory は指定された銘柄の全ての配当履歴を権利落日の新しい順で取得します。
+func (repo *DefaultMarketPriceRepository) FetchDividendHistory(ctx context.Context, ticker string) ([]DividendHistoryDto, error) {
+    res, err := repo.fetchDividendResponse(ctx, ticker)
+    if err != nil {
+        return nil, err
+    }
+    histories := make([]DividendHistoryDto, 0, len(res.Historical))
+    for _, historical := range res.Historical {
+        histories = append(histories, DividendHistoryDto{
+            ExDate:          historical.Date,
+            RecordDate:      historical.RecordDate,
+            PaymentDate:     historical.PaymentDate,
+            DeclarationDate: historical.DeclarationDate,
+            Amount:          historical.Dividend,
+            AdjustedAmount:  historical.AdjDividend,
+        })
+    }
+    sort.SliceStable(histories, func(i, j int) bool {
+        return histories[i].ExDate > histories[j].ExDate
+    })
+    return histories, nil
+}
+
+// fetchDividendResponse は配当APIを呼び出します(レート制限時は別のトークンで再試行します)。
+func (repo *DefaultMarketPriceRepository) fetchDividendResponse(ctx context.Context, ticker string) (*DividendResponse, error) {
     token := repo.dividendMainToken
     res, err := repo.fetchDividendApi(ctx, token, ticker)
     if err != nil {
@@ -107,7 +158,7 @@ func (repo *DefaultMarketPriceRepository) FetchDividend(ctx context.Context, tic
             return nil, fmt.Errorf("配当情報の取得に失敗しました: %w", err)
         }
     }
-    return repo.createDividendEntity(res), nil
+    return res, nil
 }
 
 // FetchDividend は指定された銘柄の配当情報を取得します。
@@ -135,6 +186,100 @@ func (repo *DefaultMarketPriceRepository) fetchDividendApi(ctx context.Context,
     return &dividendResponse, nil
 }
 
+// FetchCompanyProfile は指定された銘柄の企業情報(会社名・セクターなど)を取得します。
+func (repo *DefaultMarketPriceRepository) FetchCompanyProfile(ctx context.Context, ticker string) (*CompanyProfileDto, error) {
+    url := fmt.Sprintf("%s/v3/profile/%s?apikey=%s", repo.baseURL, ticker, repo.tickerToken)
+
+    req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+    if err != nil {
+        return nil, err
+    }
+
+    resp, err := repo.httpClient.Do(req)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode != http.StatusOK {
+        return nil, fmt.Errorf("error fetching company profile: status %d", resp.StatusCode)
+    }
+
+    var profiles []CompanyProfileResponse
+    err = json.NewDecoder(resp.Body).Decode(&profiles)
+    if err != nil {
+        return nil, err
+    }
+
+    // 企業情報が存在するかチェック
+    if len(profiles) == 0 {
+        return nil, ErrCompanyProfileNotFound
+    }
+
+    profile := profiles[0]
+    // 上場市場は略称を優先する
+    exchange := profile.ExchangeShortName
+    if exchange == "" {
+        exchange = profile.Exchange
+    }
+    return &CompanyProfileDto{
+        Ticker:   profile.Symbol,
+        Name:     profile.CompanyName,
+        Sector:   profile.Sector,
+        Industry: profile.Industry,
+        Country:  profile.Country,
+        Exchange: exchange,
+    }, nil
+}
+
+// FetchHistoricalPrices は指定された銘柄の期間内の日次価格を日付の昇順で取得します。
+func (repo *DefaultMarketPriceRepository) FetchHistoricalPrices(ctx context.Context, ticker string, from time.Time, to time.Time) ([]HistoricalPriceDto, error) {
+    url := fmt.Sprintf("%s/v3/historical-price-full/%s?from=%s&to=%s&apikey=%s", repo.baseURL, ticker, from.Format("2006-01-02"), to.Format("2006-01-02"), repo.tickerToken)
+
+    req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+    if err != nil {
+        return nil, err
+    }
+
+    resp, err := repo.httpClient.Do(req)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode != http.StatusOK {
+        return nil, fmt.Errorf("error fetching historical prices: status %d", resp.StatusCode)
+    }
+
+    var historicalPriceResponse HistoricalPriceResponse
+    err = json.NewDecoder(resp.Body).Decode(&historicalPriceResponse)
+    if err != nil {
+        return nil, err
+    }
+
+    // HistoricalPriceからHistoricalPriceDtoに変換
+    priceDtos := make([]HistoricalPriceDto, 0, len(historicalPriceResponse.Historical))
+    for _, price := range historicalPriceResponse.Historical {
+        date, err := time.Parse("2006-01-02", price.Date)
+        if err != nil {
+            return nil, err
+        }
+        priceDtos = append(priceDtos, HistoricalPriceDto{
+            Ticker: ticker,
+            Date:   date,
+            Open:   price.Open,
+            High:   price.High,
+            Low:    price.Low,
+            Close:  price.Close,
+            Volume: price.Volume,
+        })
+    }
+
+    // APIは日付の降順で返却するため昇順に並び替える
+    sort.Slice(priceDtos, func(i, j int) bool {
+        return priceDtos[i].Date.Before(priceDtos[j].Date)
+    })
+    return priceDtos, nil
+}
+
 // parseMonth は日付文字列から月を解析します。
 func parseMonth(dateStr string) int {
     date, _ := time.Parse("2006-01-02", dateStr)
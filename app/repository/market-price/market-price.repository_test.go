@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -71,6 +72,77 @@ func TestFetchMarketPriceList(t *testing.T) {
 	assert.Equal(t, 0.13, prices[0].PriceGets)
 }
 
+// 株価指標(高値・安値、PER、EPSなど)も取得される
+func TestFetchMarketPriceList_QuoteDetail(t *testing.T) {
+	// モックの HTTP レスポンスを設定
+	mockResponseBody := `[
+		{
+			"symbol": "AAPL",
+			"name": "Apple Inc.",
+			"price": 192.53,
+			"changesPercentage": -0.5424,
+			"change": -1.05,
+			"dayLow": 191.725,
+			"dayHigh": 194.39,
+			"yearHigh": 199.62,
+			"yearLow": 124.17,
+			"marketCap": 2994380584000,
+			"priceAvg50": 186.3,
+			"priceAvg200": 179.2902,
+			"exchange": "NASDAQ",
+			"volume": 41936044,
+			"avgVolume": 53103488,
+			"open": 193.9,
+			"previousClose": 193.58,
+			"eps": 6.12,
+			"pe": 31.46,
+			"earningsAnnouncement": "2024-01-31T10:59:00.000+0000",
+			"sharesOutstanding": 15552800000,
+			"timestamp": 1703883601
+		},
+		{
+			"symbol": "VTI",
+			"price": 238.55,
+			"earningsAnnouncement": null
+		}
+	]`
+
+	// モックの HTTP クライアントを設定
+	mockTransport := &MockHTTPTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			r := io.NopCloser(bytes.NewReader([]byte(mockResponseBody)))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       r,
+			}, nil
+		},
+	}
+	mockHTTPClient := &http.Client{Transport: mockTransport}
+
+	// リポジトリを初期化
+	repo := NewMarketPriceRepository(mockHTTPClient)
+
+	// テストの実行
+	prices, err := repo.FetchMarketPriceList(context.Background(), []string{"AAPL", "VTI"})
+
+	// アサーション
+	assert.NoError(t, err)
+	assert.Len(t, prices, 2)
+	assert.Equal(t, 191.725, prices[0].DayLow)
+	assert.Equal(t, 194.39, prices[0].DayHigh)
+	assert.Equal(t, 199.62, prices[0].YearHigh)
+	assert.Equal(t, 124.17, prices[0].YearLow)
+	assert.Equal(t, 2994380584000.0, prices[0].MarketCap)
+	assert.Equal(t, 31.46, prices[0].Pe)
+	assert.Equal(t, 6.12, prices[0].Eps)
+	assert.Equal(t, 41936044.0, prices[0].Volume)
+	assert.Equal(t, 186.3, prices[0].PriceAvg50)
+	assert.Equal(t, 179.2902, prices[0].PriceAvg200)
+	assert.Equal(t, "2024-01-31T10:59:00.000+0000", prices[0].EarningsAnnouncement)
+	// 決算発表日が未定の場合は空文字
+	assert.Equal(t, "", prices[1].EarningsAnnouncement)
+}
+
 func TestFetchMarketPriceList_NoData(t *testing.T) {
 	// モックの HTTP レスポンスを設定（空のリスト）
 	mockResponseBody := `[]`
@@ -387,4 +459,166 @@ func TestFetchDividend_No_Dividend(t *testing.T) {
     assert.Equal(t, []int(nil), dividend.DividendMonth)
     assert.Equal(t, 0, dividend.DividendTime)
     assert.Equal(t, 0.0, dividend.DividendTotal)
-}
\ No newline at end of file
+}
+
+// 指定したティッカーの企業情報を取得する
+func TestFetchCompanyProfile(t *testing.T) {
+	// モックの HTTP レスポンスを設定
+	mockResponseBody := `[
+		{
+			"symbol": "AAPL",
+			"companyName": "Apple Inc.",
+			"sector": "Technology",
+			"industry": "Consumer Electronics",
+			"country": "US",
+			"exchange": "NASDAQ Global Select",
+			"exchangeShortName": "NASDAQ"
+		}
+	]`
+
+	// モックの HTTP クライアントを設定
+	mockTransport := &MockHTTPTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			r := io.NopCloser(bytes.NewReader([]byte(mockResponseBody)))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       r,
+			}, nil
+		},
+	}
+	mockHTTPClient := &http.Client{Transport: mockTransport}
+
+	// リポジトリを初期化
+	repo := NewMarketPriceRepository(mockHTTPClient)
+
+	// テストの実行
+	profile, err := repo.FetchCompanyProfile(context.Background(), "AAPL")
+
+	// アサーション
+	assert.NoError(t, err)
+	assert.Equal(t, "AAPL", profile.Ticker)
+	assert.Equal(t, "Apple Inc.", profile.Name)
+	assert.Equal(t, "Technology", profile.Sector)
+	assert.Equal(t, "Consumer Electronics", profile.Industry)
+	assert.Equal(t, "US", profile.Country)
+	assert.Equal(t, "NASDAQ", profile.Exchange)
+}
+
+// 企業情報が存在しない場合、エラーが返却される
+func TestFetchCompanyProfile_NoData(t *testing.T) {
+	// モックの HTTP レスポンスを設定（空のリスト）
+	mockResponseBody := `[]`
+
+	// モックの HTTP クライアントを設定
+	mockTransport := &MockHTTPTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			r := io.NopCloser(bytes.NewReader([]byte(mockResponseBody)))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       r,
+			}, nil
+		},
+	}
+	mockHTTPClient := &http.Client{Transport: mockTransport}
+
+	// リポジトリを初期化
+	repo := NewMarketPriceRepository(mockHTTPClient)
+
+	// テストの実行
+	_, err := repo.FetchCompanyProfile(context.Background(), "AAPLKK")
+
+	// アサーション
+	assert.Equal(t, "the specified ticker was not found", err.Error())
+}
+
+// 指定した期間の日次価格を日付の昇順で取得する
+func TestFetchHistoricalPrices(t *testing.T) {
+	// モックの HTTP レスポンスを設定(APIは新しい順で返却する)
+	mockResponseBody := `{
+		"symbol": "AAPL",
+		"historical": [
+			{"date": "2024-01-03", "open": 184.22, "high": 185.88, "low": 183.43, "close": 184.25, "volume": 58414500},
+			{"date": "2024-01-02", "open": 187.15, "high": 188.44, "low": 183.89, "close": 185.64, "volume": 82488700}
+		]
+	}`
+
+	// モックの HTTP クライアントを設定
+	var requestedURL string
+	mockTransport := &MockHTTPTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			requestedURL = req.URL.String()
+			r := io.NopCloser(bytes.NewReader([]byte(mockResponseBody)))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       r,
+			}, nil
+		},
+	}
+	mockHTTPClient := &http.Client{Transport: mockTransport}
+
+	// リポジトリを初期化
+	repo := NewMarketPriceRepository(mockHTTPClient)
+
+	// テストの実行
+	from := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	prices, err := repo.FetchHistoricalPrices(context.Background(), "AAPL", from, to)
+
+	// アサーション
+	assert.NoError(t, err)
+	assert.Contains(t, requestedURL, "/v3/historical-price-full/AAPL?from=2024-01-02&to=2024-01-03")
+	assert.Len(t, prices, 2)
+	assert.Equal(t, "AAPL", prices[0].Ticker)
+	assert.Equal(t, from, prices[0].Date)
+	assert.Equal(t, 187.15, prices[0].Open)
+	assert.Equal(t, 185.64, prices[0].Close)
+	assert.Equal(t, 82488700.0, prices[0].Volume)
+	assert.Equal(t, to, prices[1].Date)
+}
+
+// 直近1年に絞らず全ての配当履歴を権利落日の新しい順で取得する
+func TestFetchDividendHistory(t *testing.T) {
+	// モックの HTTP レスポンスを設定
+	mockResponseBody := `{
+		"symbol": "KO",
+		"historical": [
+			{"date": "2013-09-11", "label": "September 11, 13", "adjDividend": 0.28, "dividend": 0.28, "recordDate": "2013-09-13", "paymentDate": "2013-10-01", "declarationDate": "2013-07-18"},
+			{"date": "2023-11-30", "label": "November 30, 23", "adjDividend": 0.46, "dividend": 0.46, "recordDate": "2023-12-01", "paymentDate": "2023-12-15", "declarationDate": "2023-10-19"},
+			{"date": "1990-03-09", "label": "March 09, 90", "adjDividend": 0.0425, "dividend": 0.17, "recordDate": "", "paymentDate": "", "declarationDate": ""}
+		]
+	}`
+
+	// モックの HTTP クライアントを設定
+	mockTransport := &MockHTTPTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			r := io.NopCloser(bytes.NewReader([]byte(mockResponseBody)))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       r,
+			}, nil
+		},
+	}
+	mockHTTPClient := &http.Client{Transport: mockTransport}
+
+	// リポジトリを初期化
+	repo := NewMarketPriceRepository(mockHTTPClient)
+
+	// テストの実行
+	histories, err := repo.FetchDividendHistory(context.Background(), "KO")
+
+	// アサーション
+	assert.NoError(t, err)
+	assert.Len(t, histories, 3)
+	assert.Equal(t, DividendHistoryDto{
+		ExDate:          "2023-11-30",
+		RecordDate:      "2023-12-01",
+		PaymentDate:     "2023-12-15",
+		DeclarationDate: "2023-10-19",
+		Amount:          0.46,
+		AdjustedAmount:  0.46,
+	}, histories[0])
+	assert.Equal(t, "2013-09-11", histories[1].ExDate)
+	assert.Equal(t, "1990-03-09", histories[2].ExDate)
+	assert.Equal(t, 0.17, histories[2].Amount)
+	assert.Equal(t, 0.0425, histories[2].AdjustedAmount)
+}
@@ -2,6 +2,7 @@ package marketprice
 
 import (
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -24,4 +25,19 @@ func (m *MockMarketPriceRepository) FetchMarketPriceList(ctx context.Context, ti
 func (m *MockMarketPriceRepository) FetchDividend(ctx context.Context, ticker string) (*DividendEntity, error) {
 	args := m.Called(ctx, ticker)
 	return args.Get(0).(*DividendEntity), args.Error(1)
-}
\ No newline at end of file
+}
+
+func (m *MockMarketPriceRepository) FetchCompanyProfile(ctx context.Context, ticker string) (*CompanyProfileDto, error) {
+	args := m.Called(ctx, ticker)
+	return args.Get(0).(*CompanyProfileDto), args.Error(1)
+}
+
+func (m *MockMarketPriceRepository) FetchHistoricalPrices(ctx context.Context, ticker string, from time.Time, to time.Time) ([]HistoricalPriceDto, error) {
+	args := m.Called(ctx, ticker, from, to)
+	return args.Get(0).([]HistoricalPriceDto), args.Error(1)
+}
+
+func (m *MockMarketPriceRepository) FetchDividendHistory(ctx context.Context, ticker string) ([]DividendHistoryDto, error) {
+	args := m.Called(ctx, ticker)
+	return args.Get(0).([]DividendHistoryDto), args.Error(1)
+}
@@ -0,0 +1,29 @@
+package pricehistory
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPriceHistoryRepository は PriceHistoryRepository のモックです。
+type MockPriceHistoryRepository struct {
+	mock.Mock
+}
+
+// NewMockPriceHistoryRepository は新しい MockPriceHistoryRepository を作成し、初期設定を行います。
+func NewMockPriceHistoryRepository() *MockPriceHistoryRepository {
+	return &MockPriceHistoryRepository{}
+}
+
+func (m *MockPriceHistoryRepository) FetchPriceHistoryList(ctx context.Context, ticker string, from time.Time, to time.Time) ([]model.PriceHistory, error) {
+	args := m.Called(ctx, ticker, from, to)
+	return args.Get(0).([]model.PriceHistory), args.Error(1)
+}
+
+func (m *MockPriceHistoryRepository) UpsertPriceHistories(ctx context.Context, dtos []CreatePriceHistoryDto) error {
+	args := m.Called(ctx, dtos)
+	return args.Error(0)
+}
@@ -0,0 +1,64 @@
+package pricehistory
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PriceHistoryRepository インターフェースの定義
+type PriceHistoryRepository interface {
+    FetchPriceHistoryList(ctx context.Context, ticker string, from time.Time, to time.Time) ([]model.PriceHistory, error)
+    UpsertPriceHistories(ctx context.Context, dtos []CreatePriceHistoryDto) error
+}
+
+// DefaultPriceHistoryRepository 構造体の定義
+type DefaultPriceHistoryRepository struct {
+    DB *gorm.DB
+}
+
+// 共通フィールドを選択するためのヘルパー関数です。
+func selectBaseQuery(db *gorm.DB) *gorm.DB {
+    return db.Select("id", "ticker", "date", "open", "high", "low", "close", "volume")
+}
+
+// NewPriceHistoryRepository は DefaultPriceHistoryRepository の新しいインスタンスを作成します
+func NewPriceHistoryRepository(db *gorm.DB) PriceHistoryRepository {
+    return &DefaultPriceHistoryRepository{DB: db}
+}
+
+// 指定した銘柄の期間内の日次価格を日付の昇順で取得する
+func (r *DefaultPriceHistoryRepository) FetchPriceHistoryList(ctx context.Context, ticker string, from time.Time, to time.Time) ([]model.PriceHistory, error) {
+    var histories []model.PriceHistory
+    err := selectBaseQuery(r.DB).Where("ticker = ? AND date >= ? AND date <= ?", ticker, from, to).Order("date asc").Find(&histories).Error
+    if err != nil {
+        return nil, err
+    }
+    return histories, nil
+}
+
+// 日次価格を登録します。同じ銘柄・日付のデータが存在する場合は上書きします
+func (r *DefaultPriceHistoryRepository) UpsertPriceHistories(ctx context.Context, dtos []CreatePriceHistoryDto) error {
+    if len(dtos) == 0 {
+        return nil
+    }
+    histories := make([]model.PriceHistory, len(dtos))
+    for i, dto := range dtos {
+        histories[i] = model.PriceHistory{
+            Ticker: dto.Ticker,
+            Date:   dto.Date,
+            Open:   dto.Open,
+            High:   dto.High,
+            Low:    dto.Low,
+            Close:  dto.Close,
+            Volume: dto.Volume,
+        }
+    }
+    return r.DB.Clauses(clause.OnConflict{
+        Columns:   []clause.Column{{Name: "ticker"}, {Name: "date"}},
+        DoUpdates: clause.AssignmentColumns([]string{"open", "high", "low", "close", "volume", "updated_at"}),
+    }).Create(&histories).Error
+}
@@ -0,0 +1,70 @@
+package pricehistory
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// テスト用のデータベース設定
+func setupTestDB() *gorm.DB {
+    db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+    if err != nil {
+        panic("failed to connect database")
+    }
+    // テスト用のテーブルを準備
+    db.AutoMigrate(&model.PriceHistory{})
+    return db
+}
+
+func date(value string) time.Time {
+    d, _ := time.Parse("2006-01-02", value)
+    return d
+}
+
+func TestFetchPriceHistoryList(t *testing.T) {
+    db := setupTestDB()
+    repo := NewPriceHistoryRepository(db)
+
+    // テスト用データを作成
+    db.Create(&model.PriceHistory{Ticker: "AAPL", Date: date("2024-01-03"), Close: 184.25})
+    db.Create(&model.PriceHistory{Ticker: "AAPL", Date: date("2024-01-02"), Close: 185.64})
+    db.Create(&model.PriceHistory{Ticker: "AAPL", Date: date("2023-12-29"), Close: 192.53})
+    db.Create(&model.PriceHistory{Ticker: "KO", Date: date("2024-01-02"), Close: 59.81})
+
+    histories, err := repo.FetchPriceHistoryList(context.Background(), "AAPL", date("2024-01-01"), date("2024-01-31"))
+    assert.NoError(t, err)
+    assert.Len(t, histories, 2)
+    // 日付の昇順で返却される
+    assert.Equal(t, 185.64, histories[0].Close)
+    assert.Equal(t, 184.25, histories[1].Close)
+}
+
+// 同じ銘柄・日付のデータは上書きされる
+func TestUpsertPriceHistories(t *testing.T) {
+    db := setupTestDB()
+    repo := NewPriceHistoryRepository(db)
+
+    err := repo.UpsertPriceHistories(context.Background(), []CreatePriceHistoryDto{
+        {Ticker: "AAPL", Date: date("2024-01-02"), Close: 185.0},
+        {Ticker: "AAPL", Date: date("2024-01-03"), Close: 184.25},
+    })
+    assert.NoError(t, err)
+
+    err = repo.UpsertPriceHistories(context.Background(), []CreatePriceHistoryDto{
+        {Ticker: "AAPL", Date: date("2024-01-02"), Close: 185.64},
+    })
+    assert.NoError(t, err)
+
+    var count int64
+    db.Model(&model.PriceHistory{}).Count(&count)
+    assert.Equal(t, int64(2), count)
+
+    histories, _ := repo.FetchPriceHistoryList(context.Background(), "AAPL", date("2024-01-01"), date("2024-01-31"))
+    assert.Equal(t, 185.64, histories[0].Close)
+}
@@ -0,0 +1,10 @@
+package nisa
+
+type CreateNisaPurchaseDto struct {
+    Frame       string  `json:"frame"`
+    HoldingType string  `json:"holdingType"`
+    Code        string  `json:"code"`
+    Amount      float64 `json:"amount"`
+    AccountId   uint    `json:"accountId"`
+    UserId      uint    `json:"userId"`
+}
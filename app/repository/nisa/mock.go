@@ -0,0 +1,25 @@
+package nisa
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockNisaRepository は NisaRepository のモックです。
+type MockNisaRepository struct {
+	mock.Mock
+}
+
+// NewMockNisaRepository は新しい MockNisaRepository を作成し、初期設定を行います。
+func NewMockNisaRepository() *MockNisaRepository {
+	return &MockNisaRepository{}
+}
+
+func (m *MockNisaRepository) FetchNisaUsage(ctx context.Context, userId uint, year int) (*NisaUsage, error) {
+	args := m.Called(ctx, userId, year)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*NisaUsage), args.Error(1)
+}
@@ -0,0 +1,263 @@
+package nisa
+
+import (
+	"context"
+	"errors"
+	"math"
+	"my-us-stock-backend/app/database/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 投資枠の種類
+const (
+    FrameGrowth    = "GROWTH"
+    FrameTsumitate = "TSUMITATE"
+)
+
+// 投資枠の上限額(円)
+const (
+    GrowthAnnualLimit    = 2400000.0
+    TsumitateAnnualLimit = 1200000.0
+    AnnualLimit          = GrowthAnnualLimit + TsumitateAnnualLimit
+    LifetimeLimit        = 18000000.0
+    GrowthLifetimeLimit  = 12000000.0
+)
+
+// 口座の種類と投資枠の対応
+var frameByAccountType = map[string]string{
+    "NISA_GROWTH":    FrameGrowth,
+    "NISA_TSUMITATE": FrameTsumitate,
+}
+
+// NisaUsage はNISA投資枠の利用額(円)を表します。
+// 年間投資枠は指定年の購入額、生涯投資枠は保有中の取得価額で計算します(売却すると生涯投資枠が空く)。
+type NisaUsage struct {
+    GrowthAnnual      float64
+    TsumitateAnnual   float64
+    GrowthLifetime    float64
+    TsumitateLifetime float64
+}
+
+// NisaRepository インターフェースの定義
+type NisaRepository interface {
+    FetchNisaUsage(ctx context.Context, userId uint, year int) (*NisaUsage, error)
+}
+
+// DefaultNisaRepository 構造体の定義
+type DefaultNisaRepository struct {
+    DB *gorm.DB
+}
+
+// NewNisaRepository は DefaultNisaRepository の新しいインスタンスを作成します
+func NewNisaRepository(db *gorm.DB) NisaRepository {
+    return &DefaultNisaRepository{DB: db}
+}
+
+// 指定したuserIdのユーザーのNISA投資枠の利用額を取得する
+func (r *DefaultNisaRepository) FetchNisaUsage(ctx context.Context, userId uint, year int) (*NisaUsage, error) {
+    return fetchUsage(r.DB, userId, year)
+}
+
+// FetchFrame は口座の投資枠を取得します(NISA口座でない場合は空文字)
+func FetchFrame(db *gorm.DB, accountId uint) (string, error) {
+    var account model.BrokerageAccount
+    if err := db.Select("id", "type").Where("id = ?", accountId).First(&account).Error; err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return "", nil
+        }
+        return "", err
+    }
+    return frameByAccountType[account.Type], nil
+}
+
+// ReservePurchase はNISA口座での購入を投資枠の上限と照合し、購入記録を登録します(上限を超える場合はエラー)
+// 生涯投資枠は保有中の取得価額から計算するため、保有資産を作成・更新する前に呼び出してください
+func ReservePurchase(db *gorm.DB, dto CreateNisaPurchaseDto) error {
+    amount := math.Round(dto.Amount)
+    if amount <= 0 {
+        return nil
+    }
+    year := time.Now().Year()
+    usage, err := fetchUsage(db, dto.UserId, year)
+    if err != nil {
+        return err
+    }
+    switch dto.Frame {
+    case FrameGrowth:
+        usage.GrowthAnnual += amount
+        usage.GrowthLifetime += amount
+    case FrameTsumitate:
+        usage.TsumitateAnnual += amount
+        usage.TsumitateLifetime += amount
+    default:
+        return errors.New("投資枠の種類が無効です")
+    }
+    if err := checkLimits(usage, dto.Frame); err != nil {
+        return err
+    }
+
+    purchase := &model.NisaPurchase{
+        Year:        year,
+        Frame:       dto.Frame,
+        HoldingType: dto.HoldingType,
+        Code:        dto.Code,
+        Amount:      amount,
+        AccountId:   dto.AccountId,
+        UserId:      dto.UserId,
+    }
+    return db.Create(purchase).Error
+}
+
+// ReserveUsStockPurchase はNISA口座での米国株式の購入額(円)を投資枠と照合して記録します(NISA口座でない場合は何もしない)
+func ReserveUsStockPurchase(db *gorm.DB, usStock model.UsStock, amount float64) error {
+    frame, err := FetchFrame(db, usStock.AccountId)
+    if err != nil || frame == "" {
+        return err
+    }
+    if frame == FrameTsumitate {
+        return errors.New("つみたて投資枠では個別株式を購入できません")
+    }
+    if usStock.UsdJpy <= 0 {
+        return errors.New("NISA口座に登録する場合は購入時為替を入力してください")
+    }
+    return ReservePurchase(db, CreateNisaPurchaseDto{
+        Frame:       frame,
+        HoldingType: "US_STOCK",
+        Code:        usStock.Code,
+        Amount:      amount,
+        AccountId:   usStock.AccountId,
+        UserId:      usStock.UserId,
+    })
+}
+
+// ReserveJapanFundPurchase はNISA口座での投資信託の購入額(円)を投資枠と照合して記録します(NISA口座でない場合は何もしない)
+func ReserveJapanFundPurchase(db *gorm.DB, japanFund model.JapanFund, amount float64) error {
+    frame, err := FetchFrame(db, japanFund.AccountId)
+    if err != nil || frame == "" {
+        return err
+    }
+    return ReservePurchase(db, CreateNisaPurchaseDto{
+        Frame:       frame,
+        HoldingType: "JAPAN_FUND",
+        Code:        japanFund.Code,
+        Amount:      amount,
+        AccountId:   japanFund.AccountId,
+        UserId:      japanFund.UserId,
+    })
+}
+
+// CheckLifetimeCapacity は削除済みの保有資産をNISA口座に戻す際に、生涯投資枠の上限を超えないか確認します(NISA口座でない場合は何もしない)
+// 購入記録は購入時に登録済みのため、年間投資枠は消費しない
+func CheckLifetimeCapacity(db *gorm.DB, accountId uint, userId uint, amount float64) error {
+    frame, err := FetchFrame(db, accountId)
+    if err != nil || frame == "" {
+        return err
+    }
+    usage, err := fetchUsage(db, userId, time.Now().Year())
+    if err != nil {
+        return err
+    }
+    if frame == FrameGrowth {
+        usage.GrowthLifetime += math.Round(amount)
+    } else {
+        usage.TsumitateLifetime += math.Round(amount)
+    }
+    return checkLimits(usage, frame)
+}
+
+// ValidateUsage は指定した各年の投資枠の利用額が上限を超えていないか確認します(バックアップの復元などで一括登録した後に呼び出す)
+func ValidateUsage(db *gorm.DB, userId uint, years []int) error {
+    checked := map[int]bool{}
+    for _, year := range years {
+        if checked[year] {
+            continue
+        }
+        checked[year] = true
+        usage, err := fetchUsage(db, userId, year)
+        if err != nil {
+            return err
+        }
+        if err := checkLimits(usage, FrameGrowth); err != nil {
+            return err
+        }
+        if err := checkLimits(usage, FrameTsumitate); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// 投資枠の利用額が上限を超えていないか確認する(生涯投資枠の合計は投資枠の種類に関わらず確認する)
+func checkLimits(usage *NisaUsage, frame string) error {
+    switch frame {
+    case FrameGrowth:
+        if usage.GrowthAnnual > GrowthAnnualLimit {
+            return errors.New("成長投資枠の年間投資枠(240万円)を超えるため登録できません")
+        }
+        if usage.GrowthLifetime > GrowthLifetimeLimit {
+            return errors.New("成長投資枠の生涯投資枠(1,200万円)を超えるため登録できません")
+        }
+    case FrameTsumitate:
+        if usage.TsumitateAnnual > TsumitateAnnualLimit {
+            return errors.New("つみたて投資枠の年間投資枠(120万円)を超えるため登録できません")
+        }
+    }
+    if usage.GrowthLifetime+usage.TsumitateLifetime > LifetimeLimit {
+        return errors.New("NISAの生涯投資枠(1,800万円)を超えるため登録できません")
+    }
+    return nil
+}
+
+// 投資枠ごとの合計額
+type frameAmount struct {
+    AccountType string
+    Frame       string
+    Amount      float64
+}
+
+// 年間投資枠は購入記録、生涯投資枠は保有中のNISA口座の取得価額から計算する
+func fetchUsage(db *gorm.DB, userId uint, year int) (*NisaUsage, error) {
+    usage := &NisaUsage{}
+
+    var purchases []frameAmount
+    if err := db.Model(&model.NisaPurchase{}).Select("frame, SUM(amount) AS amount").Where("user_id = ? AND year = ?", userId, year).Group("frame").Scan(&purchases).Error; err != nil {
+        return nil, err
+    }
+    for _, purchase := range purchases {
+        switch purchase.Frame {
+        case FrameGrowth:
+            usage.GrowthAnnual += purchase.Amount
+        case FrameTsumitate:
+            usage.TsumitateAnnual += purchase.Amount
+        }
+    }
+
+    // 米国株式は購入時為替で円換算する
+    var stocks []frameAmount
+    if err := db.Model(&model.UsStock{}).
+        Select("brokerage_accounts.type AS account_type, SUM(us_stocks.get_price * us_stocks.quantity * us_stocks.usd_jpy) AS amount").
+        Joins("JOIN brokerage_accounts ON brokerage_accounts.id = us_stocks.account_id").
+        Where("us_stocks.user_id = ?", userId).
+        Group("brokerage_accounts.type").Scan(&stocks).Error; err != nil {
+        return nil, err
+    }
+    var funds []frameAmount
+    if err := db.Model(&model.JapanFund{}).
+        Select("brokerage_accounts.type AS account_type, SUM(japan_funds.get_price_total) AS amount").
+        Joins("JOIN brokerage_accounts ON brokerage_accounts.id = japan_funds.account_id").
+        Where("japan_funds.user_id = ?", userId).
+        Group("brokerage_accounts.type").Scan(&funds).Error; err != nil {
+        return nil, err
+    }
+    for _, holding := range append(stocks, funds...) {
+        switch frameByAccountType[holding.AccountType] {
+        case FrameGrowth:
+            usage.GrowthLifetime += holding.Amount
+        case FrameTsumitate:
+            usage.TsumitateLifetime += holding.Amount
+        }
+    }
+    return usage, nil
+}
@@ -0,0 +1,78 @@
+package nisa
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// テスト用のデータベース設定
+func setupTestDB() *gorm.DB {
+    db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+    if err != nil {
+        panic("failed to connect database")
+    }
+    // テスト用のテーブルを準備
+    db.AutoMigrate(&model.BrokerageAccount{}, &model.UsStock{}, &model.JapanFund{}, &model.NisaPurchase{})
+    return db
+}
+
+func TestFetchNisaUsage(t *testing.T) {
+    db := setupTestDB()
+    repo := NewNisaRepository(db)
+    year := time.Now().Year()
+
+    growth := model.BrokerageAccount{Name: "NISA成長", Type: "NISA_GROWTH", UserId: 1}
+    db.Create(&growth)
+    tsumitate := model.BrokerageAccount{Name: "NISAつみたて", Type: "NISA_TSUMITATE", UserId: 1}
+    db.Create(&tsumitate)
+    taxable := model.BrokerageAccount{Name: "特定", Type: "TAXABLE", UserId: 1}
+    db.Create(&taxable)
+
+    db.Create(&model.UsStock{Code: "AAPL", Quantity: 10, GetPrice: 100, UsdJpy: 150, Sector: "Tech", AccountId: growth.ID, UserId: 1})
+    db.Create(&model.JapanFund{Code: "SP500", Name: "S&P500", GetPriceTotal: 500000, AccountId: tsumitate.ID, UserId: 1})
+    db.Create(&model.JapanFund{Code: "SP500", Name: "S&P500", GetPriceTotal: 800000, AccountId: taxable.ID, UserId: 1})
+    // 売却済みの保有資産は生涯投資枠に含めない
+    sold := model.JapanFund{Code: "ORCN", Name: "オルカン", GetPriceTotal: 300000, AccountId: growth.ID, UserId: 1}
+    db.Create(&sold)
+    db.Delete(&sold)
+
+    db.Create(&model.NisaPurchase{Year: year, Frame: FrameGrowth, HoldingType: "US_STOCK", Code: "AAPL", Amount: 150000, AccountId: growth.ID, UserId: 1})
+    db.Create(&model.NisaPurchase{Year: year, Frame: FrameGrowth, HoldingType: "JAPAN_FUND", Code: "ORCN", Amount: 300000, AccountId: growth.ID, UserId: 1})
+    db.Create(&model.NisaPurchase{Year: year, Frame: FrameTsumitate, HoldingType: "JAPAN_FUND", Code: "SP500", Amount: 500000, AccountId: tsumitate.ID, UserId: 1})
+    db.Create(&model.NisaPurchase{Year: year - 1, Frame: FrameGrowth, HoldingType: "US_STOCK", Code: "AAPL", Amount: 100000, AccountId: growth.ID, UserId: 1})
+
+    usage, err := repo.FetchNisaUsage(context.Background(), 1, year)
+
+    assert.NoError(t, err)
+    // 売却しても年間投資枠は戻らない
+    assert.Equal(t, 450000.0, usage.GrowthAnnual)
+    assert.Equal(t, 500000.0, usage.TsumitateAnnual)
+    assert.Equal(t, 150000.0, usage.GrowthLifetime)
+    assert.Equal(t, 500000.0, usage.TsumitateLifetime)
+}
+
+func TestReservePurchase(t *testing.T) {
+    db := setupTestDB()
+    tsumitate := model.BrokerageAccount{Name: "NISAつみたて", Type: "NISA_TSUMITATE", UserId: 2}
+    db.Create(&tsumitate)
+
+    frame, err := FetchFrame(db, tsumitate.ID)
+    assert.NoError(t, err)
+    assert.Equal(t, FrameTsumitate, frame)
+
+    err = ReservePurchase(db, CreateNisaPurchaseDto{Frame: frame, HoldingType: "JAPAN_FUND", Code: "SP500", Amount: 1000000, AccountId: tsumitate.ID, UserId: 2})
+    assert.NoError(t, err)
+
+    // つみたて投資枠の年間投資枠(120万円)を超える購入は記録しない
+    err = ReservePurchase(db, CreateNisaPurchaseDto{Frame: frame, HoldingType: "JAPAN_FUND", Code: "SP500", Amount: 200001, AccountId: tsumitate.ID, UserId: 2})
+    assert.EqualError(t, err, "つみたて投資枠の年間投資枠(120万円)を超えるため登録できません")
+    var count int64
+    db.Model(&model.NisaPurchase{}).Where("user_id = ?", 2).Count(&count)
+    assert.Equal(t, int64(1), count)
+}
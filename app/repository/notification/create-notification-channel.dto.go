@@ -0,0 +1,9 @@
+package notification
+
+type CreateNotificationChannelDto struct {
+    Type   string `json:"type"`
+    Name   string `json:"name"`
+    Target string `json:"target"`
+    Secret string `json:"secret"`
+    UserId uint   `json:"userId"`
+}
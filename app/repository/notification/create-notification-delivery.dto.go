@@ -0,0 +1,9 @@
+package notification
+
+type CreateNotificationDeliveryDto struct {
+    UserId   uint   `json:"userId"`
+    Event    string `json:"event"`
+    Subject  string `json:"subject"`
+    Body     string `json:"body"`
+    HTMLBody string `json:"htmlBody"`
+}
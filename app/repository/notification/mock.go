@@ -0,0 +1,64 @@
+package notification
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockNotificationRepository は NotificationRepository のモックです。
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+// NewMockNotificationRepository は新しい MockNotificationRepository を作成し、初期設定を行います。
+func NewMockNotificationRepository() *MockNotificationRepository {
+	return &MockNotificationRepository{}
+}
+
+func (m *MockNotificationRepository) FetchNotificationChannelListById(ctx context.Context, userId uint) ([]model.NotificationChannel, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]model.NotificationChannel), args.Error(1)
+}
+
+func (m *MockNotificationRepository) FindNotificationChannelById(ctx context.Context, id uint, userId uint) (*model.NotificationChannel, error) {
+	args := m.Called(ctx, id, userId)
+	return args.Get(0).(*model.NotificationChannel), args.Error(1)
+}
+
+func (m *MockNotificationRepository) CreateNotificationChannel(ctx context.Context, dto CreateNotificationChannelDto) (*model.NotificationChannel, error) {
+	args := m.Called(ctx, dto)
+	return args.Get(0).(*model.NotificationChannel), args.Error(1)
+}
+
+func (m *MockNotificationRepository) UpdateNotificationChannel(ctx context.Context, dto UpdateNotificationChannelDto) (*model.NotificationChannel, error) {
+	args := m.Called(ctx, dto)
+	return args.Get(0).(*model.NotificationChannel), args.Error(1)
+}
+
+func (m *MockNotificationRepository) DeleteNotificationChannel(ctx context.Context, id uint, userId uint) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) EnqueueNotificationDeliveries(ctx context.Context, dto CreateNotificationDeliveryDto, now time.Time) ([]model.NotificationDelivery, error) {
+	args := m.Called(ctx, dto, now)
+	return args.Get(0).([]model.NotificationDelivery), args.Error(1)
+}
+
+func (m *MockNotificationRepository) ClaimDueNotificationDeliveryList(ctx context.Context, now time.Time, limit int, claimUntil time.Time) ([]model.NotificationDelivery, error) {
+	args := m.Called(ctx, now, limit, claimUntil)
+	return args.Get(0).([]model.NotificationDelivery), args.Error(1)
+}
+
+func (m *MockNotificationRepository) UpdateNotificationDelivery(ctx context.Context, dto UpdateNotificationDeliveryDto) error {
+	args := m.Called(ctx, dto)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) FetchNotificationDeliveryListById(ctx context.Context, userId uint, limit int) ([]model.NotificationDelivery, error) {
+	args := m.Called(ctx, userId, limit)
+	return args.Get(0).([]model.NotificationDelivery), args.Error(1)
+}
@@ -0,0 +1,190 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"my-us-stock-backend/app/database/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 送信状況
+const (
+    StatusPending = "PENDING"
+    StatusSent    = "SENT"
+    StatusFailed  = "FAILED"
+)
+
+// NotificationRepository インターフェースの定義
+type NotificationRepository interface {
+    FetchNotificationChannelListById(ctx context.Context, userId uint) ([]model.NotificationChannel, error)
+    FindNotificationChannelById(ctx context.Context, id uint, userId uint) (*model.NotificationChannel, error)
+    CreateNotificationChannel(ctx context.Context, dto CreateNotificationChannelDto) (*model.NotificationChannel, error)
+    UpdateNotificationChannel(ctx context.Context, dto UpdateNotificationChannelDto) (*model.NotificationChannel, error)
+    DeleteNotificationChannel(ctx context.Context, id uint, userId uint) error
+    EnqueueNotificationDeliveries(ctx context.Context, dto CreateNotificationDeliveryDto, now time.Time) ([]model.NotificationDelivery, error)
+    ClaimDueNotificationDeliveryList(ctx context.Context, now time.Time, limit int, claimUntil time.Time) ([]model.NotificationDelivery, error)
+    UpdateNotificationDelivery(ctx context.Context, dto UpdateNotificationDeliveryDto) error
+    FetchNotificationDeliveryListById(ctx context.Context, userId uint, limit int) ([]model.NotificationDelivery, error)
+}
+
+// DefaultNotificationRepository 構造体の定義
+type DefaultNotificationRepository struct {
+    DB *gorm.DB
+}
+
+// NewNotificationRepository は DefaultNotificationRepository の新しいインスタンスを作成します
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+    return &DefaultNotificationRepository{DB: db}
+}
+
+// 指定したuserIdのユーザーの通知先を登録順に取得する
+func (r *DefaultNotificationRepository) FetchNotificationChannelListById(ctx context.Context, userId uint) ([]model.NotificationChannel, error) {
+    var channels []model.NotificationChannel
+    if err := r.DB.Where("user_id = ?", userId).Order("id").Find(&channels).Error; err != nil {
+        return nil, err
+    }
+    return channels, nil
+}
+
+// 指定したidの通知先を取得する(他のユーザーの通知先は取得できない)
+func (r *DefaultNotificationRepository) FindNotificationChannelById(ctx context.Context, id uint, userId uint) (*model.NotificationChannel, error) {
+    var channel model.NotificationChannel
+    if err := r.DB.Where("id = ? AND user_id = ?", id, userId).First(&channel).Error; err != nil {
+        return nil, fmt.Errorf("指定された通知先が見つかりません")
+    }
+    return &channel, nil
+}
+
+// 通知先を作成します(作成時は有効な状態とする)
+func (r *DefaultNotificationRepository) CreateNotificationChannel(ctx context.Context, dto CreateNotificationChannelDto) (*model.NotificationChannel, error) {
+    channel := &model.NotificationChannel{
+        Type:    dto.Type,
+        Name:    dto.Name,
+        Target:  dto.Target,
+        Secret:  dto.Secret,
+        Enabled: true,
+        UserId:  dto.UserId,
+    }
+    if err := r.DB.Create(channel).Error; err != nil {
+        return nil, err
+    }
+    return channel, nil
+}
+
+// 通知先を更新します
+func (r *DefaultNotificationRepository) UpdateNotificationChannel(ctx context.Context, dto UpdateNotificationChannelDto) (*model.NotificationChannel, error) {
+    newChannel := map[string]interface{}{
+        "name":    dto.Name,
+        "target":  dto.Target,
+        "enabled": dto.Enabled,
+    }
+    if dto.Secret != nil {
+        newChannel["secret"] = *dto.Secret
+    }
+    result := r.DB.Model(&model.NotificationChannel{}).Where("id = ? AND user_id = ?", dto.ID, dto.UserId).Updates(newChannel)
+    if result.Error != nil {
+        return nil, result.Error
+    }
+    if result.RowsAffected == 0 {
+        return nil, fmt.Errorf("指定された通知先が見つかりません")
+    }
+
+    var channel model.NotificationChannel
+    if err := r.DB.Where("id = ?", dto.ID).First(&channel).Error; err != nil {
+        return nil, err
+    }
+    return &channel, nil
+}
+
+// 通知先を削除します
+func (r *DefaultNotificationRepository) DeleteNotificationChannel(ctx context.Context, id uint, userId uint) error {
+    result := r.DB.Where("id = ? AND user_id = ?", id, userId).Delete(&model.NotificationChannel{})
+    if result.Error != nil {
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        return fmt.Errorf("指定された通知先が見つかりません")
+    }
+    return nil
+}
+
+// EnqueueNotificationDeliveries はユーザーの有効な通知先ごとに送信待ちの通知を登録します
+// 有効な通知先がない場合・退会の猶予期間中のユーザー(論理削除済み)の場合は何も登録しません
+func (r *DefaultNotificationRepository) EnqueueNotificationDeliveries(ctx context.Context, dto CreateNotificationDeliveryDto, now time.Time) ([]model.NotificationDelivery, error) {
+    var deliveries []model.NotificationDelivery
+    err := r.DB.Transaction(func(tx *gorm.DB) error {
+        var channels []model.NotificationChannel
+        if err := tx.Where("user_id = ? AND enabled = ? AND user_id IN (?)", dto.UserId, true, tx.Model(&model.User{}).Select("id")).Order("id").Find(&channels).Error; err != nil {
+            return err
+        }
+        for _, channel := range channels {
+            deliveries = append(deliveries, model.NotificationDelivery{
+                ChannelId:     channel.ID,
+                Event:         dto.Event,
+                Subject:       dto.Subject,
+                Body:          dto.Body,
+                HTMLBody:      dto.HTMLBody,
+                Status:        StatusPending,
+                NextAttemptAt: now,
+                UserId:        dto.UserId,
+            })
+        }
+        if len(deliveries) == 0 {
+            return nil
+        }
+        return tx.Omit("Channel").Create(&deliveries).Error
+    })
+    if err != nil {
+        return nil, err
+    }
+    return deliveries, nil
+}
+
+// ClaimDueNotificationDeliveryList は送信予定日時を過ぎた送信待ちの通知を通知先と合わせて古い順に取得し、送信する権利を確保します
+// 確保した通知は送信予定日時を claimUntil に更新するため、他の実行(別インスタンスなど)からは取得されません
+// 送信結果が記録されないまま claimUntil を過ぎた場合は再び送信待ちとして取得されます
+// 退会の猶予期間中のユーザー(論理削除済み)の通知は送信しません
+func (r *DefaultNotificationRepository) ClaimDueNotificationDeliveryList(ctx context.Context, now time.Time, limit int, claimUntil time.Time) ([]model.NotificationDelivery, error) {
+    var candidates []model.NotificationDelivery
+    if err := r.DB.Preload("Channel").Where("status = ? AND next_attempt_at <= ? AND user_id IN (?)", StatusPending, now, r.DB.Model(&model.User{}).Select("id")).Order("next_attempt_at").Order("id").Limit(limit).Find(&candidates).Error; err != nil {
+        return nil, err
+    }
+    deliveries := make([]model.NotificationDelivery, 0, len(candidates))
+    for _, candidate := range candidates {
+        // 取得後に他の実行が確保した通知は更新されないため除外する
+        result := r.DB.Model(&model.NotificationDelivery{}).
+            Where("id = ? AND status = ? AND next_attempt_at <= ?", candidate.ID, StatusPending, now).
+            Update("next_attempt_at", claimUntil)
+        if result.Error != nil {
+            return nil, result.Error
+        }
+        if result.RowsAffected == 1 {
+            deliveries = append(deliveries, candidate)
+        }
+    }
+    return deliveries, nil
+}
+
+// 通知の送信結果を更新します
+func (r *DefaultNotificationRepository) UpdateNotificationDelivery(ctx context.Context, dto UpdateNotificationDeliveryDto) error {
+    newDelivery := map[string]interface{}{
+        "status":          dto.Status,
+        "attempts":        dto.Attempts,
+        "next_attempt_at": dto.NextAttemptAt,
+        "last_error":      dto.LastError,
+        "sent_at":         dto.SentAt,
+    }
+    return r.DB.Model(&model.NotificationDelivery{}).Where("id = ?", dto.ID).Updates(newDelivery).Error
+}
+
+// 指定したuserIdのユーザーの通知履歴を通知先と合わせて新しい順に取得する
+func (r *DefaultNotificationRepository) FetchNotificationDeliveryListById(ctx context.Context, userId uint, limit int) ([]model.NotificationDelivery, error) {
+    var deliveries []model.NotificationDelivery
+    // 削除済みの通知先も履歴には表示する
+    if err := r.DB.Preload("Channel", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).Where("user_id = ?", userId).Order("id desc").Limit(limit).Find(&deliveries).Error; err != nil {
+        return nil, err
+    }
+    return deliveries, nil
+}
@@ -0,0 +1,120 @@
+package notification
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// テスト用のデータベース設定
+func setupTestDB() *gorm.DB {
+    db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+    if err != nil {
+        panic("failed to connect database")
+    }
+    // テスト用のテーブルを準備
+    db.AutoMigrate(&model.NotificationChannel{})
+    db.AutoMigrate(&model.NotificationDelivery{})
+    db.AutoMigrate(&model.User{})
+    return db
+}
+
+func TestCreateUpdateDeleteNotificationChannel(t *testing.T) {
+    db := setupTestDB()
+    repo := NewNotificationRepository(db)
+
+    created, err := repo.CreateNotificationChannel(context.Background(), CreateNotificationChannelDto{Type: "WEBHOOK", Name: "自宅サーバー", Target: "https://example.com/hook", Secret: "secret-0123456789", UserId: 1})
+    assert.NoError(t, err)
+    assert.True(t, created.Enabled)
+
+    // シークレットを省略した場合は維持される
+    updated, err := repo.UpdateNotificationChannel(context.Background(), UpdateNotificationChannelDto{ID: created.ID, Name: "自宅", Target: "https://example.com/hook2", Enabled: false, UserId: 1})
+    assert.NoError(t, err)
+    assert.Equal(t, "自宅", updated.Name)
+    assert.Equal(t, "secret-0123456789", updated.Secret)
+    assert.False(t, updated.Enabled)
+
+    found, err := repo.FindNotificationChannelById(context.Background(), created.ID, 1)
+    assert.NoError(t, err)
+    assert.Equal(t, "WEBHOOK", found.Type)
+    _, err = repo.FindNotificationChannelById(context.Background(), created.ID, 2)
+    assert.Error(t, err)
+
+    channels, err := repo.FetchNotificationChannelListById(context.Background(), 1)
+    assert.NoError(t, err)
+    assert.Len(t, channels, 1)
+
+    // 他のユーザーの通知先は更新・削除できない
+    _, err = repo.UpdateNotificationChannel(context.Background(), UpdateNotificationChannelDto{ID: created.ID, Name: "x", Target: "x", UserId: 2})
+    assert.Error(t, err)
+    assert.Error(t, repo.DeleteNotificationChannel(context.Background(), created.ID, 2))
+    assert.NoError(t, repo.DeleteNotificationChannel(context.Background(), created.ID, 1))
+}
+
+// 有効な通知先ごとに送信待ちの通知が登録される
+func TestEnqueueAndFetchDueNotificationDeliveries(t *testing.T) {
+    db := setupTestDB()
+    repo := NewNotificationRepository(db)
+
+    db.Create(&model.User{Model: gorm.Model{ID: 1}, Email: "notification-1@example.com", Password: "hashed"})
+    db.Create(&model.User{Model: gorm.Model{ID: 2}, Email: "notification-2@example.com", Password: "hashed"})
+    deletedUser := model.User{Model: gorm.Model{ID: 4}, Email: "notification-4@example.com", Password: "hashed"}
+    db.Create(&deletedUser)
+    db.Delete(&deletedUser)
+    db.Create(&model.NotificationChannel{Type: "SLACK", Name: "slack", Target: "https://hooks.example.com/a", Enabled: true, UserId: 1})
+    db.Create(&model.NotificationChannel{Type: "DISCORD", Name: "discord", Target: "https://hooks.example.com/b", Enabled: false, UserId: 1})
+    db.Create(&model.NotificationChannel{Type: "SLACK", Name: "other", Target: "https://hooks.example.com/c", Enabled: true, UserId: 2})
+
+    now := time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)
+    deliveries, err := repo.EnqueueNotificationDeliveries(context.Background(), CreateNotificationDeliveryDto{UserId: 1, Event: "DAILY_SUMMARY", Subject: "件名", Body: "本文", HTMLBody: "<p>本文</p>"}, now)
+    assert.NoError(t, err)
+    assert.Len(t, deliveries, 1)
+    assert.Equal(t, StatusPending, deliveries[0].Status)
+    assert.Equal(t, "<p>本文</p>", deliveries[0].HTMLBody)
+
+    // 通知先がないユーザーは何も登録されない
+    deliveries, err = repo.EnqueueNotificationDeliveries(context.Background(), CreateNotificationDeliveryDto{UserId: 3, Event: "ALERT_TRIGGERED", Subject: "件名", Body: "本文"}, now)
+    assert.NoError(t, err)
+    assert.Len(t, deliveries, 0)
+
+    // 退会の猶予期間中のユーザーには登録されない
+    db.Create(&model.NotificationChannel{Type: "SLACK", Name: "deleted", Target: "https://hooks.example.com/d", Enabled: true, UserId: 4})
+    deliveries, err = repo.EnqueueNotificationDeliveries(context.Background(), CreateNotificationDeliveryDto{UserId: 4, Event: "ALERT_TRIGGERED", Subject: "件名", Body: "本文"}, now)
+    assert.NoError(t, err)
+    assert.Len(t, deliveries, 0)
+
+    claimUntil := now.Add(10 * time.Minute)
+    due, err := repo.ClaimDueNotificationDeliveryList(context.Background(), now.Add(-time.Second), 10, claimUntil)
+    assert.NoError(t, err)
+    assert.Len(t, due, 0)
+    due, err = repo.ClaimDueNotificationDeliveryList(context.Background(), now, 10, claimUntil)
+    assert.NoError(t, err)
+    assert.Len(t, due, 1)
+    assert.Equal(t, "slack", due[0].Channel.Name)
+
+    // 確保済みの通知は他の実行からは取得されず、確保期限を過ぎると再び取得される
+    claimed, err := repo.ClaimDueNotificationDeliveryList(context.Background(), now, 10, claimUntil)
+    assert.NoError(t, err)
+    assert.Len(t, claimed, 0)
+    claimed, err = repo.ClaimDueNotificationDeliveryList(context.Background(), claimUntil, 10, claimUntil.Add(10*time.Minute))
+    assert.NoError(t, err)
+    assert.Len(t, claimed, 1)
+
+    // 送信済みにすると送信待ちとして取得されない
+    sentAt := now.Add(time.Minute)
+    err = repo.UpdateNotificationDelivery(context.Background(), UpdateNotificationDeliveryDto{ID: due[0].ID, Status: StatusSent, Attempts: 1, NextAttemptAt: now, SentAt: &sentAt})
+    assert.NoError(t, err)
+    due, _ = repo.ClaimDueNotificationDeliveryList(context.Background(), now.Add(time.Hour), 10, now.Add(2*time.Hour))
+    assert.Len(t, due, 0)
+
+    history, err := repo.FetchNotificationDeliveryListById(context.Background(), 1, 10)
+    assert.NoError(t, err)
+    assert.Len(t, history, 1)
+    assert.Equal(t, StatusSent, history[0].Status)
+    assert.Equal(t, 1, history[0].Attempts)
+}
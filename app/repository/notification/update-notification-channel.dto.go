@@ -0,0 +1,11 @@
+package notification
+
+type UpdateNotificationChannelDto struct {
+    ID      uint    `json:"id"`
+    Name    string  `json:"name"`
+    Target  string  `json:"target"`
+    // nilの場合は登録済みのシークレットを維持する
+    Secret  *string `json:"secret"`
+    Enabled bool    `json:"enabled"`
+    UserId  uint    `json:"userId"`
+}
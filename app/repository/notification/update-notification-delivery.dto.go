@@ -0,0 +1,13 @@
+package notification
+
+import "time"
+
+// UpdateNotificationDeliveryDto は送信を試行した結果を表します
+type UpdateNotificationDeliveryDto struct {
+    ID            uint       `json:"id"`
+    Status        string     `json:"status"`
+    Attempts      int        `json:"attempts"`
+    NextAttemptAt time.Time  `json:"nextAttemptAt"`
+    LastError     string     `json:"lastError"`
+    SentAt        *time.Time `json:"sentAt"`
+}
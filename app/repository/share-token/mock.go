@@ -0,0 +1,45 @@
+package sharetoken
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockShareTokenRepository は ShareTokenRepository のモックです。
+type MockShareTokenRepository struct {
+	mock.Mock
+}
+
+// NewMockShareTokenRepository は新しい MockShareTokenRepository を作成し、初期設定を行います。
+func NewMockShareTokenRepository() *MockShareTokenRepository {
+	return &MockShareTokenRepository{}
+}
+
+func (m *MockShareTokenRepository) FetchShareTokenListByUserId(ctx context.Context, userId uint) ([]model.ShareToken, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]model.ShareToken), args.Error(1)
+}
+
+func (m *MockShareTokenRepository) FindValidShareToken(ctx context.Context, token string, now time.Time) (*model.ShareToken, error) {
+	args := m.Called(ctx, token, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ShareToken), args.Error(1)
+}
+
+func (m *MockShareTokenRepository) CreateShareToken(ctx context.Context, dto CreateShareTokenDto) (*model.ShareToken, error) {
+	args := m.Called(ctx, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ShareToken), args.Error(1)
+}
+
+func (m *MockShareTokenRepository) DeleteShareToken(ctx context.Context, id uint, userId uint) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
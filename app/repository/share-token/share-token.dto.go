@@ -0,0 +1,10 @@
+package sharetoken
+
+import "time"
+
+type CreateShareTokenDto struct {
+    Token     string    `json:"token"`
+    Privacy   string    `json:"privacy"`
+    ExpiresAt time.Time `json:"expiresAt"`
+    UserId    uint      `json:"userId"`
+}
@@ -0,0 +1,86 @@
+package sharetoken
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"my-us-stock-backend/app/database/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 公開範囲
+const (
+    PrivacyPercentage = "PERCENTAGE"
+    PrivacyAmount     = "AMOUNT"
+)
+
+// ShareTokenRepository インターフェースの定義
+type ShareTokenRepository interface {
+    FetchShareTokenListByUserId(ctx context.Context, userId uint) ([]model.ShareToken, error)
+    FindValidShareToken(ctx context.Context, token string, now time.Time) (*model.ShareToken, error)
+    CreateShareToken(ctx context.Context, dto CreateShareTokenDto) (*model.ShareToken, error)
+    DeleteShareToken(ctx context.Context, id uint, userId uint) error
+}
+
+// DefaultShareTokenRepository 構造体の定義
+type DefaultShareTokenRepository struct {
+    DB *gorm.DB
+}
+
+// NewShareTokenRepository は DefaultShareTokenRepository の新しいインスタンスを作成します
+func NewShareTokenRepository(db *gorm.DB) ShareTokenRepository {
+    return &DefaultShareTokenRepository{DB: db}
+}
+
+// HashToken は共有リンクのトークンを保存・照合用のハッシュに変換します
+func HashToken(token string) string {
+    sum := sha256.Sum256([]byte(token))
+    return hex.EncodeToString(sum[:])
+}
+
+// 指定したuserIdのユーザーの共有リンクのリストを新しい順に取得する(有効期限切れのものも含む)
+func (r *DefaultShareTokenRepository) FetchShareTokenListByUserId(ctx context.Context, userId uint) ([]model.ShareToken, error) {
+    var shareTokens []model.ShareToken
+    if err := r.DB.Where("user_id = ?", userId).Order("id desc").Find(&shareTokens).Error; err != nil {
+        return nil, err
+    }
+    return shareTokens, nil
+}
+
+// FindValidShareToken は有効期限内のトークンを取得します(取り消し済み・期限切れ・退会の猶予期間中のユーザーの場合は gorm.ErrRecordNotFound)
+func (r *DefaultShareTokenRepository) FindValidShareToken(ctx context.Context, token string, now time.Time) (*model.ShareToken, error) {
+    var shareToken model.ShareToken
+    if err := r.DB.Where("token_hash = ? AND expires_at > ? AND user_id IN (?)", HashToken(token), now, r.DB.Model(&model.User{}).Select("id")).First(&shareToken).Error; err != nil {
+        return nil, err
+    }
+    return &shareToken, nil
+}
+
+// 共有リンクを作成します(トークンはハッシュのみ保存する)
+func (r *DefaultShareTokenRepository) CreateShareToken(ctx context.Context, dto CreateShareTokenDto) (*model.ShareToken, error) {
+    shareToken := &model.ShareToken{
+        TokenHash: HashToken(dto.Token),
+        Privacy:   dto.Privacy,
+        ExpiresAt: dto.ExpiresAt,
+        UserId:    dto.UserId,
+    }
+    if err := r.DB.Create(shareToken).Error; err != nil {
+        return nil, err
+    }
+    return shareToken, nil
+}
+
+// 共有リンクを取り消します(取り消したトークンは以降参照できない)
+func (r *DefaultShareTokenRepository) DeleteShareToken(ctx context.Context, id uint, userId uint) error {
+    result := r.DB.Where("id = ? AND user_id = ?", id, userId).Delete(&model.ShareToken{})
+    if result.Error != nil {
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        return errors.New("指定された共有リンクが見つかりません")
+    }
+    return nil
+}
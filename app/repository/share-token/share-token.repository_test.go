@@ -0,0 +1,89 @@
+package sharetoken
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// テスト用のデータベース設定
+func setupTestDB() *gorm.DB {
+    db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+    if err != nil {
+        panic("failed to connect database")
+    }
+    // テスト用のテーブルを準備
+    db.AutoMigrate(&model.ShareToken{})
+    db.AutoMigrate(&model.User{})
+    return db
+}
+
+func TestFindValidShareToken(t *testing.T) {
+    db := setupTestDB()
+    repo := NewShareTokenRepository(db)
+    now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+    db.Create(&model.User{Model: gorm.Model{ID: 1}, Email: "share-1@example.com", Password: "hashed"})
+    deletedUser := model.User{Model: gorm.Model{ID: 2}, Email: "share-2@example.com", Password: "hashed"}
+    db.Create(&deletedUser)
+    db.Delete(&deletedUser)
+    db.Create(&model.ShareToken{TokenHash: HashToken("valid"), Privacy: PrivacyPercentage, ExpiresAt: now.AddDate(0, 0, 7), UserId: 1})
+    db.Create(&model.ShareToken{TokenHash: HashToken("expired"), Privacy: PrivacyAmount, ExpiresAt: now.Add(-time.Second), UserId: 1})
+    db.Create(&model.ShareToken{TokenHash: HashToken("deleted-user"), Privacy: PrivacyAmount, ExpiresAt: now.AddDate(0, 0, 7), UserId: 2})
+
+    shareToken, err := repo.FindValidShareToken(context.Background(), "valid", now)
+    assert.NoError(t, err)
+    assert.Equal(t, uint(1), shareToken.UserId)
+
+    // 有効期限切れ・未登録のトークンは参照できない
+    _, err = repo.FindValidShareToken(context.Background(), "expired", now)
+    assert.Equal(t, gorm.ErrRecordNotFound, err)
+    _, err = repo.FindValidShareToken(context.Background(), "unknown", now)
+    assert.Equal(t, gorm.ErrRecordNotFound, err)
+
+    // 退会の猶予期間中のユーザーの共有リンクは参照できない
+    _, err = repo.FindValidShareToken(context.Background(), "deleted-user", now)
+    assert.Equal(t, gorm.ErrRecordNotFound, err)
+}
+
+func TestCreateAndFetchShareTokens(t *testing.T) {
+    db := setupTestDB()
+    repo := NewShareTokenRepository(db)
+    expiresAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+    _, err := repo.CreateShareToken(context.Background(), CreateShareTokenDto{Token: "token-1", Privacy: PrivacyPercentage, ExpiresAt: expiresAt, UserId: 1})
+    assert.NoError(t, err)
+    _, err = repo.CreateShareToken(context.Background(), CreateShareTokenDto{Token: "token-2", Privacy: PrivacyAmount, ExpiresAt: expiresAt, UserId: 1})
+    assert.NoError(t, err)
+    _, err = repo.CreateShareToken(context.Background(), CreateShareTokenDto{Token: "token-3", Privacy: PrivacyAmount, ExpiresAt: expiresAt, UserId: 2})
+    assert.NoError(t, err)
+
+    shareTokens, err := repo.FetchShareTokenListByUserId(context.Background(), 1)
+    assert.NoError(t, err)
+    assert.Len(t, shareTokens, 2)
+    // トークンはハッシュのみ保存する
+    assert.Equal(t, HashToken("token-2"), shareTokens[0].TokenHash)
+}
+
+func TestDeleteShareToken(t *testing.T) {
+    db := setupTestDB()
+    repo := NewShareTokenRepository(db)
+    now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+    shareToken := model.ShareToken{TokenHash: HashToken("token-1"), Privacy: PrivacyPercentage, ExpiresAt: now.AddDate(0, 0, 7), UserId: 1}
+    db.Create(&shareToken)
+
+    // 他のユーザーの共有リンクは取り消せない
+    err := repo.DeleteShareToken(context.Background(), shareToken.ID, 2)
+    assert.EqualError(t, err, "指定された共有リンクが見つかりません")
+
+    err = repo.DeleteShareToken(context.Background(), shareToken.ID, 1)
+    assert.NoError(t, err)
+    _, err = repo.FindValidShareToken(context.Background(), "token-1", now)
+    assert.Equal(t, gorm.ErrRecordNotFound, err)
+}
@@ -0,0 +1,11 @@
+package taxreport
+
+type CreateDividendReceiptDto struct {
+    UserId      uint    `json:"userId"`
+    Code        string  `json:"code"`
+    PaymentDate string  `json:"paymentDate"`
+    Amount      float64 `json:"amount"`
+    ForeignTax  float64 `json:"foreignTax"`
+    DomesticTax float64 `json:"domesticTax"`
+    UsdJpy      float64 `json:"usdJpy"`
+}
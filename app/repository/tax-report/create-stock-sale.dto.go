@@ -0,0 +1,13 @@
+package taxreport
+
+type CreateStockSaleDto struct {
+    UserId    uint    `json:"userId"`
+    Code      string  `json:"code"`
+    TradeDate string  `json:"tradeDate"`
+    Quantity  float64 `json:"quantity"`
+    SalePrice float64 `json:"salePrice"`
+    GetPrice  float64 `json:"getPrice"`
+    Fee       float64 `json:"fee"`
+    UsdJpy    float64 `json:"usdJpy"`
+    GetUsdJpy float64 `json:"getUsdJpy"`
+}
@@ -0,0 +1,54 @@
+package taxreport
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTaxReportRepository は TaxReportRepository のモックです。
+type MockTaxReportRepository struct {
+	mock.Mock
+}
+
+// NewMockTaxReportRepository は新しい MockTaxReportRepository を作成し、初期設定を行います。
+func NewMockTaxReportRepository() *MockTaxReportRepository {
+	return &MockTaxReportRepository{}
+}
+
+func (m *MockTaxReportRepository) FetchDividendReceiptListByYear(ctx context.Context, userId uint, year int) ([]model.DividendReceipt, error) {
+	args := m.Called(ctx, userId, year)
+	return args.Get(0).([]model.DividendReceipt), args.Error(1)
+}
+
+func (m *MockTaxReportRepository) CreateDividendReceipt(ctx context.Context, dto CreateDividendReceiptDto) (*model.DividendReceipt, error) {
+	args := m.Called(ctx, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DividendReceipt), args.Error(1)
+}
+
+func (m *MockTaxReportRepository) DeleteDividendReceipt(ctx context.Context, id uint, userId uint) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (m *MockTaxReportRepository) FetchStockSaleListByYear(ctx context.Context, userId uint, year int) ([]model.StockSale, error) {
+	args := m.Called(ctx, userId, year)
+	return args.Get(0).([]model.StockSale), args.Error(1)
+}
+
+func (m *MockTaxReportRepository) CreateStockSale(ctx context.Context, dto CreateStockSaleDto) (*model.StockSale, error) {
+	args := m.Called(ctx, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.StockSale), args.Error(1)
+}
+
+func (m *MockTaxReportRepository) DeleteStockSale(ctx context.Context, id uint, userId uint) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
@@ -0,0 +1,96 @@
+package taxreport
+
+import (
+	"math"
+	"my-us-stock-backend/app/database/model"
+	"sort"
+)
+
+// SecuritySummary は銘柄別の配当・譲渡損益の集計(円)です
+type SecuritySummary struct {
+    Code           string
+    DividendAmount float64
+    ForeignTax     float64
+    DomesticTax    float64
+    Proceeds       float64
+    Cost           float64
+    Fee            float64
+    RealizedGain   float64
+}
+
+// AnnualSummary は指定した年の配当・譲渡損益の集計(円)です
+type AnnualSummary struct {
+    Year              int
+    Securities        []SecuritySummary
+    DividendTotal     float64
+    ForeignTaxTotal   float64
+    DomesticTaxTotal  float64
+    ProceedsTotal     float64
+    CostTotal         float64
+    FeeTotal          float64
+    RealizedGainTotal float64
+}
+
+// ConvertToJpy はドル建ての金額を円に換算します(円未満切り捨て)
+// 浮動小数点の誤差で切り捨て結果が1円ずれないよう、小数第6位で丸めてから切り捨てる
+func ConvertToJpy(amount float64, usdJpy float64) float64 {
+    return math.Floor(math.Round(amount*usdJpy*1e6) / 1e6)
+}
+
+// DividendJpy は配当金と外国源泉徴収税額を支払日のTTMで円に換算します
+func DividendJpy(receipt model.DividendReceipt) (float64, float64) {
+    return ConvertToJpy(receipt.Amount, receipt.UsdJpy), ConvertToJpy(receipt.ForeignTax, receipt.UsdJpy)
+}
+
+// StockSaleJpy は売却記録の譲渡価額・取得費・売却手数料を円に換算します
+// 譲渡価額は売却代金を約定日のTTM、取得費は取得価額を取得日のTTMで換算する
+func StockSaleJpy(sale model.StockSale) (float64, float64, float64) {
+    proceeds := ConvertToJpy(sale.Quantity*sale.SalePrice, sale.UsdJpy)
+    cost := ConvertToJpy(sale.Quantity*sale.GetPrice, sale.GetUsdJpy)
+    fee := ConvertToJpy(sale.Fee, sale.UsdJpy)
+    return proceeds, cost, fee
+}
+
+// SummarizeAnnualTax は配当・売却の記録を銘柄別に集計します(銘柄はティッカー順)
+func SummarizeAnnualTax(year int, receipts []model.DividendReceipt, sales []model.StockSale) AnnualSummary {
+    securityMap := map[string]*SecuritySummary{}
+    findSecurity := func(code string) *SecuritySummary {
+        security, ok := securityMap[code]
+        if !ok {
+            security = &SecuritySummary{Code: code}
+            securityMap[code] = security
+        }
+        return security
+    }
+    for _, receipt := range receipts {
+        amountJpy, foreignTaxJpy := DividendJpy(receipt)
+        security := findSecurity(receipt.Code)
+        security.DividendAmount += amountJpy
+        security.ForeignTax += foreignTaxJpy
+        security.DomesticTax += receipt.DomesticTax
+    }
+    for _, sale := range sales {
+        proceeds, cost, fee := StockSaleJpy(sale)
+        security := findSecurity(sale.Code)
+        security.Proceeds += proceeds
+        security.Cost += cost
+        security.Fee += fee
+        security.RealizedGain += proceeds - cost - fee
+    }
+
+    summary := AnnualSummary{Year: year, Securities: []SecuritySummary{}}
+    for _, security := range securityMap {
+        summary.Securities = append(summary.Securities, *security)
+        summary.DividendTotal += security.DividendAmount
+        summary.ForeignTaxTotal += security.ForeignTax
+        summary.DomesticTaxTotal += security.DomesticTax
+        summary.ProceedsTotal += security.Proceeds
+        summary.CostTotal += security.Cost
+        summary.FeeTotal += security.Fee
+        summary.RealizedGainTotal += security.RealizedGain
+    }
+    sort.Slice(summary.Securities, func(i, j int) bool {
+        return summary.Securities[i].Code < summary.Securities[j].Code
+    })
+    return summary
+}
@@ -0,0 +1,116 @@
+package taxreport
+
+import (
+	"context"
+	"fmt"
+	"my-us-stock-backend/app/database/model"
+
+	"gorm.io/gorm"
+)
+
+// TaxReportRepository インターフェースの定義
+type TaxReportRepository interface {
+    FetchDividendReceiptListByYear(ctx context.Context, userId uint, year int) ([]model.DividendReceipt, error)
+    CreateDividendReceipt(ctx context.Context, dto CreateDividendReceiptDto) (*model.DividendReceipt, error)
+    DeleteDividendReceipt(ctx context.Context, id uint, userId uint) error
+    FetchStockSaleListByYear(ctx context.Context, userId uint, year int) ([]model.StockSale, error)
+    CreateStockSale(ctx context.Context, dto CreateStockSaleDto) (*model.StockSale, error)
+    DeleteStockSale(ctx context.Context, id uint, userId uint) error
+}
+
+// DefaultTaxReportRepository 構造体の定義
+type DefaultTaxReportRepository struct {
+    DB *gorm.DB
+}
+
+// NewTaxReportRepository は DefaultTaxReportRepository の新しいインスタンスを作成します
+func NewTaxReportRepository(db *gorm.DB) TaxReportRepository {
+    return &DefaultTaxReportRepository{DB: db}
+}
+
+// 指定した年の1月1日〜12月31日の範囲(YYYY-MM-DD)
+func yearRange(year int) (string, string) {
+    return fmt.Sprintf("%04d-01-01", year), fmt.Sprintf("%04d-12-31", year)
+}
+
+// 指定した年に受け取った配当の記録を支払日順に取得します
+func (r *DefaultTaxReportRepository) FetchDividendReceiptListByYear(ctx context.Context, userId uint, year int) ([]model.DividendReceipt, error) {
+    from, to := yearRange(year)
+    var receipts []model.DividendReceipt
+    err := r.DB.Where("user_id = ? AND payment_date BETWEEN ? AND ?", userId, from, to).Order("payment_date, id").Find(&receipts).Error
+    if err != nil {
+        return nil, err
+    }
+    return receipts, nil
+}
+
+// 配当の受取記録を登録します
+func (r *DefaultTaxReportRepository) CreateDividendReceipt(ctx context.Context, dto CreateDividendReceiptDto) (*model.DividendReceipt, error) {
+    receipt := &model.DividendReceipt{
+        Code:        dto.Code,
+        PaymentDate: dto.PaymentDate,
+        Amount:      dto.Amount,
+        ForeignTax:  dto.ForeignTax,
+        DomesticTax: dto.DomesticTax,
+        UsdJpy:      dto.UsdJpy,
+        UserId:      dto.UserId,
+    }
+    if err := r.DB.Create(receipt).Error; err != nil {
+        return nil, err
+    }
+    return receipt, nil
+}
+
+// 配当の受取記録を削除します
+func (r *DefaultTaxReportRepository) DeleteDividendReceipt(ctx context.Context, id uint, userId uint) error {
+    result := r.DB.Where("id = ? AND user_id = ?", id, userId).Delete(&model.DividendReceipt{})
+    if result.Error != nil {
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        return fmt.Errorf("指定された配当の記録が見つかりません")
+    }
+    return nil
+}
+
+// 指定した年に約定した売却の記録を約定日順に取得します
+func (r *DefaultTaxReportRepository) FetchStockSaleListByYear(ctx context.Context, userId uint, year int) ([]model.StockSale, error) {
+    from, to := yearRange(year)
+    var sales []model.StockSale
+    err := r.DB.Where("user_id = ? AND trade_date BETWEEN ? AND ?", userId, from, to).Order("trade_date, id").Find(&sales).Error
+    if err != nil {
+        return nil, err
+    }
+    return sales, nil
+}
+
+// 売却の記録を登録します
+func (r *DefaultTaxReportRepository) CreateStockSale(ctx context.Context, dto CreateStockSaleDto) (*model.StockSale, error) {
+    sale := &model.StockSale{
+        Code:      dto.Code,
+        TradeDate: dto.TradeDate,
+        Quantity:  dto.Quantity,
+        SalePrice: dto.SalePrice,
+        GetPrice:  dto.GetPrice,
+        Fee:       dto.Fee,
+        UsdJpy:    dto.UsdJpy,
+        GetUsdJpy: dto.GetUsdJpy,
+        UserId:    dto.UserId,
+    }
+    if err := r.DB.Create(sale).Error; err != nil {
+        return nil, err
+    }
+    return sale, nil
+}
+
+// 売却の記録を削除します
+func (r *DefaultTaxReportRepository) DeleteStockSale(ctx context.Context, id uint, userId uint) error {
+    result := r.DB.Where("id = ? AND user_id = ?", id, userId).Delete(&model.StockSale{})
+    if result.Error != nil {
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        return fmt.Errorf("指定された売却の記録が見つかりません")
+    }
+    return nil
+}
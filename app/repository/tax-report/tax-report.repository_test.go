@@ -0,0 +1,100 @@
+package taxreport
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// テスト用のデータベース設定
+func setupTestDB() *gorm.DB {
+    db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+    if err != nil {
+        panic("failed to connect database")
+    }
+    // テスト用のテーブルを準備
+    db.AutoMigrate(&model.DividendReceipt{}, &model.StockSale{})
+    return db
+}
+
+func TestFetchDividendReceiptListByYear(t *testing.T) {
+    db := setupTestDB()
+    repo := NewTaxReportRepository(db)
+
+    db.Create(&model.DividendReceipt{Code: "KO", PaymentDate: "2024-04-01", Amount: 10, UserId: 1})
+    db.Create(&model.DividendReceipt{Code: "AAPL", PaymentDate: "2024-02-15", Amount: 5, UserId: 1})
+    db.Create(&model.DividendReceipt{Code: "KO", PaymentDate: "2023-12-15", Amount: 9, UserId: 1})
+    db.Create(&model.DividendReceipt{Code: "KO", PaymentDate: "2024-12-31", Amount: 11, UserId: 1})
+    db.Create(&model.DividendReceipt{Code: "KO", PaymentDate: "2024-04-01", Amount: 10, UserId: 2})
+
+    receipts, err := repo.FetchDividendReceiptListByYear(context.Background(), 1, 2024)
+
+    assert.NoError(t, err)
+    assert.Len(t, receipts, 3)
+    assert.Equal(t, "2024-02-15", receipts[0].PaymentDate)
+    assert.Equal(t, "2024-12-31", receipts[2].PaymentDate)
+}
+
+func TestCreateAndDeleteDividendReceipt(t *testing.T) {
+    db := setupTestDB()
+    repo := NewTaxReportRepository(db)
+
+    receipt, err := repo.CreateDividendReceipt(context.Background(), CreateDividendReceiptDto{UserId: 1, Code: "KO", PaymentDate: "2024-04-01", Amount: 10, ForeignTax: 1, DomesticTax: 200, UsdJpy: 151.2})
+    assert.NoError(t, err)
+    assert.NotZero(t, receipt.ID)
+
+    // 他のユーザーの記録は削除できない
+    assert.Error(t, repo.DeleteDividendReceipt(context.Background(), receipt.ID, 2))
+    assert.NoError(t, repo.DeleteDividendReceipt(context.Background(), receipt.ID, 1))
+
+    var count int64
+    db.Model(&model.DividendReceipt{}).Count(&count)
+    assert.Equal(t, int64(0), count)
+}
+
+func TestStockSale(t *testing.T) {
+    db := setupTestDB()
+    repo := NewTaxReportRepository(db)
+
+    sale, err := repo.CreateStockSale(context.Background(), CreateStockSaleDto{UserId: 1, Code: "AAPL", TradeDate: "2024-06-03", Quantity: 5, SalePrice: 190, GetPrice: 150, Fee: 2, UsdJpy: 157, GetUsdJpy: 140})
+    assert.NoError(t, err)
+    repo.CreateStockSale(context.Background(), CreateStockSaleDto{UserId: 1, Code: "AAPL", TradeDate: "2025-01-06", Quantity: 1})
+
+    sales, err := repo.FetchStockSaleListByYear(context.Background(), 1, 2024)
+    assert.NoError(t, err)
+    assert.Len(t, sales, 1)
+    assert.Equal(t, 140.0, sales[0].GetUsdJpy)
+
+    assert.Error(t, repo.DeleteStockSale(context.Background(), sale.ID, 2))
+    assert.NoError(t, repo.DeleteStockSale(context.Background(), sale.ID, 1))
+}
+
+func TestConvertToJpy(t *testing.T) {
+    assert.Equal(t, 6911.0, ConvertToJpy(46, 150.25))
+    // 0.1 × 150 が 14.999... とならずに 15 円になること
+    assert.Equal(t, 15.0, ConvertToJpy(0.1, 150))
+}
+
+func TestSummarizeAnnualTax(t *testing.T) {
+    receipts := []model.DividendReceipt{
+        {Code: "KO", PaymentDate: "2024-04-01", Amount: 46, ForeignTax: 4.6, DomesticTax: 1053, UsdJpy: 150.25},
+        {Code: "AAPL", PaymentDate: "2024-05-16", Amount: 2.4, ForeignTax: 0.24, DomesticTax: 67, UsdJpy: 155},
+    }
+    sales := []model.StockSale{
+        {Code: "AAPL", TradeDate: "2024-06-03", Quantity: 10, SalePrice: 190, GetPrice: 150, Fee: 2, UsdJpy: 150, GetUsdJpy: 130},
+    }
+
+    summary := SummarizeAnnualTax(2024, receipts, sales)
+
+    assert.Equal(t, 2024, summary.Year)
+    assert.Len(t, summary.Securities, 2)
+    assert.Equal(t, SecuritySummary{Code: "AAPL", DividendAmount: 372, ForeignTax: 37, DomesticTax: 67, Proceeds: 285000, Cost: 195000, Fee: 300, RealizedGain: 89700}, summary.Securities[0])
+    assert.Equal(t, "KO", summary.Securities[1].Code)
+    assert.Equal(t, 7283.0, summary.DividendTotal)
+    assert.Equal(t, 728.0, summary.ForeignTaxTotal)
+    assert.Equal(t, 89700.0, summary.RealizedGainTotal)
+}
@@ -7,5 +7,6 @@ type CreateTotalAssetDto struct {
 	Fund float64 `json:"fund"`
 	Crypto float64 `json:"crypto"`
 	FixedIncomeAsset float64 `json:"fixedIncomeAsset"`
+	UsdJpy float64 `json:"usdJpy"`
 	UserId   uint  `json:"userId"`
 }
\ No newline at end of file
@@ -24,7 +24,7 @@ type DefaultTotalAssetRepository struct {
 
 // 共通フィールドを選択するためのヘルパー関数です。
 func selectBaseQuery(db *gorm.DB) *gorm.DB {
-    return db.Select("id", "cash_jpy", "cash_usd", "stock", "fund", "crypto", "fixed_income_asset", "user_id", "created_at")
+    return db.Select("id", "cash_jpy", "cash_usd", "stock", "fund", "crypto", "fixed_income_asset", "usd_jpy", "user_id", "created_at")
 }
 
 // NewTotalAssetRepository は DefaultTotalAssetRepository の新しいインスタンスを作成します
@@ -131,6 +131,7 @@ func (r *DefaultTotalAssetRepository) CreateTodayTotalAsset(ctx context.Context,
         Fund: dto.Fund,
         Crypto:   dto.Crypto,
         FixedIncomeAsset: dto.FixedIncomeAsset,
+        UsdJpy:   dto.UsdJpy,
         UserId:   dto.UserId,
     }
 
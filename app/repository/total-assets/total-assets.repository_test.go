@@ -144,10 +144,11 @@ func TestCreateTodayTotalAsset(t *testing.T) {
     repo := NewTotalAssetRepository(db)
 
     // 新規資産の作成
-    createDto := CreateTotalAssetDto{UserId: 1, CashUsd: 1000}
+    createDto := CreateTotalAssetDto{UserId: 1, CashUsd: 1000, UsdJpy: 148.5}
     createdAsset, err := repo.CreateTodayTotalAsset(context.Background(), createDto)
     assert.NoError(t, err)
     assert.NotNil(t, createdAsset)
+    assert.Equal(t, 148.5, createdAsset.UsdJpy)
     // 同じ日付での重複作成
     _, err = repo.CreateTodayTotalAsset(context.Background(), createDto)
     assert.Error(t, err)
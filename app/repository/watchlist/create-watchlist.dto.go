@@ -0,0 +1,9 @@
+package watchlist
+
+type CreateWatchlistDto struct {
+    Ticker      string   `json:"ticker"`
+    Note        string   `json:"note"`
+    TargetPrice *float64 `json:"targetPrice,omitempty"`
+    TargetYield *float64 `json:"targetYield,omitempty"`
+    UserId      uint     `json:"userId"`
+}
@@ -0,0 +1,43 @@
+package watchlist
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWatchlistRepository は WatchlistRepository のモックです。
+type MockWatchlistRepository struct {
+	mock.Mock
+}
+
+// NewMockWatchlistRepository は新しい MockWatchlistRepository を作成し、初期設定を行います。
+func NewMockWatchlistRepository() *MockWatchlistRepository {
+	return &MockWatchlistRepository{}
+}
+
+func (m *MockWatchlistRepository) FetchWatchlistListById(ctx context.Context, userId uint) ([]model.Watchlist, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]model.Watchlist), args.Error(1)
+}
+
+func (m *MockWatchlistRepository) FindWatchlistById(ctx context.Context, id uint, userId uint) (*model.Watchlist, error) {
+	args := m.Called(ctx, id, userId)
+	return args.Get(0).(*model.Watchlist), args.Error(1)
+}
+
+func (m *MockWatchlistRepository) CreateWatchlist(ctx context.Context, dto CreateWatchlistDto) (*model.Watchlist, error) {
+	args := m.Called(ctx, dto)
+	return args.Get(0).(*model.Watchlist), args.Error(1)
+}
+
+func (m *MockWatchlistRepository) UpdateWatchlist(ctx context.Context, dto UpdateWatchlistDto) (*model.Watchlist, error) {
+	args := m.Called(ctx, dto)
+	return args.Get(0).(*model.Watchlist), args.Error(1)
+}
+
+func (m *MockWatchlistRepository) DeleteWatchlist(ctx context.Context, id uint, userId uint) error {
+	args := m.Called(ctx, id, userId)
+	return args.Error(0)
+}
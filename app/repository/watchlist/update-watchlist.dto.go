@@ -0,0 +1,10 @@
+package watchlist
+
+// 目標価格・目標利回りはnilの場合に未設定に戻す
+type UpdateWatchlistDto struct {
+    ID          uint     `json:"id"`
+    Note        string   `json:"note"`
+    TargetPrice *float64 `json:"targetPrice,omitempty"`
+    TargetYield *float64 `json:"targetYield,omitempty"`
+    UserId      uint     `json:"userId"`
+}
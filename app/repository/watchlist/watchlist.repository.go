@@ -0,0 +1,102 @@
+package watchlist
+
+import (
+	"context"
+	"fmt"
+	"my-us-stock-backend/app/database/model"
+
+	"gorm.io/gorm"
+)
+
+// WatchlistRepository インターフェースの定義
+type WatchlistRepository interface {
+    FetchWatchlistListById(ctx context.Context, userId uint) ([]model.Watchlist, error)
+    FindWatchlistById(ctx context.Context, id uint, userId uint) (*model.Watchlist, error)
+    CreateWatchlist(ctx context.Context, dto CreateWatchlistDto) (*model.Watchlist, error)
+    UpdateWatchlist(ctx context.Context, dto UpdateWatchlistDto) (*model.Watchlist, error)
+    DeleteWatchlist(ctx context.Context, id uint, userId uint) error
+}
+
+// DefaultWatchlistRepository 構造体の定義
+type DefaultWatchlistRepository struct {
+    DB *gorm.DB
+}
+
+// 共通フィールドを選択するためのヘルパー関数です。
+func selectBaseQuery(db *gorm.DB) *gorm.DB {
+    return db.Select("id", "ticker", "note", "target_price", "target_yield", "user_id")
+}
+
+// NewWatchlistRepository は DefaultWatchlistRepository の新しいインスタンスを作成します
+func NewWatchlistRepository(db *gorm.DB) WatchlistRepository {
+    return &DefaultWatchlistRepository{DB: db}
+}
+
+// 指定したuserIdのユーザーのウォッチリストを登録順に取得する
+func (r *DefaultWatchlistRepository) FetchWatchlistListById(ctx context.Context, userId uint) ([]model.Watchlist, error) {
+    var watchlists []model.Watchlist
+    err := selectBaseQuery(r.DB).Where("user_id = ?", userId).Order("id").Find(&watchlists).Error
+    if err != nil {
+        return nil, err
+    }
+    return watchlists, nil
+}
+
+// 指定したユーザーのウォッチリストをidで取得する
+func (r *DefaultWatchlistRepository) FindWatchlistById(ctx context.Context, id uint, userId uint) (*model.Watchlist, error) {
+    var watchlist model.Watchlist
+    if err := selectBaseQuery(r.DB).Where("id = ? AND user_id = ?", id, userId).First(&watchlist).Error; err != nil {
+        return nil, err
+    }
+    return &watchlist, nil
+}
+
+// ウォッチリストに銘柄を追加します
+func (r *DefaultWatchlistRepository) CreateWatchlist(ctx context.Context, dto CreateWatchlistDto) (*model.Watchlist, error) {
+    // 既に同じ銘柄が存在するかを確認
+    var existingWatchlist model.Watchlist
+    if err := selectBaseQuery(r.DB).Where("ticker = ? AND user_id = ?", dto.Ticker, dto.UserId).First(&existingWatchlist).Error; err == nil {
+        return nil, fmt.Errorf("この銘柄は既にウォッチリストに登録されています")
+    }
+
+    watchlist := &model.Watchlist{
+        Ticker:      dto.Ticker,
+        Note:        dto.Note,
+        TargetPrice: dto.TargetPrice,
+        TargetYield: dto.TargetYield,
+        UserId:      dto.UserId,
+    }
+    if err := r.DB.Create(watchlist).Error; err != nil {
+        return nil, err
+    }
+    return watchlist, nil
+}
+
+// ウォッチリストのメモ・目標値を更新します
+func (r *DefaultWatchlistRepository) UpdateWatchlist(ctx context.Context, dto UpdateWatchlistDto) (*model.Watchlist, error) {
+    newWatchlist := map[string]interface{}{
+        "note":         dto.Note,
+        "target_price": dto.TargetPrice,
+        "target_yield": dto.TargetYield,
+    }
+    result := r.DB.Model(&model.Watchlist{}).Where("id = ? AND user_id = ?", dto.ID, dto.UserId).Updates(newWatchlist)
+    if result.Error != nil {
+        return nil, result.Error
+    }
+    if result.RowsAffected == 0 {
+        return nil, fmt.Errorf("指定されたウォッチリストが見つかりません")
+    }
+    return r.FindWatchlistById(ctx, dto.ID, dto.UserId)
+}
+
+// ウォッチリストから銘柄を削除します
+func (r *DefaultWatchlistRepository) DeleteWatchlist(ctx context.Context, id uint, userId uint) error {
+    result := r.DB.Where("id = ? AND user_id = ?", id, userId).Delete(&model.Watchlist{})
+    if result.Error != nil {
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        return fmt.Errorf("指定されたウォッチリストが見つかりません")
+    }
+    return nil
+}
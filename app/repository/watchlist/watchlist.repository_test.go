@@ -0,0 +1,115 @@
+package watchlist
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// テスト用のデータベース設定
+func setupTestDB() *gorm.DB {
+    db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+    if err != nil {
+        panic("failed to connect database")
+    }
+    // テスト用のテーブルを準備
+    db.AutoMigrate(&model.Watchlist{})
+    return db
+}
+
+func TestFetchWatchlistListById(t *testing.T) {
+    db := setupTestDB()
+    repo := NewWatchlistRepository(db)
+
+    targetPrice := 150.0
+    db.Create(&model.Watchlist{Ticker: "MSFT", UserId: 1, Note: "押し目待ち", TargetPrice: &targetPrice})
+    db.Create(&model.Watchlist{Ticker: "KO", UserId: 1})
+    db.Create(&model.Watchlist{Ticker: "PG", UserId: 2})
+
+    watchlists, err := repo.FetchWatchlistListById(context.Background(), 1)
+    assert.NoError(t, err)
+    assert.Len(t, watchlists, 2)
+    assert.Equal(t, "MSFT", watchlists[0].Ticker)
+    assert.Equal(t, "押し目待ち", watchlists[0].Note)
+    assert.Equal(t, 150.0, *watchlists[0].TargetPrice)
+    assert.Nil(t, watchlists[0].TargetYield)
+}
+
+// 他のユーザーのウォッチリストは取得できない
+func TestFindWatchlistById(t *testing.T) {
+    db := setupTestDB()
+    repo := NewWatchlistRepository(db)
+
+    watchlist := model.Watchlist{Ticker: "MSFT", UserId: 1}
+    db.Create(&watchlist)
+
+    found, err := repo.FindWatchlistById(context.Background(), watchlist.ID, 1)
+    assert.NoError(t, err)
+    assert.Equal(t, "MSFT", found.Ticker)
+
+    _, err = repo.FindWatchlistById(context.Background(), watchlist.ID, 2)
+    assert.Equal(t, gorm.ErrRecordNotFound, err)
+}
+
+// 同じ銘柄は重複して登録できない
+func TestCreateWatchlist(t *testing.T) {
+    db := setupTestDB()
+    repo := NewWatchlistRepository(db)
+
+    targetYield := 3.0
+    dto := CreateWatchlistDto{Ticker: "KO", Note: "高配当", TargetYield: &targetYield, UserId: 1}
+    created, err := repo.CreateWatchlist(context.Background(), dto)
+    assert.NoError(t, err)
+    assert.NotZero(t, created.ID)
+    assert.Equal(t, 3.0, *created.TargetYield)
+
+    _, err = repo.CreateWatchlist(context.Background(), dto)
+    assert.EqualError(t, err, "この銘柄は既にウォッチリストに登録されています")
+
+    // 別ユーザーであれば登録できる
+    dto.UserId = 2
+    _, err = repo.CreateWatchlist(context.Background(), dto)
+    assert.NoError(t, err)
+}
+
+// 目標値にnilを指定すると未設定に戻る
+func TestUpdateWatchlist(t *testing.T) {
+    db := setupTestDB()
+    repo := NewWatchlistRepository(db)
+
+    targetPrice := 150.0
+    watchlist := model.Watchlist{Ticker: "MSFT", UserId: 1, TargetPrice: &targetPrice}
+    db.Create(&watchlist)
+
+    targetYield := 1.0
+    updated, err := repo.UpdateWatchlist(context.Background(), UpdateWatchlistDto{ID: watchlist.ID, Note: "決算後に判断", TargetYield: &targetYield, UserId: 1})
+    assert.NoError(t, err)
+    assert.Equal(t, "決算後に判断", updated.Note)
+    assert.Nil(t, updated.TargetPrice)
+    assert.Equal(t, 1.0, *updated.TargetYield)
+
+    // 他のユーザーのウォッチリストは更新できない
+    _, err = repo.UpdateWatchlist(context.Background(), UpdateWatchlistDto{ID: watchlist.ID, Note: "不正", UserId: 2})
+    assert.Error(t, err)
+}
+
+func TestDeleteWatchlist(t *testing.T) {
+    db := setupTestDB()
+    repo := NewWatchlistRepository(db)
+
+    watchlist := model.Watchlist{Ticker: "MSFT", UserId: 1}
+    db.Create(&watchlist)
+
+    // 他のユーザーのウォッチリストは削除できない
+    err := repo.DeleteWatchlist(context.Background(), watchlist.ID, 2)
+    assert.Error(t, err)
+
+    err = repo.DeleteWatchlist(context.Background(), watchlist.ID, 1)
+    assert.NoError(t, err)
+    _, err = repo.FindWatchlistById(context.Background(), watchlist.ID, 1)
+    assert.Equal(t, gorm.ErrRecordNotFound, err)
+}
@@ -34,6 +34,19 @@ func (fpc *FundPriceController) GetFundPrices(c *gin.Context) {
 	log.Printf("GetFundPrices called from %s", clientIP)
 }
 
+// GetNavFetchFailedFundPrices handles GET requests to fetch fund prices whose NAV could not be refreshed
+func (fpc *FundPriceController) GetNavFetchFailedFundPrices(c *gin.Context) {
+	clientIP := c.ClientIP()
+	fundPrices, err := fpc.Service.FetchNavFetchFailedFundPrices(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, fundPrices)
+	// IPアドレスをログに記録
+	log.Printf("GetNavFetchFailedFundPrices called from %s", clientIP)
+}
+
 // UpdateFundPrice handles POST requests to update a fund price
 func (fpc *FundPriceController) UpdateFundPrice(c *gin.Context) {
 	clientIP := c.ClientIP()
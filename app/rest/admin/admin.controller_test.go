@@ -30,6 +30,11 @@ func (m *MockFundPriceService) CreateFundPrice(ctx context.Context, dto fund.Cre
 	return args.Get(0).(*model.FundPrice), args.Error(1)
 }
 
+func (m *MockFundPriceService) FetchNavFetchFailedFundPrices(ctx context.Context) ([]model.FundPrice, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]model.FundPrice), args.Error(1)
+}
+
 // Test for GetFundPrices method
 func TestFundPriceController_GetFundPrices(t *testing.T) {
 	mockService := new(MockFundPriceService)
@@ -10,6 +10,7 @@ type FundPriceService interface {
 	FetchFundPrices(ctx context.Context) ([]model.FundPrice, error)
 	UpdateFundPrice(ctx context.Context, dto fund.UpdateFundPriceDto) (*model.FundPrice, error)
 	CreateFundPrice(ctx context.Context, dto fund.CreateFundPriceDto) (*model.FundPrice, error)
+	FetchNavFetchFailedFundPrices(ctx context.Context) ([]model.FundPrice, error)
 }
 
 // DefaultFundPriceService provides a struct to hold any dependencies
@@ -34,3 +35,27 @@ func (s *DefaultFundPriceService) UpdateFundPrice(ctx context.Context, dto fund.
 func (s *DefaultFundPriceService) CreateFundPrice(ctx context.Context, dto fund.CreateFundPriceDto) (*model.FundPrice, error) {
 	return s.Repo.CreateFundPrice(ctx, dto)
 }
+
+// FetchNavFetchFailedFundPrices は直近の基準価額の自動取得に失敗した投資信託を取得します
+// 価格が未登録の保有中の投資信託は、名称・価格なしでファンドコードと失敗の理由のみ含めます
+func (s *DefaultFundPriceService) FetchNavFetchFailedFundPrices(ctx context.Context) ([]model.FundPrice, error) {
+	fundPrices, err := s.Repo.FetchFundPriceList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	failedFundPrices := []model.FundPrice{}
+	for _, fundPrice := range fundPrices {
+		if fundPrice.NavFetchError != "" {
+			failedFundPrices = append(failedFundPrices, fundPrice)
+		}
+	}
+	failures, err := s.Repo.FetchFundNavFailureList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, failure := range failures {
+		failedAt := failure.FailedAt
+		failedFundPrices = append(failedFundPrices, model.FundPrice{Code: failure.Code, NavFetchError: failure.Reason, NavFetchFailedAt: &failedAt})
+	}
+	return failedFundPrices, nil
+}
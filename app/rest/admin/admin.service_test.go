@@ -5,6 +5,7 @@ import (
 	"my-us-stock-backend/app/database/model"
 	"my-us-stock-backend/app/repository/market-price/fund"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -58,3 +59,28 @@ func TestDefaultFundPriceService_CreateFundPrice(t *testing.T) {
 	assert.Equal(t, expectedPrice, result)
 	mockRepo.AssertExpectations(t)
 }
+
+// Test for FetchNavFetchFailedFundPrices method
+func TestDefaultFundPriceService_FetchNavFetchFailedFundPrices(t *testing.T) {
+	mockRepo := fund.NewMockFundPriceRepository()
+	service := NewFundPriceService(mockRepo)
+
+	mockRepo.On("FetchFundPriceList", mock.Anything).Return([]model.FundPrice{
+		{Name: "Fund A", Code: "FA123", Price: 100.50},
+		{Name: "Fund B", Code: "FB456", Price: 200.75, NavFetchError: "unexpected status code: 404"},
+	}, nil)
+	failedAt := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	mockRepo.On("FetchFundNavFailureList", mock.Anything).Return([]model.FundNavFailure{
+		{Code: "UNREG01", Reason: "unexpected status code: 404", FailedAt: failedAt},
+	}, nil)
+
+	result, err := service.FetchNavFetchFailedFundPrices(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "FB456", result[0].Code)
+	// 価格が未登録の投資信託の取得失敗も含める
+	assert.Equal(t, "UNREG01", result[1].Code)
+	assert.Equal(t, "unexpected status code: 404", result[1].NavFetchError)
+	assert.True(t, result[1].NavFetchFailedAt.Equal(failedAt))
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,548 @@
+package backup
+
+import (
+	"fmt"
+	"my-us-stock-backend/app/database/model"
+	repoFixedIncome "my-us-stock-backend/app/repository/assets/fixed-income"
+	repoBackup "my-us-stock-backend/app/repository/backup"
+	repoNisa "my-us-stock-backend/app/repository/nisa"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// 固定利回り資産の評価方法として有効な値
+var validValuationModes = map[string]struct{}{
+    repoFixedIncome.ValuationModeFace: {},
+    repoFixedIncome.ValuationModeAmortizedCost: {},
+    repoFixedIncome.ValuationModeMarket: {},
+}
+
+// 口座の種類として有効な値
+var validAccountTypes = map[string]struct{}{
+    "NISA_GROWTH": {},
+    "NISA_TSUMITATE": {},
+    "TAXABLE": {},
+    "IDECO": {},
+    "OVERSEAS": {},
+}
+
+// NISAの投資枠として有効な値
+var validNisaFrames = map[string]struct{}{
+    repoNisa.FrameGrowth: {},
+    repoNisa.FrameTsumitate: {},
+}
+
+// バックアップの形式のバージョン(項目を変更した場合は上げる)
+// 1 は公開前の口座を含まない形式のため、復元には対応しない
+const backupVersion = 2
+
+// backupDocument はバックアップのJSONの形式です
+// id は元の環境のIDで、復元時に振り直したIDとの対応を返すためにのみ使います
+type backupDocument struct {
+    Version           int                      `json:"version"`
+    ExportedAt        time.Time                `json:"exportedAt"`
+    User              backupUser               `json:"user"`
+    BrokerageAccounts []backupBrokerageAccount `json:"brokerageAccounts"`
+    UsStocks          []backupUsStock          `json:"usStocks"`
+    Cryptos           []backupCrypto           `json:"cryptos"`
+    JapanFunds        []backupJapanFund        `json:"japanFunds"`
+    FixedIncomeAssets []backupFixedIncomeAsset `json:"fixedIncomeAssets"`
+    TotalAssets       []backupTotalAsset       `json:"totalAssets"`
+    NisaPurchases     []backupNisaPurchase     `json:"nisaPurchases"`
+    DividendReceipts  []backupDividendReceipt  `json:"dividendReceipts"`
+    StockSales        []backupStockSale        `json:"stockSales"`
+}
+
+// パスワードはバックアップに含めない。メールアドレスは確認用で、復元はしない
+type backupUser struct {
+    Name  string `json:"name"`
+    Email string `json:"email"`
+}
+
+type backupBrokerageAccount struct {
+    ID        uint      `json:"id"`
+    Name      string    `json:"name"`
+    Type      string    `json:"type"`
+    IsDefault bool      `json:"isDefault"`
+    CreatedAt time.Time `json:"createdAt"`
+}
+
+// 保有資産の accountId は brokerageAccounts の id を参照する
+type backupUsStock struct {
+    ID        uint      `json:"id"`
+    Code      string    `json:"code"`
+    GetPrice  float64   `json:"getPrice"`
+    Quantity  float64   `json:"quantity"`
+    Sector    string    `json:"sector"`
+    UsdJpy    float64   `json:"usdJpy"`
+    AccountId uint      `json:"accountId"`
+    CreatedAt time.Time `json:"createdAt"`
+}
+
+type backupCrypto struct {
+    ID        uint      `json:"id"`
+    Code      string    `json:"code"`
+    GetPrice  float64   `json:"getPrice"`
+    Quantity  float64   `json:"quantity"`
+    AccountId uint      `json:"accountId"`
+    CreatedAt time.Time `json:"createdAt"`
+}
+
+type backupJapanFund struct {
+    ID            uint      `json:"id"`
+    Code          string    `json:"code"`
+    Name          string    `json:"name"`
+    GetPrice      float64   `json:"getPrice"`
+    GetPriceTotal float64   `json:"getPriceTotal"`
+    AccountId     uint      `json:"accountId"`
+    CreatedAt     time.Time `json:"createdAt"`
+}
+
+type backupFixedIncomeAsset struct {
+    ID            uint      `json:"id"`
+    Code          string    `json:"code"`
+    GetPriceTotal float64   `json:"getPriceTotal"`
+    DividendRate  float64   `json:"dividendRate"`
+    UsdJpy        *float64  `json:"usdJpy"`
+    PaymentMonth  []int64   `json:"paymentMonth"`
+    IssueDate        *time.Time `json:"issueDate,omitempty"`
+    MaturityDate     *time.Time `json:"maturityDate,omitempty"`
+    CouponFrequency  string     `json:"couponFrequency,omitempty"`
+    Currency         string     `json:"currency,omitempty"`
+    RedemptionAmount *float64   `json:"redemptionAmount,omitempty"`
+    Status           string     `json:"status,omitempty"`
+    ValuationMode    string     `json:"valuationMode,omitempty"`
+    CleanPrice       *float64   `json:"cleanPrice,omitempty"`
+    PurchaseDate     *time.Time `json:"purchaseDate,omitempty"`
+    AccountId     uint      `json:"accountId"`
+    CreatedAt     time.Time `json:"createdAt"`
+}
+
+type backupTotalAsset struct {
+    ID               uint      `json:"id"`
+    CashJpy          float64   `json:"cashJpy"`
+    CashUsd          float64   `json:"cashUsd"`
+    Stock            float64   `json:"stock"`
+    Fund             float64   `json:"fund"`
+    Crypto           float64   `json:"crypto"`
+    FixedIncomeAsset float64   `json:"fixedIncomeAsset"`
+    UsdJpy           float64   `json:"usdJpy"`
+    Accounts         []backupTotalAssetAccount `json:"accounts"`
+    CreatedAt        time.Time `json:"createdAt"`
+}
+
+// 資産推移の口座別の内訳(口座が必須になる前に作成した、口座未設定の内訳は accountId が null)
+type backupTotalAssetAccount struct {
+    AccountId        *uint   `json:"accountId"`
+    Stock            float64 `json:"stock"`
+    Fund             float64 `json:"fund"`
+    Crypto           float64 `json:"crypto"`
+    FixedIncomeAsset float64 `json:"fixedIncomeAsset"`
+}
+
+type backupNisaPurchase struct {
+    ID          uint      `json:"id"`
+    Year        int       `json:"year"`
+    Frame       string    `json:"frame"`
+    HoldingType string    `json:"holdingType"`
+    Code        string    `json:"code"`
+    Amount      float64   `json:"amount"`
+    AccountId   uint      `json:"accountId"`
+    CreatedAt   time.Time `json:"createdAt"`
+}
+
+// 日付は YYYY-MM-DD の文字列で記録する
+type backupDividendReceipt struct {
+    ID          uint      `json:"id"`
+    Code        string    `json:"code"`
+    PaymentDate string    `json:"paymentDate"`
+    Amount      float64   `json:"amount"`
+    ForeignTax  float64   `json:"foreignTax"`
+    DomesticTax float64   `json:"domesticTax"`
+    UsdJpy      float64   `json:"usdJpy"`
+    CreatedAt   time.Time `json:"createdAt"`
+}
+
+type backupStockSale struct {
+    ID        uint      `json:"id"`
+    Code      string    `json:"code"`
+    TradeDate string    `json:"tradeDate"`
+    Quantity  float64   `json:"quantity"`
+    SalePrice float64   `json:"salePrice"`
+    GetPrice  float64   `json:"getPrice"`
+    Fee       float64   `json:"fee"`
+    UsdJpy    float64   `json:"usdJpy"`
+    GetUsdJpy float64   `json:"getUsdJpy"`
+    CreatedAt time.Time `json:"createdAt"`
+}
+
+// RestoreResult は復元した件数と、元のIDから新しいIDへの対応です
+type RestoreResult struct {
+    BrokerageAccounts RestoredItems `json:"brokerageAccounts"`
+    UsStocks          RestoredItems `json:"usStocks"`
+    Cryptos           RestoredItems `json:"cryptos"`
+    JapanFunds        RestoredItems `json:"japanFunds"`
+    FixedIncomeAssets RestoredItems `json:"fixedIncomeAssets"`
+    TotalAssets       RestoredItems `json:"totalAssets"`
+    NisaPurchases     RestoredItems `json:"nisaPurchases"`
+    DividendReceipts  RestoredItems `json:"dividendReceipts"`
+    StockSales        RestoredItems `json:"stockSales"`
+}
+
+type RestoredItems struct {
+    Count int           `json:"count"`
+    Ids   map[uint]uint `json:"ids"`
+}
+
+func convertToBackupDocument(dto repoBackup.BackupDto, exportedAt time.Time) backupDocument {
+    document := backupDocument{
+        Version:           backupVersion,
+        ExportedAt:        exportedAt,
+        User:              backupUser{Name: dto.UserName, Email: dto.UserEmail},
+        BrokerageAccounts: []backupBrokerageAccount{},
+        UsStocks:          []backupUsStock{},
+        Cryptos:           []backupCrypto{},
+        JapanFunds:        []backupJapanFund{},
+        FixedIncomeAssets: []backupFixedIncomeAsset{},
+        TotalAssets:       []backupTotalAsset{},
+        NisaPurchases:     []backupNisaPurchase{},
+        DividendReceipts:  []backupDividendReceipt{},
+        StockSales:        []backupStockSale{},
+    }
+    for _, account := range dto.BrokerageAccounts {
+        document.BrokerageAccounts = append(document.BrokerageAccounts, backupBrokerageAccount{
+            ID: account.ID, Name: account.Name, Type: account.Type, IsDefault: account.IsDefault, CreatedAt: account.CreatedAt,
+        })
+    }
+    for _, usStock := range dto.UsStocks {
+        document.UsStocks = append(document.UsStocks, backupUsStock{
+            ID: usStock.ID, Code: usStock.Code, GetPrice: usStock.GetPrice, Quantity: usStock.Quantity,
+            Sector: usStock.Sector, UsdJpy: usStock.UsdJpy, AccountId: usStock.AccountId, CreatedAt: usStock.CreatedAt,
+        })
+    }
+    for _, crypto := range dto.Cryptos {
+        document.Cryptos = append(document.Cryptos, backupCrypto{
+            ID: crypto.ID, Code: crypto.Code, GetPrice: crypto.GetPrice, Quantity: crypto.Quantity,
+            AccountId: crypto.AccountId, CreatedAt: crypto.CreatedAt,
+        })
+    }
+    for _, japanFund := range dto.JapanFunds {
+        document.JapanFunds = append(document.JapanFunds, backupJapanFund{
+            ID: japanFund.ID, Code: japanFund.Code, Name: japanFund.Name, GetPrice: japanFund.GetPrice,
+            GetPriceTotal: japanFund.GetPriceTotal, AccountId: japanFund.AccountId, CreatedAt: japanFund.CreatedAt,
+        })
+    }
+    for _, fixedIncomeAsset := range dto.FixedIncomeAssets {
+        paymentMonth := []int64{}
+        paymentMonth = append(paymentMonth, fixedIncomeAsset.PaymentMonth...)
+        document.FixedIncomeAssets = append(document.FixedIncomeAssets, backupFixedIncomeAsset{
+            ID: fixedIncomeAsset.ID, Code: fixedIncomeAsset.Code, GetPriceTotal: fixedIncomeAsset.GetPriceTotal,
+            DividendRate: fixedIncomeAsset.DividendRate, UsdJpy: fixedIncomeAsset.UsdJpy, PaymentMonth: paymentMonth,
+            IssueDate: fixedIncomeAsset.IssueDate, MaturityDate: fixedIncomeAsset.MaturityDate, CouponFrequency: fixedIncomeAsset.CouponFrequency,
+            Currency: fixedIncomeAsset.Currency, RedemptionAmount: fixedIncomeAsset.RedemptionAmount, Status: fixedIncomeAsset.Status,
+            ValuationMode: fixedIncomeAsset.ValuationMode, CleanPrice: fixedIncomeAsset.CleanPrice, PurchaseDate: fixedIncomeAsset.PurchaseDate,
+            AccountId: fixedIncomeAsset.AccountId, CreatedAt: fixedIncomeAsset.CreatedAt,
+        })
+    }
+    accountsByTotalAsset := map[uint][]backupTotalAssetAccount{}
+    for _, totalAssetAccount := range dto.TotalAssetAccounts {
+        accountsByTotalAsset[totalAssetAccount.TotalAssetId] = append(accountsByTotalAsset[totalAssetAccount.TotalAssetId], backupTotalAssetAccount{
+            AccountId: totalAssetAccount.AccountId, Stock: totalAssetAccount.Stock, Fund: totalAssetAccount.Fund,
+            Crypto: totalAssetAccount.Crypto, FixedIncomeAsset: totalAssetAccount.FixedIncomeAsset,
+        })
+    }
+    for _, totalAsset := range dto.TotalAssets {
+        accounts := []backupTotalAssetAccount{}
+        accounts = append(accounts, accountsByTotalAsset[totalAsset.ID]...)
+        document.TotalAssets = append(document.TotalAssets, backupTotalAsset{
+            ID: totalAsset.ID, CashJpy: totalAsset.CashJpy, CashUsd: totalAsset.CashUsd, Stock: totalAsset.Stock,
+            Fund: totalAsset.Fund, Crypto: totalAsset.Crypto, FixedIncomeAsset: totalAsset.FixedIncomeAsset,
+            UsdJpy: totalAsset.UsdJpy, Accounts: accounts, CreatedAt: totalAsset.CreatedAt,
+        })
+    }
+    for _, nisaPurchase := range dto.NisaPurchases {
+        document.NisaPurchases = append(document.NisaPurchases, backupNisaPurchase{
+            ID: nisaPurchase.ID, Year: nisaPurchase.Year, Frame: nisaPurchase.Frame, HoldingType: nisaPurchase.HoldingType,
+            Code: nisaPurchase.Code, Amount: nisaPurchase.Amount, AccountId: nisaPurchase.AccountId, CreatedAt: nisaPurchase.CreatedAt,
+        })
+    }
+    for _, dividendReceipt := range dto.DividendReceipts {
+        document.DividendReceipts = append(document.DividendReceipts, backupDividendReceipt{
+            ID: dividendReceipt.ID, Code: dividendReceipt.Code, PaymentDate: dividendReceipt.PaymentDate, Amount: dividendReceipt.Amount,
+            ForeignTax: dividendReceipt.ForeignTax, DomesticTax: dividendReceipt.DomesticTax, UsdJpy: dividendReceipt.UsdJpy, CreatedAt: dividendReceipt.CreatedAt,
+        })
+    }
+    for _, stockSale := range dto.StockSales {
+        document.StockSales = append(document.StockSales, backupStockSale{
+            ID: stockSale.ID, Code: stockSale.Code, TradeDate: stockSale.TradeDate, Quantity: stockSale.Quantity, SalePrice: stockSale.SalePrice,
+            GetPrice: stockSale.GetPrice, Fee: stockSale.Fee, UsdJpy: stockSale.UsdJpy, GetUsdJpy: stockSale.GetUsdJpy, CreatedAt: stockSale.CreatedAt,
+        })
+    }
+    return document
+}
+
+// バックアップの内容を検証する(エラーは項目の位置を含めて返す)
+func validateBackupDocument(document backupDocument) error {
+    if document.Version == 0 {
+        return fmt.Errorf("%w: version がありません", errInvalidBackup)
+    }
+    if document.Version != backupVersion {
+        return fmt.Errorf("%w: 対応していないバージョンです(version: %d)", errInvalidBackup, document.Version)
+    }
+    ids := map[string]map[uint]bool{}
+    checkId := func(field string, index int, id uint) error {
+        if id == 0 {
+            return fmt.Errorf("%w: %s[%d].id がありません", errInvalidBackup, field, index)
+        }
+        if ids[field] == nil {
+            ids[field] = map[uint]bool{}
+        }
+        if ids[field][id] {
+            return fmt.Errorf("%w: %s[%d].id が重複しています(id: %d)", errInvalidBackup, field, index, id)
+        }
+        ids[field][id] = true
+        return nil
+    }
+    // 口座は brokerageAccounts に含まれるものだけ参照できる
+    checkAccount := func(field string, index int, accountId uint) error {
+        if !ids["brokerageAccounts"][accountId] {
+            return fmt.Errorf("%w: %s[%d].accountId の口座がありません(accountId: %d)", errInvalidBackup, field, index, accountId)
+        }
+        return nil
+    }
+    defaultAccounts := 0
+    for i, account := range document.BrokerageAccounts {
+        if err := checkId("brokerageAccounts", i, account.ID); err != nil {
+            return err
+        }
+        if _, ok := validAccountTypes[account.Type]; account.Name == "" || !ok {
+            return fmt.Errorf("%w: brokerageAccounts[%d] の内容が正しくありません", errInvalidBackup, i)
+        }
+        if account.IsDefault {
+            defaultAccounts++
+        }
+    }
+    if defaultAccounts > 1 {
+        return fmt.Errorf("%w: 既定の口座が複数あります", errInvalidBackup)
+    }
+    for i, usStock := range document.UsStocks {
+        if err := checkId("usStocks", i, usStock.ID); err != nil {
+            return err
+        }
+        if err := checkAccount("usStocks", i, usStock.AccountId); err != nil {
+            return err
+        }
+        if usStock.Code == "" || usStock.Quantity < 0 || usStock.GetPrice < 0 || usStock.UsdJpy < 0 {
+            return fmt.Errorf("%w: usStocks[%d] の内容が正しくありません", errInvalidBackup, i)
+        }
+    }
+    for i, crypto := range document.Cryptos {
+        if err := checkId("cryptos", i, crypto.ID); err != nil {
+            return err
+        }
+        if err := checkAccount("cryptos", i, crypto.AccountId); err != nil {
+            return err
+        }
+        if crypto.Code == "" || crypto.Quantity < 0 || crypto.GetPrice < 0 {
+            return fmt.Errorf("%w: cryptos[%d] の内容が正しくありません", errInvalidBackup, i)
+        }
+    }
+    for i, japanFund := range document.JapanFunds {
+        if err := checkId("japanFunds", i, japanFund.ID); err != nil {
+            return err
+        }
+        if err := checkAccount("japanFunds", i, japanFund.AccountId); err != nil {
+            return err
+        }
+        if japanFund.Code == "" || japanFund.Name == "" || japanFund.GetPrice < 0 || japanFund.GetPriceTotal < 0 {
+            return fmt.Errorf("%w: japanFunds[%d] の内容が正しくありません", errInvalidBackup, i)
+        }
+    }
+    for i, fixedIncomeAsset := range document.FixedIncomeAssets {
+        if err := checkId("fixedIncomeAssets", i, fixedIncomeAsset.ID); err != nil {
+            return err
+        }
+        if err := checkAccount("fixedIncomeAssets", i, fixedIncomeAsset.AccountId); err != nil {
+            return err
+        }
+        if fixedIncomeAsset.Code == "" || fixedIncomeAsset.GetPriceTotal < 0 || fixedIncomeAsset.DividendRate < 0 {
+            return fmt.Errorf("%w: fixedIncomeAssets[%d] の内容が正しくありません", errInvalidBackup, i)
+        }
+        for _, month := range fixedIncomeAsset.PaymentMonth {
+            if month < 1 || month > 12 {
+                return fmt.Errorf("%w: fixedIncomeAssets[%d].paymentMonth は1〜12で指定してください", errInvalidBackup, i)
+            }
+        }
+        // 満期日などは後から追加した項目のため、未設定のバックアップも受け付ける
+        if fixedIncomeAsset.Currency != "" && fixedIncomeAsset.Currency != repoFixedIncome.CurrencyJpy && fixedIncomeAsset.Currency != repoFixedIncome.CurrencyUsd {
+            return fmt.Errorf("%w: fixedIncomeAssets[%d].currency が正しくありません", errInvalidBackup, i)
+        }
+        if fixedIncomeAsset.Status != "" && fixedIncomeAsset.Status != repoFixedIncome.StatusActive && fixedIncomeAsset.Status != repoFixedIncome.StatusMatured {
+            return fmt.Errorf("%w: fixedIncomeAssets[%d].status が正しくありません", errInvalidBackup, i)
+        }
+        if _, ok := validValuationModes[fixedIncomeAsset.ValuationMode]; fixedIncomeAsset.ValuationMode != "" && !ok {
+            return fmt.Errorf("%w: fixedIncomeAssets[%d].valuationMode が正しくありません", errInvalidBackup, i)
+        }
+    }
+    for i, totalAsset := range document.TotalAssets {
+        if err := checkId("totalAssets", i, totalAsset.ID); err != nil {
+            return err
+        }
+        // 資産推移は作成日時を日付として扱うため必須
+        if totalAsset.CreatedAt.IsZero() {
+            return fmt.Errorf("%w: totalAssets[%d].createdAt がありません", errInvalidBackup, i)
+        }
+        for j, account := range totalAsset.Accounts {
+            if account.AccountId != nil && !ids["brokerageAccounts"][*account.AccountId] {
+                return fmt.Errorf("%w: totalAssets[%d].accounts[%d].accountId の口座がありません(accountId: %d)", errInvalidBackup, i, j, *account.AccountId)
+            }
+        }
+    }
+    for i, nisaPurchase := range document.NisaPurchases {
+        if err := checkId("nisaPurchases", i, nisaPurchase.ID); err != nil {
+            return err
+        }
+        if err := checkAccount("nisaPurchases", i, nisaPurchase.AccountId); err != nil {
+            return err
+        }
+        if _, ok := validNisaFrames[nisaPurchase.Frame]; !ok || nisaPurchase.Year <= 0 || nisaPurchase.HoldingType == "" || nisaPurchase.Code == "" || nisaPurchase.Amount < 0 {
+            return fmt.Errorf("%w: nisaPurchases[%d] の内容が正しくありません", errInvalidBackup, i)
+        }
+    }
+    for i, dividendReceipt := range document.DividendReceipts {
+        if err := checkId("dividendReceipts", i, dividendReceipt.ID); err != nil {
+            return err
+        }
+        if _, err := time.Parse("2006-01-02", dividendReceipt.PaymentDate); err != nil || dividendReceipt.Code == "" || dividendReceipt.Amount < 0 || dividendReceipt.ForeignTax < 0 || dividendReceipt.DomesticTax < 0 || dividendReceipt.UsdJpy < 0 {
+            return fmt.Errorf("%w: dividendReceipts[%d] の内容が正しくありません", errInvalidBackup, i)
+        }
+    }
+    for i, stockSale := range document.StockSales {
+        if err := checkId("stockSales", i, stockSale.ID); err != nil {
+            return err
+        }
+        if _, err := time.Parse("2006-01-02", stockSale.TradeDate); err != nil || stockSale.Code == "" || stockSale.Quantity <= 0 || stockSale.SalePrice < 0 || stockSale.GetPrice < 0 || stockSale.Fee < 0 || stockSale.UsdJpy < 0 || stockSale.GetUsdJpy < 0 {
+            return fmt.Errorf("%w: stockSales[%d] の内容が正しくありません", errInvalidBackup, i)
+        }
+    }
+    return nil
+}
+
+// バックアップを復元用のデータに変換する(IDは復元時に振り直すため、ここでは元のIDを保持する)
+func convertToBackupDto(document backupDocument, userId uint) repoBackup.BackupDto {
+    dto := repoBackup.BackupDto{UserId: userId, UserName: document.User.Name}
+    for _, account := range document.BrokerageAccounts {
+        dto.BrokerageAccounts = append(dto.BrokerageAccounts, model.BrokerageAccount{
+            Model: gorm.Model{ID: account.ID, CreatedAt: account.CreatedAt}, Name: account.Name, Type: account.Type, IsDefault: account.IsDefault,
+        })
+    }
+    for _, usStock := range document.UsStocks {
+        dto.UsStocks = append(dto.UsStocks, model.UsStock{
+            Model: gorm.Model{ID: usStock.ID, CreatedAt: usStock.CreatedAt}, Code: usStock.Code, GetPrice: usStock.GetPrice,
+            Quantity: usStock.Quantity, Sector: usStock.Sector, UsdJpy: usStock.UsdJpy, AccountId: usStock.AccountId,
+        })
+    }
+    for _, crypto := range document.Cryptos {
+        dto.Cryptos = append(dto.Cryptos, model.Crypto{
+            Model: gorm.Model{ID: crypto.ID, CreatedAt: crypto.CreatedAt}, Code: crypto.Code, GetPrice: crypto.GetPrice, Quantity: crypto.Quantity,
+            AccountId: crypto.AccountId,
+        })
+    }
+    for _, japanFund := range document.JapanFunds {
+        dto.JapanFunds = append(dto.JapanFunds, model.JapanFund{
+            Model: gorm.Model{ID: japanFund.ID, CreatedAt: japanFund.CreatedAt}, Code: japanFund.Code, Name: japanFund.Name,
+            GetPrice: japanFund.GetPrice, GetPriceTotal: japanFund.GetPriceTotal, AccountId: japanFund.AccountId,
+        })
+    }
+    for _, fixedIncomeAsset := range document.FixedIncomeAssets {
+        dto.FixedIncomeAssets = append(dto.FixedIncomeAssets, model.FixedIncomeAsset{
+            Model: gorm.Model{ID: fixedIncomeAsset.ID, CreatedAt: fixedIncomeAsset.CreatedAt}, Code: fixedIncomeAsset.Code,
+            GetPriceTotal: fixedIncomeAsset.GetPriceTotal, DividendRate: fixedIncomeAsset.DividendRate,
+            UsdJpy: fixedIncomeAsset.UsdJpy, PaymentMonth: pq.Int64Array(fixedIncomeAsset.PaymentMonth),
+            IssueDate: fixedIncomeAsset.IssueDate, MaturityDate: fixedIncomeAsset.MaturityDate, CouponFrequency: fixedIncomeAsset.CouponFrequency,
+            Currency: fixedIncomeAsset.Currency, RedemptionAmount: fixedIncomeAsset.RedemptionAmount, Status: fixedIncomeAsset.Status,
+            ValuationMode: fixedIncomeAsset.ValuationMode, CleanPrice: fixedIncomeAsset.CleanPrice, PurchaseDate: fixedIncomeAsset.PurchaseDate,
+            AccountId: fixedIncomeAsset.AccountId,
+        })
+    }
+    for _, totalAsset := range document.TotalAssets {
+        dto.TotalAssets = append(dto.TotalAssets, model.TotalAsset{
+            Model: gorm.Model{ID: totalAsset.ID, CreatedAt: totalAsset.CreatedAt}, CashJpy: totalAsset.CashJpy, CashUsd: totalAsset.CashUsd,
+            Stock: totalAsset.Stock, Fund: totalAsset.Fund, Crypto: totalAsset.Crypto, FixedIncomeAsset: totalAsset.FixedIncomeAsset,
+            UsdJpy: totalAsset.UsdJpy,
+        })
+        for _, account := range totalAsset.Accounts {
+            dto.TotalAssetAccounts = append(dto.TotalAssetAccounts, model.TotalAssetAccount{
+                Model: gorm.Model{CreatedAt: totalAsset.CreatedAt}, TotalAssetId: totalAsset.ID, AccountId: account.AccountId,
+                Stock: account.Stock, Fund: account.Fund, Crypto: account.Crypto, FixedIncomeAsset: account.FixedIncomeAsset,
+            })
+        }
+    }
+    for _, nisaPurchase := range document.NisaPurchases {
+        dto.NisaPurchases = append(dto.NisaPurchases, model.NisaPurchase{
+            Model: gorm.Model{ID: nisaPurchase.ID, CreatedAt: nisaPurchase.CreatedAt}, Year: nisaPurchase.Year, Frame: nisaPurchase.Frame,
+            HoldingType: nisaPurchase.HoldingType, Code: nisaPurchase.Code, Amount: nisaPurchase.Amount, AccountId: nisaPurchase.AccountId,
+        })
+    }
+    for _, dividendReceipt := range document.DividendReceipts {
+        dto.DividendReceipts = append(dto.DividendReceipts, model.DividendReceipt{
+            Model: gorm.Model{ID: dividendReceipt.ID, CreatedAt: dividendReceipt.CreatedAt}, Code: dividendReceipt.Code, PaymentDate: dividendReceipt.PaymentDate,
+            Amount: dividendReceipt.Amount, ForeignTax: dividendReceipt.ForeignTax, DomesticTax: dividendReceipt.DomesticTax, UsdJpy: dividendReceipt.UsdJpy,
+        })
+    }
+    for _, stockSale := range document.StockSales {
+        dto.StockSales = append(dto.StockSales, model.StockSale{
+            Model: gorm.Model{ID: stockSale.ID, CreatedAt: stockSale.CreatedAt}, Code: stockSale.Code, TradeDate: stockSale.TradeDate, Quantity: stockSale.Quantity,
+            SalePrice: stockSale.SalePrice, GetPrice: stockSale.GetPrice, Fee: stockSale.Fee, UsdJpy: stockSale.UsdJpy, GetUsdJpy: stockSale.GetUsdJpy,
+        })
+    }
+    return dto
+}
+
+// 元のIDと復元後のIDの対応を作る(リポジトリはバックアップと同じ順序で作成する)
+func createRestoreResult(dto repoBackup.BackupDto, restored repoBackup.BackupDto) *RestoreResult {
+    result := &RestoreResult{}
+    result.BrokerageAccounts = newRestoredItems(len(restored.BrokerageAccounts))
+    for i, account := range restored.BrokerageAccounts {
+        result.BrokerageAccounts.Ids[dto.BrokerageAccounts[i].ID] = account.ID
+    }
+    result.UsStocks = newRestoredItems(len(restored.UsStocks))
+    for i, usStock := range restored.UsStocks {
+        result.UsStocks.Ids[dto.UsStocks[i].ID] = usStock.ID
+    }
+    result.Cryptos = newRestoredItems(len(restored.Cryptos))
+    for i, crypto := range restored.Cryptos {
+        result.Cryptos.Ids[dto.Cryptos[i].ID] = crypto.ID
+    }
+    result.JapanFunds = newRestoredItems(len(restored.JapanFunds))
+    for i, japanFund := range restored.JapanFunds {
+        result.JapanFunds.Ids[dto.JapanFunds[i].ID] = japanFund.ID
+    }
+    result.FixedIncomeAssets = newRestoredItems(len(restored.FixedIncomeAssets))
+    for i, fixedIncomeAsset := range restored.FixedIncomeAssets {
+        result.FixedIncomeAssets.Ids[dto.FixedIncomeAssets[i].ID] = fixedIncomeAsset.ID
+    }
+    result.TotalAssets = newRestoredItems(len(restored.TotalAssets))
+    for i, totalAsset := range restored.TotalAssets {
+        result.TotalAssets.Ids[dto.TotalAssets[i].ID] = totalAsset.ID
+    }
+    result.NisaPurchases = newRestoredItems(len(restored.NisaPurchases))
+    for i, nisaPurchase := range restored.NisaPurchases {
+        result.NisaPurchases.Ids[dto.NisaPurchases[i].ID] = nisaPurchase.ID
+    }
+    result.DividendReceipts = newRestoredItems(len(restored.DividendReceipts))
+    for i, dividendReceipt := range restored.DividendReceipts {
+        result.DividendReceipts.Ids[dto.DividendReceipts[i].ID] = dividendReceipt.ID
+    }
+    result.StockSales = newRestoredItems(len(restored.StockSales))
+    for i, stockSale := range restored.StockSales {
+        result.StockSales.Ids[dto.StockSales[i].ID] = stockSale.ID
+    }
+    return result
+}
+
+func newRestoredItems(count int) RestoredItems {
+    return RestoredItems{Count: count, Ids: map[uint]uint{}}
+}
@@ -0,0 +1,69 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"io"
+	"my-us-stock-backend/app/graphql/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 復元できるバックアップの最大サイズ
+const maxBackupSize = 10 << 20
+
+type BackupController struct {
+    BackupService BackupService
+}
+
+func NewBackupController(backupService BackupService) *BackupController {
+    return &BackupController{BackupService: backupService}
+}
+
+// GetBackup はログインユーザーのデータをまとめたJSONをダウンロードさせます
+func (bc *BackupController) GetBackup(c *gin.Context) {
+    data, err := bc.BackupService.ExportBackup(withAccessToken(c))
+    if err != nil {
+        respondError(c, err)
+        return
+    }
+    filename := "backup-" + time.Now().Format("20060102") + ".json"
+    c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+    c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
+
+// RestoreBackup はリクエストボディのバックアップを空のアカウントに復元します
+func (bc *BackupController) RestoreBackup(c *gin.Context) {
+    data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBackupSize))
+    if err != nil {
+        c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "backup must be 10MB or less"})
+        return
+    }
+    result, err := bc.BackupService.RestoreBackup(withAccessToken(c), data)
+    if err != nil {
+        respondError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, result)
+}
+
+// 認証はGraphQLと同じくCookieのアクセストークンで行う
+func withAccessToken(c *gin.Context) context.Context {
+    accessToken, _ := c.Cookie("access_token")
+    return context.WithValue(c.Request.Context(), utils.CookieKey, accessToken)
+}
+
+func respondError(c *gin.Context, err error) {
+    switch {
+    case errors.Is(err, errUnauthenticated):
+        c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+    case errors.Is(err, errInvalidBackup):
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+    case errors.Is(err, errAccountNotEmpty):
+        c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+    default:
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+    }
+}
@@ -0,0 +1,104 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"my-us-stock-backend/app/graphql/utils"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBackupService の定義
+type MockBackupService struct {
+    mock.Mock
+}
+
+func (m *MockBackupService) ExportBackup(ctx context.Context) ([]byte, error) {
+    args := m.Called(ctx)
+    return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockBackupService) RestoreBackup(ctx context.Context, data []byte) (*RestoreResult, error) {
+    args := m.Called(ctx, data)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*RestoreResult), args.Error(1)
+}
+
+func TestBackupController_GetBackup(t *testing.T) {
+    mockService := new(MockBackupService)
+    controller := NewBackupController(mockService)
+
+    // Cookieのアクセストークンがサービスに渡されること
+    hasToken := mock.MatchedBy(func(ctx context.Context) bool {
+        return ctx.Value(utils.CookieKey) == "token"
+    })
+    mockService.On("ExportBackup", hasToken).Return([]byte(`{"version": 1}`), nil)
+
+    req, _ := http.NewRequest(http.MethodGet, "/api/v1/backup", nil)
+    req.AddCookie(&http.Cookie{Name: "access_token", Value: "token"})
+    w := httptest.NewRecorder()
+    c, _ := gin.CreateTestContext(w)
+    c.Request = req
+
+    controller.GetBackup(c)
+
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+    assert.Regexp(t, `^attachment; filename="backup-\d{8}\.json"$`, w.Header().Get("Content-Disposition"))
+    assert.Equal(t, `{"version": 1}`, w.Body.String())
+    mockService.AssertExpectations(t)
+}
+
+func TestBackupController_RestoreBackup(t *testing.T) {
+    mockService := new(MockBackupService)
+    controller := NewBackupController(mockService)
+
+    result := &RestoreResult{UsStocks: RestoredItems{Count: 1, Ids: map[uint]uint{3: 21}}}
+    mockService.On("RestoreBackup", mock.Anything, []byte(`{"version": 1}`)).Return(result, nil)
+
+    req, _ := http.NewRequest(http.MethodPost, "/api/v1/backup/restore", strings.NewReader(`{"version": 1}`))
+    w := httptest.NewRecorder()
+    c, _ := gin.CreateTestContext(w)
+    c.Request = req
+
+    controller.RestoreBackup(c)
+
+    assert.Equal(t, http.StatusOK, w.Code)
+    var response map[string]interface{}
+    assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+    assert.Equal(t, map[string]interface{}{"count": 1.0, "ids": map[string]interface{}{"3": 21.0}}, response["usStocks"])
+}
+
+func TestBackupController_Errors(t *testing.T) {
+    tests := []struct {
+        err        error
+        statusCode int
+    }{
+        {errUnauthenticated, http.StatusUnauthorized},
+        {errInvalidBackup, http.StatusBadRequest},
+        {errAccountNotEmpty, http.StatusConflict},
+        {context.DeadlineExceeded, http.StatusInternalServerError},
+    }
+    for _, tt := range tests {
+        mockService := new(MockBackupService)
+        controller := NewBackupController(mockService)
+        mockService.On("RestoreBackup", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+        req, _ := http.NewRequest(http.MethodPost, "/api/v1/backup/restore", strings.NewReader(`{}`))
+        w := httptest.NewRecorder()
+        c, _ := gin.CreateTestContext(w)
+        c.Request = req
+
+        controller.RestoreBackup(c)
+
+        assert.Equal(t, tt.statusCode, w.Code)
+    }
+}
@@ -0,0 +1,78 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"my-us-stock-backend/app/common/auth"
+	repoBackup "my-us-stock-backend/app/repository/backup"
+	"time"
+)
+
+var (
+    errUnauthenticated = errors.New("unauthenticated")
+    errInvalidBackup   = errors.New("invalid backup")
+    errAccountNotEmpty = repoBackup.ErrAccountNotEmpty
+)
+
+// BackupService インターフェースの定義
+type BackupService interface {
+    ExportBackup(ctx context.Context) ([]byte, error)
+    RestoreBackup(ctx context.Context, data []byte) (*RestoreResult, error)
+}
+
+// DefaultBackupService 構造体の定義
+type DefaultBackupService struct {
+    Auth auth.AuthService
+    BackupRepo repoBackup.BackupRepository
+}
+
+// NewBackupService は DefaultBackupService の新しいインスタンスを作成します
+func NewBackupService(auth auth.AuthService, backupRepo repoBackup.BackupRepository) BackupService {
+    return &DefaultBackupService{
+        Auth: auth,
+        BackupRepo: backupRepo,
+    }
+}
+
+// ExportBackup はログインユーザーのプロフィール・口座・保有資産・資産推移・NISAの購入記録・配当の受取記録・売却記録を1つのJSONとして出力します
+func (s *DefaultBackupService) ExportBackup(ctx context.Context) ([]byte, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, errUnauthenticated
+    }
+    dto, err := s.BackupRepo.FetchBackup(ctx, userId)
+    if err != nil {
+        return nil, err
+    }
+    return json.MarshalIndent(convertToBackupDocument(*dto, time.Now()), "", "  ")
+}
+
+// RestoreBackup はバックアップのJSONをログインユーザーのアカウントに復元します
+// 復元先のアカウントは口座・保有資産・資産推移・配当の受取記録・売却記録が空である必要があります
+func (s *DefaultBackupService) RestoreBackup(ctx context.Context, data []byte) (*RestoreResult, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return nil, errUnauthenticated
+    }
+
+    // 未知の項目がある場合は別のバージョンの形式とみなしてエラーにする
+    decoder := json.NewDecoder(bytes.NewReader(data))
+    decoder.DisallowUnknownFields()
+    var document backupDocument
+    if err := decoder.Decode(&document); err != nil {
+        return nil, fmt.Errorf("%w: %s", errInvalidBackup, err.Error())
+    }
+    if err := validateBackupDocument(document); err != nil {
+        return nil, err
+    }
+
+    dto := convertToBackupDto(document, userId)
+    restored, err := s.BackupRepo.RestoreBackup(ctx, dto)
+    if err != nil {
+        return nil, err
+    }
+    return createRestoreResult(dto, *restored), nil
+}
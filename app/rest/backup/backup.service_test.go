@@ -0,0 +1,181 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/database/model"
+	repoBackup "my-us-stock-backend/app/repository/backup"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type testMocks struct {
+    auth       *auth.MockAuthService
+    backupRepo *repoBackup.MockBackupRepository
+}
+
+func newTestService() (BackupService, testMocks) {
+    mocks := testMocks{
+        auth:       auth.NewMockAuthService(),
+        backupRepo: repoBackup.NewMockBackupRepository(),
+    }
+    service := NewBackupService(mocks.auth, mocks.backupRepo)
+    return service, mocks
+}
+
+func TestExportBackup(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    usdJpy := 150.0
+    accountId := uint(2)
+    createdAt := time.Date(2024, 1, 10, 1, 0, 0, 0, time.UTC)
+    mocks.backupRepo.On("FetchBackup", mock.Anything, uint(1)).Return(&repoBackup.BackupDto{
+        UserId:            1,
+        UserName:          "taro",
+        UserEmail:         "taro@example.com",
+        BrokerageAccounts: []model.BrokerageAccount{{Model: gorm.Model{ID: 2, CreatedAt: createdAt}, Name: "SBI NISA", Type: "NISA_GROWTH", UserId: 1}},
+        UsStocks:          []model.UsStock{{Model: gorm.Model{ID: 3, CreatedAt: createdAt}, Code: "AAPL", GetPrice: 150, Quantity: 10, Sector: "Technology", UsdJpy: 140, AccountId: 2, UserId: 1}},
+        FixedIncomeAssets: []model.FixedIncomeAsset{{Model: gorm.Model{ID: 5}, Code: "米国債", GetPriceTotal: 1000000, DividendRate: 4.5, UsdJpy: &usdJpy, PaymentMonth: pq.Int64Array{5, 11}, AccountId: 2, UserId: 1}},
+        TotalAssets:       []model.TotalAsset{{Model: gorm.Model{ID: 8, CreatedAt: createdAt}, CashJpy: 100000, UserId: 1}},
+        TotalAssetAccounts: []model.TotalAssetAccount{{TotalAssetId: 8, AccountId: &accountId, Stock: 210000, UserId: 1}},
+        NisaPurchases:     []model.NisaPurchase{{Model: gorm.Model{ID: 4, CreatedAt: createdAt}, Year: 2024, Frame: "GROWTH", HoldingType: "US_STOCK", Code: "AAPL", Amount: 210000, AccountId: 2, UserId: 1}},
+        DividendReceipts:  []model.DividendReceipt{{Model: gorm.Model{ID: 6, CreatedAt: createdAt}, Code: "AAPL", PaymentDate: "2024-02-15", Amount: 2.4, ForeignTax: 0.24, DomesticTax: 65, UsdJpy: 150, UserId: 1}},
+        StockSales:        []model.StockSale{{Model: gorm.Model{ID: 7, CreatedAt: createdAt}, Code: "KO", TradeDate: "2024-03-01", Quantity: 5, SalePrice: 60, GetPrice: 55, Fee: 1, UsdJpy: 150, GetUsdJpy: 140, UserId: 1}},
+    }, nil)
+
+    data, err := service.ExportBackup(context.Background())
+
+    assert.NoError(t, err)
+    var document map[string]interface{}
+    assert.NoError(t, json.Unmarshal(data, &document))
+    assert.Equal(t, 2.0, document["version"])
+    assert.Equal(t, map[string]interface{}{"name": "taro", "email": "taro@example.com"}, document["user"])
+    assert.Equal(t, map[string]interface{}{
+        "id": 2.0, "name": "SBI NISA", "type": "NISA_GROWTH", "isDefault": false, "createdAt": "2024-01-10T01:00:00Z",
+    }, document["brokerageAccounts"].([]interface{})[0])
+    assert.Equal(t, map[string]interface{}{
+        "id": 3.0, "code": "AAPL", "getPrice": 150.0, "quantity": 10.0, "sector": "Technology", "usdJpy": 140.0, "accountId": 2.0, "createdAt": "2024-01-10T01:00:00Z",
+    }, document["usStocks"].([]interface{})[0])
+    assert.Equal(t, []interface{}{map[string]interface{}{
+        "accountId": 2.0, "stock": 210000.0, "fund": 0.0, "crypto": 0.0, "fixedIncomeAsset": 0.0,
+    }}, document["totalAssets"].([]interface{})[0].(map[string]interface{})["accounts"])
+    assert.Equal(t, map[string]interface{}{
+        "id": 4.0, "year": 2024.0, "frame": "GROWTH", "holdingType": "US_STOCK", "code": "AAPL", "amount": 210000.0, "accountId": 2.0, "createdAt": "2024-01-10T01:00:00Z",
+    }, document["nisaPurchases"].([]interface{})[0])
+    assert.Equal(t, []interface{}{5.0, 11.0}, document["fixedIncomeAssets"].([]interface{})[0].(map[string]interface{})["paymentMonth"])
+    assert.Equal(t, map[string]interface{}{
+        "id": 6.0, "code": "AAPL", "paymentDate": "2024-02-15", "amount": 2.4, "foreignTax": 0.24, "domesticTax": 65.0, "usdJpy": 150.0, "createdAt": "2024-01-10T01:00:00Z",
+    }, document["dividendReceipts"].([]interface{})[0])
+    assert.Equal(t, map[string]interface{}{
+        "id": 7.0, "code": "KO", "tradeDate": "2024-03-01", "quantity": 5.0, "salePrice": 60.0, "getPrice": 55.0, "fee": 1.0, "usdJpy": 150.0, "getUsdJpy": 140.0, "createdAt": "2024-01-10T01:00:00Z",
+    }, document["stockSales"].([]interface{})[0])
+    // 保有していない資産も空の配列として出力する
+    assert.Equal(t, []interface{}{}, document["cryptos"])
+    assert.NotContains(t, string(data), "password")
+}
+
+func TestRestoreBackup(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(2), nil)
+    createdAt := time.Date(2024, 1, 10, 1, 0, 0, 0, time.UTC)
+    accountId := uint(5)
+    expectedDto := repoBackup.BackupDto{
+        UserId:             2,
+        UserName:           "taro",
+        BrokerageAccounts:  []model.BrokerageAccount{{Model: gorm.Model{ID: 5, CreatedAt: createdAt}, Name: "SBI NISA", Type: "NISA_GROWTH"}},
+        UsStocks:           []model.UsStock{{Model: gorm.Model{ID: 3, CreatedAt: createdAt}, Code: "AAPL", GetPrice: 150, Quantity: 10, Sector: "Technology", UsdJpy: 140, AccountId: 5}},
+        TotalAssets:        []model.TotalAsset{{Model: gorm.Model{ID: 8, CreatedAt: createdAt}, CashJpy: 100000}},
+        TotalAssetAccounts: []model.TotalAssetAccount{{Model: gorm.Model{CreatedAt: createdAt}, TotalAssetId: 8, AccountId: &accountId, Stock: 210000}},
+        NisaPurchases:      []model.NisaPurchase{{Model: gorm.Model{ID: 4, CreatedAt: createdAt}, Year: 2024, Frame: "GROWTH", HoldingType: "US_STOCK", Code: "AAPL", Amount: 210000, AccountId: 5}},
+        DividendReceipts:   []model.DividendReceipt{{Model: gorm.Model{ID: 6, CreatedAt: createdAt}, Code: "AAPL", PaymentDate: "2024-02-15", Amount: 2.4, ForeignTax: 0.24, DomesticTax: 65, UsdJpy: 150}},
+        StockSales:         []model.StockSale{{Model: gorm.Model{ID: 7, CreatedAt: createdAt}, Code: "KO", TradeDate: "2024-03-01", Quantity: 5, SalePrice: 60, GetPrice: 55, Fee: 1, UsdJpy: 150, GetUsdJpy: 140}},
+    }
+    mocks.backupRepo.On("RestoreBackup", mock.Anything, expectedDto).Return(&repoBackup.BackupDto{
+        UserId:            2,
+        BrokerageAccounts: []model.BrokerageAccount{{Model: gorm.Model{ID: 30}, UserId: 2}},
+        UsStocks:          []model.UsStock{{Model: gorm.Model{ID: 21}, Code: "AAPL", UserId: 2}},
+        TotalAssets:       []model.TotalAsset{{Model: gorm.Model{ID: 40}, UserId: 2}},
+        NisaPurchases:     []model.NisaPurchase{{Model: gorm.Model{ID: 50}, UserId: 2}},
+        DividendReceipts:  []model.DividendReceipt{{Model: gorm.Model{ID: 60}, UserId: 2}},
+        StockSales:        []model.StockSale{{Model: gorm.Model{ID: 70}, UserId: 2}},
+    }, nil)
+
+    result, err := service.RestoreBackup(context.Background(), []byte(`{
+        "version": 2,
+        "exportedAt": "2024-02-01T00:00:00Z",
+        "user": {"name": "taro", "email": "taro@example.com"},
+        "brokerageAccounts": [{"id": 5, "name": "SBI NISA", "type": "NISA_GROWTH", "isDefault": false, "createdAt": "2024-01-10T01:00:00Z"}],
+        "usStocks": [{"id": 3, "code": "AAPL", "getPrice": 150, "quantity": 10, "sector": "Technology", "usdJpy": 140, "accountId": 5, "createdAt": "2024-01-10T01:00:00Z"}],
+        "cryptos": [],
+        "totalAssets": [{"id": 8, "cashJpy": 100000, "accounts": [{"accountId": 5, "stock": 210000}], "createdAt": "2024-01-10T01:00:00Z"}],
+        "nisaPurchases": [{"id": 4, "year": 2024, "frame": "GROWTH", "holdingType": "US_STOCK", "code": "AAPL", "amount": 210000, "accountId": 5, "createdAt": "2024-01-10T01:00:00Z"}],
+        "dividendReceipts": [{"id": 6, "code": "AAPL", "paymentDate": "2024-02-15", "amount": 2.4, "foreignTax": 0.24, "domesticTax": 65, "usdJpy": 150, "createdAt": "2024-01-10T01:00:00Z"}],
+        "stockSales": [{"id": 7, "code": "KO", "tradeDate": "2024-03-01", "quantity": 5, "salePrice": 60, "getPrice": 55, "fee": 1, "usdJpy": 150, "getUsdJpy": 140, "createdAt": "2024-01-10T01:00:00Z"}]
+    }`))
+
+    assert.NoError(t, err)
+    assert.Equal(t, RestoredItems{Count: 1, Ids: map[uint]uint{5: 30}}, result.BrokerageAccounts)
+    assert.Equal(t, RestoredItems{Count: 1, Ids: map[uint]uint{3: 21}}, result.UsStocks)
+    assert.Equal(t, RestoredItems{Count: 1, Ids: map[uint]uint{8: 40}}, result.TotalAssets)
+    assert.Equal(t, RestoredItems{Count: 1, Ids: map[uint]uint{4: 50}}, result.NisaPurchases)
+    assert.Equal(t, RestoredItems{Count: 1, Ids: map[uint]uint{6: 60}}, result.DividendReceipts)
+    assert.Equal(t, RestoredItems{Count: 1, Ids: map[uint]uint{7: 70}}, result.StockSales)
+    assert.Equal(t, 0, result.Cryptos.Count)
+    mocks.backupRepo.AssertExpectations(t)
+}
+
+func TestRestoreBackupInvalid(t *testing.T) {
+    tests := []struct {
+        name    string
+        data    string
+        message string
+    }{
+        {"JSONでない", `usStocks`, "invalid backup: invalid character 'u' looking for beginning of value"},
+        {"バージョンなし", `{"usStocks": []}`, "invalid backup: version がありません"},
+        {"未対応のバージョン", `{"version": 3}`, "invalid backup: 対応していないバージョンです(version: 3)"},
+        {"公開前のバージョン", `{"version": 1}`, "invalid backup: 対応していないバージョンです(version: 1)"},
+        {"未知の項目", `{"version": 2, "stocks": []}`, `invalid backup: json: unknown field "stocks"`},
+        {"IDの重複", `{"version": 2, "brokerageAccounts": [{"id": 1, "name": "口座", "type": "TAXABLE"}], "cryptos": [{"id": 1, "code": "btc", "accountId": 1}, {"id": 1, "code": "eth", "accountId": 1}]}`, "invalid backup: cryptos[1].id が重複しています(id: 1)"},
+        {"コードなし", `{"version": 2, "brokerageAccounts": [{"id": 1, "name": "口座", "type": "TAXABLE"}], "usStocks": [{"id": 1, "quantity": 10, "accountId": 1}]}`, "invalid backup: usStocks[0] の内容が正しくありません"},
+        {"支払月の範囲外", `{"version": 2, "brokerageAccounts": [{"id": 1, "name": "口座", "type": "TAXABLE"}], "fixedIncomeAssets": [{"id": 1, "code": "社債", "paymentMonth": [13], "accountId": 1}]}`, "invalid backup: fixedIncomeAssets[0].paymentMonth は1〜12で指定してください"},
+        {"資産推移の日付なし", `{"version": 2, "totalAssets": [{"id": 1, "cashJpy": 100}]}`, "invalid backup: totalAssets[0].createdAt がありません"},
+        {"配当の支払日が不正", `{"version": 2, "dividendReceipts": [{"id": 1, "code": "AAPL", "paymentDate": "2024/05/16", "amount": 2.4}]}`, "invalid backup: dividendReceipts[0] の内容が正しくありません"},
+        {"売却数量なし", `{"version": 2, "stockSales": [{"id": 1, "code": "AAPL", "tradeDate": "2024-03-01", "quantity": 0}]}`, "invalid backup: stockSales[0] の内容が正しくありません"},
+        {"口座の種類が無効", `{"version": 2, "brokerageAccounts": [{"id": 1, "name": "口座", "type": "SAVINGS"}]}`, "invalid backup: brokerageAccounts[0] の内容が正しくありません"},
+        {"既定の口座が複数", `{"version": 2, "brokerageAccounts": [{"id": 1, "name": "口座A", "type": "TAXABLE", "isDefault": true}, {"id": 2, "name": "口座B", "type": "TAXABLE", "isDefault": true}]}`, "invalid backup: 既定の口座が複数あります"},
+        {"保有資産の口座なし", `{"version": 2, "cryptos": [{"id": 1, "code": "btc", "accountId": 3}]}`, "invalid backup: cryptos[0].accountId の口座がありません(accountId: 3)"},
+        {"内訳の口座なし", `{"version": 2, "totalAssets": [{"id": 1, "accounts": [{"accountId": 3}], "createdAt": "2024-01-10T01:00:00Z"}]}`, "invalid backup: totalAssets[0].accounts[0].accountId の口座がありません(accountId: 3)"},
+        {"投資枠が無効", `{"version": 2, "brokerageAccounts": [{"id": 1, "name": "NISA", "type": "NISA_GROWTH"}], "nisaPurchases": [{"id": 1, "year": 2024, "frame": "IDECO", "holdingType": "US_STOCK", "code": "AAPL", "amount": 100, "accountId": 1}]}`, "invalid backup: nisaPurchases[0] の内容が正しくありません"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            service, mocks := newTestService()
+            mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(2), nil)
+
+            _, err := service.RestoreBackup(context.Background(), []byte(tt.data))
+
+            assert.ErrorIs(t, err, errInvalidBackup)
+            assert.EqualError(t, err, tt.message)
+            mocks.backupRepo.AssertNotCalled(t, "RestoreBackup", mock.Anything, mock.Anything)
+        })
+    }
+}
+
+func TestBackupUnauthenticated(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(0), errors.New("invalid token"))
+
+    _, err := service.ExportBackup(context.Background())
+    assert.ErrorIs(t, err, errUnauthenticated)
+
+    _, err = service.RestoreBackup(context.Background(), []byte(`{"version": 2}`))
+    assert.ErrorIs(t, err, errUnauthenticated)
+    mocks.backupRepo.AssertNotCalled(t, "FetchBackup", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,49 @@
+package calendar
+
+import (
+	"errors"
+	"my-us-stock-backend/app/common/event"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarController struct {
+    CalendarService CalendarService
+}
+
+func NewCalendarController(calendarService CalendarService) *CalendarController {
+    return &CalendarController{CalendarService: calendarService}
+}
+
+// GetCalendarFeed はカレンダーアプリ購読用のiCalendarフィードを返却します
+// 認証はクエリパラメータのトークンで行います(例: /api/v1/calendar/events.ics?token=xxx&days=90)
+func (cc *CalendarController) GetCalendarFeed(c *gin.Context) {
+    days := 0
+    if daysStr := c.Query("days"); daysStr != "" {
+        parsed, err := strconv.Atoi(daysStr)
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "invalid days"})
+            return
+        }
+        days = parsed
+    }
+
+    feed, err := cc.CalendarService.FetchCalendarFeed(c.Request.Context(), c.Query("token"), days)
+    if errors.Is(err, errInvalidToken) {
+        c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+        return
+    }
+    if errors.Is(err, event.ErrInvalidDays) {
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return
+    }
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+
+    c.Header("Content-Disposition", `inline; filename="events.ics"`)
+    c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(feed))
+}
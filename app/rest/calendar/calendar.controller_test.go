@@ -0,0 +1,77 @@
+package calendar
+
+import (
+	"context"
+	"my-us-stock-backend/app/common/event"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCalendarService の定義
+type MockCalendarService struct {
+    mock.Mock
+}
+
+func (m *MockCalendarService) FetchCalendarFeed(ctx context.Context, token string, days int) (string, error) {
+    args := m.Called(ctx, token, days)
+    return args.String(0), args.Error(1)
+}
+
+func TestCalendarController_GetCalendarFeed(t *testing.T) {
+    mockService := new(MockCalendarService)
+    controller := NewCalendarController(mockService)
+
+    feed := "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"
+    mockService.On("FetchCalendarFeed", mock.Anything, "valid-token", 30).Return(feed, nil)
+
+    req, _ := http.NewRequest(http.MethodGet, "/api/v1/calendar/events.ics?token=valid-token&days=30", nil)
+    w := httptest.NewRecorder()
+    c, _ := gin.CreateTestContext(w)
+    c.Request = req
+
+    controller.GetCalendarFeed(c)
+
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+    assert.Equal(t, feed, w.Body.String())
+    mockService.AssertExpectations(t)
+}
+
+// トークンが無効な場合は401
+func TestCalendarController_GetCalendarFeedInvalidToken(t *testing.T) {
+    mockService := new(MockCalendarService)
+    controller := NewCalendarController(mockService)
+
+    mockService.On("FetchCalendarFeed", mock.Anything, "unknown", 0).Return("", errInvalidToken)
+
+    req, _ := http.NewRequest(http.MethodGet, "/api/v1/calendar/events.ics?token=unknown", nil)
+    w := httptest.NewRecorder()
+    c, _ := gin.CreateTestContext(w)
+    c.Request = req
+
+    controller.GetCalendarFeed(c)
+
+    assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+// 日数が範囲外の場合は400
+func TestCalendarController_GetCalendarFeedInvalidDays(t *testing.T) {
+    mockService := new(MockCalendarService)
+    controller := NewCalendarController(mockService)
+
+    mockService.On("FetchCalendarFeed", mock.Anything, "valid-token", 400).Return("", event.ErrInvalidDays)
+
+    req, _ := http.NewRequest(http.MethodGet, "/api/v1/calendar/events.ics?token=valid-token&days=400", nil)
+    w := httptest.NewRecorder()
+    c, _ := gin.CreateTestContext(w)
+    c.Request = req
+
+    controller.GetCalendarFeed(c)
+
+    assert.Equal(t, http.StatusBadRequest, w.Code)
+}
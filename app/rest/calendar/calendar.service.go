@@ -0,0 +1,54 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/common/event"
+	"my-us-stock-backend/app/repository/calendar"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// フィードに含めるイベントのデフォルト日数
+const defaultFeedDays = 90
+
+var errInvalidToken = errors.New("invalid calendar token")
+
+// CalendarService インターフェースの定義
+type CalendarService interface {
+    FetchCalendarFeed(ctx context.Context, token string, days int) (string, error)
+}
+
+// DefaultCalendarService 構造体の定義
+type DefaultCalendarService struct {
+    EventCollector event.EventCollector
+    CalendarTokenRepo calendar.CalendarTokenRepository
+}
+
+// NewCalendarService は DefaultCalendarService の新しいインスタンスを作成します
+func NewCalendarService(eventCollector event.EventCollector, calendarTokenRepo calendar.CalendarTokenRepository) CalendarService {
+    return &DefaultCalendarService{EventCollector: eventCollector, CalendarTokenRepo: calendarTokenRepo}
+}
+
+// FetchCalendarFeed はトークンに紐づくユーザーの保有銘柄のイベントをiCalendar形式で取得します
+func (s *DefaultCalendarService) FetchCalendarFeed(ctx context.Context, token string, days int) (string, error) {
+    if token == "" {
+        return "", errInvalidToken
+    }
+    calendarToken, err := s.CalendarTokenRepo.FindCalendarTokenByToken(ctx, token)
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return "", errInvalidToken
+    }
+    if err != nil {
+        return "", err
+    }
+    if days == 0 {
+        days = defaultFeedDays
+    }
+    events, err := s.EventCollector.FetchUpcomingEvents(ctx, calendarToken.UserId, days)
+    if err != nil {
+        return "", err
+    }
+    return createICalendar(events, time.Now()), nil
+}
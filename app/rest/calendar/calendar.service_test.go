@@ -0,0 +1,79 @@
+package calendar
+
+import (
+	"context"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/app/common/event"
+	repo "my-us-stock-backend/app/repository/calendar"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// トークンに紐づくユーザーのイベントがiCalendar形式で返却される
+func TestFetchCalendarFeed(t *testing.T) {
+    mockEventCollector := event.NewMockEventCollector()
+    mockTokenRepo := repo.NewMockCalendarTokenRepository()
+    service := NewCalendarService(mockEventCollector, mockTokenRepo)
+
+    amount := 0.24
+    mockTokenRepo.On("FindCalendarTokenByToken", mock.Anything, "valid-token").Return(&model.CalendarToken{UserId: 1, Token: "valid-token"}, nil)
+    // 日数未指定の場合は90日分
+    mockEventCollector.On("FetchUpcomingEvents", mock.Anything, uint(1), 90).Return([]event.Event{
+        {Ticker: "AAPL", Type: event.TypeExDividend, Date: "2024-02-09", Title: "AAPL 権利落ち日 ($0.24)", Amount: &amount},
+        {Ticker: "KO", Type: event.TypeEarnings, Date: "2024-02-13", Title: "KO 決算発表"},
+    }, nil)
+
+    feed, err := service.FetchCalendarFeed(context.Background(), "valid-token", 0)
+
+    assert.NoError(t, err)
+    assert.True(t, strings.HasPrefix(feed, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+    assert.True(t, strings.HasSuffix(feed, "END:VCALENDAR\r\n"))
+    assert.Equal(t, 2, strings.Count(feed, "BEGIN:VEVENT"))
+    assert.Contains(t, feed, "UID:AAPL-ex_dividend-20240209@my-us-stock\r\n")
+    assert.Contains(t, feed, "DTSTART;VALUE=DATE:20240209\r\nDTEND;VALUE=DATE:20240210\r\n")
+    assert.Contains(t, feed, "SUMMARY:AAPL 権利落ち日 ($0.24)\r\n")
+    assert.Contains(t, feed, "SUMMARY:KO 決算発表\r\n")
+    mockEventCollector.AssertExpectations(t)
+}
+
+// 存在しないトークンの場合はエラー
+func TestFetchCalendarFeedInvalidToken(t *testing.T) {
+    mockEventCollector := event.NewMockEventCollector()
+    mockTokenRepo := repo.NewMockCalendarTokenRepository()
+    service := NewCalendarService(mockEventCollector, mockTokenRepo)
+
+    mockTokenRepo.On("FindCalendarTokenByToken", mock.Anything, "unknown").Return((*model.CalendarToken)(nil), gorm.ErrRecordNotFound)
+
+    _, err := service.FetchCalendarFeed(context.Background(), "unknown", 30)
+    assert.ErrorIs(t, err, errInvalidToken)
+
+    // トークン未指定の場合も同様
+    _, err = service.FetchCalendarFeed(context.Background(), "", 30)
+    assert.ErrorIs(t, err, errInvalidToken)
+    mockEventCollector.AssertNotCalled(t, "FetchUpcomingEvents", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// iCalendarの特殊文字がエスケープされる
+func TestEscapeICalendarText(t *testing.T) {
+    assert.Equal(t, `a\,b\;c\\d\ne`, escapeICalendarText("a,b;c\\d\ne"))
+}
+
+// 75オクテットを超える行は空白で始まる継続行に折り返し、マルチバイト文字の途中では折り返さない
+func TestFoldICalendarLine(t *testing.T) {
+    assert.Equal(t, "SUMMARY:short", foldICalendarLine("SUMMARY:short"))
+
+    line := "SUMMARY:" + strings.Repeat("配当", 20)
+    folded := foldICalendarLine(line)
+    for _, part := range strings.Split(folded, "\r\n") {
+        assert.LessOrEqual(t, len(part), 75)
+        assert.True(t, utf8.ValidString(part))
+    }
+    assert.True(t, strings.HasPrefix(strings.Split(folded, "\r\n")[1], " "))
+    // 折り返しを戻すと元の行になる
+    assert.Equal(t, line, strings.ReplaceAll(folded, "\r\n ", ""))
+}
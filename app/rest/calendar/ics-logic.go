@@ -0,0 +1,75 @@
+package calendar
+
+import (
+	"fmt"
+	"my-us-stock-backend/app/common/event"
+	"strings"
+	"time"
+)
+
+// iCalendar(RFC 5545)の改行コード
+const icsLineBreak = "\r\n"
+
+// iCalendarの1行の最大オクテット数(改行コードを除く)
+const icsMaxLineOctets = 75
+
+// イベントをiCalendar形式(終日イベント)に変換する
+func createICalendar(events []event.Event, now time.Time) string {
+    var builder strings.Builder
+    writeLine := func(line string) {
+        builder.WriteString(foldICalendarLine(line))
+        builder.WriteString(icsLineBreak)
+    }
+    stamp := now.UTC().Format("20060102T150405Z")
+
+    writeLine("BEGIN:VCALENDAR")
+    writeLine("VERSION:2.0")
+    writeLine("PRODID:-//my-us-stock//events//JA")
+    writeLine("CALSCALE:GREGORIAN")
+    writeLine("METHOD:PUBLISH")
+    writeLine("X-WR-CALNAME:" + escapeICalendarText("保有銘柄イベント"))
+    for _, calendarEvent := range events {
+        date, err := time.Parse("2006-01-02", calendarEvent.Date)
+        if err != nil {
+            continue
+        }
+        writeLine("BEGIN:VEVENT")
+        // 同じイベントは購読のたびに同じUIDとなるようにする
+        writeLine(fmt.Sprintf("UID:%s-%s-%s@my-us-stock", calendarEvent.Ticker, strings.ToLower(calendarEvent.Type), date.Format("20060102")))
+        writeLine("DTSTAMP:" + stamp)
+        writeLine("DTSTART;VALUE=DATE:" + date.Format("20060102"))
+        writeLine("DTEND;VALUE=DATE:" + date.AddDate(0, 0, 1).Format("20060102"))
+        writeLine("SUMMARY:" + escapeICalendarText(calendarEvent.Title))
+        writeLine("CATEGORIES:" + calendarEvent.Type)
+        writeLine("TRANSP:TRANSPARENT")
+        writeLine("END:VEVENT")
+    }
+    writeLine("END:VCALENDAR")
+    return builder.String()
+}
+
+// iCalendarのテキスト値で特別な意味を持つ文字をエスケープする
+func escapeICalendarText(text string) string {
+    replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+    return replacer.Replace(text)
+}
+
+// 75オクテットを超える行を折り返す(RFC 5545 3.1)
+// 折り返した行は空白で始め、マルチバイト文字の途中では折り返さない
+func foldICalendarLine(line string) string {
+    if len(line) <= icsMaxLineOctets {
+        return line
+    }
+    var builder strings.Builder
+    lineOctets := 0
+    for _, r := range line {
+        size := len(string(r))
+        if lineOctets+size > icsMaxLineOctets {
+            builder.WriteString(icsLineBreak + " ")
+            lineOctets = 1
+        }
+        builder.WriteRune(r)
+        lineOctets += size
+    }
+    return builder.String()
+}
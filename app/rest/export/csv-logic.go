@@ -0,0 +1,31 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+)
+
+// 表をCSVに変換する
+// Excelで開いた際に文字化けしないよう、先頭にBOMを付ける
+func renderCSV(table exportTable) ([]byte, error) {
+    var buf bytes.Buffer
+    buf.WriteString("\xef\xbb\xbf")
+    writer := csv.NewWriter(&buf)
+    if err := writer.Write(table.headers); err != nil {
+        return nil, err
+    }
+    for _, row := range table.rows {
+        record := make([]string, len(row))
+        for i, cell := range row {
+            record[i] = cell.String()
+        }
+        if err := writer.Write(record); err != nil {
+            return nil, err
+        }
+    }
+    writer.Flush()
+    if err := writer.Error(); err != nil {
+        return nil, err
+    }
+    return buf.Bytes(), nil
+}
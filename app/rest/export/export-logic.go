@@ -0,0 +1,247 @@
+package export
+
+import (
+	"math"
+	"my-us-stock-backend/app/database/model"
+	repoFixedIncome "my-us-stock-backend/app/repository/assets/fixed-income"
+	repoTaxReport "my-us-stock-backend/app/repository/tax-report"
+	"strconv"
+	"time"
+)
+
+// 出力する表(見出しと各行のセル)
+type exportTable struct {
+    headers []string
+    rows    [][]exportCell
+}
+
+// 表のセル(数値の場合は number、文字列の場合は text を使う。どちらもない場合は空欄)
+type exportCell struct {
+    text   string
+    number *float64
+}
+
+func textCell(text string) exportCell {
+    return exportCell{text: text}
+}
+
+func numberCell(number float64) exportCell {
+    return exportCell{number: &number}
+}
+
+// CSVに出力する文字列(数値は指数表記にしない)
+func (c exportCell) String() string {
+    if c.number != nil {
+        return strconv.FormatFloat(*c.number, 'f', -1, 64)
+    }
+    return c.text
+}
+
+// 見出し・資産の種類の表示名(日本語・英語)
+var labels = map[string]map[string]string{
+    langJa: {
+        "assetType":     "資産の種類",
+        "code":          "コード",
+        "name":          "銘柄名",
+        "quantity":      "保有数量",
+        "getPrice":      "取得単価",
+        "currentPrice":  "現在値",
+        "currency":      "通貨",
+        "cost":          "取得総額(円)",
+        "value":         "評価額(円)",
+        "gain":          "評価損益(円)",
+        "date":          "日付",
+        "cashJpy":       "現金(円)",
+        "cashUsd":       "現金(ドル)",
+        "stock":         "米国株式(円)",
+        "fund":          "投資信託(円)",
+        "crypto":        "暗号通貨(円)",
+        "fixedIncome":   "固定利回り資産(円)",
+        "total":         "合計(円)",
+        "usdJpy":        "ドル円",
+        "US_STOCK":      "米国株式",
+        "JAPAN_FUND":    "投資信託",
+        "CRYPTO":        "暗号通貨",
+        "FIXED_INCOME":  "固定利回り資産",
+        "holdingsSheet": "保有資産",
+        "historySheet":  "資産推移",
+        "security":       "銘柄",
+        "country":        "国名",
+        "unitedStates":   "アメリカ",
+        "dividendAmount": "配当金(円)",
+        "foreignTax":     "外国源泉徴収税額(円)",
+        "domesticTax":    "国内源泉徴収税額(円)",
+        "proceeds":       "譲渡価額(円)",
+        "saleCost":       "取得費(円)",
+        "saleFee":        "売却手数料(円)",
+        "realizedGain":   "譲渡損益(円)",
+        "totalRow":       "合計",
+        "taxReportSheet": "年間損益",
+    },
+    langEn: {
+        "assetType":     "Asset Type",
+        "code":          "Code",
+        "name":          "Name",
+        "quantity":      "Quantity",
+        "getPrice":      "Average Cost",
+        "currentPrice":  "Current Price",
+        "currency":      "Currency",
+        "cost":          "Cost (JPY)",
+        "value":         "Market Value (JPY)",
+        "gain":          "Unrealized Gain (JPY)",
+        "date":          "Date",
+        "cashJpy":       "Cash (JPY)",
+        "cashUsd":       "Cash (USD)",
+        "stock":         "US Stocks (JPY)",
+        "fund":          "Japan Funds (JPY)",
+        "crypto":        "Crypto (JPY)",
+        "fixedIncome":   "Fixed Income (JPY)",
+        "total":         "Total (JPY)",
+        "usdJpy":        "USD/JPY",
+        "US_STOCK":      "US Stock",
+        "JAPAN_FUND":    "Japan Fund",
+        "CRYPTO":        "Crypto",
+        "FIXED_INCOME":  "Fixed Income",
+        "holdingsSheet": "Holdings",
+        "historySheet":  "Total Assets",
+        "security":       "Security",
+        "country":        "Country",
+        "unitedStates":   "United States",
+        "dividendAmount": "Dividends (JPY)",
+        "foreignTax":     "Foreign Withholding Tax (JPY)",
+        "domesticTax":    "Domestic Withholding Tax (JPY)",
+        "proceeds":       "Proceeds (JPY)",
+        "saleCost":       "Acquisition Cost (JPY)",
+        "saleFee":        "Sale Fees (JPY)",
+        "realizedGain":   "Realized Gain (JPY)",
+        "totalRow":       "Total",
+        "taxReportSheet": "Tax Report",
+    },
+}
+
+func translate(lang string, keys ...string) []string {
+    translated := make([]string, len(keys))
+    for i, key := range keys {
+        translated[i] = labels[lang][key]
+    }
+    return translated
+}
+
+// 現在の保有資産の評価に使う価格
+type holdingPrices struct {
+    valuedAt     time.Time
+    usdJpy       float64
+    stockPrices  map[string]float64
+    stockNames   map[string]string
+    fundPrices   map[string]model.FundPrice
+    cryptoPrices map[string]float64
+}
+
+// 保有資産の表を作成する(評価額・損益は円換算、現在値が取得できない銘柄は評価額を空欄にする)
+func createHoldingsTable(lang string, holdings holdingList, prices holdingPrices) exportTable {
+    table := exportTable{headers: translate(lang, "assetType", "code", "name", "quantity", "getPrice", "currentPrice", "currency", "cost", "value", "gain")}
+    for _, usStock := range holdings.usStocks {
+        cost := usStock.Quantity * usStock.GetPrice * usStock.UsdJpy
+        currentPrice, ok := prices.stockPrices[usStock.Code]
+        table.rows = append(table.rows, createHoldingRow(lang, "US_STOCK", usStock.Code, prices.stockNames[usStock.Code], usStock.Quantity, usStock.GetPrice, currentPrice, ok, "USD", cost, usStock.Quantity*currentPrice*prices.usdJpy))
+    }
+    for _, japanFund := range holdings.japanFunds {
+        // 基準価額は1万口あたりのため、口数は 取得総額 ÷ 取得価額 × 10000
+        quantity := math.Round(japanFund.GetPriceTotal / japanFund.GetPrice * 10000)
+        fundPrice, ok := prices.fundPrices[japanFund.Code]
+        table.rows = append(table.rows, createHoldingRow(lang, "JAPAN_FUND", japanFund.Code, japanFund.Name, quantity, japanFund.GetPrice, fundPrice.Price, ok, "JPY", japanFund.GetPriceTotal, japanFund.GetPriceTotal*fundPrice.Price/japanFund.GetPrice))
+    }
+    for _, crypto := range holdings.cryptos {
+        currentPrice, ok := prices.cryptoPrices[crypto.Code]
+        table.rows = append(table.rows, createHoldingRow(lang, "CRYPTO", crypto.Code, "", crypto.Quantity, crypto.GetPrice, currentPrice, ok, "JPY", crypto.Quantity*crypto.GetPrice, crypto.Quantity*currentPrice))
+    }
+    for _, fixedIncome := range holdings.fixedIncomeAssets {
+        // 固定利回り資産は評価方法に従った評価額(経過利息を含む)で評価する
+        currency := repoFixedIncome.CurrencyJpy
+        cost := fixedIncome.GetPriceTotal
+        if repoFixedIncome.IsUsd(fixedIncome) {
+            currency = repoFixedIncome.CurrencyUsd
+            cost = fixedIncomeCostJpy(fixedIncome, prices.usdJpy)
+        }
+        value := math.Round(repoFixedIncome.ValuationJpy(fixedIncome, prices.usdJpy, prices.valuedAt))
+        table.rows = append(table.rows, []exportCell{
+            textCell(labels[lang]["FIXED_INCOME"]), textCell(fixedIncome.Code), {}, {}, {}, {}, textCell(currency),
+            numberCell(math.Round(cost)), numberCell(value), numberCell(value - math.Round(cost)),
+        })
+    }
+    return table
+}
+
+// ドル建ての固定利回り資産の取得総額を購入時為替(未登録の場合は現在のドル円)で円に換算する
+func fixedIncomeCostJpy(fixedIncome model.FixedIncomeAsset, usdJpy float64) float64 {
+    if fixedIncome.UsdJpy != nil {
+        return fixedIncome.GetPriceTotal * *fixedIncome.UsdJpy
+    }
+    return fixedIncome.GetPriceTotal * usdJpy
+}
+
+func hasUsdFixedIncome(fixedIncomeAssets []model.FixedIncomeAsset) bool {
+    for _, fixedIncome := range fixedIncomeAssets {
+        if repoFixedIncome.IsUsd(fixedIncome) {
+            return true
+        }
+    }
+    return false
+}
+
+func createHoldingRow(lang string, assetType string, code string, name string, quantity float64, getPrice float64, currentPrice float64, hasPrice bool, currency string, cost float64, value float64) []exportCell {
+    row := []exportCell{
+        textCell(labels[lang][assetType]), textCell(code), textCell(name),
+        numberCell(quantity), numberCell(getPrice), {}, textCell(currency),
+        numberCell(math.Round(cost)), {}, {},
+    }
+    if hasPrice {
+        row[5] = numberCell(currentPrice)
+        row[8] = numberCell(math.Round(value))
+        row[9] = numberCell(math.Round(value) - math.Round(cost))
+    }
+    return row
+}
+
+// 資産推移の表を作成する(古い順)
+// 合計はドル建ての現金を登録時点のドル円で換算する。ドル円が記録されていない日は合計を空欄にする
+func createTotalAssetTable(lang string, totalAssets []model.TotalAsset) exportTable {
+    table := exportTable{headers: translate(lang, "date", "cashJpy", "cashUsd", "stock", "fund", "crypto", "fixedIncome", "total", "usdJpy")}
+    for i := len(totalAssets) - 1; i >= 0; i-- {
+        asset := totalAssets[i]
+        row := []exportCell{
+            textCell(asset.CreatedAt.UTC().Format("2006-01-02")),
+            numberCell(asset.CashJpy), numberCell(asset.CashUsd), numberCell(asset.Stock),
+            numberCell(asset.Fund), numberCell(asset.Crypto), numberCell(asset.FixedIncomeAsset),
+            {}, {},
+        }
+        if asset.UsdJpy != 0 || asset.CashUsd == 0 {
+            total := asset.CashJpy + asset.CashUsd*asset.UsdJpy + asset.Stock + asset.Fund + asset.Crypto + asset.FixedIncomeAsset
+            row[7] = numberCell(math.Round(total))
+        }
+        if asset.UsdJpy != 0 {
+            row[8] = numberCell(asset.UsdJpy)
+        }
+        table.rows = append(table.rows, row)
+    }
+    return table
+}
+
+// 確定申告用の銘柄別の集計表を作成する(最終行は合計)
+// 外国税額控除の明細書に転記できるよう、国名・配当金(相手国での課税標準)・外国源泉徴収税額を並べる
+func createTaxReportTable(lang string, report repoTaxReport.AnnualSummary) exportTable {
+    table := exportTable{headers: translate(lang, "security", "country", "dividendAmount", "foreignTax", "domesticTax", "proceeds", "saleCost", "saleFee", "realizedGain")}
+    for _, security := range report.Securities {
+        table.rows = append(table.rows, []exportCell{
+            textCell(security.Code), textCell(labels[lang]["unitedStates"]),
+            numberCell(security.DividendAmount), numberCell(security.ForeignTax), numberCell(security.DomesticTax),
+            numberCell(security.Proceeds), numberCell(security.Cost), numberCell(security.Fee), numberCell(security.RealizedGain),
+        })
+    }
+    table.rows = append(table.rows, []exportCell{
+        textCell(labels[lang]["totalRow"]), {},
+        numberCell(report.DividendTotal), numberCell(report.ForeignTaxTotal), numberCell(report.DomesticTaxTotal),
+        numberCell(report.ProceedsTotal), numberCell(report.CostTotal), numberCell(report.FeeTotal), numberCell(report.RealizedGainTotal),
+    })
+    return table
+}
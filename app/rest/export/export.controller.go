@@ -0,0 +1,77 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/graphql/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 出力形式ごとのContent-Type
+var contentTypes = map[string]string{
+    formatCSV:  "text/csv; charset=utf-8",
+    formatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+type ExportController struct {
+    ExportService ExportService
+}
+
+func NewExportController(exportService ExportService) *ExportController {
+    return &ExportController{ExportService: exportService}
+}
+
+// GetHoldings は現在の保有資産をダウンロードさせます
+// 例: /api/v1/export/holdings?format=xlsx&lang=en (format は csv(デフォルト)・xlsx、lang は ja(デフォルト)・en)
+func (ec *ExportController) GetHoldings(c *gin.Context) {
+    ec.download(c, "holdings", ec.ExportService.ExportHoldings)
+}
+
+// GetTotalAssets は資産推移の全履歴をダウンロードさせます
+// 例: /api/v1/export/total-assets?format=csv&lang=ja
+func (ec *ExportController) GetTotalAssets(c *gin.Context) {
+    ec.download(c, "total-assets", ec.ExportService.ExportTotalAssets)
+}
+
+// GetTaxReport は確定申告用の年間の配当・譲渡損益の集計をダウンロードさせます
+// 例: /api/v1/export/tax-report?year=2024&format=csv&lang=ja
+func (ec *ExportController) GetTaxReport(c *gin.Context) {
+    year, err := strconv.Atoi(c.Query("year"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": errInvalidYear.Error()})
+        return
+    }
+    ec.download(c, "tax-report-"+strconv.Itoa(year), func(ctx context.Context, format string, lang string) ([]byte, error) {
+        return ec.ExportService.ExportTaxReport(ctx, year, format, lang)
+    })
+}
+
+func (ec *ExportController) download(c *gin.Context, name string, export func(ctx context.Context, format string, lang string) ([]byte, error)) {
+    format := c.DefaultQuery("format", formatCSV)
+    lang := c.DefaultQuery("lang", langJa)
+
+    // 認証はGraphQLと同じくCookieのアクセストークンで行う
+    accessToken, _ := c.Cookie("access_token")
+    ctx := context.WithValue(c.Request.Context(), utils.CookieKey, accessToken)
+
+    data, err := export(ctx, format, lang)
+    switch {
+    case errors.Is(err, errUnauthenticated):
+        c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+        return
+    case errors.Is(err, errInvalidFormat), errors.Is(err, errInvalidLang), errors.Is(err, errInvalidYear):
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return
+    case err != nil:
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+
+    filename := name + "-" + time.Now().Format("20060102") + "." + format
+    c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+    c.Data(http.StatusOK, contentTypes[format], data)
+}
@@ -0,0 +1,137 @@
+package export
+
+import (
+	"context"
+	"my-us-stock-backend/app/graphql/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockExportService の定義
+type MockExportService struct {
+    mock.Mock
+}
+
+func (m *MockExportService) ExportHoldings(ctx context.Context, format string, lang string) ([]byte, error) {
+    args := m.Called(ctx, format, lang)
+    return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockExportService) ExportTotalAssets(ctx context.Context, format string, lang string) ([]byte, error) {
+    args := m.Called(ctx, format, lang)
+    return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockExportService) ExportTaxReport(ctx context.Context, year int, format string, lang string) ([]byte, error) {
+    args := m.Called(ctx, year, format, lang)
+    return args.Get(0).([]byte), args.Error(1)
+}
+
+func TestExportController_GetHoldings(t *testing.T) {
+    mockService := new(MockExportService)
+    controller := NewExportController(mockService)
+
+    // Cookieのアクセストークンがサービスに渡されること
+    hasToken := mock.MatchedBy(func(ctx context.Context) bool {
+        return ctx.Value(utils.CookieKey) == "token"
+    })
+    mockService.On("ExportHoldings", hasToken, "xlsx", "en").Return([]byte("xlsx"), nil)
+
+    req, _ := http.NewRequest(http.MethodGet, "/api/v1/export/holdings?format=xlsx&lang=en", nil)
+    req.AddCookie(&http.Cookie{Name: "access_token", Value: "token"})
+    w := httptest.NewRecorder()
+    c, _ := gin.CreateTestContext(w)
+    c.Request = req
+
+    controller.GetHoldings(c)
+
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", w.Header().Get("Content-Type"))
+    assert.Regexp(t, `^attachment; filename="holdings-\d{8}\.xlsx"$`, w.Header().Get("Content-Disposition"))
+    assert.Equal(t, "xlsx", w.Body.String())
+    mockService.AssertExpectations(t)
+}
+
+// format・lang を省略した場合は日本語のCSV
+func TestExportController_GetTotalAssetsDefault(t *testing.T) {
+    mockService := new(MockExportService)
+    controller := NewExportController(mockService)
+
+    mockService.On("ExportTotalAssets", mock.Anything, "csv", "ja").Return([]byte("csv"), nil)
+
+    req, _ := http.NewRequest(http.MethodGet, "/api/v1/export/total-assets", nil)
+    w := httptest.NewRecorder()
+    c, _ := gin.CreateTestContext(w)
+    c.Request = req
+
+    controller.GetTotalAssets(c)
+
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+    assert.Regexp(t, `^attachment; filename="total-assets-\d{8}\.csv"$`, w.Header().Get("Content-Disposition"))
+}
+
+func TestExportController_GetTaxReport(t *testing.T) {
+    mockService := new(MockExportService)
+    controller := NewExportController(mockService)
+
+    mockService.On("ExportTaxReport", mock.Anything, 2024, "csv", "en").Return([]byte("csv"), nil)
+
+    req, _ := http.NewRequest(http.MethodGet, "/api/v1/export/tax-report?year=2024&lang=en", nil)
+    w := httptest.NewRecorder()
+    c, _ := gin.CreateTestContext(w)
+    c.Request = req
+
+    controller.GetTaxReport(c)
+
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.Regexp(t, `^attachment; filename="tax-report-2024-\d{8}\.csv"$`, w.Header().Get("Content-Disposition"))
+    mockService.AssertExpectations(t)
+}
+
+// year が数値でない場合はサービスを呼ばずに400
+func TestExportController_GetTaxReportInvalidYear(t *testing.T) {
+    mockService := new(MockExportService)
+    controller := NewExportController(mockService)
+
+    req, _ := http.NewRequest(http.MethodGet, "/api/v1/export/tax-report?year=abc", nil)
+    w := httptest.NewRecorder()
+    c, _ := gin.CreateTestContext(w)
+    c.Request = req
+
+    controller.GetTaxReport(c)
+
+    assert.Equal(t, http.StatusBadRequest, w.Code)
+    mockService.AssertNotCalled(t, "ExportTaxReport", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExportController_Errors(t *testing.T) {
+    tests := []struct {
+        err        error
+        statusCode int
+    }{
+        {errUnauthenticated, http.StatusUnauthorized},
+        {errInvalidFormat, http.StatusBadRequest},
+        {errInvalidLang, http.StatusBadRequest},
+        {context.DeadlineExceeded, http.StatusInternalServerError},
+    }
+    for _, tt := range tests {
+        mockService := new(MockExportService)
+        controller := NewExportController(mockService)
+        mockService.On("ExportHoldings", mock.Anything, "csv", "ja").Return([]byte(nil), tt.err)
+
+        req, _ := http.NewRequest(http.MethodGet, "/api/v1/export/holdings", nil)
+        w := httptest.NewRecorder()
+        c, _ := gin.CreateTestContext(w)
+        c.Request = req
+
+        controller.GetHoldings(c)
+
+        assert.Equal(t, tt.statusCode, w.Code)
+    }
+}
@@ -0,0 +1,245 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"log"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/database/model"
+	repoCrypto "my-us-stock-backend/app/repository/assets/crypto"
+	repoFixedIncome "my-us-stock-backend/app/repository/assets/fixed-income"
+	repoJapanFund "my-us-stock-backend/app/repository/assets/fund"
+	"my-us-stock-backend/app/repository/assets/stock"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	repoMarketCrypto "my-us-stock-backend/app/repository/market-price/crypto"
+	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
+	repoFundPrice "my-us-stock-backend/app/repository/market-price/fund"
+	repoInstrument "my-us-stock-backend/app/repository/market-price/instrument"
+	repoTaxReport "my-us-stock-backend/app/repository/tax-report"
+	repoTotalAsset "my-us-stock-backend/app/repository/total-assets"
+	"time"
+)
+
+// 出力形式
+const (
+    formatCSV  = "csv"
+    formatXLSX = "xlsx"
+)
+
+// 見出しの言語
+const (
+    langJa = "ja"
+    langEn = "en"
+)
+
+var (
+    errUnauthenticated = errors.New("unauthenticated")
+    errInvalidFormat   = errors.New("format must be csv or xlsx")
+    errInvalidLang     = errors.New("lang must be ja or en")
+    errInvalidYear     = errors.New("invalid year")
+)
+
+// ExportService インターフェースの定義
+type ExportService interface {
+    ExportHoldings(ctx context.Context, format string, lang string) ([]byte, error)
+    ExportTotalAssets(ctx context.Context, format string, lang string) ([]byte, error)
+    ExportTaxReport(ctx context.Context, year int, format string, lang string) ([]byte, error)
+}
+
+// DefaultExportService 構造体の定義
+type DefaultExportService struct {
+    Auth auth.AuthService
+    StockRepo stock.UsStockRepository
+    JapanFundRepo repoJapanFund.JapanFundRepository
+    CryptoRepo repoCrypto.CryptoRepository
+    FixedIncomeRepo repoFixedIncome.FixedIncomeRepository
+    TotalAssetRepo repoTotalAsset.TotalAssetRepository
+    MarketPriceRepo marketPrice.MarketPriceRepository
+    CurrencyRepo repoCurrency.CurrencyRepository
+    MarketCryptoRepo repoMarketCrypto.CryptoRepository
+    FundPriceRepo repoFundPrice.FundPriceRepository
+    InstrumentRepo repoInstrument.InstrumentRepository
+    TaxReportRepo repoTaxReport.TaxReportRepository
+}
+
+// NewExportService は DefaultExportService の新しいインスタンスを作成します
+func NewExportService(auth auth.AuthService, stockRepo stock.UsStockRepository, japanFundRepo repoJapanFund.JapanFundRepository, cryptoRepo repoCrypto.CryptoRepository, fixedIncomeRepo repoFixedIncome.FixedIncomeRepository, totalAssetRepo repoTotalAsset.TotalAssetRepository, marketPriceRepo marketPrice.MarketPriceRepository, currencyRepo repoCurrency.CurrencyRepository, marketCryptoRepo repoMarketCrypto.CryptoRepository, fundPriceRepo repoFundPrice.FundPriceRepository, instrumentRepo repoInstrument.InstrumentRepository, taxReportRepo repoTaxReport.TaxReportRepository) ExportService {
+    return &DefaultExportService{
+        Auth: auth,
+        StockRepo: stockRepo,
+        JapanFundRepo: japanFundRepo,
+        CryptoRepo: cryptoRepo,
+        FixedIncomeRepo: fixedIncomeRepo,
+        TotalAssetRepo: totalAssetRepo,
+        MarketPriceRepo: marketPriceRepo,
+        CurrencyRepo: currencyRepo,
+        MarketCryptoRepo: marketCryptoRepo,
+        FundPriceRepo: fundPriceRepo,
+        InstrumentRepo: instrumentRepo,
+        TaxReportRepo: taxReportRepo,
+    }
+}
+
+// ログインユーザーの保有資産
+type holdingList struct {
+    usStocks          []model.UsStock
+    japanFunds        []model.JapanFund
+    cryptos           []model.Crypto
+    fixedIncomeAssets []model.FixedIncomeAsset
+}
+
+// ExportHoldings はログインユーザーの全ての保有資産を現在の価格で評価し、CSVまたはXLSXで出力します
+func (s *DefaultExportService) ExportHoldings(ctx context.Context, format string, lang string) ([]byte, error) {
+    userId, err := s.authorize(ctx, format, lang)
+    if err != nil {
+        return nil, err
+    }
+    holdings, err := s.fetchHoldings(ctx, userId)
+    if err != nil {
+        return nil, err
+    }
+    prices, err := s.fetchHoldingPrices(ctx, holdings)
+    if err != nil {
+        return nil, err
+    }
+    return render(createHoldingsTable(lang, holdings, prices), format, labels[lang]["holdingsSheet"])
+}
+
+// ExportTotalAssets はログインユーザーの資産推移の全履歴をCSVまたはXLSXで出力します
+func (s *DefaultExportService) ExportTotalAssets(ctx context.Context, format string, lang string) ([]byte, error) {
+    userId, err := s.authorize(ctx, format, lang)
+    if err != nil {
+        return nil, err
+    }
+    totalAssets, err := s.TotalAssetRepo.FetchTotalAssetListById(ctx, userId, 0)
+    if err != nil {
+        return nil, err
+    }
+    return render(createTotalAssetTable(lang, totalAssets), format, labels[lang]["historySheet"])
+}
+
+// ExportTaxReport はログインユーザーの指定した年の配当・譲渡損益の銘柄別の集計をCSVまたはXLSXで出力します
+func (s *DefaultExportService) ExportTaxReport(ctx context.Context, year int, format string, lang string) ([]byte, error) {
+    userId, err := s.authorize(ctx, format, lang)
+    if err != nil {
+        return nil, err
+    }
+    if year < 2000 || year > 9999 {
+        return nil, errInvalidYear
+    }
+    receipts, err := s.TaxReportRepo.FetchDividendReceiptListByYear(ctx, userId, year)
+    if err != nil {
+        return nil, err
+    }
+    sales, err := s.TaxReportRepo.FetchStockSaleListByYear(ctx, userId, year)
+    if err != nil {
+        return nil, err
+    }
+    return render(createTaxReportTable(lang, repoTaxReport.SummarizeAnnualTax(year, receipts, sales)), format, labels[lang]["taxReportSheet"])
+}
+
+// 出力形式・言語を検証し、ログインユーザーのIDを取得する
+func (s *DefaultExportService) authorize(ctx context.Context, format string, lang string) (uint, error) {
+    userId, _ := s.Auth.FetchUserIdAccessToken(ctx)
+    if userId == 0 {
+        return 0, errUnauthenticated
+    }
+    if format != formatCSV && format != formatXLSX {
+        return 0, errInvalidFormat
+    }
+    if lang != langJa && lang != langEn {
+        return 0, errInvalidLang
+    }
+    return userId, nil
+}
+
+func (s *DefaultExportService) fetchHoldings(ctx context.Context, userId uint) (holdingList, error) {
+    var holdings holdingList
+    var err error
+    if holdings.usStocks, err = s.StockRepo.FetchUsStockListById(ctx, userId); err != nil {
+        return holdings, err
+    }
+    if holdings.japanFunds, err = s.JapanFundRepo.FetchJapanFundListById(ctx, userId); err != nil {
+        return holdings, err
+    }
+    if holdings.cryptos, err = s.CryptoRepo.FetchCryptoListById(ctx, userId); err != nil {
+        return holdings, err
+    }
+    if holdings.fixedIncomeAssets, err = s.FixedIncomeRepo.FetchFixedIncomeAssetListById(ctx, userId); err != nil {
+        return holdings, err
+    }
+    // 満期償還済みの資産は現金に移っているため出力しない
+    holdings.fixedIncomeAssets = repoFixedIncome.ExcludeMatured(holdings.fixedIncomeAssets)
+    return holdings, nil
+}
+
+// 保有資産の評価に使う現在の価格を取得する
+// 個別の暗号通貨の価格や銘柄名が取得できない場合は空欄で出力するため、処理は継続させる
+func (s *DefaultExportService) fetchHoldingPrices(ctx context.Context, holdings holdingList) (holdingPrices, error) {
+    prices := holdingPrices{
+        valuedAt:     time.Now(),
+        stockPrices:  map[string]float64{},
+        stockNames:   map[string]string{},
+        fundPrices:   map[string]model.FundPrice{},
+        cryptoPrices: map[string]float64{},
+    }
+    if len(holdings.usStocks) != 0 {
+        codes := make([]string, len(holdings.usStocks))
+        for i, usStock := range holdings.usStocks {
+            codes[i] = usStock.Code
+        }
+        marketPrices, err := s.MarketPriceRepo.FetchMarketPriceList(ctx, codes)
+        if err != nil {
+            return prices, err
+        }
+        for _, marketPrice := range marketPrices {
+            prices.stockPrices[marketPrice.Ticker] = marketPrice.CurrentPrice
+        }
+        if prices.usdJpy, err = s.CurrencyRepo.FetchCurrentUsdJpy(ctx); err != nil {
+            return prices, err
+        }
+        instruments, err := s.InstrumentRepo.FetchInstrumentListByTickers(ctx, codes)
+        if err != nil {
+            log.Printf("企業情報の取得に失敗しました: %v", err)
+        }
+        for _, instrument := range instruments {
+            prices.stockNames[instrument.Ticker] = instrument.Name
+        }
+    }
+    // ドル建ての固定利回り資産を現在のドル円で換算する
+    if prices.usdJpy == 0 && hasUsdFixedIncome(holdings.fixedIncomeAssets) {
+        usdJpy, err := s.CurrencyRepo.FetchCurrentUsdJpy(ctx)
+        if err != nil {
+            return prices, err
+        }
+        prices.usdJpy = usdJpy
+    }
+    if len(holdings.japanFunds) != 0 {
+        fundPrices, err := s.FundPriceRepo.FetchFundPriceList(ctx)
+        if err != nil {
+            return prices, err
+        }
+        for _, fundPrice := range fundPrices {
+            prices.fundPrices[fundPrice.Code] = fundPrice
+        }
+    }
+    for _, crypto := range holdings.cryptos {
+        if _, ok := prices.cryptoPrices[crypto.Code]; ok {
+            continue
+        }
+        cryptoPrice, err := s.MarketCryptoRepo.FetchCryptoPrice(crypto.Code)
+        if err != nil {
+            log.Printf("暗号通貨の価格の取得に失敗しました(%s): %v", crypto.Code, err)
+            continue
+        }
+        prices.cryptoPrices[crypto.Code] = cryptoPrice.Price
+    }
+    return prices, nil
+}
+
+func render(table exportTable, format string, sheetName string) ([]byte, error) {
+    if format == formatXLSX {
+        return renderXLSX(table, sheetName)
+    }
+    return renderCSV(table)
+}
@@ -0,0 +1,266 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/database/model"
+	repoCrypto "my-us-stock-backend/app/repository/assets/crypto"
+	repoFixedIncome "my-us-stock-backend/app/repository/assets/fixed-income"
+	repoJapanFund "my-us-stock-backend/app/repository/assets/fund"
+	"my-us-stock-backend/app/repository/assets/stock"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	repoMarketCrypto "my-us-stock-backend/app/repository/market-price/crypto"
+	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
+	repoFundPrice "my-us-stock-backend/app/repository/market-price/fund"
+	repoInstrument "my-us-stock-backend/app/repository/market-price/instrument"
+	repoTaxReport "my-us-stock-backend/app/repository/tax-report"
+	repoTotalAsset "my-us-stock-backend/app/repository/total-assets"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockTotalAssetRepository は TotalAssetRepository のモックです。
+type MockTotalAssetRepository struct {
+    mock.Mock
+}
+
+func (m *MockTotalAssetRepository) FetchTotalAssetListById(ctx context.Context, userId uint, day int) ([]model.TotalAsset, error) {
+    args := m.Called(ctx, userId, day)
+    return args.Get(0).([]model.TotalAsset), args.Error(1)
+}
+
+func (m *MockTotalAssetRepository) FindTodayTotalAsset(ctx context.Context, userId uint) (*model.TotalAsset, error) {
+    args := m.Called(ctx, userId)
+    return args.Get(0).(*model.TotalAsset), args.Error(1)
+}
+
+func (m *MockTotalAssetRepository) UpdateTotalAsset(ctx context.Context, dto repoTotalAsset.UpdateTotalAssetDto) (*model.TotalAsset, error) {
+    args := m.Called(ctx, dto)
+    return args.Get(0).(*model.TotalAsset), args.Error(1)
+}
+
+func (m *MockTotalAssetRepository) CreateTodayTotalAsset(ctx context.Context, dto repoTotalAsset.CreateTotalAssetDto) (*model.TotalAsset, error) {
+    args := m.Called(ctx, dto)
+    return args.Get(0).(*model.TotalAsset), args.Error(1)
+}
+
+type testMocks struct {
+    auth             *auth.MockAuthService
+    stockRepo        *stock.MockUsStockRepository
+    japanFundRepo    *repoJapanFund.MockJapanFundRepository
+    cryptoRepo       *repoCrypto.MockCryptoRepository
+    fixedIncomeRepo  *repoFixedIncome.MockFixedIncomeAssetRepository
+    totalAssetRepo   *MockTotalAssetRepository
+    marketPriceRepo  *marketPrice.MockMarketPriceRepository
+    currencyRepo     *repoCurrency.MockCurrencyRepository
+    marketCryptoRepo *repoMarketCrypto.MockCryptoRepository
+    fundPriceRepo    *repoFundPrice.MockFundPriceRepository
+    instrumentRepo   *repoInstrument.MockInstrumentRepository
+    taxReportRepo    *repoTaxReport.MockTaxReportRepository
+}
+
+func newTestService() (ExportService, testMocks) {
+    mocks := testMocks{
+        auth:             auth.NewMockAuthService(),
+        stockRepo:        stock.NewMockUsStockRepository(),
+        japanFundRepo:    repoJapanFund.NewMockJapanFundRepository(),
+        cryptoRepo:       repoCrypto.NewMockCryptoRepository(),
+        fixedIncomeRepo:  repoFixedIncome.NewMockFixedIncomeAssetRepository(),
+        totalAssetRepo:   new(MockTotalAssetRepository),
+        marketPriceRepo:  marketPrice.NewMockMarketPriceRepository(),
+        currencyRepo:     repoCurrency.NewMockCurrencyRepository(),
+        marketCryptoRepo: repoMarketCrypto.NewMockCryptoRepository(),
+        fundPriceRepo:    repoFundPrice.NewMockFundPriceRepository(),
+        instrumentRepo:   repoInstrument.NewMockInstrumentRepository(),
+        taxReportRepo:    repoTaxReport.NewMockTaxReportRepository(),
+    }
+    service := NewExportService(mocks.auth, mocks.stockRepo, mocks.japanFundRepo, mocks.cryptoRepo, mocks.fixedIncomeRepo, mocks.totalAssetRepo, mocks.marketPriceRepo, mocks.currencyRepo, mocks.marketCryptoRepo, mocks.fundPriceRepo, mocks.instrumentRepo, mocks.taxReportRepo)
+    return service, mocks
+}
+
+func setupHoldings(mocks testMocks, userId uint) {
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mocks.stockRepo.On("FetchUsStockListById", mock.Anything, userId).Return([]model.UsStock{
+        {Code: "AAPL", Quantity: 10, GetPrice: 150, UsdJpy: 140, UserId: userId},
+        {Code: "XYZ", Quantity: 1, GetPrice: 10, UsdJpy: 140, UserId: userId},
+    }, nil)
+    mocks.japanFundRepo.On("FetchJapanFundListById", mock.Anything, userId).Return([]model.JapanFund{
+        {Code: "0331418A", Name: "eMAXIS Slim 米国株式(S&P500)", GetPrice: 20000, GetPriceTotal: 200000, UserId: userId},
+    }, nil)
+    mocks.cryptoRepo.On("FetchCryptoListById", mock.Anything, userId).Return([]model.Crypto{
+        {Code: "btc", Quantity: 0.1, GetPrice: 5000000, UserId: userId},
+    }, nil)
+    mocks.fixedIncomeRepo.On("FetchFixedIncomeAssetListById", mock.Anything, userId).Return([]model.FixedIncomeAsset{
+        {Code: "個人向け国債", GetPriceTotal: 1000000, UserId: userId},
+    }, nil)
+    mocks.marketPriceRepo.On("FetchMarketPriceList", mock.Anything, []string{"AAPL", "XYZ"}).Return([]marketPrice.MarketPriceDto{
+        {Ticker: "AAPL", CurrentPrice: 180},
+    }, nil)
+    mocks.currencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(150.0, nil)
+    mocks.instrumentRepo.On("FetchInstrumentListByTickers", mock.Anything, []string{"AAPL", "XYZ"}).Return([]model.Instrument{
+        {Ticker: "AAPL", Name: "Apple Inc."},
+    }, nil)
+    mocks.fundPriceRepo.On("FetchFundPriceList", mock.Anything).Return([]model.FundPrice{
+        {Code: "0331418A", Price: 25000},
+    }, nil)
+    mocks.marketCryptoRepo.On("FetchCryptoPrice", "btc").Return(&repoMarketCrypto.Crypto{Name: "btc", Price: 6000000}, nil)
+}
+
+func TestExportHoldingsCSV(t *testing.T) {
+    service, mocks := newTestService()
+    setupHoldings(mocks, 1)
+
+    data, err := service.ExportHoldings(context.Background(), "csv", "ja")
+
+    assert.NoError(t, err)
+    expected := "\xef\xbb\xbf" + strings.Join([]string{
+        "資産の種類,コード,銘柄名,保有数量,取得単価,現在値,通貨,取得総額(円),評価額(円),評価損益(円)",
+        "米国株式,AAPL,Apple Inc.,10,150,180,USD,210000,270000,60000",
+        // 現在値が取得できない銘柄は評価額を空欄にする
+        "米国株式,XYZ,,1,10,,USD,1400,,",
+        "投資信託,0331418A,eMAXIS Slim 米国株式(S&P500),100000,20000,25000,JPY,200000,250000,50000",
+        "暗号通貨,btc,,0.1,5000000,6000000,JPY,500000,600000,100000",
+        "固定利回り資産,個人向け国債,,,,,JPY,1000000,1000000,0",
+    }, "\n") + "\n"
+    assert.Equal(t, expected, string(data))
+}
+
+// 固定利回り資産は評価方法に従って通貨ごとに評価し、満期償還済みの資産は出力しない
+func TestExportHoldingsFixedIncome(t *testing.T) {
+    service, mocks := newTestService()
+    userId := uint(1)
+    redemptionAmount := 10000.0
+    purchaseUsdJpy := 140.0
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(userId, nil)
+    mocks.stockRepo.On("FetchUsStockListById", mock.Anything, userId).Return([]model.UsStock{}, nil)
+    mocks.japanFundRepo.On("FetchJapanFundListById", mock.Anything, userId).Return([]model.JapanFund{}, nil)
+    mocks.cryptoRepo.On("FetchCryptoListById", mock.Anything, userId).Return([]model.Crypto{}, nil)
+    mocks.fixedIncomeRepo.On("FetchFixedIncomeAssetListById", mock.Anything, userId).Return([]model.FixedIncomeAsset{
+        {Code: "米国債", GetPriceTotal: 9500, RedemptionAmount: &redemptionAmount, ValuationMode: repoFixedIncome.ValuationModeFace, Currency: repoFixedIncome.CurrencyUsd, UsdJpy: &purchaseUsdJpy, UserId: userId},
+        {Code: "償還済みの社債", GetPriceTotal: 500000, Currency: repoFixedIncome.CurrencyJpy, Status: repoFixedIncome.StatusMatured, UserId: userId},
+    }, nil)
+    mocks.currencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(150.0, nil)
+
+    data, err := service.ExportHoldings(context.Background(), "csv", "ja")
+
+    assert.NoError(t, err)
+    expected := "\xef\xbb\xbf" + strings.Join([]string{
+        "資産の種類,コード,銘柄名,保有数量,取得単価,現在値,通貨,取得総額(円),評価額(円),評価損益(円)",
+        // 取得総額は購入時為替、評価額は額面を現在のドル円で換算する
+        "固定利回り資産,米国債,,,,,USD,1330000,1500000,170000",
+    }, "\n") + "\n"
+    assert.Equal(t, expected, string(data))
+}
+
+func TestExportHoldingsXLSX(t *testing.T) {
+    service, mocks := newTestService()
+    setupHoldings(mocks, 1)
+
+    data, err := service.ExportHoldings(context.Background(), "xlsx", "en")
+    assert.NoError(t, err)
+
+    reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+    assert.NoError(t, err)
+    files := map[string]string{}
+    for _, file := range reader.File {
+        rc, err := file.Open()
+        assert.NoError(t, err)
+        content, _ := io.ReadAll(rc)
+        rc.Close()
+        files[file.Name] = string(content)
+    }
+    assert.Contains(t, files, "[Content_Types].xml")
+    assert.Contains(t, files["xl/workbook.xml"], `<sheet name="Holdings"`)
+    sheet := files["xl/worksheets/sheet1.xml"]
+    assert.Contains(t, sheet, `<c r="A1" t="inlineStr" s="1"><is><t xml:space="preserve">Asset Type</t></is></c>`)
+    assert.Contains(t, sheet, `<c r="J1" t="inlineStr" s="1"><is><t xml:space="preserve">Unrealized Gain (JPY)</t></is></c>`)
+    assert.Contains(t, sheet, `<c r="B2" t="inlineStr"><is><t xml:space="preserve">AAPL</t></is></c>`)
+    assert.Contains(t, sheet, `<c r="I2"><v>270000</v></c>`)
+    assert.Contains(t, sheet, `<c r="C4" t="inlineStr"><is><t xml:space="preserve">eMAXIS Slim 米国株式(S&amp;P500)</t></is></c>`)
+    assert.NotContains(t, sheet, `r="F3"`)
+}
+
+func TestExportTotalAssets(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    mocks.totalAssetRepo.On("FetchTotalAssetListById", mock.Anything, uint(1), 0).Return([]model.TotalAsset{
+        {Model: gorm.Model{CreatedAt: time.Date(2024, 1, 11, 1, 0, 0, 0, time.UTC)}, CashJpy: 100000, CashUsd: 1000, Stock: 1500000, Fund: 300000, Crypto: 50000, FixedIncomeAsset: 200000, UsdJpy: 150},
+        {Model: gorm.Model{CreatedAt: time.Date(2024, 1, 10, 1, 0, 0, 0, time.UTC)}, CashJpy: 100000, CashUsd: 1000, Stock: 1450000},
+    }, nil)
+
+    data, err := service.ExportTotalAssets(context.Background(), "csv", "en")
+
+    assert.NoError(t, err)
+    expected := "\xef\xbb\xbf" + strings.Join([]string{
+        "Date,Cash (JPY),Cash (USD),US Stocks (JPY),Japan Funds (JPY),Crypto (JPY),Fixed Income (JPY),Total (JPY),USD/JPY",
+        // ドル円が記録されていない日はドル建ての現金を換算できないため合計を空欄にする
+        "2024-01-10,100000,1000,1450000,0,0,0,,",
+        "2024-01-11,100000,1000,1500000,300000,50000,200000,2300000,150",
+    }, "\n") + "\n"
+    assert.Equal(t, expected, string(data))
+}
+
+func TestExportTaxReport(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+    mocks.taxReportRepo.On("FetchDividendReceiptListByYear", mock.Anything, uint(1), 2024).Return([]model.DividendReceipt{
+        {Code: "KO", PaymentDate: "2024-04-01", Amount: 46, ForeignTax: 4.6, DomesticTax: 1053, UsdJpy: 150.25},
+        {Code: "AAPL", PaymentDate: "2024-05-16", Amount: 2.4, ForeignTax: 0.24, DomesticTax: 67, UsdJpy: 155},
+        {Code: "KO", PaymentDate: "2024-07-01", Amount: 46, ForeignTax: 4.6, DomesticTax: 1119, UsdJpy: 160},
+    }, nil)
+    mocks.taxReportRepo.On("FetchStockSaleListByYear", mock.Anything, uint(1), 2024).Return([]model.StockSale{
+        {Code: "AAPL", TradeDate: "2024-06-03", Quantity: 10, SalePrice: 190, GetPrice: 150, Fee: 2, UsdJpy: 150, GetUsdJpy: 130},
+    }, nil)
+
+    data, err := service.ExportTaxReport(context.Background(), 2024, "csv", "ja")
+
+    assert.NoError(t, err)
+    expected := "\xef\xbb\xbf" + strings.Join([]string{
+        "銘柄,国名,配当金(円),外国源泉徴収税額(円),国内源泉徴収税額(円),譲渡価額(円),取得費(円),売却手数料(円),譲渡損益(円)",
+        "AAPL,アメリカ,372,37,67,285000,195000,300,89700",
+        "KO,アメリカ,14271,1427,2172,0,0,0,0",
+        "合計,,14643,1464,2239,285000,195000,300,89700",
+    }, "\n") + "\n"
+    assert.Equal(t, expected, string(data))
+
+    _, err = service.ExportTaxReport(context.Background(), 99999, "csv", "ja")
+    assert.ErrorIs(t, err, errInvalidYear)
+}
+
+func TestExportInvalidParams(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(1), nil)
+
+    _, err := service.ExportHoldings(context.Background(), "pdf", "ja")
+    assert.ErrorIs(t, err, errInvalidFormat)
+
+    _, err = service.ExportTotalAssets(context.Background(), "csv", "fr")
+    assert.ErrorIs(t, err, errInvalidLang)
+}
+
+func TestExportUnauthenticated(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.auth.On("FetchUserIdAccessToken", mock.Anything).Return(uint(0), errors.New("invalid token"))
+
+    _, err := service.ExportHoldings(context.Background(), "csv", "ja")
+
+    assert.ErrorIs(t, err, errUnauthenticated)
+    mocks.stockRepo.AssertNotCalled(t, "FetchUsStockListById", mock.Anything, mock.Anything)
+}
+
+func TestColumnName(t *testing.T) {
+    assert.Equal(t, "A", columnName(0))
+    assert.Equal(t, "Z", columnName(25))
+    assert.Equal(t, "AA", columnName(26))
+    assert.Equal(t, "AZ", columnName(51))
+    assert.Equal(t, "BA", columnName(52))
+}
@@ -0,0 +1,127 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"strconv"
+)
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+    `<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+    `<Default Extension="xml" ContentType="application/xml"/>` +
+    `<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+    `<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+    `<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+    `</Types>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+    `<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+    `</Relationships>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+    `<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+    `<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+    `</Relationships>`
+
+// 見出し行を太字にするためのスタイル(s="1")
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+    `<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+    `<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+    `<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+    `<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+    `<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+    `</styleSheet>`
+
+// 表をXLSX(1シートのワークブック)に変換する
+// 外部ライブラリは使わず、Office Open XML の最小構成を ZIP にまとめて作成する
+func renderXLSX(table exportTable, sheetName string) ([]byte, error) {
+    var buf bytes.Buffer
+    writer := zip.NewWriter(&buf)
+    files := []struct {
+        name    string
+        content []byte
+    }{
+        {"[Content_Types].xml", []byte(xlsxContentTypes)},
+        {"_rels/.rels", []byte(xlsxRootRels)},
+        {"xl/workbook.xml", createWorkbookXML(sheetName)},
+        {"xl/_rels/workbook.xml.rels", []byte(xlsxWorkbookRels)},
+        {"xl/styles.xml", []byte(xlsxStyles)},
+        {"xl/worksheets/sheet1.xml", createSheetXML(table)},
+    }
+    for _, file := range files {
+        w, err := writer.Create(file.name)
+        if err != nil {
+            return nil, err
+        }
+        if _, err := w.Write(file.content); err != nil {
+            return nil, err
+        }
+    }
+    if err := writer.Close(); err != nil {
+        return nil, err
+    }
+    return buf.Bytes(), nil
+}
+
+func createWorkbookXML(sheetName string) []byte {
+    var buf bytes.Buffer
+    buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+    buf.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+    buf.WriteString(`<sheets><sheet name="`)
+    xml.EscapeText(&buf, []byte(sheetName))
+    buf.WriteString(`" sheetId="1" r:id="rId1"/></sheets></workbook>`)
+    return buf.Bytes()
+}
+
+// 文字列はインライン文字列、数値は数値セルとして出力する(値がないセルは出力しない)
+func createSheetXML(table exportTable) []byte {
+    var buf bytes.Buffer
+    buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+    buf.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+    header := make([]exportCell, len(table.headers))
+    for i, title := range table.headers {
+        header[i] = textCell(title)
+    }
+    writeRow(&buf, 1, header, true)
+    for i, row := range table.rows {
+        writeRow(&buf, i+2, row, false)
+    }
+    buf.WriteString(`</sheetData></worksheet>`)
+    return buf.Bytes()
+}
+
+func writeRow(buf *bytes.Buffer, rowNumber int, cells []exportCell, bold bool) {
+    buf.WriteString(`<row r="` + strconv.Itoa(rowNumber) + `">`)
+    for i, cell := range cells {
+        ref := columnName(i) + strconv.Itoa(rowNumber)
+        style := ""
+        if bold {
+            style = ` s="1"`
+        }
+        switch {
+        case cell.number != nil:
+            buf.WriteString(`<c r="` + ref + `"` + style + `><v>` + strconv.FormatFloat(*cell.number, 'f', -1, 64) + `</v></c>`)
+        case cell.text != "":
+            buf.WriteString(`<c r="` + ref + `" t="inlineStr"` + style + `><is><t xml:space="preserve">`)
+            xml.EscapeText(buf, []byte(cell.text))
+            buf.WriteString(`</t></is></c>`)
+        }
+    }
+    buf.WriteString(`</row>`)
+}
+
+// 列番号(0始まり)を A, B, ..., Z, AA, AB ... の列名に変換する
+func columnName(index int) string {
+    name := ""
+    for index >= 0 {
+        name = string(rune('A'+index%26)) + name
+        index = index/26 - 1
+    }
+    return name
+}
@@ -3,18 +3,33 @@ package rest
 import (
 	authService "my-us-stock-backend/app/common/auth"
 	"my-us-stock-backend/app/common/auth/logic"
+	dailyReport "my-us-stock-backend/app/common/daily-report"
+	commonEvent "my-us-stock-backend/app/common/event"
+	"my-us-stock-backend/app/common/notification"
 	repoUser "my-us-stock-backend/app/repository/user"
 
 	repoCrypto "my-us-stock-backend/app/repository/assets/crypto"
 	repoFixedIncome "my-us-stock-backend/app/repository/assets/fixed-income"
 	repoJapanFund "my-us-stock-backend/app/repository/assets/fund"
 	repoStock "my-us-stock-backend/app/repository/assets/stock"
+	repoBackup "my-us-stock-backend/app/repository/backup"
+	repoBrokerageAccount "my-us-stock-backend/app/repository/brokerage-account"
+	repoCalendar "my-us-stock-backend/app/repository/calendar"
+	repoDailyReport "my-us-stock-backend/app/repository/daily-report"
 	repoMarketPrice "my-us-stock-backend/app/repository/market-price"
 	repoMarketCrypto "my-us-stock-backend/app/repository/market-price/crypto"
 	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
 	repoFundPrice "my-us-stock-backend/app/repository/market-price/fund"
+	repoInstrument "my-us-stock-backend/app/repository/market-price/instrument"
+	repoNotification "my-us-stock-backend/app/repository/notification"
+	repoShareToken "my-us-stock-backend/app/repository/share-token"
+	repoTaxReport "my-us-stock-backend/app/repository/tax-report"
 	repoTotalAsset "my-us-stock-backend/app/repository/total-assets"
 	"my-us-stock-backend/app/rest/auth"
+	"my-us-stock-backend/app/rest/backup"
+	"my-us-stock-backend/app/rest/calendar"
+	"my-us-stock-backend/app/rest/export"
+	"my-us-stock-backend/app/rest/share"
 	totalAssets "my-us-stock-backend/app/rest/total-assets"
 
 	"my-us-stock-backend/app/rest/admin"
@@ -38,6 +53,14 @@ func SetupREST(r *gin.Engine, db *gorm.DB) {
     fixedIncomeAssetRepo := repoFixedIncome.NewFixedIncomeRepository(db)
     cryptoRepo := repoCrypto.NewCryptoRepository(db)
     fundPriceRepo := repoFundPrice.NewFetchFundRepository(db)
+    calendarTokenRepo := repoCalendar.NewCalendarTokenRepository(db)
+    notificationRepo := repoNotification.NewNotificationRepository(db)
+    dailyReportRepo := repoDailyReport.NewDailyReportRepository(db)
+    instrumentRepo := repoInstrument.NewInstrumentRepository(db)
+    taxReportRepo := repoTaxReport.NewTaxReportRepository(db)
+    backupRepo := repoBackup.NewBackupRepository(db)
+    brokerageAccountRepo := repoBrokerageAccount.NewBrokerageAccountRepository(db)
+    shareTokenRepo := repoShareToken.NewShareTokenRepository(db)
 
     // 認証機能
     userLogic := logic.NewUserLogic()
@@ -53,9 +76,26 @@ func SetupREST(r *gin.Engine, db *gorm.DB) {
     authService := authService.NewAuthService(userRepo, userLogic, responseLogic, jwtLogic, authValidation)
     authController := auth.NewAuthController(authService)
 
-    totalAssetService := totalAssets.NewTotalAssetService(totalAssetRepo, usStockRepo, marketPriceRepo, currencyRepo, japanFundRepo, cryptoRepo, fixedIncomeAssetRepo, marketCryptoRepo, fundPriceRepo)
+    notificationService := notification.NewNotificationService(notificationRepo, nil)
+
+    eventCollector := commonEvent.NewEventCollector(usStockRepo, marketPriceRepo)
+    dailyReportSender := dailyReport.NewReportSender(dailyReportRepo, totalAssetRepo, usStockRepo, marketPriceRepo, currencyRepo, eventCollector, notificationService)
+
+    totalAssetService := totalAssets.NewTotalAssetService(totalAssetRepo, usStockRepo, marketPriceRepo, currencyRepo, japanFundRepo, cryptoRepo, fixedIncomeAssetRepo, marketCryptoRepo, fundPriceRepo, notificationService, dailyReportSender, brokerageAccountRepo)
     totalAssetController := totalAssets.NewTotalAssetController(totalAssetService)
 
+    calendarService := calendar.NewCalendarService(eventCollector, calendarTokenRepo)
+    calendarController := calendar.NewCalendarController(calendarService)
+
+    exportService := export.NewExportService(authService, usStockRepo, japanFundRepo, cryptoRepo, fixedIncomeAssetRepo, totalAssetRepo, marketPriceRepo, currencyRepo, marketCryptoRepo, fundPriceRepo, instrumentRepo, taxReportRepo)
+    exportController := export.NewExportController(exportService)
+
+    backupService := backup.NewBackupService(authService, backupRepo)
+    backupController := backup.NewBackupController(backupService)
+
+    shareService := share.NewShareService(shareTokenRepo, usStockRepo, japanFundRepo, cryptoRepo, fixedIncomeAssetRepo, totalAssetRepo, marketPriceRepo, currencyRepo, marketCryptoRepo, fundPriceRepo)
+    shareController := share.NewShareController(shareService)
+
     adminService := admin.NewFundPriceService(fundPriceRepo)
     adminController := admin.NewFundPriceController(adminService)
 
@@ -67,8 +107,20 @@ func SetupREST(r *gin.Engine, db *gorm.DB) {
     r.POST("/api/v1/signup", authController.SignUp)
     r.POST("/api/v1/total-assets", totalAssetController.CreateTodayTotalAsset)
     r.POST("/api/v1/refresh", authController.RefreshAccessToken)
+    // カレンダーアプリ購読用(トークン認証)
+    r.GET("/api/v1/calendar/events.ics", calendarController.GetCalendarFeed)
+    // 共有リンクで公開したポートフォリオの閲覧用(トークン認証)
+    r.GET("/api/v1/share/:token", shareController.GetSharedPortfolio)
+    // 保有資産・資産推移のダウンロード(Cookie認証)
+    r.GET("/api/v1/export/holdings", exportController.GetHoldings)
+    r.GET("/api/v1/export/total-assets", exportController.GetTotalAssets)
+    r.GET("/api/v1/export/tax-report", exportController.GetTaxReport)
+    // アカウントのバックアップ・復元(Cookie認証)
+    r.GET("/api/v1/backup", backupController.GetBackup)
+    r.POST("/api/v1/backup/restore", backupController.RestoreBackup)
     // 管理画面用
     r.GET("/api/v1/admin/fund-prices", adminController.GetFundPrices)
+    r.GET("/api/v1/admin/fund-prices/nav-failures", adminController.GetNavFetchFailedFundPrices)
     r.POST("/api/v1/admin/fund-prices", adminController.CreateFundPrice)
     r.PUT("/api/v1/admin/fund-prices", adminController.UpdateFundPrice)
 }
@@ -0,0 +1,158 @@
+package share
+
+import (
+	"math"
+	"my-us-stock-backend/app/database/model"
+	repoShareToken "my-us-stock-backend/app/repository/share-token"
+	"sort"
+	"time"
+)
+
+// 資産の種類(構成比の表示順)
+const (
+    assetTypeUsStock     = "US_STOCK"
+    assetTypeJapanFund   = "JAPAN_FUND"
+    assetTypeCrypto      = "CRYPTO"
+    assetTypeFixedIncome = "FIXED_INCOME"
+)
+
+var assetTypes = []string{assetTypeUsStock, assetTypeJapanFund, assetTypeCrypto, assetTypeFixedIncome}
+
+// SharedPortfolio は共有リンクで公開するポートフォリオです(金額は公開範囲が AMOUNT の場合のみ設定する)
+type SharedPortfolio struct {
+    Privacy     string              `json:"privacy"`
+    ExpiresAt   string              `json:"expiresAt"`
+    TotalAmount *float64            `json:"totalAmount,omitempty"`
+    Allocation  []SharedAllocation  `json:"allocation"`
+    Holdings    []SharedHolding     `json:"holdings"`
+    Performance []SharedPerformance `json:"performance"`
+}
+
+// SharedAllocation は資産の種類ごとの構成比(%)です
+type SharedAllocation struct {
+    AssetType string   `json:"assetType"`
+    Weight    float64  `json:"weight"`
+    Amount    *float64 `json:"amount,omitempty"`
+}
+
+// SharedHolding は銘柄ごとの構成比(%)と損益率(%)です
+type SharedHolding struct {
+    AssetType string   `json:"assetType"`
+    Code      string   `json:"code"`
+    Name      string   `json:"name,omitempty"`
+    Weight    float64  `json:"weight"`
+    GainRate  float64  `json:"gainRate"`
+    Amount    *float64 `json:"amount,omitempty"`
+}
+
+// SharedPerformance は資産総額の推移です(ReturnRate は期間の初日からの変化率(%))
+type SharedPerformance struct {
+    Date        string   `json:"date"`
+    ReturnRate  float64  `json:"returnRate"`
+    TotalAmount *float64 `json:"totalAmount,omitempty"`
+}
+
+// 円換算で評価した保有資産
+type valuedHolding struct {
+    assetType string
+    code      string
+    name      string
+    cost      float64
+    value     float64
+}
+
+// 公開用のポートフォリオを作成する
+func createSharedPortfolio(shareToken *model.ShareToken, holdings []valuedHolding, totalAssets []model.TotalAsset) *SharedPortfolio {
+    showAmount := shareToken.Privacy == repoShareToken.PrivacyAmount
+    var total float64
+    typeTotals := map[string]float64{}
+    for _, holding := range holdings {
+        total += holding.value
+        typeTotals[holding.assetType] += holding.value
+    }
+
+    portfolio := &SharedPortfolio{
+        Privacy:     shareToken.Privacy,
+        ExpiresAt:   shareToken.ExpiresAt.Format(time.RFC3339),
+        Allocation:  []SharedAllocation{},
+        Holdings:    make([]SharedHolding, len(holdings)),
+        Performance: createSharedPerformance(totalAssets, showAmount),
+    }
+    if showAmount {
+        portfolio.TotalAmount = amount(total)
+    }
+    for _, assetType := range assetTypes {
+        if typeTotals[assetType] == 0 {
+            continue
+        }
+        allocation := SharedAllocation{AssetType: assetType, Weight: weight(typeTotals[assetType], total)}
+        if showAmount {
+            allocation.Amount = amount(typeTotals[assetType])
+        }
+        portfolio.Allocation = append(portfolio.Allocation, allocation)
+    }
+    for i, holding := range holdings {
+        sharedHolding := SharedHolding{
+            AssetType: holding.assetType,
+            Code:      holding.code,
+            Name:      holding.name,
+            Weight:    weight(holding.value, total),
+        }
+        if holding.cost != 0 {
+            sharedHolding.GainRate = round2((holding.value/holding.cost - 1) * 100)
+        }
+        if showAmount {
+            sharedHolding.Amount = amount(holding.value)
+        }
+        portfolio.Holdings[i] = sharedHolding
+    }
+    // 構成比の大きい順
+    sort.SliceStable(portfolio.Holdings, func(i, j int) bool {
+        return portfolio.Holdings[i].Weight > portfolio.Holdings[j].Weight
+    })
+    return portfolio
+}
+
+// 資産総額の推移を古い順に作成する
+// ドル建ての現金があるのに登録時点のドル円が記録されていない日は合計が計算できないため除外する
+func createSharedPerformance(totalAssets []model.TotalAsset, showAmount bool) []SharedPerformance {
+    performance := []SharedPerformance{}
+    var base float64
+    for i := len(totalAssets) - 1; i >= 0; i-- {
+        asset := totalAssets[i]
+        if asset.UsdJpy == 0 && asset.CashUsd != 0 {
+            continue
+        }
+        total := asset.CashJpy + asset.CashUsd*asset.UsdJpy + asset.Stock + asset.Fund + asset.Crypto + asset.FixedIncomeAsset
+        if base == 0 {
+            base = total
+        }
+        point := SharedPerformance{Date: asset.CreatedAt.UTC().Format("2006-01-02")}
+        if base != 0 {
+            point.ReturnRate = round2((total/base - 1) * 100)
+        }
+        if showAmount {
+            point.TotalAmount = amount(total)
+        }
+        performance = append(performance, point)
+    }
+    return performance
+}
+
+// 構成比(%)を小数点以下2桁で計算する
+func weight(value float64, total float64) float64 {
+    if total == 0 {
+        return 0
+    }
+    return round2(value / total * 100)
+}
+
+func round2(value float64) float64 {
+    return math.Round(value*100) / 100
+}
+
+// 金額は円単位に丸める
+func amount(value float64) *float64 {
+    rounded := math.Round(value)
+    return &rounded
+}
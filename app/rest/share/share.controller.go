@@ -0,0 +1,34 @@
+package share
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ShareController struct {
+    ShareService ShareService
+}
+
+func NewShareController(shareService ShareService) *ShareController {
+    return &ShareController{ShareService: shareService}
+}
+
+// GetSharedPortfolio は共有リンクで公開されたポートフォリオを返却します(ログイン不要)
+// 認証はパスのトークンで行います(例: /api/v1/share/xxx)
+func (sc *ShareController) GetSharedPortfolio(c *gin.Context) {
+    portfolio, err := sc.ShareService.FetchSharedPortfolio(c.Request.Context(), c.Param("token"))
+    if errors.Is(err, errInvalidToken) {
+        c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+        return
+    }
+    // 匿名で呼び出されるため、内部のエラー内容は返さずにログにのみ出力する
+    if err != nil {
+        log.Printf("共有ポートフォリオの取得に失敗しました: %v", err)
+        c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+        return
+    }
+    c.JSON(http.StatusOK, portfolio)
+}
@@ -0,0 +1,81 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockShareService の定義
+type MockShareService struct {
+    mock.Mock
+}
+
+func (m *MockShareService) FetchSharedPortfolio(ctx context.Context, token string) (*SharedPortfolio, error) {
+    args := m.Called(ctx, token)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*SharedPortfolio), args.Error(1)
+}
+
+func newTestContext(token string) (*gin.Context, *httptest.ResponseRecorder) {
+    req, _ := http.NewRequest(http.MethodGet, "/api/v1/share/"+token, nil)
+    w := httptest.NewRecorder()
+    c, _ := gin.CreateTestContext(w)
+    c.Request = req
+    c.Params = gin.Params{{Key: "token", Value: token}}
+    return c, w
+}
+
+func TestShareController_GetSharedPortfolio(t *testing.T) {
+    mockService := new(MockShareService)
+    controller := NewShareController(mockService)
+
+    portfolio := &SharedPortfolio{
+        Privacy:     "PERCENTAGE",
+        ExpiresAt:   "2099-01-01T00:00:00Z",
+        Allocation:  []SharedAllocation{{AssetType: "US_STOCK", Weight: 100}},
+        Holdings:    []SharedHolding{{AssetType: "US_STOCK", Code: "AAPL", Weight: 100, GainRate: 12.5}},
+        Performance: []SharedPerformance{},
+    }
+    mockService.On("FetchSharedPortfolio", mock.Anything, "valid-token").Return(portfolio, nil)
+
+    c, w := newTestContext("valid-token")
+    controller.GetSharedPortfolio(c)
+
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.JSONEq(t, `{
+        "privacy": "PERCENTAGE",
+        "expiresAt": "2099-01-01T00:00:00Z",
+        "allocation": [{"assetType": "US_STOCK", "weight": 100}],
+        "holdings": [{"assetType": "US_STOCK", "code": "AAPL", "weight": 100, "gainRate": 12.5}],
+        "performance": []
+    }`, w.Body.String())
+    mockService.AssertExpectations(t)
+}
+
+// トークンが無効な場合は404、取得に失敗した場合は500
+func TestShareController_GetSharedPortfolioInvalidToken(t *testing.T) {
+    mockService := new(MockShareService)
+    controller := NewShareController(mockService)
+
+    mockService.On("FetchSharedPortfolio", mock.Anything, "unknown").Return(nil, errInvalidToken)
+    mockService.On("FetchSharedPortfolio", mock.Anything, "broken").Return(nil, errors.New("db error"))
+
+    c, w := newTestContext("unknown")
+    controller.GetSharedPortfolio(c)
+    assert.Equal(t, http.StatusNotFound, w.Code)
+
+    // 内部のエラー内容は返さない
+    c, w = newTestContext("broken")
+    controller.GetSharedPortfolio(c)
+    assert.Equal(t, http.StatusInternalServerError, w.Code)
+    assert.JSONEq(t, `{"error": "internal server error"}`, w.Body.String())
+}
@@ -0,0 +1,155 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/common/valuation"
+	repoCrypto "my-us-stock-backend/app/repository/assets/crypto"
+	repoFixedIncome "my-us-stock-backend/app/repository/assets/fixed-income"
+	repoJapanFund "my-us-stock-backend/app/repository/assets/fund"
+	"my-us-stock-backend/app/repository/assets/stock"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	repoMarketCrypto "my-us-stock-backend/app/repository/market-price/crypto"
+	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
+	repoFundPrice "my-us-stock-backend/app/repository/market-price/fund"
+	repoShareToken "my-us-stock-backend/app/repository/share-token"
+	repoTotalAsset "my-us-stock-backend/app/repository/total-assets"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 資産推移として公開する日数
+const performanceDays = 30
+
+var errInvalidToken = errors.New("share link not found or expired")
+
+// ShareService インターフェースの定義
+type ShareService interface {
+    FetchSharedPortfolio(ctx context.Context, token string) (*SharedPortfolio, error)
+}
+
+// DefaultShareService 構造体の定義
+type DefaultShareService struct {
+    ShareTokenRepo repoShareToken.ShareTokenRepository
+    StockRepo stock.UsStockRepository
+    JapanFundRepo repoJapanFund.JapanFundRepository
+    CryptoRepo repoCrypto.CryptoRepository
+    FixedIncomeRepo repoFixedIncome.FixedIncomeRepository
+    TotalAssetRepo repoTotalAsset.TotalAssetRepository
+    MarketPriceRepo marketPrice.MarketPriceRepository
+    CurrencyRepo repoCurrency.CurrencyRepository
+    MarketCryptoRepo repoMarketCrypto.CryptoRepository
+    FundPriceRepo repoFundPrice.FundPriceRepository
+}
+
+// NewShareService は DefaultShareService の新しいインスタンスを作成します
+func NewShareService(shareTokenRepo repoShareToken.ShareTokenRepository, stockRepo stock.UsStockRepository, japanFundRepo repoJapanFund.JapanFundRepository, cryptoRepo repoCrypto.CryptoRepository, fixedIncomeRepo repoFixedIncome.FixedIncomeRepository, totalAssetRepo repoTotalAsset.TotalAssetRepository, marketPriceRepo marketPrice.MarketPriceRepository, currencyRepo repoCurrency.CurrencyRepository, marketCryptoRepo repoMarketCrypto.CryptoRepository, fundPriceRepo repoFundPrice.FundPriceRepository) ShareService {
+    return &DefaultShareService{
+        ShareTokenRepo: shareTokenRepo,
+        StockRepo: stockRepo,
+        JapanFundRepo: japanFundRepo,
+        CryptoRepo: cryptoRepo,
+        FixedIncomeRepo: fixedIncomeRepo,
+        TotalAssetRepo: totalAssetRepo,
+        MarketPriceRepo: marketPriceRepo,
+        CurrencyRepo: currencyRepo,
+        MarketCryptoRepo: marketCryptoRepo,
+        FundPriceRepo: fundPriceRepo,
+    }
+}
+
+// FetchSharedPortfolio は共有リンクのトークンに紐づくユーザーのポートフォリオを匿名化して取得します
+// 公開範囲が PERCENTAGE の場合は金額を含めず、構成比・損益率・資産総額の変化率のみ返却します
+func (s *DefaultShareService) FetchSharedPortfolio(ctx context.Context, token string) (*SharedPortfolio, error) {
+    if token == "" {
+        return nil, errInvalidToken
+    }
+    shareToken, err := s.ShareTokenRepo.FindValidShareToken(ctx, token, time.Now())
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return nil, errInvalidToken
+    }
+    if err != nil {
+        return nil, err
+    }
+    holdings, err := s.fetchValuedHoldings(ctx, shareToken.UserId)
+    if err != nil {
+        return nil, err
+    }
+    totalAssets, err := s.TotalAssetRepo.FetchTotalAssetListById(ctx, shareToken.UserId, performanceDays)
+    if err != nil {
+        return nil, err
+    }
+    return createSharedPortfolio(shareToken, holdings, totalAssets), nil
+}
+
+// 保有資産を現在の価格で円換算して評価する(資産総額と同じ評価方法を使う)
+func (s *DefaultShareService) fetchValuedHoldings(ctx context.Context, userId uint) ([]valuedHolding, error) {
+    var holdings []valuedHolding
+    valuer := valuation.NewValuation(s.MarketPriceRepo, s.CurrencyRepo, s.MarketCryptoRepo, s.FundPriceRepo)
+
+    usStocks, err := s.StockRepo.FetchUsStockListById(ctx, userId)
+    if err != nil {
+        return nil, err
+    }
+    if len(usStocks) != 0 {
+        values, err := valuer.StockValues(ctx, usStocks)
+        if err != nil {
+            return nil, err
+        }
+        for i, usStock := range usStocks {
+            holdings = append(holdings, valuedHolding{assetType: assetTypeUsStock, code: usStock.Code, cost: usStock.Quantity * usStock.GetPrice * usStock.UsdJpy, value: values[i]})
+        }
+    }
+
+    japanFunds, err := s.JapanFundRepo.FetchJapanFundListById(ctx, userId)
+    if err != nil {
+        return nil, err
+    }
+    values, err := valuer.FundValues(ctx, japanFunds)
+    if err != nil {
+        return nil, err
+    }
+    for i, japanFund := range japanFunds {
+        holdings = append(holdings, valuedHolding{assetType: assetTypeJapanFund, code: japanFund.Code, name: japanFund.Name, cost: japanFund.GetPriceTotal, value: values[i]})
+    }
+
+    cryptos, err := s.CryptoRepo.FetchCryptoListById(ctx, userId)
+    if err != nil {
+        return nil, err
+    }
+    values, err = valuer.CryptoValues(ctx, cryptos)
+    if err != nil {
+        return nil, err
+    }
+    for i, crypto := range cryptos {
+        holdings = append(holdings, valuedHolding{assetType: assetTypeCrypto, code: crypto.Code, cost: crypto.Quantity * crypto.GetPrice, value: values[i]})
+    }
+
+    fixedIncomeAssets, err := s.FixedIncomeRepo.FetchFixedIncomeAssetListById(ctx, userId)
+    if err != nil {
+        return nil, err
+    }
+    // 満期償還済みの資産は現金に移っているため対象外とする
+    fixedIncomeAssets = repoFixedIncome.ExcludeMatured(fixedIncomeAssets)
+    currentUsdJpy, err := repoFixedIncome.CurrentUsdJpy(ctx, s.CurrencyRepo, fixedIncomeAssets)
+    if err != nil {
+        return nil, err
+    }
+    now := time.Now()
+    for _, fixedIncome := range fixedIncomeAssets {
+        // 固定利回り資産は資産ごとの評価方法で評価する(ドル建ては取得原価を購入時為替、評価額を現在のドル円で円に換算する)
+        value := repoFixedIncome.ValuationJpy(fixedIncome, currentUsdJpy, now)
+        cost := fixedIncome.GetPriceTotal
+        if repoFixedIncome.IsUsd(fixedIncome) {
+            // 購入時為替が未登録の場合は現在のドル円で換算する
+            purchaseUsdJpy := currentUsdJpy
+            if fixedIncome.UsdJpy != nil {
+                purchaseUsdJpy = *fixedIncome.UsdJpy
+            }
+            cost *= purchaseUsdJpy
+        }
+        holdings = append(holdings, valuedHolding{assetType: assetTypeFixedIncome, code: fixedIncome.Code, cost: cost, value: value})
+    }
+    return holdings, nil
+}
@@ -0,0 +1,182 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"my-us-stock-backend/app/database/model"
+	repoCrypto "my-us-stock-backend/app/repository/assets/crypto"
+	repoFixedIncome "my-us-stock-backend/app/repository/assets/fixed-income"
+	repoJapanFund "my-us-stock-backend/app/repository/assets/fund"
+	"my-us-stock-backend/app/repository/assets/stock"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	repoMarketCrypto "my-us-stock-backend/app/repository/market-price/crypto"
+	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
+	repoFundPrice "my-us-stock-backend/app/repository/market-price/fund"
+	repoShareToken "my-us-stock-backend/app/repository/share-token"
+	repoTotalAsset "my-us-stock-backend/app/repository/total-assets"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockTotalAssetRepository は TotalAssetRepository のモックです。
+type MockTotalAssetRepository struct {
+    mock.Mock
+}
+
+func (m *MockTotalAssetRepository) FetchTotalAssetListById(ctx context.Context, userId uint, day int) ([]model.TotalAsset, error) {
+    args := m.Called(ctx, userId, day)
+    return args.Get(0).([]model.TotalAsset), args.Error(1)
+}
+
+func (m *MockTotalAssetRepository) FindTodayTotalAsset(ctx context.Context, userId uint) (*model.TotalAsset, error) {
+    args := m.Called(ctx, userId)
+    return args.Get(0).(*model.TotalAsset), args.Error(1)
+}
+
+func (m *MockTotalAssetRepository) UpdateTotalAsset(ctx context.Context, dto repoTotalAsset.UpdateTotalAssetDto) (*model.TotalAsset, error) {
+    args := m.Called(ctx, dto)
+    return args.Get(0).(*model.TotalAsset), args.Error(1)
+}
+
+func (m *MockTotalAssetRepository) CreateTodayTotalAsset(ctx context.Context, dto repoTotalAsset.CreateTotalAssetDto) (*model.TotalAsset, error) {
+    args := m.Called(ctx, dto)
+    return args.Get(0).(*model.TotalAsset), args.Error(1)
+}
+
+type testMocks struct {
+    shareTokenRepo   *repoShareToken.MockShareTokenRepository
+    stockRepo        *stock.MockUsStockRepository
+    japanFundRepo    *repoJapanFund.MockJapanFundRepository
+    cryptoRepo       *repoCrypto.MockCryptoRepository
+    fixedIncomeRepo  *repoFixedIncome.MockFixedIncomeAssetRepository
+    totalAssetRepo   *MockTotalAssetRepository
+    marketPriceRepo  *marketPrice.MockMarketPriceRepository
+    currencyRepo     *repoCurrency.MockCurrencyRepository
+    marketCryptoRepo *repoMarketCrypto.MockCryptoRepository
+    fundPriceRepo    *repoFundPrice.MockFundPriceRepository
+}
+
+func newTestService() (ShareService, testMocks) {
+    mocks := testMocks{
+        shareTokenRepo:   repoShareToken.NewMockShareTokenRepository(),
+        stockRepo:        stock.NewMockUsStockRepository(),
+        japanFundRepo:    repoJapanFund.NewMockJapanFundRepository(),
+        cryptoRepo:       repoCrypto.NewMockCryptoRepository(),
+        fixedIncomeRepo:  repoFixedIncome.NewMockFixedIncomeAssetRepository(),
+        totalAssetRepo:   new(MockTotalAssetRepository),
+        marketPriceRepo:  marketPrice.NewMockMarketPriceRepository(),
+        currencyRepo:     repoCurrency.NewMockCurrencyRepository(),
+        marketCryptoRepo: repoMarketCrypto.NewMockCryptoRepository(),
+        fundPriceRepo:    repoFundPrice.NewMockFundPriceRepository(),
+    }
+    service := NewShareService(mocks.shareTokenRepo, mocks.stockRepo, mocks.japanFundRepo, mocks.cryptoRepo, mocks.fixedIncomeRepo, mocks.totalAssetRepo, mocks.marketPriceRepo, mocks.currencyRepo, mocks.marketCryptoRepo, mocks.fundPriceRepo)
+    return service, mocks
+}
+
+// 評価額の合計が100万円になる保有資産と資産推移を設定する
+func setupPortfolio(mocks testMocks, privacy string) {
+    userId := uint(1)
+    mocks.shareTokenRepo.On("FindValidShareToken", mock.Anything, "valid-token", mock.Anything).Return(&model.ShareToken{TokenHash: repoShareToken.HashToken("valid-token"), Privacy: privacy, ExpiresAt: time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC), UserId: userId}, nil)
+    mocks.stockRepo.On("FetchUsStockListById", mock.Anything, userId).Return([]model.UsStock{
+        {Code: "AAPL", Quantity: 10, GetPrice: 100, UsdJpy: 100, UserId: userId},
+    }, nil)
+    mocks.marketPriceRepo.On("FetchMarketPriceList", mock.Anything, []string{"AAPL"}).Return([]marketPrice.MarketPriceDto{
+        {Ticker: "AAPL", CurrentPrice: 150},
+    }, nil)
+    mocks.currencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(100.0, nil)
+    mocks.japanFundRepo.On("FetchJapanFundListById", mock.Anything, userId).Return([]model.JapanFund{
+        {Code: "0331418A", Name: "eMAXIS Slim 米国株式(S&P500)", GetPrice: 10000, GetPriceTotal: 100000, UserId: userId},
+    }, nil)
+    mocks.fundPriceRepo.On("FindFundPriceByCode", mock.Anything, "0331418A").Return(&model.FundPrice{Code: "0331418A", Price: 15000}, nil)
+    mocks.cryptoRepo.On("FetchCryptoListById", mock.Anything, userId).Return([]model.Crypto{
+        {Code: "btc", Quantity: 0.1, GetPrice: 5000000, UserId: userId},
+    }, nil)
+    mocks.marketCryptoRepo.On("FetchCryptoPrice", "btc").Return(&repoMarketCrypto.Crypto{Name: "btc", Price: 2000000}, nil)
+    mocks.fixedIncomeRepo.On("FetchFixedIncomeAssetListById", mock.Anything, userId).Return([]model.FixedIncomeAsset{
+        {Code: "個人向け国債", GetPriceTotal: 500000, UserId: userId},
+    }, nil)
+    // 新しい順(ドル円が記録されていない日は除外される)
+    mocks.totalAssetRepo.On("FetchTotalAssetListById", mock.Anything, userId, 30).Return([]model.TotalAsset{
+        {Model: gorm.Model{CreatedAt: time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)}, CashJpy: 100000, Stock: 1000000},
+        {Model: gorm.Model{CreatedAt: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)}, CashJpy: 100000, CashUsd: 100, Stock: 900000},
+        {Model: gorm.Model{CreatedAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}, CashJpy: 100000, Stock: 900000},
+    }, nil)
+}
+
+// 公開範囲が PERCENTAGE の場合は構成比・損益率のみ返却する
+func TestFetchSharedPortfolioPercentage(t *testing.T) {
+    service, mocks := newTestService()
+    setupPortfolio(mocks, repoShareToken.PrivacyPercentage)
+
+    portfolio, err := service.FetchSharedPortfolio(context.Background(), "valid-token")
+
+    assert.NoError(t, err)
+    assert.Nil(t, portfolio.TotalAmount)
+    assert.Equal(t, []SharedAllocation{
+        {AssetType: "US_STOCK", Weight: 15},
+        {AssetType: "JAPAN_FUND", Weight: 15},
+        {AssetType: "CRYPTO", Weight: 20},
+        {AssetType: "FIXED_INCOME", Weight: 50},
+    }, portfolio.Allocation)
+    assert.Equal(t, []SharedHolding{
+        {AssetType: "FIXED_INCOME", Code: "個人向け国債", Weight: 50, GainRate: 0},
+        {AssetType: "CRYPTO", Code: "btc", Weight: 20, GainRate: -60},
+        {AssetType: "US_STOCK", Code: "AAPL", Weight: 15, GainRate: 50},
+        {AssetType: "JAPAN_FUND", Code: "0331418A", Name: "eMAXIS Slim 米国株式(S&P500)", Weight: 15, GainRate: 50},
+    }, portfolio.Holdings)
+    assert.Equal(t, []SharedPerformance{
+        {Date: "2024-05-01", ReturnRate: 0},
+        {Date: "2024-05-03", ReturnRate: 10},
+    }, portfolio.Performance)
+}
+
+// 公開範囲が AMOUNT の場合は金額も返却する
+func TestFetchSharedPortfolioAmount(t *testing.T) {
+    service, mocks := newTestService()
+    setupPortfolio(mocks, repoShareToken.PrivacyAmount)
+
+    portfolio, err := service.FetchSharedPortfolio(context.Background(), "valid-token")
+
+    assert.NoError(t, err)
+    assert.Equal(t, 1000000.0, *portfolio.TotalAmount)
+    assert.Equal(t, 150000.0, *portfolio.Allocation[0].Amount)
+    assert.Equal(t, 500000.0, *portfolio.Holdings[0].Amount)
+    assert.Equal(t, 1100000.0, *portfolio.Performance[1].TotalAmount)
+}
+
+// 取り消し済み・期限切れのトークンは参照できない
+func TestFetchSharedPortfolioInvalidToken(t *testing.T) {
+    service, mocks := newTestService()
+    mocks.shareTokenRepo.On("FindValidShareToken", mock.Anything, "expired", mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+    _, err := service.FetchSharedPortfolio(context.Background(), "expired")
+    assert.ErrorIs(t, err, errInvalidToken)
+
+    _, err = service.FetchSharedPortfolio(context.Background(), "")
+    assert.ErrorIs(t, err, errInvalidToken)
+    mocks.stockRepo.AssertNotCalled(t, "FetchUsStockListById", mock.Anything, mock.Anything)
+}
+
+// 資産総額と同じく、暗号通貨の価格が取得できない場合は評価できないためエラーとする
+func TestFetchSharedPortfolioCryptoPriceError(t *testing.T) {
+    service, mocks := newTestService()
+    userId := uint(1)
+    mocks.shareTokenRepo.On("FindValidShareToken", mock.Anything, "valid-token", mock.Anything).Return(&model.ShareToken{TokenHash: repoShareToken.HashToken("valid-token"), Privacy: repoShareToken.PrivacyAmount, UserId: userId}, nil)
+    mocks.stockRepo.On("FetchUsStockListById", mock.Anything, userId).Return([]model.UsStock{}, nil)
+    mocks.japanFundRepo.On("FetchJapanFundListById", mock.Anything, userId).Return([]model.JapanFund{}, nil)
+    mocks.cryptoRepo.On("FetchCryptoListById", mock.Anything, userId).Return([]model.Crypto{
+        {Code: "eth", Quantity: 2, GetPrice: 300000, UserId: userId},
+    }, nil)
+    mocks.marketCryptoRepo.On("FetchCryptoPrice", "eth").Return((*repoMarketCrypto.Crypto)(nil), errors.New("timeout"))
+    mocks.fixedIncomeRepo.On("FetchFixedIncomeAssetListById", mock.Anything, userId).Return([]model.FixedIncomeAsset{}, nil)
+
+    portfolio, err := service.FetchSharedPortfolio(context.Background(), "valid-token")
+
+    assert.Nil(t, portfolio)
+    assert.EqualError(t, err, "timeout")
+    mocks.totalAssetRepo.AssertNotCalled(t, "FetchTotalAssetListById", mock.Anything, mock.Anything, mock.Anything)
+}
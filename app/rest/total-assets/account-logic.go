@@ -0,0 +1,89 @@
+package totalassets
+
+import (
+	"context"
+	"math"
+	"my-us-stock-backend/app/database/model"
+	repoFixedIncome "my-us-stock-backend/app/repository/assets/fixed-income"
+	"time"
+)
+
+// 口座ごとの保有資産
+type accountHoldings struct {
+	accountId         uint
+	stocks            []model.UsStock
+	funds             []model.JapanFund
+	cryptos           []model.Crypto
+	fixedIncomeAssets []model.FixedIncomeAsset
+}
+
+// 保有資産を口座ごとに分ける(口座の並び順は最初に出てきた順)
+func groupHoldingsByAccount(stocks []model.UsStock, funds []model.JapanFund, cryptos []model.Crypto, fixedIncomeAssets []model.FixedIncomeAsset) []*accountHoldings {
+	groups := []*accountHoldings{}
+	groupByKey := map[uint]*accountHoldings{}
+	find := func(accountId uint) *accountHoldings {
+		group, ok := groupByKey[accountId]
+		if !ok {
+			group = &accountHoldings{accountId: accountId}
+			groupByKey[accountId] = group
+			groups = append(groups, group)
+		}
+		return group
+	}
+	for _, stock := range stocks {
+		group := find(stock.AccountId)
+		group.stocks = append(group.stocks, stock)
+	}
+	for _, fund := range funds {
+		group := find(fund.AccountId)
+		group.funds = append(group.funds, fund)
+	}
+	for _, crypto := range cryptos {
+		group := find(crypto.AccountId)
+		group.cryptos = append(group.cryptos, crypto)
+	}
+	for _, fixedIncomeAsset := range fixedIncomeAssets {
+		group := find(fixedIncomeAsset.AccountId)
+		group.fixedIncomeAssets = append(group.fixedIncomeAssets, fixedIncomeAsset)
+	}
+	return groups
+}
+
+// 口座ごとの評価額を計算する(評価額は円ベースで四捨五入する)
+func calculateAccountAmounts(ctx context.Context, ts *DefaultTotalAssetService, groups []*accountHoldings, userId uint) ([]model.TotalAssetAccount, error) {
+	amounts := make([]model.TotalAssetAccount, len(groups))
+	for i, group := range groups {
+		accountId := group.accountId
+		amounts[i] = model.TotalAssetAccount{AccountId: &accountId, UserId: userId}
+		// 空の場合は計算処理をスキップする
+		if len(group.stocks) != 0 {
+			stockTotal, err := calculateStockTotal(ctx, ts, group.stocks)
+			if err != nil {
+				return nil, err
+			}
+			amounts[i].Stock = math.Round(stockTotal)
+		}
+		if len(group.funds) != 0 {
+			fundTotal, err := calculateFundPriceTotal(ctx, ts, group.funds)
+			if err != nil {
+				return nil, err
+			}
+			amounts[i].Fund = math.Round(fundTotal)
+		}
+		if len(group.cryptos) != 0 {
+			cryptoTotal, err := calculateCryptoTotal(ctx, ts, group.cryptos)
+			if err != nil {
+				return nil, err
+			}
+			amounts[i].Crypto = math.Round(cryptoTotal)
+		}
+		if len(group.fixedIncomeAssets) != 0 {
+			fixedIncomeTotal, err := repoFixedIncome.TotalValuationJpy(ctx, ts.CurrencyRepo, group.fixedIncomeAssets, time.Now())
+			if err != nil {
+				return nil, err
+			}
+			amounts[i].FixedIncomeAsset = math.Round(fixedIncomeTotal)
+		}
+	}
+	return amounts, nil
+}
@@ -2,9 +2,13 @@ package totalassets
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"math"
 
+	dailyReport "my-us-stock-backend/app/common/daily-report"
+	"my-us-stock-backend/app/common/notification"
+
 	repoCrypto "my-us-stock-backend/app/repository/assets/crypto"
 	repoFixedIncome "my-us-stock-backend/app/repository/assets/fixed-income"
 	repoJapanFund "my-us-stock-backend/app/repository/assets/fund"
@@ -13,6 +17,7 @@ import (
 	repoMarketCrypto "my-us-stock-backend/app/repository/market-price/crypto"
 	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
 	repoFundPrice "my-us-stock-backend/app/repository/market-price/fund"
+	repoBrokerageAccount "my-us-stock-backend/app/repository/brokerage-account"
 	repoTotalAsset "my-us-stock-backend/app/repository/total-assets"
 
 	"github.com/gin-gonic/gin"
@@ -34,11 +39,14 @@ type DefaultTotalAssetService struct {
 	FixedIncomeRepo repoFixedIncome.FixedIncomeRepository
 	MarketCryptoRepo repoMarketCrypto.CryptoRepository
 	FundPriceRepo repoFundPrice.FundPriceRepository
+	NotificationService notification.NotificationService
+	DailyReportSender dailyReport.ReportSender
+	BrokerageAccountRepo repoBrokerageAccount.BrokerageAccountRepository
 }
 
 // DefaultTotalAssetService の新しいインスタンスを作成します
-func NewTotalAssetService(totalAssetRepo repoTotalAsset.TotalAssetRepository, stockRepo stock.UsStockRepository, marketPriceRepo marketPrice.MarketPriceRepository, currencyRepo repoCurrency.CurrencyRepository, japanFundRepo repoJapanFund.JapanFundRepository,	cryptoRepo repoCrypto.CryptoRepository,fixedIncomeRepo repoFixedIncome.FixedIncomeRepository, marketCryptoRepo repoMarketCrypto.CryptoRepository, fundPriceRepo repoFundPrice.FundPriceRepository) TotalAssetService {
-	return &DefaultTotalAssetService{totalAssetRepo, stockRepo, marketPriceRepo, currencyRepo, japanFundRepo, cryptoRepo, fixedIncomeRepo, marketCryptoRepo, fundPriceRepo}
+func NewTotalAssetService(totalAssetRepo repoTotalAsset.TotalAssetRepository, stockRepo stock.UsStockRepository, marketPriceRepo marketPrice.MarketPriceRepository, currencyRepo repoCurrency.CurrencyRepository, japanFundRepo repoJapanFund.JapanFundRepository,	cryptoRepo repoCrypto.CryptoRepository,fixedIncomeRepo repoFixedIncome.FixedIncomeRepository, marketCryptoRepo repoMarketCrypto.CryptoRepository, fundPriceRepo repoFundPrice.FundPriceRepository, notificationService notification.NotificationService, dailyReportSender dailyReport.ReportSender, brokerageAccountRepo repoBrokerageAccount.BrokerageAccountRepository) TotalAssetService {
+	return &DefaultTotalAssetService{totalAssetRepo, stockRepo, marketPriceRepo, currencyRepo, japanFundRepo, cryptoRepo, fixedIncomeRepo, marketCryptoRepo, fundPriceRepo, notificationService, dailyReportSender, brokerageAccountRepo}
 }
 
 // 資産新規登録処理
@@ -49,80 +57,74 @@ func (ts *DefaultTotalAssetService) CreateTodayTotalAsset(ctx context.Context, c
         return "Bad Request", err
     }
 
+	response, err := ts.createTodayTotalAsset(ctx, requestParam)
+	if err != nil {
+		// 資産の登録に失敗したことをユーザーに通知する
+		subject := "[資産スナップショット] 本日の資産登録に失敗しました"
+		body := fmt.Sprintf("エラー内容: %v", err)
+		if notifyErr := ts.NotificationService.Notify(ctx, uint(requestParam.UserId), notification.EventSnapshotFailed, subject, body); notifyErr != nil {
+			log.Printf("資産登録の失敗の通知に失敗しました: %v", notifyErr)
+		}
+		return response, err
+	}
+	// 新しく資産を登録した場合は日次サマリーを作成して送信する(失敗しても登録結果は変えない)
+	if response == "OK" {
+		if reportErr := ts.DailyReportSender.SendDailyReport(ctx, uint(requestParam.UserId)); reportErr != nil {
+			log.Printf("日次サマリーの送信に失敗しました: %v", reportErr)
+		}
+	}
+	return response, nil
+}
+
+// 当日分の資産総額を計算して登録する
+func (ts *DefaultTotalAssetService) createTodayTotalAsset(ctx context.Context, requestParam CreateTotalAssetRequest) (string, error) {
 	// 当日分の資産が登録されているか確認
 	latestTotalAsset, err  := ts.TotalAssetRepo.FindTodayTotalAsset(ctx, uint(requestParam.UserId))
 	if err == nil && latestTotalAsset != nil {
         return "すでに資産が登録されています。", nil
     }
-	// 保有株式を取得
-	var amountOfStock = 0.0
+	// 保有資産を取得
 	modelStocks, err := ts.StockRepo.FetchUsStockListById(ctx, uint(requestParam.UserId))
 	if err != nil {
         return "Internal Server Error", err
     }
-    // modelStocksが空の場合は計算処理をスキップする
-	if len(modelStocks) != 0 {
-		// 米国株の市場価格情報取得
-		stockTotal,err := calculateStockTotal(ctx, ts, modelStocks)
-		if err != nil {
-			return "Internal Server Error", err
-		}
-		// 資産総額に加算
-		amountOfStock += stockTotal
-	}
-	// 日本投資信託の評価額を取得
-	var amountOfFund = 0.0
 	modelFunds, err := ts.JapanFundRepo.FetchJapanFundListById(ctx, uint(requestParam.UserId))
 	if err != nil {
         return "Internal Server Error", err
     }
-	// modelFundsが空の場合は計算処理をスキップする
-	if len(modelFunds) != 0 {
-		// 仮想通貨の評価総額を計算
-		fundTotal,err := calculateFundPriceTotal(ctx, ts, modelFunds)
-		if err != nil {
-		log.Fatalf("エラーが発生しました: %v", err)
-        return "Internal Server Error", err
-		}
-		// 資産総額に加算
-		amountOfFund += fundTotal
-	   }
-
-	// 仮想通貨の評価額を取得
-	var amountOfCrypto = 0.0
 	modelCryptos, err := ts.CryptoRepo.FetchCryptoListById(ctx, uint(requestParam.UserId))
 	if err != nil {
         return "Internal Server Error", err
     }
-	// 空の場合は計算処理をスキップする
-	if len(modelCryptos) != 0 {
-		// 仮想通貨の評価総額を計算
-		cryptoTotal,err := calculateCryptoTotal(ctx, ts, modelCryptos)
-		if err != nil {
-			return "Internal Server Error", err
-		}
-		// 資産総額に加算
-		amountOfCrypto += cryptoTotal
-	}
-
-	// 固定利回り資産の評価額を取得
-	var amountOfFixedIncomeAsset= 0.0
 	modelAssets, err := ts.FixedIncomeRepo.FetchFixedIncomeAssetListById(ctx, uint(requestParam.UserId))
 	if err != nil {
         return "Internal Server Error", err
     }
-	// 空の場合は計算処理をスキップする
-	if len(modelAssets) != 0 {
-		// 仮想通貨の評価総額を計算
-		for _, modelAsset := range modelAssets {
-			amountOfFixedIncomeAsset += modelAsset.GetPriceTotal
-		}
+	// 満期償還済みの資産は現金に移っているため評価額に含めない
+	modelAssets = repoFixedIncome.ExcludeMatured(modelAssets)
+	// 口座ごとに評価額を計算し、その合計を資産総額とする
+	accountAmounts, err := calculateAccountAmounts(ctx, ts, groupHoldingsByAccount(modelStocks, modelFunds, modelCryptos, modelAssets), uint(requestParam.UserId))
+	if err != nil {
+		return "Internal Server Error", err
+	}
+	var amountOfStock, amountOfFund, amountOfCrypto, amountOfFixedIncomeAsset float64
+	for _, accountAmount := range accountAmounts {
+		amountOfStock += accountAmount.Stock
+		amountOfFund += accountAmount.Fund
+		amountOfCrypto += accountAmount.Crypto
+		amountOfFixedIncomeAsset += accountAmount.FixedIncomeAsset
 	}
 	// 登録処理を行うか、すでに資産が登録されているか確認
     totalAsset, err := ts.TotalAssetRepo.FetchTotalAssetListById(ctx, uint(requestParam.UserId),1)
     if err != nil {
         return  "Bad Request", err
     }
+	// 為替影響の計算用に登録時点のドル円を記録する(取得できない場合も資産の登録は続ける)
+	usdJpy, err := ts.CurrencyRepo.FetchCurrentUsdJpy(ctx)
+	if err != nil {
+		log.Printf("ドル円の取得に失敗しました: %v", err)
+		usdJpy = 0
+	}
 		// 登録内容準備
 		createDto := repoTotalAsset.CreateTotalAssetDto{
 			CashJpy: totalAsset[0].CashJpy,
@@ -131,14 +133,24 @@ func (ts *DefaultTotalAssetService) CreateTodayTotalAsset(ctx context.Context, c
 			Fund: math.Round(amountOfFund),
 			Crypto: math.Round(amountOfCrypto),
 			FixedIncomeAsset: amountOfFixedIncomeAsset,
+			UsdJpy: usdJpy,
 			UserId: uint(requestParam.UserId),
 		}
 
 	// 当日分の資産総額を新規登録
-	_, err = ts.TotalAssetRepo.CreateTodayTotalAsset(ctx, createDto)
+	createdTotalAsset, err := ts.TotalAssetRepo.CreateTodayTotalAsset(ctx, createDto)
 	if err != nil {
         return "Internal Server Error", err
     }
+	// 口座別の内訳を登録する(失敗しても資産の登録結果は変えない)
+	if len(accountAmounts) != 0 {
+		for i := range accountAmounts {
+			accountAmounts[i].TotalAssetId = createdTotalAsset.ID
+		}
+		if err := ts.BrokerageAccountRepo.CreateTotalAssetAccounts(ctx, accountAmounts); err != nil {
+			log.Printf("口座別の資産内訳の登録に失敗しました: %v", err)
+		}
+	}
 
     return "OK", nil
 }
\ No newline at end of file
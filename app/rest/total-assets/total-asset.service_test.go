@@ -0,0 +1,183 @@
+package totalassets
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	dailyReport "my-us-stock-backend/app/common/daily-report"
+	"my-us-stock-backend/app/common/notification"
+	"my-us-stock-backend/app/database/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+
+	cryptoRepo "my-us-stock-backend/app/repository/assets/crypto"
+	fixedIncomeAssetRepo "my-us-stock-backend/app/repository/assets/fixed-income"
+	fundRepo "my-us-stock-backend/app/repository/assets/fund"
+	brokerageAccount "my-us-stock-backend/app/repository/brokerage-account"
+	"my-us-stock-backend/app/repository/assets/stock"
+	marketPrice "my-us-stock-backend/app/repository/market-price"
+	marketCryptoRepo "my-us-stock-backend/app/repository/market-price/crypto"
+	"my-us-stock-backend/app/repository/market-price/currency"
+	"my-us-stock-backend/app/repository/market-price/fund"
+	totalAssetRepo "my-us-stock-backend/app/repository/total-assets"
+)
+
+// MockTotalAssetRepository は TotalAssetRepository のモックです。
+type MockTotalAssetRepository struct {
+	mock.Mock
+}
+
+func (m *MockTotalAssetRepository) FetchTotalAssetListById(ctx context.Context, userId uint, day int) ([]model.TotalAsset, error) {
+	args := m.Called(ctx, userId, day)
+	return args.Get(0).([]model.TotalAsset), args.Error(1)
+}
+
+func (m *MockTotalAssetRepository) UpdateTotalAsset(ctx context.Context, dto totalAssetRepo.UpdateTotalAssetDto) (*model.TotalAsset, error) {
+	args := m.Called(ctx, dto)
+	return args.Get(0).(*model.TotalAsset), args.Error(1)
+}
+
+func (m *MockTotalAssetRepository) CreateTodayTotalAsset(ctx context.Context, dto totalAssetRepo.CreateTotalAssetDto) (*model.TotalAsset, error) {
+	args := m.Called(ctx, dto)
+	return args.Get(0).(*model.TotalAsset), args.Error(1)
+}
+
+func (m *MockTotalAssetRepository) FindTodayTotalAsset(ctx context.Context, userId uint) (*model.TotalAsset, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).(*model.TotalAsset), args.Error(1)
+}
+
+// 資産の登録に失敗した場合はユーザーに通知する
+func TestCreateTodayTotalAssetNotifiesFailure(t *testing.T) {
+	mockTotalAssetRepo := new(MockTotalAssetRepository)
+	mockStockRepo := stock.NewMockUsStockRepository()
+	mockNotificationService := notification.NewMockNotificationService()
+	service := NewTotalAssetService(mockTotalAssetRepo, mockStockRepo, marketPrice.NewMockMarketPriceRepository(), currency.NewMockCurrencyRepository(), fundRepo.NewMockJapanFundRepository(), cryptoRepo.NewMockCryptoRepository(), fixedIncomeAssetRepo.NewMockFixedIncomeAssetRepository(), marketCryptoRepo.NewMockCryptoRepository(), fund.NewMockFundPriceRepository(), mockNotificationService, dailyReport.NewMockReportSender(), brokerageAccount.NewMockBrokerageAccountRepository())
+
+	userId := uint(1)
+	mockTotalAssetRepo.On("FindTodayTotalAsset", mock.Anything, userId).Return((*model.TotalAsset)(nil), errors.New("record not found"))
+	mockStockRepo.On("FetchUsStockListById", mock.Anything, userId).Return([]model.UsStock{}, errors.New("db error"))
+	mockNotificationService.On("Notify", mock.Anything, userId, notification.EventSnapshotFailed, "[資産スナップショット] 本日の資産登録に失敗しました", "エラー内容: db error").Return(nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"userId": 1}`))
+
+	_, err := service.CreateTodayTotalAsset(context.Background(), c)
+
+	assert.Error(t, err)
+	mockNotificationService.AssertExpectations(t)
+}
+
+// 資産を新しく登録した場合は日次サマリーを送信する(送信に失敗しても登録は成功とする)
+func TestCreateTodayTotalAssetSendsDailyReport(t *testing.T) {
+	mockTotalAssetRepo := new(MockTotalAssetRepository)
+	mockStockRepo := stock.NewMockUsStockRepository()
+	mockCurrencyRepo := currency.NewMockCurrencyRepository()
+	mockJapanFundRepo := fundRepo.NewMockJapanFundRepository()
+	mockCryptoRepo := cryptoRepo.NewMockCryptoRepository()
+	mockFixedIncomeRepo := fixedIncomeAssetRepo.NewMockFixedIncomeAssetRepository()
+	mockDailyReportSender := dailyReport.NewMockReportSender()
+	service := NewTotalAssetService(mockTotalAssetRepo, mockStockRepo, marketPrice.NewMockMarketPriceRepository(), mockCurrencyRepo, mockJapanFundRepo, mockCryptoRepo, mockFixedIncomeRepo, marketCryptoRepo.NewMockCryptoRepository(), fund.NewMockFundPriceRepository(), notification.NewMockNotificationService(), mockDailyReportSender, brokerageAccount.NewMockBrokerageAccountRepository())
+
+	userId := uint(1)
+	mockTotalAssetRepo.On("FindTodayTotalAsset", mock.Anything, userId).Return((*model.TotalAsset)(nil), errors.New("record not found"))
+	mockStockRepo.On("FetchUsStockListById", mock.Anything, userId).Return([]model.UsStock{}, nil)
+	mockJapanFundRepo.On("FetchJapanFundListById", mock.Anything, userId).Return([]model.JapanFund{}, nil)
+	mockCryptoRepo.On("FetchCryptoListById", mock.Anything, userId).Return([]model.Crypto{}, nil)
+	mockFixedIncomeRepo.On("FetchFixedIncomeAssetListById", mock.Anything, userId).Return([]model.FixedIncomeAsset{}, nil)
+	mockTotalAssetRepo.On("FetchTotalAssetListById", mock.Anything, userId, 1).Return([]model.TotalAsset{{CashJpy: 10000, CashUsd: 100}}, nil)
+	mockCurrencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(150.0, nil)
+	mockTotalAssetRepo.On("CreateTodayTotalAsset", mock.Anything, mock.Anything).Return(&model.TotalAsset{}, nil)
+	mockDailyReportSender.On("SendDailyReport", mock.Anything, userId).Return(errors.New("send error"))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"userId": 1}`))
+
+	response, err := service.CreateTodayTotalAsset(context.Background(), c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "OK", response)
+	mockDailyReportSender.AssertExpectations(t)
+}
+
+// すでに当日分が登録されている場合は日次サマリーを送信しない
+func TestCreateTodayTotalAssetAlreadyRegistered(t *testing.T) {
+	mockTotalAssetRepo := new(MockTotalAssetRepository)
+	mockDailyReportSender := dailyReport.NewMockReportSender()
+	service := NewTotalAssetService(mockTotalAssetRepo, stock.NewMockUsStockRepository(), marketPrice.NewMockMarketPriceRepository(), currency.NewMockCurrencyRepository(), fundRepo.NewMockJapanFundRepository(), cryptoRepo.NewMockCryptoRepository(), fixedIncomeAssetRepo.NewMockFixedIncomeAssetRepository(), marketCryptoRepo.NewMockCryptoRepository(), fund.NewMockFundPriceRepository(), notification.NewMockNotificationService(), mockDailyReportSender, brokerageAccount.NewMockBrokerageAccountRepository())
+
+	mockTotalAssetRepo.On("FindTodayTotalAsset", mock.Anything, uint(1)).Return(&model.TotalAsset{}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"userId": 1}`))
+
+	response, err := service.CreateTodayTotalAsset(context.Background(), c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "すでに資産が登録されています。", response)
+	mockDailyReportSender.AssertNotCalled(t, "SendDailyReport", mock.Anything, mock.Anything)
+}
+
+// 資産総額と合わせて口座別の内訳を登録する
+func TestCreateTodayTotalAssetCreatesAccountBreakdown(t *testing.T) {
+	mockTotalAssetRepo := new(MockTotalAssetRepository)
+	mockStockRepo := stock.NewMockUsStockRepository()
+	mockCurrencyRepo := currency.NewMockCurrencyRepository()
+	mockJapanFundRepo := fundRepo.NewMockJapanFundRepository()
+	mockCryptoRepo := cryptoRepo.NewMockCryptoRepository()
+	mockFixedIncomeRepo := fixedIncomeAssetRepo.NewMockFixedIncomeAssetRepository()
+	mockMarketCryptoRepo := marketCryptoRepo.NewMockCryptoRepository()
+	mockBrokerageAccountRepo := brokerageAccount.NewMockBrokerageAccountRepository()
+	mockDailyReportSender := dailyReport.NewMockReportSender()
+	service := NewTotalAssetService(mockTotalAssetRepo, mockStockRepo, marketPrice.NewMockMarketPriceRepository(), mockCurrencyRepo, mockJapanFundRepo, mockCryptoRepo, mockFixedIncomeRepo, mockMarketCryptoRepo, fund.NewMockFundPriceRepository(), notification.NewMockNotificationService(), mockDailyReportSender, mockBrokerageAccountRepo)
+
+	userId := uint(1)
+	accountId := uint(2)
+	defaultAccountId := uint(1)
+	purchaseUsdJpy := 140.0
+	faceAmount := 510000.0
+	mockTotalAssetRepo.On("FindTodayTotalAsset", mock.Anything, userId).Return((*model.TotalAsset)(nil), errors.New("record not found"))
+	mockStockRepo.On("FetchUsStockListById", mock.Anything, userId).Return([]model.UsStock{}, nil)
+	mockJapanFundRepo.On("FetchJapanFundListById", mock.Anything, userId).Return([]model.JapanFund{}, nil)
+	mockCryptoRepo.On("FetchCryptoListById", mock.Anything, userId).Return([]model.Crypto{
+		{Code: "btc", Quantity: 0.1, AccountId: accountId},
+		{Code: "btc", Quantity: 0.2, AccountId: defaultAccountId},
+	}, nil)
+	mockMarketCryptoRepo.On("FetchCryptoPrice", "btc").Return(&marketCryptoRepo.Crypto{Name: "btc", Price: 10000000}, nil)
+	mockFixedIncomeRepo.On("FetchFixedIncomeAssetListById", mock.Anything, userId).Return([]model.FixedIncomeAsset{
+		// 額面で評価する資産は償還金額で評価する
+		{Code: "社債A", GetPriceTotal: 500000, RedemptionAmount: &faceAmount, ValuationMode: "FACE", AccountId: accountId},
+		// ドル建ての資産は現在のドル円で円に換算する
+		{Code: "米国債", GetPriceTotal: 1000, UsdJpy: &purchaseUsdJpy, Currency: "USD", AccountId: defaultAccountId},
+	}, nil)
+	mockTotalAssetRepo.On("FetchTotalAssetListById", mock.Anything, userId, 1).Return([]model.TotalAsset{{CashJpy: 10000, CashUsd: 100}}, nil)
+	mockCurrencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(150.0, nil)
+	mockTotalAssetRepo.On("CreateTodayTotalAsset", mock.Anything, mock.MatchedBy(func(dto totalAssetRepo.CreateTotalAssetDto) bool {
+		return dto.Crypto == 3000000 && dto.FixedIncomeAsset == 660000
+	})).Return(&model.TotalAsset{Model: gorm.Model{ID: 5}}, nil)
+	mockBrokerageAccountRepo.On("CreateTotalAssetAccounts", mock.Anything, []model.TotalAssetAccount{
+		{TotalAssetId: 5, AccountId: &accountId, Crypto: 1000000, FixedIncomeAsset: 510000, UserId: userId},
+		{TotalAssetId: 5, AccountId: &defaultAccountId, Crypto: 2000000, FixedIncomeAsset: 150000, UserId: userId},
+	}).Return(nil)
+	mockDailyReportSender.On("SendDailyReport", mock.Anything, userId).Return(nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"userId": 1}`))
+
+	response, err := service.CreateTodayTotalAsset(context.Background(), c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "OK", response)
+	mockTotalAssetRepo.AssertExpectations(t)
+	mockBrokerageAccountRepo.AssertExpectations(t)
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"log"
 	"my-us-stock-backend/app/graphql"
+	"my-us-stock-backend/app/job"
 	"my-us-stock-backend/app/rest"
 	"os"
 	"strings"
@@ -29,7 +31,9 @@ func main() {
 	db := database.Connect()
     
     // マイグレーションの実行
-    database.Migrate(db)
+    if err := database.Migrate(db); err != nil {
+        log.Fatalf("Failed to migrate database: %v", err)
+    }
 
     // Gin HTTPサーバーの初期化
     r := gin.Default() // gin.Engineのインスタンスを初期化
@@ -51,6 +55,9 @@ func main() {
     // GraphQLの設定
     graphql.SetupGraphQL(r, db)
 
+    // バックグラウンドジョブの起動
+    job.SetupJobs(context.Background(), db)
+
     // サーバーを起動
     err = r.Run(":" + port)
     if err != nil {
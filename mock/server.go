@@ -2,8 +2,11 @@ package main
 
 import (
 	"encoding/json"
+	"io"
 	"log"
 	"net/http"
+	"strings"
+	"time"
 )
 
 // QuoteData は株価データを表す構造体です。
@@ -32,6 +35,17 @@ type QuoteData struct {
 	Timestamp            int64   `json:"timestamp"`
 }
 
+// ProfileData は企業情報を表す構造体です。
+type ProfileData struct {
+	Symbol            string `json:"symbol"`
+	CompanyName       string `json:"companyName"`
+	Sector            string `json:"sector"`
+	Industry          string `json:"industry"`
+	Country           string `json:"country"`
+	Exchange          string `json:"exchange"`
+	ExchangeShortName string `json:"exchangeShortName"`
+}
+
 // HistoricalData は履歴データを表す構造体です。
 type HistoricalData struct {
 	Date            string  `json:"date"`
@@ -43,6 +57,22 @@ type HistoricalData struct {
 	DeclarationDate string  `json:"declarationDate"`
 }
 
+// PriceData は日次価格データを表す構造体です。
+type PriceData struct {
+	Date   string  `json:"date"`
+	Open   float64 `json:"open"`
+	High   float64 `json:"high"`
+	Low    float64 `json:"low"`
+	Close  float64 `json:"close"`
+	Volume float64 `json:"volume"`
+}
+
+// PriceResponse は価格履歴のレスポンスデータを表す構造体です。
+type PriceResponse struct {
+	Symbol     string      `json:"symbol"`
+	Historical []PriceData `json:"historical"`
+}
+
 type Quote struct {
 	High             string `json:"high"`
 	Open             string `json:"open"`
@@ -89,6 +119,27 @@ func quoteOrderHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(responseData)
 }
 
+// profiles はモックで返却する企業情報です(ティッカーごと)
+var profiles = map[string]ProfileData{
+	"AAPL": {"AAPL", "Apple Inc.", "Technology", "Consumer Electronics", "US", "NASDAQ Global Select", "NASDAQ"},
+	"MSFT": {"MSFT", "Microsoft Corporation", "Technology", "Software—Infrastructure", "US", "NASDAQ Global Select", "NASDAQ"},
+	"KO":   {"KO", "The Coca-Cola Company", "Consumer Defensive", "Beverages—Non-Alcoholic", "US", "New York Stock Exchange", "NYSE"},
+	"JNJ":  {"JNJ", "Johnson & Johnson", "Healthcare", "Drug Manufacturers—General", "US", "New York Stock Exchange", "NYSE"},
+	"VOO":  {"VOO", "Vanguard S&P 500 ETF", "Financial Services", "Asset Management", "US", "New York Stock Exchange Arca", "AMEX"},
+}
+
+func profileHandler(w http.ResponseWriter, r *http.Request) {
+	// 指定されたティッカーの企業情報を返却する(未登録のティッカーは空の配列)
+	symbol := strings.ToUpper(strings.TrimPrefix(r.URL.Path, "/api/v3/profile/"))
+	responseData := []ProfileData{}
+	if profile, ok := profiles[symbol]; ok {
+		responseData = append(responseData, profile)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(responseData)
+}
+
 func historicalPriceHandler(w http.ResponseWriter, r *http.Request) {
 	// 固定のレスポンスデータを設定
 	response := Response{
@@ -105,6 +156,39 @@ func historicalPriceHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+func priceHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	// 期間内の平日について、日付から決まる固定の価格を新しい順で返却する
+	to := time.Now().UTC()
+	if parsed, err := time.Parse("2006-01-02", r.URL.Query().Get("to")); err == nil {
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -30)
+	if parsed, err := time.Parse("2006-01-02", r.URL.Query().Get("from")); err == nil {
+		from = parsed
+	}
+	response := PriceResponse{
+		Symbol:     strings.TrimPrefix(r.URL.Path, "/api/v3/historical-price-full/"),
+		Historical: []PriceData{},
+	}
+	for date := to; !date.Before(from); date = date.AddDate(0, 0, -1) {
+		if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			continue
+		}
+		base := 150.0 + float64(date.YearDay()%30)
+		response.Historical = append(response.Historical, PriceData{
+			Date:   date.Format("2006-01-02"),
+			Open:   base,
+			High:   base + 2,
+			Low:    base - 2,
+			Close:  base + 1,
+			Volume: 50000000,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
 func currencyHandler(w http.ResponseWriter, r *http.Request) {
 	// 固定のレスポンスデータを設定
 	response := Currency{
@@ -127,13 +211,57 @@ func cryptoHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// FundNav は投資信託の基準価額を表す構造体です。
+type FundNav struct {
+	FundCode string  `json:"fundCode"`
+	FundName string  `json:"fundName"`
+	BaseDate string  `json:"baseDate"`
+	Nav      float64 `json:"nav"`
+}
+
+// fundNavHandler は /fund-nav/funds/{code}/nav へのリクエストに、前営業日の固定の基準価額を返却します
+func fundNavHandler(w http.ResponseWriter, r *http.Request) {
+	code := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/fund-nav/funds/"), "/nav")
+	// 取得失敗を確認するため、NOTFOUND で始まるコードは404を返却する
+	if code == "" || strings.Contains(code, "/") || strings.HasPrefix(code, "NOTFOUND") {
+		http.NotFound(w, r)
+		return
+	}
+	baseDate := time.Now().UTC().AddDate(0, 0, -1)
+	for baseDate.Weekday() == time.Saturday || baseDate.Weekday() == time.Sunday {
+		baseDate = baseDate.AddDate(0, 0, -1)
+	}
+	response := FundNav{
+		FundCode: code,
+		FundName: "モックファンド " + code,
+		BaseDate: baseDate.Format("2006-01-02"),
+		Nav:      20000 + float64(baseDate.YearDay()%30)*10,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// webhookHandler は通知(Webhook・Slack・Discord形式)を受信してログに出力します
+func webhookHandler(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	log.Printf("webhook received: signature=%s body=%s", r.Header.Get("X-MyUsStock-Signature"), string(body))
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func main() {
 	http.HandleFunc("/api/v3/quote-order/", quoteOrderHandler)
 	http.HandleFunc("/api/v3/historical-price-full/stock_dividend/", historicalPriceHandler)
+	http.HandleFunc("/api/v3/historical-price-full/", priceHistoryHandler)
+	http.HandleFunc("/api/v3/profile/", profileHandler)
 	// 外為情報取得
 	http.HandleFunc("/", currencyHandler)
 	// 暗号通貨情報(BTCのみモック化)
 	http.HandleFunc("/btc_jpy/ticker", cryptoHandler)
+	// 投資信託の基準価額
+	http.HandleFunc("/fund-nav/", fundNavHandler)
+	// 通知の受信先
+	http.HandleFunc("/webhook", webhookHandler)
 
 	log.Println("Server is running on http://localhost:8080")
 	log.Fatal(http.ListenAndServe(":8080", nil))
@@ -0,0 +1,83 @@
+package account
+
+import (
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/test"
+	"my-us-stock-backend/test/graphql"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+func TestDeleteAccountE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := graphql.SetupGraphQLServer(db, nil)
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    hashed, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+    userId := uint(86)
+    db.Create(&model.User{Model: gorm.Model{ID: userId}, Name: "delete me", Email: "delete-me@example.com", Password: string(hashed)})
+    db.Create(&model.UsStock{Code: "AAPL", Quantity: 10, Sector: "Technology", UserId: userId})
+    db.Create(&model.TotalAsset{CashJpy: 100000, UserId: userId})
+    db.Create(&model.Watchlist{Ticker: "KO", UserId: userId})
+    token, err := graphql.GenerateTestAccessTokenForUserId(userId)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+
+    // パスワードが一致しない場合は削除しない
+    w := graphql.ExecuteGraphQLRequestWithToken(ts.URL, `mutation { deleteAccount(input: {password: "wrong"}) { deleted } }`, token)
+    assert.Contains(t, w.Body.String(), "パスワードが一致しません")
+    var count int64
+    db.Model(&model.UsStock{}).Where("user_id = ?", userId).Count(&count)
+    assert.Equal(t, int64(1), count)
+
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, `mutation { deleteAccount(input: {password: "password"}) { deleted purgeAt } }`, token)
+    assert.JSONEq(t, `{"data": {"deleteAccount": {"deleted": true, "purgeAt": null}}}`, w.Body.String())
+
+    db.Unscoped().Model(&model.User{}).Where("id = ?", userId).Count(&count)
+    assert.Equal(t, int64(0), count)
+    for _, table := range []interface{}{&model.UsStock{}, &model.TotalAsset{}, &model.Watchlist{}} {
+        db.Unscoped().Model(table).Where("user_id = ?", userId).Count(&count)
+        assert.Equal(t, int64(0), count)
+    }
+}
+
+// 猶予期間を指定した場合はログインできない状態にし、データは残す
+func TestDeleteAccountWithGracePeriodE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := graphql.SetupGraphQLServer(db, nil)
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    hashed, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+    userId := uint(87)
+    db.Create(&model.User{Model: gorm.Model{ID: userId}, Name: "grace", Email: "grace@example.com", Password: string(hashed)})
+    db.Create(&model.UsStock{Code: "AAPL", Quantity: 10, Sector: "Technology", UserId: userId})
+    token, err := graphql.GenerateTestAccessTokenForUserId(userId)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+
+    w := graphql.ExecuteGraphQLRequestWithToken(ts.URL, `mutation { deleteAccount(input: {password: "password", gracePeriodDays: 7}) { deleted purgeAt } }`, token)
+    assert.Contains(t, w.Body.String(), `"deleted":false`)
+    assert.Contains(t, w.Body.String(), `"purgeAt":"`)
+
+    var user model.User
+    assert.Error(t, db.First(&user, userId).Error)
+    assert.NoError(t, db.Unscoped().First(&user, userId).Error)
+    assert.NotNil(t, user.PurgeAt)
+    var count int64
+    db.Model(&model.UsStock{}).Where("user_id = ?", userId).Count(&count)
+    assert.Equal(t, int64(1), count)
+
+    // 猶予期間中は発行済みのトークンが使えない
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, `query { usStocks { code } }`, token)
+    assert.Contains(t, w.Body.String(), `"code":"UNAUTHENTICATED"`)
+}
@@ -0,0 +1,127 @@
+package alert
+
+import (
+	"encoding/json"
+	"fmt"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/test"
+	"my-us-stock-backend/test/graphql"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type alertRuleResponse struct {
+    ID              string  `json:"id"`
+    TargetType      string  `json:"targetType"`
+    Symbol          string  `json:"symbol"`
+    Condition       string  `json:"condition"`
+    Threshold       float64 `json:"threshold"`
+    Mode            string  `json:"mode"`
+    CooldownMinutes int     `json:"cooldownMinutes"`
+    Enabled         bool    `json:"enabled"`
+    LastTriggeredAt *string `json:"lastTriggeredAt"`
+}
+
+func TestAlertRuleE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := graphql.SetupGraphQLServer(db, nil)
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    token, err := graphql.GenerateTestAccessTokenForUserId(60)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+
+    // アラート条件を登録(通知モードは省略時COOLDOWN)
+    createQuery := `mutation {
+        createAlertRule(input: {targetType: STOCK_PRICE, symbol: "aapl", condition: BELOW, threshold: 170.0}) {
+          id targetType symbol condition threshold mode cooldownMinutes enabled lastTriggeredAt
+        }
+      }`
+    w := graphql.ExecuteGraphQLRequestWithToken(ts.URL, createQuery, token)
+    var createResponse struct {
+        Data struct {
+            CreateAlertRule alertRuleResponse `json:"createAlertRule"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &createResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    created := createResponse.Data.CreateAlertRule
+    assert.Equal(t, "STOCK_PRICE", created.TargetType)
+    assert.Equal(t, "AAPL", created.Symbol)
+    assert.Equal(t, "BELOW", created.Condition)
+    assert.Equal(t, 170.0, created.Threshold)
+    assert.Equal(t, "COOLDOWN", created.Mode)
+    assert.Equal(t, 60, created.CooldownMinutes)
+    assert.True(t, created.Enabled)
+    assert.Nil(t, created.LastTriggeredAt)
+
+    // 一覧取得
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, `query { alertRules { id symbol } }`, token)
+    var listResponse struct {
+        Data struct {
+            AlertRules []alertRuleResponse `json:"alertRules"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &listResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    assert.Len(t, listResponse.Data.AlertRules, 1)
+
+    // 更新
+    updateQuery := fmt.Sprintf(`mutation {
+        updateAlertRule(input: {id: "%s", condition: BELOW, threshold: 165.0, mode: ONCE, cooldownMinutes: 0, enabled: false}) {
+          id threshold mode enabled
+        }
+      }`, created.ID)
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, updateQuery, token)
+    var updateResponse struct {
+        Data struct {
+            UpdateAlertRule alertRuleResponse `json:"updateAlertRule"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &updateResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    assert.Equal(t, 165.0, updateResponse.Data.UpdateAlertRule.Threshold)
+    assert.Equal(t, "ONCE", updateResponse.Data.UpdateAlertRule.Mode)
+    assert.False(t, updateResponse.Data.UpdateAlertRule.Enabled)
+
+    // 発生履歴の取得
+    db.Create(&model.TriggeredAlert{AlertRuleId: 1, TargetType: "STOCK_PRICE", Symbol: "AAPL", Condition: "BELOW", Threshold: 165, Value: 164.2, TriggeredAt: time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC), UserId: 60})
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, `query { triggeredAlerts(limit: 10) { symbol value triggeredAt } }`, token)
+    var triggeredResponse struct {
+        Data struct {
+            TriggeredAlerts []struct {
+                Symbol      string  `json:"symbol"`
+                Value       float64 `json:"value"`
+                TriggeredAt string  `json:"triggeredAt"`
+            } `json:"triggeredAlerts"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &triggeredResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    assert.Len(t, triggeredResponse.Data.TriggeredAlerts, 1)
+    assert.Equal(t, 164.2, triggeredResponse.Data.TriggeredAlerts[0].Value)
+    assert.Equal(t, "2024-01-05T09:00:00Z", triggeredResponse.Data.TriggeredAlerts[0].TriggeredAt)
+
+    // 削除
+    deleteQuery := fmt.Sprintf(`mutation { deleteAlertRule(id: "%s") }`, created.ID)
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, deleteQuery, token)
+    var deleteResponse struct {
+        Data struct {
+            DeleteAlertRule bool `json:"deleteAlertRule"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &deleteResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    assert.True(t, deleteResponse.Data.DeleteAlertRule)
+}
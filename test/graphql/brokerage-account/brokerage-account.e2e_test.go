@@ -0,0 +1,66 @@
+package brokerageaccount
+
+import (
+	"encoding/json"
+	"fmt"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/test"
+	"my-us-stock-backend/test/graphql"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBrokerageAccountsE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := graphql.SetupGraphQLServer(db, nil)
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    userId := uint(89)
+    token, err := graphql.GenerateTestAccessTokenForUserId(userId)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+
+    // 口座を作成する
+    w := graphql.ExecuteGraphQLRequestWithToken(ts.URL, `mutation { createBrokerageAccount(input: {name: "NISA口座", type: NISA_GROWTH}) { id name type } }`, token)
+    var createResponse struct {
+        Data struct {
+            CreateBrokerageAccount struct {
+                ID   string `json:"id"`
+                Name string `json:"name"`
+                Type string `json:"type"`
+            } `json:"createBrokerageAccount"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &createResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    accountId := createResponse.Data.CreateBrokerageAccount.ID
+    assert.NotEmpty(t, accountId)
+    assert.Equal(t, "NISA_GROWTH", createResponse.Data.CreateBrokerageAccount.Type)
+
+    // 口座を指定しない場合は既定の口座に登録し、口座が異なれば同じ銘柄を登録できる
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, `mutation { createFixedIncomeAsset(input: {code: "社債B", getPriceTotal: 100000, dividendRate: 1.5, paymentMonth: [6]}) { id accountId } }`, token)
+    assert.NotContains(t, w.Body.String(), fmt.Sprintf(`"accountId":"%s"`, accountId))
+    assert.Contains(t, w.Body.String(), `"accountId":"`)
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(`mutation { createFixedIncomeAsset(input: {code: "社債B", getPriceTotal: 200000, dividendRate: 1.5, paymentMonth: [6], accountId: "%s"}) { id accountId } }`, accountId), token)
+    assert.Contains(t, w.Body.String(), fmt.Sprintf(`"accountId":"%s"`, accountId))
+
+    // 口座で絞り込む
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(`query { fixedIncomeAssets(accountId: "%s") { code getPriceTotal accountId } }`, accountId), token)
+    assert.JSONEq(t, fmt.Sprintf(`{"data": {"fixedIncomeAssets": [{"code": "社債B", "getPriceTotal": 200000, "accountId": "%s"}]}}`, accountId), w.Body.String())
+
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, `query { brokerageAccounts { name isDefault } }`, token)
+    assert.JSONEq(t, `{"data": {"brokerageAccounts": [{"name": "NISA口座", "isDefault": false}, {"name": "メイン口座", "isDefault": true}]}}`, w.Body.String())
+
+    // 保有資産が登録されている口座は削除できない
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(`mutation { deleteBrokerageAccount(id: "%s") }`, accountId), token)
+    assert.Contains(t, w.Body.String(), "保有資産が登録されている口座は削除できません")
+    var count int64
+    db.Model(&model.BrokerageAccount{}).Where("user_id = ?", userId).Count(&count)
+    assert.Equal(t, int64(2), count)
+}
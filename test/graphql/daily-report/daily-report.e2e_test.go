@@ -0,0 +1,95 @@
+package dailyreport
+
+import (
+	"encoding/json"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/test"
+	"my-us-stock-backend/test/graphql"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDailyReportE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := graphql.SetupGraphQLServer(db, nil)
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    userId := uint(80)
+    token, err := graphql.GenerateTestAccessTokenForUserId(userId)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+
+    // 資産登録時に作成されたサマリー
+    content := `{"date":"2024-01-11","previousDate":"2024-01-10","totalAmount":2300000,"totalChange":52000,"totalChangeRate":2.31,` +
+        `"assetClassChanges":[{"assetClass":"STOCK","amount":1500000,"previousAmount":1450000,"change":50000}],` +
+        `"usdJpy":150,"previousUsdJpy":148,"fxImpact":22000,` +
+        `"topGainers":[{"code":"MSFT","quantity":3,"currentPrice":400,"changeRate":3.09,"change":5400}],"topLosers":[],` +
+        `"upcomingDividends":[{"ticker":"KO","paymentDate":"2024-02-15","amountPerShare":0.46,"quantity":20,"amount":1380}],"expectedDividendTotal":1380}`
+    db.Create(&model.DailyReport{UserId: userId, Date: "2024-01-11", Content: content})
+    db.Create(&model.DailyReport{UserId: userId + 1, Date: "2024-01-12", Content: content})
+
+    query := `query {
+        dailyReport(date: "2024-01-11") {
+          date previousDate totalAmount totalChange totalChangeRate fxImpact
+          assetClassChanges { assetClass change }
+          topGainers { code changeRate }
+          upcomingDividends { ticker paymentDate amount }
+          expectedDividendTotal
+        }
+      }`
+    w := graphql.ExecuteGraphQLRequestWithToken(ts.URL, query, token)
+    var response struct {
+        Data struct {
+            DailyReport struct {
+                Date              string   `json:"date"`
+                PreviousDate      string   `json:"previousDate"`
+                TotalAmount       float64  `json:"totalAmount"`
+                TotalChange       float64  `json:"totalChange"`
+                FxImpact          *float64 `json:"fxImpact"`
+                AssetClassChanges []struct {
+                    AssetClass string  `json:"assetClass"`
+                    Change     float64 `json:"change"`
+                } `json:"assetClassChanges"`
+                TopGainers []struct {
+                    Code string `json:"code"`
+                } `json:"topGainers"`
+                UpcomingDividends []struct {
+                    Ticker string  `json:"ticker"`
+                    Amount float64 `json:"amount"`
+                } `json:"upcomingDividends"`
+                ExpectedDividendTotal float64 `json:"expectedDividendTotal"`
+            } `json:"dailyReport"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    report := response.Data.DailyReport
+    assert.Equal(t, "2024-01-11", report.Date)
+    assert.Equal(t, "2024-01-10", report.PreviousDate)
+    assert.Equal(t, 2300000.0, report.TotalAmount)
+    assert.Equal(t, 22000.0, *report.FxImpact)
+    assert.Equal(t, "STOCK", report.AssetClassChanges[0].AssetClass)
+    assert.Equal(t, "MSFT", report.TopGainers[0].Code)
+    assert.Equal(t, "KO", report.UpcomingDividends[0].Ticker)
+    assert.Equal(t, 1380.0, report.ExpectedDividendTotal)
+
+    // 他のユーザーのサマリーは取得できない
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, `query { dailyReport(date: "2024-01-12") { date } }`, token)
+    var emptyResponse struct {
+        Data struct {
+            DailyReport *struct {
+                Date string `json:"date"`
+            } `json:"dailyReport"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &emptyResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    assert.Nil(t, emptyResponse.Data.DailyReport)
+}
@@ -0,0 +1,80 @@
+package deletedholding
+
+import (
+	"encoding/json"
+	"fmt"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/test"
+	"my-us-stock-backend/test/graphql"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeletedHoldingsE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := graphql.SetupGraphQLServer(db, nil)
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    userId := uint(88)
+    token, err := graphql.GenerateTestAccessTokenForUserId(userId)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+    account := model.BrokerageAccount{Name: "SBI 特定", Type: "TAXABLE", UserId: userId}
+    db.Create(&account)
+    crypto := model.Crypto{Code: "btc", GetPrice: 5000000, Quantity: 0.1, AccountId: account.ID, UserId: userId}
+    db.Create(&crypto)
+    fixedIncomeAsset := model.FixedIncomeAsset{Code: "社債A", GetPriceTotal: 500000, DividendRate: 2.5, AccountId: account.ID, UserId: userId}
+    db.Create(&fixedIncomeAsset)
+
+    // 既存の削除処理でゴミ箱に移す
+    w := graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(`mutation { deleteCrypto(id: "%d") }`, crypto.ID), token)
+    assert.Contains(t, w.Body.String(), `"deleteCrypto":true`)
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(`mutation { deleteFixedIncomeAsset(id: "%d") }`, fixedIncomeAsset.ID), token)
+    assert.Contains(t, w.Body.String(), `"deleteFixedIncomeAsset":true`)
+
+    query := `query { deletedHoldings { id type code quantity getPrice getPriceTotal deletedAt purgeAt } }`
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, query, token)
+    var response struct {
+        Data struct {
+            DeletedHoldings []struct {
+                ID            string   `json:"id"`
+                Type          string   `json:"type"`
+                Code          string   `json:"code"`
+                Quantity      *float64 `json:"quantity"`
+                GetPriceTotal *float64 `json:"getPriceTotal"`
+                DeletedAt     string   `json:"deletedAt"`
+                PurgeAt       string   `json:"purgeAt"`
+            } `json:"deletedHoldings"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    assert.Len(t, response.Data.DeletedHoldings, 2)
+    types := map[string]string{}
+    for _, holding := range response.Data.DeletedHoldings {
+        types[holding.Type] = holding.Code
+        assert.NotEmpty(t, holding.DeletedAt)
+        assert.Greater(t, holding.PurgeAt, holding.DeletedAt)
+    }
+    assert.Equal(t, map[string]string{"CRYPTO": "btc", "FIXED_INCOME_ASSET": "社債A"}, types)
+
+    // 復元すると保有資産の一覧に戻り、ゴミ箱から消える
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(`mutation { restoreFixedIncomeAsset(id: "%d") }`, fixedIncomeAsset.ID), token)
+    assert.Contains(t, w.Body.String(), `"restoreFixedIncomeAsset":true`)
+    var restored model.FixedIncomeAsset
+    assert.NoError(t, db.First(&restored, fixedIncomeAsset.ID).Error)
+
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, `query { deletedHoldings(type: FIXED_INCOME_ASSET) { id } }`, token)
+    assert.JSONEq(t, `{"data": {"deletedHoldings": []}}`, w.Body.String())
+
+    // 同じ銘柄を登録し直している場合は復元できない
+    db.Create(&model.Crypto{Code: "btc", GetPrice: 6000000, Quantity: 0.2, AccountId: account.ID, UserId: userId})
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(`mutation { restoreCrypto(id: "%d") }`, crypto.ID), token)
+    assert.Contains(t, w.Body.String(), "同じ銘柄が既に登録されているため復元できません")
+}
@@ -0,0 +1,146 @@
+package event
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"my-us-stock-backend/app/database/model"
+	repoMarketPrice "my-us-stock-backend/app/repository/market-price"
+	"my-us-stock-backend/test"
+	"my-us-stock-backend/test/graphql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// MockHTTPTransport は http.RoundTripper のインターフェースを満たすモック実装です。
+type MockHTTPTransport struct {
+    RoundTripFunc func(req *http.Request) (*http.Response, error)
+}
+
+// RoundTrip は http.RoundTripper の RoundTrip メソッドを模倣します。
+func (m *MockHTTPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+    return m.RoundTripFunc(req)
+}
+
+func TestUpcomingEventsE2E(t *testing.T) {
+    db := test.SetupTestDB()
+
+    // 今日からの相対日付でモックのレスポンスを作成
+    earningsDate := time.Now().AddDate(0, 0, 10).Format("2006-01-02")
+    exDate := time.Now().AddDate(0, 0, 3).Format("2006-01-02")
+    paymentDate := time.Now().AddDate(0, 0, 60).Format("2006-01-02")
+    mockStockPrice := fmt.Sprintf(`[{"symbol": "KO", "price": 57.205, "earningsAnnouncement": "%sT10:59:00.000+0000"}]`, earningsDate)
+    mockDividend := fmt.Sprintf(`{
+        "symbol": "KO",
+        "historical": [
+            {"date": "%s", "adjDividend": 0.485, "dividend": 0.485, "recordDate": "", "paymentDate": "%s", "declarationDate": ""}
+        ]
+    }`, exDate, paymentDate)
+
+    mockTransport := &MockHTTPTransport{
+        RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+            var responseBody string
+            if req.URL.Path == "/v3/quote-order/KO" {
+                responseBody = mockStockPrice
+            } else if req.URL.Path == "/v3/historical-price-full/stock_dividend/KO" {
+                responseBody = mockDividend
+            }
+            r := io.NopCloser(bytes.NewReader([]byte(responseBody)))
+            return &http.Response{
+                StatusCode: http.StatusOK,
+                Body:       r,
+            }, nil
+        },
+    }
+    mockHTTPClient := &http.Client{Transport: mockTransport}
+    opts := &graphql.SetupOptions{
+        MockHTTPClient: mockHTTPClient,
+        MarketPriceRepo: repoMarketPrice.NewMarketPriceRepository(mockHTTPClient),
+    }
+    router := graphql.SetupGraphQLServer(db, opts)
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    db.Create(&model.UsStock{Code: "KO", UserId: 30, Quantity: 10, GetPrice: 50, Sector: "Consumer Staples", UsdJpy: 140})
+
+    token, err := graphql.GenerateTestAccessTokenForUserId(30)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+
+    query := `query {
+        upcomingEvents(days: 30) { ticker type date title amount }
+      }`
+    w := graphql.ExecuteGraphQLRequestWithToken(ts.URL, query, token)
+
+    var response struct {
+        Data struct {
+            UpcomingEvents []struct {
+                Ticker string   `json:"ticker"`
+                Type   string   `json:"type"`
+                Date   string   `json:"date"`
+                Title  string   `json:"title"`
+                Amount *float64 `json:"amount"`
+            } `json:"upcomingEvents"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+
+    // 支払日は期間外のため含まれない
+    events := response.Data.UpcomingEvents
+    if assert.Len(t, events, 2) {
+        assert.Equal(t, "EX_DIVIDEND", events[0].Type)
+        assert.Equal(t, exDate, events[0].Date)
+        assert.Equal(t, 0.485, *events[0].Amount)
+        assert.Equal(t, "EARNINGS", events[1].Type)
+        assert.Equal(t, earningsDate, events[1].Date)
+        assert.Equal(t, "KO 決算発表", events[1].Title)
+        assert.Nil(t, events[1].Amount)
+    }
+}
+
+func TestIssueCalendarTokenE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := graphql.SetupGraphQLServer(db, nil)
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    token, err := graphql.GenerateTestAccessTokenForUserId(31)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+
+    query := `mutation { issueCalendarToken }`
+    issue := func() string {
+        w := graphql.ExecuteGraphQLRequestWithToken(ts.URL, query, token)
+        var response struct {
+            Data struct {
+                IssueCalendarToken string `json:"issueCalendarToken"`
+            } `json:"data"`
+        }
+        if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+            t.Fatalf("Failed to parse response body: %v", err)
+        }
+        return response.Data.IssueCalendarToken
+    }
+
+    first := issue()
+    second := issue()
+    assert.Len(t, first, 64)
+    assert.NotEqual(t, first, second)
+
+    // 再発行すると以前のトークンは無効になる
+    var calendarTokens []model.CalendarToken
+    db.Where("user_id = ?", 31).Find(&calendarTokens)
+    assert.Len(t, calendarTokens, 1)
+    assert.Equal(t, second, calendarTokens[0].Token)
+}
@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
@@ -268,3 +269,39 @@ func TestDeleteFixedIncomeAssetE2E(t *testing.T) {
 	result := db.First(&assetAfterDelete, "id = ?", createdFixedIncomeAssetID)
 	assert.ErrorIs(t, result.Error, gorm.ErrRecordNotFound)
 }
+
+func TestFixedIncomeCashFlowsE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := graphql.SetupGraphQLServer(db, nil)
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    userId := uint(98)
+    token, err := graphql.GenerateTestAccessTokenForUserId(userId)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+
+    // 3ヶ月後に満期を迎える満期一括の債券を登録する
+    today := time.Now().UTC()
+    issueDate := today.AddDate(-1, 0, 0).Format("2006-01-02")
+    maturityDate := today.AddDate(0, 3, 0).Format("2006-01-02")
+    mutation := fmt.Sprintf(`mutation { createFixedIncomeAsset(input: {code: "Bond", getPriceTotal: 100000, dividendRate: 2.0, paymentMonth: [], issueDate: "%s", maturityDate: "%s", couponFrequency: AT_MATURITY, redemptionAmount: 100000}) { code maturityDate couponFrequency currency redemptionAmount status } }`, issueDate, maturityDate)
+    w := graphql.ExecuteGraphQLRequestWithToken(ts.URL, mutation, token)
+    assert.JSONEq(t, fmt.Sprintf(`{"data": {"createFixedIncomeAsset": {"code": "Bond", "maturityDate": "%s", "couponFrequency": "AT_MATURITY", "currency": "JPY", "redemptionAmount": 100000, "status": "ACTIVE"}}}`, maturityDate), w.Body.String())
+
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, `query { fixedIncomeCashFlows(months: 6) { code date type currency } }`, token)
+    assert.JSONEq(t, fmt.Sprintf(`{"data": {"fixedIncomeCashFlows": [
+        {"code": "Bond", "date": "%[1]s", "type": "COUPON", "currency": "JPY"},
+        {"code": "Bond", "date": "%[1]s", "type": "REDEMPTION", "currency": "JPY"}
+    ]}}`, maturityDate), w.Body.String())
+
+    // 取得期間外の満期償還は含まれない
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, `query { fixedIncomeCashFlows(months: 1) { type } }`, token)
+    assert.JSONEq(t, `{"data": {"fixedIncomeCashFlows": []}}`, w.Body.String())
+
+    // 満期日の形式が不正な場合はエラー
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, `mutation { createFixedIncomeAsset(input: {code: "Loan", getPriceTotal: 1000, dividendRate: 5.0, paymentMonth: [], maturityDate: "2027/01/01"}) { id } }`, token)
+    assert.Contains(t, w.Body.String(), "日付はYYYY-MM-DD形式で指定してください")
+}
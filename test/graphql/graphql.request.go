@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io"
 	"log"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -75,4 +76,43 @@ func GenerateTestAccessTokenForUserId(userId uint) (string, error) {
         return "", err
     }
     return tokenString, nil
-}
\ No newline at end of file
+}
+// テスト用のダミーアクセストークンを用いて、ファイルを添付したGraphQLリクエスト(multipart/form-data)を実行する
+// variables のうち fileVariable で指定した変数(例: "input.file")にファイルが割り当てられる
+func ExecuteGraphQLUploadWithToken(url, query string, variables map[string]interface{}, fileVariable, filename string, content []byte, token string) *httptest.ResponseRecorder {
+    operations, _ := json.Marshal(map[string]interface{}{
+        "query":     query,
+        "variables": variables,
+    })
+    fileMap, _ := json.Marshal(map[string][]string{"0": {"variables." + fileVariable}})
+
+    var body bytes.Buffer
+    writer := multipart.NewWriter(&body)
+    writer.WriteField("operations", string(operations))
+    writer.WriteField("map", string(fileMap))
+    part, _ := writer.CreateFormFile("0", filename)
+    part.Write(content)
+    writer.Close()
+
+    req, _ := http.NewRequest("POST", url+"/graphql", &body)
+    req.Header.Set("Content-Type", writer.FormDataContentType())
+    req.AddCookie(&http.Cookie{Name: "access_token", Value: token})
+
+    resp, err := http.DefaultClient.Do(req)
+    if err != nil {
+        log.Printf("Error making request: %v", err)
+        return nil
+    }
+    defer resp.Body.Close()
+
+    responseBody, err := io.ReadAll(resp.Body)
+    if err != nil {
+        log.Printf("Error reading response body: %v", err)
+        return nil
+    }
+
+    w := httptest.NewRecorder()
+    w.WriteHeader(resp.StatusCode)
+    w.Write(responseBody)
+    return w
+}
@@ -0,0 +1,122 @@
+package holdingimport
+
+import (
+	"encoding/json"
+	"errors"
+	"my-us-stock-backend/app/database/model"
+	repoBrokerageAccount "my-us-stock-backend/app/repository/brokerage-account"
+	repoMarketPrice "my-us-stock-backend/app/repository/market-price"
+	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
+	"my-us-stock-backend/test"
+	"my-us-stock-backend/test/graphql"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type importResponse struct {
+    Data struct {
+        ImportHoldings struct {
+            DryRun        bool `json:"dryRun"`
+            Committed     bool `json:"committed"`
+            CreateCount   int  `json:"createCount"`
+            UpdateCount   int  `json:"updateCount"`
+            ConflictCount int  `json:"conflictCount"`
+            Rows          []struct {
+                Line    int     `json:"line"`
+                Action  string  `json:"action"`
+                Code    string  `json:"code"`
+                Message *string `json:"message"`
+            } `json:"rows"`
+        } `json:"importHoldings"`
+    } `json:"data"`
+    Errors []struct {
+        Message string `json:"message"`
+    } `json:"errors"`
+}
+
+func TestImportHoldingsE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    mockMarketPriceRepo := repoMarketPrice.NewMockMarketPriceRepository()
+    mockCurrencyRepo := repoCurrency.NewMockCurrencyRepository()
+    mockMarketPriceRepo.On("FetchCompanyProfile", mock.Anything, mock.Anything).Return((*repoMarketPrice.CompanyProfileDto)(nil), errors.New("not found"))
+    mockCurrencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(150.0, nil)
+    router := graphql.SetupGraphQLServer(db, &graphql.SetupOptions{
+        MarketPriceRepo: mockMarketPriceRepo,
+        CurrencyRepo:    mockCurrencyRepo,
+    })
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    userId := uint(82)
+    // 口座を指定せずに取り込むと既定の口座の保有資産と突き合わせる
+    defaultAccountId, err := repoBrokerageAccount.FindOrCreateDefaultAccount(db, userId)
+    if err != nil {
+        t.Fatalf("Failed to create default account: %v", err)
+    }
+    db.Create(&model.UsStock{Code: "AAPL", GetPrice: 150, Quantity: 5, Sector: "Technology", UsdJpy: 140, AccountId: defaultAccountId, UserId: userId})
+
+    token, err := graphql.GenerateTestAccessTokenForUserId(userId)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+
+    csv := strings.Join([]string{
+        "type,code,name,quantity,get_price,usd_jpy,sector",
+        "US_STOCK,AAPL,,8,160,,",
+        "US_STOCK,IMPX,,2,50,145,ETF",
+        "CRYPTO,BTC,,0.1,5000000,,",
+    }, "\n")
+    query := `mutation ($input: ImportHoldingsInput!) {
+        importHoldings(input: $input) {
+          dryRun committed createCount updateCount conflictCount
+          rows { line action code message }
+        }
+      }`
+    importHoldings := func(dryRun bool) importResponse {
+        variables := map[string]interface{}{
+            "input": map[string]interface{}{"file": nil, "format": "GENERIC", "dryRun": dryRun},
+        }
+        w := graphql.ExecuteGraphQLUploadWithToken(ts.URL, query, variables, "input.file", "holdings.csv", []byte(csv), token)
+        var response importResponse
+        if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+            t.Fatalf("Failed to unmarshal response: %v", err)
+        }
+        assert.Empty(t, response.Errors)
+        return response
+    }
+
+    // プレビューでは登録しない
+    preview := importHoldings(true).Data.ImportHoldings
+    assert.True(t, preview.DryRun)
+    assert.False(t, preview.Committed)
+    assert.Equal(t, 2, preview.CreateCount)
+    assert.Equal(t, 1, preview.UpdateCount)
+    assert.Equal(t, 0, preview.ConflictCount)
+    assert.Equal(t, "UPDATE", preview.Rows[0].Action)
+    assert.Equal(t, "btc", preview.Rows[2].Code)
+    var count int64
+    db.Model(&model.Crypto{}).Where("user_id = ?", userId).Count(&count)
+    assert.Equal(t, int64(0), count)
+
+    // 確定すると全ての行が登録される
+    result := importHoldings(false).Data.ImportHoldings
+    assert.True(t, result.Committed)
+
+    var usStocks []model.UsStock
+    db.Where("user_id = ?", userId).Order("code").Find(&usStocks)
+    assert.Len(t, usStocks, 2)
+    assert.Equal(t, 8.0, usStocks[0].Quantity)
+    assert.Equal(t, 160.0, usStocks[0].GetPrice)
+    assert.Equal(t, "IMPX", usStocks[1].Code)
+    assert.Equal(t, "ETF", usStocks[1].Sector)
+    assert.Equal(t, 145.0, usStocks[1].UsdJpy)
+    var cryptos []model.Crypto
+    db.Where("user_id = ?", userId).Find(&cryptos)
+    assert.Len(t, cryptos, 1)
+    assert.Equal(t, "btc", cryptos[0].Code)
+}
@@ -0,0 +1,117 @@
+package household
+
+import (
+	"encoding/json"
+	"fmt"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/test"
+	"my-us-stock-backend/test/graphql"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestHouseholdE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := graphql.SetupGraphQLServer(db, nil)
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    ownerId := uint(91)
+    partnerId := uint(92)
+    outsiderId := uint(93)
+    db.Create(&model.User{Model: gorm.Model{ID: ownerId}, Name: "太郎", Email: "household-owner@example.com", Password: "hashed"})
+    db.Create(&model.User{Model: gorm.Model{ID: partnerId}, Name: "花子", Email: "household-partner@example.com", Password: "hashed"})
+    ownerToken, err := graphql.GenerateTestAccessTokenForUserId(ownerId)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+    partnerToken, err := graphql.GenerateTestAccessTokenForUserId(partnerId)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+    outsiderToken, err := graphql.GenerateTestAccessTokenForUserId(outsiderId)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+
+    // 世帯を作成し、パートナーを閲覧者として追加する
+    w := graphql.ExecuteGraphQLRequestWithToken(ts.URL, `mutation { createHousehold(input: {name: "山田家"}) { id role } }`, ownerToken)
+    var createResponse struct {
+        Data struct {
+            CreateHousehold struct {
+                ID   string `json:"id"`
+                Role string `json:"role"`
+            } `json:"createHousehold"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &createResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    householdId := createResponse.Data.CreateHousehold.ID
+    assert.NotEmpty(t, householdId)
+    assert.Equal(t, "OWNER", createResponse.Data.CreateHousehold.Role)
+
+    // 招待した時点ではメンバーにならず、登録されていないメールアドレスと同じ結果を返す
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(`mutation { addHouseholdMember(input: {householdId: "%s", email: "household-partner@example.com", role: VIEWER}) { members { name role } } }`, householdId), ownerToken)
+    assert.JSONEq(t, `{"data": {"addHouseholdMember": {"members": [{"name": "太郎", "role": "OWNER"}]}}}`, w.Body.String())
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(`mutation { addHouseholdMember(input: {householdId: "%s", email: "unknown@example.com", role: VIEWER}) { members { name role } } }`, householdId), ownerToken)
+    assert.JSONEq(t, `{"data": {"addHouseholdMember": {"members": [{"name": "太郎", "role": "OWNER"}]}}}`, w.Body.String())
+
+    // 承認前は世帯の資産を参照できない
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(`query { fixedIncomeAssets(householdId: "%s") { code } }`, householdId), partnerToken)
+    assert.Contains(t, w.Body.String(), "指定された世帯が見つかりません")
+
+    // 招待されたユーザーが自分のセッションで承認するとメンバーになる
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, `query { householdInvitations { name role } }`, partnerToken)
+    assert.JSONEq(t, `{"data": {"householdInvitations": [{"name": "山田家", "role": "VIEWER"}]}}`, w.Body.String())
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(`mutation { acceptHouseholdInvitation(householdId: "%s") { role members { name role } } }`, householdId), partnerToken)
+    assert.JSONEq(t, `{"data": {"acceptHouseholdInvitation": {"role": "VIEWER", "members": [{"name": "太郎", "role": "OWNER"}, {"name": "花子", "role": "VIEWER"}]}}}`, w.Body.String())
+
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, `query { households { name role } }`, partnerToken)
+    assert.JSONEq(t, `{"data": {"households": [{"name": "山田家", "role": "VIEWER"}]}}`, w.Body.String())
+
+    // それぞれの保有資産を登録する
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, `mutation { createFixedIncomeAsset(input: {code: "社債A", getPriceTotal: 100000, dividendRate: 1.5, paymentMonth: [6]}) { id } }`, ownerToken)
+    var assetResponse struct {
+        Data struct {
+            CreateFixedIncomeAsset struct {
+                ID string `json:"id"`
+            } `json:"createFixedIncomeAsset"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &assetResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    ownerAssetId := assetResponse.Data.CreateFixedIncomeAsset.ID
+    graphql.ExecuteGraphQLRequestWithToken(ts.URL, `mutation { createFixedIncomeAsset(input: {code: "国債B", getPriceTotal: 200000, dividendRate: 0.5, paymentMonth: [3]}) { id } }`, partnerToken)
+
+    // 世帯を指定すると全メンバーの保有資産を合算して返す
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(`query { fixedIncomeAssets(householdId: "%s") { code getPriceTotal } }`, householdId), partnerToken)
+    assert.JSONEq(t, `{"data": {"fixedIncomeAssets": [{"code": "社債A", "getPriceTotal": 100000}, {"code": "国債B", "getPriceTotal": 200000}]}}`, w.Body.String())
+
+    // 世帯を指定しない場合は自分の保有資産のみ
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, `query { fixedIncomeAssets { code } }`, partnerToken)
+    assert.JSONEq(t, `{"data": {"fixedIncomeAssets": [{"code": "国債B"}]}}`, w.Body.String())
+
+    // 閲覧者は他のメンバーの保有資産を編集できない
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(`mutation { updateFixedIncomeAsset(input: {id: "%s", getPriceTotal: 1}) { id } }`, ownerAssetId), partnerToken)
+    assert.Contains(t, w.Body.String(), "この保有資産を編集する権限がありません")
+    var asset model.FixedIncomeAsset
+    db.First(&asset, ownerAssetId)
+    assert.Equal(t, 100000.0, asset.GetPriceTotal)
+
+    // 編集者に変更すると編集できる
+    graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(`mutation { updateHouseholdMember(input: {householdId: "%s", userId: "%d", role: EDITOR}) { id } }`, householdId, partnerId), ownerToken)
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(`mutation { updateFixedIncomeAsset(input: {id: "%s", getPriceTotal: 150000}) { getPriceTotal } }`, ownerAssetId), partnerToken)
+    assert.JSONEq(t, `{"data": {"updateFixedIncomeAsset": {"getPriceTotal": 150000}}}`, w.Body.String())
+
+    // 世帯に所属していないユーザーは参照できない
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(`query { fixedIncomeAssets(householdId: "%s") { code } }`, householdId), outsiderToken)
+    assert.Contains(t, w.Body.String(), "指定された世帯が見つかりません")
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(`mutation { deleteFixedIncomeAsset(id: "%s") }`, ownerAssetId), outsiderToken)
+    assert.Contains(t, w.Body.String(), "指定された保有資産が見つかりません")
+}
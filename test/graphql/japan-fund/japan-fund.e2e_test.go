@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
@@ -274,3 +275,48 @@ func TestDeleteJapanFundE2E(t *testing.T) {
 	result := db.First(&fundAfterDelete, "id = ?", createdJapanFundID)
 	assert.ErrorIs(t, result.Error, gorm.ErrRecordNotFound)
 }
+
+// 直近の日次価格を日付の昇順で取得する
+func TestFundPriceHistoryE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := graphql.SetupGraphQLServer(db, nil)
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    today := time.Now().UTC()
+    date := func(daysAgo int) time.Time {
+        return time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -daysAgo)
+    }
+    db.Create(&model.FundPriceHistory{Code: "HISTE2E", Date: date(40), Price: 9500})
+    db.Create(&model.FundPriceHistory{Code: "HISTE2E", Date: date(2), Price: 10100})
+    db.Create(&model.FundPriceHistory{Code: "HISTE2E", Date: date(5), Price: 10000})
+
+    token, err := graphql.GenerateTestAccessTokenForUserId(100)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+
+    query := `query {
+        fundPriceHistory(code: "HISTE2E", days: 30) { date price }
+    }`
+    w := graphql.ExecuteGraphQLRequestWithToken(ts.URL, query, token)
+
+    var response struct {
+        Data struct {
+            FundPriceHistory []struct {
+                Date  string  `json:"date"`
+                Price float64 `json:"price"`
+            } `json:"fundPriceHistory"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+
+    // 30日より前の価格は含まない
+    assert.Len(t, response.Data.FundPriceHistory, 2, w.Body.String())
+    assert.Equal(t, date(5).Format("2006-01-02"), response.Data.FundPriceHistory[0].Date)
+    assert.Equal(t, 10000.0, response.Data.FundPriceHistory[0].Price)
+    assert.Equal(t, 10100.0, response.Data.FundPriceHistory[1].Price)
+}
@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"io"
 	repoMarketPrice "my-us-stock-backend/app/repository/market-price"
+	"my-us-stock-backend/test"
 	"my-us-stock-backend/test/graphql"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -111,4 +113,172 @@ func TestMarketPriceE2E(t *testing.T) {
     } else {
         t.Fatalf("Expected non-empty MarketPrice array")
     }
-}
\ No newline at end of file
+}
+
+func TestPriceHistoryE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    // モックの HTTP レスポンスを設定(APIは新しい順で返却する)
+    mockResponseBody := `{
+        "symbol": "AAPL",
+        "historical": [
+            {"date": "2024-01-09", "open": 183.92, "high": 185.15, "low": 182.73, "close": 185.14, "volume": 400},
+            {"date": "2024-01-08", "open": 182.09, "high": 185.60, "low": 181.50, "close": 185.56, "volume": 300},
+            {"date": "2024-01-05", "open": 181.99, "high": 182.76, "low": 180.17, "close": 181.18, "volume": 200},
+            {"date": "2024-01-04", "open": 182.15, "high": 183.09, "low": 180.88, "close": 181.91, "volume": 100}
+        ]
+    }`
+
+    // モックのHTTPクライアント設定(外部APIの呼び出し回数を記録する)
+    callCount := 0
+    mockTransport := &MockHTTPTransport{
+        RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+            if strings.Contains(req.URL.Path, "/v3/historical-price-full/AAPL") {
+                callCount++
+            }
+            r := io.NopCloser(bytes.NewReader([]byte(mockResponseBody)))
+            return &http.Response{
+                StatusCode: http.StatusOK,
+                Body:       r,
+            }, nil
+        },
+    }
+    mockHTTPClient := &http.Client{Transport: mockTransport}
+    mockMarketPriceRepo := repoMarketPrice.NewMarketPriceRepository(mockHTTPClient)
+    opts := &graphql.SetupOptions{
+        MockHTTPClient: mockHTTPClient,
+        MarketPriceRepo: mockMarketPriceRepo,
+    }
+    graphqlServer := graphql.SetupGraphQLServer(db, opts)
+
+    type pricePoint struct {
+        Date   string  `json:"date"`
+        Open   float64 `json:"open"`
+        High   float64 `json:"high"`
+        Low    float64 `json:"low"`
+        Close  float64 `json:"close"`
+        Volume float64 `json:"volume"`
+    }
+    var response struct {
+        Data struct {
+            PriceHistory []pricePoint `json:"priceHistory"`
+        } `json:"data"`
+    }
+
+    // 日次(外部APIから取得し、キャッシュに保存される)
+    dailyQuery := `query {
+        priceHistory(ticker: "AAPL", from: "2024-01-04", to: "2024-01-09") {
+          date
+          open
+          high
+          low
+          close
+          volume
+        }
+      }`
+    w := executeGraphQLRequest(graphqlServer, dailyQuery)
+    if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    assert.Len(t, response.Data.PriceHistory, 4)
+    assert.Equal(t, "2024-01-04", response.Data.PriceHistory[0].Date)
+    assert.Equal(t, 181.91, response.Data.PriceHistory[0].Close)
+    assert.Equal(t, "2024-01-09", response.Data.PriceHistory[3].Date)
+
+    // 週次(キャッシュから取得するため外部APIは呼ばれない)
+    weeklyQuery := `query {
+        priceHistory(ticker: "AAPL", from: "2024-01-04", to: "2024-01-09", interval: WEEKLY) {
+          date
+          open
+          high
+          low
+          close
+          volume
+        }
+      }`
+    w = executeGraphQLRequest(graphqlServer, weeklyQuery)
+    if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    assert.Equal(t, []pricePoint{
+        {Date: "2024-01-04", Open: 182.15, High: 183.09, Low: 180.17, Close: 181.18, Volume: 300},
+        {Date: "2024-01-08", Open: 182.09, High: 185.60, Low: 181.50, Close: 185.14, Volume: 700},
+    }, response.Data.PriceHistory)
+    assert.Equal(t, 1, callCount)
+}
+
+func TestDividendHistoryE2E(t *testing.T) {
+    // モックの HTTP レスポンスを設定
+    mockResponseBody := `{
+        "symbol": "KO",
+        "historical": [
+            {"date": "2023-11-30", "label": "November 30, 23", "adjDividend": 0.46, "dividend": 0.46, "recordDate": "2023-12-01", "paymentDate": "2023-12-15", "declarationDate": "2023-10-19"},
+            {"date": "2013-09-11", "label": "September 11, 13", "adjDividend": 0.28, "dividend": 0.28, "recordDate": "2013-09-13", "paymentDate": "2013-10-01", "declarationDate": "2013-07-18"}
+        ]
+    }`
+
+    mockTransport := &MockHTTPTransport{
+        RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+            r := io.NopCloser(bytes.NewReader([]byte(mockResponseBody)))
+            return &http.Response{
+                StatusCode: http.StatusOK,
+                Body:       r,
+            }, nil
+        },
+    }
+    mockHTTPClient := &http.Client{Transport: mockTransport}
+    mockMarketPriceRepo := repoMarketPrice.NewMarketPriceRepository(mockHTTPClient)
+    opts := &graphql.SetupOptions{
+        MockHTTPClient: mockHTTPClient,
+        MarketPriceRepo: mockMarketPriceRepo,
+    }
+    graphqlServer := graphql.SetupGraphQLServer(nil, opts)
+
+    query := `query {
+        dividendHistory(ticker: "KO") {
+          ticker
+          payments {
+            exDate
+            recordDate
+            paymentDate
+            declarationDate
+            amount
+          }
+          cagr10y
+          consecutiveGrowthYears
+          latestPaymentCut
+        }
+      }`
+    w := executeGraphQLRequest(graphqlServer, query)
+
+    var response struct {
+        Data struct {
+            DividendHistory struct {
+                Ticker string `json:"ticker"`
+                Payments []struct {
+                    ExDate          string  `json:"exDate"`
+                    RecordDate      string  `json:"recordDate"`
+                    PaymentDate     string  `json:"paymentDate"`
+                    DeclarationDate string  `json:"declarationDate"`
+                    Amount          float64 `json:"amount"`
+                } `json:"payments"`
+                Cagr10y                *float64 `json:"cagr10y"`
+                ConsecutiveGrowthYears int      `json:"consecutiveGrowthYears"`
+                LatestPaymentCut       bool     `json:"latestPaymentCut"`
+            } `json:"dividendHistory"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+
+    // 直近1年より前の配当も全て返却される
+    history := response.Data.DividendHistory
+    assert.Equal(t, "KO", history.Ticker)
+    assert.Len(t, history.Payments, 2)
+    assert.Equal(t, "2023-11-30", history.Payments[0].ExDate)
+    assert.Equal(t, "2023-12-01", history.Payments[0].RecordDate)
+    assert.Equal(t, "2023-12-15", history.Payments[0].PaymentDate)
+    assert.Equal(t, "2023-10-19", history.Payments[0].DeclarationDate)
+    assert.Equal(t, "2013-09-11", history.Payments[1].ExDate)
+    assert.False(t, history.LatestPaymentCut)
+}
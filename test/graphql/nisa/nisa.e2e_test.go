@@ -0,0 +1,53 @@
+package nisa
+
+import (
+	"fmt"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/test"
+	"my-us-stock-backend/test/graphql"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNisaAllowanceE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := graphql.SetupGraphQLServer(db, nil)
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    userId := uint(90)
+    token, err := graphql.GenerateTestAccessTokenForUserId(userId)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+    account := model.BrokerageAccount{Name: "NISAつみたて", Type: "NISA_TSUMITATE", UserId: userId}
+    db.Create(&account)
+    db.Create(&model.FundPrice{Name: "ｅＭＡＸＩＳ Ｓｌｉｍ 全世界株式（オール・カントリー）", Code: "ORCN", Price: 25000})
+
+    // つみたて投資枠で100万円分購入する
+    createFund := `mutation { createJapanFund(input: {code: "ORCN", name: "ｅＭＡＸＩＳ Ｓｌｉｍ 全世界株式（オール・カントリー）", getPrice: 20000, getPriceTotal: %d, accountId: "%d"}) { code } }`
+    w := graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(createFund, 1000000, account.ID), token)
+    assert.Contains(t, w.Body.String(), `"code":"ORCN"`)
+
+    year := time.Now().Year()
+    query := fmt.Sprintf(`query { nisaAllowance(year: %d) { year annual { limit used remaining } tsumitateAnnual { limit used remaining } lifetime { used remaining } } }`, year)
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, query, token)
+    assert.JSONEq(t, fmt.Sprintf(`{"data": {"nisaAllowance": {
+        "year": %d,
+        "annual": {"limit": 3600000, "used": 1000000, "remaining": 2600000},
+        "tsumitateAnnual": {"limit": 1200000, "used": 1000000, "remaining": 200000},
+        "lifetime": {"used": 1000000, "remaining": 17000000}
+    }}}`, year), w.Body.String())
+
+    // 年間投資枠を超える購入は登録できない
+    var fund model.JapanFund
+    db.Where("user_id = ?", userId).First(&fund)
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(`mutation { updateJapanFund(input: {id: "%d", getPrice: 20000, getPriceTotal: 1300000}) { id } }`, fund.ID), token)
+    assert.Contains(t, w.Body.String(), "つみたて投資枠の年間投資枠(120万円)を超えるため登録できません")
+    db.First(&fund, fund.ID)
+    assert.Equal(t, 1000000.0, fund.GetPriceTotal)
+}
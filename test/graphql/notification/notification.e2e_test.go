@@ -0,0 +1,129 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	commonNotification "my-us-stock-backend/app/common/notification"
+	"my-us-stock-backend/app/database/model"
+	repoNotification "my-us-stock-backend/app/repository/notification"
+	"my-us-stock-backend/test"
+	"my-us-stock-backend/test/graphql"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestNotificationE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := graphql.SetupGraphQLServer(db, nil)
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    // Webhookの受信先
+    var receivedBody []byte
+    var receivedSignature string
+    receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        receivedBody, _ = io.ReadAll(r.Body)
+        receivedSignature = r.Header.Get(commonNotification.HeaderSignature)
+    }))
+    defer receiver.Close()
+    // 内部ネットワーク宛ての通知先は登録できないため、公開ホスト名宛ての送信を受信先へ接続する
+    webhookTarget := "http://hooks.example.com/notify"
+    receiverClient := &http.Client{Transport: &http.Transport{
+        DialContext: func(ctx context.Context, network string, _ string) (net.Conn, error) {
+            return (&net.Dialer{}).DialContext(ctx, network, receiver.Listener.Addr().String())
+        },
+    }}
+
+    userId := uint(70)
+    db.Create(&model.User{Model: gorm.Model{ID: userId}, Name: "通知", Email: "notification@example.com", Password: "hashed"})
+    token, err := graphql.GenerateTestAccessTokenForUserId(userId)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+
+    // ループバック宛ての通知先は登録できない
+    rejectQuery := fmt.Sprintf(`mutation {
+        createNotificationChannel(input: {type: WEBHOOK, name: "内部", target: "%s", secret: "secret-0123456789"}) { id }
+      }`, receiver.URL)
+    w := graphql.ExecuteGraphQLRequestWithToken(ts.URL, rejectQuery, token)
+    assert.Contains(t, w.Body.String(), commonNotification.ErrDisallowedWebhookTarget.Error())
+
+    // 通知先を登録
+    createQuery := fmt.Sprintf(`mutation {
+        createNotificationChannel(input: {type: WEBHOOK, name: "自宅サーバー", target: "%s", secret: "secret-0123456789"}) {
+          id type name target enabled
+        }
+      }`, webhookTarget)
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, createQuery, token)
+    var createResponse struct {
+        Data struct {
+            CreateNotificationChannel struct {
+                ID      string `json:"id"`
+                Type    string `json:"type"`
+                Target  string `json:"target"`
+                Enabled bool   `json:"enabled"`
+            } `json:"createNotificationChannel"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &createResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    assert.Equal(t, "WEBHOOK", createResponse.Data.CreateNotificationChannel.Type)
+    assert.Equal(t, webhookTarget, createResponse.Data.CreateNotificationChannel.Target)
+    assert.True(t, createResponse.Data.CreateNotificationChannel.Enabled)
+
+    // 通知を登録して送信
+    notificationService := commonNotification.NewNotificationService(repoNotification.NewNotificationRepository(db), receiverClient)
+    assert.NoError(t, notificationService.Notify(context.Background(), userId, commonNotification.EventAlertTriggered, "[アラート] AAPL の株価が 170 以下になりました", "現在値: 168.5"))
+    assert.NoError(t, notificationService.DispatchPendingNotifications(context.Background()))
+
+    var payload map[string]interface{}
+    assert.NoError(t, json.Unmarshal(receivedBody, &payload))
+    assert.Equal(t, "ALERT_TRIGGERED", payload["event"])
+    assert.NotEmpty(t, receivedSignature)
+
+    // 送信履歴を取得
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, `query { notificationDeliveries { channelName event subject status attempts sentAt } }`, token)
+    var deliveriesResponse struct {
+        Data struct {
+            NotificationDeliveries []struct {
+                ChannelName string  `json:"channelName"`
+                Event       string  `json:"event"`
+                Subject     string  `json:"subject"`
+                Status      string  `json:"status"`
+                Attempts    int     `json:"attempts"`
+                SentAt      *string `json:"sentAt"`
+            } `json:"notificationDeliveries"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &deliveriesResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    assert.Len(t, deliveriesResponse.Data.NotificationDeliveries, 1)
+    delivery := deliveriesResponse.Data.NotificationDeliveries[0]
+    assert.Equal(t, "自宅サーバー", delivery.ChannelName)
+    assert.Equal(t, "SENT", delivery.Status)
+    assert.Equal(t, 1, delivery.Attempts)
+    assert.NotNil(t, delivery.SentAt)
+
+    // 通知先を削除
+    deleteQuery := fmt.Sprintf(`mutation { deleteNotificationChannel(id: "%s") }`, createResponse.Data.CreateNotificationChannel.ID)
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, deleteQuery, token)
+    var deleteResponse struct {
+        Data struct {
+            DeleteNotificationChannel bool `json:"deleteNotificationChannel"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &deleteResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    assert.True(t, deleteResponse.Data.DeleteNotificationChannel)
+}
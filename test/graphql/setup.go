@@ -3,26 +3,58 @@ package graphql
 import (
 	authService "my-us-stock-backend/app/common/auth"
 	"my-us-stock-backend/app/common/auth/logic"
+	commonHousehold "my-us-stock-backend/app/common/household"
+	"my-us-stock-backend/app/common/housekeeping"
 	"my-us-stock-backend/app/graphql"
+	serviceAlert "my-us-stock-backend/app/graphql/alert"
 	"my-us-stock-backend/app/graphql/crypto"
 	serviceCurrency "my-us-stock-backend/app/graphql/currency"
+	serviceDailyReport "my-us-stock-backend/app/graphql/daily-report"
+	serviceEvent "my-us-stock-backend/app/graphql/event"
 	serviceFixedIncomeAsset "my-us-stock-backend/app/graphql/fixed-income-asset"
+	serviceHoldingImport "my-us-stock-backend/app/graphql/holding-import"
 	serviceJapanFund "my-us-stock-backend/app/graphql/japan-fund"
 	serviceMarketPrice "my-us-stock-backend/app/graphql/market-price"
+	serviceNotification "my-us-stock-backend/app/graphql/notification"
 	serviceStock "my-us-stock-backend/app/graphql/stock"
+	serviceSubscription "my-us-stock-backend/app/graphql/subscription"
+	serviceTaxReport "my-us-stock-backend/app/graphql/tax-report"
+	serviceAccount "my-us-stock-backend/app/graphql/account"
+	serviceDeletedHolding "my-us-stock-backend/app/graphql/deleted-holding"
+	serviceBrokerageAccount "my-us-stock-backend/app/graphql/brokerage-account"
+	serviceNisa "my-us-stock-backend/app/graphql/nisa"
+	serviceHousehold "my-us-stock-backend/app/graphql/household"
+	serviceShareToken "my-us-stock-backend/app/graphql/share-token"
 	serviceTotalAsset "my-us-stock-backend/app/graphql/total-asset"
 	serviceUser "my-us-stock-backend/app/graphql/user"
+	serviceWatchlist "my-us-stock-backend/app/graphql/watchlist"
+	repoAlert "my-us-stock-backend/app/repository/alert"
 	repoCrypto "my-us-stock-backend/app/repository/assets/crypto"
 	repoFixedIncome "my-us-stock-backend/app/repository/assets/fixed-income"
 	repoJapanFund "my-us-stock-backend/app/repository/assets/fund"
 	repoStock "my-us-stock-backend/app/repository/assets/stock"
+	repoCalendar "my-us-stock-backend/app/repository/calendar"
+	repoDailyReport "my-us-stock-backend/app/repository/daily-report"
+	repoHoldingImport "my-us-stock-backend/app/repository/holding-import"
 	repoMarketPrice "my-us-stock-backend/app/repository/market-price"
 	repoMarketCrypto "my-us-stock-backend/app/repository/market-price/crypto"
 	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
 	repoFundPrice "my-us-stock-backend/app/repository/market-price/fund"
+	repoInstrument "my-us-stock-backend/app/repository/market-price/instrument"
+	repoPriceHistory "my-us-stock-backend/app/repository/market-price/price-history"
+	repoNotification "my-us-stock-backend/app/repository/notification"
+	repoTaxReport "my-us-stock-backend/app/repository/tax-report"
+	repoAccount "my-us-stock-backend/app/repository/account"
+	repoDeletedHolding "my-us-stock-backend/app/repository/deleted-holding"
+	repoBrokerageAccount "my-us-stock-backend/app/repository/brokerage-account"
+	repoNisa "my-us-stock-backend/app/repository/nisa"
+	repoHousehold "my-us-stock-backend/app/repository/household"
+	repoShareToken "my-us-stock-backend/app/repository/share-token"
 	repoTotalAsset "my-us-stock-backend/app/repository/total-assets"
 	repoUser "my-us-stock-backend/app/repository/user"
+	repoWatchlist "my-us-stock-backend/app/repository/watchlist"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -31,6 +63,7 @@ import (
 // SetupOptions - GraphQLサーバーのセットアップオプション
 type SetupOptions struct {
     MockHTTPClient    *http.Client
+    PricePollInterval time.Duration
     CurrencyRepo      repoCurrency.CurrencyRepository
     UserRepo          repoUser.UserRepository
     MarketPriceRepo   repoMarketPrice.MarketPriceRepository
@@ -41,6 +74,21 @@ type SetupOptions struct {
     JapanFundRepo repoJapanFund.JapanFundRepository
     TotalAssetRepo repoTotalAsset.TotalAssetRepository
     FundPriceRepo repoFundPrice.FundPriceRepository
+    InstrumentRepo repoInstrument.InstrumentRepository
+    PriceHistoryRepo repoPriceHistory.PriceHistoryRepository
+    CalendarTokenRepo repoCalendar.CalendarTokenRepository
+    WatchlistRepo repoWatchlist.WatchlistRepository
+    AlertRepo repoAlert.AlertRepository
+    NotificationRepo repoNotification.NotificationRepository
+    DailyReportRepo repoDailyReport.DailyReportRepository
+    HoldingImportRepo repoHoldingImport.HoldingImportRepository
+    TaxReportRepo repoTaxReport.TaxReportRepository
+    AccountRepo repoAccount.AccountRepository
+    DeletedHoldingRepo repoDeletedHolding.DeletedHoldingRepository
+    BrokerageAccountRepo repoBrokerageAccount.BrokerageAccountRepository
+    NisaRepo repoNisa.NisaRepository
+    HouseholdRepo repoHousehold.HouseholdRepository
+    ShareTokenRepo repoShareToken.ShareTokenRepository
 }
 
 // SetupGraphQLServer - GraphQLサーバーのセットアップ
@@ -55,6 +103,21 @@ func SetupGraphQLServer(db *gorm.DB, opts *SetupOptions) http.Handler {
     var japanFundRepo repoJapanFund.JapanFundRepository
     var totalAssetRepo repoTotalAsset.TotalAssetRepository
     var fundPriceRepo repoFundPrice.FundPriceRepository
+    var instrumentRepo repoInstrument.InstrumentRepository
+    var priceHistoryRepo repoPriceHistory.PriceHistoryRepository
+    var calendarTokenRepo repoCalendar.CalendarTokenRepository
+    var watchlistRepo repoWatchlist.WatchlistRepository
+    var alertRepo repoAlert.AlertRepository
+    var notificationRepo repoNotification.NotificationRepository
+    var dailyReportRepo repoDailyReport.DailyReportRepository
+    var holdingImportRepo repoHoldingImport.HoldingImportRepository
+    var taxReportRepo repoTaxReport.TaxReportRepository
+    var accountRepo repoAccount.AccountRepository
+    var deletedHoldingRepo repoDeletedHolding.DeletedHoldingRepository
+    var brokerageAccountRepo repoBrokerageAccount.BrokerageAccountRepository
+    var nisaRepo repoNisa.NisaRepository
+    var householdRepo repoHousehold.HouseholdRepository
+    var shareTokenRepo repoShareToken.ShareTokenRepository
 
     // optsがnilでない場合にのみ、各リポジトリを設定
     if opts != nil {
@@ -67,6 +130,21 @@ func SetupGraphQLServer(db *gorm.DB, opts *SetupOptions) http.Handler {
         japanFundRepo = opts.JapanFundRepo
         totalAssetRepo = opts.TotalAssetRepo
         fundPriceRepo = opts.FundPriceRepo
+        instrumentRepo = opts.InstrumentRepo
+        priceHistoryRepo = opts.PriceHistoryRepo
+        calendarTokenRepo = opts.CalendarTokenRepo
+        watchlistRepo = opts.WatchlistRepo
+        alertRepo = opts.AlertRepo
+        notificationRepo = opts.NotificationRepo
+        dailyReportRepo = opts.DailyReportRepo
+        holdingImportRepo = opts.HoldingImportRepo
+        taxReportRepo = opts.TaxReportRepo
+        accountRepo = opts.AccountRepo
+        deletedHoldingRepo = opts.DeletedHoldingRepo
+        brokerageAccountRepo = opts.BrokerageAccountRepo
+        nisaRepo = opts.NisaRepo
+        householdRepo = opts.HouseholdRepo
+        shareTokenRepo = opts.ShareTokenRepo
     }
 
     // 各リポジトリがまだnilの場合、デフォルトのリポジトリを使用
@@ -100,6 +178,62 @@ func SetupGraphQLServer(db *gorm.DB, opts *SetupOptions) http.Handler {
         fundPriceRepo = repoFundPrice.NewFetchFundRepository(db)
     }
 
+    if instrumentRepo == nil {
+        instrumentRepo = repoInstrument.NewInstrumentRepository(db)
+    }
+
+    if priceHistoryRepo == nil {
+        priceHistoryRepo = repoPriceHistory.NewPriceHistoryRepository(db)
+    }
+
+    if calendarTokenRepo == nil {
+        calendarTokenRepo = repoCalendar.NewCalendarTokenRepository(db)
+    }
+
+    if watchlistRepo == nil {
+        watchlistRepo = repoWatchlist.NewWatchlistRepository(db)
+    }
+
+    if alertRepo == nil {
+        alertRepo = repoAlert.NewAlertRepository(db)
+    }
+
+    if notificationRepo == nil {
+        notificationRepo = repoNotification.NewNotificationRepository(db)
+    }
+
+    if dailyReportRepo == nil {
+        dailyReportRepo = repoDailyReport.NewDailyReportRepository(db)
+    }
+
+    if holdingImportRepo == nil {
+        holdingImportRepo = repoHoldingImport.NewHoldingImportRepository(db)
+    }
+
+    if taxReportRepo == nil {
+        taxReportRepo = repoTaxReport.NewTaxReportRepository(db)
+    }
+
+    if accountRepo == nil {
+        accountRepo = repoAccount.NewAccountRepository(db)
+    }
+
+    if deletedHoldingRepo == nil {
+        deletedHoldingRepo = repoDeletedHolding.NewDeletedHoldingRepository(db)
+    }
+    if brokerageAccountRepo == nil {
+        brokerageAccountRepo = repoBrokerageAccount.NewBrokerageAccountRepository(db)
+    }
+    if nisaRepo == nil {
+        nisaRepo = repoNisa.NewNisaRepository(db)
+    }
+    if householdRepo == nil {
+        householdRepo = repoHousehold.NewHouseholdRepository(db)
+    }
+    if shareTokenRepo == nil {
+        shareTokenRepo = repoShareToken.NewShareTokenRepository(db)
+    }
+
     if marketPriceRepo == nil {
         // 注意: ここでは opts が nil の可能性があるため、opts.MockHTTPClient の前に nil チェックが必要
         var httpClient *http.Client
@@ -127,32 +261,85 @@ func SetupGraphQLServer(db *gorm.DB, opts *SetupOptions) http.Handler {
     // 認証サービスの初期化
     authService := authService.NewAuthService(userRepo, userLogic, responseLogic, jwtLogic, authValidation)
 
+    // 世帯メンバーの保有資産へのアクセス制御
+    householdAccess := commonHousehold.NewHouseholdAccess(householdRepo)
+
     // サービスとリゾルバの初期化
     currencyService := serviceCurrency.NewCurrencyService(currencyRepo)
     currencyResolver := serviceCurrency.NewResolver(currencyService)
     userService := serviceUser.NewUserService(userRepo, authService)
     userResolver := serviceUser.NewResolver(userService)
-    marketPriceService := serviceMarketPrice.NewMarketPriceService(marketPriceRepo)
+    marketPriceService := serviceMarketPrice.NewMarketPriceService(marketPriceRepo, priceHistoryRepo)
     marketPriceResolver := serviceMarketPrice.NewResolver(marketPriceService)
 
-    usStockService := serviceStock.NewUsStockService(usStockRepo, authService,marketPriceRepo)
+    usStockService := serviceStock.NewUsStockService(usStockRepo, authService,marketPriceRepo, instrumentRepo, householdAccess)
     usStockResolver := serviceStock.NewResolver(usStockService)
 
-    cryptoService := crypto.NewCryptoService(cryptoRepo, authService, marketCryptoRepo)
+    cryptoService := crypto.NewCryptoService(cryptoRepo, authService, marketCryptoRepo, householdAccess)
     cryptoResolver := crypto.NewResolver(cryptoService)
 
-    fixedIncomeAssetService := serviceFixedIncomeAsset.NewAssetService(fixedIncomeAssetRepo, authService)
+    fixedIncomeAssetService := serviceFixedIncomeAsset.NewAssetService(fixedIncomeAssetRepo, authService, householdAccess, currencyRepo)
     fixedIncomeAssetResolver := serviceFixedIncomeAsset.NewResolver(fixedIncomeAssetService)
 
-    japanFundService := serviceJapanFund.NewJapanFundService(japanFundRepo, authService, fundPriceRepo)
+    japanFundService := serviceJapanFund.NewJapanFundService(japanFundRepo, authService, fundPriceRepo, householdAccess)
     japanFundResolver := serviceJapanFund.NewResolver(japanFundService)
 
-    totalAssetService := serviceTotalAsset.NewTotalAssetService(authService,totalAssetRepo, usStockRepo, marketPriceRepo, currencyRepo, japanFundRepo, cryptoRepo, fixedIncomeAssetRepo, marketCryptoRepo, fundPriceRepo)
+    totalAssetService := serviceTotalAsset.NewTotalAssetService(authService,totalAssetRepo, usStockRepo, marketPriceRepo, currencyRepo, japanFundRepo, cryptoRepo, fixedIncomeAssetRepo, marketCryptoRepo, fundPriceRepo, brokerageAccountRepo, householdAccess)
     totalAssetResolver := serviceTotalAsset.NewResolver(totalAssetService)
+
+    eventService := serviceEvent.NewEventService(authService, usStockRepo, marketPriceRepo, calendarTokenRepo)
+    eventResolver := serviceEvent.NewResolver(eventService)
+
+    watchlistService := serviceWatchlist.NewWatchlistService(watchlistRepo, authService, marketPriceRepo, usStockService)
+    watchlistResolver := serviceWatchlist.NewResolver(watchlistService)
+
+    alertService := serviceAlert.NewAlertService(alertRepo, authService)
+    alertResolver := serviceAlert.NewResolver(alertService)
+
+    notificationService := serviceNotification.NewNotificationService(notificationRepo, authService)
+    notificationResolver := serviceNotification.NewResolver(notificationService)
+
+    dailyReportService := serviceDailyReport.NewDailyReportService(dailyReportRepo, authService)
+    dailyReportResolver := serviceDailyReport.NewResolver(dailyReportService)
+
+    // テストではポーリング間隔を短くする
+    pricePollInterval := 100 * time.Millisecond
+    if opts != nil && opts.PricePollInterval != 0 {
+        pricePollInterval = opts.PricePollInterval
+    }
+    pricePoller := serviceSubscription.NewPricePoller(marketPriceRepo, currencyRepo, pricePollInterval)
+    subscriptionService := serviceSubscription.NewSubscriptionService(pricePoller, usStockRepo, totalAssetRepo, authService)
+    subscriptionResolver := serviceSubscription.NewResolver(subscriptionService)
+
+    holdingImportService := serviceHoldingImport.NewHoldingImportService(holdingImportRepo, usStockRepo, japanFundRepo, cryptoRepo, fundPriceRepo, instrumentRepo, marketPriceRepo, currencyRepo, brokerageAccountRepo, authService)
+    holdingImportResolver := serviceHoldingImport.NewResolver(holdingImportService)
+
+    taxReportService := serviceTaxReport.NewTaxReportService(taxReportRepo, usStockRepo, marketPriceRepo, authService)
+    taxReportResolver := serviceTaxReport.NewResolver(taxReportService)
+
+    accountService := serviceAccount.NewAccountService(userRepo, accountRepo, authService)
+    accountResolver := serviceAccount.NewResolver(accountService)
+
+    deletedHoldingService := serviceDeletedHolding.NewDeletedHoldingService(deletedHoldingRepo, authService, housekeeping.RetentionFromEnv())
+    deletedHoldingResolver := serviceDeletedHolding.NewResolver(deletedHoldingService)
+
+    brokerageAccountService := serviceBrokerageAccount.NewBrokerageAccountService(brokerageAccountRepo, authService)
+    brokerageAccountResolver := serviceBrokerageAccount.NewResolver(brokerageAccountService)
+
+    nisaService := serviceNisa.NewNisaService(nisaRepo, authService)
+    nisaResolver := serviceNisa.NewResolver(nisaService)
+
+    householdService := serviceHousehold.NewHouseholdService(householdRepo, userRepo, authService)
+    householdResolver := serviceHousehold.NewResolver(householdService)
+
+    shareTokenService := serviceShareToken.NewShareTokenService(shareTokenRepo, authService)
+    shareTokenResolver := serviceShareToken.NewResolver(shareTokenService)
     // Ginのルーターを初期化
     r := gin.Default()
     // GraphQLのエンドポイントを設定
-    r.POST("/graphql", graphql.GinContextToGraphQLMiddleware(), graphql.Handler(userResolver, currencyResolver, marketPriceResolver,usStockResolver, cryptoResolver,fixedIncomeAssetResolver,japanFundResolver,totalAssetResolver))
+    graphQLHandler := graphql.Handler(userResolver, currencyResolver, marketPriceResolver,usStockResolver, cryptoResolver,fixedIncomeAssetResolver,japanFundResolver,totalAssetResolver,eventResolver,watchlistResolver,alertResolver,notificationResolver,dailyReportResolver,subscriptionResolver,holdingImportResolver,taxReportResolver,accountResolver,deletedHoldingResolver,brokerageAccountResolver,nisaResolver,householdResolver,shareTokenResolver)
+    r.POST("/graphql", graphql.GinContextToGraphQLMiddleware(), graphQLHandler)
+    r.GET("/graphql", graphql.GinContextToGraphQLMiddleware(), graphql.WebsocketOrPlaygroundHandler(graphQLHandler))
 
     return r
 }
\ No newline at end of file
@@ -0,0 +1,67 @@
+package sharetoken
+
+import (
+	"encoding/json"
+	"fmt"
+	"my-us-stock-backend/app/database/model"
+	repoShareToken "my-us-stock-backend/app/repository/share-token"
+	"my-us-stock-backend/test"
+	"my-us-stock-backend/test/graphql"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShareTokensE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := graphql.SetupGraphQLServer(db, nil)
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    userId := uint(97)
+    token, err := graphql.GenerateTestAccessTokenForUserId(userId)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+
+    // 共有リンクを作成する
+    w := graphql.ExecuteGraphQLRequestWithToken(ts.URL, `mutation { createShareToken(input: {privacy: PERCENTAGE, expiresInDays: 30}) { id token privacy expired } }`, token)
+    var createResponse struct {
+        Data struct {
+            CreateShareToken struct {
+                ID      string `json:"id"`
+                Token   string `json:"token"`
+                Privacy string `json:"privacy"`
+                Expired bool   `json:"expired"`
+            } `json:"createShareToken"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &createResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    created := createResponse.Data.CreateShareToken
+    assert.Len(t, created.Token, 64)
+    assert.Equal(t, "PERCENTAGE", created.Privacy)
+    assert.False(t, created.Expired)
+
+    // トークンはハッシュのみ保存し、一覧では返さない
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, `query { shareTokens { id token } }`, token)
+    assert.JSONEq(t, fmt.Sprintf(`{"data": {"shareTokens": [{"id": "%s", "token": null}]}}`, created.ID), w.Body.String())
+    var stored model.ShareToken
+    db.Where("user_id = ?", userId).First(&stored)
+    assert.NotEqual(t, created.Token, stored.TokenHash)
+    assert.Equal(t, repoShareToken.HashToken(created.Token), stored.TokenHash)
+
+    // 有効日数の範囲外はエラー
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, `mutation { createShareToken(input: {privacy: AMOUNT, expiresInDays: 400}) { id } }`, token)
+    assert.Contains(t, w.Body.String(), "有効日数は1日から365日の範囲で指定してください")
+
+    // 共有リンクを取り消す
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, fmt.Sprintf(`mutation { revokeShareToken(id: "%s") }`, created.ID), token)
+    assert.JSONEq(t, `{"data": {"revokeShareToken": true}}`, w.Body.String())
+    var count int64
+    db.Model(&model.ShareToken{}).Where("user_id = ?", userId).Count(&count)
+    assert.Equal(t, int64(0), count)
+}
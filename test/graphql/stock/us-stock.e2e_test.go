@@ -111,6 +111,18 @@ func TestUsStocksE2E(t *testing.T) {
 	}
 	`
 
+	mockProfile := `[
+		{
+			"symbol": "AAPL",
+			"companyName": "Apple Inc.",
+			"sector": "Technology",
+			"industry": "Consumer Electronics",
+			"country": "US",
+			"exchange": "NASDAQ Global Select",
+			"exchangeShortName": "NASDAQ"
+		}
+	]`
+
 	// モックのHTTPクライアント設定
 	mockTransport := &MockHTTPTransport{
 		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
@@ -121,6 +133,8 @@ func TestUsStocksE2E(t *testing.T) {
 				responseBody = mockStockPrice
 			} else if req.URL.Path == "/v3/historical-price-full/stock_dividend/AAPL" {
 				responseBody = mockDividend
+			} else if req.URL.Path == "/v3/profile/AAPL" {
+				responseBody = mockProfile
 			}
 	
 			r := io.NopCloser(bytes.NewReader([]byte(responseBody)))
@@ -156,7 +170,7 @@ func TestUsStocksE2E(t *testing.T) {
 
 	// GraphQLリクエストの実行
 	query := `query {
-		usStocks{ id code getPrice sector dividend quantity usdJpy currentPrice priceGets currentRate }
+		usStocks{ id code name getPrice sector dividend quantity usdJpy currentPrice priceGets currentRate yearHigh pe earningsAnnouncement }
 	  }`
 	  w := graphql.ExecuteGraphQLRequestWithToken(ts.URL, query, token)
 
@@ -166,6 +180,7 @@ func TestUsStocksE2E(t *testing.T) {
             UsStocks []struct {
                 ID string `json:"id"`
 				Code string `json:"code"`
+				Name string `json:"name"`
 				GetPrice float64 `json:"getPrice"`
 				Sector string `json:"sector"`
 				Dividend float64 `json:"dividend"`
@@ -174,6 +189,9 @@ func TestUsStocksE2E(t *testing.T) {
                 CurrentPrice float64 `json:"currentPrice"`
                 PriceGets float64 `json:"priceGets"`
                 CurrentRate float64 `json:"currentRate"`
+                YearHigh float64 `json:"yearHigh"`
+                Pe float64 `json:"pe"`
+                EarningsAnnouncement *string `json:"earningsAnnouncement"`
             } `json:"usStocks"`
         } `json:"data"`
     }
@@ -186,6 +204,7 @@ func TestUsStocksE2E(t *testing.T) {
     // レスポンスボディの内容の検証
     if len(response.Data.UsStocks) > 0 {
         assert.Equal(t, "AAPL", response.Data.UsStocks[0].Code)
+		assert.Equal(t, "Apple Inc.", response.Data.UsStocks[0].Name)
 		assert.Equal(t, 100.0, response.Data.UsStocks[0].GetPrice)
 		assert.Equal(t, "IT", response.Data.UsStocks[0].Sector)
 		assert.Equal(t, 0.95, response.Data.UsStocks[0].Dividend)
@@ -194,6 +213,9 @@ func TestUsStocksE2E(t *testing.T) {
 		assert.Equal(t, 193.94, response.Data.UsStocks[0].CurrentPrice)
 		assert.Equal(t, 0.79, response.Data.UsStocks[0].PriceGets)
 		assert.Equal(t, 0.409, response.Data.UsStocks[0].CurrentRate)
+		assert.Equal(t, 199.62, response.Data.UsStocks[0].YearHigh)
+		assert.Equal(t, 31.59, response.Data.UsStocks[0].Pe)
+		assert.Equal(t, "2024-01-31T10:59:00.000+0000", *response.Data.UsStocks[0].EarningsAnnouncement)
     } else {
         t.Fatalf("Expected non-empty MarketPrice array")
     }
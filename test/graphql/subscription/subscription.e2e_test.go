@@ -0,0 +1,187 @@
+package subscription
+
+import (
+	"encoding/json"
+	"my-us-stock-backend/app/database/model"
+	repoMarketPrice "my-us-stock-backend/app/repository/market-price"
+	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
+	"my-us-stock-backend/test"
+	"my-us-stock-backend/test/graphql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// graphql-transport-ws プロトコルのメッセージ
+type wsMessage struct {
+    ID      string          `json:"id,omitempty"`
+    Type    string          `json:"type"`
+    Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// access_token クッキーを付けてWebSocketで接続し、Subscriptionを開始する
+func subscribe(t *testing.T, serverURL string, token string, query string) *websocket.Conn {
+    header := http.Header{}
+    header.Set("Cookie", "access_token="+token)
+    dialer := websocket.Dialer{Subprotocols: []string{"graphql-transport-ws"}}
+    conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(serverURL, "http")+"/graphql", header)
+    if err != nil {
+        t.Fatalf("Failed to connect websocket: %v", err)
+    }
+    assert.NoError(t, conn.WriteJSON(wsMessage{Type: "connection_init"}))
+    var ack wsMessage
+    assert.NoError(t, conn.ReadJSON(&ack))
+    assert.Equal(t, "connection_ack", ack.Type)
+
+    payload, _ := json.Marshal(map[string]string{"query": query})
+    assert.NoError(t, conn.WriteJSON(wsMessage{ID: "1", Type: "subscribe", Payload: payload}))
+    return conn
+}
+
+// キープアライブ以外の次のメッセージを読む
+func readMessage(t *testing.T, conn *websocket.Conn) wsMessage {
+    conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+    for {
+        var message wsMessage
+        if err := conn.ReadJSON(&message); err != nil {
+            t.Fatalf("Failed to read websocket message: %v", err)
+        }
+        if message.Type != "ping" && message.Type != "pong" {
+            return message
+        }
+    }
+}
+
+func TestSubscriptionE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    mockMarketPriceRepo := repoMarketPrice.NewMockMarketPriceRepository()
+    mockCurrencyRepo := repoCurrency.NewMockCurrencyRepository()
+    mockMarketPriceRepo.On("FetchMarketPriceList", mock.Anything, mock.Anything).Return([]repoMarketPrice.MarketPriceDto{
+        {Ticker: "AAPL", CurrentPrice: 180, PriceGets: 2, CurrentRate: 1.12},
+        {Ticker: "KO", CurrentPrice: 58, PriceGets: -0.5, CurrentRate: -0.85},
+    }, nil)
+    mockCurrencyRepo.On("FetchCurrentUsdJpy", mock.Anything).Return(150.0, nil)
+    router := graphql.SetupGraphQLServer(db, &graphql.SetupOptions{
+        MarketPriceRepo:   mockMarketPriceRepo,
+        CurrencyRepo:      mockCurrencyRepo,
+        PricePollInterval: 50 * time.Millisecond,
+    })
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    userId := uint(81)
+    db.Create(&model.UsStock{Code: "AAPL", GetPrice: 150, Quantity: 10, Sector: "Technology", UsdJpy: 140, UserId: userId})
+    db.Create(&model.TotalAsset{CashJpy: 100000, CashUsd: 1000, Fund: 300000, UserId: userId})
+    token, err := graphql.GenerateTestAccessTokenForUserId(userId)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+
+    // 資産評価額
+    portfolioConn := subscribe(t, ts.URL, token, `subscription { portfolioValue { totalAmount stockAmount stockDayChange otherAmount usdJpy updatedAt } }`)
+    defer portfolioConn.Close()
+    message := readMessage(t, portfolioConn)
+    assert.Equal(t, "next", message.Type)
+    var portfolioPayload struct {
+        Data struct {
+            PortfolioValue struct {
+                TotalAmount    float64 `json:"totalAmount"`
+                StockAmount    float64 `json:"stockAmount"`
+                StockDayChange float64 `json:"stockDayChange"`
+                OtherAmount    float64 `json:"otherAmount"`
+                UsdJpy         float64 `json:"usdJpy"`
+            } `json:"portfolioValue"`
+        } `json:"data"`
+    }
+    assert.NoError(t, json.Unmarshal(message.Payload, &portfolioPayload))
+    assert.Equal(t, 270000.0, portfolioPayload.Data.PortfolioValue.StockAmount)
+    assert.Equal(t, 3000.0, portfolioPayload.Data.PortfolioValue.StockDayChange)
+    assert.Equal(t, 400000.0, portfolioPayload.Data.PortfolioValue.OtherAmount)
+    assert.Equal(t, 820000.0, portfolioPayload.Data.PortfolioValue.TotalAmount)
+
+    // 価格(複数の接続で同じポーリングを共有する)
+    quotesConns := []*websocket.Conn{}
+    for i := 0; i < 3; i++ {
+        conn := subscribe(t, ts.URL, token, `subscription { quotes(tickers: ["ko", "AAPL"]) { ticker currentPrice currentRate } }`)
+        defer conn.Close()
+        quotesConns = append(quotesConns, conn)
+    }
+    for _, conn := range quotesConns {
+        message := readMessage(t, conn)
+        assert.Equal(t, "next", message.Type)
+        var quotesPayload struct {
+            Data struct {
+                Quotes []struct {
+                    Ticker       string  `json:"ticker"`
+                    CurrentPrice float64 `json:"currentPrice"`
+                } `json:"quotes"`
+            } `json:"data"`
+        }
+        assert.NoError(t, json.Unmarshal(message.Payload, &quotesPayload))
+        assert.Len(t, quotesPayload.Data.Quotes, 2)
+        assert.Equal(t, "KO", quotesPayload.Data.Quotes[0].Ticker)
+        assert.Equal(t, 180.0, quotesPayload.Data.Quotes[1].CurrentPrice)
+    }
+}
+
+// クッキーがない場合は購読できない
+func TestSubscriptionUnauthenticatedE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := graphql.SetupGraphQLServer(db, &graphql.SetupOptions{
+        MarketPriceRepo: repoMarketPrice.NewMockMarketPriceRepository(),
+        CurrencyRepo:    repoCurrency.NewMockCurrencyRepository(),
+    })
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    conn := subscribe(t, ts.URL, "", `subscription { quotes(tickers: ["AAPL"]) { ticker } }`)
+    defer conn.Close()
+
+    message := readMessage(t, conn)
+    var payload struct {
+        Errors []struct {
+            Message string `json:"message"`
+        } `json:"errors"`
+        Data interface{} `json:"data"`
+    }
+    assert.NoError(t, json.Unmarshal(message.Payload, &payload))
+    assert.NotEmpty(t, payload.Errors)
+    assert.Nil(t, payload.Data)
+    assert.Equal(t, "complete", readMessage(t, conn).Type)
+}
+
+// 許可していないオリジンからの接続は拒否する
+func TestSubscriptionOriginE2E(t *testing.T) {
+    t.Setenv("CLIENT_URL", "http://localhost:3000")
+    db := test.SetupTestDB()
+    router := graphql.SetupGraphQLServer(db, nil)
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    dialer := websocket.Dialer{Subprotocols: []string{"graphql-transport-ws"}}
+    serverURL := "ws" + strings.TrimPrefix(ts.URL, "http") + "/graphql"
+
+    header := http.Header{}
+    header.Set("Origin", "http://evil.example.com")
+    _, resp, err := dialer.Dial(serverURL, header)
+    assert.Error(t, err)
+    if resp != nil {
+        assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+    }
+
+    header.Set("Origin", "http://localhost:3000")
+    conn, _, err := dialer.Dial(serverURL, header)
+    assert.NoError(t, err)
+    if conn != nil {
+        conn.Close()
+    }
+}
@@ -0,0 +1,151 @@
+package taxreport
+
+import (
+	"encoding/json"
+	"fmt"
+	"my-us-stock-backend/app/database/model"
+	"my-us-stock-backend/test"
+	"my-us-stock-backend/test/graphql"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnualTaxReportE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := graphql.SetupGraphQLServer(db, nil)
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    userId := uint(84)
+    token, err := graphql.GenerateTestAccessTokenForUserId(userId)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+    db.Create(&model.UsStock{Code: "AAPL", Quantity: 20, GetPrice: 150, UsdJpy: 130, Sector: "Technology", UserId: userId})
+
+    // 外国源泉徴収税額・国内源泉徴収税額は省略して計算させる
+    createDividendQuery := `mutation {
+        createDividendReceipt(input: {code: "ko", paymentDate: "2024-04-01", amount: 46, usdJpy: 150.25}) {
+          id code amount foreignTax domesticTax amountJpy foreignTaxJpy
+        }
+      }`
+    w := graphql.ExecuteGraphQLRequestWithToken(ts.URL, createDividendQuery, token)
+    var dividendResponse struct {
+        Data struct {
+            CreateDividendReceipt struct {
+                ID            string  `json:"id"`
+                Code          string  `json:"code"`
+                ForeignTax    float64 `json:"foreignTax"`
+                DomesticTax   float64 `json:"domesticTax"`
+                AmountJpy     float64 `json:"amountJpy"`
+                ForeignTaxJpy float64 `json:"foreignTaxJpy"`
+            } `json:"createDividendReceipt"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &dividendResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    dividend := dividendResponse.Data.CreateDividendReceipt
+    assert.Equal(t, "KO", dividend.Code)
+    assert.InDelta(t, 4.6, dividend.ForeignTax, 1e-9)
+    assert.Equal(t, 6911.0, dividend.AmountJpy)
+    assert.Equal(t, 691.0, dividend.ForeignTaxJpy)
+    // (6911 - 691) × 15.315% = 952, × 5% = 311
+    assert.Equal(t, 1263.0, dividend.DomesticTax)
+
+    // 取得単価・取得時のドル円は保有している米国株から補完する
+    createSaleQuery := `mutation {
+        createStockSale(input: {code: "AAPL", tradeDate: "2024-06-03", quantity: 10, salePrice: 190, fee: 2, usdJpy: 150}) {
+          id getPrice getUsdJpy proceeds cost feeJpy realizedGain
+        }
+      }`
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, createSaleQuery, token)
+    var saleResponse struct {
+        Data struct {
+            CreateStockSale struct {
+                ID           string  `json:"id"`
+                GetPrice     float64 `json:"getPrice"`
+                GetUsdJpy    float64 `json:"getUsdJpy"`
+                RealizedGain float64 `json:"realizedGain"`
+            } `json:"createStockSale"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &saleResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    sale := saleResponse.Data.CreateStockSale
+    assert.Equal(t, 150.0, sale.GetPrice)
+    assert.Equal(t, 130.0, sale.GetUsdJpy)
+    assert.Equal(t, 89700.0, sale.RealizedGain)
+
+    // 別の年の記録は集計に含まれない
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, `mutation {
+        createDividendReceipt(input: {code: "KO", paymentDate: "2023-12-15", amount: 46, usdJpy: 142}) { id }
+      }`, token)
+    assert.Equal(t, 200, w.Code)
+
+    reportQuery := `query {
+        annualTaxReport(year: 2024) {
+          year dividendTotal foreignTaxTotal domesticTaxTotal proceedsTotal costTotal feeTotal realizedGainTotal
+          securities { code dividendAmount realizedGain dividends { id } sales { id } }
+        }
+      }`
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, reportQuery, token)
+    var reportResponse struct {
+        Data struct {
+            AnnualTaxReport struct {
+                Year              int     `json:"year"`
+                DividendTotal     float64 `json:"dividendTotal"`
+                ForeignTaxTotal   float64 `json:"foreignTaxTotal"`
+                DomesticTaxTotal  float64 `json:"domesticTaxTotal"`
+                ProceedsTotal     float64 `json:"proceedsTotal"`
+                CostTotal         float64 `json:"costTotal"`
+                FeeTotal          float64 `json:"feeTotal"`
+                RealizedGainTotal float64 `json:"realizedGainTotal"`
+                Securities        []struct {
+                    Code           string  `json:"code"`
+                    DividendAmount float64 `json:"dividendAmount"`
+                    RealizedGain   float64 `json:"realizedGain"`
+                    Dividends      []struct {
+                        ID string `json:"id"`
+                    } `json:"dividends"`
+                    Sales []struct {
+                        ID string `json:"id"`
+                    } `json:"sales"`
+                } `json:"securities"`
+            } `json:"annualTaxReport"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &reportResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    report := reportResponse.Data.AnnualTaxReport
+    assert.Equal(t, 2024, report.Year)
+    assert.Equal(t, 6911.0, report.DividendTotal)
+    assert.Equal(t, 691.0, report.ForeignTaxTotal)
+    assert.Equal(t, 1263.0, report.DomesticTaxTotal)
+    assert.Equal(t, 285000.0, report.ProceedsTotal)
+    assert.Equal(t, 195000.0, report.CostTotal)
+    assert.Equal(t, 300.0, report.FeeTotal)
+    assert.Equal(t, 89700.0, report.RealizedGainTotal)
+    assert.Len(t, report.Securities, 2)
+    assert.Equal(t, "AAPL", report.Securities[0].Code)
+    assert.Len(t, report.Securities[0].Sales, 1)
+    assert.Equal(t, "KO", report.Securities[1].Code)
+    assert.Len(t, report.Securities[1].Dividends, 1)
+
+    // 売却記録を削除すると集計から外れる
+    deleteQuery := fmt.Sprintf(`mutation { deleteStockSale(id: "%s") }`, sale.ID)
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, deleteQuery, token)
+    assert.Contains(t, w.Body.String(), `"deleteStockSale":true`)
+
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, reportQuery, token)
+    if err := json.Unmarshal(w.Body.Bytes(), &reportResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    assert.Len(t, reportResponse.Data.AnnualTaxReport.Securities, 1)
+    assert.Equal(t, 0.0, reportResponse.Data.AnnualTaxReport.RealizedGainTotal)
+}
@@ -0,0 +1,155 @@
+package watchlist
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"my-us-stock-backend/app/database/model"
+	repoMarketPrice "my-us-stock-backend/app/repository/market-price"
+	"my-us-stock-backend/test"
+	"my-us-stock-backend/test/graphql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// MockHTTPTransport は http.RoundTripper のインターフェースを満たすモック実装です。
+type MockHTTPTransport struct {
+    RoundTripFunc func(req *http.Request) (*http.Response, error)
+}
+
+// RoundTrip は http.RoundTripper の RoundTrip メソッドを模倣します。
+func (m *MockHTTPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+    return m.RoundTripFunc(req)
+}
+
+func TestWatchlistE2E(t *testing.T) {
+    db := test.SetupTestDB()
+
+    mockStockPrice := `[{"symbol": "PG", "price": 150.0, "changesPercentage": 0.5, "change": 0.75, "yearHigh": 158.11, "yearLow": 141.45, "pe": 24.6}]`
+    mockDividend := `{"symbol": "PG", "historical": []}`
+    mockProfile := `[{"symbol": "PG", "companyName": "The Procter & Gamble Company", "sector": "Consumer Defensive", "industry": "Household & Personal Products", "country": "US", "exchange": "New York Stock Exchange", "exchangeShortName": "NYSE"}]`
+    mockTransport := &MockHTTPTransport{
+        RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+            var responseBody string
+            if req.URL.Path == "/v3/quote-order/PG" {
+                responseBody = mockStockPrice
+            } else if req.URL.Path == "/v3/historical-price-full/stock_dividend/PG" {
+                responseBody = mockDividend
+            } else if req.URL.Path == "/v3/profile/PG" {
+                responseBody = mockProfile
+            }
+            r := io.NopCloser(bytes.NewReader([]byte(responseBody)))
+            return &http.Response{
+                StatusCode: http.StatusOK,
+                Body:       r,
+            }, nil
+        },
+    }
+    mockHTTPClient := &http.Client{Transport: mockTransport}
+    opts := &graphql.SetupOptions{
+        MockHTTPClient: mockHTTPClient,
+        MarketPriceRepo: repoMarketPrice.NewMarketPriceRepository(mockHTTPClient),
+    }
+    router := graphql.SetupGraphQLServer(db, opts)
+
+    ts := httptest.NewServer(router)
+    defer ts.Close()
+
+    token, err := graphql.GenerateTestAccessTokenForUserId(50)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+
+    // ウォッチリストに追加
+    createQuery := `mutation {
+        createWatchlist(input: {ticker: "PG", note: "押し目待ち", targetPrice: 155.0}) {
+          id ticker note targetPrice targetYield currentPrice yearHigh pe dividend reachedTargetPrice reachedTargetYield
+        }
+      }`
+    w := graphql.ExecuteGraphQLRequestWithToken(ts.URL, createQuery, token)
+
+    type watchlistResponse struct {
+        ID                 string   `json:"id"`
+        Ticker             string   `json:"ticker"`
+        Note               string   `json:"note"`
+        TargetPrice        *float64 `json:"targetPrice"`
+        TargetYield        *float64 `json:"targetYield"`
+        CurrentPrice       float64  `json:"currentPrice"`
+        YearHigh           float64  `json:"yearHigh"`
+        Pe                 float64  `json:"pe"`
+        Dividend           float64  `json:"dividend"`
+        ReachedTargetPrice bool     `json:"reachedTargetPrice"`
+        ReachedTargetYield bool     `json:"reachedTargetYield"`
+    }
+    var createResponse struct {
+        Data struct {
+            CreateWatchlist watchlistResponse `json:"createWatchlist"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &createResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    created := createResponse.Data.CreateWatchlist
+    assert.Equal(t, "PG", created.Ticker)
+    assert.Equal(t, "押し目待ち", created.Note)
+    assert.Equal(t, 155.0, *created.TargetPrice)
+    assert.Nil(t, created.TargetYield)
+    assert.Equal(t, 150.0, created.CurrentPrice)
+    assert.Equal(t, 158.11, created.YearHigh)
+    assert.True(t, created.ReachedTargetPrice)
+    assert.False(t, created.ReachedTargetYield)
+
+    // 一覧取得
+    listQuery := `query { watchlists { id ticker note currentPrice } }`
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, listQuery, token)
+    var listResponse struct {
+        Data struct {
+            Watchlists []watchlistResponse `json:"watchlists"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &listResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    assert.Len(t, listResponse.Data.Watchlists, 1)
+
+    // 指定した口座の保有銘柄に変換
+    account := model.BrokerageAccount{Name: "SBI NISA", Type: "NISA_GROWTH", UserId: 50}
+    db.Create(&account)
+    convertQuery := fmt.Sprintf(`mutation {
+        convertWatchlistToUsStock(input: {id: "%s", getPrice: 149.5, quantity: 3, usdJpy: 148.2, accountId: "%d"}) {
+          code name sector getPrice quantity usdJpy
+        }
+      }`, created.ID, account.ID)
+    w = graphql.ExecuteGraphQLRequestWithToken(ts.URL, convertQuery, token)
+    var convertResponse struct {
+        Data struct {
+            ConvertWatchlistToUsStock struct {
+                Code     string  `json:"code"`
+                Name     string  `json:"name"`
+                Sector   string  `json:"sector"`
+                GetPrice float64 `json:"getPrice"`
+                Quantity float64 `json:"quantity"`
+                UsdJpy   float64 `json:"usdJpy"`
+            } `json:"convertWatchlistToUsStock"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(w.Body.Bytes(), &convertResponse); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    converted := convertResponse.Data.ConvertWatchlistToUsStock
+    assert.Equal(t, "PG", converted.Code)
+    assert.Equal(t, "The Procter & Gamble Company", converted.Name)
+    assert.Equal(t, "Consumer Defensive", converted.Sector)
+    assert.Equal(t, 3.0, converted.Quantity)
+
+    // ウォッチリストから削除され、保有銘柄に登録されている
+    var watchlistCount, usStockCount int64
+    db.Model(&model.Watchlist{}).Where("user_id = ?", 50).Count(&watchlistCount)
+    db.Model(&model.UsStock{}).Where("user_id = ? AND code = ? AND account_id = ?", 50, "PG", account.ID).Count(&usStockCount)
+    assert.Equal(t, int64(0), watchlistCount)
+    assert.Equal(t, int64(1), usStockCount)
+}
@@ -2,6 +2,7 @@ package admin_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	Repo "my-us-stock-backend/app/repository/market-price/fund"
 	"my-us-stock-backend/app/rest/admin"
@@ -9,6 +10,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -87,3 +89,34 @@ func TestUpdateFundPriceE2E(t *testing.T) {
     // Further assertions to verify the response body can be added here
 }
 
+
+// 基準価額の自動取得に失敗した投資信託(価格が未登録のものを含む)のみを返す
+func TestGetNavFetchFailedFundPricesE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    controller := setupFundPriceController(db)
+
+    repo := Repo.NewFetchFundRepository(db)
+    repo.CreateFundPrice(context.Background(), Repo.CreateFundPriceDto{Name: "Nav OK Fund", Code: "NAVOK01", Price: 10000})
+    repo.CreateFundPrice(context.Background(), Repo.CreateFundPriceDto{Name: "Nav Failed Fund", Code: "NAVNG01", Price: 12000})
+    repo.RecordFundNavFailure(context.Background(), "NAVNG01", "unexpected status code: 404", time.Now())
+    repo.RecordFundNavFailure(context.Background(), "UNREG01", "unexpected status code: 404", time.Now())
+
+    router := gin.Default()
+    router.GET("/api/v1/admin/fund-prices/nav-failures", controller.GetNavFetchFailedFundPrices)
+
+    req, _ := http.NewRequest("GET", "/api/v1/admin/fund-prices/nav-failures", nil)
+    w := httptest.NewRecorder()
+    router.ServeHTTP(w, req)
+
+    assert.Equal(t, http.StatusOK, w.Code)
+    var fundPrices []map[string]interface{}
+    json.Unmarshal(w.Body.Bytes(), &fundPrices)
+    codes := []string{}
+    for _, fundPrice := range fundPrices {
+        codes = append(codes, fundPrice["Code"].(string))
+        assert.NotEmpty(t, fundPrice["NavFetchError"])
+    }
+    assert.Contains(t, codes, "NAVNG01")
+    assert.Contains(t, codes, "UNREG01")
+    assert.NotContains(t, codes, "NAVOK01")
+}
@@ -0,0 +1,127 @@
+package backup_test
+
+import (
+	"encoding/json"
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/common/auth/logic"
+	"my-us-stock-backend/app/database/model"
+	repoBackup "my-us-stock-backend/app/repository/backup"
+	repoUser "my-us-stock-backend/app/repository/user"
+	"my-us-stock-backend/app/rest/backup"
+	"my-us-stock-backend/test"
+	"my-us-stock-backend/test/graphql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// テスト用のバックアップコントローラをセットアップ
+func setupBackupRouter(db *gorm.DB) *gin.Engine {
+    authService := auth.NewAuthService(repoUser.NewUserRepository(db), logic.NewUserLogic(), logic.NewResponseLogic(), logic.NewJWTLogic(), auth.NewAuthValidation())
+    controller := backup.NewBackupController(backup.NewBackupService(authService, repoBackup.NewBackupRepository(db)))
+
+    router := gin.Default()
+    router.GET("/api/v1/backup", controller.GetBackup)
+    router.POST("/api/v1/backup/restore", controller.RestoreBackup)
+    return router
+}
+
+func TestBackupAndRestoreE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := setupBackupRouter(db)
+
+    sourceUserId, targetUserId := uint(84), uint(85)
+    db.Create(&model.User{Model: gorm.Model{ID: sourceUserId}, Name: "backup source", Email: "backup-source@example.com", Password: "hashed"})
+    db.Create(&model.User{Model: gorm.Model{ID: targetUserId}, Name: "", Email: "backup-target@example.com", Password: "hashed"})
+    account := model.BrokerageAccount{Name: "SBI 特定", Type: "TAXABLE", UserId: sourceUserId}
+    db.Create(&account)
+    db.Create(&model.UsStock{Code: "AAPL", GetPrice: 150, Quantity: 10, Sector: "Technology", UsdJpy: 140, AccountId: account.ID, UserId: sourceUserId})
+    db.Create(&model.Crypto{Code: "btc", GetPrice: 5000000, Quantity: 0.1, AccountId: account.ID, UserId: sourceUserId})
+    db.Create(&model.JapanFund{Code: "0331418A", Name: "eMAXIS Slim 米国株式(S&P500)", GetPrice: 20000, GetPriceTotal: 200000, AccountId: account.ID, UserId: sourceUserId})
+    db.Create(&model.FixedIncomeAsset{Code: "社債A", GetPriceTotal: 500000, DividendRate: 2.5, PaymentMonth: pq.Int64Array{6, 12}, AccountId: account.ID, UserId: sourceUserId})
+    db.Create(&model.TotalAsset{Model: gorm.Model{CreatedAt: time.Date(2024, 1, 10, 1, 0, 0, 0, time.UTC)}, CashJpy: 100000, UserId: sourceUserId})
+    db.Create(&model.DividendReceipt{Code: "AAPL", PaymentDate: "2024-02-15", Amount: 2.4, ForeignTax: 0.24, DomesticTax: 65, UsdJpy: 150, UserId: sourceUserId})
+    db.Create(&model.StockSale{Code: "KO", TradeDate: "2024-03-01", Quantity: 5, SalePrice: 60, GetPrice: 55, Fee: 1, UsdJpy: 150, GetUsdJpy: 140, UserId: sourceUserId})
+
+    request := func(method string, path string, body string, userId uint) *httptest.ResponseRecorder {
+        token, err := graphql.GenerateTestAccessTokenForUserId(userId)
+        if err != nil {
+            t.Fatalf("Failed to generate test access token: %v", err)
+        }
+        req, _ := http.NewRequest(method, path, strings.NewReader(body))
+        req.AddCookie(&http.Cookie{Name: "access_token", Value: token})
+        w := httptest.NewRecorder()
+        router.ServeHTTP(w, req)
+        return w
+    }
+
+    // バックアップを取得
+    w := request("GET", "/api/v1/backup", "", sourceUserId)
+    assert.Equal(t, http.StatusOK, w.Code)
+    backupData := w.Body.String()
+    assert.Contains(t, backupData, `"version": 2`)
+    assert.Contains(t, backupData, `"email": "backup-source@example.com"`)
+
+    // 空のアカウントに復元
+    w = request("POST", "/api/v1/backup/restore", backupData, targetUserId)
+    assert.Equal(t, http.StatusOK, w.Code)
+    var result backup.RestoreResult
+    if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+        t.Fatalf("Failed to parse response body: %v", err)
+    }
+    assert.Equal(t, 1, result.BrokerageAccounts.Count)
+    assert.Equal(t, 1, result.UsStocks.Count)
+    assert.Equal(t, 1, result.TotalAssets.Count)
+    assert.Equal(t, 1, result.DividendReceipts.Count)
+    assert.Equal(t, 1, result.StockSales.Count)
+
+    var targetAccount model.BrokerageAccount
+    assert.NoError(t, db.Where("user_id = ?", targetUserId).First(&targetAccount).Error)
+    assert.Equal(t, "SBI 特定", targetAccount.Name)
+    var usStocks []model.UsStock
+    db.Where("user_id = ?", targetUserId).Find(&usStocks)
+    assert.Len(t, usStocks, 1)
+    assert.Equal(t, "AAPL", usStocks[0].Code)
+    assert.Equal(t, targetAccount.ID, usStocks[0].AccountId)
+    for sourceId, restoredId := range result.UsStocks.Ids {
+        assert.NotEqual(t, sourceId, restoredId)
+        assert.Equal(t, usStocks[0].ID, restoredId)
+    }
+    var fixedIncomeAsset model.FixedIncomeAsset
+    db.Where("user_id = ?", targetUserId).First(&fixedIncomeAsset)
+    assert.Equal(t, pq.Int64Array{6, 12}, fixedIncomeAsset.PaymentMonth)
+    var totalAsset model.TotalAsset
+    db.Where("user_id = ?", targetUserId).First(&totalAsset)
+    assert.Equal(t, "2024-01-10", totalAsset.CreatedAt.UTC().Format("2006-01-02"))
+    // 確定申告の集計に使う配当の受取記録・売却記録も引き継がれる
+    var dividendReceipt model.DividendReceipt
+    assert.NoError(t, db.Where("user_id = ?", targetUserId).First(&dividendReceipt).Error)
+    assert.Equal(t, "2024-02-15", dividendReceipt.PaymentDate)
+    assert.Equal(t, 0.24, dividendReceipt.ForeignTax)
+    var stockSale model.StockSale
+    assert.NoError(t, db.Where("user_id = ?", targetUserId).First(&stockSale).Error)
+    assert.Equal(t, 5.0, stockSale.Quantity)
+    assert.Equal(t, 140.0, stockSale.GetUsdJpy)
+    var targetUser model.User
+    db.First(&targetUser, targetUserId)
+    assert.Equal(t, "backup source", targetUser.Name)
+    assert.Equal(t, "backup-target@example.com", targetUser.Email)
+
+    // 既にデータがあるアカウントには復元できない
+    w = request("POST", "/api/v1/backup/restore", backupData, targetUserId)
+    assert.Equal(t, http.StatusConflict, w.Code)
+    var count int64
+    db.Model(&model.UsStock{}).Where("user_id = ?", targetUserId).Count(&count)
+    assert.Equal(t, int64(1), count)
+
+    // 未対応のバージョン
+    w = request("POST", "/api/v1/backup/restore", `{"version": 99}`, targetUserId)
+    assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,89 @@
+package calendar_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"my-us-stock-backend/app/common/event"
+	"my-us-stock-backend/app/database/model"
+	repoStock "my-us-stock-backend/app/repository/assets/stock"
+	repoCalendar "my-us-stock-backend/app/repository/calendar"
+	repoMarketPrice "my-us-stock-backend/app/repository/market-price"
+	"my-us-stock-backend/app/rest/calendar"
+	"my-us-stock-backend/test"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// MockHTTPTransport は http.RoundTripper のインターフェースを満たすモック実装です。
+type MockHTTPTransport struct {
+    RoundTripFunc func(req *http.Request) (*http.Response, error)
+}
+
+// RoundTrip は http.RoundTripper の RoundTrip メソッドを模倣します。
+func (m *MockHTTPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+    return m.RoundTripFunc(req)
+}
+
+// テスト用のカレンダーコントローラをセットアップ
+func setupCalendarRouter(db *gorm.DB, client *http.Client) *gin.Engine {
+    calendarTokenRepo := repoCalendar.NewCalendarTokenRepository(db)
+    eventCollector := event.NewEventCollector(repoStock.NewUsStockRepository(db), repoMarketPrice.NewMarketPriceRepository(client))
+    controller := calendar.NewCalendarController(calendar.NewCalendarService(eventCollector, calendarTokenRepo))
+
+    router := gin.Default()
+    router.GET("/api/v1/calendar/events.ics", controller.GetCalendarFeed)
+    return router
+}
+
+func TestGetCalendarFeedE2E(t *testing.T) {
+    db := test.SetupTestDB()
+
+    earningsDate := time.Now().AddDate(0, 0, 20)
+    mockStockPrice := fmt.Sprintf(`[{"symbol": "MSFT", "price": 374.58, "earningsAnnouncement": "%sT21:00:00.000+0000"}]`, earningsDate.Format("2006-01-02"))
+    mockTransport := &MockHTTPTransport{
+        RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+            responseBody := `{"symbol": "MSFT", "historical": []}`
+            if req.URL.Path == "/v3/quote-order/MSFT" {
+                responseBody = mockStockPrice
+            }
+            r := io.NopCloser(bytes.NewReader([]byte(responseBody)))
+            return &http.Response{
+                StatusCode: http.StatusOK,
+                Body:       r,
+            }, nil
+        },
+    }
+    router := setupCalendarRouter(db, &http.Client{Transport: mockTransport})
+
+    db.Create(&model.User{Model: gorm.Model{ID: 40}, Name: "calendar", Email: "calendar-feed@example.com", Password: "hashed"})
+    db.Create(&model.UsStock{Code: "MSFT", UserId: 40, Quantity: 1, GetPrice: 300, Sector: "IT", UsdJpy: 140})
+    db.Create(&model.CalendarToken{UserId: 40, Token: "feed-token-40"})
+
+    req, _ := http.NewRequest("GET", "/api/v1/calendar/events.ics?token=feed-token-40", nil)
+    w := httptest.NewRecorder()
+    router.ServeHTTP(w, req)
+
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+    assert.Contains(t, w.Body.String(), "BEGIN:VCALENDAR\r\n")
+    assert.Contains(t, w.Body.String(), "DTSTART;VALUE=DATE:"+earningsDate.Format("20060102")+"\r\n")
+    assert.Contains(t, w.Body.String(), "SUMMARY:MSFT 決算発表\r\n")
+}
+
+func TestGetCalendarFeedInvalidTokenE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := setupCalendarRouter(db, nil)
+
+    req, _ := http.NewRequest("GET", "/api/v1/calendar/events.ics?token=unknown-token", nil)
+    w := httptest.NewRecorder()
+    router.ServeHTTP(w, req)
+
+    assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,111 @@
+package export_test
+
+import (
+	"my-us-stock-backend/app/common/auth"
+	"my-us-stock-backend/app/common/auth/logic"
+	"my-us-stock-backend/app/database/model"
+	repoCrypto "my-us-stock-backend/app/repository/assets/crypto"
+	repoFixedIncome "my-us-stock-backend/app/repository/assets/fixed-income"
+	repoJapanFund "my-us-stock-backend/app/repository/assets/fund"
+	repoStock "my-us-stock-backend/app/repository/assets/stock"
+	repoMarketPrice "my-us-stock-backend/app/repository/market-price"
+	repoMarketCrypto "my-us-stock-backend/app/repository/market-price/crypto"
+	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
+	repoFundPrice "my-us-stock-backend/app/repository/market-price/fund"
+	repoInstrument "my-us-stock-backend/app/repository/market-price/instrument"
+	repoTaxReport "my-us-stock-backend/app/repository/tax-report"
+	repoTotalAsset "my-us-stock-backend/app/repository/total-assets"
+	repoUser "my-us-stock-backend/app/repository/user"
+	"my-us-stock-backend/app/rest/export"
+	"my-us-stock-backend/test"
+	"my-us-stock-backend/test/graphql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// テスト用のエクスポートコントローラをセットアップ
+func setupExportRouter(db *gorm.DB) *gin.Engine {
+    authService := auth.NewAuthService(repoUser.NewUserRepository(db), logic.NewUserLogic(), logic.NewResponseLogic(), logic.NewJWTLogic(), auth.NewAuthValidation())
+    exportService := export.NewExportService(
+        authService,
+        repoStock.NewUsStockRepository(db),
+        repoJapanFund.NewJapanFundRepository(db),
+        repoCrypto.NewCryptoRepository(db),
+        repoFixedIncome.NewFixedIncomeRepository(db),
+        repoTotalAsset.NewTotalAssetRepository(db),
+        repoMarketPrice.NewMarketPriceRepository(nil),
+        repoCurrency.NewCurrencyRepository(nil),
+        repoMarketCrypto.NewCryptoRepository(nil),
+        repoFundPrice.NewFetchFundRepository(db),
+        repoInstrument.NewInstrumentRepository(db),
+        repoTaxReport.NewTaxReportRepository(db),
+    )
+    controller := export.NewExportController(exportService)
+
+    router := gin.Default()
+    router.GET("/api/v1/export/holdings", controller.GetHoldings)
+    router.GET("/api/v1/export/total-assets", controller.GetTotalAssets)
+    router.GET("/api/v1/export/tax-report", controller.GetTaxReport)
+    return router
+}
+
+func TestExportE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := setupExportRouter(db)
+
+    userId := uint(83)
+    db.Create(&model.FixedIncomeAsset{Code: "社債A", GetPriceTotal: 500000, UserId: userId})
+    db.Create(&model.DividendReceipt{Code: "KO", PaymentDate: "2024-04-01", Amount: 46, ForeignTax: 4.6, DomesticTax: 1263, UsdJpy: 150.25, UserId: userId})
+    db.Create(&model.TotalAsset{Model: gorm.Model{CreatedAt: time.Date(2024, 1, 10, 1, 0, 0, 0, time.UTC)}, CashJpy: 100000, FixedIncomeAsset: 500000, UserId: userId})
+    db.Create(&model.TotalAsset{Model: gorm.Model{CreatedAt: time.Date(2024, 1, 11, 1, 0, 0, 0, time.UTC)}, CashJpy: 120000, CashUsd: 100, FixedIncomeAsset: 500000, UsdJpy: 150, UserId: userId})
+
+    token, err := graphql.GenerateTestAccessTokenForUserId(userId)
+    if err != nil {
+        t.Fatalf("Failed to generate test access token: %v", err)
+    }
+    download := func(path string) *httptest.ResponseRecorder {
+        req, _ := http.NewRequest("GET", path, nil)
+        req.AddCookie(&http.Cookie{Name: "access_token", Value: token})
+        w := httptest.NewRecorder()
+        router.ServeHTTP(w, req)
+        return w
+    }
+
+    w := download("/api/v1/export/holdings")
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.Equal(t, "\xef\xbb\xbf資産の種類,コード,銘柄名,保有数量,取得単価,現在値,通貨,取得総額(円),評価額(円),評価損益(円)\n"+
+        "固定利回り資産,社債A,,,,,JPY,500000,500000,0\n", w.Body.String())
+
+    w = download("/api/v1/export/total-assets?lang=en")
+    assert.Equal(t, http.StatusOK, w.Code)
+    lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+    assert.Len(t, lines, 3)
+    assert.Equal(t, "2024-01-10,100000,0,0,0,0,500000,600000,", lines[1])
+    assert.Equal(t, "2024-01-11,120000,100,0,0,0,500000,635000,150", lines[2])
+
+    w = download("/api/v1/export/total-assets?format=xlsx")
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.Equal(t, "PK", w.Body.String()[:2])
+
+    w = download("/api/v1/export/tax-report?year=2024")
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.Equal(t, "\xef\xbb\xbf銘柄,国名,配当金(円),外国源泉徴収税額(円),国内源泉徴収税額(円),譲渡価額(円),取得費(円),売却手数料(円),譲渡損益(円)\n"+
+        "KO,アメリカ,6911,691,1263,0,0,0,0\n"+
+        "合計,,6911,691,1263,0,0,0,0\n", w.Body.String())
+
+    w = download("/api/v1/export/tax-report")
+    assert.Equal(t, http.StatusBadRequest, w.Code)
+
+    // 未ログインの場合は401
+    req, _ := http.NewRequest("GET", "/api/v1/export/holdings", nil)
+    w = httptest.NewRecorder()
+    router.ServeHTTP(w, req)
+    assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,100 @@
+package share_test
+
+import (
+	"my-us-stock-backend/app/database/model"
+	repoCrypto "my-us-stock-backend/app/repository/assets/crypto"
+	repoFixedIncome "my-us-stock-backend/app/repository/assets/fixed-income"
+	repoJapanFund "my-us-stock-backend/app/repository/assets/fund"
+	repoStock "my-us-stock-backend/app/repository/assets/stock"
+	repoMarketPrice "my-us-stock-backend/app/repository/market-price"
+	repoMarketCrypto "my-us-stock-backend/app/repository/market-price/crypto"
+	repoCurrency "my-us-stock-backend/app/repository/market-price/currency"
+	repoFundPrice "my-us-stock-backend/app/repository/market-price/fund"
+	repoShareToken "my-us-stock-backend/app/repository/share-token"
+	repoTotalAsset "my-us-stock-backend/app/repository/total-assets"
+	"my-us-stock-backend/app/rest/share"
+	"my-us-stock-backend/test"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// テスト用の共有リンクのコントローラをセットアップ
+func setupShareRouter(db *gorm.DB) *gin.Engine {
+    service := share.NewShareService(
+        repoShareToken.NewShareTokenRepository(db),
+        repoStock.NewUsStockRepository(db),
+        repoJapanFund.NewJapanFundRepository(db),
+        repoCrypto.NewCryptoRepository(db),
+        repoFixedIncome.NewFixedIncomeRepository(db),
+        repoTotalAsset.NewTotalAssetRepository(db),
+        repoMarketPrice.NewMarketPriceRepository(nil),
+        repoCurrency.NewCurrencyRepository(nil),
+        repoMarketCrypto.NewCryptoRepository(nil),
+        repoFundPrice.NewFetchFundRepository(db),
+    )
+    router := gin.Default()
+    router.GET("/api/v1/share/:token", share.NewShareController(service).GetSharedPortfolio)
+    return router
+}
+
+func TestGetSharedPortfolioE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := setupShareRouter(db)
+
+    userId := uint(94)
+    expiresAt := time.Now().AddDate(0, 0, 7)
+    db.Create(&model.User{Model: gorm.Model{ID: userId}, Name: "share", Email: "share@example.com", Password: "hashed"})
+    db.Create(&model.FundPrice{Name: "eMAXIS Slim 全世界株式", Code: "0331418A", Price: 12000})
+    db.Create(&model.JapanFund{Code: "0331418A", Name: "eMAXIS Slim 全世界株式", GetPrice: 10000, GetPriceTotal: 500000, UserId: userId})
+    db.Create(&model.FixedIncomeAsset{Code: "個人向け国債", GetPriceTotal: 400000, DividendRate: 0.5, UserId: userId})
+    db.Create(&model.ShareToken{TokenHash: repoShareToken.HashToken("percentage-token"), Privacy: repoShareToken.PrivacyPercentage, ExpiresAt: expiresAt, UserId: userId})
+    db.Create(&model.ShareToken{TokenHash: repoShareToken.HashToken("amount-token"), Privacy: repoShareToken.PrivacyAmount, ExpiresAt: expiresAt, UserId: userId})
+
+    // 構成比のみの公開では金額を含めない
+    req, _ := http.NewRequest("GET", "/api/v1/share/percentage-token", nil)
+    w := httptest.NewRecorder()
+    router.ServeHTTP(w, req)
+
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.NotContains(t, w.Body.String(), "amount")
+    assert.NotContains(t, w.Body.String(), "Amount")
+    assert.Contains(t, w.Body.String(), `"allocation":[{"assetType":"JAPAN_FUND","weight":60},{"assetType":"FIXED_INCOME","weight":40}]`)
+    assert.Contains(t, w.Body.String(), `{"assetType":"JAPAN_FUND","code":"0331418A","name":"eMAXIS Slim 全世界株式","weight":60,"gainRate":20}`)
+
+    // 金額も公開する場合
+    req, _ = http.NewRequest("GET", "/api/v1/share/amount-token", nil)
+    w = httptest.NewRecorder()
+    router.ServeHTTP(w, req)
+
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.Contains(t, w.Body.String(), `"totalAmount":1000000`)
+}
+
+// 期限切れ・取り消し済み・退会の猶予期間中のユーザーの共有リンクは参照できない
+func TestGetSharedPortfolioInvalidTokenE2E(t *testing.T) {
+    db := test.SetupTestDB()
+    router := setupShareRouter(db)
+
+    userId := uint(95)
+    db.Create(&model.ShareToken{TokenHash: repoShareToken.HashToken("expired-token"), Privacy: repoShareToken.PrivacyAmount, ExpiresAt: time.Now().Add(-time.Minute), UserId: userId})
+    revoked := model.ShareToken{TokenHash: repoShareToken.HashToken("revoked-token"), Privacy: repoShareToken.PrivacyAmount, ExpiresAt: time.Now().AddDate(0, 0, 7), UserId: userId}
+    db.Create(&revoked)
+    db.Delete(&revoked)
+    deletedUser := model.User{Model: gorm.Model{ID: 96}, Name: "deleted", Email: "share-deleted@example.com", Password: "hashed"}
+    db.Create(&deletedUser)
+    db.Delete(&deletedUser)
+    db.Create(&model.ShareToken{TokenHash: repoShareToken.HashToken("deleted-user-token"), Privacy: repoShareToken.PrivacyAmount, ExpiresAt: time.Now().AddDate(0, 0, 7), UserId: deletedUser.ID})
+
+    for _, token := range []string{"expired-token", "revoked-token", "unknown-token", "deleted-user-token"} {
+        req, _ := http.NewRequest("GET", "/api/v1/share/"+token, nil)
+        w := httptest.NewRecorder()
+        router.ServeHTTP(w, req)
+        assert.Equal(t, http.StatusNotFound, w.Code, token)
+    }
+}
@@ -21,5 +21,24 @@ func SetupTestDB() *gorm.DB {
 	db.AutoMigrate(&model.JapanFund{})
 	db.AutoMigrate(&model.TotalAsset{})
 	db.AutoMigrate(&model.FundPrice{})
+	db.AutoMigrate(&model.FundPriceHistory{})
+	db.AutoMigrate(&model.FundNavFailure{})
+	db.AutoMigrate(&model.Instrument{})
+	db.AutoMigrate(&model.PriceHistory{})
+	db.AutoMigrate(&model.CalendarToken{})
+	db.AutoMigrate(&model.Watchlist{})
+	db.AutoMigrate(&model.AlertRule{})
+	db.AutoMigrate(&model.TriggeredAlert{})
+	db.AutoMigrate(&model.NotificationChannel{})
+	db.AutoMigrate(&model.NotificationDelivery{})
+	db.AutoMigrate(&model.DailyReport{})
+	db.AutoMigrate(&model.DividendReceipt{})
+	db.AutoMigrate(&model.StockSale{})
+	db.AutoMigrate(&model.BrokerageAccount{})
+	db.AutoMigrate(&model.TotalAssetAccount{})
+	db.AutoMigrate(&model.NisaPurchase{})
+	db.AutoMigrate(&model.Household{})
+	db.AutoMigrate(&model.HouseholdMember{})
+	db.AutoMigrate(&model.ShareToken{})
 	return db
 }
\ No newline at end of file